@@ -11,6 +11,7 @@ import (
 
 	"github.com/duesk/monstera/internal/batch"
 	"github.com/duesk/monstera/internal/common/logger"
+	"github.com/duesk/monstera/internal/common/transaction"
 	"github.com/duesk/monstera/internal/config"
 	"github.com/duesk/monstera/internal/repository"
 	"github.com/duesk/monstera/internal/service"
@@ -77,6 +78,36 @@ func main() {
 
 	archiveService := service.NewArchiveService(database, log)
 
+	// 期限超過・督促サービス（メールサービスが使える場合のみ有効）
+	var invoiceDunningService service.InvoiceDunningServiceInterface
+	if emailService, err := service.NewEmailService(&cfg.Email, log); err != nil {
+		log.Error("Failed to initialize email service, invoice dunning is disabled", zap.Error(err))
+	} else {
+		invoiceDunningService = service.NewInvoiceDunningService(database, emailService, log)
+	}
+
+	// 月次請求サービス
+	baseRepo := repository.NewBaseRepository(database, log)
+	assignmentRepo := repository.NewProjectAssignmentRepository(baseRepo)
+	billingService := service.NewBillingService(
+		database,
+		log,
+		repository.NewClientRepository(baseRepo),
+		repository.NewProjectRepository(baseRepo),
+		assignmentRepo,
+		repository.NewInvoiceRepository(baseRepo),
+		repository.NewProjectGroupRepository(database, log),
+		transaction.NewTransactionManager(database, log),
+	)
+	invoiceNumberingService, err := service.NewInvoiceNumberingService(database, &cfg.InvoiceNumbering, cfg.Database.Driver, log)
+	if err != nil {
+		log.Fatal("Invalid invoice numbering configuration", zap.Error(err))
+	}
+	billingRunService := service.NewBillingRunService(database, billingService, assignmentRepo, invoiceNumberingService, &cfg.Company, log)
+
+	// 仮払金サービス（精算期限超過の督促）
+	cashAdvanceService := service.NewCashAdvanceService(database, repository.NewCashAdvanceRepository(database, log), notificationService, log)
+
 	// バッチスケジューラーの作成
 	scheduler := batch.NewScheduler(
 		database,
@@ -84,6 +115,9 @@ func main() {
 		alertService,
 		alertDetectionBatchService,
 		archiveService,
+		invoiceDunningService,
+		billingRunService,
+		cashAdvanceService,
 		log,
 	)
 
@@ -13,7 +13,9 @@ import (
 	"github.com/duesk/monstera/internal/cache"
 	"github.com/duesk/monstera/internal/common/logger"
 	"github.com/duesk/monstera/internal/common/repository"
+	"github.com/duesk/monstera/internal/common/transaction"
 	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/freee"
 	"github.com/duesk/monstera/internal/handler"
 	"github.com/duesk/monstera/internal/middleware"
 	internalRepo "github.com/duesk/monstera/internal/repository"
@@ -232,8 +234,52 @@ func main() {
 	adminDashboardService := service.NewAdminDashboardService(db, logger)
 	// ビジネス系サービスを追加
 	clientService := service.NewClientService(db, clientRepo, logger)
-    invoiceService := service.NewInvoiceService(db, invoiceRepo, clientRepo, projectRepo, userRepo, logger)
+	invoiceNumberingService, err := service.NewInvoiceNumberingService(db, &cfg.InvoiceNumbering, cfg.Database.Driver, logger)
+	if err != nil {
+		logger.Fatal("Invalid invoice numbering configuration", zap.Error(err))
+	}
+    invoicePDFService := service.NewInvoicePDFService(&cfg.InvoicePDF, &cfg.Company, logger)
+    invoiceService := service.NewInvoiceService(db, invoiceRepo, clientRepo, projectRepo, userRepo, invoicePDFService, invoiceNumberingService, &cfg.Company, logger)
     salesService := service.NewSalesService(db, salesActivityRepo, clientRepo, projectRepo, userRepo, logger)
+	// freee連携サービス（freeeと暗号化キーの設定がある場合のみ有効）
+	var freeeService service.FreeeServiceInterface
+	if cfg.Freee.IsValid() && cfg.Encryption.IsValid() {
+		tokenEncryption, err := utils.NewTokenEncryption(cfg.Encryption.Key)
+		if err != nil {
+			logger.Warn("Failed to initialize token encryption, freee integration is disabled", zap.Error(err))
+		} else {
+			freeeSettingsRepo := internalRepo.NewFreeeSettingsRepository(db, logger)
+			freeSyncLogRepo := internalRepo.NewFreeSyncLogRepository(db, logger)
+			freeeService = service.NewFreeeService(db, &cfg.Freee, freee.NewClient(&cfg.Freee, nil), tokenEncryption, freeeSettingsRepo, freeSyncLogRepo, invoiceRepo, cfg.Database.Driver, logger)
+		}
+	} else {
+		logger.Info("freee integration is not configured")
+	}
+	// 入金消込サービス
+	bankReconciliationService := service.NewBankReconciliationService(db, internalRepo.NewBankReconciliationRepository(db, logger), logger)
+	// 期限超過・督促サービス（メールサービスが使える場合のみ有効）
+	var invoiceDunningService service.InvoiceDunningServiceInterface
+	if emailService != nil {
+		invoiceDunningService = service.NewInvoiceDunningService(db, emailService, logger)
+	}
+	// 月次請求サービス
+	assignmentRepo := internalRepo.NewProjectAssignmentRepository(internalBaseRepo)
+	billingService := service.NewBillingService(db, logger, clientRepo, projectRepo, assignmentRepo, invoiceRepo, internalRepo.NewProjectGroupRepository(db, logger), transaction.NewTransactionManager(db, logger))
+	billingRunService := service.NewBillingRunService(db, billingService, assignmentRepo, invoiceNumberingService, &cfg.Company, logger)
+	assignmentRateService := service.NewProjectAssignmentRateService(db, logger)
+	businessPartnerService := service.NewBusinessPartnerService(db, logger, billingService)
+	accountingAnalyticsService := service.NewAccountingAnalyticsService(db, logger)
+	journalExportService := service.NewJournalExportService(db, logger)
+	// 経費精算（総合振込）サービス（振込先口座の暗号化キーの設定がある場合のみ有効）
+	var expenseReimbursementService service.ExpenseReimbursementServiceInterface
+	if cfg.Encryption.IsValid() {
+		accountEncryption, err := utils.NewTokenEncryption(cfg.Encryption.Key)
+		if err != nil {
+			logger.Warn("Failed to initialize bank account encryption, expense reimbursement is disabled", zap.Error(err))
+		} else {
+			expenseReimbursementService = service.NewExpenseReimbursementService(db, accountEncryption, &cfg.Company, logger)
+		}
+	}
 	// エンジニアサービスを追加（CognitoAuthServiceとConfigを渡す）
 	cognitoAuthSvc, ok := authSvc.(*service.CognitoAuthService)
 	if !ok {
@@ -345,6 +391,19 @@ func main() {
 		}
 	}
 
+	// 電子帳簿保存サービス（保存先の初期化に失敗した場合は機能を無効化）
+	var documentStoreService service.DocumentStoreServiceInterface
+	documentRegion := os.Getenv("AWS_REGION")
+	if documentRegion == "" {
+		documentRegion = "us-east-1"
+	}
+	documentBackend, err := service.NewDocumentBackend(&cfg.DocumentStore, documentRegion)
+	if err != nil {
+		logger.Warn("Document store is disabled", zap.Error(err), zap.String("backend", cfg.DocumentStore.Backend))
+	} else {
+		documentStoreService = service.NewDocumentStoreService(db, documentBackend, invoicePDFService, s3Service, &cfg.DocumentStore, cfg.InvoiceNumbering.FiscalYearStartMonth, logger)
+	}
+
 	// 経費申請サービスを追加（s3Service, notificationService, userRepo, cacheManager, auditLogServiceを含む）
 	// 経費領収書リポジトリを初期化
 	expenseReceiptRepo := internalRepo.NewExpenseReceiptRepository(db, logger)
@@ -352,6 +411,13 @@ func main() {
 	expenseService := service.NewExpenseService(db, expenseRepo, expenseCategoryRepo, expenseLimitRepo, expenseApprovalRepo, expenseReceiptRepo, expenseDeadlineSettingRepo, s3Service, notificationService, userRepo, cacheManager, auditLogService, logger)
 	// 経費承認者設定サービスを追加
 	expenseApproverSettingService := service.NewExpenseApproverSettingService(db, expenseApproverSettingRepo, userRepo, logger)
+	// 経費申請の承認ルールサービスを追加
+	expenseApprovalRuleService := service.NewExpenseApprovalRuleService(db, internalRepo.NewExpenseApprovalRuleRepository(db, logger), logger)
+	approvalDelegationService := service.NewApprovalDelegationService(db, internalRepo.NewApprovalDelegationRepository(db, logger), logger)
+	// 出張申請・出張精算サービスを追加
+	businessTripService := service.NewBusinessTripService(db, internalRepo.NewBusinessTripRepository(db, logger), logger)
+	// 仮払金サービスを追加
+	cashAdvanceService := service.NewCashAdvanceService(db, internalRepo.NewCashAdvanceRepository(db, logger), notificationService, logger)
 	// スケジューラーサービスを追加
 	schedulerService := service.NewSchedulerService(logger)
 
@@ -370,7 +436,31 @@ func main() {
 	// ビジネス系ハンドラーを追加
 	clientHandler := handler.NewClientHandler(clientService, logger)
 	invoiceHandler := handler.NewInvoiceHandler(invoiceService, logger)
+	invoiceNumberHandler := handler.NewInvoiceNumberHandler(invoiceNumberingService, logger)
+	var documentStoreHandler *handler.DocumentStoreHandler
+	if documentStoreService != nil {
+		documentStoreHandler = handler.NewDocumentStoreHandler(documentStoreService, logger)
+	}
 	salesHandler := handler.NewSalesHandler(salesService, logger)
+	var freeeHandler *handler.FreeeHandler
+	if freeeService != nil {
+		freeeHandler = handler.NewFreeeHandler(freeeService, logger)
+	}
+	bankReconciliationHandler := handler.NewBankReconciliationHandler(bankReconciliationService, logger)
+	var invoiceDunningHandler *handler.InvoiceDunningHandler
+	if invoiceDunningService != nil {
+		invoiceDunningHandler = handler.NewInvoiceDunningHandler(invoiceDunningService, logger)
+	}
+	billingHandler := handler.NewBillingHandler(billingService, logger)
+	billingRunHandler := handler.NewBillingRunHandler(billingRunService, logger)
+	assignmentRateHandler := handler.NewProjectAssignmentRateHandler(assignmentRateService, logger)
+	businessPartnerHandler := handler.NewBusinessPartnerHandler(businessPartnerService, logger)
+	accountingDashboardHandler := handler.NewAccountingDashboardHandler(accountingAnalyticsService, logger)
+	journalExportHandler := handler.NewJournalExportHandler(journalExportService, logger)
+	var expenseReimbursementHandler *handler.ExpenseReimbursementHandler
+	if expenseReimbursementService != nil {
+		expenseReimbursementHandler = handler.NewExpenseReimbursementHandler(expenseReimbursementService, logger)
+	}
 	// エンジニアハンドラーを追加
 	engineerHandler := handler.NewAdminEngineerHandler(engineerService, logger)
     // スキルシートPDFハンドラー（v0除外）
@@ -407,6 +497,10 @@ func main() {
     // 経費申請PDFハンドラー（v0除外）
 	// 経費承認者設定ハンドラーを追加
 	expenseApproverSettingHandler := handler.NewExpenseApproverSettingHandler(expenseApproverSettingService, logger)
+	expenseApprovalRuleHandler := handler.NewExpenseApprovalRuleHandler(expenseApprovalRuleService, logger)
+	approvalDelegationHandler := handler.NewApprovalDelegationHandler(approvalDelegationService, logger)
+	businessTripHandler := handler.NewBusinessTripHandler(businessTripService, logger)
+	cashAdvanceHandler := handler.NewCashAdvanceHandler(cashAdvanceService, logger)
 	// 経費期限設定ハンドラーを追加
 	// expenseDeadlineHandler := handler.NewExpenseDeadlineHandler(expenseService, logger) // setupRouter内で使用
 	// 承認催促ハンドラーを追加
@@ -429,7 +523,12 @@ func main() {
 		PocSyncHandler:           *pocSyncHandler,
 		SalesTeamHandler:         *salesTeamHandler,
 	}
-    router := setupRouter(cfg, logger, authHandler, profileHandler, skillSheetHandler, reportHandler, leaveHandler, notificationHandler, adminWeeklyReportHandler, adminDashboardHandler, clientHandler, invoiceHandler, salesHandler, userRoleHandler, leaveAdminHandler, *unsubmittedReportHandler, reminderHandler, alertSettingsHandler, *alertHandler, auditLogHandler, salesHandlers, expenseHandler, expenseApproverSettingHandler, approvalReminderHandler, workHistoryHandler, engineerHandler, rolePermissionRepo, userRepo, departmentRepo, reportRepo, weeklyReportRefactoredRepo, auditLogService, projectService)
+    router := setupRouter(cfg, logger, authHandler, profileHandler, skillSheetHandler, reportHandler, leaveHandler, notificationHandler, adminWeeklyReportHandler, adminDashboardHandler, clientHandler, invoiceHandler, salesHandler, userRoleHandler, leaveAdminHandler, *unsubmittedReportHandler, reminderHandler, alertSettingsHandler, *alertHandler, auditLogHandler, salesHandlers, expenseHandler, expenseApproverSettingHandler, approvalReminderHandler, workHistoryHandler, engineerHandler, freeeHandler, bankReconciliationHandler, invoiceDunningHandler, billingHandler, billingRunHandler, assignmentRateHandler, businessPartnerHandler, accountingDashboardHandler, journalExportHandler, expenseReimbursementHandler, invoiceNumberHandler, documentStoreHandler, expenseApprovalRuleHandler, approvalDelegationHandler, businessTripHandler, cashAdvanceHandler, rolePermissionRepo, userRepo, departmentRepo, reportRepo, weeklyReportRefactoredRepo, auditLogService, projectService)
+
+	// freee Webhook（署名シークレットが設定されている場合のみ受け付ける）
+	if freeeService != nil && cfg.Freee.WebhookSecret != "" {
+		routes.RegisterAccountingWebhooks(router.Group("/api/v1"), freeeService, cfg.Freee.WebhookSecret, logger)
+	}
 
 	// HTTPサーバーの設定
 	srv := &http.Server{
@@ -446,6 +545,9 @@ func main() {
 		alertService,
 		alertDetectionBatchService,
 		archiveService,
+		invoiceDunningService,
+		billingRunService,
+		cashAdvanceService,
 		logger,
 	)
 	scheduler.Start()
@@ -459,6 +561,11 @@ func main() {
 	// 1時間ごとに実行
 	go expenseDeadlineProcessor.Run(ctx, 1*time.Hour)
 
+	// freee同期キューのワーカー（freee連携が有効な場合のみ）
+	if freeeService != nil {
+		go batch.NewFreeeSyncWorker(freeeService, logger).Run(ctx, 30*time.Second)
+	}
+
 	// 期限切れセッションクリーンアップの停止チャネル
 	cleanupStop := make(chan struct{})
 
@@ -515,7 +622,7 @@ func main() {
 }
 
 // setupRouter ルーターのセットアップ
-func setupRouter(cfg *config.Config, logger *zap.Logger, authHandler *handler.AuthHandler, profileHandler *handler.ProfileHandler, skillSheetHandler *handler.SkillSheetHandler, reportHandler *handler.WeeklyReportHandler, leaveHandler handler.LeaveHandler, notificationHandler handler.NotificationHandler, adminWeeklyReportHandler handler.AdminWeeklyReportHandler, adminDashboardHandler handler.AdminDashboardHandler, clientHandler handler.ClientHandler, invoiceHandler handler.InvoiceHandler, salesHandler handler.SalesHandler, userRoleHandler *handler.UserRoleHandler, leaveAdminHandler handler.LeaveAdminHandler, unsubmittedReportHandler handler.UnsubmittedReportHandler, reminderHandler handler.ReminderHandler, alertSettingsHandler *handler.AlertSettingsHandler, alertHandler handler.AlertHandler, auditLogHandler *handler.AuditLogHandler, salesHandlers *routes.SalesHandlers, expenseHandler *handler.ExpenseHandler, expenseApproverSettingHandler *handler.ExpenseApproverSettingHandler, approvalReminderHandler *handler.ApprovalReminderHandler, workHistoryHandler *handler.WorkHistoryHandler, engineerHandler handler.AdminEngineerHandler, rolePermissionRepo internalRepo.RolePermissionRepository, userRepo internalRepo.UserRepository, departmentRepo internalRepo.DepartmentRepository, reportRepo *internalRepo.WeeklyReportRepository, weeklyReportRefactoredRepo internalRepo.WeeklyReportRefactoredRepository, auditLogService service.AuditLogService, projectService service.ProjectService) *gin.Engine {
+func setupRouter(cfg *config.Config, logger *zap.Logger, authHandler *handler.AuthHandler, profileHandler *handler.ProfileHandler, skillSheetHandler *handler.SkillSheetHandler, reportHandler *handler.WeeklyReportHandler, leaveHandler handler.LeaveHandler, notificationHandler handler.NotificationHandler, adminWeeklyReportHandler handler.AdminWeeklyReportHandler, adminDashboardHandler handler.AdminDashboardHandler, clientHandler handler.ClientHandler, invoiceHandler handler.InvoiceHandler, salesHandler handler.SalesHandler, userRoleHandler *handler.UserRoleHandler, leaveAdminHandler handler.LeaveAdminHandler, unsubmittedReportHandler handler.UnsubmittedReportHandler, reminderHandler handler.ReminderHandler, alertSettingsHandler *handler.AlertSettingsHandler, alertHandler handler.AlertHandler, auditLogHandler *handler.AuditLogHandler, salesHandlers *routes.SalesHandlers, expenseHandler *handler.ExpenseHandler, expenseApproverSettingHandler *handler.ExpenseApproverSettingHandler, approvalReminderHandler *handler.ApprovalReminderHandler, workHistoryHandler *handler.WorkHistoryHandler, engineerHandler handler.AdminEngineerHandler, freeeHandler *handler.FreeeHandler, bankReconciliationHandler *handler.BankReconciliationHandler, invoiceDunningHandler *handler.InvoiceDunningHandler, billingHandler *handler.BillingHandler, billingRunHandler *handler.BillingRunHandler, assignmentRateHandler *handler.ProjectAssignmentRateHandler, businessPartnerHandler *handler.BusinessPartnerHandler, accountingDashboardHandler *handler.AccountingDashboardHandler, journalExportHandler *handler.JournalExportHandler, expenseReimbursementHandler *handler.ExpenseReimbursementHandler, invoiceNumberHandler *handler.InvoiceNumberHandler, documentStoreHandler *handler.DocumentStoreHandler, expenseApprovalRuleHandler *handler.ExpenseApprovalRuleHandler, approvalDelegationHandler *handler.ApprovalDelegationHandler, businessTripHandler *handler.BusinessTripHandler, cashAdvanceHandler *handler.CashAdvanceHandler, rolePermissionRepo internalRepo.RolePermissionRepository, userRepo internalRepo.UserRepository, departmentRepo internalRepo.DepartmentRepository, reportRepo *internalRepo.WeeklyReportRepository, weeklyReportRefactoredRepo internalRepo.WeeklyReportRefactoredRepository, auditLogService service.AuditLogService, projectService service.ProjectService) *gin.Engine {
 	router := gin.New()
 
 	// DatabaseUtilsの初期化（メトリクスハンドラー用）
@@ -610,6 +717,15 @@ func setupRouter(cfg *config.Config, logger *zap.Logger, authHandler *handler.Au
 
 			// 経費
 			routes.SetupExpenseRoutes(api, authMiddlewareFunc, expenseHandler)
+			if expenseReimbursementHandler != nil {
+				routes.SetupExpenseReimbursementRoutes(api, authMiddlewareFunc, expenseReimbursementHandler)
+			}
+			if businessTripHandler != nil {
+				routes.SetupBusinessTripRoutes(api, authMiddlewareFunc, businessTripHandler)
+			}
+			if cashAdvanceHandler != nil {
+				routes.SetupCashAdvanceRoutes(api, authMiddlewareFunc, cashAdvanceHandler)
+			}
 
             // Engineer向けルート（案件CRUD / 軽量クライアント一覧）
             projectHandler := handler.NewProjectHandler(projectService, logger)
@@ -637,6 +753,22 @@ func setupRouter(cfg *config.Config, logger *zap.Logger, authHandler *handler.Au
 			ExpenseApproverSettingHandler: expenseApproverSettingHandler,
 			ApprovalReminderHandler:       approvalReminderHandler,
 			EngineerHandler:               engineerHandler,
+			FreeeHandler:                  freeeHandler,
+			BankReconciliationHandler:     bankReconciliationHandler,
+			InvoiceDunningHandler:         invoiceDunningHandler,
+			BillingHandler:                billingHandler,
+			BillingRunHandler:             billingRunHandler,
+			AssignmentRateHandler:         assignmentRateHandler,
+			BusinessPartnerHandler:        businessPartnerHandler,
+			AccountingDashboardHandler:    accountingDashboardHandler,
+			JournalExportHandler:          journalExportHandler,
+			ExpenseReimbursementHandler:   expenseReimbursementHandler,
+			InvoiceNumberHandler:          invoiceNumberHandler,
+			DocumentStoreHandler:          documentStoreHandler,
+			ExpenseApprovalRuleHandler:    expenseApprovalRuleHandler,
+			ApprovalDelegationHandler:     approvalDelegationHandler,
+			BusinessTripHandler:           businessTripHandler,
+			CashAdvanceHandler:            cashAdvanceHandler,
 		}
 		routes.SetupAdminRoutes(api, cfg, adminHandlers, logger, rolePermissionRepo, cognitoMiddleware, userRepo)
 
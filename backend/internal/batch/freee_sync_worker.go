@@ -0,0 +1,63 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/service"
+)
+
+// freeeSyncWorkerBatchSize 1回の実行で処理するジョブの上限
+const freeeSyncWorkerBatchSize = 50
+
+// FreeeSyncWorker freee同期キューのワーカー
+// サーバーを複数台で動かしても、同じ事業所のジョブはキュー側で1件ずつに直列化される
+type FreeeSyncWorker struct {
+	freeeService service.FreeeServiceInterface
+	workerID     string
+	logger       *zap.Logger
+}
+
+// NewFreeeSyncWorker freee同期キューのワーカーを生成
+func NewFreeeSyncWorker(freeeService service.FreeeServiceInterface, logger *zap.Logger) *FreeeSyncWorker {
+	hostname, _ := os.Hostname()
+	return &FreeeSyncWorker{
+		freeeService: freeeService,
+		workerID:     fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.New().String()[:8]),
+		logger:       logger,
+	}
+}
+
+// Run ワーカーを実行（定期実行用）
+func (w *FreeeSyncWorker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	w.logger.Info("Starting freee sync worker", zap.String("worker_id", w.workerID))
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping freee sync worker", zap.String("worker_id", w.workerID))
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// process 実行時期が来たジョブを処理
+func (w *FreeeSyncWorker) process(ctx context.Context) {
+	processed, err := w.freeeService.ProcessSyncQueue(ctx, w.workerID, freeeSyncWorkerBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to process freee sync queue", zap.String("worker_id", w.workerID), zap.Error(err))
+		return
+	}
+	if processed > 0 {
+		w.logger.Info("Processed freee sync jobs", zap.String("worker_id", w.workerID), zap.Int("count", processed))
+	}
+}
@@ -27,6 +27,9 @@ type Scheduler struct {
 	reminderBatchService         service.ReminderBatchService
 	archiveService               service.ArchiveService
 	expenseMonthlyCloseProcessor *ExpenseMonthlyCloseProcessor
+	invoiceDunningService        service.InvoiceDunningServiceInterface
+	billingRunService            service.BillingRunServiceInterface
+	cashAdvanceService           service.CashAdvanceService
 	ctx                          context.Context
 	cancel                       context.CancelFunc
 }
@@ -47,6 +50,9 @@ func NewScheduler(
 	alertService service.AlertService,
 	alertDetectionBatchService service.AlertDetectionBatchService,
 	archiveService service.ArchiveService,
+	invoiceDunningService service.InvoiceDunningServiceInterface,
+	billingRunService service.BillingRunServiceInterface,
+	cashAdvanceService service.CashAdvanceService,
 	logger *zap.Logger,
 ) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -106,6 +112,9 @@ func NewScheduler(
 		reminderBatchService:         reminderBatchService,
 		archiveService:               archiveService,
 		expenseMonthlyCloseProcessor: expenseMonthlyCloseProcessor,
+		invoiceDunningService:        invoiceDunningService,
+		billingRunService:            billingRunService,
+		cashAdvanceService:           cashAdvanceService,
 		ctx:                          ctx,
 		cancel:                       cancel,
 	}
@@ -200,6 +209,39 @@ func (s *Scheduler) registerJobs() error {
 		return err
 	}
 
+	// 7. 請求書督促バッチ - 毎日9時実行（期限超過への更新と督促）
+	if s.invoiceDunningService != nil {
+		_, err = s.cron.AddFunc("0 9 * * *", func() {
+			s.runInvoiceDunningBatch()
+		})
+		if err != nil {
+			s.logger.Error("Failed to register invoice dunning batch", zap.Error(err))
+			return err
+		}
+	}
+
+	// 8. 月次請求バッチ - 毎月1日の午前6時実行（有効なbillingジョブで前月分の請求書の下書きを作成）
+	if s.billingRunService != nil {
+		_, err = s.cron.AddFunc("0 6 1 * *", func() {
+			s.runBillingRunBatch()
+		})
+		if err != nil {
+			s.logger.Error("Failed to register billing run batch", zap.Error(err))
+			return err
+		}
+	}
+
+	// 9. 仮払金の精算督促バッチ - 毎日9時30分実行（精算期限を過ぎた仮払金の申請者に通知）
+	if s.cashAdvanceService != nil {
+		_, err = s.cron.AddFunc("30 9 * * *", func() {
+			s.runCashAdvanceReminderBatch()
+		})
+		if err != nil {
+			s.logger.Error("Failed to register cash advance reminder batch", zap.Error(err))
+			return err
+		}
+	}
+
 	s.logger.Info("All batch jobs registered successfully")
 	return nil
 }
@@ -393,6 +435,37 @@ func (s *Scheduler) runExpenseMonthlyCloseBatch() {
 		zap.Duration("duration", time.Since(start)))
 }
 
+// runInvoiceDunningBatch 請求書督促バッチを実行
+func (s *Scheduler) runInvoiceDunningBatch() {
+	jobID := "invoice_dunning_" + time.Now().Format("20060102_150405")
+	s.logger.Info("Starting invoice dunning batch", zap.String("job_id", jobID))
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(s.ctx, 30*time.Minute)
+	defer cancel()
+
+	result, err := s.invoiceDunningService.Run(ctx, nil)
+	duration := time.Since(start)
+
+	if err != nil {
+		s.logger.Error("Invoice dunning batch failed",
+			zap.String("job_id", jobID),
+			zap.Duration("duration", duration),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Invoice dunning batch completed successfully",
+		zap.String("job_id", jobID),
+		zap.Duration("duration", duration),
+		zap.Int64("marked_overdue", result.MarkedOverdue),
+		zap.Bool("dunning_enabled", result.DunningEnabled),
+		zap.Int("sent_count", result.SentCount),
+		zap.Int("failed_count", result.FailedCount),
+	)
+}
+
 // runArchiveCleanupBatch アーカイブクリーンアップバッチを実行
 func (s *Scheduler) runArchiveCleanupBatch(ctx context.Context, parentJobID string, executedBy string) {
 	cleanupJobID := parentJobID + "_cleanup"
@@ -522,3 +595,62 @@ type HealthStatus struct {
 	LastUpdate time.Time   `json:"last_update"`
 	Jobs       []JobStatus `json:"jobs"`
 }
+
+// runBillingRunBatch 月次請求バッチを実行
+func (s *Scheduler) runBillingRunBatch() {
+	jobID := "billing_run_" + time.Now().Format("20060102_150405")
+	s.logger.Info("Starting billing run batch", zap.String("job_id", jobID))
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(s.ctx, 60*time.Minute)
+	defer cancel()
+
+	executed, err := s.billingRunService.RunScheduledJobs(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		s.logger.Error("Billing run batch failed",
+			zap.String("job_id", jobID),
+			zap.Duration("duration", duration),
+			zap.Int("jobs", executed),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Billing run batch completed successfully",
+		zap.String("job_id", jobID),
+		zap.Duration("duration", duration),
+		zap.Int("jobs", executed),
+	)
+}
+
+// runCashAdvanceReminderBatch 仮払金の精算督促バッチを実行
+func (s *Scheduler) runCashAdvanceReminderBatch() {
+	jobID := "cash_advance_reminder_" + time.Now().Format("20060102_150405")
+	s.logger.Info("Starting cash advance reminder batch", zap.String("job_id", jobID))
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Minute)
+	defer cancel()
+
+	result, err := s.cashAdvanceService.SendOverdueReminders(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		s.logger.Error("Cash advance reminder batch failed",
+			zap.String("job_id", jobID),
+			zap.Duration("duration", duration),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Cash advance reminder batch completed successfully",
+		zap.String("job_id", jobID),
+		zap.Duration("duration", duration),
+		zap.Int("overdue", result.OverdueCount),
+		zap.Int("reminded", result.ReminderCount),
+		zap.Int("failed", result.FailedCount),
+	)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -21,6 +22,11 @@ type Config struct {
 	Encryption EncryptionConfig
 	Prometheus PrometheusConfig
 	Cognito    CognitoConfig
+	Company    CompanyConfig
+	InvoicePDF InvoicePDFConfig
+
+	InvoiceNumbering InvoiceNumberingConfig
+	DocumentStore    DocumentStoreConfig
 }
 
 // ServerConfig サーバー関連の設定
@@ -110,6 +116,55 @@ type FreeeConfig struct {
 	TimeoutSeconds    int
 	MaxRetries        int
 	RetryDelaySeconds int
+	WebhookSecret     string // Webhook署名検証用のシークレット
+}
+
+// CompanyConfig 自社（請求元）情報
+type CompanyConfig struct {
+	Name                      string
+	PostalCode                string
+	Address                   string
+	Phone                     string
+	Email                     string
+	SealImagePath             string // 社印画像（PNG/JPEG）
+	InvoiceRegistrationNumber string // 適格請求書発行事業者登録番号（T+13桁）
+	BankName                  string
+	BankBranchName            string
+	BankAccountType           string // 普通, 当座
+	BankAccountNumber         string
+	BankAccountHolder         string
+
+	// 経費精算の総合振込（振込元口座、名称は半角カナに変換して出力する）
+	TransferRequesterCode string // 振込依頼人コード（銀行との契約で付与される）
+	TransferRequesterName string // 振込依頼人名（カナ）
+	TransferBankCode      string // 金融機関コード（4桁）
+	TransferBankName      string // 金融機関名（カナ）
+	TransferBranchCode    string // 支店コード（3桁）
+	TransferBranchName    string // 支店名（カナ）
+	TransferAccountType   string // 預金種目（1:普通 2:当座）
+	TransferAccountNumber string
+}
+
+// InvoicePDFConfig 請求書PDF出力設定
+type InvoicePDFConfig struct {
+	FontPath     string // 埋め込み用TTFフォント
+	BoldFontPath string // 太字用TTFフォント（未指定時はFontPathを使用）
+	TemplateDir  string // クライアント別レイアウトテンプレート（*.json）の格納先
+}
+
+// InvoiceNumberingConfig 請求書番号の採番設定
+type InvoiceNumberingConfig struct {
+	Pattern              string // 採番パターン（例: INV-{FY}-{seq:05}）
+	FiscalYearStartMonth int    // 会計年度の開始月
+}
+
+// DocumentStoreConfig 電子帳簿保存法に対応した文書保存の設定
+type DocumentStoreConfig struct {
+	Backend        string // 保存先（local / s3）
+	LocalDir       string // localの保存先ディレクトリ
+	S3Bucket       string // s3の保存先バケット（オブジェクトロックの設定を推奨）
+	S3Prefix       string
+	RetentionYears int // 法定保存期間（年）
 }
 
 // EncryptionConfig トークン暗号化設定
@@ -265,6 +320,22 @@ func (c *FreeeConfig) GetTokenURL() string {
 	return fmt.Sprintf("%s/oauth/token", c.OAuthBaseURL)
 }
 
+// GetAuthorizeURL 認可画面のURLを生成（パラメータをURLエンコードする）
+func (c *FreeeConfig) GetAuthorizeURL(redirectURI, state string) string {
+	params := url.Values{}
+	params.Set("client_id", c.ClientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("response_type", "code")
+	params.Set("state", state)
+	params.Set("scope", c.Scope)
+	return fmt.Sprintf("%s/oauth/authorize?%s", c.OAuthBaseURL, params.Encode())
+}
+
+// GetRevokeURL トークン失効用のURLを生成
+func (c *FreeeConfig) GetRevokeURL() string {
+	return fmt.Sprintf("%s/oauth/revoke", c.OAuthBaseURL)
+}
+
 // GetAPIURL API呼び出し用のURLを生成
 func (c *FreeeConfig) GetAPIURL(endpoint string) string {
 	return fmt.Sprintf("%s/api/%d%s", c.APIBaseURL, c.APIVersion, endpoint)
@@ -319,6 +390,10 @@ func Load(envFile ...string) (*Config, error) {
 	freeeMaxRetries, _ := strconv.Atoi(getEnv("FREEE_MAX_RETRIES", "3"))
 	freeeRetryDelaySeconds, _ := strconv.Atoi(getEnv("FREEE_RETRY_DELAY_SECONDS", "5"))
 
+	// 請求書番号の採番設定の読み込み
+	fiscalYearStartMonth, _ := strconv.Atoi(getEnv("INVOICE_FISCAL_YEAR_START_MONTH", "4"))
+	documentRetentionYears, _ := strconv.Atoi(getEnv("DOCUMENT_RETENTION_YEARS", "7"))
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:           getEnv("SERVER_PORT", "8080"),
@@ -395,6 +470,7 @@ func Load(envFile ...string) (*Config, error) {
 			TimeoutSeconds:    freeeTimeoutSeconds,
 			MaxRetries:        freeeMaxRetries,
 			RetryDelaySeconds: freeeRetryDelaySeconds,
+			WebhookSecret:     getEnv("FREEE_WEBHOOK_SECRET", ""),
 		},
 		Encryption: EncryptionConfig{
 			Key:       getEnv("TOKEN_ENCRYPTION_KEY", ""),
@@ -416,6 +492,45 @@ func Load(envFile ...string) (*Config, error) {
 			Endpoint:     getEnv("COGNITO_ENDPOINT", ""),
 			Environment:  getEnv("GO_ENV", "development"),
 		},
+		Company: CompanyConfig{
+			Name:                      getEnv("COMPANY_NAME", ""),
+			PostalCode:                getEnv("COMPANY_POSTAL_CODE", ""),
+			Address:                   getEnv("COMPANY_ADDRESS", ""),
+			Phone:                     getEnv("COMPANY_PHONE", ""),
+			Email:                     getEnv("COMPANY_EMAIL", ""),
+			SealImagePath:             getEnv("COMPANY_SEAL_IMAGE_PATH", ""),
+			InvoiceRegistrationNumber: getEnv("COMPANY_INVOICE_REGISTRATION_NUMBER", ""),
+			BankName:                  getEnv("COMPANY_BANK_NAME", ""),
+			BankBranchName:            getEnv("COMPANY_BANK_BRANCH_NAME", ""),
+			BankAccountType:           getEnv("COMPANY_BANK_ACCOUNT_TYPE", "普通"),
+			BankAccountNumber:         getEnv("COMPANY_BANK_ACCOUNT_NUMBER", ""),
+			BankAccountHolder:         getEnv("COMPANY_BANK_ACCOUNT_HOLDER", ""),
+
+			TransferRequesterCode: getEnv("COMPANY_TRANSFER_REQUESTER_CODE", ""),
+			TransferRequesterName: getEnv("COMPANY_TRANSFER_REQUESTER_NAME", ""),
+			TransferBankCode:      getEnv("COMPANY_TRANSFER_BANK_CODE", ""),
+			TransferBankName:      getEnv("COMPANY_TRANSFER_BANK_NAME", ""),
+			TransferBranchCode:    getEnv("COMPANY_TRANSFER_BRANCH_CODE", ""),
+			TransferBranchName:    getEnv("COMPANY_TRANSFER_BRANCH_NAME", ""),
+			TransferAccountType:   getEnv("COMPANY_TRANSFER_ACCOUNT_TYPE", "1"),
+			TransferAccountNumber: getEnv("COMPANY_TRANSFER_ACCOUNT_NUMBER", ""),
+		},
+		InvoicePDF: InvoicePDFConfig{
+			FontPath:     getEnv("INVOICE_PDF_FONT_PATH", "/usr/share/fonts/opentype/ipaexfont-gothic/ipaexg.ttf"),
+			BoldFontPath: getEnv("INVOICE_PDF_BOLD_FONT_PATH", ""),
+			TemplateDir:  getEnv("INVOICE_PDF_TEMPLATE_DIR", "templates/invoice"),
+		},
+		InvoiceNumbering: InvoiceNumberingConfig{
+			Pattern:              getEnv("INVOICE_NUMBER_PATTERN", "INV-{FY}-{seq:05}"),
+			FiscalYearStartMonth: fiscalYearStartMonth,
+		},
+		DocumentStore: DocumentStoreConfig{
+			Backend:        getEnv("DOCUMENT_STORE_BACKEND", "local"),
+			LocalDir:       getEnv("DOCUMENT_STORE_LOCAL_DIR", "storage/documents"),
+			S3Bucket:       getEnv("DOCUMENT_STORE_S3_BUCKET", ""),
+			S3Prefix:       getEnv("DOCUMENT_STORE_S3_PREFIX", "documents/"),
+			RetentionYears: documentRetentionYears,
+		},
 	}
 
 	// ドライバー固有の設定を調整
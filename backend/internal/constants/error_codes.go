@@ -36,9 +36,10 @@ const (
 	ErrRejectionReasonRequired = "E002V002" // 却下理由は必須です
 
 	// ビジネスロジックエラー
-	ErrAlreadyApproved     = "E002B001" // この申請は既に承認済みです
-	ErrAlreadyRejected     = "E002B002" // この申請は既に却下済みです
-	ErrNoApprovalAuthority = "E002B003" // 承認権限がありません
+	ErrAlreadyApproved           = "E002B001" // この申請は既に承認済みです
+	ErrAlreadyRejected           = "E002B002" // この申請は既に却下済みです
+	ErrNoApprovalAuthority       = "E002B003" // 承認権限がありません
+	ErrDuplicateOverrideRequired = "E002B004" // 重複の可能性がある経費申請の承認には理由が必要です
 
 	// 権限エラー
 	ErrApprovalPermissionDenied = "E002P001" // 他のユーザーの申請を承認する権限がありません
@@ -127,14 +128,15 @@ var ErrorMessages = map[string]string{
 	ErrExpenseInvalidStatus:         "この経費申請は現在のステータスでは実行できません",
 
 	// 承認フロー管理
-	ErrApprovalCommentTooLong:   "承認コメントは500文字以内で入力してください",
-	ErrRejectionReasonRequired:  "却下理由は必須です",
-	ErrAlreadyApproved:          "この申請は既に承認済みです",
-	ErrAlreadyRejected:          "この申請は既に却下済みです",
-	ErrNoApprovalAuthority:      "承認権限がありません",
-	ErrApprovalPermissionDenied: "他のユーザーの申請を承認する権限がありません",
-	ErrApprovalTargetNotFound:   "承認対象の申請が見つかりません",
-	ErrApprovalProcessFailed:    "承認処理に失敗しました",
+	ErrApprovalCommentTooLong:    "承認コメントは500文字以内で入力してください",
+	ErrRejectionReasonRequired:   "却下理由は必須です",
+	ErrAlreadyApproved:           "この申請は既に承認済みです",
+	ErrAlreadyRejected:           "この申請は既に却下済みです",
+	ErrNoApprovalAuthority:       "承認権限がありません",
+	ErrDuplicateOverrideRequired: "重複の可能性がある経費申請の承認には理由が必要です",
+	ErrApprovalPermissionDenied:  "他のユーザーの申請を承認する権限がありません",
+	ErrApprovalTargetNotFound:    "承認対象の申請が見つかりません",
+	ErrApprovalProcessFailed:     "承認処理に失敗しました",
 
 	// 上限管理
 	ErrLimitAmountInvalid:          "上限金額は1円以上1億円以下で入力してください",
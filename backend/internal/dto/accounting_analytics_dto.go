@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// AgingBucketDTO 年齢区分ごとの売掛金残高
+type AgingBucketDTO struct {
+	Bucket string       `json:"bucket"`
+	Label  string       `json:"label"`
+	Amount money.Amount `json:"amount"`
+}
+
+// ReceivablesAgingResponse 売掛金年齢表
+type ReceivablesAgingResponse struct {
+	AsOf             string              `json:"as_of"` // YYYY-MM-DD
+	TotalOutstanding money.Amount        `json:"total_outstanding"`
+	OverdueAmount    money.Amount        `json:"overdue_amount"`
+	InvoiceCount     int                 `json:"invoice_count"`
+	Buckets          []AgingBucketDTO    `json:"buckets"`
+	Clients          []model.ClientAging `json:"clients"`
+}
+
+// DSODTO 売上債権回転日数
+type DSODTO struct {
+	AsOf        string       `json:"as_of"`       // YYYY-MM-DD
+	PeriodDays  int          `json:"period_days"` // 請求額を集計した日数
+	Receivables money.Amount `json:"receivables"` // 基準日時点の売掛金残高
+	Billed      money.Amount `json:"billed"`      // 期間の請求額（税込）
+	DSO         float64      `json:"dso"`
+}
+
+// MonthlyCollectionDTO 月別の請求額と回収額
+type MonthlyCollectionDTO struct {
+	Month          string       `json:"month"`
+	Billed         money.Amount `json:"billed"`    // 請求月で集計した請求額（税込）
+	Collected      money.Amount `json:"collected"` // 入金日で集計した回収額
+	InvoiceCount   int          `json:"invoice_count"`
+	CollectionRate float64      `json:"collection_rate"` // 回収額 ÷ 請求額（%、小数点第1位まで）
+}
+
+// MonthlyCollectionTrendResponse 月別の請求額と回収額の推移
+type MonthlyCollectionTrendResponse struct {
+	StartMonth     string                 `json:"start_month"`
+	EndMonth       string                 `json:"end_month"`
+	Months         []MonthlyCollectionDTO `json:"months"`
+	TotalBilled    money.Amount           `json:"total_billed"`
+	TotalCollected money.Amount           `json:"total_collected"`
+}
+
+// RevenueRankingDTO 売上ランキングの1行
+type RevenueRankingDTO struct {
+	Rank         int          `json:"rank"`
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	ClientName   string       `json:"client_name,omitempty"` // 案件別の場合の取引先名
+	Revenue      money.Amount `json:"revenue"`               // 税抜
+	InvoiceCount int          `json:"invoice_count"`
+	Share        float64      `json:"share"` // 期間の売上に占める割合（%、小数点第1位まで）
+}
+
+// RevenueRankingResponse 売上ランキング
+type RevenueRankingResponse struct {
+	StartMonth string              `json:"start_month"`
+	EndMonth   string              `json:"end_month"`
+	Total      money.Amount        `json:"total"`
+	Ranking    []RevenueRankingDTO `json:"ranking"`
+}
+
+// UpcomingBillingDTO 今後の請求予定（請求書未作成の取引先）
+type UpcomingBillingDTO struct {
+	ClientID        string       `json:"client_id"`
+	ClientName      string       `json:"client_name"`
+	BillingMonth    string       `json:"billing_month"`
+	ClosingDate     string       `json:"closing_date"` // 締め日（YYYY-MM-DD）
+	AssignmentCount int          `json:"assignment_count"`
+	EstimatedAmount money.Amount `json:"estimated_amount"` // 月額単価の合計（精算・日割り前、税抜）
+}
+
+// PaymentStatusItemDTO 請求書ごとの入金状況
+type PaymentStatusItemDTO struct {
+	InvoiceID     string       `json:"invoice_id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	ClientID      string       `json:"client_id"`
+	ClientName    string       `json:"client_name"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	Collected     money.Amount `json:"collected"`
+	Outstanding   money.Amount `json:"outstanding"`
+	DueDate       string       `json:"due_date"`
+	DaysOverdue   int          `json:"days_overdue"`
+	Status        string       `json:"status"` // paid, unpaid, overdue
+	PaidDate      *time.Time   `json:"paid_date"`
+}
+
+// PaymentStatusResponse 請求月の入金状況
+type PaymentStatusResponse struct {
+	Month            string                 `json:"month"`
+	TotalAmount      money.Amount           `json:"total_amount"`
+	TotalCollected   money.Amount           `json:"total_collected"`
+	TotalOutstanding money.Amount           `json:"total_outstanding"`
+	Items            []PaymentStatusItemDTO `json:"items"`
+}
+
+// AccountingKPIDTO 経理ダッシュボードのKPI
+type AccountingKPIDTO struct {
+	BilledAmount         money.Amount `json:"billed_amount"` // 対象月の請求額（税込）
+	CollectedAmount      money.Amount `json:"collected_amount"`
+	OutstandingAmount    money.Amount `json:"outstanding_amount"` // 基準日時点の売掛金残高
+	OverdueAmount        money.Amount `json:"overdue_amount"`
+	DSO                  float64      `json:"dso"`
+	CollectionRate       float64      `json:"collection_rate"`         // 対象月の回収率（%）
+	MonthOverMonthGrowth float64      `json:"month_over_month_growth"` // 請求額の前月比（%）
+	AverageInvoiceAmount money.Amount `json:"average_invoice_amount"`
+	InvoiceCount         int          `json:"invoice_count"`
+	ActiveClientCount    int          `json:"active_client_count"`
+	OverdueInvoiceCount  int          `json:"overdue_invoice_count"`
+}
+
+// AccountingDashboardResponse 経理ダッシュボード
+type AccountingDashboardResponse struct {
+	Month            string                   `json:"month"`
+	KPIs             AccountingKPIDTO         `json:"kpis"`
+	Aging            ReceivablesAgingResponse `json:"aging"`
+	MonthlyTrend     []MonthlyCollectionDTO   `json:"monthly_trend"` // 対象月までの直近6ヶ月
+	ClientRanking    []RevenueRankingDTO      `json:"client_ranking"`
+	UpcomingBillings []UpcomingBillingDTO     `json:"upcoming_billings"`
+	LastUpdated      time.Time                `json:"last_updated"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ApprovalDelegationRequest 代理承認の登録リクエスト
+// delegator_idを省略した場合はログインユーザーの代理承認として登録する
+type ApprovalDelegationRequest struct {
+	DelegatorID *string `json:"delegator_id" binding:"omitempty,max=255"`
+	DelegateID  string  `json:"delegate_id" binding:"required,max=255"`
+	Scope       string  `json:"scope" binding:"omitempty,oneof=all expense leave weekly_report"` // 省略時はall
+	StartDate   string  `json:"start_date" binding:"required"`                                   // YYYY-MM-DD
+	EndDate     string  `json:"end_date" binding:"required"`                                     // YYYY-MM-DD（この日を含む）
+	Reason      string  `json:"reason" binding:"omitempty,max=1000"`
+}
+
+// ApprovalDelegationResponse 代理承認レスポンス
+type ApprovalDelegationResponse struct {
+	ID             string       `json:"id"`
+	Delegator      *UserSummary `json:"delegator,omitempty"`
+	DelegatorID    string       `json:"delegator_id"`
+	Delegate       *UserSummary `json:"delegate,omitempty"`
+	DelegateID     string       `json:"delegate_id"`
+	Scope          string       `json:"scope"`
+	StartDate      string       `json:"start_date"`
+	EndDate        string       `json:"end_date"`
+	Source         string       `json:"source"`
+	LeaveRequestID *string      `json:"leave_request_id,omitempty"`
+	Reason         string       `json:"reason"`
+	IsActive       bool         `json:"is_active"` // 本日時点で有効か
+	RevokedAt      *time.Time   `json:"revoked_at,omitempty"`
+	CreatedBy      string       `json:"created_by"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// FromModel ApprovalDelegationモデルからレスポンスに変換
+func (r *ApprovalDelegationResponse) FromModel(delegation *model.ApprovalDelegation) {
+	r.ID = delegation.ID
+	r.DelegatorID = delegation.DelegatorID
+	r.DelegateID = delegation.DelegateID
+	r.Scope = string(delegation.Scope)
+	r.StartDate = delegation.StartDate.Format("2006-01-02")
+	r.EndDate = delegation.EndDate.Format("2006-01-02")
+	r.Source = string(delegation.Source)
+	r.LeaveRequestID = delegation.LeaveRequestID
+	r.Reason = delegation.Reason
+	r.IsActive = delegation.IsActiveOn(time.Now())
+	r.RevokedAt = delegation.RevokedAt
+	r.CreatedBy = delegation.CreatedBy
+	r.CreatedAt = delegation.CreatedAt
+
+	if delegation.Delegator != nil && delegation.Delegator.ID != "" {
+		r.Delegator = &UserSummary{ID: delegation.Delegator.ID, Email: delegation.Delegator.Email, Name: delegation.Delegator.Name}
+	}
+	if delegation.Delegate != nil && delegation.Delegate.ID != "" {
+		r.Delegate = &UserSummary{ID: delegation.Delegate.ID, Email: delegation.Delegate.Email, Name: delegation.Delegate.Name}
+	}
+}
+
+// ApprovalDelegationsResponse 代理承認一覧レスポンス
+type ApprovalDelegationsResponse struct {
+	Delegations []ApprovalDelegationResponse `json:"delegations"`
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/pkg/bankstatement"
+)
+
+// ImportBankStatementRequest 入出金明細取込リクエスト（multipart/form-data）
+type ImportBankStatementRequest struct {
+	Format      string                    `form:"format" binding:"required,oneof=zengin csv"`
+	AutoConfirm bool                      `form:"auto_confirm"` // 一意に照合できた入金をそのまま確定する
+	MaxBankFee  *int64                    `form:"max_bank_fee" binding:"omitempty,min=0"`
+	Mapping     *bankstatement.CSVMapping `form:"-"` // CSVの列対応（mappingフィールドにJSONで指定）
+}
+
+// BankStatementImportDTO 入出金明細取込結果DTO
+type BankStatementImportDTO struct {
+	ID             string     `json:"id"`
+	FileName       string     `json:"file_name"`
+	Format         string     `json:"format"`
+	BankName       string     `json:"bank_name"`
+	BranchName     string     `json:"branch_name"`
+	AccountNumber  string     `json:"account_number"`
+	PeriodFrom     *time.Time `json:"period_from"`
+	PeriodTo       *time.Time `json:"period_to"`
+	TotalCount     int        `json:"total_count"`
+	DepositCount   int        `json:"deposit_count"`
+	DuplicateCount int        `json:"duplicate_count"`
+	MatchedCount   int        `json:"matched_count"`
+	ReviewCount    int        `json:"review_count"`
+	UnmatchedCount int        `json:"unmatched_count"`
+	ImportedBy     string     `json:"imported_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// BankTransactionSearchRequest 入出金明細検索リクエスト
+type BankTransactionSearchRequest struct {
+	ImportID string     `form:"import_id"`
+	Status   string     `form:"status"`
+	DateFrom *time.Time `form:"date_from" time_format:"2006-01-02"`
+	DateTo   *time.Time `form:"date_to" time_format:"2006-01-02"`
+	Page     int        `form:"page"`
+	Limit    int        `form:"limit"`
+}
+
+// BankTransactionDTO 入出金明細DTO
+type BankTransactionDTO struct {
+	ID              string                     `json:"id"`
+	ImportID        string                     `json:"import_id"`
+	TransactionDate time.Time                  `json:"transaction_date"`
+	Direction       string                     `json:"direction"`
+	Amount          int64                      `json:"amount"`
+	PayerName       string                     `json:"payer_name"`
+	Description     string                     `json:"description"`
+	Reference       string                     `json:"reference"`
+	Status          string                     `json:"status"`
+	MatchType       string                     `json:"match_type"`
+	Confidence      float64                    `json:"confidence"`
+	FeeAmount       int64                      `json:"fee_amount"`
+	OverpaidAmount  int64                      `json:"overpaid_amount"`
+	ReviewReason    string                     `json:"review_reason,omitempty"`
+	Allocations     []BankAllocationDTO        `json:"allocations"`
+	Candidates      []ReconciliationInvoiceDTO `json:"candidates,omitempty"`
+	ConfirmedBy     *string                    `json:"confirmed_by,omitempty"`
+	ConfirmedAt     *time.Time                 `json:"confirmed_at,omitempty"`
+}
+
+// BankAllocationDTO 入金の請求書への割当DTO
+type BankAllocationDTO struct {
+	InvoiceID     string `json:"invoice_id"`
+	InvoiceNumber string `json:"invoice_number"`
+	ClientName    string `json:"client_name"`
+	Amount        int64  `json:"amount"`
+	FeeAmount     int64  `json:"fee_amount"`
+}
+
+// ReconciliationInvoiceDTO 消込候補の請求書DTO
+type ReconciliationInvoiceDTO struct {
+	InvoiceID       string    `json:"invoice_id"`
+	InvoiceNumber   string    `json:"invoice_number"`
+	ClientID        string    `json:"client_id"`
+	ClientName      string    `json:"client_name"`
+	ClientNameKana  string    `json:"client_name_kana"`
+	TotalAmount     int64     `json:"total_amount"`
+	RemainingAmount int64     `json:"remaining_amount"`
+	DueDate         time.Time `json:"due_date"`
+}
+
+// ConfirmBankMatchRequest 消込確定リクエスト
+// Allocationsを省略した場合は照合結果の割当をそのまま確定する
+type ConfirmBankMatchRequest struct {
+	Allocations []BankAllocationRequest `json:"allocations" binding:"omitempty,dive"`
+}
+
+// BankAllocationRequest 割当の指定
+type BankAllocationRequest struct {
+	InvoiceID string `json:"invoice_id" binding:"required"`
+	Amount    int64  `json:"amount" binding:"min=0"`
+	FeeAmount int64  `json:"fee_amount" binding:"min=0"`
+}
+
+// IgnoreBankTransactionRequest 消込対象外リクエスト
+type IgnoreBankTransactionRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
@@ -3,6 +3,9 @@ package dto
 import (
 	"fmt"
 	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // BillingPreviewRequest 請求プレビューリクエスト
@@ -18,7 +21,7 @@ type BillingPreviewResponse struct {
 	BillingYear  int                    `json:"billing_year"`
 	BillingMonth int                    `json:"billing_month"`
 	Clients      []ClientBillingPreview `json:"clients"`
-	TotalAmount  float64                `json:"total_amount"`
+	TotalAmount  money.Amount           `json:"total_amount"`
 	TotalClients int                    `json:"total_clients"`
 	CreatedAt    time.Time              `json:"created_at"`
 	Summary      BillingSummaryDTO      `json:"summary"`
@@ -31,16 +34,16 @@ type BillingPreviewResponse struct {
 
 // BillingSummaryDTO 請求サマリーDTO
 type BillingSummaryDTO struct {
-	TotalClients       int     `json:"total_clients"`
-	TotalAmount        float64 `json:"total_amount"`
-	TotalTaxAmount     float64 `json:"total_tax_amount"`
-	TotalGrossAmount   float64 `json:"total_gross_amount"`
-	BillableClients    int     `json:"billable_clients"`
-	NonBillableClients int     `json:"non_billable_clients"`
-	GroupedInvoices    int     `json:"grouped_invoices"`
-	IndividualInvoices int     `json:"individual_invoices"`
-	AverageAmount      float64 `json:"average_amount"`
-	BillingPeriod      string  `json:"billing_period"` // "2024-01"
+	TotalClients       int          `json:"total_clients"`
+	TotalAmount        money.Amount `json:"total_amount"`
+	TotalTaxAmount     money.Amount `json:"total_tax_amount"`
+	TotalGrossAmount   money.Amount `json:"total_gross_amount"`
+	BillableClients    int          `json:"billable_clients"`
+	NonBillableClients int          `json:"non_billable_clients"`
+	GroupedInvoices    int          `json:"grouped_invoices"`
+	IndividualInvoices int          `json:"individual_invoices"`
+	AverageAmount      money.Amount `json:"average_amount"`
+	BillingPeriod      string       `json:"billing_period"` // "2024-01"
 }
 
 // ClientBillingDTO 取引先別請求DTO
@@ -52,9 +55,9 @@ type ClientBillingDTO struct {
 	InvoiceType        string                   `json:"invoice_type"` // "individual", "grouped"
 	ProjectGroups      []ProjectGroupBillingDTO `json:"project_groups,omitempty"`
 	IndividualProjects []ProjectBillingDTO      `json:"individual_projects,omitempty"`
-	TotalAmount        float64                  `json:"total_amount"`
-	TotalTaxAmount     float64                  `json:"total_tax_amount"`
-	TotalGrossAmount   float64                  `json:"total_gross_amount"`
+	TotalAmount        money.Amount             `json:"total_amount"`
+	TotalTaxAmount     money.Amount             `json:"total_tax_amount"`
+	TotalGrossAmount   money.Amount             `json:"total_gross_amount"`
 	TaxRate            float64                  `json:"tax_rate"`
 	InvoiceDate        time.Time                `json:"invoice_date"`
 	DueDate            time.Time                `json:"due_date"`
@@ -67,7 +70,7 @@ type ProjectGroupBillingDTO struct {
 	GroupID     string              `json:"group_id"`
 	GroupName   string              `json:"group_name"`
 	Projects    []ProjectBillingDTO `json:"projects"`
-	GroupTotal  float64             `json:"group_total"`
+	GroupTotal  money.Amount        `json:"group_total"`
 	Description string              `json:"description,omitempty"`
 }
 
@@ -77,7 +80,7 @@ type ProjectBillingDTO struct {
 	ProjectName  string                 `json:"project_name"`
 	ProjectCode  string                 `json:"project_code"`
 	Assignments  []AssignmentBillingDTO `json:"assignments"`
-	ProjectTotal float64                `json:"project_total"`
+	ProjectTotal money.Amount           `json:"project_total"`
 	BillingType  string                 `json:"billing_type"`
 	Period       BillingPeriodDTO       `json:"period"`
 }
@@ -89,12 +92,12 @@ type AssignmentBillingDTO struct {
 	UserName         string                `json:"user_name"`
 	Role             string                `json:"role"`
 	BillingType      string                `json:"billing_type"` // "fixed", "variable_upper_lower", "variable_middle"
-	BillingRate      float64               `json:"billing_rate"`
+	BillingRate      money.Amount          `json:"billing_rate"`
 	WorkedHours      float64               `json:"worked_hours"`
 	BillableHours    float64               `json:"billable_hours"`
 	MinHours         *float64              `json:"min_hours,omitempty"`
 	MaxHours         *float64              `json:"max_hours,omitempty"`
-	CalculatedAmount float64               `json:"calculated_amount"`
+	CalculatedAmount money.Amount          `json:"calculated_amount"`
 	UtilizationRate  int                   `json:"utilization_rate"`
 	Calculation      BillingCalculationDTO `json:"calculation"`
 }
@@ -103,8 +106,8 @@ type AssignmentBillingDTO struct {
 type ClientBillingPreview struct {
 	ClientID     string                  `json:"client_id"`
 	ClientName   string                  `json:"client_name"`
-	Amount       float64                 `json:"amount"`
-	TotalAmount  float64                 `json:"total_amount"`
+	Amount       money.Amount            `json:"amount"`
+	TotalAmount  money.Amount            `json:"total_amount"`
 	ProjectCount int                     `json:"project_count"`
 	Status       string                  `json:"status"`
 	Error        string                  `json:"error,omitempty"`
@@ -114,13 +117,13 @@ type ClientBillingPreview struct {
 
 // BillingCalculationDTO 請求計算詳細DTO
 type BillingCalculationDTO struct {
-	BaseAmount     float64 `json:"base_amount"`
-	HourAdjustment float64 `json:"hour_adjustment"`
-	RateAdjustment float64 `json:"rate_adjustment"`
-	FinalAmount    float64 `json:"final_amount"`
-	Method         string  `json:"method"` // "fixed", "upper_lower", "middle"
-	Formula        string  `json:"formula,omitempty"`
-	Details        string  `json:"details,omitempty"`
+	BaseAmount     money.Amount `json:"base_amount"`
+	HourAdjustment money.Amount `json:"hour_adjustment"`
+	RateAdjustment money.Amount `json:"rate_adjustment"`
+	FinalAmount    money.Amount `json:"final_amount"`
+	Method         string       `json:"method"` // "fixed", "upper_lower", "middle"
+	Formula        string       `json:"formula,omitempty"`
+	Details        string       `json:"details,omitempty"`
 }
 
 // BillingPeriodDTO 請求期間DTO
@@ -190,30 +193,30 @@ type ExecuteBillingResponse struct {
 	ProcessedClients []*ProcessedClientResult `json:"processed_clients"`
 	SuccessCount     int                      `json:"success_count"`
 	FailureCount     int                      `json:"failure_count"`
-	TotalAmount      float64                  `json:"total_amount"`
+	TotalAmount      money.Amount             `json:"total_amount"`
 	ProcessedAt      time.Time                `json:"processed_at"`
 }
 
 // ProcessedClientResult 処理済みクライアント結果
 type ProcessedClientResult struct {
-	ClientID      string   `json:"client_id"`
-	ClientName    string   `json:"client_name"`
-	Status        string   `json:"status"`
-	InvoiceID     *string  `json:"invoice_id,omitempty"`
-	InvoiceNumber *string  `json:"invoice_number,omitempty"`
-	TotalAmount   *float64 `json:"total_amount,omitempty"`
-	Errors        []string `json:"errors,omitempty"`
+	ClientID      string        `json:"client_id"`
+	ClientName    string        `json:"client_name"`
+	Status        string        `json:"status"`
+	InvoiceID     *string       `json:"invoice_id,omitempty"`
+	InvoiceNumber *string       `json:"invoice_number,omitempty"`
+	TotalAmount   *money.Amount `json:"total_amount,omitempty"`
+	Errors        []string      `json:"errors,omitempty"`
 }
 
 // BillingExecutionSummaryDTO 請求実行サマリーDTO
 type BillingExecutionSummaryDTO struct {
-	TotalClients      int     `json:"total_clients"`
-	SuccessfulClients int     `json:"successful_clients"`
-	FailedClients     int     `json:"failed_clients"`
-	TotalInvoices     int     `json:"total_invoices"`
-	TotalAmount       float64 `json:"total_amount"`
-	ProcessingTime    int64   `json:"processing_time_ms"`
-	Status            string  `json:"status"` // "completed", "partial", "failed"
+	TotalClients      int          `json:"total_clients"`
+	SuccessfulClients int          `json:"successful_clients"`
+	FailedClients     int          `json:"failed_clients"`
+	TotalInvoices     int          `json:"total_invoices"`
+	TotalAmount       money.Amount `json:"total_amount"`
+	ProcessingTime    int64        `json:"processing_time_ms"`
+	Status            string       `json:"status"` // "completed", "partial", "failed"
 }
 
 // ClientBillingResultDTO 取引先別請求結果DTO
@@ -223,7 +226,7 @@ type ClientBillingResultDTO struct {
 	Status         string                  `json:"status"` // "success", "failed", "skipped"
 	InvoiceID      *string                 `json:"invoice_id,omitempty"`
 	InvoiceNumber  *string                 `json:"invoice_number,omitempty"`
-	Amount         float64                 `json:"amount"`
+	Amount         money.Amount            `json:"amount"`
 	ProcessingTime int64                   `json:"processing_time_ms"`
 	Errors         []BillingErrorDTO       `json:"errors,omitempty"`
 	Warnings       []BillingWarningDTO     `json:"warnings,omitempty"`
@@ -241,16 +244,21 @@ type ClientBillingDetailDTO struct {
 
 // ProjectBillingDetail プロジェクト請求詳細
 type ProjectBillingDetail struct {
-	ProjectID     string   `json:"project_id"`
-	ProjectName   string   `json:"project_name"`
-	AssignmentID  string   `json:"assignment_id"`
-	UserID        string   `json:"user_id"`
-	UserName      string   `json:"user_name"`
-	BillingType   string   `json:"billing_type"`
-	MonthlyRate   float64  `json:"monthly_rate"`
-	ActualHours   *float64 `json:"actual_hours,omitempty"`
-	BillingAmount float64  `json:"billing_amount"`
-	Notes         string   `json:"notes,omitempty"`
+	ProjectID     string       `json:"project_id"`
+	ProjectName   string       `json:"project_name"`
+	AssignmentID  string       `json:"assignment_id"`
+	UserID        string       `json:"user_id"`
+	UserName      string       `json:"user_name"`
+	BillingType   string       `json:"billing_type"`
+	MonthlyRate   money.Amount `json:"monthly_rate"`
+	ActualHours   *float64     `json:"actual_hours,omitempty"`
+	BillingAmount money.Amount `json:"billing_amount"`
+	Notes         string       `json:"notes,omitempty"`
+
+	HoursEvidence  *model.BillingHoursEvidence `json:"hours_evidence,omitempty"`  // 精算に使った週報と稼働時間
+	ProrationBasis string                      `json:"proration_basis,omitempty"` // 日割りの根拠（日割りしない場合は空）
+
+	RateSegments model.BillingRateSettlements `json:"rate_segments,omitempty"` // 単価履歴の区間ごとの精算結果
 }
 
 // GroupBillingPreview グループ請求プレビュー
@@ -258,18 +266,18 @@ type GroupBillingPreview struct {
 	GroupID      string                  `json:"group_id"`
 	GroupName    string                  `json:"group_name"`
 	Projects     []*ProjectBillingDetail `json:"projects"`
-	TotalAmount  float64                 `json:"total_amount"`
+	TotalAmount  money.Amount            `json:"total_amount"`
 	ProjectCount int                     `json:"project_count"`
 }
 
 // ProjectBillingResultDTO プロジェクト請求結果DTO
 type ProjectBillingResultDTO struct {
-	ProjectID     string  `json:"project_id"`
-	ProjectName   string  `json:"project_name"`
-	Amount        float64 `json:"amount"`
-	Assignments   int     `json:"assignments"`
-	WorkHours     float64 `json:"work_hours"`
-	BillableHours float64 `json:"billable_hours"`
+	ProjectID     string       `json:"project_id"`
+	ProjectName   string       `json:"project_name"`
+	Amount        money.Amount `json:"amount"`
+	Assignments   int          `json:"assignments"`
+	WorkHours     float64      `json:"work_hours"`
+	BillableHours float64      `json:"billable_hours"`
 }
 
 // FreeSyncResultDTO freee同期結果DTO
@@ -330,23 +338,42 @@ type BillingScheduleResponse struct {
 	Status         string     `json:"status"`
 }
 
-// BillingHistoryRequest 請求履歴リクエスト
+// BillingHistoryRequest 請求履歴（確定した請求計算のスナップショット）の取得条件
 type BillingHistoryRequest struct {
-	ClientID      *string    `form:"client_id"`
-	StartDate     *time.Time `form:"start_date"`
-	EndDate       *time.Time `form:"end_date"`
-	Status        *string    `form:"status"`         // "success", "failed", "scheduled"
-	ExecutionType *string    `form:"execution_type"` // "manual", "scheduled"
-	Page          int        `form:"page" binding:"min=1"`
-	Limit         int        `form:"limit" binding:"min=1,max=100"`
+	ClientID       *string `form:"client_id"`
+	ProjectGroupID *string `form:"project_group_id"`
+	ProjectID      *string `form:"project_id"`
+	UserID         *string `form:"user_id"`
+	StartMonth     *string `form:"from"`          // YYYY-MM
+	EndMonth       *string `form:"to"`            // YYYY-MM
+	AllRevisions   bool    `form:"all_revisions"` // 再計算前の版も含める（既定は最新の版のみ）
+	Page           int     `form:"page" binding:"omitempty,min=1"`
+	Limit          int     `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 // BillingHistoryResponse 請求履歴レスポンス
 type BillingHistoryResponse struct {
-	Items []BillingHistoryItemDTO `json:"items"`
-	Total int                     `json:"total"`
-	Page  int                     `json:"page"`
-	Limit int                     `json:"limit"`
+	Items []BillingCalculationSnapshotDTO `json:"items"`
+	Total int                             `json:"total"`
+	Page  int                             `json:"page"`
+	Limit int                             `json:"limit"`
+}
+
+// BillingCalculationSnapshotDTO 確定した請求計算のスナップショットと前の版からの変更点
+type BillingCalculationSnapshotDTO struct {
+	model.BillingCalculation
+	ClientName  string                           `json:"client_name"`
+	ProjectName string                           `json:"project_name"`
+	UserName    string                           `json:"user_name"`
+	IsLatest    bool                             `json:"is_latest"` // 同じアサイン・請求月の最新の版か
+	Changes     []model.BillingCalculationChange `json:"changes"`   // 前の版からの変更点（初回はなし）
+}
+
+// BillingCalculationRevisionsResponse 同じアサイン・請求月の計算の全ての版
+type BillingCalculationRevisionsResponse struct {
+	AssignmentID string                          `json:"assignment_id"`
+	BillingMonth string                          `json:"billing_month"`
+	Revisions    []BillingCalculationSnapshotDTO `json:"revisions"`
 }
 
 // BillingHistoryItemDTO 請求履歴項目DTO
@@ -355,7 +382,7 @@ type BillingHistoryItemDTO struct {
 	BillingPeriod string                      `json:"billing_period"`
 	ClientCount   int                         `json:"client_count"`
 	InvoiceCount  int                         `json:"invoice_count"`
-	TotalAmount   float64                     `json:"total_amount"`
+	TotalAmount   money.Amount                `json:"total_amount"`
 	Status        string                      `json:"status"`
 	ExecutionType string                      `json:"execution_type"`
 	ExecutedBy    *string                     `json:"executed_by,omitempty"`
@@ -376,8 +403,8 @@ type BillingStatsRequest struct {
 type BillingStatsResponse struct {
 	Period          string            `json:"period"`
 	TotalInvoices   int               `json:"total_invoices"`
-	TotalAmount     float64           `json:"total_amount"`
-	AverageAmount   float64           `json:"average_amount"`
+	TotalAmount     money.Amount      `json:"total_amount"`
+	AverageAmount   money.Amount      `json:"average_amount"`
 	UniqueClients   int               `json:"unique_clients"`
 	SuccessRate     float64           `json:"success_rate"`
 	MonthlyTrends   []MonthlyTrendDTO `json:"monthly_trends,omitempty"`
@@ -387,32 +414,32 @@ type BillingStatsResponse struct {
 
 // MonthlyTrendDTO 月次トレンドDTO
 type MonthlyTrendDTO struct {
-	Year         int     `json:"year"`
-	Month        int     `json:"month"`
-	InvoiceCount int     `json:"invoice_count"`
-	TotalAmount  float64 `json:"total_amount"`
-	ClientCount  int     `json:"client_count"`
-	Display      string  `json:"display"` // "2024年1月"
+	Year         int          `json:"year"`
+	Month        int          `json:"month"`
+	InvoiceCount int          `json:"invoice_count"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	ClientCount  int          `json:"client_count"`
+	Display      string       `json:"display"` // "2024年1月"
 }
 
 // ClientStatsDTO 取引先統計DTO
 type ClientStatsDTO struct {
-	ClientID      string     `json:"client_id"`
-	ClientName    string     `json:"client_name"`
-	InvoiceCount  int        `json:"invoice_count"`
-	TotalAmount   float64    `json:"total_amount"`
-	AverageAmount float64    `json:"average_amount"`
-	LastBilling   *time.Time `json:"last_billing"`
+	ClientID      string       `json:"client_id"`
+	ClientName    string       `json:"client_name"`
+	InvoiceCount  int          `json:"invoice_count"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	AverageAmount money.Amount `json:"average_amount"`
+	LastBilling   *time.Time   `json:"last_billing"`
 }
 
 // ProjectStatsDTO プロジェクト統計DTO
 type ProjectStatsDTO struct {
-	ProjectID    string     `json:"project_id"`
-	ProjectName  string     `json:"project_name"`
-	ClientName   string     `json:"client_name"`
-	TotalAmount  float64    `json:"total_amount"`
-	BillingCount int        `json:"billing_count"`
-	LastBilling  *time.Time `json:"last_billing"`
+	ProjectID    string       `json:"project_id"`
+	ProjectName  string       `json:"project_name"`
+	ClientName   string       `json:"client_name"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	BillingCount int          `json:"billing_count"`
+	LastBilling  *time.Time   `json:"last_billing"`
 }
 
 // Validate BillingPreviewRequestのバリデーション
@@ -464,14 +491,14 @@ func (r *BillingHistoryRequest) SetDefaults() {
 
 // Additional missing DTOs
 type MonthlyInvoiceGenerationResult struct {
-	Year         int       `json:"year"`
-	Month        int       `json:"month"`
-	GeneratedAt  time.Time `json:"generated_at"`
-	InvoiceCount int       `json:"invoice_count"`
-	TotalAmount  float64   `json:"total_amount"`
-	SuccessCount int       `json:"success_count"`
-	FailureCount int       `json:"failure_count"`
-	Errors       []string  `json:"errors,omitempty"`
+	Year         int          `json:"year"`
+	Month        int          `json:"month"`
+	GeneratedAt  time.Time    `json:"generated_at"`
+	InvoiceCount int          `json:"invoice_count"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	SuccessCount int          `json:"success_count"`
+	FailureCount int          `json:"failure_count"`
+	Errors       []string     `json:"errors,omitempty"`
 }
 
 type RetryBillingResult struct {
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// ExecuteBillingRunRequest 月次請求の実行リクエスト
+type ExecuteBillingRunRequest struct {
+	BillingYear  int      `json:"billing_year" binding:"required,min=2020,max=2050"`
+	BillingMonth int      `json:"billing_month" binding:"required,min=1,max=12"`
+	ClientIDs    []string `json:"client_ids"` // 指定した取引先の下書きのみ作り直す（省略時は全取引先）
+}
+
+// BillingRunDTO 月次請求DTO
+type BillingRunDTO struct {
+	ID                  string                    `json:"id"`
+	BillingMonth        string                    `json:"billing_month"`
+	Status              string                    `json:"status"`
+	RunCount            int                       `json:"run_count"`
+	InvoiceCount        int                       `json:"invoice_count"`
+	TotalAmount         money.Amount              `json:"total_amount"`
+	PreviousMonth       string                    `json:"previous_month"`
+	PreviousTotalAmount money.Amount              `json:"previous_total_amount"` // 前月の月次請求の合計額（未実行の場合は0）
+	ExecutedBy          string                    `json:"executed_by"`
+	ExecutedAt          time.Time                 `json:"executed_at"`
+	ApprovedBy          *string                   `json:"approved_by"`
+	ApprovedAt          *time.Time                `json:"approved_at"`
+	Invoices            []BillingRunInvoiceDTO    `json:"invoices"`
+	Changes             []BillingRunChangeDTO     `json:"changes"`
+	Skipped             []BillingRunSkippedTarget `json:"skipped,omitempty"`  // 実行時のみ
+	Warnings            []string                  `json:"warnings,omitempty"` // 実行時のみ
+}
+
+// BillingRunInvoiceDTO 月次請求で作成した請求書
+type BillingRunInvoiceDTO struct {
+	ID               string       `json:"id"`
+	InvoiceNumber    string       `json:"invoice_number"`
+	ClientID         string       `json:"client_id"`
+	ClientName       string       `json:"client_name"`
+	ProjectGroupID   *string      `json:"project_group_id"`
+	ProjectGroupName string       `json:"project_group_name,omitempty"`
+	Status           string       `json:"status"`
+	DetailCount      int          `json:"detail_count"`
+	Subtotal         money.Amount `json:"subtotal"`
+	TaxAmount        money.Amount `json:"tax_amount"`
+	TotalAmount      money.Amount `json:"total_amount"`
+}
+
+// BillingRunChangeDTO 請求単位（取引先・プロジェクトグループ）ごとの前月との差分
+type BillingRunChangeDTO struct {
+	ClientID         string       `json:"client_id"`
+	ClientName       string       `json:"client_name"`
+	ProjectGroupID   *string      `json:"project_group_id"`
+	ProjectGroupName string       `json:"project_group_name,omitempty"`
+	ChangeType       string       `json:"change_type"` // added, removed, changed, unchanged
+	PreviousAmount   money.Amount `json:"previous_amount"`
+	CurrentAmount    money.Amount `json:"current_amount"`
+	Difference       money.Amount `json:"difference"`
+}
+
+// BillingRunSkippedTarget 月次請求で請求書を作成しなかった請求単位
+type BillingRunSkippedTarget struct {
+	ClientID       string  `json:"client_id"`
+	ClientName     string  `json:"client_name"`
+	ProjectGroupID *string `json:"project_group_id"`
+	Reason         string  `json:"reason"`
+}
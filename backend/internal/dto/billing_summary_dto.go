@@ -1,10 +1,12 @@
 package dto
 
+import "github.com/duesk/monstera/pkg/money"
+
 // BillingSummaryResponse 請求サマリーレスポンス
 type BillingSummaryResponse struct {
 	Year            int                    `json:"year"`
 	Month           int                    `json:"month"`
-	TotalAmount     float64                `json:"total_amount"`
+	TotalAmount     money.Amount           `json:"total_amount"`
 	TotalInvoices   int                    `json:"total_invoices"`
 	PaidInvoices    int                    `json:"paid_invoices"`
 	UnpaidInvoices  int                    `json:"unpaid_invoices"`
@@ -17,17 +19,17 @@ type BillingSummaryResponse struct {
 
 // ClientBillingSummary クライアント別請求サマリー
 type ClientBillingSummary struct {
-	ClientID     string  `json:"client_id"`
-	ClientName   string  `json:"client_name"`
-	TotalAmount  float64 `json:"total_amount"`
-	InvoiceCount int     `json:"invoice_count"`
-	Status       string  `json:"status"`
+	ClientID     string       `json:"client_id"`
+	ClientName   string       `json:"client_name"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	InvoiceCount int          `json:"invoice_count"`
+	Status       string       `json:"status"`
 }
 
 // BillingTypeSummary 請求タイプ別サマリー
 type BillingTypeSummary struct {
-	BillingType   string  `json:"billing_type"`
-	TotalAmount   float64 `json:"total_amount"`
-	InvoiceCount  int     `json:"invoice_count"`
-	AverageAmount float64 `json:"average_amount"`
+	BillingType   string       `json:"billing_type"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	InvoiceCount  int          `json:"invoice_count"`
+	AverageAmount money.Amount `json:"average_amount"`
 }
@@ -0,0 +1,296 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// BusinessPartnerRequest ビジネスパートナーの登録・更新リクエスト
+type BusinessPartnerRequest struct {
+	CompanyName        string `json:"company_name" binding:"required,max=200"`
+	CompanyNameKana    string `json:"company_name_kana" binding:"max=200"`
+	RegistrationNumber string `json:"registration_number" binding:"omitempty,len=14"` // T + 13桁
+	PaymentTerms       *int   `json:"payment_terms" binding:"omitempty,min=0,max=120"`
+	ProrationMethod    string `json:"proration_method" binding:"omitempty,oneof=business_days calendar_days hours"`
+	ContactPerson      string `json:"contact_person" binding:"max=100"`
+	ContactEmail       string `json:"contact_email" binding:"omitempty,email,max=100"`
+	ContactPhone       string `json:"contact_phone" binding:"max=20"`
+	Address            string `json:"address" binding:"max=255"`
+	Notes              string `json:"notes"`
+	IsActive           *bool  `json:"is_active"`
+}
+
+// BusinessPartnerDTO ビジネスパートナーDTO
+type BusinessPartnerDTO struct {
+	ID                 string    `json:"id"`
+	CompanyName        string    `json:"company_name"`
+	CompanyNameKana    string    `json:"company_name_kana"`
+	RegistrationNumber string    `json:"registration_number"`
+	PaymentTerms       int       `json:"payment_terms"`
+	ProrationMethod    string    `json:"proration_method"`
+	ContactPerson      string    `json:"contact_person"`
+	ContactEmail       string    `json:"contact_email"`
+	ContactPhone       string    `json:"contact_phone"`
+	Address            string    `json:"address"`
+	Notes              string    `json:"notes"`
+	IsActive           bool      `json:"is_active"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ProjectAssignmentCostRequest 仕入単価の登録・変更リクエスト
+type ProjectAssignmentCostRequest struct {
+	PartnerID     string       `json:"partner_id" binding:"required"`
+	EffectiveFrom string       `json:"effective_from" binding:"required"` // YYYY-MM-DD
+	EffectiveTo   *string      `json:"effective_to"`                      // YYYY-MM-DD（省略時は終了日なし）
+	CostType      string       `json:"cost_type" binding:"required,oneof=fixed variable_upper_lower variable_middle"`
+	CostRate      money.Amount `json:"cost_rate"`
+	MinHours      *float64     `json:"min_hours"`
+	MaxHours      *float64     `json:"max_hours"`
+	Notes         string       `json:"notes"`
+}
+
+// ProjectAssignmentCostDTO 仕入単価DTO
+type ProjectAssignmentCostDTO struct {
+	ID            string       `json:"id"`
+	AssignmentID  string       `json:"assignment_id"`
+	PartnerID     string       `json:"partner_id"`
+	PartnerName   string       `json:"partner_name,omitempty"`
+	EffectiveFrom string       `json:"effective_from"`
+	EffectiveTo   *string      `json:"effective_to"`
+	CostType      string       `json:"cost_type"`
+	CostRate      money.Amount `json:"cost_rate"`
+	MinHours      *float64     `json:"min_hours"`
+	MaxHours      *float64     `json:"max_hours"`
+	Notes         string       `json:"notes"`
+	CreatedBy     string       `json:"created_by"`
+	UpdatedBy     string       `json:"updated_by"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// AssignmentCostDetail 案件アサインの仕入額の計算結果
+type AssignmentCostDetail struct {
+	AssignmentID string       `json:"assignment_id"`
+	UserID       string       `json:"user_id"`
+	PartnerID    string       `json:"partner_id"`
+	PartnerName  string       `json:"partner_name"`
+	CostID       string       `json:"cost_id"`
+	CostType     string       `json:"cost_type"`
+	CostRate     money.Amount `json:"cost_rate"`
+	ActualHours  *float64     `json:"actual_hours,omitempty"`
+	CostAmount   money.Amount `json:"cost_amount"`
+	Notes        string       `json:"notes,omitempty"`
+
+	HoursEvidence  *model.BillingHoursEvidence `json:"hours_evidence,omitempty"`  // 精算に使った週報と稼働時間
+	ProrationBasis string                      `json:"proration_basis,omitempty"` // 日割りの根拠（日割りしない場合は空）
+}
+
+// IssuePurchaseOrdersRequest 注文書の一括発行リクエスト
+type IssuePurchaseOrdersRequest struct {
+	Year  int `json:"year" binding:"required,min=2020,max=2100"`
+	Month int `json:"month" binding:"required,min=1,max=12"`
+}
+
+// PurchaseOrderDTO 注文書DTO
+type PurchaseOrderDTO struct {
+	ID           string       `json:"id"`
+	OrderNumber  string       `json:"order_number"`
+	PartnerID    string       `json:"partner_id"`
+	PartnerName  string       `json:"partner_name,omitempty"`
+	AssignmentID string       `json:"assignment_id"`
+	CostID       string       `json:"cost_id"`
+	OrderMonth   string       `json:"order_month"`
+	PeriodStart  string       `json:"period_start"`
+	PeriodEnd    string       `json:"period_end"`
+	CostType     string       `json:"cost_type"`
+	CostRate     money.Amount `json:"cost_rate"`
+	MinHours     *float64     `json:"min_hours"`
+	MaxHours     *float64     `json:"max_hours"`
+	Status       string       `json:"status"`
+	IssuedBy     string       `json:"issued_by"`
+	IssuedAt     time.Time    `json:"issued_at"`
+}
+
+// PurchaseOrderSkip 注文書を発行しなかったアサインと理由
+type PurchaseOrderSkip struct {
+	AssignmentID string `json:"assignment_id"`
+	Reason       string `json:"reason"`
+}
+
+// IssuePurchaseOrdersResponse 注文書の一括発行結果
+type IssuePurchaseOrdersResponse struct {
+	OrderMonth string              `json:"order_month"`
+	Orders     []PurchaseOrderDTO  `json:"orders"`
+	Skipped    []PurchaseOrderSkip `json:"skipped"`
+}
+
+// PartnerInvoiceRequest 受領した請求書の登録リクエスト
+type PartnerInvoiceRequest struct {
+	PurchaseOrderID string       `json:"purchase_order_id" binding:"required"`
+	InvoiceNumber   string       `json:"invoice_number" binding:"max=50"`
+	InvoiceDate     string       `json:"invoice_date" binding:"required"` // YYYY-MM-DD
+	DueDate         *string      `json:"due_date"`                        // YYYY-MM-DD（省略時はパートナーの支払サイトから計算）
+	Hours           *float64     `json:"hours" binding:"omitempty,min=0"`
+	Subtotal        money.Amount `json:"subtotal"`
+	TaxAmount       money.Amount `json:"tax_amount"`
+}
+
+// RejectPartnerInvoiceRequest 受領した請求書の差戻しリクエスト
+type RejectPartnerInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// PartnerInvoiceDTO 受領した請求書DTO
+type PartnerInvoiceDTO struct {
+	ID              string        `json:"id"`
+	PartnerID       string        `json:"partner_id"`
+	PartnerName     string        `json:"partner_name,omitempty"`
+	PurchaseOrderID string        `json:"purchase_order_id"`
+	OrderNumber     string        `json:"order_number,omitempty"`
+	AssignmentID    string        `json:"assignment_id"`
+	BillingMonth    string        `json:"billing_month"`
+	InvoiceNumber   string        `json:"invoice_number"`
+	InvoiceDate     string        `json:"invoice_date"`
+	DueDate         string        `json:"due_date"`
+	Hours           *float64      `json:"hours"`
+	Subtotal        money.Amount  `json:"subtotal"`
+	TaxAmount       money.Amount  `json:"tax_amount"`
+	TotalAmount     money.Amount  `json:"total_amount"`
+	Status          string        `json:"status"`
+	ExpectedAmount  *money.Amount `json:"expected_amount"`
+	ExpectedHours   *float64      `json:"expected_hours"`
+	BilledHours     *float64      `json:"billed_hours"`
+	MatchNotes      string        `json:"match_notes"`
+	ApprovedBy      *string       `json:"approved_by"`
+	ApprovedAt      *time.Time    `json:"approved_at"`
+	CreatedBy       string        `json:"created_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// MarginReportRequest 粗利レポートの取得条件
+type MarginReportRequest struct {
+	StartMonth string `form:"start_month" binding:"required"` // YYYY-MM
+	EndMonth   string `form:"end_month" binding:"required"`   // YYYY-MM
+	GroupBy    string `form:"group_by" binding:"omitempty,oneof=engineer project client month"`
+	ClientID   string `form:"client_id"`
+	ProjectID  string `form:"project_id"`
+	UserID     string `form:"user_id"`
+}
+
+// MarginReportResponse 粗利レポート
+type MarginReportResponse struct {
+	GroupBy    string            `json:"group_by"`
+	StartMonth string            `json:"start_month"`
+	EndMonth   string            `json:"end_month"`
+	Rows       []model.MarginRow `json:"rows"`
+	Total      model.MarginRow   `json:"total"`
+}
+
+// BusinessPartnerToDTO ビジネスパートナーをDTOに変換
+func BusinessPartnerToDTO(partner *model.BusinessPartner) BusinessPartnerDTO {
+	return BusinessPartnerDTO{
+		ID:                 partner.ID,
+		CompanyName:        partner.CompanyName,
+		CompanyNameKana:    partner.CompanyNameKana,
+		RegistrationNumber: partner.RegistrationNumber,
+		PaymentTerms:       partner.PaymentTerms,
+		ProrationMethod:    string(partner.ProrationMethod),
+		ContactPerson:      partner.ContactPerson,
+		ContactEmail:       partner.ContactEmail,
+		ContactPhone:       partner.ContactPhone,
+		Address:            partner.Address,
+		Notes:              partner.Notes,
+		IsActive:           partner.IsActive,
+		CreatedAt:          partner.CreatedAt,
+		UpdatedAt:          partner.UpdatedAt,
+	}
+}
+
+// ProjectAssignmentCostToDTO 仕入単価をDTOに変換
+func ProjectAssignmentCostToDTO(cost *model.ProjectAssignmentCost) ProjectAssignmentCostDTO {
+	result := ProjectAssignmentCostDTO{
+		ID:            cost.ID,
+		AssignmentID:  cost.AssignmentID,
+		PartnerID:     cost.PartnerID,
+		EffectiveFrom: cost.EffectiveFrom.Format("2006-01-02"),
+		CostType:      string(cost.CostType),
+		CostRate:      cost.CostRate,
+		MinHours:      cost.MinHours,
+		MaxHours:      cost.MaxHours,
+		Notes:         cost.Notes,
+		CreatedBy:     cost.CreatedBy,
+		UpdatedBy:     cost.UpdatedBy,
+		CreatedAt:     cost.CreatedAt,
+		UpdatedAt:     cost.UpdatedAt,
+	}
+	if cost.EffectiveTo != nil {
+		effectiveTo := cost.EffectiveTo.Format("2006-01-02")
+		result.EffectiveTo = &effectiveTo
+	}
+	if cost.Partner != nil {
+		result.PartnerName = cost.Partner.CompanyName
+	}
+	return result
+}
+
+// PurchaseOrderToDTO 注文書をDTOに変換
+func PurchaseOrderToDTO(order *model.PurchaseOrder) PurchaseOrderDTO {
+	result := PurchaseOrderDTO{
+		ID:           order.ID,
+		OrderNumber:  order.OrderNumber,
+		PartnerID:    order.PartnerID,
+		AssignmentID: order.AssignmentID,
+		CostID:       order.CostID,
+		OrderMonth:   order.OrderMonth,
+		PeriodStart:  order.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:    order.PeriodEnd.Format("2006-01-02"),
+		CostType:     string(order.CostType),
+		CostRate:     order.CostRate,
+		MinHours:     order.MinHours,
+		MaxHours:     order.MaxHours,
+		Status:       string(order.Status),
+		IssuedBy:     order.IssuedBy,
+		IssuedAt:     order.IssuedAt,
+	}
+	if order.Partner != nil {
+		result.PartnerName = order.Partner.CompanyName
+	}
+	return result
+}
+
+// PartnerInvoiceToDTO 受領した請求書をDTOに変換
+func PartnerInvoiceToDTO(invoice *model.PartnerInvoice) PartnerInvoiceDTO {
+	result := PartnerInvoiceDTO{
+		ID:              invoice.ID,
+		PartnerID:       invoice.PartnerID,
+		PurchaseOrderID: invoice.PurchaseOrderID,
+		AssignmentID:    invoice.AssignmentID,
+		BillingMonth:    invoice.BillingMonth,
+		InvoiceNumber:   invoice.InvoiceNumber,
+		InvoiceDate:     invoice.InvoiceDate.Format("2006-01-02"),
+		DueDate:         invoice.DueDate.Format("2006-01-02"),
+		Hours:           invoice.Hours,
+		Subtotal:        invoice.Subtotal,
+		TaxAmount:       invoice.TaxAmount,
+		TotalAmount:     invoice.TotalAmount,
+		Status:          string(invoice.Status),
+		ExpectedAmount:  invoice.ExpectedAmount,
+		ExpectedHours:   invoice.ExpectedHours,
+		BilledHours:     invoice.BilledHours,
+		MatchNotes:      invoice.MatchNotes,
+		ApprovedBy:      invoice.ApprovedBy,
+		ApprovedAt:      invoice.ApprovedAt,
+		CreatedBy:       invoice.CreatedBy,
+		CreatedAt:       invoice.CreatedAt,
+	}
+	if invoice.Partner != nil {
+		result.PartnerName = invoice.Partner.CompanyName
+	}
+	if invoice.PurchaseOrder != nil {
+		result.OrderNumber = invoice.PurchaseOrder.OrderNumber
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// BusinessTripRequest 出張申請の作成・更新リクエスト
+type BusinessTripRequest struct {
+	ProjectID     *string `json:"project_id" binding:"omitempty,max=255"` // 出張先の顧客案件
+	Purpose       string  `json:"purpose" binding:"required,max=1000"`
+	Destination   string  `json:"destination" binding:"required,max=255"`
+	StartDate     string  `json:"start_date" binding:"required"`                // YYYY-MM-DD
+	EndDate       string  `json:"end_date" binding:"required"`                  // YYYY-MM-DD（この日を含む）
+	EstimatedCost int     `json:"estimated_cost" binding:"min=0,max=100000000"` // 概算費用（円）
+}
+
+// BusinessTripSettlementRequest 出張精算の申請リクエスト
+type BusinessTripSettlementRequest struct {
+	ExpenseIDs []string `json:"expense_ids" binding:"max=100,dive,required"` // 精算する経費申請（交通費・宿泊費など）
+}
+
+// BusinessTripReviewRequest 出張申請・出張精算の承認リクエスト
+type BusinessTripReviewRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+// BusinessTripRejectRequest 出張申請の却下・出張精算の差し戻しリクエスト
+type BusinessTripRejectRequest struct {
+	Comment string `json:"comment" binding:"required,min=1,max=1000"` // 理由（必須）
+}
+
+// BusinessTripResponse 出張申請レスポンス
+type BusinessTripResponse struct {
+	ID              string                          `json:"id"`
+	User            *UserSummary                    `json:"user,omitempty"`
+	UserID          string                          `json:"user_id"`
+	ProjectID       *string                         `json:"project_id"`
+	ProjectName     string                          `json:"project_name,omitempty"`
+	Purpose         string                          `json:"purpose"`
+	Destination     string                          `json:"destination"`
+	StartDate       string                          `json:"start_date"`
+	EndDate         string                          `json:"end_date"`
+	Days            int                             `json:"days"`
+	TripType        string                          `json:"trip_type"`
+	EstimatedCost   int                             `json:"estimated_cost"`
+	Status          string                          `json:"status"`
+	ApproverID      *string                         `json:"approver_id"`
+	OnBehalfOfID    *string                         `json:"on_behalf_of_id,omitempty"`
+	ApprovedAt      *time.Time                      `json:"approved_at"`
+	ApprovalComment string                          `json:"approval_comment"`
+	Settlement      *BusinessTripSettlementResponse `json:"settlement,omitempty"`
+	CreatedAt       time.Time                       `json:"created_at"`
+	UpdatedAt       time.Time                       `json:"updated_at"`
+}
+
+// BusinessTripSettlementResponse 出張精算（精算額と概算費用の差を承認者に示す）
+type BusinessTripSettlementResponse struct {
+	AllowanceDays          int                           `json:"allowance_days"`
+	AllowanceDailyRate     int                           `json:"allowance_daily_rate"`
+	AllowanceAmount        int                           `json:"allowance_amount"`
+	ExpenseTotal           int                           `json:"expense_total"`
+	SettlementTotal        int                           `json:"settlement_total"`         // 経費申請と日当の合計
+	Variance               int                           `json:"variance"`                 // 精算額 - 概算費用
+	VarianceRate           float64                       `json:"variance_rate"`            // 概算費用に対する差の割合
+	HasSignificantVariance bool                          `json:"has_significant_variance"` // 差が大きい場合はtrue
+	Expenses               []BusinessTripExpenseResponse `json:"expenses"`
+	SubmittedAt            *time.Time                    `json:"submitted_at"`
+	SettledBy              *string                       `json:"settled_by"`
+	SettledAt              *time.Time                    `json:"settled_at"`
+	Comment                string                        `json:"comment"` // 承認者のコメント・差し戻し理由
+}
+
+// BusinessTripExpenseResponse 出張精算で精算する経費申請
+type BusinessTripExpenseResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Category    string    `json:"category"`
+	Amount      int       `json:"amount"`
+	ExpenseDate time.Time `json:"expense_date"`
+	Status      string    `json:"status"`
+}
+
+// FromModel BusinessTripモデルからレスポンスに変換
+func (r *BusinessTripResponse) FromModel(trip *model.BusinessTrip) {
+	r.ID = trip.ID
+	r.UserID = trip.UserID
+	r.ProjectID = trip.ProjectID
+	r.Purpose = trip.Purpose
+	r.Destination = trip.Destination
+	r.StartDate = trip.StartDate.Format("2006-01-02")
+	r.EndDate = trip.EndDate.Format("2006-01-02")
+	r.Days = trip.Days()
+	r.TripType = string(trip.TripType())
+	r.EstimatedCost = trip.EstimatedCost
+	r.Status = string(trip.Status)
+	r.ApproverID = trip.ApproverID
+	r.OnBehalfOfID = trip.OnBehalfOfID
+	r.ApprovedAt = trip.ApprovedAt
+	r.ApprovalComment = trip.ApprovalComment
+	r.CreatedAt = trip.CreatedAt
+	r.UpdatedAt = trip.UpdatedAt
+
+	if trip.User != nil && trip.User.ID != "" {
+		r.User = &UserSummary{ID: trip.User.ID, Email: trip.User.Email, Name: trip.User.Name}
+	}
+	if trip.Project != nil {
+		r.ProjectName = trip.Project.ProjectName
+	}
+
+	if trip.SettlementSubmittedAt == nil {
+		return
+	}
+	settlement := &BusinessTripSettlementResponse{
+		AllowanceDays:          trip.AllowanceDays,
+		AllowanceDailyRate:     trip.AllowanceDailyRate,
+		AllowanceAmount:        trip.AllowanceAmount,
+		ExpenseTotal:           trip.ExpenseTotal,
+		SettlementTotal:        trip.SettlementTotal(),
+		Variance:               trip.Variance(),
+		VarianceRate:           trip.VarianceRate(),
+		HasSignificantVariance: trip.HasSignificantVariance(),
+		Expenses:               make([]BusinessTripExpenseResponse, len(trip.Expenses)),
+		SubmittedAt:            trip.SettlementSubmittedAt,
+		SettledBy:              trip.SettledBy,
+		SettledAt:              trip.SettledAt,
+		Comment:                trip.SettlementComment,
+	}
+	for i, expense := range trip.Expenses {
+		settlement.Expenses[i] = BusinessTripExpenseResponse{
+			ID:          expense.ID,
+			Title:       expense.Title,
+			Category:    string(expense.Category),
+			Amount:      expense.Amount,
+			ExpenseDate: expense.ExpenseDate,
+			Status:      string(expense.Status),
+		}
+	}
+	r.Settlement = settlement
+}
+
+// BusinessTripsResponse 出張申請一覧レスポンス
+type BusinessTripsResponse struct {
+	Trips []BusinessTripResponse `json:"trips"`
+	Total int64                  `json:"total"`
+	Page  int                    `json:"page"`
+	Limit int                    `json:"limit"`
+}
+
+// BusinessTripPerDiemRateRequest 日当の単価の作成・更新リクエスト
+type BusinessTripPerDiemRateRequest struct {
+	TripType      string `json:"trip_type" binding:"required,oneof=day_trip overnight"`
+	DailyAmount   int    `json:"daily_amount" binding:"min=0,max=1000000"`
+	EffectiveFrom string `json:"effective_from" binding:"required"` // YYYY-MM-DD
+	Description   string `json:"description" binding:"omitempty,max=255"`
+}
+
+// BusinessTripPerDiemRateResponse 日当の単価レスポンス
+type BusinessTripPerDiemRateResponse struct {
+	ID            string    `json:"id"`
+	TripType      string    `json:"trip_type"`
+	DailyAmount   int       `json:"daily_amount"`
+	EffectiveFrom string    `json:"effective_from"`
+	Description   string    `json:"description"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FromModel BusinessTripPerDiemRateモデルからレスポンスに変換
+func (r *BusinessTripPerDiemRateResponse) FromModel(rate *model.BusinessTripPerDiemRate) {
+	r.ID = rate.ID
+	r.TripType = string(rate.TripType)
+	r.DailyAmount = rate.DailyAmount
+	r.EffectiveFrom = rate.EffectiveFrom.Format("2006-01-02")
+	r.Description = rate.Description
+	r.CreatedBy = rate.CreatedBy
+	r.CreatedAt = rate.CreatedAt
+	r.UpdatedAt = rate.UpdatedAt
+}
+
+// BusinessTripPerDiemRatesResponse 日当の単価表レスポンス
+type BusinessTripPerDiemRatesResponse struct {
+	Rates []BusinessTripPerDiemRateResponse `json:"rates"`
+}
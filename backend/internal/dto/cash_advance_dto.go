@@ -0,0 +1,216 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// CashAdvanceRequest 仮払金の申請・更新リクエスト
+type CashAdvanceRequest struct {
+	Purpose           string       `json:"purpose" binding:"required,max=1000"`
+	Amount            money.Amount `json:"amount" binding:"required,min=0"`        // 仮払金額
+	SettlementDueDate string       `json:"settlement_due_date" binding:"required"` // 精算期限（YYYY-MM-DD）
+}
+
+// CashAdvanceFilterRequest 仮払金フィルターリクエスト
+type CashAdvanceFilterRequest struct {
+	Status *string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled paid settlement_pending settled closed"`
+	Page   int     `form:"page" binding:"omitempty,min=1"`
+	Limit  int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	UserID *string `form:"user_id" binding:"omitempty"`
+}
+
+// SetDefaults フィルターのデフォルト値
+func (f *CashAdvanceFilterRequest) SetDefaults() {
+	if f.Page == 0 {
+		f.Page = 1
+	}
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+}
+
+// CashAdvanceSettlementRequest 仮払金の精算の申請リクエスト
+type CashAdvanceSettlementRequest struct {
+	ExpenseIDs []string `json:"expense_ids" binding:"max=100,dive,required"` // 精算に紐付ける経費申請
+}
+
+// CashAdvanceReviewRequest 仮払金・精算の承認リクエスト
+type CashAdvanceReviewRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+// CashAdvanceRejectRequest 仮払金の却下・精算の差し戻しリクエスト
+type CashAdvanceRejectRequest struct {
+	Comment string `json:"comment" binding:"required,min=1,max=1000"` // 理由（必須）
+}
+
+// CashAdvancePaymentRequest 仮払金の支払・差額の精算完了の記録リクエスト
+type CashAdvancePaymentRequest struct {
+	Date string `json:"date" binding:"required"` // 支払日・返金日（YYYY-MM-DD）
+}
+
+// CashAdvanceResponse 仮払金レスポンス
+type CashAdvanceResponse struct {
+	ID                string                         `json:"id"`
+	User              *UserSummary                   `json:"user,omitempty"`
+	UserID            string                         `json:"user_id"`
+	Purpose           string                         `json:"purpose"`
+	Amount            money.Amount                   `json:"amount"`
+	SettlementDueDate string                         `json:"settlement_due_date"`
+	Status            string                         `json:"status"`
+	IsOverdue         bool                           `json:"is_overdue"`   // 精算期限を過ぎても精算を申請していない
+	DaysOverdue       int                            `json:"days_overdue"` // 精算期限を過ぎた日数
+	ApprovalRuleID    *string                        `json:"approval_rule_id"`
+	Approvals         []ApprovalResponse             `json:"approvals,omitempty"` // 承認フロー（承認順序順）
+	ApproverID        *string                        `json:"approver_id"`         // 最終承認者
+	OnBehalfOfID      *string                        `json:"on_behalf_of_id,omitempty"`
+	ApprovedAt        *time.Time                     `json:"approved_at"`
+	ApprovalComment   string                         `json:"approval_comment"`
+	PaidAt            *string                        `json:"paid_at"`
+	PaidBy            *string                        `json:"paid_by"`
+	Settlement        *CashAdvanceSettlementResponse `json:"settlement,omitempty"`
+	CreatedAt         time.Time                      `json:"created_at"`
+	UpdatedAt         time.Time                      `json:"updated_at"`
+}
+
+// CashAdvanceSettlementResponse 仮払金の精算（差額は返金または追加支払）
+type CashAdvanceSettlementResponse struct {
+	ExpenseTotal   money.Amount                 `json:"expense_total"`
+	Difference     money.Amount                 `json:"difference"`      // 仮払金額 - 精算額（正:返金 負:追加支払）
+	DifferenceType string                       `json:"difference_type"` // none / refund / reimburse
+	Expenses       []CashAdvanceExpenseResponse `json:"expenses"`
+	SubmittedAt    *time.Time                   `json:"submitted_at"`
+	SettledBy      *string                      `json:"settled_by"`
+	SettledAt      *time.Time                   `json:"settled_at"`
+	Comment        string                       `json:"comment"` // 承認者のコメント・差し戻し理由
+	ClosedAt       *string                      `json:"closed_at"`
+	ClosedBy       *string                      `json:"closed_by"`
+}
+
+// CashAdvanceExpenseResponse 仮払金の精算に紐付けた経費申請
+type CashAdvanceExpenseResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Category    string    `json:"category"`
+	Amount      int       `json:"amount"`
+	ExpenseDate time.Time `json:"expense_date"`
+	Status      string    `json:"status"`
+}
+
+// CashAdvanceToResponse 仮払金モデルをレスポンスDTOに変換
+func CashAdvanceToResponse(advance *model.CashAdvance) CashAdvanceResponse {
+	var r CashAdvanceResponse
+	now := time.Now()
+	r.ID = advance.ID
+	r.UserID = advance.UserID
+	r.Purpose = advance.Purpose
+	r.Amount = advance.Amount
+	r.SettlementDueDate = advance.SettlementDueDate.Format("2006-01-02")
+	r.Status = string(advance.Status)
+	r.IsOverdue = advance.IsOverdue(now)
+	r.DaysOverdue = advance.DaysOverdue(now)
+	r.ApprovalRuleID = advance.ApprovalRuleID
+	r.Approvals = make([]ApprovalResponse, len(advance.Approvals))
+	for i := range advance.Approvals {
+		r.Approvals[i].FromModel(&advance.Approvals[i])
+	}
+	r.ApproverID = advance.ApproverID
+	r.OnBehalfOfID = advance.OnBehalfOfID
+	r.ApprovedAt = advance.ApprovedAt
+	r.ApprovalComment = advance.ApprovalComment
+	r.PaidAt = formatOptionalDate(advance.PaidAt)
+	r.PaidBy = advance.PaidBy
+	r.CreatedAt = advance.CreatedAt
+	r.UpdatedAt = advance.UpdatedAt
+
+	if advance.User != nil && advance.User.ID != "" {
+		r.User = &UserSummary{ID: advance.User.ID, Email: advance.User.Email, Name: advance.User.Name}
+	}
+
+	if advance.SettlementSubmittedAt == nil {
+		return r
+	}
+	settlement := &CashAdvanceSettlementResponse{
+		ExpenseTotal:   advance.ExpenseTotal,
+		Difference:     advance.Difference(),
+		DifferenceType: string(advance.DifferenceType()),
+		Expenses:       make([]CashAdvanceExpenseResponse, len(advance.Expenses)),
+		SubmittedAt:    advance.SettlementSubmittedAt,
+		SettledBy:      advance.SettledBy,
+		SettledAt:      advance.SettledAt,
+		Comment:        advance.SettlementComment,
+		ClosedAt:       formatOptionalDate(advance.ClosedAt),
+		ClosedBy:       advance.ClosedBy,
+	}
+	for i, expense := range advance.Expenses {
+		settlement.Expenses[i] = CashAdvanceExpenseResponse{
+			ID:          expense.ID,
+			Title:       expense.Title,
+			Category:    string(expense.Category),
+			Amount:      expense.Amount,
+			ExpenseDate: expense.ExpenseDate,
+			Status:      string(expense.Status),
+		}
+	}
+	r.Settlement = settlement
+	return r
+}
+
+// CashAdvanceListResponse 仮払金一覧レスポンス
+type CashAdvanceListResponse struct {
+	Items      []CashAdvanceResponse `json:"items"`
+	Total      int64                 `json:"total"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// CashAdvanceBalanceResponse 社員ごとの未精算の仮払金
+type CashAdvanceBalanceResponse struct {
+	User              *UserSummary `json:"user,omitempty"`
+	UserID            string       `json:"user_id"`
+	OutstandingCount  int          `json:"outstanding_count"`  // 精算待ち・精算の承認待ちの件数
+	OutstandingAmount money.Amount `json:"outstanding_amount"` // 精算待ち・精算の承認待ちの仮払金額の合計
+	OverdueCount      int          `json:"overdue_count"`      // 精算期限を過ぎた件数
+	RefundDue         money.Amount `json:"refund_due"`         // 社員からの返金待ち
+	ReimbursementDue  money.Amount `json:"reimbursement_due"`  // 会社からの追加支払待ち
+}
+
+// FromModel CashAdvanceBalanceからレスポンスに変換
+func (r *CashAdvanceBalanceResponse) FromModel(balance *model.CashAdvanceBalance) {
+	r.UserID = balance.UserID
+	r.OutstandingCount = balance.OutstandingCount
+	r.OutstandingAmount = balance.OutstandingAmount
+	r.OverdueCount = balance.OverdueCount
+	r.RefundDue = balance.RefundDue
+	r.ReimbursementDue = balance.ReimbursementDue
+	if balance.User != nil && balance.User.ID != "" {
+		r.User = &UserSummary{ID: balance.User.ID, Email: balance.User.Email, Name: balance.User.Name}
+	}
+}
+
+// CashAdvanceBalancesResponse 未精算の仮払金の社員別一覧レスポンス
+type CashAdvanceBalancesResponse struct {
+	Balances          []CashAdvanceBalanceResponse `json:"balances"`
+	OutstandingAmount money.Amount                 `json:"outstanding_amount"` // 全社員の未精算の仮払金額の合計
+	OverdueCount      int                          `json:"overdue_count"`      // 全社員の精算期限を過ぎた件数
+}
+
+// CashAdvanceReminderResult 精算期限超過の督促の実行結果
+type CashAdvanceReminderResult struct {
+	OverdueCount  int `json:"overdue_count"`  // 精算期限を過ぎた仮払金の件数
+	ReminderCount int `json:"reminder_count"` // 督促を送った件数
+	FailedCount   int `json:"failed_count"`   // 督促の送信に失敗した件数
+}
+
+// formatOptionalDate 任意の日付をYYYY-MM-DD形式に変換
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02")
+	return &formatted
+}
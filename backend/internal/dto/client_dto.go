@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ClientDTO 取引先DTO
@@ -16,8 +18,13 @@ type ClientDTO struct {
 	ContactPhone    string    `json:"contact_phone"`
 	Address         string    `json:"address"`
 	Notes           string    `json:"notes"`
+	InvoiceTemplate string    `json:"invoice_template"`
+	ProrationMethod string    `json:"proration_method"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+
+	// 請求書番号の取引先プレフィックス
+	InvoiceNumberPrefix string `json:"invoice_number_prefix"`
 }
 
 // ClientDetailDTO 取引先詳細DTO
@@ -39,6 +46,11 @@ type CreateClientRequest struct {
 	ContactPhone    string `json:"contact_phone" binding:"max=20"`
 	Address         string `json:"address" binding:"max=255"`
 	Notes           string `json:"notes"`
+	InvoiceTemplate string `json:"invoice_template" binding:"max=50"`
+	ProrationMethod string `json:"proration_method" binding:"omitempty,oneof=business_days calendar_days hours"`
+
+	// 請求書番号の取引先プレフィックス
+	InvoiceNumberPrefix string `json:"invoice_number_prefix" binding:"omitempty,alphanum,max=20"`
 }
 
 // UpdateClientRequest 取引先更新リクエスト
@@ -52,23 +64,28 @@ type UpdateClientRequest struct {
 	ContactPhone    *string `json:"contact_phone" binding:"omitempty,max=20"`
 	Address         *string `json:"address" binding:"omitempty,max=255"`
 	Notes           *string `json:"notes"`
+	InvoiceTemplate *string `json:"invoice_template" binding:"omitempty,max=50"`
+	ProrationMethod *string `json:"proration_method" binding:"omitempty,oneof=business_days calendar_days hours"`
+
+	// 請求書番号の取引先プレフィックス（空文字で解除）
+	InvoiceNumberPrefix *string `json:"invoice_number_prefix" binding:"omitempty,alphanum,max=20"`
 }
 
 // ProjectDTO 案件DTO
 type ProjectDTO struct {
-	ID            string     `json:"id"`
-	ClientID      string     `json:"client_id"`
-	ClientName    string     `json:"client_name,omitempty"`
-	ProjectName   string     `json:"project_name"`
-	ProjectCode   string     `json:"project_code"`
-	Status        string     `json:"status"`
-	StartDate     *time.Time `json:"start_date"`
-	EndDate       *time.Time `json:"end_date"`
-	MonthlyRate   float64    `json:"monthly_rate"`
-	ContractType  string     `json:"contract_type"`
-	WorkLocation  string     `json:"work_location"`
-	Description   string     `json:"description"`
-	Requirements  string     `json:"requirements"`
-	AssignedCount int        `json:"assigned_count"`
-	CreatedAt     time.Time  `json:"created_at"`
+	ID            string       `json:"id"`
+	ClientID      string       `json:"client_id"`
+	ClientName    string       `json:"client_name,omitempty"`
+	ProjectName   string       `json:"project_name"`
+	ProjectCode   string       `json:"project_code"`
+	Status        string       `json:"status"`
+	StartDate     *time.Time   `json:"start_date"`
+	EndDate       *time.Time   `json:"end_date"`
+	MonthlyRate   money.Amount `json:"monthly_rate"`
+	ContractType  string       `json:"contract_type"`
+	WorkLocation  string       `json:"work_location"`
+	Description   string       `json:"description"`
+	Requirements  string       `json:"requirements"`
+	AssignedCount int          `json:"assigned_count"`
+	CreatedAt     time.Time    `json:"created_at"`
 }
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ExpenseApprovalRuleRequest 経費申請の承認ルールの作成・更新リクエスト
+// 金額はmin_amount以上・max_amount未満で判定し、条件を省略した項目は全ての経費申請に一致する
+type ExpenseApprovalRuleRequest struct {
+	Name         string                           `json:"name" binding:"required,max=100"`
+	Description  string                           `json:"description" binding:"omitempty,max=1000"`
+	Priority     *int                             `json:"priority" binding:"omitempty,min=1,max=9999"` // 小さいほど優先（既定100）
+	IsActive     *bool                            `json:"is_active"`
+	MinAmount    *int                             `json:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount    *int                             `json:"max_amount" binding:"omitempty,min=1"`
+	CategoryID   *string                          `json:"category_id" binding:"omitempty,max=36"`
+	DepartmentID *string                          `json:"department_id" binding:"omitempty,max=36"`
+	ProjectID    *string                          `json:"project_id" binding:"omitempty,max=36"`
+	AutoApprove  bool                             `json:"auto_approve"`
+	Steps        []ExpenseApprovalRuleStepRequest `json:"steps" binding:"omitempty,max=10,dive"`
+}
+
+// ExpenseApprovalRuleStepRequest 承認ルートの段階（配列の順に承認する）
+type ExpenseApprovalRuleStepRequest struct {
+	ApprovalType string  `json:"approval_type" binding:"required,oneof=manager executive department_head designated"`
+	ApproverID   *string `json:"approver_id"` // designatedの場合に指定
+}
+
+// ExpenseApprovalRuleResponse 経費申請の承認ルールレスポンス
+type ExpenseApprovalRuleResponse struct {
+	ID           string                            `json:"id"`
+	Name         string                            `json:"name"`
+	Description  string                            `json:"description"`
+	Priority     int                               `json:"priority"`
+	IsActive     bool                              `json:"is_active"`
+	MinAmount    *int                              `json:"min_amount"`
+	MaxAmount    *int                              `json:"max_amount"`
+	CategoryID   *string                           `json:"category_id"`
+	DepartmentID *string                           `json:"department_id"`
+	ProjectID    *string                           `json:"project_id"`
+	AutoApprove  bool                              `json:"auto_approve"`
+	Steps        []ExpenseApprovalRuleStepResponse `json:"steps"`
+	CreatedBy    string                            `json:"created_by"`
+	CreatedAt    time.Time                         `json:"created_at"`
+	UpdatedAt    time.Time                         `json:"updated_at"`
+}
+
+// ExpenseApprovalRuleStepResponse 承認ルートの段階レスポンス
+type ExpenseApprovalRuleStepResponse struct {
+	StepOrder    int     `json:"step_order"`
+	ApprovalType string  `json:"approval_type"`
+	ApproverID   *string `json:"approver_id"`
+}
+
+// ExpenseApprovalRulesResponse 経費申請の承認ルール一覧レスポンス
+type ExpenseApprovalRulesResponse struct {
+	Rules []ExpenseApprovalRuleResponse `json:"rules"`
+}
+
+// FromModel モデルからレスポンスに変換
+func (r *ExpenseApprovalRuleResponse) FromModel(rule *model.ExpenseApprovalRule) {
+	r.ID = rule.ID
+	r.Name = rule.Name
+	r.Description = rule.Description
+	r.Priority = rule.Priority
+	r.IsActive = rule.IsActive
+	r.MinAmount = rule.MinAmount
+	r.MaxAmount = rule.MaxAmount
+	r.CategoryID = rule.CategoryID
+	r.DepartmentID = rule.DepartmentID
+	r.ProjectID = rule.ProjectID
+	r.AutoApprove = rule.AutoApprove
+	r.CreatedBy = rule.CreatedBy
+	r.CreatedAt = rule.CreatedAt
+	r.UpdatedAt = rule.UpdatedAt
+
+	r.Steps = make([]ExpenseApprovalRuleStepResponse, 0, len(rule.Steps))
+	for _, step := range rule.Steps {
+		r.Steps = append(r.Steps, ExpenseApprovalRuleStepResponse{
+			StepOrder:    step.StepOrder,
+			ApprovalType: string(step.ApprovalType),
+			ApproverID:   step.ApproverID,
+		})
+	}
+}
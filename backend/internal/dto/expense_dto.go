@@ -19,6 +19,7 @@ type CreateExpenseRequest struct {
 	ReceiptURL    string    `json:"receipt_url" binding:"omitempty,url"`                                                    // 領収書URL
 	ReceiptURLs   []string  `json:"receipt_urls" binding:"omitempty,dive,url"`                                              // 領収書URL（複数）
 	OtherCategory string    `json:"other_category,omitempty" binding:"omitempty,max=100"`                                   // その他カテゴリの詳細
+	ProjectID     *string   `json:"project_id,omitempty" binding:"omitempty,max=36"`                                        // 経費を計上するプロジェクト（承認ルートの判定に使用）
 }
 
 // UpdateExpenseRequest 経費申請更新リクエスト
@@ -32,7 +33,8 @@ type UpdateExpenseRequest struct {
 	ReceiptURL    *string    `json:"receipt_url,omitempty" binding:"omitempty,url"`
 	ReceiptURLs   []string   `json:"receipt_urls,omitempty" binding:"omitempty,dive,url"`
 	OtherCategory *string    `json:"other_category,omitempty" binding:"omitempty,max=100"`
-	Version       int        `json:"version" binding:"required,min=1"` // 楽観的ロック用
+	ProjectID     *string    `json:"project_id,omitempty" binding:"omitempty,max=36"` // 空文字で解除
+	Version       int        `json:"version" binding:"required,min=1"`                // 楽観的ロック用
 }
 
 // SubmitExpenseRequest 経費申請提出リクエスト
@@ -97,6 +99,27 @@ type ApprovalResponse struct {
 	CreatedAt     time.Time  `json:"created_at"`
 	// 承認者情報
 	Approver *UserSummary `json:"approver,omitempty"`
+	// 代理承認の場合の本来の承認者（approver_idが代理承認者）
+	OnBehalfOfID *string `json:"on_behalf_of_id,omitempty"`
+	// 仮払金の承認の場合の仮払金ID（expense_idは空）
+	CashAdvanceID *string `json:"cash_advance_id,omitempty"`
+}
+
+// FromModel ExpenseApprovalモデルからレスポンスに変換
+func (r *ApprovalResponse) FromModel(approval *model.ExpenseApproval) {
+	r.ID = approval.ID
+	if approval.ExpenseID != nil {
+		r.ExpenseID = *approval.ExpenseID
+	}
+	r.ApproverID = approval.ApproverID
+	r.ApprovalType = string(approval.ApprovalType)
+	r.ApprovalOrder = approval.ApprovalOrder
+	r.Status = string(approval.Status)
+	r.Comment = approval.Comment
+	r.ApprovedAt = approval.ApprovedAt
+	r.CreatedAt = approval.CreatedAt
+	r.OnBehalfOfID = approval.OnBehalfOfID
+	r.CashAdvanceID = approval.CashAdvanceID
 }
 
 // CategoryMasterResponse カテゴリマスタレスポンス
@@ -336,18 +359,8 @@ func (r *ExpenseDetailResponse) FromExpenseWithDetails(expenseWithDetails model.
 	// 承認履歴を変換
 	if len(expenseWithDetails.Approvals) > 0 {
 		r.Approvals = make([]ApprovalResponse, len(expenseWithDetails.Approvals))
-		for i, approval := range expenseWithDetails.Approvals {
-			r.Approvals[i] = ApprovalResponse{
-				ID:            approval.ID,
-				ExpenseID:     approval.ExpenseID,
-				ApproverID:    approval.ApproverID,
-				ApprovalType:  string(approval.ApprovalType),
-				ApprovalOrder: approval.ApprovalOrder,
-				Status:        string(approval.Status),
-				Comment:       approval.Comment,
-				ApprovedAt:    approval.ApprovedAt,
-				CreatedAt:     approval.CreatedAt,
-			}
+		for i := range expenseWithDetails.Approvals {
+			r.Approvals[i].FromModel(&expenseWithDetails.Approvals[i])
 		}
 	}
 
@@ -677,6 +690,9 @@ type CompleteUploadResponse struct {
 	S3Key      string    `json:"s3_key"`      // S3オブジェクトキー
 	FileSize   int64     `json:"file_size"`   // ファイルサイズ
 	CreatedAt  time.Time `json:"created_at"`  // 作成日時
+	// 重複の可能性（提出済みの経費申請に同じ領収書がある）
+	PossibleDuplicate bool   `json:"possible_duplicate"`
+	DuplicateMessage  string `json:"duplicate_message,omitempty"`
 }
 
 // DeleteUploadRequest ファイル削除リクエスト
@@ -692,6 +708,8 @@ type DeleteUploadRequest struct {
 type ApproveExpenseRequest struct {
 	Comment string `json:"comment,omitempty" binding:"omitempty,max=500"` // 承認コメント
 	Version int    `json:"version" binding:"required,min=1"`              // 楽観的ロック用
+	// 重複の可能性がある経費申請を承認する理由（重複フラグがある場合は必須）
+	DuplicateOverrideReason string `json:"duplicate_override_reason,omitempty" binding:"omitempty,max=500"`
 }
 
 // RejectExpenseRequest 経費却下リクエスト
@@ -726,6 +744,13 @@ type ApprovalListResponse struct {
 	TotalPages int                    `json:"total_pages"` // 総ページ数
 }
 
+const (
+	// ApprovalRequestTypeExpense 承認待ちの経費申請
+	ApprovalRequestTypeExpense = "expense"
+	// ApprovalRequestTypeCashAdvance 承認待ちの仮払金
+	ApprovalRequestTypeCashAdvance = "cash_advance"
+)
+
 // ApprovalItemResponse 承認待ち項目レスポンス
 type ApprovalItemResponse struct {
 	ApprovalID    string       `json:"approval_id"`    // 承認ID
@@ -742,6 +767,50 @@ type ApprovalItemResponse struct {
 	ReceiptURLs   []string     `json:"receipt_urls"`   // 領収書URL
 	// 前の承認情報（2段階承認の場合）
 	PreviousApproval *PreviousApprovalInfo `json:"previous_approval,omitempty"`
+	// 代理承認の場合の本来の承認者
+	OnBehalfOf *UserSummary `json:"on_behalf_of,omitempty"`
+	// 重複の可能性（未確認のフラグがある場合は承認に理由が必要）
+	DuplicateFlags            []ExpenseDuplicateFlagResponse `json:"duplicate_flags,omitempty"`
+	RequiresDuplicateOverride bool                           `json:"requires_duplicate_override"`
+	// 申請種別（expense:経費申請 cash_advance:仮払金）
+	RequestType string `json:"request_type"`
+	// 仮払金の承認の場合の仮払金ID（expense_idは空）
+	CashAdvanceID *string `json:"cash_advance_id,omitempty"`
+}
+
+// ExpenseDuplicateFlagResponse 経費申請の重複の可能性
+type ExpenseDuplicateFlagResponse struct {
+	ID                 string     `json:"id"`
+	Reason             string     `json:"reason"`               // same_receipt/similar_receipt/similar_expense
+	DuplicateExpenseID string     `json:"duplicate_expense_id"` // 重複の可能性がある経費申請ID
+	DuplicateTitle     string     `json:"duplicate_title"`      // 重複の可能性がある経費申請の件名
+	DuplicateAmount    int        `json:"duplicate_amount"`     // 重複の可能性がある経費申請の金額
+	DuplicateDate      *time.Time `json:"duplicate_date"`       // 重複の可能性がある経費申請の使用日
+	DuplicateStatus    string     `json:"duplicate_status"`     // 重複の可能性がある経費申請のステータス
+	SameApplicant      bool       `json:"same_applicant"`       // 同じ申請者の経費申請か
+	DetectedAt         time.Time  `json:"detected_at"`
+	OverrideReason     *string    `json:"override_reason,omitempty"` // 承認者が入力した承認理由
+	OverriddenBy       *string    `json:"overridden_by,omitempty"`
+	OverriddenAt       *time.Time `json:"overridden_at,omitempty"`
+}
+
+// FromModel モデルからレスポンスに変換
+func (r *ExpenseDuplicateFlagResponse) FromModel(flag *model.ExpenseDuplicateFlag, applicantID string) {
+	r.ID = flag.ID
+	r.Reason = string(flag.Reason)
+	r.DuplicateExpenseID = flag.DuplicateExpenseID
+	r.DetectedAt = flag.DetectedAt
+	r.OverrideReason = flag.OverrideReason
+	r.OverriddenBy = flag.OverriddenBy
+	r.OverriddenAt = flag.OverriddenAt
+	if flag.DuplicateExpense != nil {
+		expenseDate := flag.DuplicateExpense.ExpenseDate
+		r.DuplicateTitle = flag.DuplicateExpense.Title
+		r.DuplicateAmount = flag.DuplicateExpense.Amount
+		r.DuplicateDate = &expenseDate
+		r.DuplicateStatus = string(flag.DuplicateExpense.Status)
+		r.SameApplicant = flag.DuplicateExpense.UserID == applicantID
+	}
 }
 
 // PreviousApprovalInfo 前の承認情報
@@ -1028,9 +1097,13 @@ const (
 
 	// 承認者設定関連エラーコード
 	ErrCodeNoApproversConfigured = "EXPENSE_NO_APPROVERS_CONFIGURED"
+	ErrCodeApprovalRuleNotFound  = "EXPENSE_APPROVAL_RULE_NOT_FOUND"
 
 	// 期限関連エラーコード
 	ErrCodeDeadlineExceeded = "EXPENSE_DEADLINE_EXCEEDED"
+
+	// 重複検出関連エラーコード
+	ErrCodeDuplicateOverrideRequired = "EXPENSE_DUPLICATE_OVERRIDE_REQUIRED"
 )
 
 // ExpenseLimitSettingResponse 経費申請上限設定レスポンス
@@ -36,6 +36,7 @@ type CreateExpenseWithReceiptsRequest struct {
 	ExpenseDate   time.Time                     `json:"expense_date" binding:"required"`
 	Description   string                        `json:"description" binding:"required,min=10,max=1000"`
 	OtherCategory string                        `json:"other_category,omitempty" binding:"omitempty,max=100"`
+	ProjectID     *string                       `json:"project_id,omitempty" binding:"omitempty,max=36"`
 	Receipts      []CreateExpenseReceiptRequest `json:"receipts" binding:"required,min=1,max=10,dive"`
 }
 
@@ -48,6 +49,7 @@ type UpdateExpenseWithReceiptsRequest struct {
 	ExpenseDate   *time.Time                    `json:"expense_date,omitempty" binding:"omitempty"`
 	Description   *string                       `json:"description,omitempty" binding:"omitempty,min=10,max=1000"`
 	OtherCategory *string                       `json:"other_category,omitempty" binding:"omitempty,max=100"`
+	ProjectID     *string                       `json:"project_id,omitempty" binding:"omitempty,max=36"` // 空文字で解除
 	Receipts      []CreateExpenseReceiptRequest `json:"receipts,omitempty" binding:"omitempty,max=10,dive"`
 	Version       int                           `json:"version" binding:"required,min=1"`
 }
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+)
+
+// BankAccountRequest 振込先口座の登録リクエスト
+type BankAccountRequest struct {
+	BankCode      string `json:"bank_code" binding:"required,len=4,numeric"`
+	BankName      string `json:"bank_name" binding:"required,max=30"` // カナ
+	BranchCode    string `json:"branch_code" binding:"required,len=3,numeric"`
+	BranchName    string `json:"branch_name" binding:"required,max=30"` // カナ
+	AccountType   string `json:"account_type" binding:"required,oneof=1 2 4"`
+	AccountNumber string `json:"account_number" binding:"required,max=7,numeric"`
+	AccountHolder string `json:"account_holder" binding:"required,max=30"` // カナ
+}
+
+// BankAccountDTO 振込先口座（口座番号は下4桁のみ）
+type BankAccountDTO struct {
+	BankCode      string    `json:"bank_code"`
+	BankName      string    `json:"bank_name"`
+	BranchCode    string    `json:"branch_code"`
+	BranchName    string    `json:"branch_name"`
+	AccountType   string    `json:"account_type"`
+	AccountNumber string    `json:"account_number"` // 例: ***4567
+	AccountHolder string    `json:"account_holder"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CreateReimbursementBatchRequest 経費精算の振込バッチ作成リクエスト
+type CreateReimbursementBatchRequest struct {
+	PaymentDate    string `json:"payment_date" binding:"required"` // 振込日（YYYY-MM-DD）
+	ApprovedBefore string `json:"approved_before"`                 // この日までに承認された経費のみ対象（YYYY-MM-DD、任意）
+}
+
+// ReimbursementBatchDTO 経費精算の振込バッチ
+type ReimbursementBatchDTO struct {
+	ID            string                    `json:"id"`
+	PaymentDate   string                    `json:"payment_date"` // YYYY-MM-DD
+	Status        string                    `json:"status"`
+	TotalAmount   int64                     `json:"total_amount"`
+	EmployeeCount int                       `json:"employee_count"`
+	ExpenseCount  int                       `json:"expense_count"`
+	CreatedBy     string                    `json:"created_by"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	ConfirmedAt   *time.Time                `json:"confirmed_at"`
+	CancelledAt   *time.Time                `json:"cancelled_at"`
+	Items         []ReimbursementItemDTO    `json:"items,omitempty"`
+	Skipped       []ReimbursementSkippedDTO `json:"skipped,omitempty"` // 作成時のみ（振込口座が未登録の社員）
+}
+
+// ReimbursementItemDTO 振込バッチの社員ごとの振込
+type ReimbursementItemDTO struct {
+	BatchID       string `json:"batch_id"`
+	PaymentDate   string `json:"payment_date"`
+	BatchStatus   string `json:"batch_status"`
+	UserID        string `json:"user_id"`
+	UserName      string `json:"user_name"`
+	Amount        int64  `json:"amount"`
+	ExpenseCount  int    `json:"expense_count"`
+	BankName      string `json:"bank_name"`
+	BranchName    string `json:"branch_name"`
+	AccountType   string `json:"account_type"`
+	AccountNumber string `json:"account_number"` // 下4桁のみ
+}
+
+// ReimbursementSkippedDTO 振込口座が未登録のため振込バッチに含めなかった社員
+type ReimbursementSkippedDTO struct {
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+	Amount       int64  `json:"amount"`
+	ExpenseCount int    `json:"expense_count"`
+}
+
+// ReimbursementStatementDTO 社員ごとの精算明細
+type ReimbursementStatementDTO struct {
+	ReimbursementItemDTO
+	Expenses []ReimbursementStatementLineDTO `json:"expenses"`
+}
+
+// ReimbursementStatementLineDTO 精算明細の経費1件
+type ReimbursementStatementLineDTO struct {
+	ExpenseID    string     `json:"expense_id"`
+	ExpenseDate  time.Time  `json:"expense_date"`
+	Title        string     `json:"title"`
+	CategoryName string     `json:"category_name"`
+	Amount       int64      `json:"amount"`
+	ApprovedAt   *time.Time `json:"approved_at"`
+}
+
+// ReimbursementTransferFile 総合振込ファイル
+type ReimbursementTransferFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
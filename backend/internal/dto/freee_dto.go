@@ -123,6 +123,7 @@ type FreeeInvoiceDTO struct {
 	InvoiceLayout      string                   `json:"invoice_layout,omitempty"`
 	TaxEntryMethod     string                   `json:"tax_entry_method,omitempty"`
 	InvoiceContents    []FreeeInvoiceContentDTO `json:"invoice_contents,omitempty"`
+	DealID             *int                     `json:"deal_id,omitempty"` // 入金情報を保持する取引ID
 }
 
 // FreeeInvoiceContentDTO freee請求書明細DTO
@@ -151,12 +152,23 @@ type FreeePaymentDTO struct {
 	ID                  int    `json:"id"`
 	CompanyID           int    `json:"company_id"`
 	InvoiceID           int    `json:"invoice_id"`
+	DealID              int    `json:"deal_id,omitempty"`
 	Date                string `json:"date"`
 	Amount              int    `json:"amount"`
+	FromWalletableType  string `json:"from_walletable_type,omitempty"` // bank_account, credit_card, wallet
 	FromWalletAccountID int    `json:"from_wallet_account_id"`
 	Description         string `json:"description,omitempty"`
 }
 
+// CreateFreeePaymentRequest freee入金登録リクエスト
+type CreateFreeePaymentRequest struct {
+	InvoiceID           int    `json:"invoice_id" binding:"required"`
+	Date                string `json:"date" binding:"required"`
+	Amount              int    `json:"amount" binding:"required,min=1"`
+	FromWalletableType  string `json:"from_walletable_type" binding:"required,oneof=bank_account credit_card wallet"`
+	FromWalletAccountID int    `json:"from_wallet_account_id" binding:"required"`
+}
+
 // CreateFreeePartnerRequest freee取引先作成リクエスト
 type CreateFreeePartnerRequest struct {
 	CompanyID                          int                      `json:"company_id" binding:"required"`
@@ -281,6 +293,8 @@ type FreeeInvoiceSyncResultDTO struct {
 	FreeeInvoiceURL *string `json:"freee_invoice_url,omitempty"`
 	Error           *string `json:"error,omitempty"`
 	Details         *string `json:"details,omitempty"`
+
+	TaxSummaries []InvoiceTaxSummaryDTO `json:"tax_summaries,omitempty"` // 連携した税率別小計
 }
 
 // FreeePaymentSyncRequest freee入金同期リクエスト
@@ -362,6 +376,7 @@ type FreeeStatusDTO struct {
 
 // FreeeWebhookRequest freeeWebhookリクエスト
 type FreeeWebhookRequest struct {
+	EventID      string                 `json:"event_id" binding:"required"` // 再送時も同じIDが送られる
 	CompanyID    int                    `json:"company_id"`
 	EventType    string                 `json:"event_type"` // invoice.created, invoice.updated, payment.created, etc.
 	ResourceID   int                    `json:"resource_id"`
@@ -372,7 +387,7 @@ type FreeeWebhookRequest struct {
 
 // FreeeWebhookResponse freeeWebhookレスポンス
 type FreeeWebhookResponse struct {
-	Status      string    `json:"status"` // processed, ignored, error
+	Status      string    `json:"status"` // processed, ignored
 	Message     string    `json:"message,omitempty"`
 	ProcessedAt time.Time `json:"processed_at"`
 }
@@ -535,3 +550,17 @@ type FreeBatchSyncResult struct {
 	SyncResults     []FreeSyncResult `json:"sync_results"`
 	Summary         map[string]int   `json:"summary"`
 }
+
+// FreeeSyncEnqueueRequest freee同期キューへの投入リクエスト
+type FreeeSyncEnqueueRequest struct {
+	TargetType string   `json:"target_type" binding:"required,oneof=invoice partner"`
+	TargetIDs  []string `json:"target_ids" binding:"required,min=1,max=500"`
+}
+
+// FreeeSyncEnqueueResponse freee同期キューへの投入結果
+type FreeeSyncEnqueueResponse struct {
+	QueuedCount   int      `json:"queued_count"`
+	SkippedIDs    []string `json:"skipped_ids,omitempty"` // 同じ対象のジョブが実行待ち・実行中
+	CompanyID     int      `json:"company_id"`
+	TotalRequests int      `json:"total_requests"`
+}
@@ -2,24 +2,45 @@ package dto
 
 import (
 	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // InvoiceDTO 請求書DTO
 type InvoiceDTO struct {
-	ID            string     `json:"id"`
-	ClientID      string     `json:"client_id"`
-	ClientName    string     `json:"client_name"`
-	InvoiceNumber string     `json:"invoice_number"`
-	InvoiceDate   time.Time  `json:"invoice_date"`
-	DueDate       time.Time  `json:"due_date"`
-	Status        string     `json:"status"`
-	Subtotal      float64    `json:"subtotal"`
-	Tax           float64    `json:"tax"`
-	TotalAmount   float64    `json:"total_amount"`
-	Notes         string     `json:"notes"`
-	PaymentDate   *time.Time `json:"payment_date"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID            string       `json:"id"`
+	ClientID      string       `json:"client_id"`
+	ClientName    string       `json:"client_name"`
+	InvoiceNumber string       `json:"invoice_number"`
+	InvoiceDate   time.Time    `json:"invoice_date"`
+	DueDate       time.Time    `json:"due_date"`
+	Status        string       `json:"status"`
+	Subtotal      money.Amount `json:"subtotal"`
+	Tax           money.Amount `json:"tax"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	Notes         string       `json:"notes"`
+	PaymentDate   *time.Time   `json:"payment_date"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+
+	// 適格請求書の記載事項
+	RegistrationNumber string                 `json:"registration_number"`
+	TaxSummaries       []InvoiceTaxSummaryDTO `json:"tax_summaries"`
+
+	// 赤伝・訂正再発行
+	InvoiceType       string  `json:"invoice_type"`
+	OriginalInvoiceID *string `json:"original_invoice_id"`
+	Revision          int     `json:"revision"`
+}
+
+// InvoiceTaxSummaryDTO 税率別小計DTO
+type InvoiceTaxSummaryDTO struct {
+	TaxCategory   string       `json:"tax_category"`
+	Label         string       `json:"label"`
+	TaxRate       float64      `json:"tax_rate"`
+	TaxableAmount money.Amount `json:"taxable_amount"`
+	TaxAmount     money.Amount `json:"tax_amount"`
 }
 
 // InvoiceDetailDTO 請求書詳細DTO
@@ -30,23 +51,28 @@ type InvoiceDetailDTO struct {
 
 // InvoiceItemDTO 請求明細DTO
 type InvoiceItemDTO struct {
-	ID          string  `json:"id"`
-	InvoiceID   string  `json:"invoice_id"`
-	ProjectID   *string `json:"project_id"`
-	ProjectName string  `json:"project_name,omitempty"`
-	UserID      *string `json:"user_id"`
-	UserName    string  `json:"user_name,omitempty"`
-	Description string  `json:"description"`
-	Quantity    float64 `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
-	Amount      float64 `json:"amount"`
-	OrderIndex  int     `json:"order_index"`
+	ID          string       `json:"id"`
+	InvoiceID   string       `json:"invoice_id"`
+	ProjectID   *string      `json:"project_id"`
+	ProjectName string       `json:"project_name,omitempty"`
+	UserID      *string      `json:"user_id"`
+	UserName    string       `json:"user_name,omitempty"`
+	Description string       `json:"description"`
+	Quantity    float64      `json:"quantity"`
+	UnitPrice   money.Amount `json:"unit_price"`
+	Amount      money.Amount `json:"amount"`
+	TaxCategory string       `json:"tax_category"`
+	TaxRate     float64      `json:"tax_rate"`
+	OrderIndex  int          `json:"order_index"`
+
+	ActualHours   *float64                    `json:"actual_hours,omitempty"`
+	HoursEvidence *model.BillingHoursEvidence `json:"hours_evidence,omitempty"` // 精算に使った週報と稼働時間
 }
 
 // CreateInvoiceRequest 請求書作成リクエスト
 type CreateInvoiceRequest struct {
 	ClientID      string                     `json:"client_id" binding:"required"`
-	InvoiceNumber string                     `json:"invoice_number" binding:"required,max=50"`
+	InvoiceNumber string                     `json:"invoice_number" binding:"omitempty,max=50"` // 未指定時は採番パターンで払い出す
 	InvoiceDate   time.Time                  `json:"invoice_date" binding:"required"`
 	DueDate       time.Time                  `json:"due_date" binding:"required"`
 	Notes         string                     `json:"notes"`
@@ -55,18 +81,20 @@ type CreateInvoiceRequest struct {
 
 // CreateInvoiceItemRequest 請求明細作成リクエスト
 type CreateInvoiceItemRequest struct {
-	ProjectID   *string `json:"project_id"`
-	UserID      *string `json:"user_id"`
-	Description string  `json:"description" binding:"required,max=200"`
-	Quantity    float64 `json:"quantity" binding:"required,min=0"`
-	UnitPrice   float64 `json:"unit_price" binding:"required,min=0"`
+	ProjectID   *string      `json:"project_id"`
+	UserID      *string      `json:"user_id"`
+	Description string       `json:"description" binding:"required,max=200"`
+	Quantity    float64      `json:"quantity" binding:"required,min=0"`
+	UnitPrice   money.Amount `json:"unit_price" binding:"required,min=0"`
+	TaxCategory string       `json:"tax_category" binding:"omitempty,oneof=standard_10 reduced_8 exempt non_taxable"` // 未指定時は標準税率10%
 }
 
 // UpdateInvoiceRequest 請求書更新リクエスト
 type UpdateInvoiceRequest struct {
-	InvoiceDate *time.Time `json:"invoice_date"`
-	DueDate     *time.Time `json:"due_date"`
-	Notes       *string    `json:"notes"`
+	InvoiceDate *time.Time                 `json:"invoice_date"`
+	DueDate     *time.Time                 `json:"due_date"`
+	Notes       *string                    `json:"notes"`
+	Details     []CreateInvoiceItemRequest `json:"details" binding:"omitempty,min=1,dive"` // 指定時は明細を置き換えて税額を再計算
 }
 
 // UpdateInvoiceStatusRequest 請求書ステータス更新リクエスト
@@ -77,22 +105,51 @@ type UpdateInvoiceStatusRequest struct {
 
 // InvoiceSearchRequest 請求書検索リクエスト
 type InvoiceSearchRequest struct {
-	ClientID *string    `form:"client_id"`
-	Status   string     `form:"status"`
-	DateFrom *time.Time `form:"date_from"`
-	DateTo   *time.Time `form:"date_to"`
-	Page     int        `form:"page"`
-	Limit    int        `form:"limit"`
+	ClientID    *string    `form:"client_id"`
+	Status      string     `form:"status"`
+	InvoiceType string     `form:"invoice_type"`
+	DateFrom    *time.Time `form:"date_from"`
+	DateTo      *time.Time `form:"date_to"`
+	Page        int        `form:"page"`
+	Limit       int        `form:"limit"`
 }
 
 // InvoiceSummaryDTO 請求書サマリDTO
 type InvoiceSummaryDTO struct {
-	TotalAmount   float64 `json:"total_amount"`
-	PaidAmount    float64 `json:"paid_amount"`
-	UnpaidAmount  float64 `json:"unpaid_amount"`
-	OverdueAmount float64 `json:"overdue_amount"`
-	DraftCount    int     `json:"draft_count"`
-	SentCount     int     `json:"sent_count"`
-	PaidCount     int     `json:"paid_count"`
-	OverdueCount  int     `json:"overdue_count"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	PaidAmount    money.Amount `json:"paid_amount"`
+	UnpaidAmount  money.Amount `json:"unpaid_amount"`
+	OverdueAmount money.Amount `json:"overdue_amount"`
+	DraftCount    int          `json:"draft_count"`
+	SentCount     int          `json:"sent_count"`
+	PaidCount     int          `json:"paid_count"`
+	OverdueCount  int          `json:"overdue_count"`
+
+	// 赤伝（取消分はマイナス金額として合計に含まれる）
+	CreditNoteAmount money.Amount `json:"credit_note_amount"`
+	CreditNoteCount  int          `json:"credit_note_count"`
+}
+
+// CancelInvoiceRequest 発行済み請求書の取消リクエスト（赤伝を発行する）
+type CancelInvoiceRequest struct {
+	Reason    string     `json:"reason" binding:"required,max=200"`
+	IssueDate *time.Time `json:"issue_date"` // 赤伝の発行日（未指定時は当日）
+}
+
+// ReviseInvoiceRequest 発行済み請求書の訂正リクエスト
+// 元の請求書を赤伝で取り消し、訂正後の請求書を新しい番号の下書きとして作成する
+type ReviseInvoiceRequest struct {
+	Reason        string                     `json:"reason" binding:"required,max=200"`
+	InvoiceNumber string                     `json:"invoice_number" binding:"omitempty,max=50"` // 未指定時は採番パターンで払い出す
+	InvoiceDate   *time.Time                 `json:"invoice_date"`                              // 未指定時は元の請求書を引き継ぐ
+	DueDate       *time.Time                 `json:"due_date"`
+	Notes         *string                    `json:"notes"`
+	Details       []CreateInvoiceItemRequest `json:"details" binding:"omitempty,min=1,dive"` // 未指定時は元の明細を引き継ぐ
+}
+
+// InvoiceRevisionResultDTO 取消・訂正の結果DTO
+type InvoiceRevisionResultDTO struct {
+	Original   InvoiceDTO  `json:"original"`
+	CreditNote InvoiceDTO  `json:"credit_note"`
+	Revised    *InvoiceDTO `json:"revised,omitempty"`
 }
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DunningScheduleDTO 督促スケジュールDTO
+type DunningScheduleDTO struct {
+	Enabled   bool             `json:"enabled"` // 無効の場合は期限超過への更新のみ行い、督促メールは送らない
+	Steps     []DunningStepDTO `json:"steps"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+}
+
+// DunningStepDTO 督促ステップDTO
+type DunningStepDTO struct {
+	Type         string `json:"type"`
+	DaysAfterDue int    `json:"days_after_due"`
+}
+
+// UpdateDunningScheduleRequest 督促スケジュール更新リクエスト
+type UpdateDunningScheduleRequest struct {
+	Enabled *bool                   `json:"enabled" binding:"required"`
+	Steps   []DunningStepRequestDTO `json:"steps" binding:"required,min=1,dive"`
+}
+
+// DunningStepRequestDTO 督促ステップの指定
+type DunningStepRequestDTO struct {
+	Type         string `json:"type" binding:"required,oneof=reminder second_notice escalation"`
+	DaysAfterDue int    `json:"days_after_due" binding:"min=1"`
+}
+
+// DunningRunResultDTO 期限超過検知・督促の実行結果DTO
+type DunningRunResultDTO struct {
+	MarkedOverdue  int64 `json:"marked_overdue"` // 期限超過に更新した請求書数
+	DunningEnabled bool  `json:"dunning_enabled"`
+	TargetCount    int   `json:"target_count"` // 督促対象の期限超過請求書数
+	SentCount      int   `json:"sent_count"`
+	SkippedCount   int   `json:"skipped_count"` // 次のステップの実行日に達していない
+	FailedCount    int   `json:"failed_count"`
+}
+
+// InvoiceTimelineEntryDTO 請求書タイムラインDTO
+type InvoiceTimelineEntryDTO struct {
+	ID        string          `json:"id"`
+	Action    string          `json:"action"`
+	OldValues json.RawMessage `json:"old_values,omitempty"`
+	NewValues json.RawMessage `json:"new_values,omitempty"`
+	Notes     string          `json:"notes,omitempty"`
+	ChangedBy *string         `json:"changed_by"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
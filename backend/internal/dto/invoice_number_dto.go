@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// InvoiceNumberLedgerRequest 請求書番号台帳の検索リクエスト
+type InvoiceNumberLedgerRequest struct {
+	FiscalYear int    `form:"fiscal_year" binding:"omitempty,min=2000,max=2100"`
+	Status     string `form:"status" binding:"omitempty,oneof=allocated voided"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+}
+
+// InvoiceNumberLedgerDTO 請求書番号台帳DTO
+type InvoiceNumberLedgerDTO struct {
+	Pattern              string                       `json:"pattern"`
+	FiscalYearStartMonth int                          `json:"fiscal_year_start_month"`
+	Sequences            []InvoiceNumberSequenceDTO   `json:"sequences"`
+	Allocations          []InvoiceNumberAllocationDTO `json:"allocations"`
+	Total                int64                        `json:"total"`
+	Page                 int                          `json:"page"`
+	Limit                int                          `json:"limit"`
+}
+
+// InvoiceNumberSequenceDTO 採番範囲ごとの払い出し状況DTO
+// MissingCountが0でなければ台帳に記録のない連番がある
+type InvoiceNumberSequenceDTO struct {
+	ScopeKey       string `json:"scope_key"`
+	FiscalYear     int    `json:"fiscal_year"`
+	LastValue      int    `json:"last_value"`
+	AllocatedCount int    `json:"allocated_count"`
+	VoidedCount    int    `json:"voided_count"`
+	MissingCount   int    `json:"missing_count"`
+}
+
+// InvoiceNumberAllocationDTO 払い出した請求書番号DTO
+type InvoiceNumberAllocationDTO struct {
+	InvoiceNumber string     `json:"invoice_number"`
+	FiscalYear    int        `json:"fiscal_year"`
+	Sequence      int        `json:"sequence"`
+	Status        string     `json:"status"`
+	InvoiceID     *string    `json:"invoice_id"`
+	ClientID      *string    `json:"client_id"`
+	AllocatedBy   string     `json:"allocated_by"`
+	AllocatedAt   time.Time  `json:"allocated_at"`
+	VoidReason    *string    `json:"void_reason,omitempty"`
+	VoidedBy      *string    `json:"voided_by,omitempty"`
+	VoidedAt      *time.Time `json:"voided_at,omitempty"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// JournalAccountMappingDTO 仕訳の勘定科目設定
+type JournalAccountMappingDTO struct {
+	MappingKey        string     `json:"mapping_key"`
+	Label             string     `json:"label"`
+	Configured        bool       `json:"configured"` // 未登録の経費カテゴリはfalse
+	DebitAccount      string     `json:"debit_account"`
+	DebitSubAccount   string     `json:"debit_sub_account"`
+	DebitTaxCategory  string     `json:"debit_tax_category"`
+	CreditAccount     string     `json:"credit_account"`
+	CreditSubAccount  string     `json:"credit_sub_account"`
+	CreditTaxCategory string     `json:"credit_tax_category"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
+
+// JournalAccountMappingRequest 仕訳の勘定科目設定の更新リクエスト
+type JournalAccountMappingRequest struct {
+	DebitAccount      string `json:"debit_account" binding:"required,max=100"`
+	DebitSubAccount   string `json:"debit_sub_account" binding:"max=100"`
+	DebitTaxCategory  string `json:"debit_tax_category" binding:"max=50"`
+	CreditAccount     string `json:"credit_account" binding:"required,max=100"`
+	CreditSubAccount  string `json:"credit_sub_account" binding:"max=100"`
+	CreditTaxCategory string `json:"credit_tax_category" binding:"max=50"`
+}
+
+// JournalEntriesRequest 仕訳一覧・出力のリクエスト
+type JournalEntriesRequest struct {
+	Month  string `form:"month" binding:"required"`   // YYYY-MM
+	Format string `form:"format" binding:"omitempty"` // 出力時のみ（freee, moneyforward, yayoi）
+}
+
+// JournalEntriesResponse 対象月の仕訳
+type JournalEntriesResponse struct {
+	Month       string               `json:"month"`
+	Locked      bool                 `json:"locked"` // 締め済みの仕訳か（falseの場合は現時点の集計）
+	EntryCount  int                  `json:"entry_count"`
+	TotalAmount int64                `json:"total_amount"` // 借方合計（円）
+	Entries     []model.JournalEntry `json:"entries"`
+}
+
+// JournalExportFile 出力した仕訳ファイル
+type JournalExportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	Export      model.JournalExport
+}
+
+// CloseJournalMonthRequest 仕訳の月次締めリクエスト
+type CloseJournalMonthRequest struct {
+	Month string `json:"month" binding:"required"` // YYYY-MM
+}
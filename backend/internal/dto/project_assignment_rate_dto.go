@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// ProjectAssignmentRateRequest 単価履歴の登録・変更リクエスト
+type ProjectAssignmentRateRequest struct {
+	EffectiveFrom string       `json:"effective_from" binding:"required"` // YYYY-MM-DD
+	EffectiveTo   *string      `json:"effective_to"`                      // YYYY-MM-DD（省略時は終了日なし）
+	BillingType   string       `json:"billing_type" binding:"required,oneof=fixed variable_upper_lower variable_middle"`
+	BillingRate   money.Amount `json:"billing_rate"`
+	MinHours      *float64     `json:"min_hours"`
+	MaxHours      *float64     `json:"max_hours"`
+	Notes         string       `json:"notes"`
+	Reason        string       `json:"reason"` // 変更理由（監査ログに記録）
+}
+
+// DeleteProjectAssignmentRateRequest 単価履歴の削除リクエスト
+type DeleteProjectAssignmentRateRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ProjectAssignmentRateDTO 単価履歴DTO
+type ProjectAssignmentRateDTO struct {
+	ID            string       `json:"id"`
+	AssignmentID  string       `json:"assignment_id"`
+	EffectiveFrom string       `json:"effective_from"`
+	EffectiveTo   *string      `json:"effective_to"`
+	BillingType   string       `json:"billing_type"`
+	BillingRate   money.Amount `json:"billing_rate"`
+	MinHours      *float64     `json:"min_hours"`
+	MaxHours      *float64     `json:"max_hours"`
+	Notes         string       `json:"notes"`
+	CreatedBy     string       `json:"created_by"`
+	UpdatedBy     string       `json:"updated_by"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// ProjectAssignmentRateAuditLogDTO 単価履歴の変更ログDTO
+type ProjectAssignmentRateAuditLogDTO struct {
+	ID        string                              `json:"id"`
+	RateID    string                              `json:"rate_id"`
+	Action    string                              `json:"action"`
+	OldValues model.ProjectAssignmentRateSnapshot `json:"old_values"`
+	NewValues model.ProjectAssignmentRateSnapshot `json:"new_values"`
+	Reason    string                              `json:"reason"`
+	ChangedBy string                              `json:"changed_by"`
+	ChangedAt time.Time                           `json:"changed_at"`
+}
+
+// ProjectAssignmentRateListResponse 単価履歴一覧レスポンス
+type ProjectAssignmentRateListResponse struct {
+	AssignmentID string                             `json:"assignment_id"`
+	Rates        []ProjectAssignmentRateDTO         `json:"rates"`
+	AuditLogs    []ProjectAssignmentRateAuditLogDTO `json:"audit_logs"`
+}
+
+// ProjectAssignmentRateToDTO 単価履歴をDTOに変換
+func ProjectAssignmentRateToDTO(rate *model.ProjectAssignmentRate) ProjectAssignmentRateDTO {
+	result := ProjectAssignmentRateDTO{
+		ID:            rate.ID,
+		AssignmentID:  rate.AssignmentID,
+		EffectiveFrom: rate.EffectiveFrom.Format("2006-01-02"),
+		BillingType:   string(rate.BillingType),
+		BillingRate:   rate.BillingRate,
+		MinHours:      rate.MinHours,
+		MaxHours:      rate.MaxHours,
+		Notes:         rate.Notes,
+		CreatedBy:     rate.CreatedBy,
+		UpdatedBy:     rate.UpdatedBy,
+		CreatedAt:     rate.CreatedAt,
+		UpdatedAt:     rate.UpdatedAt,
+	}
+	if rate.EffectiveTo != nil {
+		effectiveTo := rate.EffectiveTo.Format("2006-01-02")
+		result.EffectiveTo = &effectiveTo
+	}
+	return result
+}
+
+// ProjectAssignmentRateAuditLogToDTO 単価履歴の変更ログをDTOに変換
+func ProjectAssignmentRateAuditLogToDTO(log *model.ProjectAssignmentRateAuditLog) ProjectAssignmentRateAuditLogDTO {
+	return ProjectAssignmentRateAuditLogDTO{
+		ID:        log.ID,
+		RateID:    log.RateID,
+		Action:    log.Action,
+		OldValues: log.OldValues,
+		NewValues: log.NewValues,
+		Reason:    log.Reason,
+		ChangedBy: log.ChangedBy,
+		ChangedAt: log.ChangedAt,
+	}
+}
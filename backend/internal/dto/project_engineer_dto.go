@@ -12,6 +12,7 @@ type ProjectMinimalDTO struct {
     Description string     `json:"description,omitempty"`
     ClientID    string     `json:"client_id"`
     ClientName  string     `json:"client_name,omitempty"`
+    ProrationMethod *string `json:"proration_method"` // 日割り方法（nullは取引先の設定に従う）
     CreatedAt   time.Time  `json:"created_at"`
     UpdatedAt   time.Time  `json:"updated_at"`
 }
@@ -33,6 +34,7 @@ type ProjectCreate struct {
     StartDate   *string `json:"start_date" binding:"omitempty"` // 形式検証はハンドラ側で実施
     EndDate     *string `json:"end_date" binding:"omitempty"`
     Description *string `json:"description" binding:"omitempty,max=1000"`
+    ProrationMethod *string `json:"proration_method" binding:"omitempty,oneof=business_days calendar_days hours"`
 }
 
 // ProjectUpdate リクエスト
@@ -43,6 +45,7 @@ type ProjectUpdate struct {
     StartDate   *string `json:"start_date" binding:"omitempty"`
     EndDate     *string `json:"end_date" binding:"omitempty"`
     Description *string `json:"description" binding:"omitempty,max=1000"`
+    ProrationMethod *string `json:"proration_method" binding:"omitempty,oneof=business_days calendar_days hours"` // 空文字で取引先の設定に戻す
     Version     *int64  `json:"version" binding:"omitempty"`
 }
 
@@ -3,6 +3,8 @@ package dto
 import (
 	"fmt"
 	"time"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ProjectGroupDTO プロジェクトグループDTO
@@ -22,7 +24,7 @@ type ProjectGroupDetailDTO struct {
 	ProjectGroupDTO
 	ProjectCount    int                     `json:"project_count"`
 	ActiveProjects  int                     `json:"active_projects"`
-	TotalRevenue    float64                 `json:"total_revenue"`
+	TotalRevenue    money.Amount            `json:"total_revenue"`
 	LastInvoiceDate *time.Time              `json:"last_invoice_date"`
 	Projects        []ProjectSummaryDTO     `json:"projects,omitempty"`
 	RecentInvoices  []InvoiceListSummaryDTO `json:"recent_invoices,omitempty"`
@@ -31,26 +33,26 @@ type ProjectGroupDetailDTO struct {
 
 // ProjectSummaryDTO プロジェクトサマリーDTO
 type ProjectSummaryDTO struct {
-	ID            string     `json:"id"`
-	ProjectName   string     `json:"project_name"`
-	ProjectCode   string     `json:"project_code"`
-	Status        string     `json:"status"`
-	StartDate     *time.Time `json:"start_date"`
-	EndDate       *time.Time `json:"end_date"`
-	MonthlyRate   float64    `json:"monthly_rate"`
-	AssignedCount int        `json:"assigned_count"`
-	IsActive      bool       `json:"is_active"`
+	ID            string       `json:"id"`
+	ProjectName   string       `json:"project_name"`
+	ProjectCode   string       `json:"project_code"`
+	Status        string       `json:"status"`
+	StartDate     *time.Time   `json:"start_date"`
+	EndDate       *time.Time   `json:"end_date"`
+	MonthlyRate   money.Amount `json:"monthly_rate"`
+	AssignedCount int          `json:"assigned_count"`
+	IsActive      bool         `json:"is_active"`
 }
 
 // InvoiceListSummaryDTO 請求書リストサマリーDTO
 type InvoiceListSummaryDTO struct {
-	ID            string    `json:"id"`
-	InvoiceNumber string    `json:"invoice_number"`
-	BillingMonth  string    `json:"billing_month"`
-	TotalAmount   float64   `json:"total_amount"`
-	Status        string    `json:"status"`
-	InvoiceDate   time.Time `json:"invoice_date"`
-	DueDate       time.Time `json:"due_date"`
+	ID            string       `json:"id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	BillingMonth  string       `json:"billing_month"`
+	TotalAmount   money.Amount `json:"total_amount"`
+	Status        string       `json:"status"`
+	InvoiceDate   time.Time    `json:"invoice_date"`
+	DueDate       time.Time    `json:"due_date"`
 }
 
 // UserSummaryDTO ユーザーサマリーDTO
@@ -89,7 +91,7 @@ type RemoveProjectsFromGroupRequest struct {
 type ProjectGroupWithStatsResponse struct {
 	ProjectGroupResponse ProjectGroupDTO `json:"project_group"`
 	ProjectCount         int             `json:"project_count"`
-	TotalRevenue         float64         `json:"total_revenue"`
+	TotalRevenue         money.Amount    `json:"total_revenue"`
 	LastInvoiceDate      *time.Time      `json:"last_invoice_date"`
 	ClientName           string          `json:"client_name"`
 }
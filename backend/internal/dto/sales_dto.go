@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // SalesActivityDTO 営業活動DTO
@@ -63,17 +65,17 @@ type SalesActivitySearchRequest struct {
 
 // SalesPipelineDTO 営業パイプラインDTO
 type SalesPipelineDTO struct {
-	ID            string     `json:"id"`
-	ClientID      string     `json:"client_id"`
-	ClientName    string     `json:"client_name"`
-	ProjectName   string     `json:"project_name"`
-	Stage         string     `json:"stage"`
-	Probability   int        `json:"probability"`
-	ExpectedValue float64    `json:"expected_value"`
-	ExpectedDate  *time.Time `json:"expected_date"`
-	LastActivity  *time.Time `json:"last_activity"`
-	NextAction    string     `json:"next_action"`
-	Owner         string     `json:"owner"`
+	ID            string       `json:"id"`
+	ClientID      string       `json:"client_id"`
+	ClientName    string       `json:"client_name"`
+	ProjectName   string       `json:"project_name"`
+	Stage         string       `json:"stage"`
+	Probability   int          `json:"probability"`
+	ExpectedValue money.Amount `json:"expected_value"`
+	ExpectedDate  *time.Time   `json:"expected_date"`
+	LastActivity  *time.Time   `json:"last_activity"`
+	NextAction    string       `json:"next_action"`
+	Owner         string       `json:"owner"`
 }
 
 // SalesSummaryDTO 営業サマリDTO
@@ -0,0 +1,103 @@
+package dto
+
+import "time"
+
+// StoreDocumentRequest 文書の登録リクエスト（multipart/form-data、ファイルはfile）
+type StoreDocumentRequest struct {
+	DocumentType    string `form:"document_type" binding:"required,oneof=invoice receipt other"`
+	TransactionDate string `form:"transaction_date" binding:"required"` // 取引年月日（YYYY-MM-DD）
+	Amount          int64  `form:"amount"`                              // 取引金額（円）
+	Counterparty    string `form:"counterparty" binding:"required,max=200"`
+}
+
+// ReplaceDocumentRequest 文書の差し替えリクエスト（multipart/form-data、ファイルはfile）
+// 検索項目を指定しない場合は差し替え前の版を引き継ぐ
+type ReplaceDocumentRequest struct {
+	Reason          string  `form:"reason" binding:"required,max=500"`
+	TransactionDate string  `form:"transaction_date"`
+	Amount          *int64  `form:"amount"`
+	Counterparty    *string `form:"counterparty" binding:"omitempty,max=200"`
+}
+
+// ArchiveReceiptRequest 経費の領収書の保存リクエスト
+type ArchiveReceiptRequest struct {
+	Counterparty string `json:"counterparty" binding:"required,max=200"` // 領収書の発行者
+}
+
+// DocumentSearchRequest 保存文書の検索リクエスト
+// 取引年月日・金額は範囲で指定でき、複数の条件を組み合わせられる
+type DocumentSearchRequest struct {
+	DateFrom          string `form:"date_from"` // YYYY-MM-DD
+	DateTo            string `form:"date_to"`   // YYYY-MM-DD
+	AmountMin         *int64 `form:"amount_min"`
+	AmountMax         *int64 `form:"amount_max"`
+	Counterparty      string `form:"counterparty"` // 部分一致
+	DocumentType      string `form:"document_type" binding:"omitempty,oneof=invoice receipt other"`
+	IncludeSuperseded bool   `form:"include_superseded"` // 差し替え前の版も含める
+	Page              int    `form:"page"`
+	Limit             int    `form:"limit"`
+}
+
+// StoredDocumentDTO 保存文書DTO
+type StoredDocumentDTO struct {
+	ID              string     `json:"id"`
+	GroupID         string     `json:"group_id"`
+	Version         int        `json:"version"`
+	DocumentType    string     `json:"document_type"`
+	SourceType      *string    `json:"source_type"`
+	SourceID        *string    `json:"source_id"`
+	TransactionDate string     `json:"transaction_date"` // YYYY-MM-DD
+	Amount          int64      `json:"amount"`
+	Counterparty    string     `json:"counterparty"`
+	FileName        string     `json:"file_name"`
+	ContentType     string     `json:"content_type"`
+	FileSize        int64      `json:"file_size"`
+	ContentHash     string     `json:"content_hash"`
+	ChainSeq        int64      `json:"chain_seq"`
+	ChainHash       string     `json:"chain_hash"`
+	RetentionUntil  string     `json:"retention_until"` // YYYY-MM-DD
+	IsLatest        bool       `json:"is_latest"`
+	SupersededByID  *string    `json:"superseded_by_id"`
+	ReplaceReason   *string    `json:"replace_reason"`
+	DisposedAt      *time.Time `json:"disposed_at"`
+	RegisteredBy    string     `json:"registered_by"`
+	RegisteredAt    time.Time  `json:"registered_at"`
+}
+
+// StoredDocumentDetailDTO 保存文書の詳細DTO（全ての版を含む）
+type StoredDocumentDetailDTO struct {
+	StoredDocumentDTO
+	Versions []StoredDocumentDTO `json:"versions"`
+}
+
+// DocumentSearchResponse 保存文書の検索結果
+type DocumentSearchResponse struct {
+	Documents []StoredDocumentDTO `json:"documents"`
+	Total     int64               `json:"total"`
+	Page      int                 `json:"page"`
+	Limit     int                 `json:"limit"`
+}
+
+// StoredDocumentContent 保存文書の本体
+type StoredDocumentContent struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// DocumentChainVerificationDTO ハッシュチェーンの検証結果
+type DocumentChainVerificationDTO struct {
+	Valid          bool                     `json:"valid"`
+	CheckedCount   int                      `json:"checked_count"`
+	LastSeq        int64                    `json:"last_seq"`
+	ContentChecked bool                     `json:"content_checked"` // 文書本体のハッシュも照合したか
+	Violations     []DocumentChainViolation `json:"violations"`
+	VerifiedAt     time.Time                `json:"verified_at"`
+}
+
+// DocumentChainViolation ハッシュチェーンの検証で見つかった不整合
+type DocumentChainViolation struct {
+	ChainSeq   int64  `json:"chain_seq"`
+	DocumentID string `json:"document_id"`
+	Reason     string `json:"reason"`
+}
@@ -18,6 +18,7 @@ type DailyRecordRequest struct {
 	ClientBreakTime float64 `json:"client_break_time"`
 	ClientWorkHours float64 `json:"client_work_hours"`
 	HasClientWork   bool    `json:"has_client_work"`
+	ProjectID       *string `json:"project_id"` // 複数の案件に並行してアサインされている場合に指定
 	Remarks         string  `json:"remarks"`
 	IsHolidayWork   bool    `json:"is_holiday_work"`
 }
@@ -35,6 +36,7 @@ type DailyRecordResponse struct {
 	ClientBreakTime float64 `json:"client_break_time"`
 	ClientWorkHours float64 `json:"client_work_hours"`
 	HasClientWork   bool    `json:"has_client_work"`
+	ProjectID       *string `json:"project_id"`
 	Remarks         string  `json:"remarks"`
 	IsHolidayWork   bool    `json:"is_holiday_work"`
 }
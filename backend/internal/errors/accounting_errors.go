@@ -35,6 +35,9 @@ const (
 	ErrBillingProjectNotActive  AccountingErrorCode = "BILLING_PROJECT_NOT_ACTIVE"
 	ErrBillingScheduleFailed    AccountingErrorCode = "BILLING_SCHEDULE_FAILED"
 	ErrBillingRetryFailed       AccountingErrorCode = "BILLING_RETRY_FAILED"
+	ErrBillingReportsUnsettled  AccountingErrorCode = "BILLING_REPORTS_UNSETTLED"
+	ErrBillingRateOverlap       AccountingErrorCode = "BILLING_RATE_OVERLAP"
+	ErrBillingRateUndefined     AccountingErrorCode = "BILLING_RATE_UNDEFINED"
 
 	// freee連携関連エラー
 	ErrFreeeNotConnected        AccountingErrorCode = "FREEE_NOT_CONNECTED"
@@ -64,6 +67,37 @@ const (
 	ErrScheduleInvalidTime     AccountingErrorCode = "SCHEDULE_INVALID_TIME"
 	ErrScheduleAlreadyExecuted AccountingErrorCode = "SCHEDULE_ALREADY_EXECUTED"
 	ErrScheduleCronInvalid     AccountingErrorCode = "SCHEDULE_CRON_INVALID"
+
+	// 入金消込関連エラー
+	ErrReconciliationInvalidStatement  AccountingErrorCode = "RECONCILIATION_INVALID_STATEMENT"
+	ErrReconciliationInvalidAllocation AccountingErrorCode = "RECONCILIATION_INVALID_ALLOCATION"
+	ErrReconciliationAlreadySettled    AccountingErrorCode = "RECONCILIATION_ALREADY_SETTLED"
+
+	// 仕入関連エラー
+	ErrPurchaseCostOverlap          AccountingErrorCode = "PURCHASE_COST_OVERLAP"
+	ErrPurchaseCostUndefined        AccountingErrorCode = "PURCHASE_COST_UNDEFINED"
+	ErrPartnerInvoiceAlreadySettled AccountingErrorCode = "PARTNER_INVOICE_ALREADY_SETTLED"
+
+	// 仕訳関連エラー
+	ErrJournalMappingUndefined AccountingErrorCode = "JOURNAL_MAPPING_UNDEFINED"
+	ErrJournalMonthClosed      AccountingErrorCode = "JOURNAL_MONTH_CLOSED"
+	ErrJournalCloseOutOfOrder  AccountingErrorCode = "JOURNAL_CLOSE_OUT_OF_ORDER"
+
+	// 経費精算（総合振込）関連エラー
+	ErrReimbursementNoExpenses      AccountingErrorCode = "REIMBURSEMENT_NO_EXPENSES"
+	ErrReimbursementBatchNotDraft   AccountingErrorCode = "REIMBURSEMENT_BATCH_NOT_DRAFT"
+	ErrReimbursementTransferInvalid AccountingErrorCode = "REIMBURSEMENT_TRANSFER_INVALID"
+
+	// 電子帳簿保存関連エラー
+	ErrDocumentRetentionActive AccountingErrorCode = "DOCUMENT_RETENTION_ACTIVE"
+	ErrDocumentSuperseded      AccountingErrorCode = "DOCUMENT_SUPERSEDED"
+	ErrDocumentTampered        AccountingErrorCode = "DOCUMENT_TAMPERED"
+
+	// 出張申請関連エラー
+	ErrBusinessTripStatusInvalid AccountingErrorCode = "BUSINESS_TRIP_STATUS_INVALID"
+
+	// 仮払金関連エラー
+	ErrCashAdvanceStatusInvalid AccountingErrorCode = "CASH_ADVANCE_STATUS_INVALID"
 )
 
 // AccountingError 経理機能のエラー構造体
@@ -156,9 +190,16 @@ func getDefaultHTTPCode(code AccountingErrorCode) int {
 		return http.StatusNotFound
 	case ErrAccountingPermission:
 		return http.StatusForbidden
-	case ErrAccountingInvalidRequest, ErrProjectGroupInvalidName, ErrBillingInvalidMonth, ErrBillingInvalidAmount, ErrScheduleInvalidTime, ErrScheduleCronInvalid:
+	case ErrAccountingInvalidRequest, ErrProjectGroupInvalidName, ErrBillingInvalidMonth, ErrBillingInvalidAmount, ErrScheduleInvalidTime, ErrScheduleCronInvalid,
+		ErrReconciliationInvalidStatement, ErrReconciliationInvalidAllocation:
 		return http.StatusBadRequest
-	case ErrAccountingDataConflict, ErrProjectGroupDuplicate, ErrBillingAlreadyProcessed, ErrBatchJobAlreadyRunning, ErrScheduleAlreadyExecuted:
+	case ErrAccountingDataConflict, ErrProjectGroupDuplicate, ErrBillingAlreadyProcessed, ErrBatchJobAlreadyRunning, ErrScheduleAlreadyExecuted, ErrReconciliationAlreadySettled,
+		ErrBillingReportsUnsettled, ErrBillingRateOverlap, ErrBillingRateUndefined,
+		ErrPurchaseCostOverlap, ErrPurchaseCostUndefined, ErrPartnerInvoiceAlreadySettled,
+		ErrJournalMappingUndefined, ErrJournalMonthClosed, ErrJournalCloseOutOfOrder,
+		ErrReimbursementNoExpenses, ErrReimbursementBatchNotDraft, ErrReimbursementTransferInvalid,
+		ErrDocumentRetentionActive, ErrDocumentSuperseded, ErrDocumentTampered,
+		ErrBusinessTripStatusInvalid, ErrCashAdvanceStatusInvalid:
 		return http.StatusConflict
 	case ErrFreeeAuthFailed, ErrFreeeTokenExpired:
 		return http.StatusUnauthorized
@@ -209,12 +250,145 @@ func ErrBillingNoWorkRecordsError(month string, projectID string) *AccountingErr
 		WithDetail("project_id", projectID)
 }
 
+// ErrBillingReportsUnsettledError 精算期間の週報が未提出・未承認のエラー
+func ErrBillingReportsUnsettledError(month string, userID string, cause error) *AccountingError {
+	return NewAccountingErrorWithCause(ErrBillingReportsUnsettled, "未提出・未承認の週報があるため請求できません", cause).
+		WithDetail("month", month).
+		WithDetail("user_id", userID)
+}
+
+// ErrBillingReportsUnattributedError 並行するアサインの稼働を案件に振り分けられないエラー
+func ErrBillingReportsUnattributedError(month string, userID string, cause error) *AccountingError {
+	return NewAccountingErrorWithCause(ErrBillingReportsUnsettled, "週報の勤怠に案件が指定されていないため請求できません", cause).
+		WithDetail("month", month).
+		WithDetail("user_id", userID)
+}
+
+// ErrBillingRateOverlapError 単価履歴の適用期間が重複するエラー
+func ErrBillingRateOverlapError(assignmentID string, rateID string) *AccountingError {
+	return NewAccountingError(ErrBillingRateOverlap, "適用期間が他の単価履歴と重複しています").
+		WithDetail("assignment_id", assignmentID).
+		WithDetail("rate_id", rateID)
+}
+
+// ErrBillingRateUndefinedError 請求期間に単価履歴がないエラー
+func ErrBillingRateUndefinedError(month string, assignmentID string, cause error) *AccountingError {
+	return NewAccountingErrorWithCause(ErrBillingRateUndefined, "単価が登録されていない期間があるため請求できません", cause).
+		WithDetail("month", month).
+		WithDetail("assignment_id", assignmentID)
+}
+
 // ErrBillingCalculationFailedError 請求額計算失敗エラー
 func ErrBillingCalculationFailedError(reason string) *AccountingError {
 	return NewAccountingError(ErrBillingCalculationFailed, "請求額の計算に失敗しました").
 		WithDetail("reason", reason)
 }
 
+// 仕入関連エラー関数
+
+// ErrPurchaseCostOverlapError 仕入単価の適用期間が重複するエラー
+func ErrPurchaseCostOverlapError(assignmentID string, costID string) *AccountingError {
+	return NewAccountingError(ErrPurchaseCostOverlap, "適用期間が他の仕入単価と重複しています").
+		WithDetail("assignment_id", assignmentID).
+		WithDetail("cost_id", costID)
+}
+
+// ErrPurchaseCostUndefinedError 対象期間に仕入単価がないエラー
+func ErrPurchaseCostUndefinedError(month string, assignmentID string, cause error) *AccountingError {
+	return NewAccountingErrorWithCause(ErrPurchaseCostUndefined, "仕入単価が登録されていない期間があるため仕入額を計算できません", cause).
+		WithDetail("month", month).
+		WithDetail("assignment_id", assignmentID)
+}
+
+// ErrPartnerInvoiceAlreadySettledError 承認・差戻し済みの受領請求書を変更しようとしたエラー
+func ErrPartnerInvoiceAlreadySettledError(invoiceID string, status string) *AccountingError {
+	return NewAccountingError(ErrPartnerInvoiceAlreadySettled, "受領した請求書は既に承認または差戻しされています").
+		WithDetail("partner_invoice_id", invoiceID).
+		WithDetail("status", status)
+}
+
+// 仕訳関連エラー関数
+
+// ErrJournalMappingUndefinedError 勘定科目の対応が登録されていないエラー
+func ErrJournalMappingUndefinedError(mappingKeys []string) *AccountingError {
+	return NewAccountingError(ErrJournalMappingUndefined, "勘定科目の対応が登録されていない仕訳があります").
+		WithDetail("mapping_keys", mappingKeys)
+}
+
+// ErrJournalMonthClosedError 締め済みの月を締めようとしたエラー
+func ErrJournalMonthClosedError(month string) *AccountingError {
+	return NewAccountingError(ErrJournalMonthClosed, "対象月は既に締められています").
+		WithDetail("month", month)
+}
+
+// ErrJournalCloseOutOfOrderError 前月を締めずに月を締めようとしたエラー
+func ErrJournalCloseOutOfOrderError(month string, nextMonth string) *AccountingError {
+	return NewAccountingError(ErrJournalCloseOutOfOrder, "月締めは締め済みの月の翌月から順に行ってください").
+		WithDetail("month", month).
+		WithDetail("next_month", nextMonth)
+}
+
+// 経費精算（総合振込）関連エラー関数
+
+// ErrReimbursementNoExpensesError 精算対象の経費がないエラー
+func ErrReimbursementNoExpensesError(paymentDate string) *AccountingError {
+	return NewAccountingError(ErrReimbursementNoExpenses, "振込口座が登録された社員の精算対象の経費がありません").
+		WithDetail("payment_date", paymentDate)
+}
+
+// ErrReimbursementBatchNotDraftError 確定・取消済みの振込バッチを操作しようとしたエラー
+func ErrReimbursementBatchNotDraftError(batchID string, status string) *AccountingError {
+	return NewAccountingError(ErrReimbursementBatchNotDraft, "振込バッチは既に確定または取消されています").
+		WithDetail("batch_id", batchID).
+		WithDetail("status", status)
+}
+
+// ErrReimbursementTransferInvalidError 総合振込ファイルを作成できないエラー
+func ErrReimbursementTransferInvalidError(cause error) *AccountingError {
+	return NewAccountingErrorWithCause(ErrReimbursementTransferInvalid, "総合振込ファイルを作成できません", cause).
+		WithDetail("reason", cause.Error())
+}
+
+// 電子帳簿保存関連エラー関数
+
+// ErrDocumentRetentionActiveError 法定保存期間中の文書を削除しようとしたエラー
+func ErrDocumentRetentionActiveError(documentID string, retentionUntil string) *AccountingError {
+	return NewAccountingError(ErrDocumentRetentionActive, "法定保存期間中の文書は削除できません").
+		WithDetail("document_id", documentID).
+		WithDetail("retention_until", retentionUntil)
+}
+
+// ErrDocumentSupersededError 最新でない版を差し替えようとしたエラー
+func ErrDocumentSupersededError(documentID string, latestID string) *AccountingError {
+	return NewAccountingError(ErrDocumentSuperseded, "差し替え済みの版は差し替えできません").
+		WithDetail("document_id", documentID).
+		WithDetail("latest_id", latestID)
+}
+
+// ErrDocumentTamperedError 保存文書のハッシュが登録時と一致しないエラー
+func ErrDocumentTamperedError(documentID string) *AccountingError {
+	return NewAccountingError(ErrDocumentTampered, "保存文書の改ざんを検知しました").
+		WithDetail("document_id", documentID)
+}
+
+// 出張申請関連エラー関数
+
+// ErrBusinessTripStatusInvalidError 現在のステータスでは出張申請を操作できないエラー
+func ErrBusinessTripStatusInvalidError(tripID string, status string) *AccountingError {
+	return NewAccountingError(ErrBusinessTripStatusInvalid, "この出張申請は現在のステータスでは操作できません").
+		WithDetail("business_trip_id", tripID).
+		WithDetail("status", status)
+}
+
+// 仮払金関連エラー関数
+
+// ErrCashAdvanceStatusInvalidError 現在のステータスでは仮払金を操作できないエラー
+func ErrCashAdvanceStatusInvalidError(advanceID string, status string) *AccountingError {
+	return NewAccountingError(ErrCashAdvanceStatusInvalid, "この仮払金は現在のステータスでは操作できません").
+		WithDetail("cash_advance_id", advanceID).
+		WithDetail("status", status)
+}
+
 // freee連携関連エラー関数
 
 // ErrFreeeNotConnectedError freee未接続エラー
@@ -342,6 +516,9 @@ func GetErrorMessage(code AccountingErrorCode) string {
 		ErrBillingProjectNotActive:    "プロジェクトがアクティブではありません",
 		ErrBillingScheduleFailed:      "請求スケジュールの設定に失敗しました",
 		ErrBillingRetryFailed:         "請求処理のリトライに失敗しました",
+		ErrBillingReportsUnsettled:    "未提出・未承認の週報があるため請求できません",
+		ErrBillingRateOverlap:         "適用期間が他の単価履歴と重複しています",
+		ErrBillingRateUndefined:       "単価が登録されていない期間があります",
 		ErrFreeeNotConnected:          "freeeとの連携が設定されていません",
 		ErrFreeeAuthFailed:            "freee認証に失敗しました",
 		ErrFreeeTokenExpired:          "freeeトークンの有効期限が切れています",
@@ -363,6 +540,26 @@ func GetErrorMessage(code AccountingErrorCode) string {
 		ErrScheduleInvalidTime:        "スケジュール時刻が無効です",
 		ErrScheduleAlreadyExecuted:    "スケジュールは既に実行済みです",
 		ErrScheduleCronInvalid:        "Cron式が無効です",
+
+		// 仕入関連
+		ErrPurchaseCostOverlap:          "適用期間が他の仕入単価と重複しています",
+		ErrPurchaseCostUndefined:        "仕入単価が登録されていない期間があります",
+		ErrPartnerInvoiceAlreadySettled: "受領した請求書は既に承認または差戻しされています",
+
+		// 仕訳関連
+		ErrJournalMappingUndefined: "勘定科目の対応が登録されていない仕訳があります",
+		ErrJournalMonthClosed:      "対象月は既に締められています",
+		ErrJournalCloseOutOfOrder:  "月締めは締め済みの月の翌月から順に行ってください",
+
+		// 経費精算（総合振込）関連
+		ErrReimbursementNoExpenses:      "振込口座が登録された社員の精算対象の経費がありません",
+		ErrReimbursementBatchNotDraft:   "振込バッチは既に確定または取消されています",
+		ErrReimbursementTransferInvalid: "総合振込ファイルを作成できません",
+
+		// 電子帳簿保存関連
+		ErrDocumentRetentionActive: "法定保存期間中の文書は削除できません",
+		ErrDocumentSuperseded:      "差し替え済みの版は差し替えできません",
+		ErrDocumentTampered:        "保存文書の改ざんを検知しました",
 	}
 
 	if message, exists := messages[code]; exists {
@@ -0,0 +1,545 @@
+package freee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+)
+
+const (
+	// partnerPageSize 取引先一覧の1回あたりの取得件数（freee APIの上限）
+	partnerPageSize = 3000
+	// invoicePageSize 請求書・取引一覧の1回あたりの取得件数（freee APIの上限）
+	invoicePageSize = 100
+	// maxRetryWait リトライ待機時間の上限
+	maxRetryWait = 60 * time.Second
+)
+
+// Client freee APIクライアント
+// 接続先は config.FreeeConfig の APIBaseURL / OAuthBaseURL で切り替えられる
+type Client struct {
+	config     *config.FreeeConfig
+	httpClient *http.Client
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// NewClient Clientのインスタンスを生成
+func NewClient(cfg *config.FreeeConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	return &Client{
+		config:     cfg,
+		httpClient: httpClient,
+		sleep:      sleepContext,
+	}
+}
+
+// Token OAuthトークン
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	CreatedAt    int64  `json:"created_at"`
+	CompanyID    int    `json:"company_id"`
+}
+
+// ExpiresAt トークンの有効期限を取得
+func (t *Token) ExpiresAt() time.Time {
+	issuedAt := time.Now()
+	if t.CreatedAt > 0 {
+		issuedAt = time.Unix(t.CreatedAt, 0)
+	}
+	return issuedAt.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// Deal freee取引（入金情報を保持する）
+type Deal struct {
+	ID        int           `json:"id"`
+	CompanyID int           `json:"company_id"`
+	IssueDate string        `json:"issue_date"`
+	DueDate   string        `json:"due_date,omitempty"`
+	Amount    int           `json:"amount"`
+	DueAmount int           `json:"due_amount"`
+	Type      string        `json:"type"` // income, expense
+	PartnerID *int          `json:"partner_id,omitempty"`
+	Status    string        `json:"status"` // settled, unsettled
+	Payments  []DealPayment `json:"payments"`
+}
+
+// DealPayment freee取引の支払行
+type DealPayment struct {
+	ID                 int    `json:"id,omitempty"`
+	Date               string `json:"date"`
+	FromWalletableType string `json:"from_walletable_type"` // bank_account, credit_card, wallet, private_account_item
+	FromWalletableID   int    `json:"from_walletable_id"`
+	Amount             int    `json:"amount"`
+}
+
+// APIError freee APIのエラー応答
+type APIError struct {
+	StatusCode int
+	Endpoint   string
+	Messages   []string
+	RetryAfter int // 秒
+}
+
+// Error エラーメッセージを返す
+func (e *APIError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("freee API error: %s (HTTP %d)", e.Endpoint, e.StatusCode)
+	}
+	return fmt.Sprintf("freee API error: %s (HTTP %d): %s", e.Endpoint, e.StatusCode, strings.Join(e.Messages, ", "))
+}
+
+// IsUnauthorized 認証エラーかチェック
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited レート制限エラーかチェック
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// AuthorizeURL 認可画面のURLを生成
+func (c *Client) AuthorizeURL(redirectURI, state string) string {
+	return c.config.GetAuthorizeURL(redirectURI, state)
+}
+
+// ExchangeCode 認可コードをトークンに交換
+func (c *Client) ExchangeCode(ctx context.Context, code, redirectURI string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	return c.requestToken(ctx, form)
+}
+
+// RefreshToken リフレッシュトークンでトークンを更新
+// freeeのリフレッシュトークンは使い捨てのため、返却された新しいリフレッシュトークンを必ず保存すること
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	return c.requestToken(ctx, form)
+}
+
+// RevokeToken トークンを失効させる
+func (c *Client) RevokeToken(ctx context.Context, token string) error {
+	form := url.Values{}
+	form.Set("client_id", c.config.ClientID)
+	form.Set("client_secret", c.config.ClientSecret)
+	form.Set("token", token)
+	return c.doForm(ctx, c.config.GetRevokeURL(), form, nil)
+}
+
+// requestToken トークンエンドポイントを呼び出す
+func (c *Client) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", c.config.ClientID)
+	form.Set("client_secret", c.config.ClientSecret)
+
+	var token Token
+	if err := c.doForm(ctx, c.config.GetTokenURL(), form, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, &APIError{StatusCode: http.StatusOK, Endpoint: c.config.GetTokenURL(), Messages: []string{"access_token is empty"}}
+	}
+	return &token, nil
+}
+
+// GetCompanies 事業所一覧を取得
+func (c *Client) GetCompanies(ctx context.Context, accessToken string) ([]*dto.FreeeCompanyDTO, error) {
+	var resp struct {
+		Companies []*dto.FreeeCompanyDTO `json:"companies"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodGet, "/companies", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Companies, nil
+}
+
+// GetCompany 事業所情報を取得
+func (c *Client) GetCompany(ctx context.Context, accessToken string, companyID int) (*dto.FreeeCompanyDTO, error) {
+	var resp struct {
+		Company *dto.FreeeCompanyDTO `json:"company"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodGet, fmt.Sprintf("/companies/%d", companyID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Company, nil
+}
+
+// GetPartners 取引先一覧を全件取得
+func (c *Client) GetPartners(ctx context.Context, accessToken string, companyID int) ([]*dto.FreeePartnerDTO, error) {
+	var partners []*dto.FreeePartnerDTO
+	for offset := 0; ; offset += partnerPageSize {
+		query := companyQuery(companyID)
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(partnerPageSize))
+
+		var resp struct {
+			Partners []*dto.FreeePartnerDTO `json:"partners"`
+		}
+		if err := c.do(ctx, accessToken, http.MethodGet, "/partners", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		partners = append(partners, resp.Partners...)
+		if len(resp.Partners) < partnerPageSize {
+			return partners, nil
+		}
+	}
+}
+
+// CreatePartner 取引先を作成
+func (c *Client) CreatePartner(ctx context.Context, accessToken string, req *dto.CreateFreeePartnerRequest) (*dto.FreeePartnerDTO, error) {
+	var resp struct {
+		Partner *dto.FreeePartnerDTO `json:"partner"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodPost, "/partners", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Partner, nil
+}
+
+// UpdatePartner 取引先を更新
+func (c *Client) UpdatePartner(ctx context.Context, accessToken string, companyID, partnerID int, req *dto.UpdateFreeePartnerRequest) (*dto.FreeePartnerDTO, error) {
+	body := struct {
+		CompanyID int `json:"company_id"`
+		*dto.UpdateFreeePartnerRequest
+	}{companyID, req}
+
+	var resp struct {
+		Partner *dto.FreeePartnerDTO `json:"partner"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodPut, fmt.Sprintf("/partners/%d", partnerID), nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Partner, nil
+}
+
+// GetInvoices 請求書一覧を発行日の範囲で全件取得
+func (c *Client) GetInvoices(ctx context.Context, accessToken string, companyID int, from, to *time.Time) ([]*dto.FreeeInvoiceDTO, error) {
+	var invoices []*dto.FreeeInvoiceDTO
+	for offset := 0; ; offset += invoicePageSize {
+		query := companyQuery(companyID)
+		setDateRange(query, "start_issue_date", "end_issue_date", from, to)
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(invoicePageSize))
+
+		var resp struct {
+			Invoices []*dto.FreeeInvoiceDTO `json:"invoices"`
+		}
+		if err := c.do(ctx, accessToken, http.MethodGet, "/invoices", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, resp.Invoices...)
+		if len(resp.Invoices) < invoicePageSize {
+			return invoices, nil
+		}
+	}
+}
+
+// GetInvoice 請求書を取得
+func (c *Client) GetInvoice(ctx context.Context, accessToken string, companyID, invoiceID int) (*dto.FreeeInvoiceDTO, error) {
+	var resp struct {
+		Invoice *dto.FreeeInvoiceDTO `json:"invoice"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodGet, fmt.Sprintf("/invoices/%d", invoiceID), companyQuery(companyID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Invoice, nil
+}
+
+// CreateInvoice 請求書を作成
+func (c *Client) CreateInvoice(ctx context.Context, accessToken string, req *dto.CreateFreeeInvoiceRequest) (*dto.FreeeInvoiceDTO, error) {
+	var resp struct {
+		Invoice *dto.FreeeInvoiceDTO `json:"invoice"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodPost, "/invoices", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Invoice, nil
+}
+
+// UpdateInvoice 請求書を更新
+func (c *Client) UpdateInvoice(ctx context.Context, accessToken string, companyID, invoiceID int, req *dto.UpdateFreeeInvoiceRequest) (*dto.FreeeInvoiceDTO, error) {
+	body := struct {
+		CompanyID int `json:"company_id"`
+		*dto.UpdateFreeeInvoiceRequest
+	}{companyID, req}
+
+	var resp struct {
+		Invoice *dto.FreeeInvoiceDTO `json:"invoice"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodPut, fmt.Sprintf("/invoices/%d", invoiceID), nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Invoice, nil
+}
+
+// DeleteInvoice 請求書を削除
+func (c *Client) DeleteInvoice(ctx context.Context, accessToken string, companyID, invoiceID int) error {
+	return c.do(ctx, accessToken, http.MethodDelete, fmt.Sprintf("/invoices/%d", invoiceID), companyQuery(companyID), nil, nil)
+}
+
+// GetDeal 取引を取得
+func (c *Client) GetDeal(ctx context.Context, accessToken string, companyID, dealID int) (*Deal, error) {
+	var resp struct {
+		Deal *Deal `json:"deal"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodGet, fmt.Sprintf("/deals/%d", dealID), companyQuery(companyID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deal, nil
+}
+
+// GetIncomeDeals 収入取引の一覧を発生日の範囲で全件取得
+func (c *Client) GetIncomeDeals(ctx context.Context, accessToken string, companyID int, from, to *time.Time) ([]*Deal, error) {
+	var deals []*Deal
+	for offset := 0; ; offset += invoicePageSize {
+		query := companyQuery(companyID)
+		query.Set("type", "income")
+		setDateRange(query, "start_issue_date", "end_issue_date", from, to)
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(invoicePageSize))
+
+		var resp struct {
+			Deals []*Deal `json:"deals"`
+		}
+		if err := c.do(ctx, accessToken, http.MethodGet, "/deals", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		deals = append(deals, resp.Deals...)
+		if len(resp.Deals) < invoicePageSize {
+			return deals, nil
+		}
+	}
+}
+
+// CreateDealPayment 取引に入金（支払行）を登録
+func (c *Client) CreateDealPayment(ctx context.Context, accessToken string, companyID, dealID int, payment *DealPayment) (*Deal, error) {
+	body := struct {
+		CompanyID int `json:"company_id"`
+		*DealPayment
+	}{companyID, payment}
+
+	var resp struct {
+		Deal *Deal `json:"deal"`
+	}
+	if err := c.do(ctx, accessToken, http.MethodPost, fmt.Sprintf("/deals/%d/payments", dealID), nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deal, nil
+}
+
+// do APIを呼び出す
+// レート制限（429）と一時的な障害（5xx・通信エラー）は設定回数までリトライする
+// 書き込み系のリクエストは二重登録を避けるため、処理されていないことが明らかな429/503のみリトライする
+func (c *Client) do(ctx context.Context, accessToken, method, endpoint string, query url.Values, body, result interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("リクエストの作成に失敗しました: %w", err)
+		}
+	}
+
+	requestURL := c.config.GetAPIURL(endpoint)
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	return c.withRetry(ctx, method, func() (*http.Response, error) {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("Accept", "application/json")
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return c.httpClient.Do(req)
+	}, endpoint, result)
+}
+
+// doForm フォーム形式でOAuthエンドポイントを呼び出す
+func (c *Client) doForm(ctx context.Context, endpoint string, form url.Values, result interface{}) error {
+	encoded := form.Encode()
+	return c.withRetry(ctx, http.MethodPost, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(encoded))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		return c.httpClient.Do(req)
+	}, endpoint, result)
+}
+
+// withRetry リトライしながらリクエストを送信しレスポンスをデコードする
+func (c *Client) withRetry(ctx context.Context, method string, send func() (*http.Response, error), endpoint string, result interface{}) error {
+	idempotent := method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		resp, err := send()
+		if err != nil {
+			if ctx.Err() != nil || !idempotent {
+				return fmt.Errorf("freee APIへの接続に失敗しました: %w", err)
+			}
+			lastErr = fmt.Errorf("freee APIへの接続に失敗しました: %w", err)
+		} else {
+			apiErr := decodeResponse(resp, endpoint, result)
+			if apiErr == nil {
+				return nil
+			}
+			if !shouldRetry(apiErr.StatusCode, idempotent) {
+				return apiErr
+			}
+			lastErr = apiErr
+		}
+
+		if attempt >= c.config.MaxRetries {
+			return lastErr
+		}
+		if err := c.sleep(ctx, c.retryWait(attempt, lastErr)); err != nil {
+			return err
+		}
+	}
+}
+
+// retryWait リトライまでの待機時間を算出
+// Retry-Afterヘッダーがあればそれに従い、なければ指数バックオフする
+func (c *Client) retryWait(attempt int, err error) time.Duration {
+	if apiErr, ok := err.(*APIError); ok && apiErr.RetryAfter > 0 {
+		return min(time.Duration(apiErr.RetryAfter)*time.Second, maxRetryWait)
+	}
+	base := time.Duration(c.config.RetryDelaySeconds) * time.Second
+	return min(base*time.Duration(1<<attempt), maxRetryWait)
+}
+
+// shouldRetry リトライ対象のステータスかチェック
+func shouldRetry(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// decodeResponse レスポンスを解析する
+func decodeResponse(resp *http.Response, endpoint string, result interface{}) *APIError {
+	defer resp.Body.Close()
+	body, readErr := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if result == nil || len(body) == 0 {
+			return nil
+		}
+		if readErr != nil {
+			return &APIError{StatusCode: resp.StatusCode, Endpoint: endpoint, Messages: []string{readErr.Error()}}
+		}
+		if err := json.Unmarshal(body, result); err != nil {
+			return &APIError{StatusCode: resp.StatusCode, Endpoint: endpoint, Messages: []string{"レスポンスの解析に失敗しました: " + err.Error()}}
+		}
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   endpoint,
+		Messages:   parseErrorMessages(body),
+	}
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = retryAfter
+	}
+	return apiErr
+}
+
+// parseErrorMessages freee APIのエラーレスポンスからメッセージを取り出す
+// 会計APIの {"errors":[{"messages":[...]}]} 形式とOAuthの {"error_description":...} 形式に対応する
+func parseErrorMessages(body []byte) []string {
+	var payload struct {
+		Message          string `json:"message"`
+		ErrorDescription string `json:"error_description"`
+		Errors           []struct {
+			Messages []string `json:"messages"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			return []string{text}
+		}
+		return nil
+	}
+
+	var messages []string
+	for _, e := range payload.Errors {
+		messages = append(messages, e.Messages...)
+	}
+	if payload.Message != "" {
+		messages = append(messages, payload.Message)
+	}
+	if payload.ErrorDescription != "" {
+		messages = append(messages, payload.ErrorDescription)
+	}
+	return messages
+}
+
+// companyQuery 事業所IDのクエリパラメータを生成
+func companyQuery(companyID int) url.Values {
+	query := url.Values{}
+	query.Set("company_id", strconv.Itoa(companyID))
+	return query
+}
+
+// setDateRange 日付範囲のクエリパラメータを設定
+func setDateRange(query url.Values, fromKey, toKey string, from, to *time.Time) {
+	if from != nil {
+		query.Set(fromKey, from.Format("2006-01-02"))
+	}
+	if to != nil {
+		query.Set(toKey, to.Format("2006-01-02"))
+	}
+}
+
+// sleepContext コンテキストのキャンセルを考慮して待機
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,216 @@
+package freee
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+)
+
+// newTestClient httptestサーバーに接続するクライアントを生成
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := &config.FreeeConfig{
+		ClientID:          "client-id",
+		ClientSecret:      "client-secret",
+		APIBaseURL:        server.URL,
+		OAuthBaseURL:      server.URL,
+		APIVersion:        1,
+		Scope:             "read write",
+		MaxRetries:        3,
+		RetryDelaySeconds: 1,
+	}
+	client := NewClient(cfg, server.Client())
+
+	// 待機時間を記録して実際には待たない
+	waits := &[]time.Duration{}
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return client, waits
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestClient_ExchangeCode(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/oauth/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "auth-code", r.PostForm.Get("code"))
+		assert.Equal(t, "https://app.example.com/callback", r.PostForm.Get("redirect_uri"))
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"token_type":    "bearer",
+			"expires_in":    21600,
+			"created_at":    1700000000,
+			"company_id":    123,
+		})
+	}))
+
+	token, err := client.ExchangeCode(context.Background(), "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.Equal(t, 123, token.CompanyID)
+	assert.Equal(t, time.Unix(1700000000+21600, 0), token.ExpiresAt())
+}
+
+func TestClient_RefreshTokenError(t *testing.T) {
+	client, waits := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"error":             "invalid_grant",
+			"error_description": "refresh token is invalid",
+		})
+	}))
+
+	_, err := client.RefreshToken(context.Background(), "expired")
+	require.Error(t, err)
+
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.True(t, apiErr.IsUnauthorized())
+	assert.Contains(t, apiErr.Messages, "refresh token is invalid")
+	assert.Empty(t, *waits, "認証エラーはリトライしない")
+}
+
+func TestClient_AuthorizeURL(t *testing.T) {
+	client, _ := newTestClient(t, http.NotFoundHandler())
+
+	authURL := client.AuthorizeURL("https://app.example.com/callback?x=1", "state+value")
+	assert.Contains(t, authURL, "/oauth/authorize?")
+	assert.Contains(t, authURL, "redirect_uri=https%3A%2F%2Fapp.example.com%2Fcallback%3Fx%3D1")
+	assert.Contains(t, authURL, "state=state%2Bvalue")
+	assert.Contains(t, authURL, "scope=read+write")
+}
+
+func TestClient_RateLimitRetry(t *testing.T) {
+	var calls int32
+	client, waits := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.Header().Set("Retry-After", "7")
+			writeJSON(w, http.StatusTooManyRequests, map[string]string{"message": "Too Many Requests"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"companies": []map[string]interface{}{{"id": 1, "name": "テスト株式会社"}},
+		})
+	}))
+
+	companies, err := client.GetCompanies(context.Background(), "token")
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, "テスト株式会社", companies[0].Name)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, *waits)
+}
+
+func TestClient_RetryExhausted(t *testing.T) {
+	var calls int32
+	client, waits := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"errors": []map[string]interface{}{{"type": "status", "messages": []string{"メンテナンス中です"}}},
+		})
+	}))
+
+	_, err := client.GetPartners(context.Background(), "token", 1)
+	require.Error(t, err)
+
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, []string{"メンテナンス中です"}, apiErr.Messages)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	// Retry-Afterがない場合は指数バックオフ
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, *waits)
+}
+
+func TestClient_PostIsNotRetriedOnServerError(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"message": "error"})
+	}))
+
+	_, err := client.CreatePartner(context.Background(), "token", &dto.CreateFreeePartnerRequest{CompanyID: 1, Name: "A"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "二重登録を避けるためPOSTは500でリトライしない")
+}
+
+func TestClient_GetPartnersPagination(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/1/partners", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("company_id"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		size := partnerPageSize
+		if offset > 0 {
+			size = 2
+		}
+		partners := make([]map[string]interface{}, size)
+		for i := range partners {
+			partners[i] = map[string]interface{}{"id": offset + i + 1, "company_id": 10, "name": "取引先"}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"partners": partners})
+	}))
+
+	partners, err := client.GetPartners(context.Background(), "token", 10)
+	require.NoError(t, err)
+	assert.Len(t, partners, partnerPageSize+2)
+	assert.Equal(t, partnerPageSize+2, partners[len(partners)-1].ID)
+}
+
+func TestClient_UpdateInvoiceIncludesCompanyID(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/api/1/invoices/55", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(10), body["company_id"])
+		assert.Equal(t, "INV-001", body["invoice_number"])
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"invoice": map[string]interface{}{"id": 55, "invoice_number": "INV-001"},
+		})
+	}))
+
+	number := "INV-001"
+	invoice, err := client.UpdateInvoice(context.Background(), "token", 10, 55, &dto.UpdateFreeeInvoiceRequest{InvoiceNumber: &number})
+	require.NoError(t, err)
+	assert.Equal(t, 55, invoice.ID)
+}
+
+func TestClient_DeleteInvoice(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "10", r.URL.Query().Get("company_id"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	require.NoError(t, client.DeleteInvoice(context.Background(), "token", 10, 55))
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/duesk/monstera/internal/common/userutil"
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/service"
+	"github.com/duesk/monstera/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ApprovalDelegationHandler 不在時の代理承認ハンドラー
+type ApprovalDelegationHandler struct {
+	delegationService service.ApprovalDelegationService
+	logger            *zap.Logger
+}
+
+// NewApprovalDelegationHandler 不在時の代理承認ハンドラーのインスタンスを生成
+func NewApprovalDelegationHandler(
+	delegationService service.ApprovalDelegationService,
+	logger *zap.Logger,
+) *ApprovalDelegationHandler {
+	return &ApprovalDelegationHandler{
+		delegationService: delegationService,
+		logger:            logger,
+	}
+}
+
+// GetDelegations 代理承認一覧を取得
+// @Summary 代理承認一覧を取得
+// @Description 経費申請・休暇申請・週報の代理承認を取得します。mine=trueの場合はログインユーザーが承認者または代理承認者のものに絞り込みます
+// @Tags Approval Delegations
+// @Produce json
+// @Param delegator_id query string false "承認者ID"
+// @Param delegate_id query string false "代理承認者ID"
+// @Param mine query bool false "自分の代理承認のみ"
+// @Param include_inactive query bool false "取り消し済み・終了済みも含める"
+// @Success 200 {object} dto.ApprovalDelegationsResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/admin/approval-delegations [get]
+func (h *ApprovalDelegationHandler) GetDelegations(c *gin.Context) {
+	filter := repository.ApprovalDelegationFilter{
+		IncludeInactive: c.Query("include_inactive") == "true",
+	}
+	if delegatorID := c.Query("delegator_id"); delegatorID != "" {
+		filter.DelegatorID = &delegatorID
+	}
+	if delegateID := c.Query("delegate_id"); delegateID != "" {
+		filter.DelegateID = &delegateID
+	}
+
+	if c.Query("mine") == "true" {
+		userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+		if !ok {
+			utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+			return
+		}
+		asDelegator := filter
+		asDelegator.DelegatorID = &userID
+		asDelegator.DelegateID = nil
+		delegated, err := h.delegationService.ListDelegations(c.Request.Context(), asDelegator)
+		if err != nil {
+			h.respondServiceError(c, "Failed to get approval delegations", err)
+			return
+		}
+		asDelegate := filter
+		asDelegate.DelegatorID = nil
+		asDelegate.DelegateID = &userID
+		received, err := h.delegationService.ListDelegations(c.Request.Context(), asDelegate)
+		if err != nil {
+			h.respondServiceError(c, "Failed to get approval delegations", err)
+			return
+		}
+		delegated.Delegations = append(delegated.Delegations, received.Delegations...)
+		c.JSON(http.StatusOK, delegated)
+		return
+	}
+
+	response, err := h.delegationService.ListDelegations(c.Request.Context(), filter)
+	if err != nil {
+		h.respondServiceError(c, "Failed to get approval delegations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateDelegation 代理承認を登録
+// @Summary 代理承認を登録
+// @Description 期間中、承認者に割り当てられた承認を代理承認者が行えるようにします。delegator_idを省略した場合はログインユーザーの代理承認になります
+// @Tags Approval Delegations
+// @Accept json
+// @Produce json
+// @Param request body dto.ApprovalDelegationRequest true "代理承認リクエスト"
+// @Success 201 {object} dto.ApprovalDelegationResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /api/v1/admin/approval-delegations [post]
+func (h *ApprovalDelegationHandler) CreateDelegation(c *gin.Context) {
+	var req dto.ApprovalDelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.delegationService.CreateDelegation(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondServiceError(c, "Failed to create approval delegation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeDelegation 代理承認を取り消す
+// @Summary 代理承認を取り消す
+// @Description 取り消し後は代理承認者による承認はできません。代理で承認済みの履歴は残ります
+// @Tags Approval Delegations
+// @Param id path string true "代理承認ID"
+// @Success 204
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/approval-delegations/{id} [delete]
+func (h *ApprovalDelegationHandler) RevokeDelegation(c *gin.Context) {
+	delegationID := c.Param("id")
+	if delegationID == "" {
+		utils.RespondError(c, http.StatusBadRequest, "代理承認IDが不正です")
+		return
+	}
+
+	if err := h.delegationService.RevokeDelegation(c.Request.Context(), delegationID); err != nil {
+		h.respondServiceError(c, "Failed to revoke approval delegation", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondServiceError サービスのエラーをHTTPステータスに変換して返す
+func (h *ApprovalDelegationHandler) respondServiceError(c *gin.Context, logMessage string, err error) {
+	h.logger.Error(logMessage, zap.Error(err))
+
+	switch {
+	case errors.Is(err, service.ErrInvalidApprovalDelegation):
+		utils.RespondError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrApprovalDelegationNotFound):
+		utils.RespondError(c, http.StatusNotFound, err.Error())
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, "代理承認の処理に失敗しました")
+	}
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+	"github.com/duesk/monstera/pkg/bankstatement"
+)
+
+// maxBankStatementSize 取込可能な明細ファイルの上限（10MB）
+const maxBankStatementSize = 10 << 20
+
+// BankReconciliationHandler 入金消込ハンドラー
+type BankReconciliationHandler struct {
+	reconciliationService service.BankReconciliationServiceInterface
+	logger                *zap.Logger
+}
+
+// NewBankReconciliationHandler 入金消込ハンドラーのコンストラクタ
+func NewBankReconciliationHandler(reconciliationService service.BankReconciliationServiceInterface, logger *zap.Logger) *BankReconciliationHandler {
+	return &BankReconciliationHandler{
+		reconciliationService: reconciliationService,
+		logger:                logger,
+	}
+}
+
+// ImportStatement 入出金明細ファイルを取込んで入金を照合
+// multipart/form-dataでfile・format（zengin/csv）を受け取り、CSVの場合はmappingに列対応をJSONで指定する
+func (h *BankReconciliationHandler) ImportStatement(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.ImportBankStatementRequest
+	if err := c.ShouldBind(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "明細形式の指定が正しくありません")
+		return
+	}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		req.Mapping = &bankstatement.CSVMapping{}
+		if err := json.Unmarshal([]byte(mapping), req.Mapping); err != nil {
+			RespondError(c, http.StatusBadRequest, "CSVの列対応の形式が正しくありません")
+			return
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "明細ファイルを指定してください")
+		return
+	}
+	if fileHeader.Size > maxBankStatementSize {
+		RespondError(c, http.StatusBadRequest, "明細ファイルのサイズが大きすぎます（上限10MB）")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		HandleError(c, http.StatusInternalServerError, "明細ファイルを開けませんでした", h.logger, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.reconciliationService.ImportStatement(c.Request.Context(), userID, fileHeader.Filename, file, &req)
+	if err != nil {
+		HandleAccountingError(c, "明細の取込に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "明細を取込みました", gin.H{
+		"import": result,
+	})
+}
+
+// ListImports 明細の取込履歴を取得
+func (h *BankReconciliationHandler) ListImports(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	imports, total, err := h.reconciliationService.ListImports(c.Request.Context(), page, limit)
+	if err != nil {
+		HandleAccountingError(c, "取込履歴の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"imports": imports,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// ListTransactions 入出金明細を検索
+func (h *BankReconciliationHandler) ListTransactions(c *gin.Context) {
+	var req dto.BankTransactionSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "検索条件が正しくありません")
+		return
+	}
+
+	transactions, total, err := h.reconciliationService.ListTransactions(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "入出金明細の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"transactions": transactions,
+		"total":        total,
+		"page":         req.Page,
+		"limit":        req.Limit,
+	})
+}
+
+// GetTransaction 入出金明細を候補の請求書を含めて取得
+func (h *BankReconciliationHandler) GetTransaction(c *gin.Context) {
+	id, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	transaction, err := h.reconciliationService.GetTransaction(c.Request.Context(), id)
+	if err != nil {
+		HandleAccountingError(c, "入出金明細の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"transaction": transaction,
+	})
+}
+
+// GetReviewQueue 確認待ちと未照合の入金を取得
+func (h *BankReconciliationHandler) GetReviewQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	transactions, total, err := h.reconciliationService.GetReviewQueue(c.Request.Context(), page, limit)
+	if err != nil {
+		HandleAccountingError(c, "確認待ちの入金の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"transactions": transactions,
+		"total":        total,
+		"page":         page,
+		"limit":        limit,
+	})
+}
+
+// ConfirmMatch 入金の割当を確定
+func (h *BankReconciliationHandler) ConfirmMatch(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	id, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ConfirmBankMatchRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+			return
+		}
+	}
+
+	transaction, err := h.reconciliationService.ConfirmMatch(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		HandleAccountingError(c, "消込の確定に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "消込を確定しました", gin.H{
+		"transaction": transaction,
+	})
+}
+
+// IgnoreTransaction 入金を消込対象外にする
+func (h *BankReconciliationHandler) IgnoreTransaction(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	id, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.IgnoreBankTransactionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+			return
+		}
+	}
+
+	transaction, err := h.reconciliationService.IgnoreTransaction(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		HandleAccountingError(c, "入金の除外に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "入金を消込対象外にしました", gin.H{
+		"transaction": transaction,
+	})
+}
@@ -104,13 +104,19 @@ func (h *BillingHandler) GetBillingSummary(c *gin.Context) {
 	}
 
 	// 対象月の形式チェック (YYYY-MM)
-	if _, err := time.Parse("2006-01", targetMonth); err != nil {
+	month, err := time.Parse("2006-01", targetMonth)
+	if err != nil {
 		utils.RespondError(c, http.StatusBadRequest, "対象月の形式が正しくありません（YYYY-MM）")
 		return
 	}
 
-	// 現在は実装されていないため、エラーを返す
-	utils.RespondError(c, http.StatusNotImplemented, "この機能は現在実装中です")
+	summary, err := h.billingService.GetBillingSummary(c.Request.Context(), month.Year(), int(month.Month()))
+	if err != nil {
+		HandleAccountingError(c, "請求サマリーの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
 }
 
 // CalculateBilling 請求額計算
@@ -130,14 +136,17 @@ func (h *BillingHandler) CalculateBilling(c *gin.Context) {
 
 // GetBillingHistory 請求履歴取得
 // @Summary 請求履歴取得
-// @Description クライアントまたはプロジェクトの請求履歴を取得します
+// @Description 確定した請求計算のスナップショットを取引先・案件グループ・エンジニア・月で絞り込んで取得します
 // @Tags Billing
 // @Accept json
 // @Produce json
 // @Param client_id query string false "クライアントID"
+// @Param project_group_id query string false "プロジェクトグループID"
 // @Param project_id query string false "プロジェクトID"
+// @Param user_id query string false "エンジニアのユーザーID"
 // @Param from query string false "開始月（YYYY-MM）"
 // @Param to query string false "終了月（YYYY-MM）"
+// @Param all_revisions query bool false "再計算前の版も含める"
 // @Param page query int false "ページ番号" default(1)
 // @Param limit query int false "取得件数" default(20)
 // @Success 200 {object} dto.BillingHistoryResponse "請求履歴"
@@ -145,8 +154,54 @@ func (h *BillingHandler) CalculateBilling(c *gin.Context) {
 // @Failure 500 {object} utils.ErrorResponse "サーバーエラー"
 // @Router /api/v1/billing/history [get]
 func (h *BillingHandler) GetBillingHistory(c *gin.Context) {
-	// 現在は実装されていないため、エラーを返す
-	utils.RespondError(c, http.StatusNotImplemented, "この機能は現在実装中です")
+	var req dto.BillingHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "検索条件の形式が正しくありません")
+		return
+	}
+
+	history, err := h.billingService.GetBillingHistory(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "請求履歴の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetBillingCalculationRevisions 請求計算の版の一覧取得
+// @Summary 請求計算の版の一覧取得
+// @Description 同じアサイン・請求月の請求計算を古い版から順に、前の版からの変更点とあわせて取得します
+// @Tags Billing
+// @Accept json
+// @Produce json
+// @Param id path string true "アサインID"
+// @Param month query string true "請求月（YYYY-MM）"
+// @Success 200 {object} dto.BillingCalculationRevisionsResponse "請求計算の版の一覧"
+// @Failure 400 {object} utils.ErrorResponse "リクエストエラー"
+// @Failure 404 {object} utils.ErrorResponse "請求計算の履歴が見つかりません"
+// @Failure 500 {object} utils.ErrorResponse "サーバーエラー"
+// @Router /api/v1/billing/history/assignments/{id} [get]
+func (h *BillingHandler) GetBillingCalculationRevisions(c *gin.Context) {
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	billingMonth := c.Query("month")
+	if billingMonth == "" {
+		utils.RespondError(c, http.StatusBadRequest, "請求月は必須です")
+		return
+	}
+
+	revisions, err := h.billingService.GetBillingCalculationRevisions(c.Request.Context(), assignmentID, billingMonth)
+	if err != nil {
+		HandleAccountingError(c, "請求計算の履歴の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
 }
 
 // GenerateInvoiceReport 請求レポート生成
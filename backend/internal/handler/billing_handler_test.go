@@ -18,6 +18,7 @@ import (
 
 	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // MockBillingService BillingServiceのモック実装
@@ -61,10 +62,19 @@ func (m *MockBillingService) CalculateProjectBilling(ctx context.Context, assign
 	return args.Get(0).(*dto.ProjectBillingDetail), args.Error(1)
 }
 
+// CalculateAssignmentCost 案件アサインの仕入額計算
+func (m *MockBillingService) CalculateAssignmentCost(ctx context.Context, assignment *model.ProjectAssignment, year, month int) (*dto.AssignmentCostDetail, error) {
+	args := m.Called(ctx, assignment, year, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentCostDetail), args.Error(1)
+}
+
 // CalculateBillingAmount 請求額計算
-func (m *MockBillingService) CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate float64, actualHours, lowerLimit, upperLimit *float64) (float64, error) {
+func (m *MockBillingService) CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate money.Amount, actualHours, lowerLimit, upperLimit *float64) (money.Amount, error) {
 	args := m.Called(billingType, monthlyRate, actualHours, lowerLimit, upperLimit)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Amount), args.Error(1)
 }
 
 // GetBillingHistory 請求履歴取得
@@ -85,6 +95,15 @@ func (m *MockBillingService) GetClientBillingHistory(ctx context.Context, client
 	return args.Get(0).([]dto.BillingHistoryItemDTO), args.Error(1)
 }
 
+// GetBillingCalculationRevisions 請求計算の版履歴取得
+func (m *MockBillingService) GetBillingCalculationRevisions(ctx context.Context, assignmentID, billingMonth string) (*dto.BillingCalculationRevisionsResponse, error) {
+	args := m.Called(ctx, assignmentID, billingMonth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.BillingCalculationRevisionsResponse), args.Error(1)
+}
+
 // ValidateBillingPeriod 請求期間バリデーション
 func (m *MockBillingService) ValidateBillingPeriod(year, month int) error {
 	args := m.Called(year, month)
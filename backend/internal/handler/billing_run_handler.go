@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// BillingRunHandler 月次請求ハンドラー
+type BillingRunHandler struct {
+	billingRunService service.BillingRunServiceInterface
+	logger            *zap.Logger
+}
+
+// NewBillingRunHandler 月次請求ハンドラーのコンストラクタ
+func NewBillingRunHandler(billingRunService service.BillingRunServiceInterface, logger *zap.Logger) *BillingRunHandler {
+	return &BillingRunHandler{
+		billingRunService: billingRunService,
+		logger:            logger,
+	}
+}
+
+// Execute 月次請求を実行（承認前は請求書の下書きを作り直す）
+func (h *BillingRunHandler) Execute(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.ExecuteBillingRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "請求月の指定が正しくありません")
+		return
+	}
+
+	run, err := h.billingRunService.Execute(c.Request.Context(), &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "月次請求の実行に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "請求書の下書きを作成しました", gin.H{
+		"billing_run": run,
+	})
+}
+
+// GetRun 月次請求と前月との差分を取得
+func (h *BillingRunHandler) GetRun(c *gin.Context) {
+	month, ok := h.parseMonth(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.billingRunService.GetRun(c.Request.Context(), month.Year(), int(month.Month()))
+	if err != nil {
+		HandleAccountingError(c, "月次請求の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"billing_run": run,
+	})
+}
+
+// Approve 月次請求を承認してロック
+func (h *BillingRunHandler) Approve(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	month, ok := h.parseMonth(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.billingRunService.Approve(c.Request.Context(), month.Year(), int(month.Month()), userID)
+	if err != nil {
+		HandleAccountingError(c, "月次請求の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "月次請求を承認しました", gin.H{
+		"billing_run": run,
+	})
+}
+
+// parseMonth パスパラメータの請求月（YYYY-MM）を解析
+func (h *BillingRunHandler) parseMonth(c *gin.Context) (time.Time, bool) {
+	month, err := time.Parse("2006-01", c.Param("month"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "請求月はYYYY-MM形式で指定してください")
+		return time.Time{}, false
+	}
+	return month, true
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// BusinessPartnerHandler ビジネスパートナー（仕入）ハンドラー
+type BusinessPartnerHandler struct {
+	partnerService service.BusinessPartnerServiceInterface
+	logger         *zap.Logger
+}
+
+// NewBusinessPartnerHandler ビジネスパートナー（仕入）ハンドラーのコンストラクタ
+func NewBusinessPartnerHandler(partnerService service.BusinessPartnerServiceInterface, logger *zap.Logger) *BusinessPartnerHandler {
+	return &BusinessPartnerHandler{
+		partnerService: partnerService,
+		logger:         logger,
+	}
+}
+
+// ListPartners ビジネスパートナー一覧を取得
+func (h *BusinessPartnerHandler) ListPartners(c *gin.Context) {
+	partners, err := h.partnerService.ListPartners(c.Request.Context(), c.Query("active") == "true")
+	if err != nil {
+		HandleAccountingError(c, "ビジネスパートナーの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"partners": partners,
+	})
+}
+
+// CreatePartner ビジネスパートナーを登録
+func (h *BusinessPartnerHandler) CreatePartner(c *gin.Context) {
+	var req dto.BusinessPartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "ビジネスパートナーの入力内容が正しくありません")
+		return
+	}
+
+	partner, err := h.partnerService.CreatePartner(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "ビジネスパートナーの登録に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "ビジネスパートナーを登録しました", gin.H{
+		"partner": partner,
+	})
+}
+
+// UpdatePartner ビジネスパートナーを更新
+func (h *BusinessPartnerHandler) UpdatePartner(c *gin.Context) {
+	partnerID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.BusinessPartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "ビジネスパートナーの入力内容が正しくありません")
+		return
+	}
+
+	partner, err := h.partnerService.UpdatePartner(c.Request.Context(), partnerID, &req)
+	if err != nil {
+		HandleAccountingError(c, "ビジネスパートナーの更新に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "ビジネスパートナーを更新しました", gin.H{
+		"partner": partner,
+	})
+}
+
+// ListAssignmentCosts 案件アサインの仕入単価を取得
+func (h *BusinessPartnerHandler) ListAssignmentCosts(c *gin.Context) {
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	costs, err := h.partnerService.ListAssignmentCosts(c.Request.Context(), assignmentID)
+	if err != nil {
+		HandleAccountingError(c, "仕入単価の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"costs": costs,
+	})
+}
+
+// CreateAssignmentCost 仕入単価を登録
+func (h *BusinessPartnerHandler) CreateAssignmentCost(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ProjectAssignmentCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "仕入単価の入力内容が正しくありません")
+		return
+	}
+
+	cost, err := h.partnerService.CreateAssignmentCost(c.Request.Context(), assignmentID, &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "仕入単価の登録に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "仕入単価を登録しました", gin.H{
+		"cost": cost,
+	})
+}
+
+// UpdateAssignmentCost 仕入単価を変更
+func (h *BusinessPartnerHandler) UpdateAssignmentCost(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+	costID, err := ParseUUID(c, "cost_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ProjectAssignmentCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "仕入単価の入力内容が正しくありません")
+		return
+	}
+
+	cost, err := h.partnerService.UpdateAssignmentCost(c.Request.Context(), assignmentID, costID, &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "仕入単価の変更に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "仕入単価を変更しました", gin.H{
+		"cost": cost,
+	})
+}
+
+// DeleteAssignmentCost 仕入単価を削除
+func (h *BusinessPartnerHandler) DeleteAssignmentCost(c *gin.Context) {
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+	costID, err := ParseUUID(c, "cost_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	if err := h.partnerService.DeleteAssignmentCost(c.Request.Context(), assignmentID, costID); err != nil {
+		HandleAccountingError(c, "仕入単価の削除に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "仕入単価を削除しました", nil)
+}
+
+// IssuePurchaseOrders 対象月の注文書を一括発行
+func (h *BusinessPartnerHandler) IssuePurchaseOrders(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.IssuePurchaseOrdersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "対象年月を指定してください")
+		return
+	}
+
+	result, err := h.partnerService.IssuePurchaseOrders(c.Request.Context(), req.Year, req.Month, userID)
+	if err != nil {
+		HandleAccountingError(c, "注文書の発行に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "注文書を発行しました", gin.H{
+		"result": result,
+	})
+}
+
+// ListPurchaseOrders 注文書一覧を取得
+func (h *BusinessPartnerHandler) ListPurchaseOrders(c *gin.Context) {
+	orders, err := h.partnerService.ListPurchaseOrders(c.Request.Context(), c.Query("month"), c.Query("partner_id"))
+	if err != nil {
+		HandleAccountingError(c, "注文書の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"orders": orders,
+	})
+}
+
+// RegisterPartnerInvoice 受領した請求書を登録して突合
+func (h *BusinessPartnerHandler) RegisterPartnerInvoice(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.PartnerInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "請求書の入力内容が正しくありません")
+		return
+	}
+
+	invoice, err := h.partnerService.RegisterPartnerInvoice(c.Request.Context(), &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "受領した請求書の登録に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "受領した請求書を登録しました", gin.H{
+		"invoice": invoice,
+	})
+}
+
+// ListPartnerInvoices 受領した請求書一覧を取得
+func (h *BusinessPartnerHandler) ListPartnerInvoices(c *gin.Context) {
+	invoices, err := h.partnerService.ListPartnerInvoices(c.Request.Context(), c.Query("month"), c.Query("status"))
+	if err != nil {
+		HandleAccountingError(c, "受領した請求書の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"invoices": invoices,
+	})
+}
+
+// ApprovePartnerInvoice 受領した請求書を承認
+func (h *BusinessPartnerHandler) ApprovePartnerInvoice(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	invoiceID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	invoice, err := h.partnerService.ApprovePartnerInvoice(c.Request.Context(), invoiceID, userID)
+	if err != nil {
+		HandleAccountingError(c, "受領した請求書の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "受領した請求書を承認しました", gin.H{
+		"invoice": invoice,
+	})
+}
+
+// RejectPartnerInvoice 受領した請求書を差戻し
+func (h *BusinessPartnerHandler) RejectPartnerInvoice(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	invoiceID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.RejectPartnerInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "差戻し理由を入力してください")
+		return
+	}
+
+	invoice, err := h.partnerService.RejectPartnerInvoice(c.Request.Context(), invoiceID, req.Reason, userID)
+	if err != nil {
+		HandleAccountingError(c, "受領した請求書の差戻しに失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "受領した請求書を差し戻しました", gin.H{
+		"invoice": invoice,
+	})
+}
+
+// GetMarginReport 粗利レポートを取得
+func (h *BusinessPartnerHandler) GetMarginReport(c *gin.Context) {
+	var req dto.MarginReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "集計期間と集計単位を正しく指定してください")
+		return
+	}
+
+	report, err := h.partnerService.GetMarginReport(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "粗利レポートの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"report": report,
+	})
+}
@@ -0,0 +1,514 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/duesk/monstera/internal/common/userutil"
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/service"
+	"github.com/duesk/monstera/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BusinessTripHandler 出張申請・出張精算ハンドラー
+type BusinessTripHandler struct {
+	tripService service.BusinessTripService
+	logger      *zap.Logger
+}
+
+// NewBusinessTripHandler 出張申請・出張精算ハンドラーのインスタンスを生成
+func NewBusinessTripHandler(
+	tripService service.BusinessTripService,
+	logger *zap.Logger,
+) *BusinessTripHandler {
+	return &BusinessTripHandler{
+		tripService: tripService,
+		logger:      logger,
+	}
+}
+
+// ListMyTrips 自分の出張申請一覧を取得
+// @Summary 自分の出張申請一覧を取得
+// @Tags Business Trips
+// @Produce json
+// @Param status query string false "ステータス（カンマ区切り）"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} dto.BusinessTripsResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips [get]
+func (h *BusinessTripHandler) ListMyTrips(c *gin.Context) {
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.ListMyTrips(c.Request.Context(), userID, parseBusinessTripFilter(c))
+	if err != nil {
+		HandleAccountingError(c, "出張申請の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMyTrip 自分の出張申請を取得
+// @Summary 自分の出張申請を取得
+// @Tags Business Trips
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips/{id} [get]
+func (h *BusinessTripHandler) GetMyTrip(c *gin.Context) {
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.GetMyTrip(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		HandleAccountingError(c, "出張申請の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateTrip 出張申請を作成
+// @Summary 出張申請を作成
+// @Description 目的・出張先・日程・概算費用・顧客案件を指定して事前承認を申請します
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param request body dto.BusinessTripRequest true "出張申請"
+// @Success 201 {object} dto.BusinessTripResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips [post]
+func (h *BusinessTripHandler) CreateTrip(c *gin.Context) {
+	var req dto.BusinessTripRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.CreateTrip(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "出張申請の作成に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateTrip 出張申請を更新
+// @Summary 出張申請を更新
+// @Description 事前承認前の出張申請のみ更新できます
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripRequest true "出張申請"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips/{id} [put]
+func (h *BusinessTripHandler) UpdateTrip(c *gin.Context) {
+	var req dto.BusinessTripRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.UpdateTrip(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張申請の更新に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CancelTrip 出張申請を取り消す
+// @Summary 出張申請を取り消す
+// @Description 精算の申請前まで取り消せます
+// @Tags Business Trips
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips/{id}/cancel [post]
+func (h *BusinessTripHandler) CancelTrip(c *gin.Context) {
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.CancelTrip(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		HandleAccountingError(c, "出張申請の取り消しに失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SubmitSettlement 出張精算を申請
+// @Summary 出張精算を申請
+// @Description 出張で使った経費申請を選び、日当の単価表から計算した日当とあわせて精算を申請します
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripSettlementRequest true "精算する経費申請"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/business-trips/{id}/settlement [post]
+func (h *BusinessTripHandler) SubmitSettlement(c *gin.Context) {
+	var req dto.BusinessTripSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.SubmitSettlement(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張精算の申請に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListTrips 出張申請一覧を取得（管理者用）
+// @Summary 出張申請一覧を取得
+// @Description 事前承認待ちはstatus=pending、精算の承認待ちはstatus=settlement_pendingで絞り込みます
+// @Tags Business Trips
+// @Produce json
+// @Param status query string false "ステータス（カンマ区切り）"
+// @Param user_id query string false "申請者ID"
+// @Param project_id query string false "案件ID"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} dto.BusinessTripsResponse
+// @Router /api/v1/admin/engineers/business-trips [get]
+func (h *BusinessTripHandler) ListTrips(c *gin.Context) {
+	filter := parseBusinessTripFilter(c)
+	if userID := c.Query("user_id"); userID != "" {
+		filter.UserID = &userID
+	}
+
+	response, err := h.tripService.ListTrips(c.Request.Context(), filter)
+	if err != nil {
+		HandleAccountingError(c, "出張申請の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTrip 出張申請を取得（管理者用）
+// @Summary 出張申請を取得
+// @Description 精算の申請後は精算額と概算費用の差を含みます
+// @Tags Business Trips
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/business-trips/{id} [get]
+func (h *BusinessTripHandler) GetTrip(c *gin.Context) {
+	response, err := h.tripService.GetTrip(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		HandleAccountingError(c, "出張申請の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveTrip 出張を事前承認
+// @Summary 出張を事前承認
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripReviewRequest false "コメント"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/business-trips/{id}/approve [put]
+func (h *BusinessTripHandler) ApproveTrip(c *gin.Context) {
+	var req dto.BusinessTripReviewRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.ApproveTrip(c.Request.Context(), approverID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張申請の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RejectTrip 出張申請を却下
+// @Summary 出張申請を却下
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripRejectRequest true "却下理由"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/business-trips/{id}/reject [put]
+func (h *BusinessTripHandler) RejectTrip(c *gin.Context) {
+	var req dto.BusinessTripRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "却下理由を入力してください")
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.RejectTrip(c.Request.Context(), approverID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張申請の却下に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveSettlement 出張精算を承認
+// @Summary 出張精算を承認
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripReviewRequest false "コメント"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/business-trips/{id}/settlement/approve [put]
+func (h *BusinessTripHandler) ApproveSettlement(c *gin.Context) {
+	var req dto.BusinessTripReviewRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.ApproveSettlement(c.Request.Context(), approverID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張精算の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ReturnSettlement 出張精算を差し戻す
+// @Summary 出張精算を差し戻す
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "出張申請ID"
+// @Param request body dto.BusinessTripRejectRequest true "差し戻し理由"
+// @Success 200 {object} dto.BusinessTripResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/business-trips/{id}/settlement/return [put]
+func (h *BusinessTripHandler) ReturnSettlement(c *gin.Context) {
+	var req dto.BusinessTripRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "差し戻し理由を入力してください")
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.ReturnSettlement(c.Request.Context(), approverID, c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "出張精算の差し戻しに失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPerDiemRates 日当の単価表を取得
+// @Summary 日当の単価表を取得
+// @Tags Business Trips
+// @Produce json
+// @Success 200 {object} dto.BusinessTripPerDiemRatesResponse
+// @Router /api/v1/admin/business-trip-per-diem-rates [get]
+func (h *BusinessTripHandler) GetPerDiemRates(c *gin.Context) {
+	response, err := h.tripService.ListPerDiemRates(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "日当の単価の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreatePerDiemRate 日当の単価を登録
+// @Summary 日当の単価を登録
+// @Description 出張の区分ごとに適用開始日を指定して登録します。精算時は出張の開始日時点で有効な単価を使います
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param request body dto.BusinessTripPerDiemRateRequest true "日当の単価"
+// @Success 201 {object} dto.BusinessTripPerDiemRateResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/admin/business-trip-per-diem-rates [post]
+func (h *BusinessTripHandler) CreatePerDiemRate(c *gin.Context) {
+	var req dto.BusinessTripPerDiemRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.tripService.CreatePerDiemRate(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "日当の単価の登録に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdatePerDiemRate 日当の単価を更新
+// @Summary 日当の単価を更新
+// @Tags Business Trips
+// @Accept json
+// @Produce json
+// @Param id path string true "日当の単価ID"
+// @Param request body dto.BusinessTripPerDiemRateRequest true "日当の単価"
+// @Success 200 {object} dto.BusinessTripPerDiemRateResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/business-trip-per-diem-rates/{id} [put]
+func (h *BusinessTripHandler) UpdatePerDiemRate(c *gin.Context) {
+	var req dto.BusinessTripPerDiemRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	response, err := h.tripService.UpdatePerDiemRate(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		HandleAccountingError(c, "日当の単価の更新に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeletePerDiemRate 日当の単価を削除
+// @Summary 日当の単価を削除
+// @Tags Business Trips
+// @Param id path string true "日当の単価ID"
+// @Success 204
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/business-trip-per-diem-rates/{id} [delete]
+func (h *BusinessTripHandler) DeletePerDiemRate(c *gin.Context) {
+	if err := h.tripService.DeletePerDiemRate(c.Request.Context(), c.Param("id")); err != nil {
+		HandleAccountingError(c, "日当の単価の削除に失敗しました", h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindOptionalJSON 省略可能なリクエストボディをバインド
+func (h *BusinessTripHandler) bindOptionalJSON(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return false
+	}
+	return true
+}
+
+// parseBusinessTripFilter クエリパラメータから出張申請の検索条件を作成
+func parseBusinessTripFilter(c *gin.Context) repository.BusinessTripFilter {
+	filter := repository.BusinessTripFilter{Page: 1, Limit: 20}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		filter.Limit = limit
+	}
+	if projectID := c.Query("project_id"); projectID != "" {
+		filter.ProjectID = &projectID
+	}
+	for _, status := range strings.Split(c.Query("status"), ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			filter.Statuses = append(filter.Statuses, model.BusinessTripStatus(status))
+		}
+	}
+	return filter
+}
@@ -0,0 +1,511 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/duesk/monstera/internal/common/userutil"
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+	"github.com/duesk/monstera/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CashAdvanceHandler 仮払金ハンドラー
+type CashAdvanceHandler struct {
+	advanceService service.CashAdvanceService
+	logger         *zap.Logger
+}
+
+// NewCashAdvanceHandler 仮払金ハンドラーのインスタンスを生成
+func NewCashAdvanceHandler(
+	advanceService service.CashAdvanceService,
+	logger *zap.Logger,
+) *CashAdvanceHandler {
+	return &CashAdvanceHandler{
+		advanceService: advanceService,
+		logger:         logger,
+	}
+}
+
+// GetCashAdvanceList 自分の仮払金一覧を取得
+// @Summary 自分の仮払金一覧を取得
+// @Tags Cash Advances
+// @Produce json
+// @Param status query string false "ステータス"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} dto.CashAdvanceListResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances [get]
+func (h *CashAdvanceHandler) GetCashAdvanceList(c *gin.Context) {
+	var filter dto.CashAdvanceFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "検索条件が不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.advanceService.List(c.Request.Context(), userID, &filter)
+	if err != nil {
+		HandleAccountingError(c, "仮払金一覧の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCashAdvance 自分の仮払金を取得
+// @Summary 自分の仮払金を取得
+// @Tags Cash Advances
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances/{id} [get]
+func (h *CashAdvanceHandler) GetCashAdvance(c *gin.Context) {
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.GetByID(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// CreateCashAdvance 仮払金を申請
+// @Summary 仮払金を申請
+// @Description 長期出張や機材購入などで事前に必要な金額を申請します。承認後に経理が支払います
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param request body dto.CashAdvanceRequest true "仮払金の申請"
+// @Success 201 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances [post]
+func (h *CashAdvanceHandler) CreateCashAdvance(c *gin.Context) {
+	var req dto.CashAdvanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の申請に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CashAdvanceToResponse(advance))
+}
+
+// UpdateCashAdvance 仮払金を更新
+// @Summary 仮払金を更新
+// @Description 承認前の仮払金のみ更新できます
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceRequest true "仮払金の申請"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances/{id} [put]
+func (h *CashAdvanceHandler) UpdateCashAdvance(c *gin.Context) {
+	var req dto.CashAdvanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.Update(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の更新に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// CancelCashAdvance 仮払金を取り消す
+// @Summary 仮払金を取り消す
+// @Description 支払前まで取り消せます
+// @Tags Cash Advances
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances/{id}/cancel [post]
+func (h *CashAdvanceHandler) CancelCashAdvance(c *gin.Context) {
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.CancelCashAdvance(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の取り消しに失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// SubmitSettlement 仮払金の精算を申請
+// @Summary 仮払金の精算を申請
+// @Description 実際に使った経費申請を紐付けて精算を申請します。仮払金額との差額は返金または追加で支払われます
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceSettlementRequest true "精算に紐付ける経費申請"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/cash-advances/{id}/settlement [post]
+func (h *CashAdvanceHandler) SubmitSettlement(c *gin.Context) {
+	var req dto.CashAdvanceSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.SubmitSettlement(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の精算の申請に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// GetAllCashAdvances 仮払金一覧を取得（管理者用）
+// @Summary 仮払金一覧を取得
+// @Description 承認待ちはstatus=pending、精算の承認待ちはstatus=settlement_pendingで絞り込みます
+// @Tags Cash Advances
+// @Produce json
+// @Param status query string false "ステータス"
+// @Param user_id query string false "申請者ID"
+// @Param page query int false "ページ番号"
+// @Param limit query int false "1ページあたりの件数"
+// @Success 200 {object} dto.CashAdvanceListResponse
+// @Router /api/v1/admin/engineers/cash-advances [get]
+func (h *CashAdvanceHandler) GetAllCashAdvances(c *gin.Context) {
+	var filter dto.CashAdvanceFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "検索条件が不正です")
+		return
+	}
+
+	response, err := h.advanceService.ListAll(c.Request.Context(), &filter)
+	if err != nil {
+		HandleAccountingError(c, "仮払金一覧の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCashAdvanceForAdmin 仮払金を取得（管理者用）
+// @Summary 仮払金を取得
+// @Tags Cash Advances
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/cash-advances/{id} [get]
+func (h *CashAdvanceHandler) GetCashAdvanceForAdmin(c *gin.Context) {
+	advance, err := h.advanceService.GetByIDForAdmin(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		HandleAccountingError(c, "仮払金の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// ApproveCashAdvance 仮払金を承認
+// @Summary 仮払金を承認
+// @Description 経費申請と同じ承認フローの現在の段階の承認者（または代理承認者）が承認する。全段階の承認で支払待ちになる
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceReviewRequest false "コメント"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/cash-advances/{id}/approve [put]
+func (h *CashAdvanceHandler) ApproveCashAdvance(c *gin.Context) {
+	var req dto.CashAdvanceReviewRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.ApproveCashAdvance(c.Request.Context(), c.Param("id"), approverID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// RejectCashAdvance 仮払金を却下
+// @Summary 仮払金を却下
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceRejectRequest true "却下理由"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/cash-advances/{id}/reject [put]
+func (h *CashAdvanceHandler) RejectCashAdvance(c *gin.Context) {
+	var req dto.CashAdvanceRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "却下理由を入力してください")
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.RejectCashAdvance(c.Request.Context(), c.Param("id"), approverID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の却下に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// ApproveSettlement 仮払金の精算を承認
+// @Summary 仮払金の精算を承認
+// @Description 紐付けた経費申請がすべて承認済みである必要があります。差額がなければそのまま精算完了になります
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceReviewRequest false "コメント"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/cash-advances/{id}/settlement/approve [put]
+func (h *CashAdvanceHandler) ApproveSettlement(c *gin.Context) {
+	var req dto.CashAdvanceReviewRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.ApproveSettlement(c.Request.Context(), c.Param("id"), approverID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の精算の承認に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// ReturnSettlement 仮払金の精算を差し戻す
+// @Summary 仮払金の精算を差し戻す
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvanceRejectRequest true "差し戻し理由"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/engineers/cash-advances/{id}/settlement/return [put]
+func (h *CashAdvanceHandler) ReturnSettlement(c *gin.Context) {
+	var req dto.CashAdvanceRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "差し戻し理由を入力してください")
+		return
+	}
+
+	approverID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.ReturnSettlement(c.Request.Context(), c.Param("id"), approverID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の精算の差し戻しに失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// RecordPayment 仮払金の支払を記録
+// @Summary 仮払金の支払を記録
+// @Description 承認済みの仮払金を支払済みにします。支払後は精算期限まで精算待ちになります
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvancePaymentRequest true "支払日"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/accounting/cash-advances/{id}/pay [post]
+func (h *CashAdvanceHandler) RecordPayment(c *gin.Context) {
+	var req dto.CashAdvancePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "支払日を入力してください")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.RecordPayment(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の支払の記録に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// CloseSettlement 精算差額の返金・追加支払の完了を記録
+// @Summary 精算差額の返金・追加支払の完了を記録
+// @Description 精算を承認した仮払金の差額の返金を受けた日、または追加で支払った日を記録して精算完了にします
+// @Tags Cash Advances
+// @Accept json
+// @Produce json
+// @Param id path string true "仮払金ID"
+// @Param request body dto.CashAdvancePaymentRequest true "返金日・支払日"
+// @Success 200 {object} dto.CashAdvanceResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Router /api/v1/admin/accounting/cash-advances/{id}/close [post]
+func (h *CashAdvanceHandler) CloseSettlement(c *gin.Context) {
+	var req dto.CashAdvancePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "精算日を入力してください")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	advance, err := h.advanceService.CloseSettlement(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "仮払金の精算完了の記録に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CashAdvanceToResponse(advance))
+}
+
+// GetBalances 未精算の仮払金を社員ごとに取得
+// @Summary 未精算の仮払金を社員ごとに取得
+// @Tags Cash Advances
+// @Produce json
+// @Success 200 {object} dto.CashAdvanceBalancesResponse
+// @Router /api/v1/admin/accounting/cash-advances/balances [get]
+func (h *CashAdvanceHandler) GetBalances(c *gin.Context) {
+	response, err := h.advanceService.GetBalances(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "未精算の仮払金の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendOverdueReminders 精算期限を過ぎた仮払金の督促を送る
+// @Summary 精算期限を過ぎた仮払金の督促を送る
+// @Description 毎日のバッチでも実行されます。前回の督促から一定期間が経っていない仮払金には送りません
+// @Tags Cash Advances
+// @Produce json
+// @Success 200 {object} dto.CashAdvanceReminderResult
+// @Router /api/v1/admin/accounting/cash-advances/reminders [post]
+func (h *CashAdvanceHandler) SendOverdueReminders(c *gin.Context) {
+	response, err := h.advanceService.SendOverdueReminders(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "仮払金の督促に失敗しました", h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindOptionalJSON 省略可能なリクエストボディをバインド
+func (h *CashAdvanceHandler) bindOptionalJSON(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return false
+	}
+	return true
+}
@@ -83,6 +83,7 @@ func convertDailyRecordRequestToModel(req *dto.DailyRecordRequest) (*model.Daily
 		ClientBreakTime: req.ClientBreakTime,
 		ClientWorkHours: req.ClientWorkHours,
 		HasClientWork:   req.HasClientWork,
+		ProjectID:       req.ProjectID,
 		Remarks:         req.Remarks,
 		IsHolidayWork:   req.IsHolidayWork,
 	}, nil
@@ -102,6 +103,7 @@ func convertDailyRecordToDTO(record *model.DailyRecord) *dto.DailyRecordResponse
 		ClientBreakTime: record.ClientBreakTime,
 		ClientWorkHours: record.ClientWorkHours,
 		HasClientWork:   record.HasClientWork,
+		ProjectID:       record.ProjectID,
 		Remarks:         record.Remarks,
 		IsHolidayWork:   record.IsHolidayWork,
 	}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// maxStoredDocumentSize 保存可能な文書ファイルの上限（10MB）
+const maxStoredDocumentSize = 10 << 20
+
+// DocumentStoreHandler 電子帳簿保存ハンドラー
+type DocumentStoreHandler struct {
+	documentService service.DocumentStoreServiceInterface
+	logger          *zap.Logger
+}
+
+// NewDocumentStoreHandler 電子帳簿保存ハンドラーのコンストラクタ
+func NewDocumentStoreHandler(documentService service.DocumentStoreServiceInterface, logger *zap.Logger) *DocumentStoreHandler {
+	return &DocumentStoreHandler{
+		documentService: documentService,
+		logger:          logger,
+	}
+}
+
+// StoreDocument 文書を登録
+// multipart/form-dataでfile・document_type・transaction_date・amount・counterpartyを受け取る
+func (h *DocumentStoreHandler) StoreDocument(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.StoreDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "文書の検索項目が正しくありません")
+		return
+	}
+	fileHeader, content, ok := h.readUploadedFile(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.documentService.Store(c.Request.Context(), userID, &req,
+		fileHeader.Filename, fileHeader.Header.Get("Content-Type"), content)
+	if err != nil {
+		HandleAccountingError(c, "文書の登録に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "文書を登録しました", gin.H{
+		"document": document,
+	})
+}
+
+// ReplaceDocument 文書を新しい版に差し替え
+func (h *DocumentStoreHandler) ReplaceDocument(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	documentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ReplaceDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "差し替えの理由を入力してください")
+		return
+	}
+	fileHeader, content, ok := h.readUploadedFile(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.documentService.Replace(c.Request.Context(), documentID, userID, &req,
+		fileHeader.Filename, fileHeader.Header.Get("Content-Type"), content)
+	if err != nil {
+		HandleAccountingError(c, "文書の差し替えに失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "文書を差し替えました", gin.H{
+		"document": document,
+	})
+}
+
+// ArchiveInvoice 発行済みの請求書のPDFを保存
+func (h *DocumentStoreHandler) ArchiveInvoice(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	invoiceID, err := ParseUUID(c, "invoice_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	document, err := h.documentService.ArchiveInvoice(c.Request.Context(), invoiceID, userID)
+	if err != nil {
+		HandleAccountingError(c, "請求書の保存に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "請求書を保存しました", gin.H{
+		"document": document,
+	})
+}
+
+// ArchiveExpenseReceipt 経費の領収書を保存
+func (h *DocumentStoreHandler) ArchiveExpenseReceipt(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	receiptID, err := ParseUUID(c, "receipt_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ArchiveReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "領収書の発行者を入力してください")
+		return
+	}
+
+	document, err := h.documentService.ArchiveExpenseReceipt(c.Request.Context(), receiptID, userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "領収書の保存に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "領収書を保存しました", gin.H{
+		"document": document,
+	})
+}
+
+// SearchDocuments 取引年月日・金額・取引先で文書を検索
+func (h *DocumentStoreHandler) SearchDocuments(c *gin.Context) {
+	var req dto.DocumentSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "検索条件が正しくありません")
+		return
+	}
+
+	result, err := h.documentService.Search(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "文書の検索に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"documents": result.Documents,
+		"total":     result.Total,
+		"page":      result.Page,
+		"limit":     result.Limit,
+	})
+}
+
+// GetDocument 文書と全ての版を取得
+func (h *DocumentStoreHandler) GetDocument(c *gin.Context) {
+	documentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	document, err := h.documentService.GetDocument(c.Request.Context(), documentID)
+	if err != nil {
+		HandleAccountingError(c, "文書の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"document": document,
+	})
+}
+
+// DownloadDocument 文書本体をダウンロード（登録時のハッシュを照合して返す）
+func (h *DocumentStoreHandler) DownloadDocument(c *gin.Context) {
+	documentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	file, err := h.documentService.GetContent(c.Request.Context(), documentID)
+	if err != nil {
+		HandleAccountingError(c, "文書の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(file.FileName)))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// DisposeDocument 保存期間が経過した文書を廃棄
+func (h *DocumentStoreHandler) DisposeDocument(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	documentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	document, err := h.documentService.Dispose(c.Request.Context(), documentID, userID)
+	if err != nil {
+		HandleAccountingError(c, "文書の廃棄に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "文書を廃棄しました", gin.H{
+		"document": document,
+	})
+}
+
+// VerifyChain ハッシュチェーンを検証
+// content=trueを指定すると文書本体のハッシュも照合する
+func (h *DocumentStoreHandler) VerifyChain(c *gin.Context) {
+	verifyContent := c.Query("content") == "true"
+
+	result, err := h.documentService.VerifyChain(c.Request.Context(), verifyContent)
+	if err != nil {
+		HandleAccountingError(c, "ハッシュチェーンの検証に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"verification": result,
+	})
+}
+
+// readUploadedFile アップロードされた文書ファイルを読み込む
+func (h *DocumentStoreHandler) readUploadedFile(c *gin.Context) (*multipart.FileHeader, []byte, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "文書ファイルを指定してください")
+		return nil, nil, false
+	}
+	if fileHeader.Size > maxStoredDocumentSize {
+		RespondError(c, http.StatusBadRequest, "文書ファイルのサイズが大きすぎます（上限10MB）")
+		return nil, nil, false
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		HandleError(c, http.StatusInternalServerError, "文書ファイルを開けませんでした", h.logger, err)
+		return nil, nil, false
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		HandleError(c, http.StatusInternalServerError, "文書ファイルを読み込めませんでした", h.logger, err)
+		return nil, nil, false
+	}
+	return fileHeader, content, true
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/duesk/monstera/internal/common/userutil"
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+	"github.com/duesk/monstera/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ExpenseApprovalRuleHandler 経費申請の承認ルールハンドラー
+type ExpenseApprovalRuleHandler struct {
+	ruleService service.ExpenseApprovalRuleService
+	logger      *zap.Logger
+}
+
+// NewExpenseApprovalRuleHandler 経費申請の承認ルールハンドラーのインスタンスを生成
+func NewExpenseApprovalRuleHandler(
+	ruleService service.ExpenseApprovalRuleService,
+	logger *zap.Logger,
+) *ExpenseApprovalRuleHandler {
+	return &ExpenseApprovalRuleHandler{
+		ruleService: ruleService,
+		logger:      logger,
+	}
+}
+
+// GetRules 承認ルール一覧を取得
+// @Summary 承認ルール一覧を取得
+// @Description 経費申請の承認ルールを優先度順に取得します
+// @Tags Expense Approval Rules
+// @Produce json
+// @Param include_inactive query bool false "無効なルールも含める"
+// @Success 200 {object} dto.ExpenseApprovalRulesResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/admin/expense-approval-rules [get]
+func (h *ExpenseApprovalRuleHandler) GetRules(c *gin.Context) {
+	includeInactive := c.Query("include_inactive") == "true"
+
+	response, err := h.ruleService.ListRules(c.Request.Context(), includeInactive)
+	if err != nil {
+		h.respondServiceError(c, "Failed to get expense approval rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRule 承認ルールを取得
+// @Summary 承認ルールを取得
+// @Tags Expense Approval Rules
+// @Produce json
+// @Param id path string true "ルールID"
+// @Success 200 {object} dto.ExpenseApprovalRuleResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/expense-approval-rules/{id} [get]
+func (h *ExpenseApprovalRuleHandler) GetRule(c *gin.Context) {
+	ruleID := c.Param("id")
+	if ruleID == "" {
+		utils.RespondError(c, http.StatusBadRequest, "ルールIDが不正です")
+		return
+	}
+
+	response, err := h.ruleService.GetRule(c.Request.Context(), ruleID)
+	if err != nil {
+		h.respondServiceError(c, "Failed to get expense approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateRule 承認ルールを作成
+// @Summary 承認ルールを作成
+// @Description 金額・カテゴリ・部署・プロジェクトの条件と承認者の段階を設定します
+// @Tags Expense Approval Rules
+// @Accept json
+// @Produce json
+// @Param request body dto.ExpenseApprovalRuleRequest true "承認ルールリクエスト"
+// @Success 201 {object} dto.ExpenseApprovalRuleResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /api/v1/admin/expense-approval-rules [post]
+func (h *ExpenseApprovalRuleHandler) CreateRule(c *gin.Context) {
+	var req dto.ExpenseApprovalRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	userID, ok := userutil.GetUserIDFromContext(c, h.logger)
+	if !ok {
+		utils.RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	response, err := h.ruleService.CreateRule(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondServiceError(c, "Failed to create expense approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateRule 承認ルールを更新
+// @Summary 承認ルールを更新
+// @Description 提出済みの経費申請の承認フローは変わりません
+// @Tags Expense Approval Rules
+// @Accept json
+// @Produce json
+// @Param id path string true "ルールID"
+// @Param request body dto.ExpenseApprovalRuleRequest true "承認ルールリクエスト"
+// @Success 200 {object} dto.ExpenseApprovalRuleResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/expense-approval-rules/{id} [put]
+func (h *ExpenseApprovalRuleHandler) UpdateRule(c *gin.Context) {
+	ruleID := c.Param("id")
+	if ruleID == "" {
+		utils.RespondError(c, http.StatusBadRequest, "ルールIDが不正です")
+		return
+	}
+
+	var req dto.ExpenseApprovalRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	response, err := h.ruleService.UpdateRule(c.Request.Context(), ruleID, &req)
+	if err != nil {
+		h.respondServiceError(c, "Failed to update expense approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteRule 承認ルールを削除
+// @Summary 承認ルールを削除
+// @Tags Expense Approval Rules
+// @Param id path string true "ルールID"
+// @Success 204
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/v1/admin/expense-approval-rules/{id} [delete]
+func (h *ExpenseApprovalRuleHandler) DeleteRule(c *gin.Context) {
+	ruleID := c.Param("id")
+	if ruleID == "" {
+		utils.RespondError(c, http.StatusBadRequest, "ルールIDが不正です")
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(c.Request.Context(), ruleID); err != nil {
+		h.respondServiceError(c, "Failed to delete expense approval rule", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondServiceError サービスのエラーをHTTPステータスに変換して返す
+func (h *ExpenseApprovalRuleHandler) respondServiceError(c *gin.Context, logMessage string, err error) {
+	h.logger.Error(logMessage, zap.Error(err))
+
+	var expenseErr *dto.ExpenseError
+	if !errors.As(err, &expenseErr) {
+		utils.RespondError(c, http.StatusInternalServerError, "承認ルールの処理に失敗しました")
+		return
+	}
+	switch expenseErr.Code {
+	case dto.ErrCodeInvalidRequest:
+		utils.RespondError(c, http.StatusBadRequest, expenseErr.Message)
+	case dto.ErrCodeApprovalRuleNotFound:
+		utils.RespondError(c, http.StatusNotFound, expenseErr.Message)
+	default:
+		utils.RespondError(c, http.StatusInternalServerError, expenseErr.Message)
+	}
+}
@@ -678,6 +678,13 @@ func (h *ExpenseHandler) ApproveExpense(c *gin.Context) {
 			return
 		}
 
+		// 重複の可能性がある経費申請は承認理由が必要
+		var expenseErr *dto.ExpenseError
+		if errors.As(err, &expenseErr) && expenseErr.Code == dto.ErrCodeDuplicateOverrideRequired {
+			RespondStandardErrorWithCode(c, http.StatusBadRequest, constants.ErrDuplicateOverrideRequired, expenseErr.Message)
+			return
+		}
+
 		HandleStandardError(c, http.StatusInternalServerError, constants.ErrApprovalProcessFailed, "経費申請の承認に失敗しました", h.logger, err)
 		return
 	}
@@ -450,6 +450,15 @@ func (m *MockS3Service) GetFileURL(ctx context.Context, s3Key string) (string, e
     return args.String(0), args.Error(1)
 }
 
+// DownloadFile ファイル取得（インターフェース整合用）
+func (m *MockS3Service) DownloadFile(ctx context.Context, s3Key string) ([]byte, error) {
+    args := m.Called(ctx, s3Key)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]byte), args.Error(1)
+}
+
 // ValidateUploadedFile アップロードファイル検証（インターフェース整合用）
 func (m *MockS3Service) ValidateUploadedFile(ctx context.Context, s3Key string) error {
     args := m.Called(ctx, s3Key)
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// ExpenseReimbursementHandler 経費精算（総合振込）ハンドラー
+type ExpenseReimbursementHandler struct {
+	reimbursementService service.ExpenseReimbursementServiceInterface
+	logger               *zap.Logger
+}
+
+// NewExpenseReimbursementHandler 経費精算（総合振込）ハンドラーのコンストラクタ
+func NewExpenseReimbursementHandler(reimbursementService service.ExpenseReimbursementServiceInterface, logger *zap.Logger) *ExpenseReimbursementHandler {
+	return &ExpenseReimbursementHandler{
+		reimbursementService: reimbursementService,
+		logger:               logger,
+	}
+}
+
+// GetBankAccount 自分の振込先口座を取得
+func (h *ExpenseReimbursementHandler) GetBankAccount(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	account, err := h.reimbursementService.GetBankAccount(c.Request.Context(), userID)
+	if err != nil {
+		HandleAccountingError(c, "振込先口座の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"bank_account": account,
+	})
+}
+
+// SaveBankAccount 自分の振込先口座を登録・更新
+func (h *ExpenseReimbursementHandler) SaveBankAccount(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "振込先口座の入力内容が正しくありません")
+		return
+	}
+
+	account, err := h.reimbursementService.SaveBankAccount(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "振込先口座の保存に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "振込先口座を保存しました", gin.H{
+		"bank_account": account,
+	})
+}
+
+// ListMyReimbursements 自分の経費精算の振込一覧を取得
+func (h *ExpenseReimbursementHandler) ListMyReimbursements(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	items, err := h.reimbursementService.ListUserReimbursements(c.Request.Context(), userID)
+	if err != nil {
+		HandleAccountingError(c, "経費精算の振込一覧の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"reimbursements": items,
+	})
+}
+
+// GetMyStatement 自分の精算明細を取得
+func (h *ExpenseReimbursementHandler) GetMyStatement(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	statement, err := h.reimbursementService.GetStatement(c.Request.Context(), batchID, userID)
+	if err != nil {
+		HandleAccountingError(c, "精算明細の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"statement": statement,
+	})
+}
+
+// CreateBatch 振込バッチを作成
+func (h *ExpenseReimbursementHandler) CreateBatch(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.CreateReimbursementBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "振込日を指定してください")
+		return
+	}
+
+	batch, err := h.reimbursementService.CreateBatch(c.Request.Context(), &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "振込バッチの作成に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "振込バッチを作成しました", gin.H{
+		"batch": batch,
+	})
+}
+
+// ListBatches 振込バッチの一覧を取得
+func (h *ExpenseReimbursementHandler) ListBatches(c *gin.Context) {
+	batches, err := h.reimbursementService.ListBatches(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		HandleAccountingError(c, "振込バッチの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"batches": batches,
+	})
+}
+
+// GetBatch 振込バッチの詳細を取得
+func (h *ExpenseReimbursementHandler) GetBatch(c *gin.Context) {
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	batch, err := h.reimbursementService.GetBatch(c.Request.Context(), batchID)
+	if err != nil {
+		HandleAccountingError(c, "振込バッチの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"batch": batch,
+	})
+}
+
+// DownloadTransferFile 全銀協フォーマットの総合振込ファイルをダウンロード
+func (h *ExpenseReimbursementHandler) DownloadTransferFile(c *gin.Context) {
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	file, err := h.reimbursementService.GenerateTransferFile(c.Request.Context(), batchID)
+	if err != nil {
+		HandleAccountingError(c, "総合振込ファイルの作成に失敗しました", h.logger, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ConfirmBatch 振込を確定し経費申請を支払済みにする
+func (h *ExpenseReimbursementHandler) ConfirmBatch(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	batch, err := h.reimbursementService.ConfirmBatch(c.Request.Context(), batchID, userID)
+	if err != nil {
+		HandleAccountingError(c, "振込の確定に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "振込を確定しました", gin.H{
+		"batch": batch,
+	})
+}
+
+// CancelBatch 振込バッチを取り消す
+func (h *ExpenseReimbursementHandler) CancelBatch(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	batch, err := h.reimbursementService.CancelBatch(c.Request.Context(), batchID, userID)
+	if err != nil {
+		HandleAccountingError(c, "振込バッチの取消に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "振込バッチを取り消しました", gin.H{
+		"batch": batch,
+	})
+}
+
+// ListStatements 振込バッチの精算明細の一覧を取得
+func (h *ExpenseReimbursementHandler) ListStatements(c *gin.Context) {
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	statements, err := h.reimbursementService.ListStatements(c.Request.Context(), batchID)
+	if err != nil {
+		HandleAccountingError(c, "精算明細の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"statements": statements,
+	})
+}
+
+// GetStatement 社員の精算明細を取得
+func (h *ExpenseReimbursementHandler) GetStatement(c *gin.Context) {
+	batchID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+	userID, err := ParseUUID(c, "user_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	statement, err := h.reimbursementService.GetStatement(c.Request.Context(), batchID, userID)
+	if err != nil {
+		HandleAccountingError(c, "精算明細の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"statement": statement,
+	})
+}
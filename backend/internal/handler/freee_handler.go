@@ -2,128 +2,319 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/service"
 )
 
 // FreeeHandler Freee会計システム連携ハンドラー
 type FreeeHandler struct {
-	logger *zap.Logger
+	freeeService service.FreeeServiceInterface
+	logger       *zap.Logger
 }
 
 // NewFreeeHandler FreeeHandlerのインスタンスを生成
-func NewFreeeHandler(logger *zap.Logger) *FreeeHandler {
+func NewFreeeHandler(freeeService service.FreeeServiceInterface, logger *zap.Logger) *FreeeHandler {
 	return &FreeeHandler{
-		logger: logger,
+		freeeService: freeeService,
+		logger:       logger,
 	}
 }
 
-// SyncTransactions Freeeから取引データを同期
+// SyncTransactions 取引先と請求書をまとめてFreeeに同期
 func (h *FreeeHandler) SyncTransactions(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "Freee同期機能は準備中です", nil)
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.freeeService.SyncAllData(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, "Freeeとの同期に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result.Message, gin.H{
+		"result": result,
+	})
 }
 
 // GetCompanies Freeeの会社一覧を取得
 func (h *FreeeHandler) GetCompanies(c *gin.Context) {
-	// TODO: 実装予定
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	companies, err := h.freeeService.GetCompanies(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, "Freeeの会社一覧の取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"companies": []interface{}{},
+		"companies": companies,
 	})
 }
 
 // GetInvoices Freeeの請求書一覧を取得
 func (h *FreeeHandler) GetInvoices(c *gin.Context) {
-	// TODO: 実装予定
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+	from, to, ok := parseFreeeDateRange(c)
+	if !ok {
+		return
+	}
+
+	invoices, err := h.freeeService.GetInvoices(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.handleError(c, "Freeeの請求書一覧の取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"invoices": []interface{}{},
-		"total":    0,
+		"invoices": invoices,
+		"total":    len(invoices),
 	})
 }
 
 // CreateInvoice Freeeで請求書を作成
 func (h *FreeeHandler) CreateInvoice(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusCreated, "請求書作成機能は準備中です", nil)
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateFreeeInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	invoice, err := h.freeeService.CreateInvoice(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "Freeeでの請求書作成に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "請求書を作成しました", gin.H{
+		"invoice": invoice,
+	})
 }
 
 // UpdateInvoice Freeeの請求書を更新
 func (h *FreeeHandler) UpdateInvoice(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "請求書更新機能は準備中です", nil)
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	invoiceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || invoiceID <= 0 {
+		RespondError(c, http.StatusBadRequest, "請求書IDが不正です")
+		return
+	}
+
+	var req dto.UpdateFreeeInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	invoice, err := h.freeeService.UpdateInvoice(c.Request.Context(), userID, invoiceID, &req)
+	if err != nil {
+		h.handleError(c, "Freeeの請求書更新に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "請求書を更新しました", gin.H{
+		"invoice": invoice,
+	})
 }
 
-// GetPayments Freeeの支払い一覧を取得
+// GetPayments Freeeの入金一覧を取得
+// invoice_idを指定した場合はその請求書の入金のみを返す
 func (h *FreeeHandler) GetPayments(c *gin.Context) {
-	// TODO: 実装予定
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var (
+		payments []*dto.FreeePaymentDTO
+		err      error
+	)
+	if invoiceIDStr := c.Query("invoice_id"); invoiceIDStr != "" {
+		invoiceID, convErr := strconv.Atoi(invoiceIDStr)
+		if convErr != nil || invoiceID <= 0 {
+			RespondError(c, http.StatusBadRequest, "請求書IDが不正です")
+			return
+		}
+		payments, err = h.freeeService.GetInvoicePayments(c.Request.Context(), userID, invoiceID)
+	} else {
+		from, to, ok := parseFreeeDateRange(c)
+		if !ok {
+			return
+		}
+		payments, err = h.freeeService.GetPayments(c.Request.Context(), userID, from, to)
+	}
+	if err != nil {
+		h.handleError(c, "Freeeの入金一覧の取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"payments": []interface{}{},
-		"total":    0,
+		"payments": payments,
+		"total":    len(payments),
 	})
 }
 
-// CreatePayment Freeeで支払いを作成
+// CreatePayment Freeeで入金を登録
 func (h *FreeeHandler) CreatePayment(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusCreated, "支払い作成機能は準備中です", nil)
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateFreeePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	payment, err := h.freeeService.CreatePayment(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "Freeeへの入金登録に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "入金を登録しました", gin.H{
+		"payment": payment,
+	})
 }
 
 // GetConnectionStatus Freee接続状態を取得
 func (h *FreeeHandler) GetConnectionStatus(c *gin.Context) {
-	// TODO: 実装予定
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.freeeService.GetConnectionStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, "Freee接続状態の取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"connected":    false,
-		"company_name": "",
-		"last_sync":    nil,
+		"status": status,
 	})
 }
 
 // TestConnection Freee接続をテスト
 func (h *FreeeHandler) TestConnection(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "接続テスト機能は準備中です", gin.H{
-		"success": false,
-		"message": "Freee APIは未設定です",
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.freeeService.TestConnection(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, "Freee接続テストに失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result.Message, gin.H{
+		"result": result,
 	})
 }
 
 // DisconnectFreee Freee接続を解除
 func (h *FreeeHandler) DisconnectFreee(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "接続解除機能は準備中です", nil)
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.freeeService.DeleteFreeeSettings(c.Request.Context(), userID); err != nil {
+		h.handleError(c, "Freee接続の解除に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "Freeeとの接続を解除しました", nil)
 }
 
 // InitiateOAuth OAuth認証を開始
 func (h *FreeeHandler) InitiateOAuth(c *gin.Context) {
-	// TODO: 実装予定
-	// 実際の実装では、FreeeのOAuth認証URLにリダイレクトする
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		RedirectURI string `json:"redirect_uri" binding:"omitempty,url"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			RespondError(c, http.StatusBadRequest, "リダイレクトURIが不正です")
+			return
+		}
+	}
+
+	resp, err := h.freeeService.GenerateAuthURL(c.Request.Context(), userID, req.RedirectURI)
+	if err != nil {
+		h.handleError(c, "Freee認証URLの生成に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"auth_url": "https://accounts.secure.freee.co.jp/public_api/authorize",
-		"message":  "OAuth認証機能は準備中です",
+		"auth_url": resp.AuthURL,
+		"state":    resp.State,
 	})
 }
 
 // CompleteOAuth OAuth認証を完了
+// フロントエンドがコールバックで受け取ったcodeとstateを送信する
 func (h *FreeeHandler) CompleteOAuth(c *gin.Context) {
-	// OAuth callbackのパラメータ
-	code := c.Query("code")
-	state := c.Query("state")
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
 
-	if code == "" {
+	var req dto.FreeeOAuthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		RespondError(c, http.StatusBadRequest, "認証コードが不足しています")
 		return
 	}
 
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "OAuth認証完了機能は準備中です", gin.H{
-		"code":  code,
-		"state": state,
+	resp, err := h.freeeService.ExchangeCodeForToken(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "Freeeとの連携に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "Freeeと連携しました", gin.H{
+		"company_id":       resp.CompanyID,
+		"company_name":     resp.CompanyName,
+		"token_expires_at": resp.ExpiresAt,
 	})
 }
 
 // SelectCompany 会社を選択
 func (h *FreeeHandler) SelectCompany(c *gin.Context) {
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
 	var req struct {
 		CompanyID int `json:"company_id" binding:"required"`
 	}
@@ -133,44 +324,257 @@ func (h *FreeeHandler) SelectCompany(c *gin.Context) {
 		return
 	}
 
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "会社選択機能は準備中です", gin.H{
+	if err := h.freeeService.SelectCompany(c.Request.Context(), userID, req.CompanyID); err != nil {
+		h.handleError(c, "会社の選択に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "連携する会社を変更しました", gin.H{
 		"company_id": req.CompanyID,
 	})
 }
 
 // SyncPartners 取引先を同期
+// client_idsを指定しない場合は全取引先を同期する
 func (h *FreeeHandler) SyncPartners(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "取引先同期機能は準備中です", gin.H{
-		"synced_count": 0,
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if c.Request.ContentLength == 0 {
+		result, err := h.freeeService.SyncClients(c.Request.Context(), userID)
+		if err != nil {
+			h.handleError(c, "取引先の同期に失敗しました", err)
+			return
+		}
+		RespondSuccess(c, http.StatusOK, result.Message, gin.H{
+			"synced_count": result.SyncedItems,
+			"result":       result,
+		})
+		return
+	}
+
+	var req dto.FreeeClientSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	resp, err := h.freeeService.SyncSelectedClients(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "取引先の同期に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"synced_count": resp.SyncedClients,
+		"result":       resp,
 	})
 }
 
 // SyncInvoices 請求書を同期
+// invoice_idsを指定しない場合は未同期・同期失敗の請求書を同期する
 func (h *FreeeHandler) SyncInvoices(c *gin.Context) {
-	// TODO: 実装予定
-	RespondSuccess(c, http.StatusOK, "請求書同期機能は準備中です", gin.H{
-		"synced_count": 0,
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if c.Request.ContentLength == 0 {
+		result, err := h.freeeService.SyncInvoices(c.Request.Context(), userID)
+		if err != nil {
+			h.handleError(c, "請求書の同期に失敗しました", err)
+			return
+		}
+		RespondSuccess(c, http.StatusOK, result.Message, gin.H{
+			"synced_count": result.SyncedItems,
+			"result":       result,
+		})
+		return
+	}
+
+	var req dto.FreeeInvoiceSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	resp, err := h.freeeService.SyncSelectedInvoices(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "請求書の同期に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"synced_count": resp.SyncedInvoices,
+		"result":       resp,
 	})
 }
 
 // GetSyncHistory 同期履歴を取得
 func (h *FreeeHandler) GetSyncHistory(c *gin.Context) {
-	// TODO: 実装予定
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var syncType *model.FreeSyncType
+	if v := c.Query("sync_type"); v != "" {
+		t := model.FreeSyncType(v)
+		syncType = &t
+	}
+	var status *model.FreeSyncStatus
+	if v := c.Query("status"); v != "" {
+		s := model.FreeSyncStatus(v)
+		status = &s
+	}
+
+	history, total, err := h.freeeService.GetSyncHistory(c.Request.Context(), syncType, status, limit, (page-1)*limit)
+	if err != nil {
+		h.handleError(c, "同期履歴の取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"history": []interface{}{},
-		"total":   0,
+		"history": history,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
 	})
 }
 
 // GetSyncSummary 同期サマリーを取得
 func (h *FreeeHandler) GetSyncSummary(c *gin.Context) {
-	// TODO: 実装予定
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.freeeService.GetSyncSummary(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, "同期サマリーの取得に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"summary": summary,
+	})
+}
+
+// EnqueueSync 請求書・取引先の送信を同期キューに投入
+func (h *FreeeHandler) EnqueueSync(c *gin.Context) {
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.FreeeSyncEnqueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "リクエストが不正です")
+		return
+	}
+
+	resp, err := h.freeeService.EnqueueSync(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, "同期キューへの登録に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusAccepted, "同期キューに登録しました", gin.H{
+		"result": resp,
+	})
+}
+
+// GetSyncJobs 同期キューのジョブ一覧を取得
+func (h *FreeeHandler) GetSyncJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var status *model.FreeeSyncJobStatus
+	if v := c.Query("status"); v != "" {
+		s := model.FreeeSyncJobStatus(v)
+		status = &s
+	}
+
+	jobs, total, err := h.freeeService.ListSyncJobs(c.Request.Context(), status, limit, (page-1)*limit)
+	if err != nil {
+		h.handleError(c, "同期ジョブの取得に失敗しました", err)
+		return
+	}
+
 	RespondSuccess(c, http.StatusOK, "", gin.H{
-		"last_sync":      nil,
-		"total_partners": 0,
-		"total_invoices": 0,
-		"sync_status":    "not_started",
+		"jobs":  jobs,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// RequeueSyncJob 再試行上限に達したジョブを再投入
+func (h *FreeeHandler) RequeueSyncJob(c *gin.Context) {
+	userID, ok := h.requireUserID(c)
+	if !ok {
+		return
+	}
+	jobID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	job, err := h.freeeService.RequeueSyncJob(c.Request.Context(), jobID, userID)
+	if err != nil {
+		h.handleError(c, "同期ジョブの再投入に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "同期ジョブを再投入しました", gin.H{
+		"job": job,
 	})
 }
+
+// requireUserID コンテキストからユーザーIDを取得（取得できない場合は401を返す）
+func (h *FreeeHandler) requireUserID(c *gin.Context) (string, bool) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return "", false
+	}
+	return userID, true
+}
+
+// handleError 経理エラーはコードに応じたステータスで返す
+func (h *FreeeHandler) handleError(c *gin.Context, message string, err error) {
+	HandleAccountingError(c, message, h.logger, err)
+}
+
+// parseFreeeDateRange from/toクエリ（YYYY-MM-DD）を解析
+func parseFreeeDateRange(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var from, to *time.Time
+	for _, p := range []struct {
+		key string
+		dst **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		v := c.Query(p.key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "日付の形式が不正です（YYYY-MM-DD）")
+			return nil, nil, false
+		}
+		*p.dst = &t
+	}
+	return from, to, true
+}
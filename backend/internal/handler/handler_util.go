@@ -10,6 +10,7 @@ import (
 
 	"github.com/duesk/monstera/internal/common/userutil"
 	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
 	"github.com/duesk/monstera/internal/message"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/gin-gonic/gin"
@@ -167,6 +168,21 @@ func HandleError(c *gin.Context, statusCode int, message string, logger *zap.Log
 	RespondError(c, statusCode, message)
 }
 
+// HandleAccountingError 経理エラーはエラーコードとステータスをそのまま返し、それ以外は500として扱う
+func HandleAccountingError(c *gin.Context, message string, logger *zap.Logger, err error) {
+	if accErr, ok := accountingerrors.AsAccountingError(err); ok {
+		if logger != nil {
+			logger.Warn(message, zap.String("code", string(accErr.Code)), zap.Error(err))
+		}
+		c.JSON(accErr.HTTPStatus(), dto.ErrorResponse{
+			Error: accErr.Message,
+			Code:  string(accErr.Code),
+		})
+		return
+	}
+	HandleError(c, http.StatusInternalServerError, message, logger, err)
+}
+
 // RespondErrorWithCode エラーコード付きのエラーレスポンスを返す（仕様書準拠）
 func RespondErrorWithCode(c *gin.Context, errorCode message.ErrorCode, msg string, details ...map[string]string) {
 	response := dto.ErrorResponse{
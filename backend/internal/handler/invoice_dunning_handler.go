@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// InvoiceDunningHandler 請求書督促ハンドラー
+type InvoiceDunningHandler struct {
+	dunningService service.InvoiceDunningServiceInterface
+	logger         *zap.Logger
+}
+
+// NewInvoiceDunningHandler 請求書督促ハンドラーのコンストラクタ
+func NewInvoiceDunningHandler(dunningService service.InvoiceDunningServiceInterface, logger *zap.Logger) *InvoiceDunningHandler {
+	return &InvoiceDunningHandler{
+		dunningService: dunningService,
+		logger:         logger,
+	}
+}
+
+// GetSchedule 督促スケジュールを取得
+func (h *InvoiceDunningHandler) GetSchedule(c *gin.Context) {
+	schedule, err := h.dunningService.GetSchedule(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "督促スケジュールの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"schedule": schedule,
+	})
+}
+
+// UpdateSchedule 督促スケジュールを更新
+func (h *InvoiceDunningHandler) UpdateSchedule(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.UpdateDunningScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "督促スケジュールの指定が正しくありません")
+		return
+	}
+
+	schedule, err := h.dunningService.UpdateSchedule(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAccountingError(c, "督促スケジュールの更新に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "督促スケジュールを更新しました", gin.H{
+		"schedule": schedule,
+	})
+}
+
+// Run 期限超過の検知と督促を手動で実行
+func (h *InvoiceDunningHandler) Run(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	result, err := h.dunningService.Run(c.Request.Context(), &userID)
+	if err != nil {
+		HandleAccountingError(c, "督促の実行に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "督促を実行しました", gin.H{
+		"result": result,
+	})
+}
+
+// GetInvoiceTimeline 請求書のタイムラインを取得
+func (h *InvoiceDunningHandler) GetInvoiceTimeline(c *gin.Context) {
+	id, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	timeline, err := h.dunningService.GetInvoiceTimeline(c.Request.Context(), id)
+	if err != nil {
+		HandleAccountingError(c, "請求書のタイムラインの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"timeline": timeline,
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,6 +22,9 @@ type InvoiceHandler interface {
 	DeleteInvoice(c *gin.Context)       // 請求書削除
 	GetInvoiceSummary(c *gin.Context)   // 請求書サマリ取得
 	ExportInvoicePDF(c *gin.Context)    // 請求書PDF出力
+	CancelInvoice(c *gin.Context)       // 請求書取消（赤伝発行）
+	ReviseInvoice(c *gin.Context)       // 請求書訂正（赤伝発行と再発行）
+	GetInvoiceRevisions(c *gin.Context) // 請求書の訂正履歴取得
 }
 
 // invoiceHandler 請求書ハンドラーの実装
@@ -64,6 +68,7 @@ func (h *invoiceHandler) GetInvoices(c *gin.Context) {
 	}
 
 	req.Status = c.Query("status")
+	req.InvoiceType = c.Query("invoice_type")
 
 	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
 		if dateFrom, err := time.Parse("2006-01-02", dateFromStr); err == nil {
@@ -122,6 +127,12 @@ func (h *invoiceHandler) GetInvoice(c *gin.Context) {
 func (h *invoiceHandler) CreateInvoice(c *gin.Context) {
 	ctx := c.Request.Context()
 
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
 	// リクエストボディを取得
 	var req dto.CreateInvoiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -132,7 +143,7 @@ func (h *invoiceHandler) CreateInvoice(c *gin.Context) {
 	}
 
 	// サービス呼び出し
-	invoice, err := h.invoiceService.CreateInvoice(ctx, &req)
+	invoice, err := h.invoiceService.CreateInvoice(ctx, userID, &req)
 	if err != nil {
 		if err.Error() == "取引先が見つかりません" {
 			RespondNotFound(c, "取引先")
@@ -142,7 +153,7 @@ func (h *invoiceHandler) CreateInvoice(c *gin.Context) {
 			RespondError(c, http.StatusConflict, err.Error())
 			return
 		}
-		HandleError(c, http.StatusInternalServerError, "請求書の作成に失敗しました", h.Logger, err)
+		HandleAccountingError(c, "請求書の作成に失敗しました", h.Logger, err)
 		return
 	}
 
@@ -216,7 +227,8 @@ func (h *invoiceHandler) UpdateInvoiceStatus(c *gin.Context) {
 			RespondNotFound(c, "請求書")
 			return
 		}
-		if err.Error() == "無効なステータス遷移です" {
+		switch err.Error() {
+		case "無効なステータス遷移です", "赤伝のステータスは変更できません", "発行済みの請求書は赤伝を発行して取り消してください":
 			RespondError(c, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -239,8 +251,14 @@ func (h *invoiceHandler) DeleteInvoice(c *gin.Context) {
 		return
 	}
 
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
 	// サービス呼び出し
-	err = h.invoiceService.DeleteInvoice(ctx, invoiceID)
+	err = h.invoiceService.DeleteInvoice(ctx, invoiceID, userID)
 	if err != nil {
 		if err.Error() == "請求書が見つかりません" {
 			RespondNotFound(c, "請求書")
@@ -318,6 +336,120 @@ func (h *invoiceHandler) ExportInvoicePDF(c *gin.Context) {
 
 	// PDFレスポンス
 	c.Header("Content-Type", "application/pdf")
-	c.Header("Content-Disposition", "attachment; filename=invoice.pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=invoice_%s.pdf", invoiceID))
 	c.Data(http.StatusOK, "application/pdf", pdfData)
 }
+
+// CancelInvoice 請求書取消（赤伝発行）
+func (h *invoiceHandler) CancelInvoice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// パスパラメータからIDを取得
+	invoiceID, err := ParseUUID(c, "id", h.Logger)
+	if err != nil {
+		return
+	}
+
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	// リクエストボディを取得
+	var req dto.CancelInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondValidationError(c, map[string]string{
+			"request": "リクエストが不正です",
+		})
+		return
+	}
+
+	// サービス呼び出し
+	result, err := h.invoiceService.CancelInvoice(ctx, invoiceID, userID, &req)
+	if err != nil {
+		h.respondRevisionError(c, "請求書の取消に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "請求書を取り消し、赤伝を発行しました", gin.H{
+		"result": result,
+	})
+}
+
+// ReviseInvoice 請求書訂正（赤伝発行と再発行）
+func (h *invoiceHandler) ReviseInvoice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// パスパラメータからIDを取得
+	invoiceID, err := ParseUUID(c, "id", h.Logger)
+	if err != nil {
+		return
+	}
+
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	// リクエストボディを取得
+	var req dto.ReviseInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondValidationError(c, map[string]string{
+			"request": "リクエストが不正です",
+		})
+		return
+	}
+
+	// サービス呼び出し
+	result, err := h.invoiceService.ReviseInvoice(ctx, invoiceID, userID, &req)
+	if err != nil {
+		h.respondRevisionError(c, "請求書の訂正に失敗しました", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "赤伝を発行し、訂正後の請求書を作成しました", gin.H{
+		"result": result,
+	})
+}
+
+// GetInvoiceRevisions 請求書の訂正履歴取得
+func (h *invoiceHandler) GetInvoiceRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// パスパラメータからIDを取得
+	invoiceID, err := ParseUUID(c, "id", h.Logger)
+	if err != nil {
+		return
+	}
+
+	// サービス呼び出し
+	revisions, err := h.invoiceService.GetInvoiceRevisions(ctx, invoiceID)
+	if err != nil {
+		if err.Error() == "請求書が見つかりません" {
+			RespondNotFound(c, "請求書")
+			return
+		}
+		HandleError(c, http.StatusInternalServerError, "請求書の訂正履歴の取得に失敗しました", h.Logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"revisions": revisions,
+	})
+}
+
+// respondRevisionError 取消・訂正のエラーレスポンス
+func (h *invoiceHandler) respondRevisionError(c *gin.Context, message string, err error) {
+	switch err.Error() {
+	case "請求書が見つかりません":
+		RespondNotFound(c, "請求書")
+	case "請求書番号が既に使用されています", "赤伝の請求書番号が既に使用されています", "既に取り消された請求書です":
+		RespondError(c, http.StatusConflict, err.Error())
+	case "赤伝は取り消せません", "発行済みの請求書のみ取り消せます":
+		RespondError(c, http.StatusBadRequest, err.Error())
+	default:
+		HandleAccountingError(c, message, h.Logger, err)
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// InvoiceNumberHandler 請求書番号台帳ハンドラー
+type InvoiceNumberHandler struct {
+	numberingService service.InvoiceNumberingServiceInterface
+	logger           *zap.Logger
+}
+
+// NewInvoiceNumberHandler 請求書番号台帳ハンドラーのコンストラクタ
+func NewInvoiceNumberHandler(numberingService service.InvoiceNumberingServiceInterface, logger *zap.Logger) *InvoiceNumberHandler {
+	return &InvoiceNumberHandler{
+		numberingService: numberingService,
+		logger:           logger,
+	}
+}
+
+// GetLedger 払い出した請求書番号と欠番の台帳を取得
+func (h *InvoiceNumberHandler) GetLedger(c *gin.Context) {
+	var req dto.InvoiceNumberLedgerRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "検索条件が正しくありません")
+		return
+	}
+
+	ledger, err := h.numberingService.GetLedger(c.Request.Context(), &req)
+	if err != nil {
+		HandleAccountingError(c, "請求書番号台帳の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"ledger": ledger,
+	})
+}
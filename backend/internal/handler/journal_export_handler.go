@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// JournalExportHandler 仕訳出力ハンドラー
+type JournalExportHandler struct {
+	journalService service.JournalExportServiceInterface
+	logger         *zap.Logger
+}
+
+// NewJournalExportHandler 仕訳出力ハンドラーのコンストラクタ
+func NewJournalExportHandler(journalService service.JournalExportServiceInterface, logger *zap.Logger) *JournalExportHandler {
+	return &JournalExportHandler{
+		journalService: journalService,
+		logger:         logger,
+	}
+}
+
+// ListMappings 勘定科目設定の一覧を取得
+func (h *JournalExportHandler) ListMappings(c *gin.Context) {
+	mappings, err := h.journalService.ListMappings(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "勘定科目設定の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"mappings": mappings,
+	})
+}
+
+// UpdateMapping 勘定科目設定を登録・更新
+func (h *JournalExportHandler) UpdateMapping(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.JournalAccountMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "勘定科目設定の入力内容が正しくありません")
+		return
+	}
+
+	mapping, err := h.journalService.UpdateMapping(c.Request.Context(), c.Param("key"), &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "勘定科目設定の更新に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "勘定科目設定を更新しました", gin.H{
+		"mapping": mapping,
+	})
+}
+
+// GetEntries 対象月の仕訳を取得
+func (h *JournalExportHandler) GetEntries(c *gin.Context) {
+	var req dto.JournalEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "対象月を指定してください")
+		return
+	}
+
+	entries, err := h.journalService.GetEntries(c.Request.Context(), req.Month)
+	if err != nil {
+		HandleAccountingError(c, "仕訳の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"journal": entries,
+	})
+}
+
+// Export 対象月の仕訳を会計ソフトの取込形式でダウンロード
+func (h *JournalExportHandler) Export(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.JournalEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil || req.Format == "" {
+		RespondError(c, http.StatusBadRequest, "対象月と出力形式を指定してください")
+		return
+	}
+
+	file, err := h.journalService.Export(c.Request.Context(), req.Month, req.Format, userID)
+	if err != nil {
+		HandleAccountingError(c, "仕訳の出力に失敗しました", h.logger, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ListExports 仕訳の出力履歴を取得
+func (h *JournalExportHandler) ListExports(c *gin.Context) {
+	exports, err := h.journalService.ListExports(c.Request.Context(), c.Query("month"))
+	if err != nil {
+		HandleAccountingError(c, "仕訳の出力履歴の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"exports": exports,
+	})
+}
+
+// ListCloses 仕訳の月次締めの一覧を取得
+func (h *JournalExportHandler) ListCloses(c *gin.Context) {
+	closes, err := h.journalService.ListCloses(c.Request.Context())
+	if err != nil {
+		HandleAccountingError(c, "月次締めの取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"closes": closes,
+	})
+}
+
+// CloseMonth 対象月の仕訳を確定して締める
+func (h *JournalExportHandler) CloseMonth(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	var req dto.CloseJournalMonthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "対象月を指定してください")
+		return
+	}
+
+	closed, err := h.journalService.CloseMonth(c.Request.Context(), req.Month, userID)
+	if err != nil {
+		HandleAccountingError(c, "月次締めに失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "仕訳を締めました", gin.H{
+		"close": closed,
+	})
+}
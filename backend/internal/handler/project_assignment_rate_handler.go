@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/service"
+)
+
+// ProjectAssignmentRateHandler 案件アサインの単価履歴ハンドラー
+type ProjectAssignmentRateHandler struct {
+	rateService service.ProjectAssignmentRateServiceInterface
+	logger      *zap.Logger
+}
+
+// NewProjectAssignmentRateHandler 案件アサインの単価履歴ハンドラーのコンストラクタ
+func NewProjectAssignmentRateHandler(rateService service.ProjectAssignmentRateServiceInterface, logger *zap.Logger) *ProjectAssignmentRateHandler {
+	return &ProjectAssignmentRateHandler{
+		rateService: rateService,
+		logger:      logger,
+	}
+}
+
+// ListRates 単価履歴と変更ログを取得
+func (h *ProjectAssignmentRateHandler) ListRates(c *gin.Context) {
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	result, err := h.rateService.ListRates(c.Request.Context(), assignmentID)
+	if err != nil {
+		HandleAccountingError(c, "単価履歴の取得に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "", gin.H{
+		"assignment_rates": result,
+	})
+}
+
+// CreateRate 単価履歴を登録（単価改定）
+func (h *ProjectAssignmentRateHandler) CreateRate(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ProjectAssignmentRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "単価履歴の入力内容が正しくありません")
+		return
+	}
+
+	rate, err := h.rateService.CreateRate(c.Request.Context(), assignmentID, &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "単価履歴の登録に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, "単価履歴を登録しました", gin.H{
+		"rate": rate,
+	})
+}
+
+// UpdateRate 単価履歴を変更
+func (h *ProjectAssignmentRateHandler) UpdateRate(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+	rateID, err := ParseUUID(c, "rate_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.ProjectAssignmentRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "単価履歴の入力内容が正しくありません")
+		return
+	}
+	if req.Reason == "" {
+		RespondError(c, http.StatusBadRequest, "変更理由を入力してください")
+		return
+	}
+
+	rate, err := h.rateService.UpdateRate(c.Request.Context(), assignmentID, rateID, &req, userID)
+	if err != nil {
+		HandleAccountingError(c, "単価履歴の変更に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "単価履歴を変更しました", gin.H{
+		"rate": rate,
+	})
+}
+
+// DeleteRate 単価履歴を削除
+func (h *ProjectAssignmentRateHandler) DeleteRate(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "認証が必要です")
+		return
+	}
+
+	assignmentID, err := ParseUUID(c, "id", h.logger)
+	if err != nil {
+		return
+	}
+	rateID, err := ParseUUID(c, "rate_id", h.logger)
+	if err != nil {
+		return
+	}
+
+	var req dto.DeleteProjectAssignmentRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "削除理由を入力してください")
+		return
+	}
+
+	if err := h.rateService.DeleteRate(c.Request.Context(), assignmentID, rateID, req.Reason, userID); err != nil {
+		HandleAccountingError(c, "単価履歴の削除に失敗しました", h.logger, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, "単価履歴を削除しました", nil)
+}
@@ -778,6 +778,7 @@ func (h *WeeklyReportHandler) Copy(c *gin.Context) {
 				EndTime:       record.EndTime,
 				BreakTime:     record.BreakTime,
 				WorkHours:     record.WorkHours,
+				ProjectID:     record.ProjectID,
 				Remarks:       record.Remarks,
 				IsHolidayWork: record.IsHolidayWork,
 			}
@@ -1055,6 +1056,7 @@ func (h *WeeklyReportHandler) createReportResponse(report *model.WeeklyReport) *
 				ClientBreakTime: record.ClientBreakTime,
 				ClientWorkHours: record.ClientWorkHours,
 				HasClientWork:   record.HasClientWork,
+				ProjectID:       record.ProjectID,
 				Remarks:         record.Remarks,
 				IsHolidayWork:   record.IsHolidayWork,
 			}
@@ -1127,6 +1129,7 @@ func (h *WeeklyReportHandler) convertDailyRecordRequests(requests []dto.DailyRec
 			ClientBreakTime: recordReq.ClientBreakTime,
 			ClientWorkHours: recordReq.ClientWorkHours,
 			HasClientWork:   recordReq.HasClientWork,
+			ProjectID:       recordReq.ProjectID,
 			Remarks:         recordReq.Remarks,
 			IsHolidayWork:   recordReq.IsHolidayWork,
 		}
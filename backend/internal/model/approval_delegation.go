@@ -0,0 +1,124 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApprovalDelegationScope 代理承認の対象
+type ApprovalDelegationScope string
+
+const (
+	// ApprovalDelegationScopeAll 全ての承認
+	ApprovalDelegationScopeAll ApprovalDelegationScope = "all"
+	// ApprovalDelegationScopeExpense 経費申請の承認
+	ApprovalDelegationScopeExpense ApprovalDelegationScope = "expense"
+	// ApprovalDelegationScopeLeave 休暇申請の承認
+	ApprovalDelegationScopeLeave ApprovalDelegationScope = "leave"
+	// ApprovalDelegationScopeWeeklyReport 週報の承認
+	ApprovalDelegationScopeWeeklyReport ApprovalDelegationScope = "weekly_report"
+)
+
+// IsValid 有効な代理承認の対象かチェック
+func (s ApprovalDelegationScope) IsValid() bool {
+	switch s {
+	case ApprovalDelegationScopeAll, ApprovalDelegationScopeExpense, ApprovalDelegationScopeLeave, ApprovalDelegationScopeWeeklyReport:
+		return true
+	}
+	return false
+}
+
+// ApprovalDelegationSource 代理承認の登録元
+type ApprovalDelegationSource string
+
+const (
+	// ApprovalDelegationSourceManual 承認者または管理者による登録
+	ApprovalDelegationSourceManual ApprovalDelegationSource = "manual"
+	// ApprovalDelegationSourceLeaveRequest 承認済みの休暇申請から自動登録
+	ApprovalDelegationSourceLeaveRequest ApprovalDelegationSource = "leave_request"
+)
+
+// approvalDelegationDateLayout 代理承認の期間の比較に使う日付の書式
+const approvalDelegationDateLayout = "2006-01-02"
+
+// ApprovalDelegation 不在時の代理承認
+// StartDateからEndDateまで（両日を含む）、DelegatorIDの承認者に割り当てられた承認をDelegateIDの代理承認者が行える
+type ApprovalDelegation struct {
+	ID             string                   `gorm:"type:varchar(36);primary_key" json:"id"`
+	DelegatorID    string                   `gorm:"type:varchar(255);not null;index" json:"delegator_id"`
+	Delegator      *User                    `gorm:"foreignKey:DelegatorID" json:"delegator,omitempty"`
+	DelegateID     string                   `gorm:"type:varchar(255);not null;index" json:"delegate_id"`
+	Delegate       *User                    `gorm:"foreignKey:DelegateID" json:"delegate,omitempty"`
+	Scope          ApprovalDelegationScope  `gorm:"size:20;not null;default:'all'" json:"scope"`
+	StartDate      time.Time                `gorm:"type:date;not null" json:"start_date"`
+	EndDate        time.Time                `gorm:"type:date;not null" json:"end_date"`
+	Source         ApprovalDelegationSource `gorm:"size:20;not null;default:'manual'" json:"source"`
+	LeaveRequestID *string                  `gorm:"type:varchar(255)" json:"leave_request_id"`
+	Reason         string                   `gorm:"type:text" json:"reason"`
+	RevokedAt      *time.Time               `json:"revoked_at"`
+	CreatedBy      string                   `gorm:"type:varchar(255);not null" json:"created_by"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (ApprovalDelegation) TableName() string {
+	return "approval_delegations"
+}
+
+// BeforeCreate UUID生成
+func (d *ApprovalDelegation) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Covers 指定した承認が代理承認の対象かチェック
+func (d *ApprovalDelegation) Covers(scope ApprovalDelegationScope) bool {
+	return d.Scope == ApprovalDelegationScopeAll || d.Scope == scope
+}
+
+// IsActiveOn 指定日に代理承認が有効かチェック（取り消し済みは無効）
+func (d *ApprovalDelegation) IsActiveOn(day time.Time) bool {
+	if d.RevokedAt != nil {
+		return false
+	}
+	date := day.Format(approvalDelegationDateLayout)
+	return d.StartDate.Format(approvalDelegationDateLayout) <= date && date <= d.EndDate.Format(approvalDelegationDateLayout)
+}
+
+// Validate 代理承認の設定内容をチェック
+func (d *ApprovalDelegation) Validate() error {
+	if d.DelegatorID == "" || d.DelegateID == "" {
+		return fmt.Errorf("承認者と代理承認者を指定してください")
+	}
+	if d.DelegatorID == d.DelegateID {
+		return fmt.Errorf("自分自身を代理承認者に指定することはできません")
+	}
+	if !d.Scope.IsValid() {
+		return fmt.Errorf("代理承認の対象が不正です: %s", d.Scope)
+	}
+	if d.EndDate.Format(approvalDelegationDateLayout) < d.StartDate.Format(approvalDelegationDateLayout) {
+		return fmt.Errorf("終了日は開始日以降を指定してください")
+	}
+	return nil
+}
+
+// DelegatorIDsFor 代理承認者が指定日に代理で承認できる承認者を取得
+func DelegatorIDsFor(delegations []ApprovalDelegation, delegateID string, scope ApprovalDelegationScope, day time.Time) []string {
+	seen := make(map[string]bool)
+	delegatorIDs := make([]string, 0)
+	for i := range delegations {
+		d := &delegations[i]
+		if d.DelegateID != delegateID || !d.Covers(scope) || !d.IsActiveOn(day) || seen[d.DelegatorID] {
+			continue
+		}
+		seen[d.DelegatorID] = true
+		delegatorIDs = append(delegatorIDs, d.DelegatorID)
+	}
+	return delegatorIDs
+}
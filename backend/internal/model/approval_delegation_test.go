@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalDelegationIsActiveOn(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	d := ApprovalDelegation{
+		StartDate: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.False(t, d.IsActiveOn(time.Date(2026, 10, 18, 23, 59, 0, 0, jst)))
+	assert.True(t, d.IsActiveOn(time.Date(2026, 10, 19, 0, 0, 0, 0, jst)))
+	assert.True(t, d.IsActiveOn(time.Date(2026, 10, 23, 23, 59, 0, 0, jst)))
+	assert.False(t, d.IsActiveOn(time.Date(2026, 10, 24, 0, 0, 0, 0, jst)))
+
+	revokedAt := time.Now()
+	d.RevokedAt = &revokedAt
+	assert.False(t, d.IsActiveOn(time.Date(2026, 10, 20, 12, 0, 0, 0, jst)))
+}
+
+func TestDelegatorIDsFor(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	delegations := []ApprovalDelegation{
+		{DelegatorID: "manager-a", DelegateID: "delegate", Scope: ApprovalDelegationScopeExpense, StartDate: start, EndDate: end},
+		{DelegatorID: "manager-b", DelegateID: "delegate", Scope: ApprovalDelegationScopeAll, StartDate: start, EndDate: end},
+		{DelegatorID: "manager-b", DelegateID: "delegate", Scope: ApprovalDelegationScopeExpense, StartDate: start, EndDate: end},
+		{DelegatorID: "manager-c", DelegateID: "delegate", Scope: ApprovalDelegationScopeLeave, StartDate: start, EndDate: end},
+		{DelegatorID: "manager-d", DelegateID: "delegate", Scope: ApprovalDelegationScopeAll, StartDate: start, EndDate: start},
+		{DelegatorID: "manager-e", DelegateID: "other", Scope: ApprovalDelegationScopeAll, StartDate: start, EndDate: end},
+	}
+
+	assert.Equal(t, []string{"manager-a", "manager-b"}, DelegatorIDsFor(delegations, "delegate", ApprovalDelegationScopeExpense, day))
+	assert.Equal(t, []string{"manager-b", "manager-c"}, DelegatorIDsFor(delegations, "delegate", ApprovalDelegationScopeLeave, day))
+	assert.Empty(t, DelegatorIDsFor(delegations, "nobody", ApprovalDelegationScopeExpense, day))
+}
+
+func TestApprovalDelegationValidate(t *testing.T) {
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, (&ApprovalDelegation{DelegatorID: "a", DelegateID: "b", Scope: ApprovalDelegationScopeAll, StartDate: start, EndDate: end}).Validate())
+	assert.NoError(t, (&ApprovalDelegation{DelegatorID: "a", DelegateID: "b", Scope: ApprovalDelegationScopeWeeklyReport, StartDate: start, EndDate: start}).Validate())
+
+	assert.Error(t, (&ApprovalDelegation{DelegatorID: "a", DelegateID: "a", Scope: ApprovalDelegationScopeAll, StartDate: start, EndDate: end}).Validate())
+	assert.Error(t, (&ApprovalDelegation{DelegatorID: "a", DelegateID: "b", Scope: "invoice", StartDate: start, EndDate: end}).Validate())
+	assert.Error(t, (&ApprovalDelegation{DelegatorID: "a", DelegateID: "b", Scope: ApprovalDelegationScopeAll, StartDate: end, EndDate: start}).Validate())
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BankStatementFormat 入出金明細の取込形式
+type BankStatementFormat string
+
+const (
+	// BankStatementFormatZengin 全銀協フォーマット
+	BankStatementFormatZengin BankStatementFormat = "zengin"
+	// BankStatementFormatCSV 汎用CSV
+	BankStatementFormatCSV BankStatementFormat = "csv"
+)
+
+// BankTransactionStatus 入金明細の消込ステータス
+type BankTransactionStatus string
+
+const (
+	// BankTransactionStatusUnmatched 該当する請求書なし
+	BankTransactionStatusUnmatched BankTransactionStatus = "unmatched"
+	// BankTransactionStatusNeedsReview 照合結果の確認待ち
+	BankTransactionStatusNeedsReview BankTransactionStatus = "needs_review"
+	// BankTransactionStatusMatched 自動照合済み（未確定）
+	BankTransactionStatusMatched BankTransactionStatus = "matched"
+	// BankTransactionStatusConfirmed 消込確定
+	BankTransactionStatusConfirmed BankTransactionStatus = "confirmed"
+	// BankTransactionStatusIgnored 消込対象外
+	BankTransactionStatusIgnored BankTransactionStatus = "ignored"
+)
+
+// BankTransactionDirection 入出金区分
+type BankTransactionDirection string
+
+const (
+	// BankTransactionDirectionDeposit 入金
+	BankTransactionDirectionDeposit BankTransactionDirection = "deposit"
+	// BankTransactionDirectionWithdrawal 出金
+	BankTransactionDirectionWithdrawal BankTransactionDirection = "withdrawal"
+)
+
+// BankStatementImport 入出金明細の取込履歴
+type BankStatementImport struct {
+	ID             string              `gorm:"type:varchar(36);primary_key" json:"id"`
+	FileName       string              `gorm:"size:255;not null" json:"file_name"`
+	Format         BankStatementFormat `gorm:"size:20;not null" json:"format"`
+	BankCode       string              `gorm:"size:4" json:"bank_code"`
+	BankName       string              `gorm:"size:100" json:"bank_name"`
+	BranchName     string              `gorm:"size:100" json:"branch_name"`
+	AccountNumber  string              `gorm:"size:20" json:"account_number"`
+	PeriodFrom     *time.Time          `json:"period_from"`
+	PeriodTo       *time.Time          `json:"period_to"`
+	TotalCount     int                 `gorm:"default:0" json:"total_count"`
+	DepositCount   int                 `gorm:"default:0" json:"deposit_count"`
+	DuplicateCount int                 `gorm:"default:0" json:"duplicate_count"`
+	MatchedCount   int                 `gorm:"default:0" json:"matched_count"`
+	ReviewCount    int                 `gorm:"default:0" json:"review_count"`
+	UnmatchedCount int                 `gorm:"default:0" json:"unmatched_count"`
+	ImportedBy     string              `gorm:"type:varchar(36);not null" json:"imported_by"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// BankTransaction 取込んだ入出金明細
+type BankTransaction struct {
+	ID              string                   `gorm:"type:varchar(36);primary_key" json:"id"`
+	ImportID        string                   `gorm:"type:varchar(36);not null;index" json:"import_id"`
+	DedupeKey       string                   `gorm:"size:64;not null;uniqueIndex" json:"-"` // 同じ明細の二重取込防止
+	TransactionDate time.Time                `gorm:"type:date;not null" json:"transaction_date"`
+	Direction       BankTransactionDirection `gorm:"size:20;not null" json:"direction"`
+	Amount          int64                    `gorm:"not null" json:"amount"`
+	PayerName       string                   `gorm:"size:255" json:"payer_name"`
+	PayerKana       string                   `gorm:"size:255" json:"payer_kana"` // 照合用に正規化した振込依頼人名
+	Description     string                   `gorm:"size:255" json:"description"`
+	Reference       string                   `gorm:"size:50" json:"reference"`
+	Status          BankTransactionStatus    `gorm:"size:20;not null;default:'unmatched'" json:"status"`
+	MatchType       string                   `gorm:"size:20" json:"match_type"`
+	Confidence      float64                  `gorm:"type:decimal(3,2);default:0" json:"confidence"`
+	FeeAmount       int64                    `gorm:"default:0" json:"fee_amount"`
+	OverpaidAmount  int64                    `gorm:"default:0" json:"overpaid_amount"`
+	ReviewReason    string                   `gorm:"size:255" json:"review_reason"`
+	CandidateIDs    string                   `gorm:"type:text" json:"-"` // 確認待ちの候補請求書ID（カンマ区切り）
+	ConfirmedBy     *string                  `gorm:"type:varchar(36)" json:"confirmed_by"`
+	ConfirmedAt     *time.Time               `json:"confirmed_at"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+
+	// リレーション
+	Import      *BankStatementImport        `gorm:"foreignKey:ImportID" json:"import,omitempty"`
+	Allocations []BankTransactionAllocation `gorm:"foreignKey:BankTransactionID" json:"allocations,omitempty"`
+}
+
+// BankTransactionAllocation 入金の請求書への割当
+// 確定前は照合結果の提案、確定後は消込実績として扱う
+type BankTransactionAllocation struct {
+	ID                string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	BankTransactionID string    `gorm:"type:varchar(36);not null;index" json:"bank_transaction_id"`
+	InvoiceID         string    `gorm:"type:varchar(36);not null;index" json:"invoice_id"`
+	Amount            int64     `gorm:"not null" json:"amount"`
+	FeeAmount         int64     `gorm:"default:0" json:"fee_amount"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	// リレーション
+	Invoice *Invoice `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
+}
+
+// BeforeCreate UUIDを生成
+func (i *BankStatementImport) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// BeforeCreate UUIDを生成
+func (t *BankTransaction) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// BeforeCreate UUIDを生成
+func (a *BankTransactionAllocation) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName テーブル名を明示的に指定
+func (BankStatementImport) TableName() string {
+	return "bank_statement_imports"
+}
+
+// TableName テーブル名を明示的に指定
+func (BankTransaction) TableName() string {
+	return "bank_transactions"
+}
+
+// TableName テーブル名を明示的に指定
+func (BankTransactionAllocation) TableName() string {
+	return "bank_transaction_allocations"
+}
+
+// IsDeposit 入金かチェック
+func (t *BankTransaction) IsDeposit() bool {
+	return t.Direction == BankTransactionDirectionDeposit
+}
+
+// IsSettled 確定済みまたは対象外かチェック
+func (t *BankTransaction) IsSettled() bool {
+	return t.Status == BankTransactionStatusConfirmed || t.Status == BankTransactionStatusIgnored
+}
+
+// AllocatedAmount 割当済みの入金額の合計
+func (t *BankTransaction) AllocatedAmount() int64 {
+	var total int64
+	for _, a := range t.Allocations {
+		total += a.Amount
+	}
+	return total
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// ErrBillingCalculationImmutable 請求計算のスナップショットは作成後に変更・削除できない
+var ErrBillingCalculationImmutable = errors.New("請求計算のスナップショットは変更・削除できません")
+
+// BillingCalculation 確定した請求計算のスナップショット（変更・削除不可）
+// 月次請求の承認で請求書が確定するたびに、案件アサイン・請求月ごとに版を重ねて記録する
+type BillingCalculation struct {
+	ID              string  `gorm:"type:varchar(36);primary_key" json:"id"`
+	BillingMonth    string  `gorm:"size:7;not null" json:"billing_month"`
+	AssignmentID    string  `gorm:"type:varchar(36);not null" json:"assignment_id"`
+	Revision        int     `gorm:"not null" json:"revision"` // 同じアサイン・請求月の計算の版（1から）
+	ClientID        string  `gorm:"type:varchar(36);not null" json:"client_id"`
+	ProjectID       string  `gorm:"type:varchar(36);not null" json:"project_id"`
+	ProjectGroupID  *string `gorm:"type:varchar(36)" json:"project_group_id"`
+	UserID          string  `gorm:"type:varchar(36);not null" json:"user_id"`
+	BillingRunID    *string `gorm:"type:varchar(36)" json:"billing_run_id"`
+	InvoiceID       string  `gorm:"type:varchar(36);not null" json:"invoice_id"`
+	InvoiceDetailID string  `gorm:"type:varchar(36);not null" json:"invoice_detail_id"`
+	InvoiceNumber   string  `gorm:"size:50;not null" json:"invoice_number"` // 請求書を取り消した後も確定時の番号を残す
+
+	// 計算の入力
+	BillingType     ProjectBillingType     `gorm:"type:billing_type_enum;not null" json:"billing_type"`
+	MonthlyRate     money.Amount           `gorm:"type:decimal(10,2);not null" json:"monthly_rate"`
+	MinHours        *float64               `gorm:"type:decimal(5,2)" json:"min_hours"`
+	MaxHours        *float64               `gorm:"type:decimal(5,2)" json:"max_hours"`
+	ProrationMethod ProrationMethod        `gorm:"size:20" json:"proration_method"`
+	ProrationBasis  string                 `gorm:"size:255" json:"proration_basis"`
+	ActualHours     *float64               `gorm:"type:decimal(6,2)" json:"actual_hours"`
+	RateSegments    BillingRateSettlements `gorm:"type:jsonb" json:"rate_segments"`
+	HoursEvidence   *BillingHoursEvidence  `gorm:"type:jsonb" json:"hours_evidence,omitempty"`
+
+	// 計算の結果
+	BillingAmount money.Amount `gorm:"type:decimal(12,2);not null" json:"billing_amount"`
+	Notes         string       `gorm:"type:text" json:"notes"`
+
+	CalculatedBy string    `gorm:"type:varchar(255);not null" json:"calculated_by"`
+	CalculatedAt time.Time `gorm:"not null" json:"calculated_at"`
+}
+
+// TableName テーブル名を指定
+func (BillingCalculation) TableName() string {
+	return "billing_calculations"
+}
+
+// BeforeCreate UUID生成
+func (c *BillingCalculation) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// BeforeUpdate スナップショットの変更を禁止
+func (c *BillingCalculation) BeforeUpdate(tx *gorm.DB) error {
+	return ErrBillingCalculationImmutable
+}
+
+// BeforeDelete スナップショットの削除を禁止
+func (c *BillingCalculation) BeforeDelete(tx *gorm.DB) error {
+	return ErrBillingCalculationImmutable
+}
+
+// BillingRateSettlements 単価履歴の区間ごとの精算結果（JSONで保存）
+type BillingRateSettlements []BillingRateSettlement
+
+// Scan BillingRateSettlementsのスキャン実装
+func (s *BillingRateSettlements) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into BillingRateSettlements", value)
+	}
+}
+
+// Value BillingRateSettlementsの値取得実装
+func (s BillingRateSettlements) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// BillingCalculationChange 前回の計算から変わった項目
+type BillingCalculationChange struct {
+	Field  string `json:"field"`
+	Label  string `json:"label"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// DiffBillingCalculations 同じアサイン・請求月の前回の計算と比べて変わった入力と結果
+// 前回の計算がない場合（初回）はnil
+func DiffBillingCalculations(previous, current *BillingCalculation) []BillingCalculationChange {
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	var changes []BillingCalculationChange
+	add := func(field, label, before, after string) {
+		if before != after {
+			changes = append(changes, BillingCalculationChange{Field: field, Label: label, Before: before, After: after})
+		}
+	}
+	add("billing_type", "精算タイプ", string(previous.BillingType), string(current.BillingType))
+	add("monthly_rate", "月額単価", previous.MonthlyRate.String(), current.MonthlyRate.String())
+	add("min_hours", "精算下限時間", formatCalculationHours(previous.MinHours), formatCalculationHours(current.MinHours))
+	add("max_hours", "精算上限時間", formatCalculationHours(previous.MaxHours), formatCalculationHours(current.MaxHours))
+	add("proration_method", "日割り方法", string(previous.ProrationMethod), string(current.ProrationMethod))
+	add("proration_basis", "日割りの根拠", previous.ProrationBasis, current.ProrationBasis)
+	add("actual_hours", "稼働時間", formatCalculationHours(previous.ActualHours), formatCalculationHours(current.ActualHours))
+	add("billing_amount", "請求額", previous.BillingAmount.String(), current.BillingAmount.String())
+	return changes
+}
+
+// formatCalculationHours 差分表示用の時間（未設定は空文字）
+func formatCalculationHours(hours *float64) string {
+	if hours == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *hours)
+}
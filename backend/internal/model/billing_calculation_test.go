@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestDiffBillingCalculations(t *testing.T) {
+	minHours, maxHours := 140.0, 180.0
+	before, after := 150.0, 130.5
+	previous := &BillingCalculation{
+		BillingType:   ProjectBillingTypeVariableUpperLower,
+		MonthlyRate:   money.Yen(700000),
+		MinHours:      &minHours,
+		MaxHours:      &maxHours,
+		ActualHours:   &before,
+		BillingAmount: money.Yen(700000),
+	}
+
+	// 初回の計算は差分なし
+	assert.Nil(t, DiffBillingCalculations(nil, previous))
+
+	// 週報の修正で稼働時間が変わり、下限割れで控除された
+	current := *previous
+	current.ActualHours = &after
+	current.BillingAmount = money.Yen(652500)
+	assert.Equal(t, []BillingCalculationChange{
+		{Field: "actual_hours", Label: "稼働時間", Before: "150.00", After: "130.50"},
+		{Field: "billing_amount", Label: "請求額", Before: "700000", After: "652500"},
+	}, DiffBillingCalculations(previous, &current))
+
+	assert.Empty(t, DiffBillingCalculations(previous, previous))
+}
+
+func TestBillingCalculationImmutable(t *testing.T) {
+	calculation := &BillingCalculation{}
+	assert.ErrorIs(t, calculation.BeforeUpdate(nil), ErrBillingCalculationImmutable)
+	assert.ErrorIs(t, calculation.BeforeDelete(nil), ErrBillingCalculationImmutable)
+}
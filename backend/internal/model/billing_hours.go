@@ -0,0 +1,261 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// 精算結果の種別
+const (
+	// BillingSettlementFixed 精算幅の範囲内（固定額）
+	BillingSettlementFixed = "fixed"
+	// BillingSettlementLower 下限割れ（控除）
+	BillingSettlementLower = "lower"
+	// BillingSettlementUpper 上限超過（超過精算）
+	BillingSettlementUpper = "upper"
+	// BillingSettlementMiddle 中間割（中間値との差で精算）
+	BillingSettlementMiddle = "middle"
+	// BillingSettlementProrated 月の途中で単価が変わったため区間ごとに精算
+	BillingSettlementProrated = "prorated"
+)
+
+// BillingHoursReport 精算に使用した週報
+type BillingHoursReport struct {
+	WeeklyReportID string    `json:"weekly_report_id"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	Hours          float64   `json:"hours"` // 精算期間内の稼働時間
+}
+
+// BillingHoursEvidence 請求明細の稼働時間の根拠
+type BillingHoursEvidence struct {
+	PeriodStart    time.Time            `json:"period_start"`
+	PeriodEnd      time.Time            `json:"period_end"`
+	ActualHours    float64              `json:"actual_hours"`
+	LowerLimit     float64              `json:"lower_limit"`
+	UpperLimit     float64              `json:"upper_limit"`
+	SettlementType string               `json:"settlement_type"`           // fixed, lower, upper, middle, prorated
+	ThresholdHours float64              `json:"threshold_hours,omitempty"` // 時間単価の計算に使った基準時間
+	Explanation    string               `json:"explanation"`
+	WeeklyReports  []BillingHoursReport `json:"weekly_reports"`
+
+	RateSegments []BillingRateSettlement `json:"rate_segments,omitempty"` // 適用した単価履歴ごとの精算内容
+}
+
+// BillingRateSettlement 単価履歴の区間ごとの精算内容
+type BillingRateSettlement struct {
+	BillingRateSegment
+	ActualHours    float64      `json:"actual_hours"`
+	LowerLimit     float64      `json:"lower_limit,omitempty"` // 日割り後の下限時間
+	UpperLimit     float64      `json:"upper_limit,omitempty"` // 日割り後の上限時間
+	SettlementType string       `json:"settlement_type"`
+	Amount         money.Amount `json:"amount"`
+	Explanation    string       `json:"explanation"`
+}
+
+// Scan BillingHoursEvidenceのスキャン実装
+func (e *BillingHoursEvidence) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into BillingHoursEvidence", value)
+	}
+}
+
+// Value BillingHoursEvidenceの値取得実装
+func (e BillingHoursEvidence) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+// UnsettledWeeklyReportsError 精算期間に未提出・未承認の週報がある
+type UnsettledWeeklyReportsError struct {
+	Missing    []time.Time // 週報がない週の開始日（月曜日）
+	Unapproved []time.Time // 承認されていない週報の開始日
+}
+
+// Error エラーメッセージを返す
+func (e *UnsettledWeeklyReportsError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "未提出の週報があります（"+joinWeekDates(e.Missing)+"）")
+	}
+	if len(e.Unapproved) > 0 {
+		parts = append(parts, "未承認の週報があります（"+joinWeekDates(e.Unapproved)+"）")
+	}
+	return strings.Join(parts, "、")
+}
+
+// UnattributedDailyRecordsError 複数の案件に並行してアサインされている日に、案件を指定していない稼働がある
+type UnattributedDailyRecordsError struct {
+	Dates []time.Time
+}
+
+// Error エラーメッセージを返す
+func (e *UnattributedDailyRecordsError) Error() string {
+	labels := make([]string, len(e.Dates))
+	for i, d := range e.Dates {
+		labels[i] = d.Format("2006/1/2")
+	}
+	return "複数の案件にアサインされている日に案件が指定されていない勤怠があります（" + strings.Join(labels, ", ") + "）"
+}
+
+// FilterProjectDailyRecords 案件の精算に使う日次勤怠だけを残した週報を返す
+// 案件を指定した日次勤怠はその案件だけに数え、指定のない日次勤怠は他の案件のアサインと重ならない日だけに数える
+// 精算期間内で他の案件と重なる日に案件の指定がない稼働がある場合はUnattributedDailyRecordsErrorを返す
+func FilterProjectDailyRecords(reports []*WeeklyReport, projectID string, concurrent []*ProjectAssignment, start, end time.Time) ([]*WeeklyReport, error) {
+	start, end = dateOnly(start), dateOnly(end)
+
+	unattributed := &UnattributedDailyRecordsError{}
+	filtered := make([]*WeeklyReport, len(reports))
+	for i, report := range reports {
+		copied := *report
+		copied.DailyRecords = make([]*DailyRecord, 0, len(report.DailyRecords))
+		for _, record := range report.DailyRecords {
+			if record.ProjectID != nil {
+				if *record.ProjectID == projectID {
+					copied.DailyRecords = append(copied.DailyRecords, record)
+				}
+				continue
+			}
+			date := dateOnly(record.Date)
+			if !assignedOnDate(concurrent, date) {
+				copied.DailyRecords = append(copied.DailyRecords, record)
+				continue
+			}
+			if !date.Before(start) && !date.After(end) && record.BillableHours() > 0 {
+				unattributed.Dates = append(unattributed.Dates, date)
+			}
+		}
+		filtered[i] = &copied
+	}
+
+	if len(unattributed.Dates) > 0 {
+		sort.Slice(unattributed.Dates, func(i, j int) bool {
+			return unattributed.Dates[i].Before(unattributed.Dates[j])
+		})
+		return nil, unattributed
+	}
+	return filtered, nil
+}
+
+// assignedOnDate 指定日がいずれかのアサイン期間に含まれるか
+func assignedOnDate(assignments []*ProjectAssignment, date time.Time) bool {
+	for _, assignment := range assignments {
+		if date.Before(dateOnly(assignment.StartDate)) {
+			continue
+		}
+		if assignment.EndDate != nil && date.After(dateOnly(*assignment.EndDate)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// CollectBillingHours 精算期間の週報から実稼働時間を集計する
+// 期間内のすべての日が承認済みの週報でカバーされていない場合はUnsettledWeeklyReportsErrorを返す
+// 客先勤怠を入力した日は客先の稼働時間を、それ以外は自社の稼働時間を使う
+func CollectBillingHours(reports []*WeeklyReport, start, end time.Time) (*BillingHoursEvidence, error) {
+	start, end = dateOnly(start), dateOnly(end)
+
+	sorted := make([]*WeeklyReport, len(reports))
+	copy(sorted, reports)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartDate.Before(sorted[j].StartDate)
+	})
+
+	unsettled := &UnsettledWeeklyReportsError{}
+	missing := make(map[time.Time]bool)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if findWeeklyReport(sorted, d) == nil {
+			week := weekStart(d)
+			if !missing[week] {
+				missing[week] = true
+				unsettled.Missing = append(unsettled.Missing, week)
+			}
+		}
+	}
+
+	evidence := &BillingHoursEvidence{PeriodStart: start, PeriodEnd: end}
+	for _, report := range sorted {
+		if dateOnly(report.EndDate).Before(start) || dateOnly(report.StartDate).After(end) {
+			continue
+		}
+		if report.Status != WeeklyReportStatusApproved {
+			unsettled.Unapproved = append(unsettled.Unapproved, dateOnly(report.StartDate))
+			continue
+		}
+
+		var hours float64
+		for _, record := range report.DailyRecords {
+			date := dateOnly(record.Date)
+			if date.Before(start) || date.After(end) {
+				continue
+			}
+			hours += record.BillableHours()
+		}
+		hours = roundHours(hours)
+		evidence.ActualHours += hours
+		evidence.WeeklyReports = append(evidence.WeeklyReports, BillingHoursReport{
+			WeeklyReportID: report.ID,
+			StartDate:      dateOnly(report.StartDate),
+			EndDate:        dateOnly(report.EndDate),
+			Hours:          hours,
+		})
+	}
+
+	if len(unsettled.Missing) > 0 || len(unsettled.Unapproved) > 0 {
+		return nil, unsettled
+	}
+	evidence.ActualHours = roundHours(evidence.ActualHours)
+	return evidence, nil
+}
+
+// findWeeklyReport 指定日を含む週報
+func findWeeklyReport(reports []*WeeklyReport, date time.Time) *WeeklyReport {
+	for _, report := range reports {
+		if !date.Before(dateOnly(report.StartDate)) && !date.After(dateOnly(report.EndDate)) {
+			return report
+		}
+	}
+	return nil
+}
+
+// dateOnly 日付のみ（UTCの0時）
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart 週の開始日（月曜日）
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return dateOnly(t).AddDate(0, 0, -offset)
+}
+
+// roundHours 稼働時間を小数点第2位に丸める
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// joinWeekDates 週の開始日を「2024/1/8週」の形式で列挙
+func joinWeekDates(dates []time.Time) string {
+	labels := make([]string, len(dates))
+	for i, d := range dates {
+		labels[i] = d.Format("2006/1/2") + "週"
+	}
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// billingHoursReport 月曜日から7日間の週報を作成（日ごとの稼働時間を指定）
+func billingHoursReport(id string, monday time.Time, status WeeklyReportStatusEnum, hours ...float64) *WeeklyReport {
+	report := &WeeklyReport{
+		ID:        id,
+		StartDate: monday,
+		EndDate:   monday.AddDate(0, 0, 6),
+		Status:    status,
+	}
+	for i, h := range hours {
+		report.DailyRecords = append(report.DailyRecords, &DailyRecord{
+			Date:      monday.AddDate(0, 0, i),
+			WorkHours: h,
+		})
+	}
+	return report
+}
+
+func TestCollectBillingHours(t *testing.T) {
+	// 2024/4/1(月)〜4/7(日) と 4/8(月)〜4/14(日) の2週を4/3〜4/9で精算する
+	week1 := billingHoursReport("w1", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), WeeklyReportStatusApproved, 8, 8, 8, 8, 8)
+	week2 := billingHoursReport("w2", time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC), WeeklyReportStatusApproved, 7.5, 9)
+	// 客先勤怠を入力した日は客先の稼働時間を使う
+	week2.DailyRecords[1].HasClientWork = true
+	week2.DailyRecords[1].ClientWorkHours = 8.75
+
+	evidence, err := CollectBillingHours([]*WeeklyReport{week2, week1},
+		time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 9, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, 8*3+7.5+8.75, evidence.ActualHours)
+	require.Len(t, evidence.WeeklyReports, 2)
+	assert.Equal(t, "w1", evidence.WeeklyReports[0].WeeklyReportID)
+	assert.Equal(t, 24.0, evidence.WeeklyReports[0].Hours)
+	assert.Equal(t, "w2", evidence.WeeklyReports[1].WeeklyReportID)
+	assert.Equal(t, 16.25, evidence.WeeklyReports[1].Hours)
+}
+
+func TestCollectBillingHours_Unsettled(t *testing.T) {
+	week1 := billingHoursReport("w1", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), WeeklyReportStatusSubmitted, 8, 8, 8, 8, 8)
+
+	_, err := CollectBillingHours([]*WeeklyReport{week1},
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+
+	var unsettled *UnsettledWeeklyReportsError
+	require.ErrorAs(t, err, &unsettled)
+	assert.Equal(t, []time.Time{time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)}, unsettled.Missing)
+	assert.Equal(t, []time.Time{time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}, unsettled.Unapproved)
+	assert.Equal(t, "未提出の週報があります（2024/4/8週）、未承認の週報があります（2024/4/1週）", err.Error())
+}
+
+func TestFilterProjectDailyRecords(t *testing.T) {
+	// 2024/4/1(月)〜4/5(金)、4/3から案件Bにも並行してアサインされている
+	monday := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	start, end := monday, monday.AddDate(0, 0, 6)
+	concurrent := []*ProjectAssignment{{ProjectID: "project-b", StartDate: monday.AddDate(0, 0, 2)}}
+	projectA, projectB := "project-a", "project-b"
+
+	t.Run("並行するアサインの日は指定した案件の稼働だけを数える", func(t *testing.T) {
+		week := billingHoursReport("w1", monday, WeeklyReportStatusApproved, 8, 8, 4, 4, 8)
+		week.DailyRecords = append(week.DailyRecords,
+			&DailyRecord{Date: monday.AddDate(0, 0, 2), WorkHours: 4, ProjectID: &projectB},
+			&DailyRecord{Date: monday.AddDate(0, 0, 3), WorkHours: 4, ProjectID: &projectB})
+		for _, record := range week.DailyRecords[2:5] {
+			record.ProjectID = &projectA
+		}
+
+		filtered, err := FilterProjectDailyRecords([]*WeeklyReport{week}, projectA, concurrent, start, end)
+		require.NoError(t, err)
+		evidence, err := CollectBillingHours(filtered, start, end)
+		require.NoError(t, err)
+		assert.Equal(t, 8+8+4+4+8.0, evidence.ActualHours)
+		// 元の週報は変更しない
+		assert.Len(t, week.DailyRecords, 7)
+	})
+
+	t.Run("並行するアサインの日に案件の指定がない稼働はエラー", func(t *testing.T) {
+		week := billingHoursReport("w1", monday, WeeklyReportStatusApproved, 8, 8, 8, 0, 8)
+		week.DailyRecords[4].ProjectID = &projectA
+
+		_, err := FilterProjectDailyRecords([]*WeeklyReport{week}, projectA, concurrent, start, end)
+
+		var unattributed *UnattributedDailyRecordsError
+		require.ErrorAs(t, err, &unattributed)
+		assert.Equal(t, []time.Time{monday.AddDate(0, 0, 2)}, unattributed.Dates)
+		assert.Equal(t, "複数の案件にアサインされている日に案件が指定されていない勤怠があります（2024/4/3）", err.Error())
+	})
+}
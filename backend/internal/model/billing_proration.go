@@ -0,0 +1,124 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProrationMethod 月の途中の参画・離任時の日割り方法
+type ProrationMethod string
+
+const (
+	// ProrationMethodBusinessDays 営業日数で按分（土日・祝日マスタの休日を除く）
+	ProrationMethodBusinessDays ProrationMethod = "business_days"
+	// ProrationMethodCalendarDays 暦日数で按分
+	ProrationMethodCalendarDays ProrationMethod = "calendar_days"
+	// ProrationMethodHours 稼働時間 × 精算幅から求めた時間単価
+	ProrationMethodHours ProrationMethod = "hours"
+
+	// DefaultProrationMethod 案件・取引先に設定がない場合の日割り方法
+	DefaultProrationMethod = ProrationMethodBusinessDays
+)
+
+// IsValid 日割り方法が有効か
+func (m ProrationMethod) IsValid() bool {
+	switch m {
+	case ProrationMethodBusinessDays, ProrationMethodCalendarDays, ProrationMethodHours:
+		return true
+	}
+	return false
+}
+
+// Label 請求書の明細に表示する日割り方法の名称
+func (m ProrationMethod) Label() string {
+	switch m {
+	case ProrationMethodBusinessDays:
+		return "営業日"
+	case ProrationMethodCalendarDays:
+		return "暦日"
+	case ProrationMethodHours:
+		return "稼働時間"
+	default:
+		return string(m)
+	}
+}
+
+// ResolveProrationMethod 案件の設定、取引先の設定、既定値の順に日割り方法を決める
+func ResolveProrationMethod(project *Project, client *Client) ProrationMethod {
+	if project != nil && project.ProrationMethod != nil && project.ProrationMethod.IsValid() {
+		return *project.ProrationMethod
+	}
+	if client != nil && client.ProrationMethod.IsValid() {
+		return client.ProrationMethod
+	}
+	return DefaultProrationMethod
+}
+
+// HolidaySet 祝日マスタの休日（日付の集合）
+type HolidaySet map[time.Time]bool
+
+// NewHolidaySet 祝日マスタから休日の集合を作成
+func NewHolidaySet(holidays []Holiday) HolidaySet {
+	set := make(HolidaySet, len(holidays))
+	for _, holiday := range holidays {
+		set[dateOnly(holiday.HolidayDate)] = true
+	}
+	return set
+}
+
+// IsBusinessDay 営業日（土日・休日以外）か
+func (h HolidaySet) IsBusinessDay(date time.Time) bool {
+	date = dateOnly(date)
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !h[date]
+}
+
+// CountProrationDays 日割りの基準となる日数（営業日按分は営業日数、それ以外は暦日数）
+func CountProrationDays(method ProrationMethod, start, end time.Time, holidays HolidaySet) int {
+	days := 0
+	for d := dateOnly(start); !d.After(dateOnly(end)); d = d.AddDate(0, 0, 1) {
+		if method == ProrationMethodBusinessDays && !holidays.IsBusinessDay(d) {
+			continue
+		}
+		days++
+	}
+	return days
+}
+
+// ApplyProration 請求月の日数を基準に単価履歴の区間ごとの日割りの割合を設定する
+// 区間が請求月全体であれば日割りしない
+func ApplyProration(segments []BillingRateSegment, method ProrationMethod, year, month int, holidays HolidaySet) {
+	monthStart, monthEnd := BillingMonthRange(year, month)
+	monthUnits := CountProrationDays(method, monthStart, monthEnd, holidays)
+	for i := range segments {
+		segments[i].ProrationMethod = method
+		segments[i].MonthUnits = monthUnits
+		if segments[i].Start.Equal(monthStart) && segments[i].End.Equal(monthEnd) {
+			segments[i].Units = monthUnits
+			continue
+		}
+		segments[i].Units = CountProrationDays(method, segments[i].Start, segments[i].End, holidays)
+	}
+}
+
+// ProrationBasis 請求書の明細に表示する日割りの根拠（日割りしない場合は空文字）
+// 例: 「日割り: 4/10〜4/30 営業日15/21日」
+func ProrationBasis(segments []BillingRateSegment) string {
+	if len(segments) == 0 || (len(segments) == 1 && !segments[0].IsProrated()) {
+		return ""
+	}
+
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		period := segment.Start.Format("1/2") + "〜" + segment.End.Format("1/2")
+		if segment.ProrationMethod == ProrationMethodHours && segment.IsProrated() {
+			parts[i] = period + " " + segment.ProrationMethod.Label()
+			continue
+		}
+		parts[i] = fmt.Sprintf("%s %s%d/%d日", period, segment.ProrationMethod.Label(), segment.Units, segment.MonthUnits)
+	}
+	return "日割り: " + strings.Join(parts, "、")
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestApplyProration(t *testing.T) {
+	// 2024/4/29（昭和の日）を祝日マスタに登録
+	holidays := NewHolidaySet([]Holiday{{HolidayDate: time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)}})
+	rates := []*ProjectAssignmentRate{
+		{ID: "r1", EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), BillingType: ProjectBillingTypeFixed, BillingRate: money.Yen(630000)},
+	}
+
+	// 4/10参画の場合は4/10〜4/30を日割りする
+	segments, err := ResolveBillingRates(rates, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+
+	ApplyProration(segments, ProrationMethodBusinessDays, 2024, 4, holidays)
+	assert.Equal(t, 14, segments[0].Units)
+	assert.Equal(t, 21, segments[0].MonthUnits)
+	assert.Equal(t, money.Yen(420000), segments[0].ProratedRate())
+	assert.Equal(t, "日割り: 4/10〜4/30 営業日14/21日", ProrationBasis(segments))
+
+	ApplyProration(segments, ProrationMethodCalendarDays, 2024, 4, holidays)
+	assert.Equal(t, 21, segments[0].Units)
+	assert.Equal(t, 30, segments[0].MonthUnits)
+	assert.Equal(t, money.Yen(441000), segments[0].ProratedRate())
+
+	// 1か月すべて参画していれば日割りしない
+	start, end := BillingMonthRange(2024, 4)
+	segments, err = ResolveBillingRates(rates, start, end)
+	require.NoError(t, err)
+	ApplyProration(segments, ProrationMethodBusinessDays, 2024, 4, holidays)
+	assert.False(t, segments[0].IsProrated())
+	assert.Equal(t, money.Yen(630000), segments[0].ProratedRate())
+	assert.Empty(t, ProrationBasis(segments))
+}
+
+func TestResolveProrationMethod(t *testing.T) {
+	hours := ProrationMethodHours
+
+	assert.Equal(t, DefaultProrationMethod, ResolveProrationMethod(&Project{}, &Client{}))
+	assert.Equal(t, ProrationMethodCalendarDays, ResolveProrationMethod(&Project{}, &Client{ProrationMethod: ProrationMethodCalendarDays}))
+	assert.Equal(t, ProrationMethodHours, ResolveProrationMethod(&Project{ProrationMethod: &hours}, &Client{ProrationMethod: ProrationMethodCalendarDays}))
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// BillingRunStatus 月次請求の状態
+type BillingRunStatus string
+
+const (
+	// BillingRunStatusDraft 下書き（再実行で請求書を作り直せる）
+	BillingRunStatusDraft BillingRunStatus = "draft"
+	// BillingRunStatusApproved 承認済み（再実行不可）
+	BillingRunStatusApproved BillingRunStatus = "approved"
+)
+
+// BillingRun 月次請求の実行単位（請求月ごとに1件）
+type BillingRun struct {
+	ID           string           `gorm:"type:varchar(36);primary_key" json:"id"`
+	BillingMonth string           `gorm:"size:7;not null;uniqueIndex" json:"billing_month"`
+	Status       BillingRunStatus `gorm:"size:20;not null;default:'draft'" json:"status"`
+	RunCount     int              `gorm:"not null;default:0" json:"run_count"`
+	InvoiceCount int              `gorm:"not null;default:0" json:"invoice_count"`
+	TotalAmount  money.Amount     `gorm:"type:decimal(12,2);not null;default:0" json:"total_amount"`
+	ExecutedBy   string           `gorm:"type:varchar(36);not null" json:"executed_by"`
+	ExecutedAt   time.Time        `gorm:"not null" json:"executed_at"`
+	ApprovedBy   *string          `gorm:"type:varchar(36)" json:"approved_by"`
+	ApprovedAt   *time.Time       `json:"approved_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+
+	// リレーション
+	Invoices []Invoice `gorm:"foreignKey:BillingRunID" json:"invoices,omitempty"`
+}
+
+// BeforeCreate UUIDを生成
+func (r *BillingRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName テーブル名を明示的に指定
+func (BillingRun) TableName() string {
+	return "billing_runs"
+}
+
+// IsLocked 承認済みで再実行できないかチェック
+func (r *BillingRun) IsLocked() bool {
+	return r.Status == BillingRunStatusApproved
+}
+
+// BillingMonthString 請求月の文字列（YYYY-MM）
+func BillingMonthString(year, month int) string {
+	return fmt.Sprintf("%04d-%02d", year, month)
+}
+
+// BillingMonthRange 請求月の初日と末日
+func BillingMonthRange(year, month int) (time.Time, time.Time) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1)
+}
+
+// PreviousBillingMonth 前月の年・月
+func PreviousBillingMonth(year, month int) (int, int) {
+	prev := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	return prev.Year(), int(prev.Month())
+}
+
+// BillingTargetKey 請求書の単位（取引先、またはプロジェクトグループ）
+type BillingTargetKey struct {
+	ClientID       string
+	ProjectGroupID string // グループに属さない案件は空文字
+}
+
+// BillingTargetKeyOf 請求書の請求単位
+func BillingTargetKeyOf(invoice *Invoice) BillingTargetKey {
+	key := BillingTargetKey{ClientID: invoice.ClientID}
+	if invoice.ProjectGroupID != nil {
+		key.ProjectGroupID = *invoice.ProjectGroupID
+	}
+	return key
+}
+
+// BillingChangeType 前月との差分の種別
+type BillingChangeType string
+
+const (
+	// BillingChangeAdded 今月から請求
+	BillingChangeAdded BillingChangeType = "added"
+	// BillingChangeRemoved 今月は請求なし
+	BillingChangeRemoved BillingChangeType = "removed"
+	// BillingChangeChanged 金額が変動
+	BillingChangeChanged BillingChangeType = "changed"
+	// BillingChangeUnchanged 前月と同額
+	BillingChangeUnchanged BillingChangeType = "unchanged"
+)
+
+// BillingRunChange 請求単位ごとの前月との差分
+type BillingRunChange struct {
+	BillingTargetKey
+	Type     BillingChangeType
+	Previous money.Amount
+	Current  money.Amount
+}
+
+// Difference 前月からの増減額
+func (c BillingRunChange) Difference() money.Amount {
+	return c.Current.Sub(c.Previous)
+}
+
+// DiffBillingTotals 請求単位ごとの請求額（税込）を前月と比較する
+// 結果は取引先・プロジェクトグループの順に並べる
+func DiffBillingTotals(previous, current map[BillingTargetKey]money.Amount) []BillingRunChange {
+	changes := make([]BillingRunChange, 0, len(current)+len(previous))
+	for key, amount := range current {
+		change := BillingRunChange{BillingTargetKey: key, Current: amount}
+		prev, ok := previous[key]
+		switch {
+		case !ok:
+			change.Type = BillingChangeAdded
+		case prev == amount:
+			change.Type = BillingChangeUnchanged
+		default:
+			change.Type = BillingChangeChanged
+		}
+		change.Previous = prev
+		changes = append(changes, change)
+	}
+	for key, amount := range previous {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		changes = append(changes, BillingRunChange{BillingTargetKey: key, Type: BillingChangeRemoved, Previous: amount})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ClientID != changes[j].ClientID {
+			return changes[i].ClientID < changes[j].ClientID
+		}
+		return changes[i].ProjectGroupID < changes[j].ProjectGroupID
+	})
+	return changes
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestBillingMonthHelpers(t *testing.T) {
+	assert.Equal(t, "2024-03", BillingMonthString(2024, 3))
+
+	start, end := BillingMonthRange(2024, 2)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), end)
+
+	year, month := PreviousBillingMonth(2024, 1)
+	assert.Equal(t, 2023, year)
+	assert.Equal(t, 12, month)
+}
+
+func TestBillingTargetKeyOf(t *testing.T) {
+	groupID := "group-1"
+	assert.Equal(t, BillingTargetKey{ClientID: "client-1"}, BillingTargetKeyOf(&Invoice{ClientID: "client-1"}))
+	assert.Equal(t, BillingTargetKey{ClientID: "client-1", ProjectGroupID: groupID},
+		BillingTargetKeyOf(&Invoice{ClientID: "client-1", ProjectGroupID: &groupID}))
+}
+
+func TestDiffBillingTotals(t *testing.T) {
+	clientA := BillingTargetKey{ClientID: "a"}
+	clientAGroup := BillingTargetKey{ClientID: "a", ProjectGroupID: "g1"}
+	clientB := BillingTargetKey{ClientID: "b"}
+	clientC := BillingTargetKey{ClientID: "c"}
+
+	previous := map[BillingTargetKey]money.Amount{
+		clientA:      money.Yen(880000),
+		clientAGroup: money.Yen(1100000),
+		clientC:      money.Yen(550000),
+	}
+	current := map[BillingTargetKey]money.Amount{
+		clientA:      money.Yen(880000),
+		clientAGroup: money.Yen(1210000),
+		clientB:      money.Yen(660000),
+	}
+
+	changes := DiffBillingTotals(previous, current)
+	require.Len(t, changes, 4)
+
+	assert.Equal(t, clientA, changes[0].BillingTargetKey)
+	assert.Equal(t, BillingChangeUnchanged, changes[0].Type)
+	assert.Equal(t, money.Zero, changes[0].Difference())
+
+	assert.Equal(t, clientAGroup, changes[1].BillingTargetKey)
+	assert.Equal(t, BillingChangeChanged, changes[1].Type)
+	assert.Equal(t, money.Yen(110000), changes[1].Difference())
+
+	assert.Equal(t, clientB, changes[2].BillingTargetKey)
+	assert.Equal(t, BillingChangeAdded, changes[2].Type)
+	assert.Equal(t, money.Zero, changes[2].Previous)
+
+	assert.Equal(t, clientC, changes[3].BillingTargetKey)
+	assert.Equal(t, BillingChangeRemoved, changes[3].Type)
+	assert.Equal(t, money.Yen(-550000), changes[3].Difference())
+}
+
+func TestBillingRunIsLocked(t *testing.T) {
+	assert.False(t, (&BillingRun{Status: BillingRunStatusDraft}).IsLocked())
+	assert.True(t, (&BillingRun{Status: BillingRunStatusApproved}).IsLocked())
+}
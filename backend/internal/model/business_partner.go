@@ -0,0 +1,233 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// BusinessPartner ビジネスパートナー（エンジニアの所属する協力会社）
+type BusinessPartner struct {
+	ID                 string          `gorm:"type:varchar(36);primary_key" json:"id"`
+	CompanyName        string          `gorm:"size:200;not null" json:"company_name"`
+	CompanyNameKana    string          `gorm:"size:200" json:"company_name_kana"`
+	RegistrationNumber string          `gorm:"size:14" json:"registration_number"` // 適格請求書発行事業者登録番号
+	PaymentTerms       int             `gorm:"default:30" json:"payment_terms"`    // 支払サイト（日）
+	ProrationMethod    ProrationMethod `gorm:"size:20;not null;default:'business_days'" json:"proration_method"`
+	ContactPerson      string          `gorm:"size:100" json:"contact_person"`
+	ContactEmail       string          `gorm:"size:100" json:"contact_email"`
+	ContactPhone       string          `gorm:"size:20" json:"contact_phone"`
+	Address            string          `gorm:"size:255" json:"address"`
+	Notes              string          `gorm:"type:text" json:"notes"`
+	IsActive           bool            `gorm:"default:true" json:"is_active"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// TableName テーブル名を指定
+func (BusinessPartner) TableName() string {
+	return "business_partners"
+}
+
+// BeforeCreate UUID生成
+func (p *BusinessPartner) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ProjectAssignmentCost 案件アサインの仕入単価（ビジネスパートナーとの契約条件）
+type ProjectAssignmentCost struct {
+	ID            string             `gorm:"type:varchar(36);primary_key" json:"id"`
+	AssignmentID  string             `gorm:"type:varchar(36);not null;index" json:"assignment_id"`
+	PartnerID     string             `gorm:"type:varchar(36);not null;index" json:"partner_id"`
+	EffectiveFrom time.Time          `gorm:"type:date;not null" json:"effective_from"`
+	EffectiveTo   *time.Time         `gorm:"type:date" json:"effective_to"`
+	CostType      ProjectBillingType `gorm:"type:billing_type_enum;not null;default:'fixed'" json:"cost_type"`
+	CostRate      money.Amount       `gorm:"type:decimal(10,2);not null" json:"cost_rate"`
+	MinHours      *float64           `gorm:"type:decimal(5,2)" json:"min_hours"`
+	MaxHours      *float64           `gorm:"type:decimal(5,2)" json:"max_hours"`
+	Notes         string             `gorm:"type:text" json:"notes"`
+	CreatedBy     string             `gorm:"type:varchar(255);not null" json:"created_by"`
+	UpdatedBy     string             `gorm:"type:varchar(255);not null" json:"updated_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt     `gorm:"index" json:"-"`
+
+	// リレーション
+	Partner *BusinessPartner `gorm:"foreignKey:PartnerID" json:"partner,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (ProjectAssignmentCost) TableName() string {
+	return "project_assignment_costs"
+}
+
+// BeforeCreate UUID生成
+func (c *ProjectAssignmentCost) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// AsRate 請求側と同じ精算ロジックで計算するため、仕入単価を単価履歴として扱う
+func (c *ProjectAssignmentCost) AsRate() *ProjectAssignmentRate {
+	return &ProjectAssignmentRate{
+		ID:            c.ID,
+		AssignmentID:  c.AssignmentID,
+		EffectiveFrom: c.EffectiveFrom,
+		EffectiveTo:   c.EffectiveTo,
+		BillingType:   c.CostType,
+		BillingRate:   c.CostRate,
+		MinHours:      c.MinHours,
+		MaxHours:      c.MaxHours,
+		Notes:         c.Notes,
+	}
+}
+
+// PurchaseOrderStatus 注文書の状態
+type PurchaseOrderStatus string
+
+const (
+	// PurchaseOrderStatusIssued 発行済み
+	PurchaseOrderStatusIssued PurchaseOrderStatus = "issued"
+	// PurchaseOrderStatusCancelled 取消
+	PurchaseOrderStatusCancelled PurchaseOrderStatus = "cancelled"
+)
+
+// PurchaseOrder ビジネスパートナーへの月次の注文書（発行時点の契約条件を記録する）
+type PurchaseOrder struct {
+	ID           string              `gorm:"type:varchar(36);primary_key" json:"id"`
+	OrderNumber  string              `gorm:"size:50;not null;unique" json:"order_number"`
+	PartnerID    string              `gorm:"type:varchar(36);not null;index" json:"partner_id"`
+	AssignmentID string              `gorm:"type:varchar(36);not null;index" json:"assignment_id"`
+	CostID       string              `gorm:"type:varchar(36);not null" json:"cost_id"`
+	OrderMonth   string              `gorm:"size:7;not null;index" json:"order_month"`
+	PeriodStart  time.Time           `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd    time.Time           `gorm:"type:date;not null" json:"period_end"`
+	CostType     ProjectBillingType  `gorm:"type:billing_type_enum;not null" json:"cost_type"`
+	CostRate     money.Amount        `gorm:"type:decimal(10,2);not null" json:"cost_rate"`
+	MinHours     *float64            `gorm:"type:decimal(5,2)" json:"min_hours"`
+	MaxHours     *float64            `gorm:"type:decimal(5,2)" json:"max_hours"`
+	Status       PurchaseOrderStatus `gorm:"size:20;not null;default:'issued'" json:"status"`
+	IssuedBy     string              `gorm:"type:varchar(255);not null" json:"issued_by"`
+	IssuedAt     time.Time           `gorm:"not null" json:"issued_at"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+
+	// リレーション
+	Partner *BusinessPartner `gorm:"foreignKey:PartnerID" json:"partner,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// BeforeCreate UUID生成
+func (o *PurchaseOrder) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// PartnerInvoiceStatus 受領した請求書の状態
+type PartnerInvoiceStatus string
+
+const (
+	// PartnerInvoiceStatusMatched 突合一致
+	PartnerInvoiceStatusMatched PartnerInvoiceStatus = "matched"
+	// PartnerInvoiceStatusMismatched 突合不一致（確認が必要）
+	PartnerInvoiceStatusMismatched PartnerInvoiceStatus = "mismatched"
+	// PartnerInvoiceStatusApproved 承認済み（支払対象）
+	PartnerInvoiceStatusApproved PartnerInvoiceStatus = "approved"
+	// PartnerInvoiceStatusRejected 差戻し
+	PartnerInvoiceStatusRejected PartnerInvoiceStatus = "rejected"
+)
+
+// PartnerInvoice ビジネスパートナーから受領した請求書
+type PartnerInvoice struct {
+	ID              string               `gorm:"type:varchar(36);primary_key" json:"id"`
+	PartnerID       string               `gorm:"type:varchar(36);not null;index" json:"partner_id"`
+	PurchaseOrderID string               `gorm:"type:varchar(36);not null;index" json:"purchase_order_id"`
+	AssignmentID    string               `gorm:"type:varchar(36);not null;index" json:"assignment_id"`
+	BillingMonth    string               `gorm:"size:7;not null;index" json:"billing_month"`
+	InvoiceNumber   string               `gorm:"size:50" json:"invoice_number"` // パートナー側の請求書番号
+	InvoiceDate     time.Time            `gorm:"type:date;not null" json:"invoice_date"`
+	DueDate         time.Time            `gorm:"type:date;not null" json:"due_date"`
+	Hours           *float64             `gorm:"type:decimal(6,2)" json:"hours"` // 請求書に記載された稼働時間
+	Subtotal        money.Amount         `gorm:"type:decimal(12,2);not null" json:"subtotal"`
+	TaxAmount       money.Amount         `gorm:"type:decimal(12,2);not null" json:"tax_amount"`
+	TotalAmount     money.Amount         `gorm:"type:decimal(12,2);not null" json:"total_amount"`
+	Status          PartnerInvoiceStatus `gorm:"size:20;not null" json:"status"`
+
+	// 突合結果
+	ExpectedAmount *money.Amount `gorm:"type:decimal(12,2)" json:"expected_amount"` // 注文条件と週報から計算した仕入額（税抜）
+	ExpectedHours  *float64      `gorm:"type:decimal(6,2)" json:"expected_hours"`   // 承認済みの週報の稼働時間
+	BilledHours    *float64      `gorm:"type:decimal(6,2)" json:"billed_hours"`     // 取引先に請求した稼働時間
+	MatchNotes     string        `gorm:"type:text" json:"match_notes"`
+
+	ApprovedBy *string    `gorm:"type:varchar(255)" json:"approved_by"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	CreatedBy  string     `gorm:"type:varchar(255);not null" json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// リレーション
+	Partner       *BusinessPartner `gorm:"foreignKey:PartnerID" json:"partner,omitempty"`
+	PurchaseOrder *PurchaseOrder   `gorm:"foreignKey:PurchaseOrderID" json:"purchase_order,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (PartnerInvoice) TableName() string {
+	return "partner_invoices"
+}
+
+// BeforeCreate UUID生成
+func (i *PartnerInvoice) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Match 受領した請求書を仕入額の見込み・週報の稼働時間・取引先への請求時間と突合する
+// 稼働時間の見込みや請求実績がない項目は突合しない
+func (i *PartnerInvoice) Match(expectedAmount money.Amount, expectedHours, billedHours *float64) {
+	i.ExpectedAmount = &expectedAmount
+	i.ExpectedHours = expectedHours
+	i.BilledHours = billedHours
+
+	var notes []string
+	if i.Subtotal != expectedAmount {
+		notes = append(notes, fmt.Sprintf("請求額が注文条件と一致しません（請求: %s円, 見込: %s円）", i.Subtotal.String(), expectedAmount.String()))
+	}
+	if i.Hours != nil && expectedHours != nil && !sameHours(*i.Hours, *expectedHours) {
+		notes = append(notes, fmt.Sprintf("稼働時間が週報と一致しません（請求: %.2fh, 週報: %.2fh）", *i.Hours, *expectedHours))
+	}
+	if i.Hours != nil && billedHours != nil && !sameHours(*i.Hours, *billedHours) {
+		notes = append(notes, fmt.Sprintf("稼働時間が取引先への請求と一致しません（請求: %.2fh, 取引先: %.2fh）", *i.Hours, *billedHours))
+	}
+
+	i.MatchNotes = strings.Join(notes, "\n")
+	if len(notes) > 0 {
+		i.Status = PartnerInvoiceStatusMismatched
+	} else {
+		i.Status = PartnerInvoiceStatusMatched
+	}
+}
+
+// sameHours 稼働時間が一致するか（小数点第2位まで比較）
+func sameHours(a, b float64) bool {
+	return math.Abs(roundHours(a)-roundHours(b)) < 0.005
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestPartnerInvoiceMatch(t *testing.T) {
+	hours := 152.5
+	billed := 152.5
+	invoice := &PartnerInvoice{Subtotal: money.Yen(550000), Hours: &hours}
+
+	invoice.Match(money.Yen(550000), &hours, &billed)
+	assert.Equal(t, PartnerInvoiceStatusMatched, invoice.Status)
+	assert.Empty(t, invoice.MatchNotes)
+
+	// 週報より多い時間で請求されている
+	reported := 150.0
+	invoice.Match(money.Yen(540000), &reported, &billed)
+	assert.Equal(t, PartnerInvoiceStatusMismatched, invoice.Status)
+	assert.Equal(t, "請求額が注文条件と一致しません（請求: 550000円, 見込: 540000円）\n"+
+		"稼働時間が週報と一致しません（請求: 152.50h, 週報: 150.00h）", invoice.MatchNotes)
+}
+
+func TestBuildMarginReport(t *testing.T) {
+	revenues := []MarginEntry{
+		{Month: "2024-04", ClientID: "c1", ProjectID: "p1", UserID: "u1", Amount: money.Yen(800000)},
+		{Month: "2024-04", ClientID: "c1", ProjectID: "p1", UserID: "u2", Amount: money.Yen(700000)},
+		{Month: "2024-05", ClientID: "c1", ProjectID: "p1", UserID: "u1", Amount: money.Yen(800000)},
+	}
+	costs := []MarginEntry{
+		{Month: "2024-04", ClientID: "c1", ProjectID: "p1", UserID: "u1", Amount: money.Yen(640000)},
+	}
+
+	rows := BuildMarginReport(MarginGroupByEngineer, revenues, costs)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, MarginRow{Month: "2024-04", UserID: "u1", Revenue: money.Yen(800000), Cost: money.Yen(640000), GrossProfit: money.Yen(160000), MarginRate: 20}, rows[0])
+	assert.Equal(t, "u2", rows[1].UserID)
+	assert.Equal(t, float64(100), rows[1].MarginRate)
+
+	rows = BuildMarginReport(MarginGroupByClient, revenues, costs)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, money.Yen(1500000), rows[0].Revenue)
+	assert.Equal(t, money.Yen(860000), rows[0].GrossProfit)
+	assert.Equal(t, 57.3, rows[0].MarginRate)
+
+	total := SumMarginRows(rows)
+	assert.Equal(t, money.Yen(2300000), total.Revenue)
+	assert.Equal(t, money.Yen(1660000), total.GrossProfit)
+	assert.Equal(t, 72.2, total.MarginRate)
+}
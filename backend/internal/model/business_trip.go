@@ -0,0 +1,263 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BusinessTripStatus 出張申請のステータス
+type BusinessTripStatus string
+
+const (
+	// BusinessTripStatusPending 事前承認待ち
+	BusinessTripStatusPending BusinessTripStatus = "pending"
+	// BusinessTripStatusApproved 事前承認済み（出張後に精算する）
+	BusinessTripStatusApproved BusinessTripStatus = "approved"
+	// BusinessTripStatusRejected 却下
+	BusinessTripStatusRejected BusinessTripStatus = "rejected"
+	// BusinessTripStatusCancelled 取消
+	BusinessTripStatusCancelled BusinessTripStatus = "cancelled"
+	// BusinessTripStatusSettlementPending 精算の承認待ち
+	BusinessTripStatusSettlementPending BusinessTripStatus = "settlement_pending"
+	// BusinessTripStatusSettled 精算済み
+	BusinessTripStatusSettled BusinessTripStatus = "settled"
+)
+
+// BusinessTripType 出張の区分（日当の単価を決める）
+type BusinessTripType string
+
+const (
+	// BusinessTripTypeDayTrip 日帰り出張
+	BusinessTripTypeDayTrip BusinessTripType = "day_trip"
+	// BusinessTripTypeOvernight 宿泊を伴う出張
+	BusinessTripTypeOvernight BusinessTripType = "overnight"
+)
+
+// IsValid 有効な出張の区分かチェック
+func (t BusinessTripType) IsValid() bool {
+	return t == BusinessTripTypeDayTrip || t == BusinessTripTypeOvernight
+}
+
+// BusinessTripVarianceAlertRate 承認者に注意を促す精算額と見積額の差の割合（見積額に対して）
+const BusinessTripVarianceAlertRate = 0.1
+
+// BusinessTripSettlementExpenseStatuses 出張精算で精算できる経費申請のステータス（提出済み以降、却下・取消を除く）
+var BusinessTripSettlementExpenseStatuses = []ExpenseStatus{
+	ExpenseStatusSubmitted,
+	ExpenseStatusApproved,
+	ExpenseStatusPaid,
+	ExpenseStatusClosed,
+}
+
+// IsBusinessTripSettlementExpense 出張精算で精算できる経費申請かチェック
+func IsBusinessTripSettlementExpense(expense *Expense) bool {
+	for _, status := range BusinessTripSettlementExpenseStatuses {
+		if expense.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// businessTripDateLayout 出張期間の比較に使う日付の書式
+const businessTripDateLayout = "2006-01-02"
+
+// BusinessTrip 出張申請
+// 出張前に目的・行き先・日程・概算費用の事前承認を受け、出張後に関連する経費申請と日当で精算する
+type BusinessTrip struct {
+	ID            string             `gorm:"type:varchar(36);primary_key" json:"id"`
+	UserID        string             `gorm:"type:varchar(255);not null;index" json:"user_id"`
+	User          *User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	ProjectID     *string            `gorm:"type:varchar(255);index" json:"project_id"` // 出張先の顧客案件
+	Project       *Project           `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
+	Purpose       string             `gorm:"type:text;not null" json:"purpose"`
+	Destination   string             `gorm:"size:255;not null" json:"destination"`
+	StartDate     time.Time          `gorm:"type:date;not null" json:"start_date"`
+	EndDate       time.Time          `gorm:"type:date;not null" json:"end_date"`
+	EstimatedCost int                `gorm:"not null" json:"estimated_cost"` // 概算費用（交通費・宿泊費・日当の合計、円）
+	Status        BusinessTripStatus `gorm:"size:20;not null;default:'pending'" json:"status"`
+
+	// 事前承認
+	ApproverID      *string    `gorm:"type:varchar(255)" json:"approver_id"`
+	Approver        *User      `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+	OnBehalfOfID    *string    `gorm:"type:varchar(255)" json:"on_behalf_of_id"` // 代理承認の場合の本来の承認者
+	ApprovedAt      *time.Time `json:"approved_at"`
+	ApprovalComment string     `gorm:"type:text" json:"approval_comment"`
+
+	// 精算
+	AllowanceDays         int        `gorm:"not null;default:0" json:"allowance_days"`
+	AllowanceDailyRate    int        `gorm:"not null;default:0" json:"allowance_daily_rate"` // 日当の単価（円）
+	AllowanceAmount       int        `gorm:"not null;default:0" json:"allowance_amount"`     // 日当の合計（円）
+	ExpenseTotal          int        `gorm:"not null;default:0" json:"expense_total"`        // 関連する経費申請の合計（円）
+	SettlementSubmittedAt *time.Time `json:"settlement_submitted_at"`
+	SettledBy             *string    `gorm:"type:varchar(255)" json:"settled_by"`
+	SettledAt             *time.Time `json:"settled_at"`
+	SettlementComment     string     `gorm:"type:text" json:"settlement_comment"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// リレーション
+	Expenses []Expense `gorm:"foreignKey:BusinessTripID" json:"expenses,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (BusinessTrip) TableName() string {
+	return "business_trips"
+}
+
+// BeforeCreate UUID生成
+func (t *BusinessTrip) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Validate 出張申請の内容をチェック
+func (t *BusinessTrip) Validate() error {
+	if t.Purpose == "" {
+		return fmt.Errorf("出張の目的を入力してください")
+	}
+	if t.Destination == "" {
+		return fmt.Errorf("出張先を入力してください")
+	}
+	if t.EndDate.Format(businessTripDateLayout) < t.StartDate.Format(businessTripDateLayout) {
+		return fmt.Errorf("終了日は開始日以降の日付を指定してください")
+	}
+	if t.EstimatedCost < 0 {
+		return fmt.Errorf("概算費用は0円以上で入力してください")
+	}
+	return nil
+}
+
+// Days 出張の日数（開始日と終了日を含む）
+func (t *BusinessTrip) Days() int {
+	start, _ := time.Parse(businessTripDateLayout, t.StartDate.Format(businessTripDateLayout))
+	end, _ := time.Parse(businessTripDateLayout, t.EndDate.Format(businessTripDateLayout))
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// TripType 出張の区分（開始日と終了日が同じ場合は日帰り）
+func (t *BusinessTrip) TripType() BusinessTripType {
+	if t.Days() > 1 {
+		return BusinessTripTypeOvernight
+	}
+	return BusinessTripTypeDayTrip
+}
+
+// CanEdit 申請者が内容を変更できるかチェック（事前承認前のみ）
+func (t *BusinessTrip) CanEdit() bool {
+	return t.Status == BusinessTripStatusPending
+}
+
+// CanCancel 申請者が取り消せるかチェック（精算の申請前まで）
+func (t *BusinessTrip) CanCancel() bool {
+	return t.Status == BusinessTripStatusPending || t.Status == BusinessTripStatusApproved
+}
+
+// CanSubmitSettlement 精算を申請できるかチェック（差し戻し後の再申請を含む）
+func (t *BusinessTrip) CanSubmitSettlement() bool {
+	return t.Status == BusinessTripStatusApproved
+}
+
+// ApplyExpenses 精算する経費申請の合計を設定（却下・取消された経費申請は含めない）
+func (t *BusinessTrip) ApplyExpenses(expenses []Expense) {
+	t.ExpenseTotal = 0
+	for i := range expenses {
+		if IsBusinessTripSettlementExpense(&expenses[i]) {
+			t.ExpenseTotal += expenses[i].Amount
+		}
+	}
+}
+
+// ApplyAllowance 日当の単価から日当を計算して設定
+func (t *BusinessTrip) ApplyAllowance(dailyRate int) {
+	t.AllowanceDays = t.Days()
+	t.AllowanceDailyRate = dailyRate
+	t.AllowanceAmount = t.AllowanceDays * dailyRate
+}
+
+// SettlementTotal 精算額（関連する経費申請と日当の合計）
+func (t *BusinessTrip) SettlementTotal() int {
+	return t.ExpenseTotal + t.AllowanceAmount
+}
+
+// Variance 精算額と概算費用の差（超過した場合は正）
+func (t *BusinessTrip) Variance() int {
+	return t.SettlementTotal() - t.EstimatedCost
+}
+
+// VarianceRate 概算費用に対する差の割合（概算費用が0円の場合は精算額があれば超過とみなして1）
+func (t *BusinessTrip) VarianceRate() float64 {
+	if t.EstimatedCost == 0 {
+		if t.SettlementTotal() == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(t.Variance()) / float64(t.EstimatedCost)
+}
+
+// HasSignificantVariance 精算額と概算費用の差が承認者に注意を促す大きさかチェック
+func (t *BusinessTrip) HasSignificantVariance() bool {
+	return math.Abs(t.VarianceRate()) > BusinessTripVarianceAlertRate
+}
+
+// BusinessTripPerDiemRate 出張の日当の単価表
+// 出張の区分ごとに適用開始日を指定して登録し、出張の開始日時点で有効な単価を使う
+type BusinessTripPerDiemRate struct {
+	ID            string           `gorm:"type:varchar(36);primary_key" json:"id"`
+	TripType      BusinessTripType `gorm:"size:20;not null" json:"trip_type"`
+	DailyAmount   int              `gorm:"not null" json:"daily_amount"` // 1日あたりの日当（円）
+	EffectiveFrom time.Time        `gorm:"type:date;not null" json:"effective_from"`
+	Description   string           `gorm:"size:255" json:"description"`
+	CreatedBy     string           `gorm:"type:varchar(255);not null" json:"created_by"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (BusinessTripPerDiemRate) TableName() string {
+	return "business_trip_per_diem_rates"
+}
+
+// BeforeCreate UUID生成
+func (r *BusinessTripPerDiemRate) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Validate 日当の単価の内容をチェック
+func (r *BusinessTripPerDiemRate) Validate() error {
+	if !r.TripType.IsValid() {
+		return fmt.Errorf("出張の区分が不正です: %s", r.TripType)
+	}
+	if r.DailyAmount < 0 {
+		return fmt.Errorf("日当は0円以上で入力してください")
+	}
+	return nil
+}
+
+// PerDiemRateFor 出張の区分と日付で有効な日当の単価を取得（該当がない場合はnil）
+// 適用開始日が指定日以前のうち最も新しい単価を使う
+func PerDiemRateFor(rates []BusinessTripPerDiemRate, tripType BusinessTripType, day time.Time) *BusinessTripPerDiemRate {
+	target := day.Format(businessTripDateLayout)
+	var found *BusinessTripPerDiemRate
+	for i := range rates {
+		rate := &rates[i]
+		if rate.TripType != tripType || rate.EffectiveFrom.Format(businessTripDateLayout) > target {
+			continue
+		}
+		if found == nil || rate.EffectiveFrom.After(found.EffectiveFrom) {
+			found = rate
+		}
+	}
+	return found
+}
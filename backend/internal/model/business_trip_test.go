@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessTripDaysAndType(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	trip := BusinessTrip{
+		StartDate: time.Date(2026, 10, 19, 0, 0, 0, 0, jst),
+		EndDate:   time.Date(2026, 10, 21, 0, 0, 0, 0, jst),
+	}
+	assert.Equal(t, 3, trip.Days())
+	assert.Equal(t, BusinessTripTypeOvernight, trip.TripType())
+
+	trip.EndDate = trip.StartDate
+	assert.Equal(t, 1, trip.Days())
+	assert.Equal(t, BusinessTripTypeDayTrip, trip.TripType())
+}
+
+func TestBusinessTripSettlement(t *testing.T) {
+	trip := BusinessTrip{
+		StartDate:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		EstimatedCost: 50000,
+	}
+	trip.ApplyAllowance(3000)
+	trip.ApplyExpenses([]Expense{
+		{Amount: 30000, Status: ExpenseStatusApproved},
+		{Amount: 12000, Status: ExpenseStatusSubmitted},
+		{Amount: 8000, Status: ExpenseStatusRejected},
+	})
+
+	assert.Equal(t, 2, trip.AllowanceDays)
+	assert.Equal(t, 6000, trip.AllowanceAmount)
+	assert.Equal(t, 48000, trip.SettlementTotal())
+	assert.Equal(t, -2000, trip.Variance())
+	assert.InDelta(t, -0.04, trip.VarianceRate(), 0.0001)
+	assert.False(t, trip.HasSignificantVariance())
+
+	// 見積もりを10%超えて超過した場合は承認者に注意を促す
+	trip.ExpenseTotal = 51000
+	assert.Equal(t, 7000, trip.Variance())
+	assert.True(t, trip.HasSignificantVariance())
+
+	// 概算費用が0円の場合は精算額があれば超過とみなす
+	trip.EstimatedCost = 0
+	assert.Equal(t, float64(1), trip.VarianceRate())
+}
+
+func TestBusinessTripValidate(t *testing.T) {
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	valid := BusinessTrip{Purpose: "顧客打ち合わせ", Destination: "大阪", StartDate: start, EndDate: start, EstimatedCost: 30000}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.EndDate = start.AddDate(0, 0, -1)
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.Destination = ""
+	assert.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.EstimatedCost = -1
+	assert.Error(t, invalid.Validate())
+}
+
+func TestPerDiemRateFor(t *testing.T) {
+	rates := []BusinessTripPerDiemRate{
+		{ID: "day-2025", TripType: BusinessTripTypeDayTrip, DailyAmount: 1000, EffectiveFrom: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "overnight-2025", TripType: BusinessTripTypeOvernight, DailyAmount: 3000, EffectiveFrom: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "overnight-2026", TripType: BusinessTripTypeOvernight, DailyAmount: 3500, EffectiveFrom: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	rate := PerDiemRateFor(rates, BusinessTripTypeOvernight, time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, rate)
+	assert.Equal(t, "overnight-2025", rate.ID)
+
+	rate = PerDiemRateFor(rates, BusinessTripTypeOvernight, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, rate)
+	assert.Equal(t, "overnight-2026", rate.ID)
+
+	rate = PerDiemRateFor(rates, BusinessTripTypeDayTrip, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, rate)
+	assert.Equal(t, 1000, rate.DailyAmount)
+
+	assert.Nil(t, PerDiemRateFor(rates, BusinessTripTypeDayTrip, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)))
+}
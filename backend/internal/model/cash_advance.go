@@ -0,0 +1,280 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// CashAdvanceStatus 仮払金のステータス
+type CashAdvanceStatus string
+
+const (
+	// CashAdvanceStatusPending 承認待ち
+	CashAdvanceStatusPending CashAdvanceStatus = "pending"
+	// CashAdvanceStatusApproved 承認済み（支払待ち）
+	CashAdvanceStatusApproved CashAdvanceStatus = "approved"
+	// CashAdvanceStatusRejected 却下
+	CashAdvanceStatusRejected CashAdvanceStatus = "rejected"
+	// CashAdvanceStatusCancelled 取消
+	CashAdvanceStatusCancelled CashAdvanceStatus = "cancelled"
+	// CashAdvanceStatusPaid 支払済み（精算待ち）
+	CashAdvanceStatusPaid CashAdvanceStatus = "paid"
+	// CashAdvanceStatusSettlementPending 精算の承認待ち
+	CashAdvanceStatusSettlementPending CashAdvanceStatus = "settlement_pending"
+	// CashAdvanceStatusSettled 精算承認済み（差額の返金・追加支払待ち）
+	CashAdvanceStatusSettled CashAdvanceStatus = "settled"
+	// CashAdvanceStatusClosed 精算完了
+	CashAdvanceStatusClosed CashAdvanceStatus = "closed"
+)
+
+// CashAdvanceOutstandingStatuses 未精算として経理に示す仮払金のステータス
+var CashAdvanceOutstandingStatuses = []CashAdvanceStatus{
+	CashAdvanceStatusPaid,
+	CashAdvanceStatusSettlementPending,
+	CashAdvanceStatusSettled,
+}
+
+// CashAdvanceDifferenceType 精算差額の種類
+type CashAdvanceDifferenceType string
+
+const (
+	// CashAdvanceDifferenceNone 差額なし
+	CashAdvanceDifferenceNone CashAdvanceDifferenceType = "none"
+	// CashAdvanceDifferenceRefund 使わなかった分を社員が返金する
+	CashAdvanceDifferenceRefund CashAdvanceDifferenceType = "refund"
+	// CashAdvanceDifferenceReimburse 不足分を会社が追加で支払う
+	CashAdvanceDifferenceReimburse CashAdvanceDifferenceType = "reimburse"
+)
+
+// CashAdvanceReminderInterval 精算期限を過ぎた仮払金の督促を再送する間隔
+const CashAdvanceReminderInterval = 7 * 24 * time.Hour
+
+// CashAdvanceSettlementExpenseStatuses 仮払金の精算に使える経費申請のステータス（提出済み以降、却下・取消・支払済みを除く）
+var CashAdvanceSettlementExpenseStatuses = []ExpenseStatus{
+	ExpenseStatusSubmitted,
+	ExpenseStatusApproved,
+	ExpenseStatusClosed,
+}
+
+// IsCashAdvanceSettlementExpense 仮払金の精算に使える経費申請かチェック（振込で精算済みのものは除く）
+func IsCashAdvanceSettlementExpense(expense *Expense) bool {
+	if expense.PaymentBatchID != nil || expense.PaidAt != nil {
+		return false
+	}
+	for _, status := range CashAdvanceSettlementExpenseStatuses {
+		if expense.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CashAdvance 仮払金
+// 承認後に支払い、実際の経費申請を紐付けて精算する。差額は社員が返金するか会社が追加で支払う
+type CashAdvance struct {
+	ID                string            `gorm:"type:varchar(36);primary_key" json:"id"`
+	UserID            string            `gorm:"type:varchar(255);not null;index" json:"user_id"`
+	User              *User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Purpose           string            `gorm:"type:text;not null" json:"purpose"`
+	Amount            money.Amount      `gorm:"type:decimal(10,2);not null" json:"amount"`     // 仮払金額
+	SettlementDueDate time.Time         `gorm:"type:date;not null" json:"settlement_due_date"` // 精算期限
+	Status            CashAdvanceStatus `gorm:"size:20;not null;default:'pending'" json:"status"`
+
+	// 承認（経費申請と同じ承認ルール・承認者設定による承認フローで承認する）
+	ApprovalRuleID  *string    `gorm:"type:varchar(36)" json:"approval_rule_id"` // 適用した承認ルール（ルール外の場合はnil）
+	ApproverID      *string    `gorm:"type:varchar(255)" json:"approver_id"`     // 最終承認者
+	Approver        *User      `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+	OnBehalfOfID    *string    `gorm:"type:varchar(255)" json:"on_behalf_of_id"` // 代理承認の場合の本来の承認者
+	ApprovedAt      *time.Time `json:"approved_at"`
+	ApprovalComment string     `gorm:"type:text" json:"approval_comment"`
+
+	// 支払
+	PaidAt *time.Time `gorm:"type:date" json:"paid_at"`
+	PaidBy *string    `gorm:"type:varchar(255)" json:"paid_by"`
+
+	// 精算
+	ExpenseTotal          money.Amount `gorm:"type:decimal(10,2);not null;default:0" json:"expense_total"` // 紐付けた経費申請の合計
+	SettlementSubmittedAt *time.Time   `json:"settlement_submitted_at"`
+	SettledBy             *string      `gorm:"type:varchar(255)" json:"settled_by"`
+	SettledAt             *time.Time   `json:"settled_at"`
+	SettlementComment     string       `gorm:"type:text" json:"settlement_comment"`
+	ClosedAt              *time.Time   `gorm:"type:date" json:"closed_at"` // 差額の返金・追加支払が完了した日
+	ClosedBy              *string      `gorm:"type:varchar(255)" json:"closed_by"`
+
+	// 督促
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	ReminderCount  int        `gorm:"not null;default:0" json:"reminder_count"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// リレーション
+	Expenses  []Expense         `gorm:"foreignKey:CashAdvanceID" json:"expenses,omitempty"`
+	Approvals []ExpenseApproval `gorm:"foreignKey:CashAdvanceID" json:"approvals,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (CashAdvance) TableName() string {
+	return "cash_advances"
+}
+
+// BeforeCreate UUID生成
+func (a *CashAdvance) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Validate 仮払金の申請内容をチェック
+func (a *CashAdvance) Validate() error {
+	if strings.TrimSpace(a.Purpose) == "" {
+		return fmt.Errorf("仮払いの目的を入力してください")
+	}
+	if a.Amount < money.Yen(1) {
+		return fmt.Errorf("仮払金額は1円以上で入力してください")
+	}
+	if a.SettlementDueDate.IsZero() {
+		return fmt.Errorf("精算期限を入力してください")
+	}
+	return nil
+}
+
+// CanEdit 申請者が内容を変更できるかチェック（承認前のみ）
+func (a *CashAdvance) CanEdit() bool {
+	return a.Status == CashAdvanceStatusPending
+}
+
+// CanCancel 申請者が取り消せるかチェック（支払前まで）
+func (a *CashAdvance) CanCancel() bool {
+	return a.Status == CashAdvanceStatusPending || a.Status == CashAdvanceStatusApproved
+}
+
+// CanSubmitSettlement 精算を申請できるかチェック（差し戻し後の再申請を含む）
+func (a *CashAdvance) CanSubmitSettlement() bool {
+	return a.Status == CashAdvanceStatusPaid
+}
+
+// IsOutstanding 未精算の仮払金かチェック
+func (a *CashAdvance) IsOutstanding() bool {
+	for _, status := range CashAdvanceOutstandingStatuses {
+		if a.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyExpenses 精算する経費申請の合計を設定（却下・取消・期限切れの経費申請は含めない）
+func (a *CashAdvance) ApplyExpenses(expenses []Expense) {
+	a.ExpenseTotal = 0
+	for i := range expenses {
+		switch expenses[i].Status {
+		case ExpenseStatusRejected, ExpenseStatusCancelled, ExpenseStatusExpired, ExpenseStatusDraft:
+			continue
+		}
+		a.ExpenseTotal = a.ExpenseTotal.Add(money.Yen(int64(expenses[i].Amount)))
+	}
+}
+
+// Difference 仮払金額と精算額の差（社員が返金する場合は正、会社が追加で支払う場合は負）
+func (a *CashAdvance) Difference() money.Amount {
+	return a.Amount.Sub(a.ExpenseTotal)
+}
+
+// DifferenceType 精算差額の種類
+func (a *CashAdvance) DifferenceType() CashAdvanceDifferenceType {
+	switch a.Difference().Sign() {
+	case 1:
+		return CashAdvanceDifferenceRefund
+	case -1:
+		return CashAdvanceDifferenceReimburse
+	default:
+		return CashAdvanceDifferenceNone
+	}
+}
+
+// DaysOverdue 精算期限を過ぎた日数（精算待ちでない場合や期限内の場合は0）
+func (a *CashAdvance) DaysOverdue(now time.Time) int {
+	if a.Status != CashAdvanceStatusPaid {
+		return 0
+	}
+	today, due := dateOnly(now), dateOnly(a.SettlementDueDate)
+	if !today.After(due) {
+		return 0
+	}
+	return int(today.Sub(due).Hours() / 24)
+}
+
+// IsOverdue 精算期限を過ぎても精算を申請していないかチェック
+func (a *CashAdvance) IsOverdue(now time.Time) bool {
+	return a.DaysOverdue(now) > 0
+}
+
+// NeedsReminder 督促を送るかチェック（前回の督促から一定期間は再送しない）
+func (a *CashAdvance) NeedsReminder(now time.Time) bool {
+	if !a.IsOverdue(now) {
+		return false
+	}
+	return a.LastRemindedAt == nil || now.Sub(*a.LastRemindedAt) >= CashAdvanceReminderInterval
+}
+
+// CashAdvanceBalance 社員ごとの未精算の仮払金
+type CashAdvanceBalance struct {
+	UserID            string
+	User              *User
+	OutstandingCount  int          // 精算待ち・精算の承認待ちの件数
+	OutstandingAmount money.Amount // 精算待ち・精算の承認待ちの仮払金額の合計
+	OverdueCount      int          // 精算期限を過ぎた件数
+	RefundDue         money.Amount // 社員からの返金待ちの合計
+	ReimbursementDue  money.Amount // 会社からの追加支払待ちの合計
+}
+
+// SummarizeCashAdvanceBalances 未精算の仮払金を社員ごとに集計（未精算の仮払金額の多い順）
+func SummarizeCashAdvanceBalances(advances []CashAdvance, now time.Time) []CashAdvanceBalance {
+	byUser := make(map[string]*CashAdvanceBalance)
+	var userIDs []string
+	for i := range advances {
+		advance := &advances[i]
+		if !advance.IsOutstanding() {
+			continue
+		}
+		balance, ok := byUser[advance.UserID]
+		if !ok {
+			balance = &CashAdvanceBalance{UserID: advance.UserID, User: advance.User}
+			byUser[advance.UserID] = balance
+			userIDs = append(userIDs, advance.UserID)
+		}
+
+		if advance.Status == CashAdvanceStatusSettled {
+			switch advance.DifferenceType() {
+			case CashAdvanceDifferenceRefund:
+				balance.RefundDue = balance.RefundDue.Add(advance.Difference())
+			case CashAdvanceDifferenceReimburse:
+				balance.ReimbursementDue = balance.ReimbursementDue.Sub(advance.Difference())
+			}
+			continue
+		}
+		balance.OutstandingCount++
+		balance.OutstandingAmount = balance.OutstandingAmount.Add(advance.Amount)
+		if advance.IsOverdue(now) {
+			balance.OverdueCount++
+		}
+	}
+
+	balances := make([]CashAdvanceBalance, 0, len(userIDs))
+	for _, userID := range userIDs {
+		balances = append(balances, *byUser[userID])
+	}
+	sort.SliceStable(balances, func(i, j int) bool {
+		return balances[i].OutstandingAmount > balances[j].OutstandingAmount
+	})
+	return balances
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestCashAdvance_ApplyExpenses(t *testing.T) {
+	tests := []struct {
+		name           string
+		amount         money.Amount
+		expenses       []Expense
+		wantTotal      money.Amount
+		wantDifference money.Amount
+		wantType       CashAdvanceDifferenceType
+	}{
+		{
+			name:   "使わなかった分を返金",
+			amount: money.Yen(100000),
+			expenses: []Expense{
+				{Amount: 60000, Status: ExpenseStatusApproved},
+				{Amount: 25000, Status: ExpenseStatusSubmitted},
+			},
+			wantTotal:      money.Yen(85000),
+			wantDifference: money.Yen(15000),
+			wantType:       CashAdvanceDifferenceRefund,
+		},
+		{
+			name:   "不足分を追加で支払",
+			amount: money.Yen(100000),
+			expenses: []Expense{
+				{Amount: 120000, Status: ExpenseStatusApproved},
+			},
+			wantTotal:      money.Yen(120000),
+			wantDifference: money.Yen(-20000),
+			wantType:       CashAdvanceDifferenceReimburse,
+		},
+		{
+			name:   "却下・取消の経費申請は含めない",
+			amount: money.Yen(50000),
+			expenses: []Expense{
+				{Amount: 50000, Status: ExpenseStatusClosed},
+				{Amount: 9000, Status: ExpenseStatusRejected},
+				{Amount: 3000, Status: ExpenseStatusCancelled},
+			},
+			wantTotal:      money.Yen(50000),
+			wantDifference: 0,
+			wantType:       CashAdvanceDifferenceNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advance := CashAdvance{Amount: tt.amount}
+			advance.ApplyExpenses(tt.expenses)
+
+			assert.Equal(t, tt.wantTotal, advance.ExpenseTotal)
+			assert.Equal(t, tt.wantDifference, advance.Difference())
+			assert.Equal(t, tt.wantType, advance.DifferenceType())
+		})
+	}
+}
+
+func TestIsCashAdvanceSettlementExpense(t *testing.T) {
+	batchID := "batch-1"
+	paidAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expense Expense
+		want    bool
+	}{
+		{name: "承認済み", expense: Expense{Status: ExpenseStatusApproved}, want: true},
+		{name: "提出済み", expense: Expense{Status: ExpenseStatusSubmitted}, want: true},
+		{name: "下書き", expense: Expense{Status: ExpenseStatusDraft}, want: false},
+		{name: "振込バッチで精算済み", expense: Expense{Status: ExpenseStatusApproved, PaymentBatchID: &batchID}, want: false},
+		{name: "支払済み", expense: Expense{Status: ExpenseStatusApproved, PaidAt: &paidAt}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsCashAdvanceSettlementExpense(&tt.expense))
+		})
+	}
+}
+
+func TestCashAdvance_NeedsReminder(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, jst)
+	remindedRecently := now.Add(-48 * time.Hour)
+	remindedLongAgo := now.Add(-CashAdvanceReminderInterval)
+
+	tests := []struct {
+		name            string
+		status          CashAdvanceStatus
+		dueDate         time.Time
+		lastRemindedAt  *time.Time
+		wantDaysOverdue int
+		wantReminder    bool
+	}{
+		{
+			name:            "精算期限の当日は期限内",
+			status:          CashAdvanceStatusPaid,
+			dueDate:         time.Date(2026, 10, 17, 0, 0, 0, 0, jst),
+			wantDaysOverdue: 0,
+			wantReminder:    false,
+		},
+		{
+			name:            "精算期限を3日過ぎている",
+			status:          CashAdvanceStatusPaid,
+			dueDate:         time.Date(2026, 10, 14, 0, 0, 0, 0, jst),
+			wantDaysOverdue: 3,
+			wantReminder:    true,
+		},
+		{
+			name:            "前回の督促から間もない",
+			status:          CashAdvanceStatusPaid,
+			dueDate:         time.Date(2026, 10, 1, 0, 0, 0, 0, jst),
+			lastRemindedAt:  &remindedRecently,
+			wantDaysOverdue: 16,
+			wantReminder:    false,
+		},
+		{
+			name:            "前回の督促から再送の間隔が経った",
+			status:          CashAdvanceStatusPaid,
+			dueDate:         time.Date(2026, 10, 1, 0, 0, 0, 0, jst),
+			lastRemindedAt:  &remindedLongAgo,
+			wantDaysOverdue: 16,
+			wantReminder:    true,
+		},
+		{
+			name:            "精算を申請済み",
+			status:          CashAdvanceStatusSettlementPending,
+			dueDate:         time.Date(2026, 10, 1, 0, 0, 0, 0, jst),
+			wantDaysOverdue: 0,
+			wantReminder:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advance := CashAdvance{
+				Status:            tt.status,
+				SettlementDueDate: tt.dueDate,
+				LastRemindedAt:    tt.lastRemindedAt,
+			}
+
+			assert.Equal(t, tt.wantDaysOverdue, advance.DaysOverdue(now))
+			assert.Equal(t, tt.wantDaysOverdue > 0, advance.IsOverdue(now))
+			assert.Equal(t, tt.wantReminder, advance.NeedsReminder(now))
+		})
+	}
+}
+
+func TestSummarizeCashAdvanceBalances(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	overdue := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	upcoming := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	advances := []CashAdvance{
+		{UserID: "a", Amount: money.Yen(50000), Status: CashAdvanceStatusPaid, SettlementDueDate: overdue},
+		{UserID: "a", Amount: money.Yen(30000), Status: CashAdvanceStatusSettlementPending, SettlementDueDate: overdue},
+		{UserID: "a", Amount: money.Yen(20000), ExpenseTotal: money.Yen(18000), Status: CashAdvanceStatusSettled},
+		{UserID: "b", Amount: money.Yen(200000), Status: CashAdvanceStatusPaid, SettlementDueDate: upcoming},
+		{UserID: "b", Amount: money.Yen(10000), ExpenseTotal: money.Yen(13000), Status: CashAdvanceStatusSettled},
+		{UserID: "c", Amount: money.Yen(40000), Status: CashAdvanceStatusClosed},
+	}
+
+	balances := SummarizeCashAdvanceBalances(advances, now)
+	require.Len(t, balances, 2)
+
+	// 未精算の仮払金額の多い順
+	assert.Equal(t, CashAdvanceBalance{
+		UserID:            "b",
+		OutstandingCount:  1,
+		OutstandingAmount: money.Yen(200000),
+		ReimbursementDue:  money.Yen(3000),
+	}, balances[0])
+	assert.Equal(t, CashAdvanceBalance{
+		UserID:            "a",
+		OutstandingCount:  2,
+		OutstandingAmount: money.Yen(80000),
+		OverdueCount:      1,
+		RefundDue:         money.Yen(2000),
+	}, balances[1])
+}
@@ -155,12 +155,21 @@ type DailyRecord struct {
 	ClientBreakTime float64      `json:"client_break_time"`
 	ClientWorkHours float64      `json:"client_work_hours"`
 	HasClientWork   bool         `gorm:"default:false" json:"has_client_work"`
+	ProjectID       *string      `gorm:"type:varchar(255)" json:"project_id"` // 稼働した案件（複数の案件に並行してアサインされている場合に指定）
 	Remarks         string       `gorm:"type:text" json:"remarks"`
 	IsHolidayWork   bool         `gorm:"default:false" json:"is_holiday_work"`
 	CreatedAt       time.Time    `json:"created_at"`
 	UpdatedAt       time.Time    `json:"updated_at"`
 }
 
+// BillableHours 請求の精算に使う稼働時間（客先勤怠を入力した日は客先の稼働時間）
+func (dr *DailyRecord) BillableHours() float64 {
+	if dr.HasClientWork {
+		return dr.ClientWorkHours
+	}
+	return dr.WorkHours
+}
+
 // BeforeCreate UUIDを生成
 func (dr *DailyRecord) BeforeCreate(tx *gorm.DB) error {
 	if dr.ID == "" {
@@ -78,6 +78,19 @@ type Expense struct {
 	CreatedAt              time.Time       `json:"created_at"`
 	UpdatedAt              time.Time       `json:"updated_at"`
 	DeletedAt              gorm.DeletedAt  `gorm:"index" json:"-"`
+
+	// 経費精算の振込
+	PaymentBatchID *string `gorm:"type:varchar(36);index" json:"payment_batch_id"` // 精算した振込バッチ（振込データ作成時に設定）
+
+	// 承認ルーティング
+	ProjectID      *string `gorm:"type:varchar(36);index" json:"project_id"` // 経費を計上するプロジェクト（任意）
+	ApprovalRuleID *string `gorm:"type:varchar(36)" json:"approval_rule_id"` // 提出時に適用した承認ルール（ルール外の場合はnil）
+
+	// 出張精算
+	BusinessTripID *string `gorm:"type:varchar(36);index" json:"business_trip_id"` // 精算した出張申請（出張精算の申請時に設定）
+
+	// 仮払金の精算
+	CashAdvanceID *string `gorm:"type:varchar(36);index" json:"cash_advance_id"` // 精算した仮払金（仮払金の精算の申請時に設定）
 }
 
 // BeforeCreate UUIDを生成
@@ -15,6 +15,10 @@ const (
 	ApprovalTypeManager ApprovalType = "manager"
 	// ApprovalTypeExecutive 役員承認
 	ApprovalTypeExecutive ApprovalType = "executive"
+	// ApprovalTypeDepartmentHead 申請者の部署長承認（承認ルールで指定）
+	ApprovalTypeDepartmentHead ApprovalType = "department_head"
+	// ApprovalTypeDesignated 指名した承認者による承認（承認ルールで指定）
+	ApprovalTypeDesignated ApprovalType = "designated"
 )
 
 // ApprovalStatus 承認ステータス
@@ -30,19 +34,26 @@ const (
 )
 
 // ExpenseApproval 経費承認履歴モデル
+// 経費申請と仮払金の承認に使い、ExpenseIDとCashAdvanceIDのどちらか一方を設定する
 type ExpenseApproval struct {
 	ID            string         `gorm:"type:varchar(255);primary_key" json:"id"`
-	ExpenseID     string         `gorm:"type:varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci;not null" json:"expense_id"`
+	ExpenseID     *string        `gorm:"type:varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci" json:"expense_id"`
 	Expense       Expense        `gorm:"foreignKey:ExpenseID" json:"expense"`
+	CashAdvanceID *string        `gorm:"type:varchar(36);index" json:"cash_advance_id,omitempty"`
+	CashAdvance   *CashAdvance   `gorm:"foreignKey:CashAdvanceID" json:"cash_advance,omitempty"`
 	ApproverID    string         `gorm:"type:varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci;not null" json:"approver_id"`
 	Approver      User           `gorm:"foreignKey:ApproverID" json:"approver"`
-	ApprovalType  ApprovalType   `gorm:"type:enum('manager','executive');not null" json:"approval_type"`
+	ApprovalType  ApprovalType   `gorm:"type:enum('manager','executive','department_head','designated');not null" json:"approval_type"`
 	ApprovalOrder int            `gorm:"not null;default:1" json:"approval_order"` // 承認順序（1段階目、2段階目）
 	Status        ApprovalStatus `gorm:"type:enum('pending','approved','rejected');default:'pending';not null" json:"status"`
 	Comment       string         `gorm:"type:text" json:"comment"` // 承認・却下コメント
 	ApprovedAt    *time.Time     `json:"approved_at"`              // 承認・却下日時
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
+
+	// 代理承認（ApproverIDの承認者が代理でOnBehalfOfIDの承認者の承認を行った場合に設定）
+	OnBehalfOfID *string `gorm:"type:varchar(255)" json:"on_behalf_of_id"`
+	OnBehalfOf   *User   `gorm:"foreignKey:OnBehalfOfID" json:"on_behalf_of,omitempty"`
 }
 
 // BeforeCreate UUIDを生成
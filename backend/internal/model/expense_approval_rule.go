@@ -0,0 +1,143 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExpenseApprovalRule 経費申請の承認ルート決定ルール
+// 金額・カテゴリ・申請者の部署・プロジェクトの条件に一致した経費申請に、Stepsの承認者を順に割り当てる。
+// 条件を指定しない項目は全てに一致する。複数のルールに一致する場合はPriorityの小さいルールを優先する
+type ExpenseApprovalRule struct {
+	ID           string                    `gorm:"type:varchar(36);primary_key" json:"id"`
+	Name         string                    `gorm:"size:100;not null" json:"name"`
+	Description  string                    `gorm:"type:text" json:"description"`
+	Priority     int                       `gorm:"not null;default:100" json:"priority"`
+	IsActive     bool                      `gorm:"not null;default:true" json:"is_active"`
+	MinAmount    *int                      `json:"min_amount"` // この金額以上（円）
+	MaxAmount    *int                      `json:"max_amount"` // この金額未満（円）
+	CategoryID   *string                   `gorm:"type:varchar(36)" json:"category_id"`
+	DepartmentID *string                   `gorm:"type:varchar(36)" json:"department_id"`
+	ProjectID    *string                   `gorm:"type:varchar(36)" json:"project_id"`
+	AutoApprove  bool                      `gorm:"not null;default:false" json:"auto_approve"` // 承認者を経ずに承認済みにする
+	Steps        []ExpenseApprovalRuleStep `gorm:"foreignKey:RuleID" json:"steps"`
+	CreatedBy    string                    `gorm:"type:varchar(255);not null" json:"created_by"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt            `gorm:"index" json:"-"`
+}
+
+// TableName テーブル名を指定
+func (ExpenseApprovalRule) TableName() string {
+	return "expense_approval_rules"
+}
+
+// BeforeCreate UUID生成
+func (r *ExpenseApprovalRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ExpenseApprovalRuleStep 承認ルートの段階
+// ApprovalTypeがmanager・executiveの場合は承認者設定の承認者、department_headの場合は申請者の部署長、
+// designatedの場合はApproverIDの承認者が承認する
+type ExpenseApprovalRuleStep struct {
+	ID           string       `gorm:"type:varchar(36);primary_key" json:"id"`
+	RuleID       string       `gorm:"type:varchar(36);not null;index" json:"rule_id"`
+	StepOrder    int          `gorm:"not null" json:"step_order"`
+	ApprovalType ApprovalType `gorm:"size:20;not null" json:"approval_type"`
+	ApproverID   *string      `gorm:"type:varchar(255)" json:"approver_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (ExpenseApprovalRuleStep) TableName() string {
+	return "expense_approval_rule_steps"
+}
+
+// BeforeCreate UUID生成
+func (s *ExpenseApprovalRuleStep) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ExpenseRoutingTarget 承認ルールの判定に使う経費申請の属性
+type ExpenseRoutingTarget struct {
+	Amount       int
+	CategoryID   string
+	DepartmentID *string
+	ProjectID    *string
+}
+
+// Matches 経費申請がルールの条件に一致するかチェック
+func (r *ExpenseApprovalRule) Matches(target ExpenseRoutingTarget) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.MinAmount != nil && target.Amount < *r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != nil && target.Amount >= *r.MaxAmount {
+		return false
+	}
+	if r.CategoryID != nil && *r.CategoryID != target.CategoryID {
+		return false
+	}
+	if r.DepartmentID != nil && (target.DepartmentID == nil || *r.DepartmentID != *target.DepartmentID) {
+		return false
+	}
+	if r.ProjectID != nil && (target.ProjectID == nil || *r.ProjectID != *target.ProjectID) {
+		return false
+	}
+	return true
+}
+
+// Validate ルールの設定内容をチェック
+func (r *ExpenseApprovalRule) Validate() error {
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount >= *r.MaxAmount {
+		return fmt.Errorf("金額の下限は上限より小さくしてください")
+	}
+	if r.AutoApprove {
+		if len(r.Steps) > 0 {
+			return fmt.Errorf("自動承認のルールには承認者を設定できません")
+		}
+		return nil
+	}
+	if len(r.Steps) == 0 {
+		return fmt.Errorf("承認者を1段階以上設定してください")
+	}
+	for i, step := range r.Steps {
+		switch step.ApprovalType {
+		case ApprovalTypeManager, ApprovalTypeExecutive, ApprovalTypeDepartmentHead:
+		case ApprovalTypeDesignated:
+			if step.ApproverID == nil || *step.ApproverID == "" {
+				return fmt.Errorf("%d段階目の承認者を指定してください", i+1)
+			}
+		default:
+			return fmt.Errorf("%d段階目の承認種別が正しくありません: %s", i+1, step.ApprovalType)
+		}
+	}
+	return nil
+}
+
+// SelectExpenseApprovalRule 経費申請に適用する承認ルールを選択（一致するルールがなければnil）
+// Priorityが同じ場合は先に並んでいるルールを優先する
+func SelectExpenseApprovalRule(rules []ExpenseApprovalRule, target ExpenseRoutingTarget) *ExpenseApprovalRule {
+	var selected *ExpenseApprovalRule
+	for i := range rules {
+		if !rules[i].Matches(target) {
+			continue
+		}
+		if selected == nil || rules[i].Priority < selected.Priority {
+			selected = &rules[i]
+		}
+	}
+	return selected
+}
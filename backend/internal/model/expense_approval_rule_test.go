@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectExpenseApprovalRule(t *testing.T) {
+	entertainment := "cat-entertainment"
+	books := "cat-books"
+	sales := "dept-sales"
+	projectA := "project-a"
+	over50000 := 50001
+	under5000 := 5000
+
+	rules := []ExpenseApprovalRule{
+		{
+			ID: "entertainment-high", Priority: 10, IsActive: true,
+			CategoryID: &entertainment, MinAmount: &over50000,
+			Steps: []ExpenseApprovalRuleStep{
+				{StepOrder: 1, ApprovalType: ApprovalTypeDepartmentHead},
+				{StepOrder: 2, ApprovalType: ApprovalTypeExecutive},
+			},
+		},
+		{ID: "books-low", Priority: 10, IsActive: true, CategoryID: &books, MaxAmount: &under5000, AutoApprove: true},
+		{ID: "sales-project", Priority: 20, IsActive: true, DepartmentID: &sales, ProjectID: &projectA},
+		{ID: "inactive", Priority: 1, IsActive: false},
+		{ID: "fallback", Priority: 100, IsActive: true},
+	}
+
+	tests := []struct {
+		name   string
+		target ExpenseRoutingTarget
+		want   string
+	}{
+		{"高額な交際費", ExpenseRoutingTarget{Amount: 60000, CategoryID: entertainment}, "entertainment-high"},
+		{"5万円ちょうどの交際費", ExpenseRoutingTarget{Amount: 50000, CategoryID: entertainment}, "fallback"},
+		{"少額の書籍", ExpenseRoutingTarget{Amount: 4999, CategoryID: books}, "books-low"},
+		{"5000円の書籍", ExpenseRoutingTarget{Amount: 5000, CategoryID: books}, "fallback"},
+		{"部署とプロジェクトが一致", ExpenseRoutingTarget{Amount: 1000, CategoryID: books, DepartmentID: &sales, ProjectID: &projectA}, "books-low"},
+		{"部署のみ一致", ExpenseRoutingTarget{Amount: 10000, CategoryID: books, DepartmentID: &sales}, "fallback"},
+		{"部署とプロジェクトが一致（高額）", ExpenseRoutingTarget{Amount: 10000, CategoryID: books, DepartmentID: &sales, ProjectID: &projectA}, "sales-project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := SelectExpenseApprovalRule(rules, tt.target)
+			if assert.NotNil(t, rule) {
+				assert.Equal(t, tt.want, rule.ID)
+			}
+		})
+	}
+
+	assert.Nil(t, SelectExpenseApprovalRule(rules[:4], ExpenseRoutingTarget{Amount: 1000, CategoryID: "cat-other"}))
+}
+
+func TestExpenseApprovalRuleValidate(t *testing.T) {
+	min, max := 10000, 5000
+	approver := "user-1"
+
+	assert.NoError(t, (&ExpenseApprovalRule{AutoApprove: true}).Validate())
+	assert.NoError(t, (&ExpenseApprovalRule{Steps: []ExpenseApprovalRuleStep{
+		{ApprovalType: ApprovalTypeManager},
+		{ApprovalType: ApprovalTypeDesignated, ApproverID: &approver},
+	}}).Validate())
+
+	assert.Error(t, (&ExpenseApprovalRule{AutoApprove: true, MinAmount: &min, MaxAmount: &max}).Validate())
+	assert.Error(t, (&ExpenseApprovalRule{}).Validate())
+	assert.Error(t, (&ExpenseApprovalRule{AutoApprove: true, Steps: []ExpenseApprovalRuleStep{{ApprovalType: ApprovalTypeManager}}}).Validate())
+	assert.Error(t, (&ExpenseApprovalRule{Steps: []ExpenseApprovalRuleStep{{ApprovalType: ApprovalTypeDesignated}}}).Validate())
+	assert.Error(t, (&ExpenseApprovalRule{Steps: []ExpenseApprovalRuleStep{{ApprovalType: "accountant"}}}).Validate())
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DuplicateReason 重複の可能性がある理由
+type DuplicateReason string
+
+const (
+	// DuplicateReasonSameReceipt 同じ領収書ファイル（内容のハッシュが一致）
+	DuplicateReasonSameReceipt DuplicateReason = "same_receipt"
+	// DuplicateReasonSimilarReceipt 同じ領収書を撮り直した可能性がある画像（知覚ハッシュが近い）
+	DuplicateReasonSimilarReceipt DuplicateReason = "similar_receipt"
+	// DuplicateReasonSimilarExpense 同じ申請者・金額・使用日・カテゴリの経費申請
+	DuplicateReasonSimilarExpense DuplicateReason = "similar_expense"
+)
+
+// ExpenseDuplicateFlag 経費申請の重複の可能性
+// 提出時に検出し、承認者は理由を入力して承認する（OverrideReason）
+type ExpenseDuplicateFlag struct {
+	ID                 string          `gorm:"type:varchar(36);primary_key" json:"id"`
+	ExpenseID          string          `gorm:"type:varchar(255);not null;index" json:"expense_id"`
+	DuplicateExpenseID string          `gorm:"type:varchar(255);not null" json:"duplicate_expense_id"`
+	DuplicateExpense   *Expense        `gorm:"foreignKey:DuplicateExpenseID" json:"duplicate_expense,omitempty"`
+	Reason             DuplicateReason `gorm:"size:20;not null" json:"reason"`
+	ReceiptID          *string         `gorm:"type:varchar(255)" json:"receipt_id"`
+	DuplicateReceiptID *string         `gorm:"type:varchar(255)" json:"duplicate_receipt_id"`
+	DetectedAt         time.Time       `gorm:"not null" json:"detected_at"`
+	OverrideReason     *string         `gorm:"type:text" json:"override_reason"`
+	OverriddenBy       *string         `gorm:"type:varchar(255)" json:"overridden_by"`
+	OverriddenAt       *time.Time      `json:"overridden_at"`
+	CreatedAt          time.Time       `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (ExpenseDuplicateFlag) TableName() string {
+	return "expense_duplicate_flags"
+}
+
+// BeforeCreate UUID生成
+func (f *ExpenseDuplicateFlag) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsOverridden 承認者が理由を入力して承認済みかチェック
+func (f *ExpenseDuplicateFlag) IsOverridden() bool {
+	return f.OverriddenAt != nil
+}
+
+// DuplicateCheckExcludedStatuses 重複チェックの比較対象にしない経費申請のステータス
+var DuplicateCheckExcludedStatuses = []ExpenseStatus{
+	ExpenseStatusDraft,
+	ExpenseStatusRejected,
+	ExpenseStatusCancelled,
+	ExpenseStatusExpired,
+}
+
+// IsDuplicateCheckTarget 重複チェックの比較対象になる経費申請かチェック（下書き・却下・取消・期限切れは対象外）
+func IsDuplicateCheckTarget(expense *Expense) bool {
+	for _, status := range DuplicateCheckExcludedStatuses {
+		if expense.Status == status {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSimilarExpense 同じ申請者・金額・使用日・カテゴリの別の経費申請かチェック
+func IsSimilarExpense(expense, other *Expense) bool {
+	return expense.ID != other.ID &&
+		expense.UserID == other.UserID &&
+		expense.Amount == other.Amount &&
+		expense.CategoryID == other.CategoryID &&
+		expense.ExpenseDate.Format("2006-01-02") == other.ExpenseDate.Format("2006-01-02")
+}
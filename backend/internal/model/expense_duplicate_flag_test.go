@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSimilarExpense(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	base := &Expense{
+		ID: "expense-1", UserID: "user-1", Amount: 2400, CategoryID: "cat-transport",
+		ExpenseDate: time.Date(2026, 9, 30, 0, 0, 0, 0, jst),
+	}
+
+	sameRide := *base
+	sameRide.ID = "expense-2"
+	sameRide.ExpenseDate = time.Date(2026, 9, 30, 18, 30, 0, 0, jst)
+	assert.True(t, IsSimilarExpense(base, &sameRide))
+
+	assert.False(t, IsSimilarExpense(base, base))
+
+	otherDay := sameRide
+	otherDay.ExpenseDate = time.Date(2026, 10, 1, 0, 0, 0, 0, jst)
+	assert.False(t, IsSimilarExpense(base, &otherDay))
+
+	otherAmount := sameRide
+	otherAmount.Amount = 2401
+	assert.False(t, IsSimilarExpense(base, &otherAmount))
+
+	otherUser := sameRide
+	otherUser.UserID = "user-2"
+	assert.False(t, IsSimilarExpense(base, &otherUser))
+
+	otherCategory := sameRide
+	otherCategory.CategoryID = "cat-meal"
+	assert.False(t, IsSimilarExpense(base, &otherCategory))
+}
+
+func TestIsDuplicateCheckTarget(t *testing.T) {
+	assert.True(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusSubmitted}))
+	assert.True(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusApproved}))
+	assert.True(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusPaid}))
+	assert.False(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusDraft}))
+	assert.False(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusRejected}))
+	assert.False(t, IsDuplicateCheckTarget(&Expense{Status: ExpenseStatusCancelled}))
+}
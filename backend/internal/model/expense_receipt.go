@@ -20,6 +20,10 @@ type ExpenseReceipt struct {
 	DisplayOrder int       `gorm:"not null;default:0" json:"display_order"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	// 重複検出（ファイルを取得できなかった場合は空）
+	ContentHash    string  `gorm:"type:varchar(64);index" json:"content_hash"`        // ファイル内容のSHA-256
+	PerceptualHash *string `gorm:"type:varchar(16)" json:"perceptual_hash,omitempty"` // 画像の知覚ハッシュ（PDFなど画像以外はNULL）
 }
 
 // TableName テーブル名を指定
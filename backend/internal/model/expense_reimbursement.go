@@ -0,0 +1,151 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReimbursableExpenseStatuses 振込で精算する経費申請のステータス（承認済み・月次締め済み）
+var ReimbursableExpenseStatuses = []ExpenseStatus{
+	ExpenseStatusApproved,
+	ExpenseStatusClosed,
+}
+
+// ProfileBankAccount 社員の振込先口座（経費精算用）
+// 口座番号と口座名義は暗号化して保存する
+type ProfileBankAccount struct {
+	ID                     string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	UserID                 string    `gorm:"type:varchar(255);not null;unique" json:"user_id"`
+	BankCode               string    `gorm:"size:4;not null" json:"bank_code"`
+	BankName               string    `gorm:"size:30;not null" json:"bank_name"` // カナ
+	BranchCode             string    `gorm:"size:3;not null" json:"branch_code"`
+	BranchName             string    `gorm:"size:30;not null" json:"branch_name"` // カナ
+	AccountType            string    `gorm:"size:1;not null" json:"account_type"` // 1:普通 2:当座 4:貯蓄
+	AccountNumberEncrypted string    `gorm:"type:text;not null" json:"-"`
+	AccountHolderEncrypted string    `gorm:"type:text;not null" json:"-"`
+	AccountNumberLast4     string    `gorm:"size:4;not null" json:"account_number_last4"` // 画面表示用
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (ProfileBankAccount) TableName() string {
+	return "profile_bank_accounts"
+}
+
+// BeforeCreate UUID生成
+func (a *ProfileBankAccount) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// MaskedAccountNumber 下4桁以外を伏せた口座番号
+func (a *ProfileBankAccount) MaskedAccountNumber() string {
+	return MaskAccountNumber(a.AccountNumberLast4)
+}
+
+// MaskAccountNumber 口座番号の下4桁から表示用の伏せ字を作る
+func MaskAccountNumber(last4 string) string {
+	if last4 == "" {
+		return ""
+	}
+	return strings.Repeat("*", 3) + last4
+}
+
+// AccountNumberLast4 口座番号の下4桁
+func AccountNumberLast4(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	return accountNumber[len(accountNumber)-4:]
+}
+
+// ReimbursementBatchStatus 経費精算の振込バッチのステータス
+type ReimbursementBatchStatus string
+
+const (
+	// ReimbursementBatchStatusDraft 振込データ作成済み（経理の確認待ち）
+	ReimbursementBatchStatusDraft ReimbursementBatchStatus = "draft"
+	// ReimbursementBatchStatusConfirmed 振込確定（経費申請は支払済み）
+	ReimbursementBatchStatusConfirmed ReimbursementBatchStatus = "confirmed"
+	// ReimbursementBatchStatusCancelled 取消（経費申請は再び精算対象になる）
+	ReimbursementBatchStatusCancelled ReimbursementBatchStatus = "cancelled"
+)
+
+// ExpenseReimbursementBatch 経費精算の振込バッチ（支払日ごとの総合振込）
+type ExpenseReimbursementBatch struct {
+	ID            string                   `gorm:"type:varchar(36);primary_key" json:"id"`
+	PaymentDate   time.Time                `gorm:"type:date;not null" json:"payment_date"`
+	Status        ReimbursementBatchStatus `gorm:"size:20;not null;default:'draft'" json:"status"`
+	TotalAmount   int64                    `gorm:"not null" json:"total_amount"` // 円
+	EmployeeCount int                      `gorm:"not null" json:"employee_count"`
+	ExpenseCount  int                      `gorm:"not null" json:"expense_count"`
+	CreatedBy     string                   `gorm:"type:varchar(36);not null" json:"created_by"`
+	ConfirmedBy   *string                  `gorm:"type:varchar(36)" json:"confirmed_by"`
+	ConfirmedAt   *time.Time               `json:"confirmed_at"`
+	CancelledBy   *string                  `gorm:"type:varchar(36)" json:"cancelled_by"`
+	CancelledAt   *time.Time               `json:"cancelled_at"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+
+	// リレーション
+	Items []ExpenseReimbursementItem `gorm:"foreignKey:BatchID" json:"items,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (ExpenseReimbursementBatch) TableName() string {
+	return "expense_reimbursement_batches"
+}
+
+// BeforeCreate UUID生成
+func (b *ExpenseReimbursementBatch) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsDraft 確定・取消前かチェック
+func (b *ExpenseReimbursementBatch) IsDraft() bool {
+	return b.Status == ReimbursementBatchStatusDraft
+}
+
+// ExpenseReimbursementItem 振込バッチの社員ごとの振込
+// 振込先口座はバッチ作成時点の登録内容を保持する
+type ExpenseReimbursementItem struct {
+	ID                     string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	BatchID                string    `gorm:"type:varchar(36);not null;index" json:"batch_id"`
+	UserID                 string    `gorm:"type:varchar(255);not null" json:"user_id"`
+	Amount                 int64     `gorm:"not null" json:"amount"` // 円
+	ExpenseCount           int       `gorm:"not null" json:"expense_count"`
+	BankCode               string    `gorm:"size:4;not null" json:"bank_code"`
+	BankName               string    `gorm:"size:30;not null" json:"bank_name"`
+	BranchCode             string    `gorm:"size:3;not null" json:"branch_code"`
+	BranchName             string    `gorm:"size:30;not null" json:"branch_name"`
+	AccountType            string    `gorm:"size:1;not null" json:"account_type"`
+	AccountNumberEncrypted string    `gorm:"type:text;not null" json:"-"`
+	AccountHolderEncrypted string    `gorm:"type:text;not null" json:"-"`
+	AccountNumberLast4     string    `gorm:"size:4;not null" json:"account_number_last4"`
+	CreatedAt              time.Time `json:"created_at"`
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName テーブル名を指定
+func (ExpenseReimbursementItem) TableName() string {
+	return "expense_reimbursement_items"
+}
+
+// BeforeCreate UUID生成
+func (i *ExpenseReimbursementItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
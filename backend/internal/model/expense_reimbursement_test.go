@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountNumberMasking(t *testing.T) {
+	assert.Equal(t, "4567", AccountNumberLast4("1234567"))
+	assert.Equal(t, "55", AccountNumberLast4("55"))
+
+	account := ProfileBankAccount{AccountNumberLast4: AccountNumberLast4("1234567")}
+	assert.Equal(t, "***4567", account.MaskedAccountNumber())
+	assert.Equal(t, "", MaskAccountNumber(""))
+}
+
+func TestExpenseReimbursementBatchIsDraft(t *testing.T) {
+	assert.True(t, (&ExpenseReimbursementBatch{Status: ReimbursementBatchStatusDraft}).IsDraft())
+	assert.False(t, (&ExpenseReimbursementBatch{Status: ReimbursementBatchStatusConfirmed}).IsDraft())
+	assert.False(t, (&ExpenseReimbursementBatch{Status: ReimbursementBatchStatusCancelled}).IsDraft())
+}
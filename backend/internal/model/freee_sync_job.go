@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FreeeSyncTargetType freee同期キューの同期対象
+type FreeeSyncTargetType string
+
+const (
+	// FreeeSyncTargetInvoice 請求書
+	FreeeSyncTargetInvoice FreeeSyncTargetType = "invoice"
+	// FreeeSyncTargetPartner 取引先（クライアント）
+	FreeeSyncTargetPartner FreeeSyncTargetType = "partner"
+)
+
+// FreeeSyncJobStatus freee同期キューのジョブのステータス
+type FreeeSyncJobStatus string
+
+const (
+	// FreeeSyncJobStatusQueued 実行待ち（再試行待ちを含む）
+	FreeeSyncJobStatusQueued FreeeSyncJobStatus = "queued"
+	// FreeeSyncJobStatusRunning 実行中
+	FreeeSyncJobStatusRunning FreeeSyncJobStatus = "running"
+	// FreeeSyncJobStatusSucceeded 成功
+	FreeeSyncJobStatusSucceeded FreeeSyncJobStatus = "succeeded"
+	// FreeeSyncJobStatusDead 再試行上限に達した（管理者の再投入待ち）
+	FreeeSyncJobStatusDead FreeeSyncJobStatus = "dead"
+)
+
+const (
+	// FreeeSyncJobDefaultMaxAttempts 再試行を含む最大実行回数
+	FreeeSyncJobDefaultMaxAttempts = 8
+	// FreeeSyncBackoffBase 1回目の失敗後の待ち時間
+	FreeeSyncBackoffBase = time.Minute
+	// FreeeSyncBackoffMax 再試行の待ち時間の上限
+	FreeeSyncBackoffMax = 6 * time.Hour
+	// FreeeSyncJobLease 実行中のジョブを他のワーカーが取らない時間（過ぎたら中断とみなす）
+	FreeeSyncJobLease = 5 * time.Minute
+)
+
+// FreeeSyncJob freee同期キューのジョブ（請求書・取引先の送信）
+// 同じ事業所のジョブは同時に1件しか実行しない
+type FreeeSyncJob struct {
+	ID          string              `gorm:"type:varchar(36);primary_key" json:"id"`
+	CompanyID   int                 `gorm:"not null;index" json:"company_id"`
+	UserID      string              `gorm:"type:varchar(255);not null" json:"user_id"` // freee連携のトークンを使うユーザー
+	TargetType  FreeeSyncTargetType `gorm:"size:20;not null" json:"target_type"`
+	TargetID    string              `gorm:"type:varchar(36);not null" json:"target_id"`
+	ActiveKey   *string             `gorm:"size:100;uniqueIndex:idx_freee_sync_jobs_active_key" json:"-"` // 未完了の間だけ対象を入れ、二重投入を一意制約で防ぐ
+	Status      FreeeSyncJobStatus  `gorm:"size:20;not null;default:'queued'" json:"status"`
+	Attempts    int                 `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int                 `gorm:"not null" json:"max_attempts"`
+	NextRunAt   time.Time           `gorm:"not null" json:"next_run_at"`
+	LockedBy    *string             `gorm:"size:100" json:"locked_by"`
+	LockedUntil *time.Time          `json:"locked_until"`
+	LastError   *string             `gorm:"type:text" json:"last_error"`
+	FreeeID     *int                `json:"freee_id"`
+	CompletedAt *time.Time          `json:"completed_at"`
+	CreatedBy   string              `gorm:"type:varchar(36);not null" json:"created_by"`
+	RequeuedBy  *string             `gorm:"type:varchar(36)" json:"requeued_by"`
+	RequeuedAt  *time.Time          `json:"requeued_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (FreeeSyncJob) TableName() string {
+	return "freee_sync_jobs"
+}
+
+// BeforeCreate UUID生成
+func (j *FreeeSyncJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == "" {
+		j.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Activate 未完了のジョブとして同期対象の一意キーを設定
+func (j *FreeeSyncJob) Activate() {
+	key := fmt.Sprintf("%s:%s", j.TargetType, j.TargetID)
+	j.ActiveKey = &key
+}
+
+// IsDead 再試行上限に達したかチェック
+func (j *FreeeSyncJob) IsDead() bool {
+	return j.Status == FreeeSyncJobStatusDead
+}
+
+// RecordFailure 失敗を記録し、上限に達していなければ指数バックオフで再試行を予約する
+// minDelayはfreeeのRetry-Afterなど、最低限待つ時間
+func (j *FreeeSyncJob) RecordFailure(now time.Time, message string, minDelay time.Duration) {
+	j.LastError = &message
+	j.LockedBy = nil
+	j.LockedUntil = nil
+	if j.Attempts >= j.MaxAttempts {
+		j.Status = FreeeSyncJobStatusDead
+		j.ActiveKey = nil
+		j.CompletedAt = &now
+		return
+	}
+	delay := FreeeSyncBackoff(j.Attempts)
+	if minDelay > delay {
+		delay = minDelay
+	}
+	j.Status = FreeeSyncJobStatusQueued
+	j.NextRunAt = now.Add(delay)
+}
+
+// RecordSuccess 成功を記録
+func (j *FreeeSyncJob) RecordSuccess(now time.Time, freeeID *int) {
+	j.Status = FreeeSyncJobStatusSucceeded
+	j.ActiveKey = nil
+	j.FreeeID = freeeID
+	j.LastError = nil
+	j.LockedBy = nil
+	j.LockedUntil = nil
+	j.CompletedAt = &now
+}
+
+// Requeue 再試行上限に達したジョブを実行回数を戻して再投入する
+func (j *FreeeSyncJob) Requeue(now time.Time, userID string) {
+	j.Status = FreeeSyncJobStatusQueued
+	j.Activate()
+	j.Attempts = 0
+	j.NextRunAt = now
+	j.CompletedAt = nil
+	j.RequeuedBy = &userID
+	j.RequeuedAt = &now
+}
+
+// FreeeSyncBackoff 失敗回数に応じた再試行までの待ち時間（1分から倍々、上限6時間）
+func FreeeSyncBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	delay := FreeeSyncBackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= FreeeSyncBackoffMax {
+			return FreeeSyncBackoffMax
+		}
+	}
+	return delay
+}
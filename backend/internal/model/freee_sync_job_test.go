@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeeSyncBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), FreeeSyncBackoff(0))
+	assert.Equal(t, time.Minute, FreeeSyncBackoff(1))
+	assert.Equal(t, 2*time.Minute, FreeeSyncBackoff(2))
+	assert.Equal(t, 64*time.Minute, FreeeSyncBackoff(7))
+	assert.Equal(t, FreeeSyncBackoffMax, FreeeSyncBackoff(20))
+}
+
+func TestFreeeSyncJobRecordFailure(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	worker := "worker-1"
+	job := &FreeeSyncJob{TargetType: FreeeSyncTargetInvoice, TargetID: "inv-1", Status: FreeeSyncJobStatusRunning, Attempts: 3, MaxAttempts: 5, LockedBy: &worker, LockedUntil: &now}
+	job.Activate()
+
+	job.RecordFailure(now, "timeout", 0)
+	assert.Equal(t, FreeeSyncJobStatusQueued, job.Status)
+	assert.Equal(t, now.Add(4*time.Minute), job.NextRunAt)
+	assert.Nil(t, job.LockedBy)
+	assert.Equal(t, "timeout", *job.LastError)
+	assert.Equal(t, "invoice:inv-1", *job.ActiveKey)
+
+	// Retry-Afterがバックオフより長い場合はそちらに合わせる
+	job.RecordFailure(now, "rate limited", time.Hour)
+	assert.Equal(t, now.Add(time.Hour), job.NextRunAt)
+
+	job.Attempts = 5
+	job.RecordFailure(now, "bad request", 0)
+	assert.True(t, job.IsDead())
+	assert.NotNil(t, job.CompletedAt)
+	assert.Nil(t, job.ActiveKey)
+
+	job.Requeue(now, "admin")
+	assert.Equal(t, FreeeSyncJobStatusQueued, job.Status)
+	assert.Equal(t, 0, job.Attempts)
+	assert.Equal(t, now, job.NextRunAt)
+	assert.Nil(t, job.CompletedAt)
+	assert.Equal(t, "admin", *job.RequeuedBy)
+	assert.Equal(t, "invoice:inv-1", *job.ActiveKey)
+}
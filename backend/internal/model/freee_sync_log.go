@@ -22,6 +22,8 @@ const (
 	FreeSyncTypePaymentSync FreeSyncType = "payment_sync"
 	// FreeSyncTypeClientSync 取引先同期
 	FreeSyncTypeClientSync FreeSyncType = "client_sync"
+	// FreeSyncTypeWebhook 上記以外のWebhookイベント
+	FreeSyncTypeWebhook FreeSyncType = "webhook"
 )
 
 const (
@@ -38,13 +40,14 @@ type FreeSyncResponseData map[string]interface{}
 // FreeSyncLog freee同期ログモデル
 type FreeSyncLog struct {
 	ID           string                `gorm:"type:varchar(36);primary_key" json:"id"`
-	SyncType     FreeSyncType          `gorm:"type:enum('invoice_create','invoice_update','payment_sync','client_sync');not null" json:"sync_type"`
+	SyncType     FreeSyncType          `gorm:"type:enum('invoice_create','invoice_update','payment_sync','client_sync','webhook');not null" json:"sync_type"`
 	TargetID     *string               `gorm:"type:varchar(36)" json:"target_id"`
 	FreeeID      *int                  `json:"freee_id"`
 	Status       FreeSyncStatus        `gorm:"type:enum('success','failed','pending');not null" json:"status"`
 	ErrorMessage *string               `gorm:"type:text" json:"error_message"`
 	RequestData  *FreeSyncRequestData  `gorm:"type:json" json:"request_data"`
 	ResponseData *FreeSyncResponseData `gorm:"type:json" json:"response_data"`
+	EventID      *string               `gorm:"type:varchar(100);uniqueIndex" json:"event_id,omitempty"` // WebhookのイベントID（再送の重複処理防止）
 	CreatedAt    time.Time             `json:"created_at"`
 }
 
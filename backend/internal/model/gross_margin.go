@@ -0,0 +1,134 @@
+package model
+
+import (
+	"math"
+	"sort"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// MarginGroupBy 粗利レポートの集計単位
+type MarginGroupBy string
+
+const (
+	// MarginGroupByEngineer エンジニア別
+	MarginGroupByEngineer MarginGroupBy = "engineer"
+	// MarginGroupByProject 案件別
+	MarginGroupByProject MarginGroupBy = "project"
+	// MarginGroupByClient 取引先別
+	MarginGroupByClient MarginGroupBy = "client"
+	// MarginGroupByMonth 月別
+	MarginGroupByMonth MarginGroupBy = "month"
+)
+
+// MarginEntry 粗利の集計元（売上は請求明細、仕入は受領した請求書の税抜額）
+type MarginEntry struct {
+	Month       string
+	ClientID    string
+	ClientName  string
+	ProjectID   string
+	ProjectName string
+	UserID      string
+	UserName    string
+	Amount      money.Amount
+}
+
+// MarginRow 粗利レポートの1行（集計単位 × 月）
+type MarginRow struct {
+	Month       string       `json:"month,omitempty"`
+	ClientID    string       `json:"client_id,omitempty"`
+	ClientName  string       `json:"client_name,omitempty"`
+	ProjectID   string       `json:"project_id,omitempty"`
+	ProjectName string       `json:"project_name,omitempty"`
+	UserID      string       `json:"user_id,omitempty"`
+	UserName    string       `json:"user_name,omitempty"`
+	Revenue     money.Amount `json:"revenue"`
+	Cost        money.Amount `json:"cost"`
+	GrossProfit money.Amount `json:"gross_profit"`
+	MarginRate  float64      `json:"margin_rate"` // 粗利率（%、小数点第1位まで）
+}
+
+// marginKey 粗利レポートの行を識別するキー
+type marginKey struct {
+	month     string
+	clientID  string
+	projectID string
+	userID    string
+}
+
+// BuildMarginReport 売上と仕入を集計単位・月ごとに集計して粗利を計算する
+func BuildMarginReport(groupBy MarginGroupBy, revenues, costs []MarginEntry) []MarginRow {
+	rows := make(map[marginKey]*MarginRow)
+	add := func(entry MarginEntry, revenue bool) {
+		key := marginKey{month: entry.Month}
+		row := &MarginRow{Month: entry.Month}
+		switch groupBy {
+		case MarginGroupByEngineer:
+			key.userID = entry.UserID
+			row.UserID, row.UserName = entry.UserID, entry.UserName
+		case MarginGroupByProject:
+			key.clientID, key.projectID = entry.ClientID, entry.ProjectID
+			row.ClientID, row.ClientName = entry.ClientID, entry.ClientName
+			row.ProjectID, row.ProjectName = entry.ProjectID, entry.ProjectName
+		case MarginGroupByClient:
+			key.clientID = entry.ClientID
+			row.ClientID, row.ClientName = entry.ClientID, entry.ClientName
+		}
+		if existing, ok := rows[key]; ok {
+			row = existing
+		} else {
+			rows[key] = row
+		}
+		if revenue {
+			row.Revenue = row.Revenue.Add(entry.Amount)
+		} else {
+			row.Cost = row.Cost.Add(entry.Amount)
+		}
+	}
+	for _, entry := range revenues {
+		add(entry, true)
+	}
+	for _, entry := range costs {
+		add(entry, false)
+	}
+
+	result := make([]MarginRow, 0, len(rows))
+	for _, row := range rows {
+		row.calculate()
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.ClientID != b.ClientID {
+			return a.ClientID < b.ClientID
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		return a.UserID < b.UserID
+	})
+	return result
+}
+
+// SumMarginRows 粗利レポートの合計
+func SumMarginRows(rows []MarginRow) MarginRow {
+	var total MarginRow
+	for _, row := range rows {
+		total.Revenue = total.Revenue.Add(row.Revenue)
+		total.Cost = total.Cost.Add(row.Cost)
+	}
+	total.calculate()
+	return total
+}
+
+// calculate 売上と仕入から粗利と粗利率を計算する
+func (r *MarginRow) calculate() {
+	r.GrossProfit = r.Revenue.Sub(r.Cost)
+	r.MarginRate = 0
+	if !r.Revenue.IsZero() {
+		r.MarginRate = math.Round(r.GrossProfit.Float64()/r.Revenue.Float64()*1000) / 10
+	}
+}
@@ -1,10 +1,13 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // InvoiceStatus 請求書ステータス
@@ -23,6 +26,16 @@ const (
 	InvoiceStatusCancelled InvoiceStatus = "cancelled"
 )
 
+// InvoiceType 請求書の種別
+type InvoiceType string
+
+const (
+	// InvoiceTypeStandard 通常の請求書
+	InvoiceTypeStandard InvoiceType = "standard"
+	// InvoiceTypeCreditNote 赤伝（送付済みの請求書を取り消すマイナスの請求書）
+	InvoiceTypeCreditNote InvoiceType = "credit_note"
+)
+
 // Invoice 請求管理モデル
 type Invoice struct {
 	ID            string        `gorm:"type:varchar(36);primary_key" json:"id"`
@@ -31,19 +44,28 @@ type Invoice struct {
 	InvoiceDate   time.Time     `gorm:"not null" json:"invoice_date"`
 	DueDate       time.Time     `gorm:"not null" json:"due_date"`
 	BillingMonth  string        `gorm:"size:7;not null" json:"billing_month"`
-	Subtotal      float64       `gorm:"type:decimal(10,2);not null" json:"subtotal"`
+	Subtotal      money.Amount  `gorm:"type:decimal(10,2);not null" json:"subtotal"`
 	TaxRate       float64       `gorm:"type:decimal(5,2);default:10.00" json:"tax_rate"`
-	TaxAmount     float64       `gorm:"type:decimal(10,2);not null" json:"tax_amount"`
-	TotalAmount   float64       `gorm:"type:decimal(10,2);not null" json:"total_amount"`
+	TaxAmount     money.Amount  `gorm:"type:decimal(10,2);not null" json:"tax_amount"`
+	TotalAmount   money.Amount  `gorm:"type:decimal(10,2);not null" json:"total_amount"`
 	Status        InvoiceStatus `gorm:"size:50;default:'draft'" json:"status"`
 	PaidDate      *time.Time    `json:"paid_date"`
 	PaymentMethod string        `gorm:"size:50" json:"payment_method"`
 	Notes         string        `gorm:"type:text" json:"notes"`
 	CreatedBy     string        `gorm:"type:varchar(36)" json:"created_by"`
 
+	// 適格請求書（インボイス制度）対応
+	RegistrationNumber string `gorm:"size:14" json:"registration_number"` // 発行時点の適格請求書発行事業者登録番号
+
+	// 赤伝・訂正再発行
+	InvoiceType       InvoiceType `gorm:"size:20;not null;default:'standard'" json:"invoice_type"`
+	OriginalInvoiceID *string     `gorm:"type:varchar(36);index" json:"original_invoice_id"` // 赤伝は取消対象、再発行は訂正元の請求書
+	Revision          int         `gorm:"default:0" json:"revision"`                         // 訂正による再発行の版数
+
 	// 経理機能拡張フィールド
 	FreeeInvoiceID *int           `json:"freee_invoice_id"`
 	ProjectGroupID *string        `gorm:"type:varchar(36)" json:"project_group_id"`
+	BillingRunID   *string        `gorm:"type:varchar(36);index" json:"billing_run_id"` // 月次請求で作成した請求書
 	FreeSyncStatus FreeSyncStatus `gorm:"type:enum('not_synced','synced','failed','pending');default:'not_synced'" json:"freee_sync_status"`
 	FreeSyncedAt   *time.Time     `json:"freee_synced_at"`
 
@@ -52,10 +74,11 @@ type Invoice struct {
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// リレーション
-	Client       Client          `gorm:"foreignKey:ClientID" json:"client,omitempty"`
-	Creator      User            `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
-	Details      []InvoiceDetail `gorm:"foreignKey:InvoiceID" json:"details,omitempty"`
-	ProjectGroup *ProjectGroup   `gorm:"foreignKey:ProjectGroupID" json:"project_group,omitempty"`
+	Client       Client              `gorm:"foreignKey:ClientID" json:"client,omitempty"`
+	Creator      User                `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+	Details      []InvoiceDetail     `gorm:"foreignKey:InvoiceID" json:"details,omitempty"`
+	TaxSummaries []InvoiceTaxSummary `gorm:"foreignKey:InvoiceID" json:"tax_summaries,omitempty"`
+	ProjectGroup *ProjectGroup       `gorm:"foreignKey:ProjectGroupID" json:"project_group,omitempty"`
 }
 
 // InvoiceDetail 請求明細モデル
@@ -66,13 +89,18 @@ type InvoiceDetail struct {
 	UserID      *string        `gorm:"type:varchar(255)" json:"user_id"`
 	Description string         `gorm:"size:255;not null" json:"description"`
 	Quantity    float64        `gorm:"type:decimal(10,2);default:1" json:"quantity"`
-	UnitPrice   float64        `gorm:"type:decimal(10,2);not null" json:"unit_price"`
-	Amount      float64        `gorm:"type:decimal(10,2);not null" json:"amount"`
+	UnitPrice   money.Amount   `gorm:"type:decimal(10,2);not null" json:"unit_price"`
+	Amount      money.Amount   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	TaxCategory TaxCategory    `gorm:"size:20;default:'standard_10'" json:"tax_category"`
 	OrderIndex  int            `gorm:"default:0" json:"order_index"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// 精算（上下割・中間割）の稼働実績
+	ActualHours   *float64              `gorm:"type:decimal(6,2)" json:"actual_hours"`
+	HoursEvidence *BillingHoursEvidence `gorm:"type:json" json:"hours_evidence,omitempty"`
+
 	// リレーション
 	Invoice Invoice  `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
 	Project *Project `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
@@ -131,6 +159,77 @@ func (i *Invoice) CanCancel() bool {
 	return i.Status == InvoiceStatusDraft || i.Status == InvoiceStatusSent
 }
 
+// IsCreditNote 赤伝かチェック
+func (i *Invoice) IsCreditNote() bool {
+	return i.InvoiceType == InvoiceTypeCreditNote
+}
+
+// IsIssued 取引先へ発行済み（送付済み・期限超過・支払済み）かチェック
+// 発行済みの請求書は直接編集せず、赤伝と再発行で訂正する
+func (i *Invoice) IsIssued() bool {
+	return i.Status == InvoiceStatusSent || i.Status == InvoiceStatusOverdue || i.Status == InvoiceStatusPaid
+}
+
+// CanIssueCreditNote 赤伝を発行して取り消せるかチェック
+func (i *Invoice) CanIssueCreditNote() bool {
+	return !i.IsCreditNote() && i.IsIssued()
+}
+
+// BuildCreditNote 請求書を取り消す赤伝を組み立てる
+// 赤伝の請求書番号は通常の請求書と同じ台帳から採番したものを渡す
+// 明細と税率別小計は元の請求書の符号を反転し、端数処理をやり直さずに元の金額と必ず相殺させる
+func (i *Invoice) BuildCreditNote(invoiceNumber string, issueDate time.Time, reason string) (*Invoice, []*InvoiceDetail) {
+	originalID := i.ID
+	notes := fmt.Sprintf("請求書 %s の取消", i.InvoiceNumber)
+	if reason != "" {
+		notes += "（" + reason + "）"
+	}
+
+	credit := &Invoice{
+		ClientID:           i.ClientID,
+		InvoiceNumber:      invoiceNumber,
+		InvoiceDate:        issueDate,
+		DueDate:            issueDate,
+		BillingMonth:       i.BillingMonth,
+		Subtotal:           -i.Subtotal,
+		TaxRate:            i.TaxRate,
+		TaxAmount:          -i.TaxAmount,
+		TotalAmount:        -i.TotalAmount,
+		Status:             InvoiceStatusSent,
+		Notes:              notes,
+		RegistrationNumber: i.RegistrationNumber,
+		ProjectGroupID:     i.ProjectGroupID,
+		FreeSyncStatus:     FreeSyncStatusNotSynced,
+		InvoiceType:        InvoiceTypeCreditNote,
+		OriginalInvoiceID:  &originalID,
+		Revision:           i.Revision,
+	}
+
+	for _, summary := range i.TaxBreakdown() {
+		credit.TaxSummaries = append(credit.TaxSummaries, InvoiceTaxSummary{
+			TaxCategory:   summary.TaxCategory,
+			TaxRate:       summary.TaxRate,
+			TaxableAmount: -summary.TaxableAmount,
+			TaxAmount:     -summary.TaxAmount,
+		})
+	}
+
+	details := make([]*InvoiceDetail, len(i.Details))
+	for n, detail := range i.Details {
+		details[n] = &InvoiceDetail{
+			ProjectID:   detail.ProjectID,
+			UserID:      detail.UserID,
+			Description: detail.Description,
+			Quantity:    detail.Quantity,
+			UnitPrice:   -detail.UnitPrice,
+			Amount:      -detail.Amount,
+			TaxCategory: detail.TaxCategory,
+			OrderIndex:  n + 1,
+		}
+	}
+	return credit, details
+}
+
 // CanMarkAsPaid 支払済みにできるかチェック
 func (i *Invoice) CanMarkAsPaid() bool {
 	return i.Status == InvoiceStatusSent || i.Status == InvoiceStatusOverdue
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestInvoiceBuildCreditNote(t *testing.T) {
+	details := []InvoiceDetail{
+		{Description: "システム開発支援", Quantity: 1, UnitPrice: money.Yen(800000), Amount: money.Yen(800000), TaxCategory: TaxCategoryStandard},
+		{Description: "会議用軽食", Quantity: 3, UnitPrice: money.Yen(1001), Amount: money.Yen(3003), TaxCategory: TaxCategoryReduced},
+	}
+	calc := CalculateInvoiceTax(details)
+	original := &Invoice{
+		ID:                 "11111111-1111-1111-1111-111111111111",
+		ClientID:           "22222222-2222-2222-2222-222222222222",
+		InvoiceNumber:      "INV-2024-0001",
+		BillingMonth:       "2024-01",
+		Subtotal:           calc.Subtotal,
+		TaxRate:            calc.PrimaryTaxRate(),
+		TaxAmount:          calc.TaxAmount,
+		TotalAmount:        calc.TotalAmount,
+		Status:             InvoiceStatusPaid,
+		RegistrationNumber: "T1234567890123",
+		InvoiceType:        InvoiceTypeStandard,
+		Details:            details,
+		TaxSummaries:       calc.Summaries,
+	}
+	require.True(t, original.CanIssueCreditNote())
+
+	issueDate := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	credit, creditDetails := original.BuildCreditNote("INV-2024-0007", issueDate, "単価誤り")
+
+	t.Run("元の請求書に紐づくマイナスの赤伝", func(t *testing.T) {
+		assert.True(t, credit.IsCreditNote())
+		assert.Equal(t, "INV-2024-0007", credit.InvoiceNumber)
+		require.NotNil(t, credit.OriginalInvoiceID)
+		assert.Equal(t, original.ID, *credit.OriginalInvoiceID)
+		assert.Equal(t, InvoiceStatusSent, credit.Status)
+		assert.Equal(t, issueDate, credit.InvoiceDate)
+		assert.Equal(t, original.BillingMonth, credit.BillingMonth)
+		assert.Equal(t, original.RegistrationNumber, credit.RegistrationNumber)
+		assert.Equal(t, "請求書 INV-2024-0001 の取消（単価誤り）", credit.Notes)
+	})
+
+	t.Run("金額は元の請求書と必ず相殺する", func(t *testing.T) {
+		assert.Equal(t, money.Zero, original.Subtotal+credit.Subtotal)
+		assert.Equal(t, money.Zero, original.TaxAmount+credit.TaxAmount)
+		assert.Equal(t, money.Zero, original.TotalAmount+credit.TotalAmount)
+
+		require.Len(t, credit.TaxSummaries, len(original.TaxSummaries))
+		for i, summary := range credit.TaxSummaries {
+			assert.Equal(t, original.TaxSummaries[i].TaxCategory, summary.TaxCategory)
+			assert.Equal(t, money.Zero, original.TaxSummaries[i].TaxAmount+summary.TaxAmount)
+			assert.Equal(t, money.Zero, original.TaxSummaries[i].TaxableAmount+summary.TaxableAmount)
+		}
+
+		require.Len(t, creditDetails, len(details))
+		for i, detail := range creditDetails {
+			assert.Equal(t, details[i].Quantity, detail.Quantity)
+			assert.Equal(t, -details[i].Amount, detail.Amount)
+			assert.Equal(t, details[i].TaxCategory, detail.TaxCategory)
+			assert.Equal(t, i+1, detail.OrderIndex)
+		}
+	})
+
+	t.Run("赤伝と下書きは赤伝で取り消せない", func(t *testing.T) {
+		assert.False(t, credit.CanIssueCreditNote())
+		assert.False(t, (&Invoice{Status: InvoiceStatusDraft}).CanIssueCreditNote())
+		assert.False(t, (&Invoice{Status: InvoiceStatusCancelled}).CanIssueCreditNote())
+	})
+}
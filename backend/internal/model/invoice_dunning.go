@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvoiceAuditAction 請求書監査ログ（タイムライン）のアクション
+type InvoiceAuditAction string
+
+const (
+	// InvoiceAuditActionCreated 作成
+	InvoiceAuditActionCreated InvoiceAuditAction = "created"
+	// InvoiceAuditActionUpdated 更新
+	InvoiceAuditActionUpdated InvoiceAuditAction = "updated"
+	// InvoiceAuditActionDeleted 削除
+	InvoiceAuditActionDeleted InvoiceAuditAction = "deleted"
+	// InvoiceAuditActionStatusChanged ステータス変更
+	InvoiceAuditActionStatusChanged InvoiceAuditAction = "status_changed"
+	// InvoiceAuditActionFreeeSynced freee同期
+	InvoiceAuditActionFreeeSynced InvoiceAuditAction = "freee_synced"
+	// InvoiceAuditActionDunningReminder 督促（お支払いのお願い）送信
+	InvoiceAuditActionDunningReminder InvoiceAuditAction = "dunning_reminder"
+	// InvoiceAuditActionDunningSecondNotice 督促（再通知）送信
+	InvoiceAuditActionDunningSecondNotice InvoiceAuditAction = "dunning_second_notice"
+	// InvoiceAuditActionDunningEscalation 督促（営業担当へのエスカレーション）
+	InvoiceAuditActionDunningEscalation InvoiceAuditAction = "dunning_escalation"
+)
+
+// InvoiceAuditLog 請求書監査ログ
+// 作成・更新・ステータス変更はトリガーで、督促はアプリケーションから記録する
+type InvoiceAuditLog struct {
+	ID             string             `gorm:"type:varchar(36);primary_key" json:"id"`
+	InvoiceID      string             `gorm:"type:varchar(36);not null;index" json:"invoice_id"`
+	Action         InvoiceAuditAction `gorm:"type:invoice_audit_action;not null" json:"action"`
+	OldValues      json.RawMessage    `gorm:"type:json" json:"old_values,omitempty"`
+	NewValues      json.RawMessage    `gorm:"type:json" json:"new_values,omitempty"`
+	ChangedBy      *string            `gorm:"type:varchar(255)" json:"changed_by"`
+	ChangedAt      time.Time          `gorm:"not null" json:"changed_at"`
+	FreeeInvoiceID *int               `json:"freee_invoice_id"`
+	Notes          string             `gorm:"type:text" json:"notes"`
+}
+
+// BeforeCreate UUIDを生成
+func (l *InvoiceAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	if l.ChangedAt.IsZero() {
+		l.ChangedAt = time.Now()
+	}
+	return nil
+}
+
+// TableName テーブル名を明示的に指定
+func (InvoiceAuditLog) TableName() string {
+	return "invoice_audit_logs"
+}
+
+// DunningStepType 督促ステップの種別
+type DunningStepType string
+
+const (
+	// DunningStepReminder お支払いのお願い（取引先担当者宛て）
+	DunningStepReminder DunningStepType = "reminder"
+	// DunningStepSecondNotice 再通知（取引先担当者宛て）
+	DunningStepSecondNotice DunningStepType = "second_notice"
+	// DunningStepEscalation 営業担当へのエスカレーション
+	DunningStepEscalation DunningStepType = "escalation"
+)
+
+// dunningStepOrder 督促ステップの進行順
+var dunningStepOrder = map[DunningStepType]int{
+	DunningStepReminder:     1,
+	DunningStepSecondNotice: 2,
+	DunningStepEscalation:   3,
+}
+
+// AuditAction 督促ステップに対応するタイムラインのアクション
+func (t DunningStepType) AuditAction() InvoiceAuditAction {
+	switch t {
+	case DunningStepReminder:
+		return InvoiceAuditActionDunningReminder
+	case DunningStepSecondNotice:
+		return InvoiceAuditActionDunningSecondNotice
+	case DunningStepEscalation:
+		return InvoiceAuditActionDunningEscalation
+	}
+	return ""
+}
+
+// DunningStep 督促スケジュールの1ステップ
+type DunningStep struct {
+	Type         DunningStepType `json:"type"`
+	DaysAfterDue int             `json:"days_after_due"` // 支払期日の何日後に実行するか
+}
+
+// DunningSchedule 督促スケジュール
+type DunningSchedule struct {
+	Steps []DunningStep `json:"steps"`
+}
+
+// DefaultDunningSchedule 既定の督促スケジュール（期日翌日・14日後・30日後）
+func DefaultDunningSchedule() DunningSchedule {
+	return DunningSchedule{
+		Steps: []DunningStep{
+			{Type: DunningStepReminder, DaysAfterDue: 1},
+			{Type: DunningStepSecondNotice, DaysAfterDue: 14},
+			{Type: DunningStepEscalation, DaysAfterDue: 30},
+		},
+	}
+}
+
+// Validate 督促スケジュールの妥当性をチェック
+// ステップは進行順に並び、実行日は後のステップほど遅くなければならない
+func (s DunningSchedule) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("督促ステップが設定されていません")
+	}
+	seen := make(map[DunningStepType]bool)
+	prevOrder, prevDays := 0, 0
+	for i, step := range s.Steps {
+		order, ok := dunningStepOrder[step.Type]
+		if !ok {
+			return fmt.Errorf("不明な督促ステップです: %s", step.Type)
+		}
+		if seen[step.Type] {
+			return fmt.Errorf("督促ステップが重複しています: %s", step.Type)
+		}
+		seen[step.Type] = true
+		if step.DaysAfterDue < 1 {
+			return fmt.Errorf("督促の実行日は支払期日の1日後以降を指定してください: %s", step.Type)
+		}
+		if i > 0 && (order < prevOrder || step.DaysAfterDue <= prevDays) {
+			return fmt.Errorf("督促ステップは進行順かつ実行日の昇順で指定してください: %s", step.Type)
+		}
+		prevOrder, prevDays = order, step.DaysAfterDue
+	}
+	return nil
+}
+
+// NextStep 超過日数と実施済みステップから次に実行すべきステップを返す（なければnil）
+// 実施済みの最終ステップより先で期日を迎えたもののうち最も進んだステップを選び、
+// 長期間超過している請求書でも同じ日に複数の督促を送らない
+func (s DunningSchedule) NextStep(daysOverdue int, done map[DunningStepType]bool) *DunningStep {
+	lastDone := 0
+	for t := range done {
+		if done[t] && dunningStepOrder[t] > lastDone {
+			lastDone = dunningStepOrder[t]
+		}
+	}
+
+	var next *DunningStep
+	for i := range s.Steps {
+		step := s.Steps[i]
+		if dunningStepOrder[step.Type] <= lastDone || step.DaysAfterDue > daysOverdue {
+			continue
+		}
+		if next == nil || dunningStepOrder[step.Type] > dunningStepOrder[next.Type] {
+			next = &step
+		}
+	}
+	return next
+}
+
+// DaysOverdue 支払期日からの超過日数（日付単位）
+func DaysOverdue(dueDate, now time.Time) int {
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(due).Hours() / 24)
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDunningScheduleNextStep(t *testing.T) {
+	schedule := DefaultDunningSchedule()
+
+	t.Run("期日翌日まではお支払いのお願いを送らない", func(t *testing.T) {
+		assert.Nil(t, schedule.NextStep(0, nil))
+	})
+
+	t.Run("期日を過ぎたらお支払いのお願い", func(t *testing.T) {
+		step := schedule.NextStep(1, map[DunningStepType]bool{})
+		require.NotNil(t, step)
+		assert.Equal(t, DunningStepReminder, step.Type)
+	})
+
+	t.Run("送信済みのステップは繰り返さない", func(t *testing.T) {
+		done := map[DunningStepType]bool{DunningStepReminder: true}
+		assert.Nil(t, schedule.NextStep(13, done))
+
+		step := schedule.NextStep(14, done)
+		require.NotNil(t, step)
+		assert.Equal(t, DunningStepSecondNotice, step.Type)
+	})
+
+	t.Run("長期間超過している場合は最も進んだステップのみ", func(t *testing.T) {
+		step := schedule.NextStep(45, nil)
+		require.NotNil(t, step)
+		assert.Equal(t, DunningStepEscalation, step.Type)
+
+		// エスカレーション後に前のステップへ戻らない
+		done := map[DunningStepType]bool{DunningStepEscalation: true}
+		assert.Nil(t, schedule.NextStep(60, done))
+	})
+}
+
+func TestDunningScheduleValidate(t *testing.T) {
+	assert.NoError(t, DefaultDunningSchedule().Validate())
+
+	// エスカレーションのみの運用も可能
+	assert.NoError(t, DunningSchedule{Steps: []DunningStep{
+		{Type: DunningStepEscalation, DaysAfterDue: 7},
+	}}.Validate())
+
+	invalid := map[string]DunningSchedule{
+		"ステップなし": {},
+		"不明な種別":  {Steps: []DunningStep{{Type: "call", DaysAfterDue: 1}}},
+		"期日当日":   {Steps: []DunningStep{{Type: DunningStepReminder, DaysAfterDue: 0}}},
+		"重複": {Steps: []DunningStep{
+			{Type: DunningStepReminder, DaysAfterDue: 1},
+			{Type: DunningStepReminder, DaysAfterDue: 5},
+		}},
+		"進行順と逆": {Steps: []DunningStep{
+			{Type: DunningStepSecondNotice, DaysAfterDue: 1},
+			{Type: DunningStepReminder, DaysAfterDue: 5},
+		}},
+		"実行日が同じ": {Steps: []DunningStep{
+			{Type: DunningStepReminder, DaysAfterDue: 7},
+			{Type: DunningStepSecondNotice, DaysAfterDue: 7},
+		}},
+	}
+	for name, schedule := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, schedule.Validate())
+		})
+	}
+}
+
+func TestDaysOverdue(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	due := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, DaysOverdue(due, time.Date(2024, 1, 31, 23, 59, 0, 0, jst)))
+	assert.Equal(t, 1, DaysOverdue(due, time.Date(2024, 2, 1, 0, 1, 0, 0, jst)))
+	assert.Equal(t, 30, DaysOverdue(due, time.Date(2024, 3, 1, 9, 0, 0, 0, jst)))
+}
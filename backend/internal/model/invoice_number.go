@@ -0,0 +1,226 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvoiceNumberAllocationStatus 請求書番号の払い出し状態
+type InvoiceNumberAllocationStatus string
+
+const (
+	// InvoiceNumberAllocationStatusAllocated 払い出し済み（請求書に使用中）
+	InvoiceNumberAllocationStatusAllocated InvoiceNumberAllocationStatus = "allocated"
+	// InvoiceNumberAllocationStatusVoided 欠番（請求書の削除などで使われなくなった）
+	InvoiceNumberAllocationStatusVoided InvoiceNumberAllocationStatus = "voided"
+)
+
+// InvoiceNumberMaxLength 請求書番号の最大長（invoices.invoice_number の桁数）
+const InvoiceNumberMaxLength = 50
+
+// invoiceNumberSeqPlaceholder 採番範囲のキーで連番の位置を表す文字列
+const invoiceNumberSeqPlaceholder = "{seq}"
+
+// InvoiceNumberSequence 採番範囲（会計年度・取引先プレフィックスなど）ごとの最終連番
+type InvoiceNumberSequence struct {
+	ScopeKey   string    `gorm:"type:varchar(100);primary_key" json:"scope_key"`
+	FiscalYear int       `gorm:"not null" json:"fiscal_year"`
+	LastValue  int       `gorm:"not null;default:0" json:"last_value"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (InvoiceNumberSequence) TableName() string {
+	return "invoice_number_sequences"
+}
+
+// InvoiceNumberAllocation 払い出した請求書番号の台帳
+// 一度払い出した番号は削除せず、使われなくなった場合は欠番として理由とともに残す
+type InvoiceNumberAllocation struct {
+	ID            string                        `gorm:"type:varchar(36);primary_key" json:"id"`
+	ScopeKey      string                        `gorm:"type:varchar(100);not null" json:"scope_key"`
+	FiscalYear    int                           `gorm:"not null;index" json:"fiscal_year"`
+	Sequence      int                           `gorm:"not null" json:"sequence"`
+	InvoiceNumber string                        `gorm:"size:50;not null;unique" json:"invoice_number"`
+	InvoiceID     *string                       `gorm:"type:varchar(36)" json:"invoice_id"`
+	ClientID      *string                       `gorm:"type:varchar(36)" json:"client_id"`
+	Status        InvoiceNumberAllocationStatus `gorm:"size:20;not null;default:'allocated'" json:"status"`
+	AllocatedBy   string                        `gorm:"type:varchar(36);not null" json:"allocated_by"`
+	VoidReason    *string                       `gorm:"type:text" json:"void_reason"`
+	VoidedBy      *string                       `gorm:"type:varchar(36)" json:"voided_by"`
+	VoidedAt      *time.Time                    `json:"voided_at"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	UpdatedAt     time.Time                     `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (InvoiceNumberAllocation) TableName() string {
+	return "invoice_number_allocations"
+}
+
+// BeforeCreate UUID生成
+func (a *InvoiceNumberAllocation) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsVoided 欠番かチェック
+func (a *InvoiceNumberAllocation) IsVoided() bool {
+	return a.Status == InvoiceNumberAllocationStatusVoided
+}
+
+// Void 欠番にする
+func (a *InvoiceNumberAllocation) Void(now time.Time, reason, userID string) {
+	a.Status = InvoiceNumberAllocationStatusVoided
+	a.VoidReason = &reason
+	a.VoidedBy = &userID
+	a.VoidedAt = &now
+}
+
+// InvoiceNumberParams 請求書番号の組み立てに使う値
+type InvoiceNumberParams struct {
+	FiscalYear   int
+	InvoiceDate  time.Time
+	ClientPrefix string
+}
+
+// invoiceNumberToken 採番パターンの1要素（固定文字列またはプレースホルダー）
+type invoiceNumberToken struct {
+	literal string
+	name    string
+	width   int
+}
+
+// InvoiceNumberPattern 請求書番号の採番パターン
+// {FY}（会計年度）、{YYYY}・{MM}（請求日の年月）、{CLIENT}（取引先プレフィックス）、
+// {seq}・{seq:NN}（NN桁ゼロ埋めの連番）を組み合わせる。連番は会計年度ごとに振り直す
+type InvoiceNumberPattern struct {
+	source string
+	tokens []invoiceNumberToken
+}
+
+// ParseInvoiceNumberPattern 採番パターンを解析する
+// 年度をまたいで番号が重複しないよう {FY} と {seq} をそれぞれ1つだけ含む必要がある
+func ParseInvoiceNumberPattern(pattern string) (*InvoiceNumberPattern, error) {
+	p := &InvoiceNumberPattern{source: pattern}
+	var fyCount, seqCount int
+	rest := pattern
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			p.tokens = append(p.tokens, invoiceNumberToken{literal: rest})
+			break
+		}
+		if start > 0 {
+			p.tokens = append(p.tokens, invoiceNumberToken{literal: rest[:start]})
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("採番パターンの括弧が閉じていません: %s", pattern)
+		}
+		placeholder := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		name, widthText, hasWidth := strings.Cut(placeholder, ":")
+		token := invoiceNumberToken{name: name}
+		switch name {
+		case "FY":
+			fyCount++
+		case "YYYY", "MM", "CLIENT":
+		case "seq":
+			seqCount++
+			if hasWidth {
+				width, err := strconv.Atoi(widthText)
+				if err != nil || width < 1 || width > 9 {
+					return nil, fmt.Errorf("連番の桁数は1〜9で指定してください: %s", placeholder)
+				}
+				token.width = width
+			}
+		default:
+			return nil, fmt.Errorf("採番パターンに使えないプレースホルダーです: {%s}", placeholder)
+		}
+		if hasWidth && name != "seq" {
+			return nil, fmt.Errorf("桁数を指定できるのは連番のみです: {%s}", placeholder)
+		}
+		p.tokens = append(p.tokens, token)
+	}
+
+	if fyCount != 1 {
+		return nil, fmt.Errorf("採番パターンには {FY} を1つ含めてください: %s", pattern)
+	}
+	if seqCount != 1 {
+		return nil, fmt.Errorf("採番パターンには {seq} を1つ含めてください: %s", pattern)
+	}
+	return p, nil
+}
+
+// String 採番パターンの文字列
+func (p *InvoiceNumberPattern) String() string {
+	return p.source
+}
+
+// UsesClientPrefix 取引先プレフィックスを使うパターンかチェック
+func (p *InvoiceNumberPattern) UsesClientPrefix() bool {
+	for _, token := range p.tokens {
+		if token.name == "CLIENT" {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeKey 連番を共有する範囲のキー（連番の位置をプレースホルダーのまま残した番号）
+func (p *InvoiceNumberPattern) ScopeKey(params InvoiceNumberParams) string {
+	return p.render(params, invoiceNumberSeqPlaceholder)
+}
+
+// Format 連番を埋め込んだ請求書番号
+func (p *InvoiceNumberPattern) Format(params InvoiceNumberParams, seq int) string {
+	for _, token := range p.tokens {
+		if token.name == "seq" {
+			return p.render(params, fmt.Sprintf("%0*d", token.width, seq))
+		}
+	}
+	return p.render(params, strconv.Itoa(seq))
+}
+
+// render プレースホルダーを置き換えた文字列
+func (p *InvoiceNumberPattern) render(params InvoiceNumberParams, seq string) string {
+	var b strings.Builder
+	for _, token := range p.tokens {
+		switch token.name {
+		case "":
+			b.WriteString(token.literal)
+		case "FY":
+			fmt.Fprintf(&b, "%04d", params.FiscalYear)
+		case "YYYY":
+			fmt.Fprintf(&b, "%04d", params.InvoiceDate.Year())
+		case "MM":
+			fmt.Fprintf(&b, "%02d", int(params.InvoiceDate.Month()))
+		case "CLIENT":
+			b.WriteString(params.ClientPrefix)
+		case "seq":
+			b.WriteString(seq)
+		}
+	}
+	return b.String()
+}
+
+// FiscalYearOf 日付が属する会計年度（開始月の属する年で表す。4月開始なら2026年3月は2025年度）
+func FiscalYearOf(date time.Time, startMonth int) int {
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = 1
+	}
+	if int(date.Month()) < startMonth {
+		return date.Year() - 1
+	}
+	return date.Year()
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInvoiceNumberPattern(t *testing.T) {
+	date := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	params := InvoiceNumberParams{FiscalYear: FiscalYearOf(date, 4), InvoiceDate: date, ClientPrefix: "ACME"}
+
+	pattern, err := ParseInvoiceNumberPattern("INV-{FY}-{seq:05}")
+	require.NoError(t, err)
+	assert.Equal(t, "INV-2025-00042", pattern.Format(params, 42))
+	assert.Equal(t, "INV-2025-{seq}", pattern.ScopeKey(params))
+	assert.False(t, pattern.UsesClientPrefix())
+
+	pattern, err = ParseInvoiceNumberPattern("{CLIENT}/{FY}{MM}-{seq}")
+	require.NoError(t, err)
+	assert.Equal(t, "ACME/202503-7", pattern.Format(params, 7))
+	assert.Equal(t, "ACME/202503-{seq}", pattern.ScopeKey(params))
+	assert.True(t, pattern.UsesClientPrefix())
+
+	for _, invalid := range []string{
+		"INV-{seq:05}",               // 年度なし
+		"INV-{FY}",                   // 連番なし
+		"INV-{FY}-{seq}-{seq}",       // 連番が2つ
+		"INV-{FY}-{seq:0}",           // 桁数が範囲外
+		"INV-{FY}-{DD}-{seq}",        // 未対応のプレースホルダー
+		"INV-{FY:4}-{seq}",           // 連番以外の桁数指定
+		"INV-{FY}-{seq",              // 括弧が閉じていない
+		"INV-{FY}-{YYYY}-{FY}-{seq}", // 年度が2つ
+	} {
+		_, err := ParseInvoiceNumberPattern(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFiscalYearOf(t *testing.T) {
+	assert.Equal(t, 2025, FiscalYearOf(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), 4))
+	assert.Equal(t, 2026, FiscalYearOf(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 4))
+	assert.Equal(t, 2026, FiscalYearOf(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), 1))
+	// 不正な開始月は暦年として扱う
+	assert.Equal(t, 2026, FiscalYearOf(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 0))
+}
+
+func TestInvoiceNumberAllocationVoid(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	allocation := &InvoiceNumberAllocation{Status: InvoiceNumberAllocationStatusAllocated}
+
+	allocation.Void(now, "請求書の削除", "user-1")
+	assert.True(t, allocation.IsVoided())
+	assert.Equal(t, "請求書の削除", *allocation.VoidReason)
+	assert.Equal(t, "user-1", *allocation.VoidedBy)
+	assert.Equal(t, now, *allocation.VoidedAt)
+}
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// TaxCategory 消費税区分
+type TaxCategory string
+
+const (
+	// TaxCategoryStandard 標準税率10%
+	TaxCategoryStandard TaxCategory = "standard_10"
+	// TaxCategoryReduced 軽減税率8%
+	TaxCategoryReduced TaxCategory = "reduced_8"
+	// TaxCategoryExempt 免税（輸出取引等）
+	TaxCategoryExempt TaxCategory = "exempt"
+	// TaxCategoryNonTaxable 非課税
+	TaxCategoryNonTaxable TaxCategory = "non_taxable"
+)
+
+// taxCategoryOrder 請求書上の表示順
+var taxCategoryOrder = map[TaxCategory]int{
+	TaxCategoryStandard:   0,
+	TaxCategoryReduced:    1,
+	TaxCategoryExempt:     2,
+	TaxCategoryNonTaxable: 3,
+}
+
+// IsValid 有効な税区分かチェック
+func (c TaxCategory) IsValid() bool {
+	_, ok := taxCategoryOrder[c]
+	return ok
+}
+
+// Rate 税率（%）を取得
+func (c TaxCategory) Rate() float64 {
+	switch c {
+	case TaxCategoryStandard:
+		return 10
+	case TaxCategoryReduced:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// IsReduced 軽減税率対象かチェック
+func (c TaxCategory) IsReduced() bool {
+	return c == TaxCategoryReduced
+}
+
+// Label 請求書に記載する区分名を取得
+func (c TaxCategory) Label() string {
+	switch c {
+	case TaxCategoryStandard:
+		return "10%対象"
+	case TaxCategoryReduced:
+		return "8%対象（軽減税率）"
+	case TaxCategoryExempt:
+		return "免税"
+	case TaxCategoryNonTaxable:
+		return "非課税"
+	default:
+		return string(c)
+	}
+}
+
+// InvoiceTaxSummary 請求書の税率別小計モデル
+// 適格請求書の記載事項として税率ごとの対価の額と消費税額を保持する
+type InvoiceTaxSummary struct {
+	ID            string       `gorm:"type:varchar(36);primary_key" json:"id"`
+	InvoiceID     string       `gorm:"type:varchar(36);not null;index" json:"invoice_id"`
+	TaxCategory   TaxCategory  `gorm:"size:20;not null" json:"tax_category"`
+	TaxRate       float64      `gorm:"type:decimal(5,2);not null" json:"tax_rate"`
+	TaxableAmount money.Amount `gorm:"type:decimal(10,2);not null" json:"taxable_amount"`
+	TaxAmount     money.Amount `gorm:"type:decimal(10,2);not null" json:"tax_amount"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// BeforeCreate UUIDを生成
+func (s *InvoiceTaxSummary) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// InvoiceTaxCalculation 請求書の税額計算結果
+type InvoiceTaxCalculation struct {
+	Subtotal    money.Amount
+	TaxAmount   money.Amount
+	TotalAmount money.Amount
+	Summaries   []InvoiceTaxSummary
+}
+
+// CalculateInvoiceTax 明細から税率別小計と消費税額を計算する
+// 消費税は明細ごとではなく、請求書1枚につき税率ごとに1回だけ端数処理（切り捨て）する
+func CalculateInvoiceTax(details []InvoiceDetail) InvoiceTaxCalculation {
+	taxable := make(map[TaxCategory]money.Amount)
+	for _, detail := range details {
+		category := detail.TaxCategory
+		if category == "" {
+			category = TaxCategoryStandard
+		}
+		taxable[category] += detail.Amount
+	}
+
+	var result InvoiceTaxCalculation
+	for category, amount := range taxable {
+		tax := RoundTaxAmount(amount, category.Rate())
+		result.Summaries = append(result.Summaries, InvoiceTaxSummary{
+			TaxCategory:   category,
+			TaxRate:       category.Rate(),
+			TaxableAmount: amount,
+			TaxAmount:     tax,
+		})
+		result.Subtotal += amount
+		result.TaxAmount += tax
+	}
+	sort.Slice(result.Summaries, func(i, j int) bool {
+		return taxCategoryOrder[result.Summaries[i].TaxCategory] < taxCategoryOrder[result.Summaries[j].TaxCategory]
+	})
+	result.TotalAmount = result.Subtotal + result.TaxAmount
+	return result
+}
+
+// RoundTaxAmount 税抜金額と税率（%）から消費税額を計算し、円未満を切り捨てる
+// 赤伝のマイナス金額でも元の請求書と絶対値が一致するよう0方向に切り捨てる
+func RoundTaxAmount(amount money.Amount, rate float64) money.Amount {
+	return amount.Percent(rate, money.RoundingTruncate)
+}
+
+// PrimaryTaxRate 請求書の代表税率（課税対象額が最も大きい税率）を取得
+func (c InvoiceTaxCalculation) PrimaryTaxRate() float64 {
+	rate := 0.0
+	largest := money.Amount(-1)
+	for _, summary := range c.Summaries {
+		if summary.TaxRate > 0 && summary.TaxableAmount.Abs() > largest {
+			largest = summary.TaxableAmount.Abs()
+			rate = summary.TaxRate
+		}
+	}
+	return rate
+}
+
+// TaxBreakdown 税率別小計を取得
+// 税率別小計を持たない既存の請求書は請求書単位の税率から1件分を組み立てる
+func (i *Invoice) TaxBreakdown() []InvoiceTaxSummary {
+	if len(i.TaxSummaries) > 0 {
+		return i.TaxSummaries
+	}
+	if i.Subtotal.IsZero() && i.TaxAmount.IsZero() {
+		return nil
+	}
+	category := TaxCategoryStandard
+	if i.TaxRate == TaxCategoryReduced.Rate() {
+		category = TaxCategoryReduced
+	}
+	return []InvoiceTaxSummary{
+		{
+			InvoiceID:     i.ID,
+			TaxCategory:   category,
+			TaxRate:       i.TaxRate,
+			TaxableAmount: i.Subtotal,
+			TaxAmount:     i.TaxAmount,
+		},
+	}
+}
+
+// DistributeTaxToDetails 税率別の消費税額を明細行に按分する
+// 明細ごとの税額を切り捨てた上で、税率別小計との差額を端数の大きい明細から1円ずつ配分する
+// 戻り値は details と同じ順序の明細ごとの税額
+func DistributeTaxToDetails(details []InvoiceDetail, summaries []InvoiceTaxSummary) []money.Amount {
+	taxes := make([]money.Amount, len(details))
+	remainders := make(map[TaxCategory][]int)
+	allocated := make(map[TaxCategory]money.Amount)
+	fractions := make([]money.Amount, len(details))
+
+	for idx, detail := range details {
+		category := detail.TaxCategory
+		if category == "" {
+			category = TaxCategoryStandard
+		}
+		raw := detail.Amount.Percent(category.Rate(), money.RoundingNone)
+		taxes[idx] = RoundTaxAmount(detail.Amount, category.Rate())
+		fractions[idx] = raw - taxes[idx]
+		allocated[category] += taxes[idx]
+		remainders[category] = append(remainders[category], idx)
+	}
+
+	for _, summary := range summaries {
+		indexes := remainders[summary.TaxCategory]
+		diff := (summary.TaxAmount - allocated[summary.TaxCategory]).Round(money.RoundingHalfUp).Yen()
+		if diff == 0 || len(indexes) == 0 {
+			continue
+		}
+		step := money.Yen(1)
+		if diff < 0 {
+			// 赤伝など負の金額の場合は1円ずつ差し引く
+			step, diff = money.Yen(-1), -diff
+		}
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return fractions[indexes[a]].Abs() > fractions[indexes[b]].Abs()
+		})
+		for n := 0; n < int(diff); n++ {
+			taxes[indexes[n%len(indexes)]] += step
+		}
+	}
+	return taxes
+}
+
+// invoiceRegistrationNumberPattern 適格請求書発行事業者登録番号（T+13桁）
+var invoiceRegistrationNumberPattern = regexp.MustCompile(`^T[0-9]{13}$`)
+
+// IsValidInvoiceRegistrationNumber 登録番号の形式が正しいかチェック
+func IsValidInvoiceRegistrationNumber(number string) bool {
+	return invoiceRegistrationNumberPattern.MatchString(number)
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestCalculateInvoiceTax(t *testing.T) {
+	t.Run("税率ごとに1回だけ端数処理する", func(t *testing.T) {
+		// 明細ごとに切り捨てると 33 + 33 + 33 = 99 円になるが、税率ごとでは 100 円
+		details := []InvoiceDetail{
+			{Amount: money.Yen(333), TaxCategory: TaxCategoryStandard},
+			{Amount: money.Yen(333), TaxCategory: TaxCategoryStandard},
+			{Amount: money.Yen(334), TaxCategory: TaxCategoryStandard},
+		}
+		result := CalculateInvoiceTax(details)
+
+		require.Len(t, result.Summaries, 1)
+		assert.Equal(t, money.Yen(1000), result.Subtotal)
+		assert.Equal(t, money.Yen(100), result.TaxAmount)
+		assert.Equal(t, money.Yen(1100), result.TotalAmount)
+	})
+
+	t.Run("複数税率の小計", func(t *testing.T) {
+		details := []InvoiceDetail{
+			{Amount: money.Yen(1099), TaxCategory: TaxCategoryReduced},
+			{Amount: money.Yen(10005), TaxCategory: TaxCategoryStandard},
+			{Amount: money.Yen(5000), TaxCategory: TaxCategoryNonTaxable},
+			{Amount: money.Yen(2000), TaxCategory: TaxCategoryExempt},
+			{Amount: money.Yen(3000)}, // 未指定は標準税率
+		}
+		result := CalculateInvoiceTax(details)
+
+		require.Len(t, result.Summaries, 4)
+		assert.Equal(t, TaxCategoryStandard, result.Summaries[0].TaxCategory)
+		assert.Equal(t, money.Yen(13005), result.Summaries[0].TaxableAmount)
+		assert.Equal(t, money.Yen(1300), result.Summaries[0].TaxAmount)
+		assert.Equal(t, TaxCategoryReduced, result.Summaries[1].TaxCategory)
+		assert.Equal(t, money.Yen(87), result.Summaries[1].TaxAmount)
+		assert.Equal(t, TaxCategoryExempt, result.Summaries[2].TaxCategory)
+		assert.Equal(t, money.Zero, result.Summaries[2].TaxAmount)
+		assert.Equal(t, TaxCategoryNonTaxable, result.Summaries[3].TaxCategory)
+		assert.Equal(t, money.Zero, result.Summaries[3].TaxAmount)
+
+		assert.Equal(t, money.Yen(21104), result.Subtotal)
+		assert.Equal(t, money.Yen(1387), result.TaxAmount)
+		assert.Equal(t, money.Yen(22491), result.TotalAmount)
+		assert.Equal(t, 10.0, result.PrimaryTaxRate())
+	})
+
+	t.Run("浮動小数点誤差で切り捨てすぎない", func(t *testing.T) {
+		result := CalculateInvoiceTax([]InvoiceDetail{{Amount: money.Yen(1150), TaxCategory: TaxCategoryReduced}})
+		assert.Equal(t, money.Yen(92), result.TaxAmount)
+	})
+}
+
+func TestDistributeTaxToDetails(t *testing.T) {
+	details := []InvoiceDetail{
+		{Amount: money.Yen(333), TaxCategory: TaxCategoryStandard},
+		{Amount: money.Yen(335), TaxCategory: TaxCategoryStandard},
+		{Amount: money.Yen(332), TaxCategory: TaxCategoryStandard},
+		{Amount: money.Yen(1099), TaxCategory: TaxCategoryReduced},
+		{Amount: money.Yen(500), TaxCategory: TaxCategoryNonTaxable},
+	}
+	result := CalculateInvoiceTax(details)
+	taxes := DistributeTaxToDetails(details, result.Summaries)
+
+	require.Len(t, taxes, len(details))
+	assert.Equal(t, money.Yen(100), taxes[0]+taxes[1]+taxes[2])
+	assert.Equal(t, money.Yen(34), taxes[1]) // 端数が最も大きい明細に配分
+	assert.Equal(t, money.Yen(87), taxes[3])
+	assert.Equal(t, money.Zero, taxes[4])
+
+	t.Run("負の金額", func(t *testing.T) {
+		negative := []InvoiceDetail{
+			{Amount: money.Yen(-335), TaxCategory: TaxCategoryStandard},
+			{Amount: money.Yen(-335), TaxCategory: TaxCategoryStandard},
+		}
+		result := CalculateInvoiceTax(negative)
+		assert.Equal(t, money.Yen(-67), result.TaxAmount)
+		taxes := DistributeTaxToDetails(negative, result.Summaries)
+		assert.Equal(t, money.Yen(-67), taxes[0]+taxes[1])
+	})
+}
+
+func TestInvoice_TaxBreakdown(t *testing.T) {
+	t.Run("税率別小計がない既存請求書", func(t *testing.T) {
+		invoice := &Invoice{Subtotal: money.Yen(10000), TaxRate: 10, TaxAmount: money.Yen(1000)}
+		breakdown := invoice.TaxBreakdown()
+		require.Len(t, breakdown, 1)
+		assert.Equal(t, TaxCategoryStandard, breakdown[0].TaxCategory)
+		assert.Equal(t, money.Yen(1000), breakdown[0].TaxAmount)
+	})
+
+	t.Run("税率別小計を持つ請求書", func(t *testing.T) {
+		invoice := &Invoice{TaxSummaries: []InvoiceTaxSummary{
+			{TaxCategory: TaxCategoryStandard}, {TaxCategory: TaxCategoryReduced},
+		}}
+		assert.Len(t, invoice.TaxBreakdown(), 2)
+	})
+}
+
+func TestIsValidInvoiceRegistrationNumber(t *testing.T) {
+	assert.True(t, IsValidInvoiceRegistrationNumber("T1234567890123"))
+	assert.False(t, IsValidInvoiceRegistrationNumber("1234567890123"))
+	assert.False(t, IsValidInvoiceRegistrationNumber("T123456789012"))
+	assert.False(t, IsValidInvoiceRegistrationNumber("T12345678901234"))
+	assert.False(t, IsValidInvoiceRegistrationNumber(""))
+}
@@ -0,0 +1,238 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrJournalEntryImmutable 締め済みの仕訳は作成後に変更・削除できない
+var ErrJournalEntryImmutable = errors.New("締め済みの仕訳は変更・削除できません")
+
+// 仕訳の勘定科目設定キー
+const (
+	// JournalMappingInvoiceCollection 請求書の入金（普通預金／売掛金）
+	JournalMappingInvoiceCollection = "invoice_collection"
+	// JournalMappingInvoiceTransferFee 入金時に差し引かれた振込手数料（支払手数料／売掛金）
+	JournalMappingInvoiceTransferFee = "invoice_transfer_fee"
+	// JournalMappingExpensePayment 経費の精算（未払金／普通預金）
+	JournalMappingExpensePayment = "expense_payment"
+
+	// journalMappingInvoiceSalesPrefix 税区分別の売上計上キー（売掛金／売上高）の接頭辞
+	journalMappingInvoiceSalesPrefix = "invoice_sales:"
+	// journalMappingExpenseCategoryPrefix 経費カテゴリ別の計上キーの接頭辞
+	journalMappingExpenseCategoryPrefix = "expense_category:"
+)
+
+// JournalGeneralMappingKeys 経費カテゴリ以外の勘定科目設定キー
+var JournalGeneralMappingKeys = []string{
+	InvoiceSalesJournalMappingKey(TaxCategoryStandard),
+	InvoiceSalesJournalMappingKey(TaxCategoryReduced),
+	InvoiceSalesJournalMappingKey(TaxCategoryExempt),
+	InvoiceSalesJournalMappingKey(TaxCategoryNonTaxable),
+	JournalMappingInvoiceCollection,
+	JournalMappingInvoiceTransferFee,
+	JournalMappingExpensePayment,
+}
+
+// InvoiceSalesJournalMappingKey 請求書の売上計上に使う税区分別の勘定科目設定キー
+func InvoiceSalesJournalMappingKey(category TaxCategory) string {
+	return journalMappingInvoiceSalesPrefix + string(category)
+}
+
+// ExpenseJournalMappingKey 経費カテゴリの計上に使う勘定科目設定キー
+func ExpenseJournalMappingKey(categoryCode string) string {
+	return journalMappingExpenseCategoryPrefix + categoryCode
+}
+
+// IsGeneralJournalMappingKey 経費カテゴリ以外の勘定科目設定キーかチェック
+func IsGeneralJournalMappingKey(key string) bool {
+	for _, k := range JournalGeneralMappingKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpenseCategoryCodeFromJournalMappingKey 勘定科目設定キーから経費カテゴリコードを取り出す
+func ExpenseCategoryCodeFromJournalMappingKey(key string) (string, bool) {
+	code, ok := strings.CutPrefix(key, journalMappingExpenseCategoryPrefix)
+	return code, ok && code != ""
+}
+
+// JournalSourceType 仕訳の元になったデータの種類
+type JournalSourceType string
+
+const (
+	// JournalSourceExpense 経費申請
+	JournalSourceExpense JournalSourceType = "expense"
+	// JournalSourceInvoice 請求書
+	JournalSourceInvoice JournalSourceType = "invoice"
+)
+
+// JournalAccountMapping 仕訳の勘定科目設定（経費カテゴリ・取引の種類ごと）
+type JournalAccountMapping struct {
+	ID                string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	MappingKey        string    `gorm:"size:100;not null;unique" json:"mapping_key"`
+	DebitAccount      string    `gorm:"size:100;not null" json:"debit_account"`
+	DebitSubAccount   string    `gorm:"size:100" json:"debit_sub_account"`
+	DebitTaxCategory  string    `gorm:"size:50" json:"debit_tax_category"`
+	CreditAccount     string    `gorm:"size:100;not null" json:"credit_account"`
+	CreditSubAccount  string    `gorm:"size:100" json:"credit_sub_account"`
+	CreditTaxCategory string    `gorm:"size:50" json:"credit_tax_category"`
+	UpdatedBy         *string   `gorm:"type:varchar(36)" json:"updated_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (JournalAccountMapping) TableName() string {
+	return "journal_account_mappings"
+}
+
+// BeforeCreate UUID生成
+func (m *JournalAccountMapping) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// AccountingPeriodClose 仕訳の月次締め
+type AccountingPeriodClose struct {
+	ID          string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	Month       string    `gorm:"size:7;not null;unique" json:"month"` // YYYY-MM
+	EntryCount  int       `gorm:"not null" json:"entry_count"`
+	TotalAmount int64     `gorm:"not null" json:"total_amount"` // 借方合計（円）
+	ClosedBy    string    `gorm:"type:varchar(36);not null" json:"closed_by"`
+	ClosedAt    time.Time `gorm:"not null" json:"closed_at"`
+}
+
+// TableName テーブル名を指定
+func (AccountingPeriodClose) TableName() string {
+	return "accounting_period_closes"
+}
+
+// BeforeCreate UUID生成
+func (c *AccountingPeriodClose) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// JournalEntry 締め済みの月に確定した仕訳（変更・削除不可）
+type JournalEntry struct {
+	ID                string            `gorm:"type:varchar(36);primary_key" json:"id"`
+	Month             string            `gorm:"size:7;not null;index" json:"month"` // 計上した締め月（YYYY-MM）
+	SlipNumber        int               `gorm:"not null" json:"slip_number"`
+	EntryDate         time.Time         `gorm:"type:date;not null" json:"entry_date"`
+	SourceType        JournalSourceType `gorm:"size:20;not null" json:"source_type"`
+	SourceID          string            `gorm:"type:varchar(36);not null" json:"source_id"`
+	MappingKey        string            `gorm:"size:100;not null" json:"mapping_key"`
+	DebitAccount      string            `gorm:"size:100;not null" json:"debit_account"`
+	DebitSubAccount   string            `gorm:"size:100" json:"debit_sub_account"`
+	DebitTaxCategory  string            `gorm:"size:50" json:"debit_tax_category"`
+	DebitTaxAmount    int64             `gorm:"not null;default:0" json:"debit_tax_amount"`
+	CreditAccount     string            `gorm:"size:100;not null" json:"credit_account"`
+	CreditSubAccount  string            `gorm:"size:100" json:"credit_sub_account"`
+	CreditTaxCategory string            `gorm:"size:50" json:"credit_tax_category"`
+	CreditTaxAmount   int64             `gorm:"not null;default:0" json:"credit_tax_amount"`
+	Amount            int64             `gorm:"not null" json:"amount"` // 円（常に正の値）
+	PartnerName       string            `gorm:"size:255" json:"partner_name"`
+	Description       string            `gorm:"size:255" json:"description"`
+	CreatedAt         time.Time         `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// BeforeCreate UUID生成
+func (e *JournalEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// BeforeUpdate 締め済みの仕訳の変更を禁止
+func (e *JournalEntry) BeforeUpdate(tx *gorm.DB) error {
+	return ErrJournalEntryImmutable
+}
+
+// BeforeDelete 締め済みの仕訳の削除を禁止
+func (e *JournalEntry) BeforeDelete(tx *gorm.DB) error {
+	return ErrJournalEntryImmutable
+}
+
+// SourceKey 元データと勘定科目設定キーの組（同じ取引を二重に計上しないための識別子）
+func (e *JournalEntry) SourceKey() string {
+	return string(e.SourceType) + "/" + e.SourceID + "/" + e.MappingKey
+}
+
+// NewJournalEntry 勘定科目設定から仕訳を組み立てる
+// 金額がマイナス（赤伝など）の場合は借方と貸方を入れ替えて正の金額で計上する
+func NewJournalEntry(mapping JournalAccountMapping, sourceType JournalSourceType, sourceID string, date time.Time, amount, taxAmount int64, partnerName, description string) JournalEntry {
+	entry := JournalEntry{
+		EntryDate:         date,
+		SourceType:        sourceType,
+		SourceID:          sourceID,
+		MappingKey:        mapping.MappingKey,
+		DebitAccount:      mapping.DebitAccount,
+		DebitSubAccount:   mapping.DebitSubAccount,
+		DebitTaxCategory:  mapping.DebitTaxCategory,
+		CreditAccount:     mapping.CreditAccount,
+		CreditSubAccount:  mapping.CreditSubAccount,
+		CreditTaxCategory: mapping.CreditTaxCategory,
+		Amount:            amount,
+		PartnerName:       partnerName,
+		Description:       description,
+	}
+	// 税額は税区分を設定した側に付ける
+	if mapping.CreditTaxCategory != "" {
+		entry.CreditTaxAmount = taxAmount
+	} else if mapping.DebitTaxCategory != "" {
+		entry.DebitTaxAmount = taxAmount
+	}
+
+	if amount < 0 {
+		entry.Amount = -amount
+		entry.DebitAccount, entry.CreditAccount = entry.CreditAccount, entry.DebitAccount
+		entry.DebitSubAccount, entry.CreditSubAccount = entry.CreditSubAccount, entry.DebitSubAccount
+		entry.DebitTaxCategory, entry.CreditTaxCategory = entry.CreditTaxCategory, entry.DebitTaxCategory
+		entry.DebitTaxAmount, entry.CreditTaxAmount = -entry.CreditTaxAmount, -entry.DebitTaxAmount
+	}
+	return entry
+}
+
+// JournalExport 仕訳の出力履歴
+type JournalExport struct {
+	ID          string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	Month       string    `gorm:"size:7;not null;index" json:"month"`
+	Format      string    `gorm:"size:20;not null" json:"format"`
+	Locked      bool      `gorm:"not null;default:false" json:"locked"` // 締め済みの仕訳から出力したか
+	EntryCount  int       `gorm:"not null" json:"entry_count"`
+	TotalAmount int64     `gorm:"not null" json:"total_amount"` // 借方合計（円）
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ExportedBy  string    `gorm:"type:varchar(36);not null" json:"exported_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (JournalExport) TableName() string {
+	return "journal_exports"
+}
+
+// BeforeCreate UUID生成
+func (e *JournalExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
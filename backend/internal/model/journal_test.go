@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJournalEntry(t *testing.T) {
+	mapping := JournalAccountMapping{
+		MappingKey:        InvoiceSalesJournalMappingKey(TaxCategoryStandard),
+		DebitAccount:      "売掛金",
+		CreditAccount:     "売上高",
+		CreditTaxCategory: "課税売上10%",
+	}
+	date := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	entry := NewJournalEntry(mapping, JournalSourceInvoice, "inv-1", date, 880000, 80000, "株式会社サンプル", "INV-001")
+	assert.Equal(t, "売掛金", entry.DebitAccount)
+	assert.Equal(t, "売上高", entry.CreditAccount)
+	assert.Equal(t, int64(880000), entry.Amount)
+	assert.Equal(t, int64(80000), entry.CreditTaxAmount)
+	assert.Zero(t, entry.DebitTaxAmount)
+	assert.Equal(t, "invoice/inv-1/invoice_sales:standard_10", entry.SourceKey())
+
+	// 赤伝は借方・貸方を入れ替えて正の金額で計上する
+	reversal := NewJournalEntry(mapping, JournalSourceInvoice, "inv-2", date, -880000, -80000, "株式会社サンプル", "INV-001-C")
+	assert.Equal(t, "売上高", reversal.DebitAccount)
+	assert.Equal(t, "課税売上10%", reversal.DebitTaxCategory)
+	assert.Equal(t, int64(80000), reversal.DebitTaxAmount)
+	assert.Equal(t, "売掛金", reversal.CreditAccount)
+	assert.Empty(t, reversal.CreditTaxCategory)
+	assert.Zero(t, reversal.CreditTaxAmount)
+	assert.Equal(t, int64(880000), reversal.Amount)
+}
+
+func TestJournalMappingKeys(t *testing.T) {
+	key := ExpenseJournalMappingKey(CategoryCodeTransport)
+	assert.Equal(t, "expense_category:transport", key)
+
+	code, ok := ExpenseCategoryCodeFromJournalMappingKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, CategoryCodeTransport, code)
+
+	_, ok = ExpenseCategoryCodeFromJournalMappingKey("expense_category:")
+	assert.False(t, ok)
+	_, ok = ExpenseCategoryCodeFromJournalMappingKey(JournalMappingExpensePayment)
+	assert.False(t, ok)
+
+	assert.True(t, IsGeneralJournalMappingKey("invoice_sales:reduced_8"))
+	assert.False(t, IsGeneralJournalMappingKey(key))
+}
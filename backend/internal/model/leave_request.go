@@ -24,6 +24,9 @@ type LeaveRequest struct {
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `gorm:"index" json:"deleted_at"`
 
+	// 代理承認（ApproverIDの承認者が代理でOnBehalfOfIDの承認者の承認を行った場合に設定）
+	OnBehalfOfID *string `gorm:"type:varchar(255)" json:"on_behalf_of_id"`
+
 	// リレーション
 	LeaveType LeaveType            `gorm:"foreignKey:LeaveTypeID" json:"leave_type"`
 	User      User                 `gorm:"foreignKey:UserID" json:"user"`
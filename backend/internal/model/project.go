@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ProjectStatus プロジェクトステータス
@@ -56,7 +58,7 @@ type Project struct {
 	Status          ProjectStatus  `gorm:"size:50;default:'proposal'" json:"status"`
 	StartDate       *time.Time     `json:"start_date"`
 	EndDate         *time.Time     `json:"end_date"`
-	MonthlyRate     float64        `gorm:"type:decimal(10,2)" json:"monthly_rate"`
+	MonthlyRate     money.Amount   `gorm:"type:decimal(10,2)" json:"monthly_rate"`
 	WorkingHoursMin int            `gorm:"default:140" json:"working_hours_min"`
 	WorkingHoursMax int            `gorm:"default:180" json:"working_hours_max"`
 	ContractType    ContractType   `gorm:"size:50;default:'ses'" json:"contract_type"`
@@ -67,6 +69,9 @@ type Project struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// 月の途中の参画・離任時の日割り方法（NULLは取引先の設定に従う）
+	ProrationMethod *ProrationMethod `gorm:"size:20" json:"proration_method"`
+
 	// リレーション
 	Client      Client              `gorm:"foreignKey:ClientID" json:"client,omitempty"`
 	Assignments []ProjectAssignment `gorm:"foreignKey:ProjectID" json:"assignments,omitempty"`
@@ -95,6 +100,13 @@ type Client struct {
 	FreeeClientID     *int           `json:"freee_client_id"`
 	FreeSyncStatus    FreeSyncStatus `gorm:"type:enum('not_synced','synced','failed','pending');default:'not_synced'" json:"freee_sync_status"`
 	FreeSyncedAt      *time.Time     `json:"freee_synced_at"`
+	InvoiceTemplate   string         `gorm:"size:50" json:"invoice_template"` // 請求書PDFのレイアウトテンプレート名
+
+	// 月の途中の参画・離任時の日割り方法
+	ProrationMethod ProrationMethod `gorm:"size:20;default:'business_days'" json:"proration_method"`
+
+	// 請求書番号の採番パターンの {CLIENT} に使うプレフィックス
+	InvoiceNumberPrefix string `gorm:"size:20" json:"invoice_number_prefix"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -120,15 +132,15 @@ const (
 
 // ProjectAssignment エンジニア案件アサインモデル
 type ProjectAssignment struct {
-	ID              string     `gorm:"type:varchar(255);primary_key" json:"id"`
-	ProjectID       string     `gorm:"type:varchar(255);not null" json:"project_id"`
-	UserID          string     `gorm:"type:varchar(255);not null" json:"user_id"`
-	Role            string     `gorm:"size:100" json:"role"`
-	StartDate       time.Time  `gorm:"not null" json:"start_date"`
-	EndDate         *time.Time `json:"end_date"`
-	UtilizationRate int        `gorm:"default:100" json:"utilization_rate"`
-	BillingRate     float64    `gorm:"type:decimal(10,2)" json:"billing_rate"`
-	Notes           string     `gorm:"type:text" json:"notes"`
+	ID              string       `gorm:"type:varchar(255);primary_key" json:"id"`
+	ProjectID       string       `gorm:"type:varchar(255);not null" json:"project_id"`
+	UserID          string       `gorm:"type:varchar(255);not null" json:"user_id"`
+	Role            string       `gorm:"size:100" json:"role"`
+	StartDate       time.Time    `gorm:"not null" json:"start_date"`
+	EndDate         *time.Time   `json:"end_date"`
+	UtilizationRate int          `gorm:"default:100" json:"utilization_rate"`
+	BillingRate     money.Amount `gorm:"type:decimal(10,2)" json:"billing_rate"`
+	Notes           string       `gorm:"type:text" json:"notes"`
 
 	// 請求機能拡張フィールド
 	BillingType *ProjectBillingType `gorm:"type:enum('fixed','variable_upper_lower','variable_middle')" json:"billing_type"`
@@ -0,0 +1,305 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// ProjectAssignmentRate 案件アサインの単価履歴（適用期間ごとの請求単価・精算条件）
+type ProjectAssignmentRate struct {
+	ID            string             `gorm:"type:varchar(36);primary_key" json:"id"`
+	AssignmentID  string             `gorm:"type:varchar(36);not null;index" json:"assignment_id"`
+	EffectiveFrom time.Time          `gorm:"type:date;not null" json:"effective_from"`
+	EffectiveTo   *time.Time         `gorm:"type:date" json:"effective_to"` // NULLは終了日なし
+	BillingType   ProjectBillingType `gorm:"type:billing_type_enum;not null;default:'fixed'" json:"billing_type"`
+	BillingRate   money.Amount       `gorm:"type:decimal(10,2);not null" json:"billing_rate"`
+	MinHours      *float64           `gorm:"type:decimal(5,2)" json:"min_hours"`
+	MaxHours      *float64           `gorm:"type:decimal(5,2)" json:"max_hours"`
+	Notes         string             `gorm:"type:text" json:"notes"`
+	CreatedBy     string             `gorm:"type:varchar(255);not null" json:"created_by"`
+	UpdatedBy     string             `gorm:"type:varchar(255);not null" json:"updated_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// TableName テーブル名を指定
+func (ProjectAssignmentRate) TableName() string {
+	return "project_assignment_rates"
+}
+
+// BeforeCreate UUID生成
+func (r *ProjectAssignmentRate) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Covers 指定日が適用期間に含まれるか
+func (r *ProjectAssignmentRate) Covers(date time.Time) bool {
+	date = dateOnly(date)
+	if date.Before(dateOnly(r.EffectiveFrom)) {
+		return false
+	}
+	return r.EffectiveTo == nil || !date.After(dateOnly(*r.EffectiveTo))
+}
+
+// Overlaps 適用期間が他の単価履歴と重なるか
+func (r *ProjectAssignmentRate) Overlaps(other *ProjectAssignmentRate) bool {
+	if r.EffectiveTo != nil && dateOnly(*r.EffectiveTo).Before(dateOnly(other.EffectiveFrom)) {
+		return false
+	}
+	if other.EffectiveTo != nil && dateOnly(*other.EffectiveTo).Before(dateOnly(r.EffectiveFrom)) {
+		return false
+	}
+	return true
+}
+
+// Validate 単価履歴の入力値を検証
+func (r *ProjectAssignmentRate) Validate() error {
+	if r.EffectiveTo != nil && dateOnly(*r.EffectiveTo).Before(dateOnly(r.EffectiveFrom)) {
+		return fmt.Errorf("適用終了日は適用開始日以降を指定してください")
+	}
+	if r.BillingRate.Sign() < 0 {
+		return fmt.Errorf("請求単価は0以上を指定してください")
+	}
+	switch r.BillingType {
+	case ProjectBillingTypeFixed:
+	case ProjectBillingTypeVariableUpperLower, ProjectBillingTypeVariableMiddle:
+		if r.MinHours == nil || r.MaxHours == nil {
+			return fmt.Errorf("精算幅（下限・上限時間）を指定してください")
+		}
+		if *r.MinHours >= *r.MaxHours {
+			return fmt.Errorf("下限時間は上限時間より小さくしてください")
+		}
+	default:
+		return fmt.Errorf("不明な請求タイプ: %s", r.BillingType)
+	}
+	return nil
+}
+
+// NewProjectAssignmentRateFromAssignment アサインに設定された現在の単価から単価履歴を作成
+// 単価履歴が登録されていないアサインの請求計算に使う
+func NewProjectAssignmentRateFromAssignment(assignment *ProjectAssignment) *ProjectAssignmentRate {
+	return &ProjectAssignmentRate{
+		AssignmentID:  assignment.ID,
+		EffectiveFrom: dateOnly(assignment.StartDate),
+		EffectiveTo:   assignment.EndDate,
+		BillingType:   assignment.GetBillingType(),
+		BillingRate:   assignment.BillingRate,
+		MinHours:      assignment.MinHours,
+		MaxHours:      assignment.MaxHours,
+	}
+}
+
+// BillingRateSegment 請求期間のうち同じ単価履歴が適用される区間
+type BillingRateSegment struct {
+	RateID          string             `json:"rate_id,omitempty"`
+	Start           time.Time          `json:"start"`
+	End             time.Time          `json:"end"`
+	Days            int                `json:"days"` // 区間の暦日数
+	BillingType     ProjectBillingType `json:"billing_type"`
+	BillingRate     money.Amount       `json:"billing_rate"` // 日割り前の月額単価
+	MinHours        *float64           `json:"min_hours,omitempty"`
+	MaxHours        *float64           `json:"max_hours,omitempty"`
+	ProrationMethod ProrationMethod    `json:"proration_method"`
+	Units           int                `json:"units"`       // 区間の日割りの基準日数
+	MonthUnits      int                `json:"month_units"` // 請求月全体の日割りの基準日数
+}
+
+// IsProrated 日割りが必要な区間か
+func (s *BillingRateSegment) IsProrated() bool {
+	return s.Units != s.MonthUnits
+}
+
+// Ratio 請求月に占める区間の割合
+func (s *BillingRateSegment) Ratio() float64 {
+	if s.MonthUnits == 0 {
+		return 0
+	}
+	return float64(s.Units) / float64(s.MonthUnits)
+}
+
+// ProratedRate 区間の日数で日割りした単価
+func (s *BillingRateSegment) ProratedRate() money.Amount {
+	if !s.IsProrated() {
+		return s.BillingRate
+	}
+	return s.BillingRate.MulDiv(float64(s.Units), float64(s.MonthUnits), money.RoundingNone)
+}
+
+// ProratedHours 区間の日数で按分した精算時間（下限・上限）
+func (s *BillingRateSegment) ProratedHours(hours float64) float64 {
+	if !s.IsProrated() {
+		return hours
+	}
+	return roundHours(hours * s.Ratio())
+}
+
+// UncoveredRateError 請求期間に単価履歴のない日がある
+type UncoveredRateError struct {
+	Dates []time.Time // 単価履歴のない期間の開始日
+}
+
+// Error エラーメッセージを返す
+func (e *UncoveredRateError) Error() string {
+	labels := make([]string, len(e.Dates))
+	for i, d := range e.Dates {
+		labels[i] = d.Format("2006/1/2")
+	}
+	return "単価が登録されていない期間があります（" + strings.Join(labels, ", ") + "〜）"
+}
+
+// ResolveBillingRates 請求期間（start〜end）に適用される単価履歴を区間に分割する
+// 期間の途中で単価が変わる場合は、変更日で区間を分けて期間の暦日数で按分する
+// 請求月を基準に日割りする場合はApplyProrationで按分の割合を設定し直す
+func ResolveBillingRates(rates []*ProjectAssignmentRate, start, end time.Time) ([]BillingRateSegment, error) {
+	start, end = dateOnly(start), dateOnly(end)
+	periodDays := int(end.Sub(start).Hours()/24) + 1
+
+	sorted := make([]*ProjectAssignmentRate, len(rates))
+	copy(sorted, rates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom)
+	})
+
+	var segments []BillingRateSegment
+	uncovered := &UncoveredRateError{}
+	gap := false
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		rate := findBillingRate(sorted, d)
+		if rate == nil {
+			if !gap {
+				uncovered.Dates = append(uncovered.Dates, d)
+			}
+			gap = true
+			continue
+		}
+		gap = false
+
+		if n := len(segments); n > 0 && segments[n-1].RateID == rate.ID && segments[n-1].End.AddDate(0, 0, 1).Equal(d) {
+			segments[n-1].End = d
+			segments[n-1].Days++
+			segments[n-1].Units++
+			continue
+		}
+		segments = append(segments, BillingRateSegment{
+			RateID:          rate.ID,
+			Start:           d,
+			End:             d,
+			Days:            1,
+			BillingType:     rate.BillingType,
+			BillingRate:     rate.BillingRate,
+			MinHours:        rate.MinHours,
+			MaxHours:        rate.MaxHours,
+			ProrationMethod: ProrationMethodCalendarDays,
+			Units:           1,
+			MonthUnits:      periodDays,
+		})
+	}
+
+	if len(uncovered.Dates) > 0 {
+		return nil, uncovered
+	}
+	return segments, nil
+}
+
+// findBillingRate 指定日に適用される単価履歴
+func findBillingRate(rates []*ProjectAssignmentRate, date time.Time) *ProjectAssignmentRate {
+	for _, rate := range rates {
+		if rate.Covers(date) {
+			return rate
+		}
+	}
+	return nil
+}
+
+// 単価履歴の監査ログのアクション
+const (
+	// ProjectAssignmentRateActionCreate 登録
+	ProjectAssignmentRateActionCreate = "create"
+	// ProjectAssignmentRateActionUpdate 変更
+	ProjectAssignmentRateActionUpdate = "update"
+	// ProjectAssignmentRateActionDelete 削除
+	ProjectAssignmentRateActionDelete = "delete"
+)
+
+// ProjectAssignmentRateSnapshot 監査ログに記録する単価履歴の内容
+type ProjectAssignmentRateSnapshot map[string]interface{}
+
+// Scan ProjectAssignmentRateSnapshotのスキャン実装
+func (s *ProjectAssignmentRateSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into ProjectAssignmentRateSnapshot", value)
+	}
+}
+
+// Value ProjectAssignmentRateSnapshotの値取得実装
+func (s ProjectAssignmentRateSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Snapshot 単価履歴の監査対象の項目を取り出す
+func (r *ProjectAssignmentRate) Snapshot() ProjectAssignmentRateSnapshot {
+	snapshot := ProjectAssignmentRateSnapshot{
+		"effective_from": r.EffectiveFrom.Format("2006-01-02"),
+		"effective_to":   nil,
+		"billing_type":   string(r.BillingType),
+		"billing_rate":   r.BillingRate.String(),
+		"min_hours":      r.MinHours,
+		"max_hours":      r.MaxHours,
+		"notes":          r.Notes,
+	}
+	if r.EffectiveTo != nil {
+		snapshot["effective_to"] = r.EffectiveTo.Format("2006-01-02")
+	}
+	return snapshot
+}
+
+// ProjectAssignmentRateAuditLog 単価履歴の変更ログ
+type ProjectAssignmentRateAuditLog struct {
+	ID           string                        `gorm:"type:varchar(36);primary_key" json:"id"`
+	RateID       string                        `gorm:"type:varchar(36);not null;index" json:"rate_id"`
+	AssignmentID string                        `gorm:"type:varchar(36);not null;index" json:"assignment_id"`
+	Action       string                        `gorm:"type:varchar(20);not null" json:"action"`
+	OldValues    ProjectAssignmentRateSnapshot `gorm:"type:json" json:"old_values"`
+	NewValues    ProjectAssignmentRateSnapshot `gorm:"type:json" json:"new_values"`
+	Reason       string                        `gorm:"type:text" json:"reason"`
+	ChangedBy    string                        `gorm:"type:varchar(255);not null" json:"changed_by"`
+	ChangedAt    time.Time                     `gorm:"not null" json:"changed_at"`
+}
+
+// TableName テーブル名を指定
+func (ProjectAssignmentRateAuditLog) TableName() string {
+	return "project_assignment_rate_audit_logs"
+}
+
+// BeforeCreate UUID生成
+func (l *ProjectAssignmentRateAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestResolveBillingRates(t *testing.T) {
+	minHours, maxHours := 140.0, 180.0
+	changeDay := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+	before := changeDay.AddDate(0, 0, -1)
+	rates := []*ProjectAssignmentRate{
+		{
+			ID:            "r2",
+			EffectiveFrom: changeDay,
+			BillingType:   ProjectBillingTypeVariableUpperLower,
+			BillingRate:   money.Yen(900000),
+			MinHours:      &minHours,
+			MaxHours:      &maxHours,
+		},
+		{
+			ID:            "r1",
+			EffectiveFrom: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			EffectiveTo:   &before,
+			BillingType:   ProjectBillingTypeFixed,
+			BillingRate:   money.Yen(600000),
+		},
+	}
+
+	// 4/10に単価改定した月は9日分と21日分に分ける
+	start, end := BillingMonthRange(2024, 4)
+	segments, err := ResolveBillingRates(rates, start, end)
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+
+	assert.Equal(t, "r1", segments[0].RateID)
+	assert.Equal(t, 9, segments[0].Days)
+	assert.Equal(t, before, segments[0].End)
+	assert.Equal(t, money.Yen(180000), segments[0].ProratedRate())
+
+	assert.Equal(t, "r2", segments[1].RateID)
+	assert.Equal(t, changeDay, segments[1].Start)
+	assert.Equal(t, 21, segments[1].Days)
+	assert.Equal(t, money.Yen(630000), segments[1].ProratedRate())
+	assert.Equal(t, 98.0, segments[1].ProratedHours(minHours))
+
+	// 改定前の月は単価履歴1件をそのまま使う
+	start, end = BillingMonthRange(2024, 3)
+	segments, err = ResolveBillingRates(rates, start, end)
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	assert.Equal(t, money.Yen(600000), segments[0].ProratedRate())
+	assert.Equal(t, 1.0, segments[0].Ratio())
+}
+
+func TestResolveBillingRates_Uncovered(t *testing.T) {
+	to := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	rates := []*ProjectAssignmentRate{
+		{ID: "r1", EffectiveFrom: time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC), EffectiveTo: &to},
+	}
+
+	start, end := BillingMonthRange(2024, 4)
+	_, err := ResolveBillingRates(rates, start, end)
+
+	var uncovered *UncoveredRateError
+	require.ErrorAs(t, err, &uncovered)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC),
+	}, uncovered.Dates)
+}
+
+func TestProjectAssignmentRateValidate(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	minHours, maxHours := 140.0, 180.0
+
+	assert.NoError(t, (&ProjectAssignmentRate{EffectiveFrom: from, BillingType: ProjectBillingTypeFixed, BillingRate: money.Yen(600000)}).Validate())
+	assert.Error(t, (&ProjectAssignmentRate{EffectiveFrom: from, EffectiveTo: &to, BillingType: ProjectBillingTypeFixed}).Validate())
+	assert.Error(t, (&ProjectAssignmentRate{EffectiveFrom: from, BillingType: ProjectBillingTypeVariableMiddle}).Validate())
+	assert.Error(t, (&ProjectAssignmentRate{EffectiveFrom: from, BillingType: ProjectBillingTypeVariableMiddle, MinHours: &maxHours, MaxHours: &minHours}).Validate())
+
+	open := &ProjectAssignmentRate{EffectiveFrom: from}
+	assert.True(t, open.Overlaps(&ProjectAssignmentRate{EffectiveFrom: from.AddDate(1, 0, 0)}))
+	assert.False(t, open.Overlaps(&ProjectAssignmentRate{EffectiveFrom: from.AddDate(0, -1, 0), EffectiveTo: &to}))
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ProjectGroup プロジェクトグループモデル
@@ -57,9 +59,9 @@ func (pgm *ProjectGroupMapping) BeforeCreate(tx *gorm.DB) error {
 // ProjectGroupWithProjects プロジェクト詳細付きのプロジェクトグループ
 type ProjectGroupWithProjects struct {
 	ProjectGroup
-	ProjectCount    int        `json:"project_count"`
-	TotalRevenue    float64    `json:"total_revenue"`
-	LastInvoiceDate *time.Time `json:"last_invoice_date"`
+	ProjectCount    int          `json:"project_count"`
+	TotalRevenue    money.Amount `json:"total_revenue"`
+	LastInvoiceDate *time.Time   `json:"last_invoice_date"`
 }
 
 // ProjectGroupSummary プロジェクトグループサマリー
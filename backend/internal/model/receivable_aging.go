@@ -0,0 +1,179 @@
+package model
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// AgingBucket 売掛金の年齢区分（支払期日からの経過日数）
+type AgingBucket string
+
+const (
+	// AgingBucketCurrent 支払期日前（期日当日を含む）
+	AgingBucketCurrent AgingBucket = "current"
+	// AgingBucket1To30 期日超過1〜30日
+	AgingBucket1To30 AgingBucket = "1_30"
+	// AgingBucket31To60 期日超過31〜60日
+	AgingBucket31To60 AgingBucket = "31_60"
+	// AgingBucket61To90 期日超過61〜90日
+	AgingBucket61To90 AgingBucket = "61_90"
+	// AgingBucketOver90 期日超過90日超
+	AgingBucketOver90 AgingBucket = "over_90"
+)
+
+// AgingBuckets 年齢区分（表示順）
+var AgingBuckets = []AgingBucket{
+	AgingBucketCurrent,
+	AgingBucket1To30,
+	AgingBucket31To60,
+	AgingBucket61To90,
+	AgingBucketOver90,
+}
+
+// Label 年齢区分の表示名
+func (b AgingBucket) Label() string {
+	switch b {
+	case AgingBucketCurrent:
+		return "期日前"
+	case AgingBucket1To30:
+		return "1〜30日"
+	case AgingBucket31To60:
+		return "31〜60日"
+	case AgingBucket61To90:
+		return "61〜90日"
+	case AgingBucketOver90:
+		return "90日超"
+	default:
+		return string(b)
+	}
+}
+
+// AgingBucketFor 支払期日からの超過日数に対応する年齢区分
+func AgingBucketFor(daysOverdue int) AgingBucket {
+	switch {
+	case daysOverdue <= 0:
+		return AgingBucketCurrent
+	case daysOverdue <= 30:
+		return AgingBucket1To30
+	case daysOverdue <= 60:
+		return AgingBucket31To60
+	case daysOverdue <= 90:
+		return AgingBucket61To90
+	default:
+		return AgingBucketOver90
+	}
+}
+
+// Receivable 売掛金（請求書ごとの未回収残高）
+type Receivable struct {
+	InvoiceID     string
+	InvoiceNumber string
+	ClientID      string
+	ClientName    string
+	DueDate       time.Time
+	Outstanding   money.Amount
+}
+
+// AgingAmounts 年齢区分ごとの売掛金残高
+type AgingAmounts struct {
+	Current      money.Amount `json:"current"`
+	Days1To30    money.Amount `json:"days_1_30"`
+	Days31To60   money.Amount `json:"days_31_60"`
+	Days61To90   money.Amount `json:"days_61_90"`
+	Over90       money.Amount `json:"over_90"`
+	Total        money.Amount `json:"total"`
+	InvoiceCount int          `json:"invoice_count"`
+}
+
+// Amount 年齢区分の残高
+func (a AgingAmounts) Amount(bucket AgingBucket) money.Amount {
+	switch bucket {
+	case AgingBucketCurrent:
+		return a.Current
+	case AgingBucket1To30:
+		return a.Days1To30
+	case AgingBucket31To60:
+		return a.Days31To60
+	case AgingBucket61To90:
+		return a.Days61To90
+	case AgingBucketOver90:
+		return a.Over90
+	default:
+		return money.Zero
+	}
+}
+
+// Overdue 支払期日を過ぎた残高
+func (a AgingAmounts) Overdue() money.Amount {
+	return a.Total.Sub(a.Current)
+}
+
+// add 残高を年齢区分に加算する
+func (a *AgingAmounts) add(bucket AgingBucket, amount money.Amount) {
+	switch bucket {
+	case AgingBucketCurrent:
+		a.Current = a.Current.Add(amount)
+	case AgingBucket1To30:
+		a.Days1To30 = a.Days1To30.Add(amount)
+	case AgingBucket31To60:
+		a.Days31To60 = a.Days31To60.Add(amount)
+	case AgingBucket61To90:
+		a.Days61To90 = a.Days61To90.Add(amount)
+	default:
+		a.Over90 = a.Over90.Add(amount)
+	}
+	a.Total = a.Total.Add(amount)
+	a.InvoiceCount++
+}
+
+// ClientAging 取引先別の年齢表の行
+type ClientAging struct {
+	ClientID   string `json:"client_id"`
+	ClientName string `json:"client_name"`
+	AgingAmounts
+}
+
+// BuildAgingReport 基準日時点の売掛金を年齢区分ごとに集計する
+// 取引先別の行は残高の大きい順
+func BuildAgingReport(receivables []Receivable, asOf time.Time) (AgingAmounts, []ClientAging) {
+	var total AgingAmounts
+	clients := make(map[string]*ClientAging)
+	for _, r := range receivables {
+		if r.Outstanding.Sign() <= 0 {
+			continue
+		}
+		bucket := AgingBucketFor(DaysOverdue(r.DueDate, asOf))
+		total.add(bucket, r.Outstanding)
+
+		row, ok := clients[r.ClientID]
+		if !ok {
+			row = &ClientAging{ClientID: r.ClientID, ClientName: r.ClientName}
+			clients[r.ClientID] = row
+		}
+		row.add(bucket, r.Outstanding)
+	}
+
+	rows := make([]ClientAging, 0, len(clients))
+	for _, row := range clients {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Total != rows[j].Total {
+			return rows[i].Total > rows[j].Total
+		}
+		return rows[i].ClientID < rows[j].ClientID
+	})
+	return total, rows
+}
+
+// CalculateDSO 売上債権回転日数（売掛金残高 ÷ 期間の請求額 × 期間の日数、小数点第1位まで）
+// 期間の請求がない場合は0
+func CalculateDSO(receivables, billed money.Amount, days int) float64 {
+	if billed.Sign() <= 0 || days <= 0 {
+		return 0
+	}
+	return math.Round(receivables.Float64()/billed.Float64()*float64(days)*10) / 10
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/duesk/monstera/pkg/money"
+)
+
+func TestAgingBucketFor(t *testing.T) {
+	assert.Equal(t, AgingBucketCurrent, AgingBucketFor(-5))
+	assert.Equal(t, AgingBucketCurrent, AgingBucketFor(0))
+	assert.Equal(t, AgingBucket1To30, AgingBucketFor(1))
+	assert.Equal(t, AgingBucket1To30, AgingBucketFor(30))
+	assert.Equal(t, AgingBucket31To60, AgingBucketFor(31))
+	assert.Equal(t, AgingBucket61To90, AgingBucketFor(90))
+	assert.Equal(t, AgingBucketOver90, AgingBucketFor(91))
+}
+
+func TestBuildAgingReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 15, 0, 0, 0, time.UTC)
+	receivables := []Receivable{
+		{InvoiceID: "i1", ClientID: "c1", DueDate: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), Outstanding: money.Yen(880000)},
+		{InvoiceID: "i2", ClientID: "c1", DueDate: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), Outstanding: money.Yen(330000)},
+		{InvoiceID: "i3", ClientID: "c2", DueDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Outstanding: money.Yen(1100000)},
+		{InvoiceID: "i4", ClientID: "c2", DueDate: time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), Outstanding: money.Zero},
+	}
+
+	total, clients := BuildAgingReport(receivables, asOf)
+	assert.Equal(t, money.Yen(880000), total.Current)
+	assert.Equal(t, money.Yen(330000), total.Days1To30)
+	assert.Equal(t, money.Yen(1100000), total.Over90)
+	assert.Equal(t, money.Yen(2310000), total.Total)
+	assert.Equal(t, money.Yen(1430000), total.Overdue())
+	assert.Equal(t, 3, total.InvoiceCount)
+
+	assert.Len(t, clients, 2)
+	assert.Equal(t, "c1", clients[0].ClientID)
+	assert.Equal(t, money.Yen(1210000), clients[0].Total)
+	assert.Equal(t, money.Yen(1100000), clients[1].Amount(AgingBucketOver90))
+	assert.Equal(t, 1, clients[1].InvoiceCount)
+}
+
+func TestCalculateDSO(t *testing.T) {
+	assert.Equal(t, 45.0, CalculateDSO(money.Yen(1500000), money.Yen(3000000), 90))
+	assert.Equal(t, 33.3, CalculateDSO(money.Yen(1000000), money.Yen(2700000), 90))
+	assert.Equal(t, float64(0), CalculateDSO(money.Yen(1000000), money.Zero, 90))
+}
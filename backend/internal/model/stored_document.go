@@ -0,0 +1,134 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/pkg/docstore"
+)
+
+// StoredDocumentType 保存文書の種類
+type StoredDocumentType string
+
+const (
+	// StoredDocumentTypeInvoice 発行した請求書の控え
+	StoredDocumentTypeInvoice StoredDocumentType = "invoice"
+	// StoredDocumentTypeReceipt 受領した領収書
+	StoredDocumentTypeReceipt StoredDocumentType = "receipt"
+	// StoredDocumentTypeOther その他の取引関係書類（見積書・契約書など）
+	StoredDocumentTypeOther StoredDocumentType = "other"
+)
+
+// 保存文書の元データの種類
+const (
+	// StoredDocumentSourceInvoice 請求書（invoices）
+	StoredDocumentSourceInvoice = "invoice"
+	// StoredDocumentSourceExpenseReceipt 経費の領収書（expense_receipts）
+	StoredDocumentSourceExpenseReceipt = "expense_receipt"
+)
+
+// documentFilingMonths 事業年度末から確定申告期限までの月数（保存期間はその翌日から数える）
+const documentFilingMonths = 2
+
+// StoredDocument 電子帳簿保存法に対応して保存した文書
+// 取引年月日・金額・取引先で検索でき、登録時に本体のSHA-256とハッシュチェーンを記録する。
+// 差し替えは同じGroupIDの新しい版として登録し、以前の版も残す
+type StoredDocument struct {
+	ID              string             `gorm:"type:varchar(36);primary_key" json:"id"`
+	GroupID         string             `gorm:"type:varchar(36);not null;index" json:"group_id"` // 版をまたいで同じ文書を表すID
+	Version         int                `gorm:"not null" json:"version"`
+	DocumentType    StoredDocumentType `gorm:"size:20;not null" json:"document_type"`
+	SourceType      *string            `gorm:"size:30" json:"source_type"`
+	SourceID        *string            `gorm:"type:varchar(36)" json:"source_id"`
+	TransactionDate time.Time          `gorm:"type:date;not null" json:"transaction_date"`
+	Amount          int64              `gorm:"not null" json:"amount"` // 取引金額（円）
+	Counterparty    string             `gorm:"size:200;not null" json:"counterparty"`
+	FileName        string             `gorm:"size:255;not null" json:"file_name"`
+	ContentType     string             `gorm:"size:100;not null" json:"content_type"`
+	FileSize        int64              `gorm:"not null" json:"file_size"`
+	StorageKey      string             `gorm:"size:500;not null;unique" json:"-"`
+	ContentHash     string             `gorm:"type:char(64);not null" json:"content_hash"`
+	ChainSeq        int64              `gorm:"not null;unique" json:"chain_seq"`
+	PrevChainHash   string             `gorm:"type:char(64);not null" json:"prev_chain_hash"`
+	ChainHash       string             `gorm:"type:char(64);not null" json:"chain_hash"`
+	RetentionUntil  time.Time          `gorm:"type:date;not null" json:"retention_until"`
+	SupersededByID  *string            `gorm:"type:varchar(36)" json:"superseded_by_id"`
+	SupersededAt    *time.Time         `json:"superseded_at"`
+	ReplaceReason   *string            `gorm:"type:text" json:"replace_reason"` // この版に差し替えた理由
+	DisposedAt      *time.Time         `json:"disposed_at"`                     // 保存期間経過後に本体を廃棄した日時
+	DisposedBy      *string            `gorm:"type:varchar(36)" json:"disposed_by"`
+	RegisteredBy    string             `gorm:"type:varchar(36);not null" json:"registered_by"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (StoredDocument) TableName() string {
+	return "stored_documents"
+}
+
+// BeforeCreate UUID生成
+func (d *StoredDocument) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.GroupID == "" {
+		d.GroupID = d.ID
+	}
+	return nil
+}
+
+// IsLatest 最新の版かチェック
+func (d *StoredDocument) IsLatest() bool {
+	return d.SupersededByID == nil
+}
+
+// IsDisposed 本体を廃棄済みかチェック
+func (d *StoredDocument) IsDisposed() bool {
+	return d.DisposedAt != nil
+}
+
+// CanDispose 保存期間が経過して廃棄できるかチェック
+func (d *StoredDocument) CanDispose(now time.Time) bool {
+	return now.After(d.RetentionUntil.AddDate(0, 0, 1))
+}
+
+// ChainEntry ハッシュチェーンに含める属性
+func (d *StoredDocument) ChainEntry() docstore.ChainEntry {
+	return docstore.ChainEntry{
+		Seq:             d.ChainSeq,
+		DocumentID:      d.ID,
+		Version:         d.Version,
+		DocumentType:    string(d.DocumentType),
+		TransactionDate: d.TransactionDate,
+		Amount:          d.Amount,
+		Counterparty:    d.Counterparty,
+		ContentHash:     d.ContentHash,
+	}
+}
+
+// DocumentChainHead ハッシュチェーンの末尾（1行のみ。登録時に行ロックして連番とハッシュを進める）
+type DocumentChainHead struct {
+	ID        int       `gorm:"primary_key" json:"id"`
+	LastSeq   int64     `gorm:"not null;default:0" json:"last_seq"`
+	LastHash  string    `gorm:"type:char(64);not null" json:"last_hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (DocumentChainHead) TableName() string {
+	return "document_chain_heads"
+}
+
+// DocumentRetentionUntil 取引年月日から法定保存期間の最終日を求める
+// 保存期間は取引が属する事業年度の確定申告期限（事業年度末の2か月後）の翌日から数える
+func DocumentRetentionUntil(transactionDate time.Time, fiscalYearStartMonth, years int) time.Time {
+	if fiscalYearStartMonth < 1 || fiscalYearStartMonth > 12 {
+		fiscalYearStartMonth = 1
+	}
+	fiscalYear := FiscalYearOf(transactionDate, fiscalYearStartMonth)
+	nextFiscalYearStart := time.Date(fiscalYear+1, time.Month(fiscalYearStartMonth), 1, 0, 0, 0, 0, transactionDate.Location())
+	filingDeadline := nextFiscalYearStart.AddDate(0, documentFilingMonths, -1)
+	return filingDeadline.AddDate(years, 0, 0)
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentRetentionUntil(t *testing.T) {
+	// 4月開始: 2026年3月の取引は2025年度（申告期限2026年5月31日）→ 7年後の5月31日まで
+	assert.Equal(t, time.Date(2033, 5, 31, 0, 0, 0, 0, time.UTC),
+		DocumentRetentionUntil(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), 4, 7))
+	assert.Equal(t, time.Date(2034, 5, 31, 0, 0, 0, 0, time.UTC),
+		DocumentRetentionUntil(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 4, 7))
+	// 1月開始（暦年）: 申告期限は翌年2月末
+	assert.Equal(t, time.Date(2037, 2, 28, 0, 0, 0, 0, time.UTC),
+		DocumentRetentionUntil(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC), 1, 10))
+}
+
+func TestStoredDocumentCanDispose(t *testing.T) {
+	document := &StoredDocument{RetentionUntil: time.Date(2033, 5, 31, 0, 0, 0, 0, time.UTC)}
+	assert.False(t, document.CanDispose(time.Date(2033, 5, 31, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, document.CanDispose(time.Date(2033, 6, 1, 0, 0, 1, 0, time.UTC)))
+}
@@ -27,6 +27,8 @@ type WeeklyReport struct {
 	ManagerComment           *string                `gorm:"type:text" json:"manager_comment"`
 	CommentedBy              *string                `gorm:"type:varchar(255)" json:"commented_by"`
 	CommentedAt              *time.Time             `json:"commented_at"`
+	// 代理承認（CommentedByの承認者が代理でOnBehalfOfIDの承認者の承認を行った場合に設定）
+	OnBehalfOfID             *string                `gorm:"type:varchar(255)" json:"on_behalf_of_id"`
 	DailyRecords             []*DailyRecord         `gorm:"foreignKey:WeeklyReportID" json:"daily_records,omitempty"`
 	CreatedAt                time.Time              `json:"created_at"`
 	UpdatedAt                time.Time              `json:"updated_at"`
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ApprovalDelegationFilter 代理承認の検索条件
+type ApprovalDelegationFilter struct {
+	DelegatorID     *string
+	DelegateID      *string
+	IncludeInactive bool // 取り消し済み・終了済みも含める
+}
+
+// ApprovalDelegationRepository 代理承認のリポジトリインターフェース
+type ApprovalDelegationRepository interface {
+	// 基本CRUD操作
+	Create(ctx context.Context, delegation *model.ApprovalDelegation) error
+	GetByID(ctx context.Context, id string) (*model.ApprovalDelegation, error)
+	Revoke(ctx context.Context, id string) error
+
+	// 検索
+	List(ctx context.Context, filter ApprovalDelegationFilter) ([]model.ApprovalDelegation, error)
+	GetActiveByDelegate(ctx context.Context, delegateID string, scope model.ApprovalDelegationScope, day time.Time) ([]model.ApprovalDelegation, error)
+	GetActiveByDelegator(ctx context.Context, delegatorID string, scope model.ApprovalDelegationScope, day time.Time) ([]model.ApprovalDelegation, error)
+
+	// 休暇申請からの自動登録
+	ExistsByLeaveRequestID(ctx context.Context, leaveRequestID string) (bool, error)
+}
+
+// ApprovalDelegationRepositoryImpl 代理承認のリポジトリ実装
+type ApprovalDelegationRepositoryImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewApprovalDelegationRepository 代理承認のリポジトリのインスタンスを生成
+func NewApprovalDelegationRepository(db *gorm.DB, logger *zap.Logger) ApprovalDelegationRepository {
+	return &ApprovalDelegationRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create 代理承認を作成
+func (r *ApprovalDelegationRepositoryImpl) Create(ctx context.Context, delegation *model.ApprovalDelegation) error {
+	if err := r.db.WithContext(ctx).Omit("Delegator", "Delegate").Create(delegation).Error; err != nil {
+		r.logger.Error("Failed to create approval delegation",
+			zap.Error(err),
+			zap.String("delegator_id", delegation.DelegatorID),
+			zap.String("delegate_id", delegation.DelegateID))
+		return err
+	}
+	return nil
+}
+
+// GetByID 代理承認を承認者・代理承認者とともに取得
+func (r *ApprovalDelegationRepositoryImpl) GetByID(ctx context.Context, id string) (*model.ApprovalDelegation, error) {
+	var delegation model.ApprovalDelegation
+	err := r.db.WithContext(ctx).
+		Preload("Delegator").
+		Preload("Delegate").
+		Where("id = ?", id).
+		First(&delegation).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get approval delegation",
+				zap.Error(err),
+				zap.String("delegation_id", id))
+		}
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// Revoke 代理承認を取り消す
+func (r *ApprovalDelegationRepositoryImpl) Revoke(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.ApprovalDelegation{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error("Failed to revoke approval delegation",
+			zap.Error(result.Error),
+			zap.String("delegation_id", id))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// List 代理承認を開始日の新しい順に取得
+func (r *ApprovalDelegationRepositoryImpl) List(ctx context.Context, filter ApprovalDelegationFilter) ([]model.ApprovalDelegation, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Delegator").
+		Preload("Delegate")
+	if filter.DelegatorID != nil {
+		query = query.Where("delegator_id = ?", *filter.DelegatorID)
+	}
+	if filter.DelegateID != nil {
+		query = query.Where("delegate_id = ?", *filter.DelegateID)
+	}
+	if !filter.IncludeInactive {
+		query = query.Where("revoked_at IS NULL AND end_date >= ?", time.Now().Format("2006-01-02"))
+	}
+
+	var delegations []model.ApprovalDelegation
+	if err := query.Order("start_date DESC, created_at DESC").Find(&delegations).Error; err != nil {
+		r.logger.Error("Failed to list approval delegations", zap.Error(err))
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// GetActiveByDelegate 代理承認者が指定日に有効な代理承認を取得
+func (r *ApprovalDelegationRepositoryImpl) GetActiveByDelegate(ctx context.Context, delegateID string, scope model.ApprovalDelegationScope, day time.Time) ([]model.ApprovalDelegation, error) {
+	return r.getActive(ctx, "delegate_id", delegateID, scope, day)
+}
+
+// GetActiveByDelegator 承認者が指定日に任せている有効な代理承認を取得
+func (r *ApprovalDelegationRepositoryImpl) GetActiveByDelegator(ctx context.Context, delegatorID string, scope model.ApprovalDelegationScope, day time.Time) ([]model.ApprovalDelegation, error) {
+	return r.getActive(ctx, "delegator_id", delegatorID, scope, day)
+}
+
+// getActive 指定日に有効で対象の承認を含む代理承認を取得
+func (r *ApprovalDelegationRepositoryImpl) getActive(ctx context.Context, column, userID string, scope model.ApprovalDelegationScope, day time.Time) ([]model.ApprovalDelegation, error) {
+	date := day.Format("2006-01-02")
+
+	var delegations []model.ApprovalDelegation
+	err := r.db.WithContext(ctx).
+		Where(column+" = ?", userID).
+		Where("scope IN ?", []model.ApprovalDelegationScope{scope, model.ApprovalDelegationScopeAll}).
+		Where("revoked_at IS NULL AND start_date <= ? AND end_date >= ?", date, date).
+		Order("created_at").
+		Find(&delegations).Error
+	if err != nil {
+		r.logger.Error("Failed to get active approval delegations",
+			zap.Error(err),
+			zap.String(column, userID),
+			zap.String("scope", string(scope)))
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// ExistsByLeaveRequestID 休暇申請から自動登録した代理承認が存在するかチェック
+func (r *ApprovalDelegationRepositoryImpl) ExistsByLeaveRequestID(ctx context.Context, leaveRequestID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.ApprovalDelegation{}).
+		Where("leave_request_id = ?", leaveRequestID).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to check approval delegation by leave request",
+			zap.Error(err),
+			zap.String("leave_request_id", leaveRequestID))
+		return false, err
+	}
+	return count > 0, nil
+}
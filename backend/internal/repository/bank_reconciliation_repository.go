@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// BankTransactionFilter 入出金明細の検索条件
+type BankTransactionFilter struct {
+	ImportID string
+	Statuses []model.BankTransactionStatus
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// BankReconciliationRepository 入金消込リポジトリインターフェース
+type BankReconciliationRepository interface {
+	// 取込履歴
+	GetImportByID(ctx context.Context, id string) (*model.BankStatementImport, error)
+	ListImports(ctx context.Context, limit, offset int) ([]*model.BankStatementImport, int64, error)
+
+	// 入出金明細
+	GetTransactionByID(ctx context.Context, id string) (*model.BankTransaction, error)
+	ListTransactions(ctx context.Context, filter BankTransactionFilter, limit, offset int) ([]*model.BankTransaction, int64, error)
+	FindExistingDedupeKeys(ctx context.Context, keys []string) (map[string]bool, error)
+
+	// 消込対象の請求書
+	GetInvoicesByIDs(ctx context.Context, ids []string) ([]*model.Invoice, error)
+}
+
+// bankReconciliationRepository 入金消込リポジトリ実装
+type bankReconciliationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewBankReconciliationRepository 入金消込リポジトリのコンストラクタ
+func NewBankReconciliationRepository(db *gorm.DB, logger *zap.Logger) BankReconciliationRepository {
+	return &bankReconciliationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetImportByID 取込履歴を取得（見つからない場合はnilを返す）
+func (r *bankReconciliationRepository) GetImportByID(ctx context.Context, id string) (*model.BankStatementImport, error) {
+	var imp model.BankStatementImport
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&imp).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get bank statement import", zap.Error(err), zap.String("id", id))
+		return nil, fmt.Errorf("取込履歴の取得に失敗しました: %w", err)
+	}
+	return &imp, nil
+}
+
+// ListImports 取込履歴を新しい順に取得
+func (r *bankReconciliationRepository) ListImports(ctx context.Context, limit, offset int) ([]*model.BankStatementImport, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.BankStatementImport{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count bank statement imports", zap.Error(err))
+		return nil, 0, fmt.Errorf("取込履歴の件数取得に失敗しました: %w", err)
+	}
+
+	var imports []*model.BankStatementImport
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&imports).Error; err != nil {
+		r.logger.Error("Failed to list bank statement imports", zap.Error(err))
+		return nil, 0, fmt.Errorf("取込履歴の取得に失敗しました: %w", err)
+	}
+	return imports, total, nil
+}
+
+// GetTransactionByID 入出金明細を割当と請求書を含めて取得（見つからない場合はnilを返す）
+func (r *bankReconciliationRepository) GetTransactionByID(ctx context.Context, id string) (*model.BankTransaction, error) {
+	var tx model.BankTransaction
+	err := r.db.WithContext(ctx).
+		Preload("Allocations.Invoice.Client").
+		Where("id = ?", id).
+		First(&tx).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get bank transaction", zap.Error(err), zap.String("id", id))
+		return nil, fmt.Errorf("入出金明細の取得に失敗しました: %w", err)
+	}
+	return &tx, nil
+}
+
+// ListTransactions 入出金明細を検索
+func (r *bankReconciliationRepository) ListTransactions(ctx context.Context, filter BankTransactionFilter, limit, offset int) ([]*model.BankTransaction, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.BankTransaction{})
+	if filter.ImportID != "" {
+		query = query.Where("import_id = ?", filter.ImportID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("transaction_date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("transaction_date <= ?", *filter.DateTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count bank transactions", zap.Error(err))
+		return nil, 0, fmt.Errorf("入出金明細の件数取得に失敗しました: %w", err)
+	}
+
+	var txs []*model.BankTransaction
+	err := query.
+		Preload("Allocations.Invoice.Client").
+		Order("transaction_date ASC, created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&txs).Error
+	if err != nil {
+		r.logger.Error("Failed to list bank transactions", zap.Error(err))
+		return nil, 0, fmt.Errorf("入出金明細の取得に失敗しました: %w", err)
+	}
+	return txs, total, nil
+}
+
+// FindExistingDedupeKeys 取込済みの重複防止キーを取得
+func (r *bankReconciliationRepository) FindExistingDedupeKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(keys) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	err := r.db.WithContext(ctx).
+		Model(&model.BankTransaction{}).
+		Where("dedupe_key IN ?", keys).
+		Pluck("dedupe_key", &found).Error
+	if err != nil {
+		r.logger.Error("Failed to find existing bank transactions", zap.Error(err))
+		return nil, fmt.Errorf("取込済み明細の確認に失敗しました: %w", err)
+	}
+	for _, key := range found {
+		existing[key] = true
+	}
+	return existing, nil
+}
+
+// GetInvoicesByIDs 請求書を取引先を含めて取得
+func (r *bankReconciliationRepository) GetInvoicesByIDs(ctx context.Context, ids []string) ([]*model.Invoice, error) {
+	var invoices []*model.Invoice
+	if len(ids) == 0 {
+		return invoices, nil
+	}
+	if err := r.db.WithContext(ctx).Preload("Client").Where("id IN ?", ids).Find(&invoices).Error; err != nil {
+		r.logger.Error("Failed to get invoices", zap.Error(err))
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	return invoices, nil
+}
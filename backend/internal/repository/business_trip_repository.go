@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// BusinessTripFilter 出張申請の検索条件
+type BusinessTripFilter struct {
+	UserID    *string
+	ProjectID *string
+	Statuses  []model.BusinessTripStatus
+	Page      int
+	Limit     int
+}
+
+// BusinessTripRepository 出張申請のリポジトリインターフェース
+type BusinessTripRepository interface {
+	// 基本CRUD操作
+	Create(ctx context.Context, trip *model.BusinessTrip) error
+	GetByID(ctx context.Context, id string) (*model.BusinessTrip, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*model.BusinessTrip, error)
+	Update(ctx context.Context, trip *model.BusinessTrip) error
+
+	// 検索
+	List(ctx context.Context, filter BusinessTripFilter) ([]model.BusinessTrip, int64, error)
+
+	// 精算する経費申請
+	GetExpensesByIDs(ctx context.Context, userID string, expenseIDs []string) ([]model.Expense, error)
+	ReplaceExpenses(ctx context.Context, tripID string, expenseIDs []string) error
+
+	// 日当の単価表
+	ListPerDiemRates(ctx context.Context) ([]model.BusinessTripPerDiemRate, error)
+	GetPerDiemRate(ctx context.Context, id string) (*model.BusinessTripPerDiemRate, error)
+	CreatePerDiemRate(ctx context.Context, rate *model.BusinessTripPerDiemRate) error
+	UpdatePerDiemRate(ctx context.Context, rate *model.BusinessTripPerDiemRate) error
+	DeletePerDiemRate(ctx context.Context, id string) error
+}
+
+// BusinessTripRepositoryImpl 出張申請のリポジトリ実装
+type BusinessTripRepositoryImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewBusinessTripRepository 出張申請のリポジトリのインスタンスを生成
+func NewBusinessTripRepository(db *gorm.DB, logger *zap.Logger) BusinessTripRepository {
+	return &BusinessTripRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create 出張申請を作成
+func (r *BusinessTripRepositoryImpl) Create(ctx context.Context, trip *model.BusinessTrip) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(trip).Error; err != nil {
+		r.logger.Error("Failed to create business trip",
+			zap.Error(err),
+			zap.String("user_id", trip.UserID))
+		return err
+	}
+	return nil
+}
+
+// GetByID 出張申請を申請者・案件・承認者・精算した経費申請とともに取得
+func (r *BusinessTripRepositoryImpl) GetByID(ctx context.Context, id string) (*model.BusinessTrip, error) {
+	var trip model.BusinessTrip
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Project").
+		Preload("Approver").
+		Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("expense_date ASC")
+		}).
+		Where("id = ?", id).
+		First(&trip).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get business trip",
+				zap.Error(err),
+				zap.String("business_trip_id", id))
+		}
+		return nil, err
+	}
+	return &trip, nil
+}
+
+// GetByIDForUpdate 出張申請を排他ロックして取得
+func (r *BusinessTripRepositoryImpl) GetByIDForUpdate(ctx context.Context, id string) (*model.BusinessTrip, error) {
+	var trip model.BusinessTrip
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&trip).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get business trip for update",
+				zap.Error(err),
+				zap.String("business_trip_id", id))
+		}
+		return nil, err
+	}
+	return &trip, nil
+}
+
+// Update 出張申請を更新
+func (r *BusinessTripRepositoryImpl) Update(ctx context.Context, trip *model.BusinessTrip) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(trip).Error; err != nil {
+		r.logger.Error("Failed to update business trip",
+			zap.Error(err),
+			zap.String("business_trip_id", trip.ID))
+		return err
+	}
+	return nil
+}
+
+// List 出張申請を開始日の新しい順に取得
+func (r *BusinessTripRepositoryImpl) List(ctx context.Context, filter BusinessTripFilter) ([]model.BusinessTrip, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.BusinessTrip{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count business trips", zap.Error(err))
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+		if filter.Page > 1 {
+			query = query.Offset((filter.Page - 1) * filter.Limit)
+		}
+	}
+
+	var trips []model.BusinessTrip
+	err := query.
+		Preload("User").
+		Preload("Project").
+		Order("start_date DESC, created_at DESC").
+		Find(&trips).Error
+	if err != nil {
+		r.logger.Error("Failed to list business trips", zap.Error(err))
+		return nil, 0, err
+	}
+	return trips, total, nil
+}
+
+// GetExpensesByIDs 申請者の経費申請を取得
+func (r *BusinessTripRepositoryImpl) GetExpensesByIDs(ctx context.Context, userID string, expenseIDs []string) ([]model.Expense, error) {
+	if len(expenseIDs) == 0 {
+		return nil, nil
+	}
+
+	var expenses []model.Expense
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND user_id = ?", expenseIDs, userID).
+		Find(&expenses).Error
+	if err != nil {
+		r.logger.Error("Failed to get expenses for business trip",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// ReplaceExpenses 出張申請で精算する経費申請を置き換える
+func (r *BusinessTripRepositoryImpl) ReplaceExpenses(ctx context.Context, tripID string, expenseIDs []string) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Expense{}).
+		Where("business_trip_id = ?", tripID).
+		Update("business_trip_id", nil).Error
+	if err != nil {
+		r.logger.Error("Failed to unlink expenses from business trip",
+			zap.Error(err),
+			zap.String("business_trip_id", tripID))
+		return err
+	}
+
+	if len(expenseIDs) == 0 {
+		return nil
+	}
+	err = r.db.WithContext(ctx).
+		Model(&model.Expense{}).
+		Where("id IN ?", expenseIDs).
+		Update("business_trip_id", tripID).Error
+	if err != nil {
+		r.logger.Error("Failed to link expenses to business trip",
+			zap.Error(err),
+			zap.String("business_trip_id", tripID),
+			zap.Int("count", len(expenseIDs)))
+		return err
+	}
+	return nil
+}
+
+// ListPerDiemRates 日当の単価表を出張の区分・適用開始日の順に取得
+func (r *BusinessTripRepositoryImpl) ListPerDiemRates(ctx context.Context) ([]model.BusinessTripPerDiemRate, error) {
+	var rates []model.BusinessTripPerDiemRate
+	if err := r.db.WithContext(ctx).Order("trip_type ASC, effective_from DESC").Find(&rates).Error; err != nil {
+		r.logger.Error("Failed to list business trip per diem rates", zap.Error(err))
+		return nil, err
+	}
+	return rates, nil
+}
+
+// GetPerDiemRate 日当の単価を取得
+func (r *BusinessTripRepositoryImpl) GetPerDiemRate(ctx context.Context, id string) (*model.BusinessTripPerDiemRate, error) {
+	var rate model.BusinessTripPerDiemRate
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&rate).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get business trip per diem rate",
+				zap.Error(err),
+				zap.String("per_diem_rate_id", id))
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// CreatePerDiemRate 日当の単価を作成
+func (r *BusinessTripRepositoryImpl) CreatePerDiemRate(ctx context.Context, rate *model.BusinessTripPerDiemRate) error {
+	if err := r.db.WithContext(ctx).Create(rate).Error; err != nil {
+		r.logger.Error("Failed to create business trip per diem rate",
+			zap.Error(err),
+			zap.String("trip_type", string(rate.TripType)))
+		return err
+	}
+	return nil
+}
+
+// UpdatePerDiemRate 日当の単価を更新
+func (r *BusinessTripRepositoryImpl) UpdatePerDiemRate(ctx context.Context, rate *model.BusinessTripPerDiemRate) error {
+	if err := r.db.WithContext(ctx).Save(rate).Error; err != nil {
+		r.logger.Error("Failed to update business trip per diem rate",
+			zap.Error(err),
+			zap.String("per_diem_rate_id", rate.ID))
+		return err
+	}
+	return nil
+}
+
+// DeletePerDiemRate 日当の単価を削除（精算済みの出張申請は適用した単価を保持している）
+func (r *BusinessTripRepositoryImpl) DeletePerDiemRate(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.BusinessTripPerDiemRate{})
+	if result.Error != nil {
+		r.logger.Error("Failed to delete business trip per diem rate",
+			zap.Error(result.Error),
+			zap.String("per_diem_rate_id", id))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// CashAdvanceRepository 仮払金のリポジトリインターフェース
+type CashAdvanceRepository interface {
+	// 基本CRUD操作
+	Create(ctx context.Context, advance *model.CashAdvance) error
+	GetByID(ctx context.Context, id string) (*model.CashAdvance, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*model.CashAdvance, error)
+	Update(ctx context.Context, advance *model.CashAdvance) error
+
+	// 検索
+	List(ctx context.Context, filter *dto.CashAdvanceFilterRequest) ([]model.CashAdvance, int64, error)
+	ListOutstanding(ctx context.Context) ([]model.CashAdvance, error)
+	ListOverdue(ctx context.Context, today time.Time) ([]model.CashAdvance, error)
+
+	// 精算する経費申請
+	GetExpensesByIDs(ctx context.Context, userID string, expenseIDs []string) ([]model.Expense, error)
+	GetExpenses(ctx context.Context, advanceID string) ([]model.Expense, error)
+	ReplaceExpenses(ctx context.Context, advanceID string, expenseIDs []string) error
+	MarkExpensesPaid(ctx context.Context, advanceID string, paidAt time.Time) error
+
+	// 督促
+	RecordReminder(ctx context.Context, id string, remindedAt time.Time) error
+}
+
+// CashAdvanceRepositoryImpl 仮払金のリポジトリ実装
+type CashAdvanceRepositoryImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCashAdvanceRepository 仮払金のリポジトリのインスタンスを生成
+func NewCashAdvanceRepository(db *gorm.DB, logger *zap.Logger) CashAdvanceRepository {
+	return &CashAdvanceRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create 仮払金を作成
+func (r *CashAdvanceRepositoryImpl) Create(ctx context.Context, advance *model.CashAdvance) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(advance).Error; err != nil {
+		r.logger.Error("Failed to create cash advance",
+			zap.Error(err),
+			zap.String("user_id", advance.UserID))
+		return err
+	}
+	return nil
+}
+
+// GetByID 仮払金を申請者・承認者・承認フロー・精算した経費申請とともに取得
+func (r *CashAdvanceRepositoryImpl) GetByID(ctx context.Context, id string) (*model.CashAdvance, error) {
+	var advance model.CashAdvance
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Approver").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("approval_order ASC")
+		}).
+		Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("expense_date ASC")
+		}).
+		Where("id = ?", id).
+		First(&advance).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get cash advance",
+				zap.Error(err),
+				zap.String("cash_advance_id", id))
+		}
+		return nil, err
+	}
+	return &advance, nil
+}
+
+// GetByIDForUpdate 仮払金を排他ロックして取得
+func (r *CashAdvanceRepositoryImpl) GetByIDForUpdate(ctx context.Context, id string) (*model.CashAdvance, error) {
+	var advance model.CashAdvance
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&advance).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get cash advance for update",
+				zap.Error(err),
+				zap.String("cash_advance_id", id))
+		}
+		return nil, err
+	}
+	return &advance, nil
+}
+
+// Update 仮払金を更新
+func (r *CashAdvanceRepositoryImpl) Update(ctx context.Context, advance *model.CashAdvance) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(advance).Error; err != nil {
+		r.logger.Error("Failed to update cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", advance.ID))
+		return err
+	}
+	return nil
+}
+
+// List 仮払金を申請の新しい順に取得
+func (r *CashAdvanceRepositoryImpl) List(ctx context.Context, filter *dto.CashAdvanceFilterRequest) ([]model.CashAdvance, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.CashAdvance{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count cash advances", zap.Error(err))
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+		if filter.Page > 1 {
+			query = query.Offset((filter.Page - 1) * filter.Limit)
+		}
+	}
+
+	var advances []model.CashAdvance
+	err := query.
+		Preload("User").
+		Order("created_at DESC").
+		Find(&advances).Error
+	if err != nil {
+		r.logger.Error("Failed to list cash advances", zap.Error(err))
+		return nil, 0, err
+	}
+	return advances, total, nil
+}
+
+// ListOutstanding 未精算の仮払金を申請者とともに取得
+func (r *CashAdvanceRepositoryImpl) ListOutstanding(ctx context.Context) ([]model.CashAdvance, error) {
+	var advances []model.CashAdvance
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("status IN ?", model.CashAdvanceOutstandingStatuses).
+		Order("user_id ASC, settlement_due_date ASC").
+		Find(&advances).Error
+	if err != nil {
+		r.logger.Error("Failed to list outstanding cash advances", zap.Error(err))
+		return nil, err
+	}
+	return advances, nil
+}
+
+// ListOverdue 精算期限を過ぎても精算を申請していない仮払金を取得
+func (r *CashAdvanceRepositoryImpl) ListOverdue(ctx context.Context, today time.Time) ([]model.CashAdvance, error) {
+	var advances []model.CashAdvance
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND settlement_due_date < ?", model.CashAdvanceStatusPaid, today.Format("2006-01-02")).
+		Order("settlement_due_date ASC").
+		Find(&advances).Error
+	if err != nil {
+		r.logger.Error("Failed to list overdue cash advances", zap.Error(err))
+		return nil, err
+	}
+	return advances, nil
+}
+
+// GetExpensesByIDs 申請者の経費申請を取得
+func (r *CashAdvanceRepositoryImpl) GetExpensesByIDs(ctx context.Context, userID string, expenseIDs []string) ([]model.Expense, error) {
+	if len(expenseIDs) == 0 {
+		return nil, nil
+	}
+
+	var expenses []model.Expense
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND user_id = ?", expenseIDs, userID).
+		Find(&expenses).Error
+	if err != nil {
+		r.logger.Error("Failed to get expenses for cash advance",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// GetExpenses 仮払金の精算に紐付けた経費申請を取得
+func (r *CashAdvanceRepositoryImpl) GetExpenses(ctx context.Context, advanceID string) ([]model.Expense, error) {
+	var expenses []model.Expense
+	if err := r.db.WithContext(ctx).Where("cash_advance_id = ?", advanceID).Find(&expenses).Error; err != nil {
+		r.logger.Error("Failed to get cash advance expenses",
+			zap.Error(err),
+			zap.String("cash_advance_id", advanceID))
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// ReplaceExpenses 仮払金の精算に紐付ける経費申請を置き換える
+func (r *CashAdvanceRepositoryImpl) ReplaceExpenses(ctx context.Context, advanceID string, expenseIDs []string) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Expense{}).
+		Where("cash_advance_id = ?", advanceID).
+		Update("cash_advance_id", nil).Error
+	if err != nil {
+		r.logger.Error("Failed to unlink expenses from cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", advanceID))
+		return err
+	}
+
+	if len(expenseIDs) == 0 {
+		return nil
+	}
+	err = r.db.WithContext(ctx).
+		Model(&model.Expense{}).
+		Where("id IN ?", expenseIDs).
+		Update("cash_advance_id", advanceID).Error
+	if err != nil {
+		r.logger.Error("Failed to link expenses to cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", advanceID),
+			zap.Int("count", len(expenseIDs)))
+		return err
+	}
+	return nil
+}
+
+// MarkExpensesPaid 仮払金で精算した承認済みの経費申請を支払済みにする
+func (r *CashAdvanceRepositoryImpl) MarkExpensesPaid(ctx context.Context, advanceID string, paidAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Expense{}).
+		Where("cash_advance_id = ? AND status IN ?", advanceID, model.ReimbursableExpenseStatuses).
+		Updates(map[string]interface{}{
+			"status":  model.ExpenseStatusPaid,
+			"paid_at": paidAt,
+			"version": gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to mark cash advance expenses as paid",
+			zap.Error(err),
+			zap.String("cash_advance_id", advanceID))
+		return err
+	}
+	return nil
+}
+
+// RecordReminder 精算期限超過の督促を記録
+func (r *CashAdvanceRepositoryImpl) RecordReminder(ctx context.Context, id string, remindedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.CashAdvance{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_reminded_at": remindedAt,
+			"reminder_count":   gorm.Expr("reminder_count + 1"),
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to record cash advance reminder",
+			zap.Error(err),
+			zap.String("cash_advance_id", id))
+		return err
+	}
+	return nil
+}
@@ -24,16 +24,21 @@ type ExpenseApprovalRepository interface {
 	GetByExpenseIDOrderByStep(ctx context.Context, expenseID string) ([]model.ExpenseApproval, error)
 	GetCurrentPendingApproval(ctx context.Context, expenseID string) (*model.ExpenseApproval, error)
 	GetPendingApprovals(ctx context.Context, expenseID string) ([]model.ExpenseApproval, error)
+	GetByCashAdvanceID(ctx context.Context, cashAdvanceID string) ([]model.ExpenseApproval, error)
 
 	// 承認者関連
 	GetByApproverID(ctx context.Context, approverID string) ([]model.ExpenseApproval, error)
 	GetPendingByApproverID(ctx context.Context, approverID string, filter *dto.ApprovalFilterRequest) ([]model.ExpenseApproval, int64, error)
+	GetPendingByApproverIDs(ctx context.Context, approverIDs []string, filter *dto.ApprovalFilterRequest) ([]model.ExpenseApproval, int64, error)
 	GetPendingByApproverIDWithLimit(ctx context.Context, approverID string, limit int) ([]model.ExpenseApproval, error)
 	CountPendingByApproverID(ctx context.Context, approverID string) (int64, error)
 
 	// 承認フロー管理
 	CreateApprovalFlow(ctx context.Context, expenseID string, amount int, settingRepo ExpenseApproverSettingRepository) error
+	CreateCashAdvanceApprovalFlow(ctx context.Context, cashAdvanceID string, amount int, settingRepo ExpenseApproverSettingRepository) error
+	DeleteByCashAdvanceID(ctx context.Context, cashAdvanceID string) error
 	UpdateApprovalStatus(ctx context.Context, approvalID string, status model.ApprovalStatus, comment string, approverID string) error
+	UpdateApprovalStatusOnBehalf(ctx context.Context, approvalID string, status model.ApprovalStatus, comment string, approverID string, delegateID string) error
 	GetNextPendingApproval(ctx context.Context, expenseID string) (*model.ExpenseApproval, error)
 	IsApprovalCompleted(ctx context.Context, expenseID string) (bool, error)
 	IsApprovalRejected(ctx context.Context, expenseID string) (bool, error)
@@ -50,6 +55,13 @@ type ExpenseApprovalRepository interface {
 	SetLogger(logger *zap.Logger)
 }
 
+// currentApprovalStepCondition 承認順序が前の承認待ちがない（現在の段階の）承認に絞り込む条件
+const currentApprovalStepCondition = `NOT EXISTS (
+	SELECT 1 FROM expense_approvals prev
+	WHERE (prev.expense_id = expense_approvals.expense_id OR prev.cash_advance_id = expense_approvals.cash_advance_id)
+	AND prev.status = 'pending'
+	AND prev.approval_order < expense_approvals.approval_order)`
+
 // ExpenseApprovalRepositoryImpl 経費承認履歴に関するデータアクセスの実装
 type ExpenseApprovalRepositoryImpl struct {
 	db     *gorm.DB
@@ -78,7 +90,8 @@ func (r *ExpenseApprovalRepositoryImpl) Create(ctx context.Context, approval *mo
 	if err := r.db.WithContext(ctx).Create(approval).Error; err != nil {
 		r.logger.Error("Failed to create expense approval",
 			zap.Error(err),
-			zap.String("expense_id", approval.ExpenseID),
+			zap.Stringp("expense_id", approval.ExpenseID),
+			zap.Stringp("cash_advance_id", approval.CashAdvanceID),
 			zap.String("approver_id", approval.ApproverID),
 			zap.String("approval_type", string(approval.ApprovalType)))
 		return err
@@ -86,7 +99,8 @@ func (r *ExpenseApprovalRepositoryImpl) Create(ctx context.Context, approval *mo
 
 	r.logger.Info("Expense approval created successfully",
 		zap.String("approval_id", approval.ID),
-		zap.String("expense_id", approval.ExpenseID),
+		zap.Stringp("expense_id", approval.ExpenseID),
+		zap.Stringp("cash_advance_id", approval.CashAdvanceID),
 		zap.String("approver_id", approval.ApproverID),
 		zap.String("approval_type", string(approval.ApprovalType)))
 	return nil
@@ -246,6 +260,25 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingApprovals(ctx context.Context,
 	return approvals, nil
 }
 
+// GetByCashAdvanceID 仮払金の承認履歴を承認順序順で取得
+func (r *ExpenseApprovalRepositoryImpl) GetByCashAdvanceID(ctx context.Context, cashAdvanceID string) ([]model.ExpenseApproval, error) {
+	var approvals []model.ExpenseApproval
+	err := r.db.WithContext(ctx).
+		Preload("Approver").
+		Where("cash_advance_id = ?", cashAdvanceID).
+		Order("approval_order ASC").
+		Find(&approvals).Error
+
+	if err != nil {
+		r.logger.Error("Failed to get expense approvals by cash advance ID",
+			zap.Error(err),
+			zap.String("cash_advance_id", cashAdvanceID))
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
 // ========================================
 // 承認者関連
 // ========================================
@@ -272,17 +305,25 @@ func (r *ExpenseApprovalRepositoryImpl) GetByApproverID(ctx context.Context, app
 
 // GetPendingByApproverID 承認者の承認待ち一覧を取得（フィルタ対応）
 func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Context, approverID string, filter *dto.ApprovalFilterRequest) ([]model.ExpenseApproval, int64, error) {
+	return r.GetPendingByApproverIDs(ctx, []string{approverID}, filter)
+}
+
+// GetPendingByApproverIDs 複数の承認者の承認待ち一覧を取得（代理承認者が代理で承認する分を含める場合に使用）
+func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverIDs(ctx context.Context, approverIDs []string, filter *dto.ApprovalFilterRequest) ([]model.ExpenseApproval, int64, error) {
 	query := r.db.WithContext(ctx).
 		Model(&model.ExpenseApproval{}).
-		Joins("JOIN expenses ON expense_approvals.expense_id = expenses.id").
-		Where("expense_approvals.approver_id = ? AND expense_approvals.status = ?", approverID, model.ApprovalStatusPending)
+		Joins("LEFT JOIN expenses ON expense_approvals.expense_id = expenses.id").
+		Joins("LEFT JOIN cash_advances ON expense_approvals.cash_advance_id = cash_advances.id").
+		Where("expense_approvals.approver_id IN ? AND expense_approvals.status = ?", approverIDs, model.ApprovalStatusPending).
+		Where("(expenses.id IS NOT NULL OR cash_advances.status = ?)", model.CashAdvanceStatusPending).
+		Where(currentApprovalStepCondition)
 
-	// フィルタ条件を適用
+	// フィルタ条件を適用（使用日・カテゴリの指定は経費申請のみが対象）
 	if filter.ApprovalType != nil {
 		query = query.Where("expense_approvals.approval_type = ?", *filter.ApprovalType)
 	}
 	if filter.UserID != nil {
-		query = query.Where("expenses.user_id = ?", *filter.UserID)
+		query = query.Where("COALESCE(expenses.user_id, cash_advances.user_id) = ?", *filter.UserID)
 	}
 	if filter.DateFrom != nil {
 		query = query.Where("expenses.expense_date >= ?", *filter.DateFrom)
@@ -291,17 +332,17 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Conte
 		query = query.Where("expenses.expense_date <= ?", *filter.DateTo)
 	}
 	if filter.AmountMin != nil {
-		query = query.Where("expenses.amount >= ?", *filter.AmountMin)
+		query = query.Where("COALESCE(expenses.amount, cash_advances.amount) >= ?", *filter.AmountMin)
 	}
 	if filter.AmountMax != nil {
-		query = query.Where("expenses.amount <= ?", *filter.AmountMax)
+		query = query.Where("COALESCE(expenses.amount, cash_advances.amount) <= ?", *filter.AmountMax)
 	}
 	if filter.CategoryID != nil {
 		query = query.Where("expenses.category_id = ?", *filter.CategoryID)
 	}
 	if filter.Keyword != nil && *filter.Keyword != "" {
 		searchTerm := "%" + *filter.Keyword + "%"
-		query = query.Where("(expenses.title LIKE ? OR expenses.description LIKE ?)", searchTerm, searchTerm)
+		query = query.Where("(expenses.title LIKE ? OR expenses.description LIKE ? OR cash_advances.purpose LIKE ?)", searchTerm, searchTerm, searchTerm)
 	}
 
 	// 総件数を取得
@@ -309,7 +350,7 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Conte
 	if err := query.Count(&total).Error; err != nil {
 		r.logger.Error("Failed to count pending approvals",
 			zap.Error(err),
-			zap.String("approver_id", approverID))
+			zap.Strings("approver_ids", approverIDs))
 		return nil, 0, err
 	}
 
@@ -320,7 +361,7 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Conte
 		if sortField == "expense_date" {
 			sortField = "expenses.expense_date"
 		} else if sortField == "amount" {
-			sortField = "expenses.amount"
+			sortField = "COALESCE(expenses.amount, cash_advances.amount)"
 		} else if sortField == "created_at" {
 			sortField = "expense_approvals.created_at"
 		}
@@ -337,6 +378,8 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Conte
 	err := query.
 		Preload("Expense").
 		Preload("Expense.User").
+		Preload("CashAdvance").
+		Preload("CashAdvance.User").
 		Preload("Approver").
 		Order(orderClause).
 		Offset((filter.Page - 1) * filter.Limit).
@@ -346,7 +389,7 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverID(ctx context.Conte
 	if err != nil {
 		r.logger.Error("Failed to get pending approvals by approver ID",
 			zap.Error(err),
-			zap.String("approver_id", approverID))
+			zap.Strings("approver_ids", approverIDs))
 		return nil, 0, err
 	}
 
@@ -360,6 +403,7 @@ func (r *ExpenseApprovalRepositoryImpl) GetPendingByApproverIDWithLimit(ctx cont
 		Preload("Expense").
 		Preload("Expense.User").
 		Where("approver_id = ? AND status = ?", approverID, model.ApprovalStatusPending).
+		Where(currentApprovalStepCondition).
 		Order("created_at ASC").
 		Limit(limit).
 		Find(&approvals).Error
@@ -381,6 +425,7 @@ func (r *ExpenseApprovalRepositoryImpl) CountPendingByApproverID(ctx context.Con
 	err := r.db.WithContext(ctx).
 		Model(&model.ExpenseApproval{}).
 		Where("approver_id = ? AND status = ?", approverID, model.ApprovalStatusPending).
+		Where(currentApprovalStepCondition).
 		Count(&count).Error
 
 	if err != nil {
@@ -399,12 +444,37 @@ func (r *ExpenseApprovalRepositoryImpl) CountPendingByApproverID(ctx context.Con
 
 // CreateApprovalFlow 承認フローを作成（金額に応じて必要な承認者を設定）
 func (r *ExpenseApprovalRepositoryImpl) CreateApprovalFlow(ctx context.Context, expenseID string, amount int, settingRepo ExpenseApproverSettingRepository) error {
+	return r.createApprovalFlow(ctx, model.ExpenseApproval{ExpenseID: &expenseID}, amount, settingRepo)
+}
+
+// CreateCashAdvanceApprovalFlow 仮払金の承認フローを作成（経費申請と同じく金額に応じて必要な承認者を設定）
+func (r *ExpenseApprovalRepositoryImpl) CreateCashAdvanceApprovalFlow(ctx context.Context, cashAdvanceID string, amount int, settingRepo ExpenseApproverSettingRepository) error {
+	return r.createApprovalFlow(ctx, model.ExpenseApproval{CashAdvanceID: &cashAdvanceID}, amount, settingRepo)
+}
+
+// DeleteByCashAdvanceID 仮払金の承認履歴を削除
+func (r *ExpenseApprovalRepositoryImpl) DeleteByCashAdvanceID(ctx context.Context, cashAdvanceID string) error {
+	err := r.db.WithContext(ctx).
+		Where("cash_advance_id = ?", cashAdvanceID).
+		Delete(&model.ExpenseApproval{}).Error
+	if err != nil {
+		r.logger.Error("Failed to delete expense approvals by cash advance ID",
+			zap.Error(err),
+			zap.String("cash_advance_id", cashAdvanceID))
+		return err
+	}
+	return nil
+}
+
+// createApprovalFlow 承認対象（経費申請または仮払金）の承認フローを作成
+func (r *ExpenseApprovalRepositoryImpl) createApprovalFlow(ctx context.Context, target model.ExpenseApproval, amount int, settingRepo ExpenseApproverSettingRepository) error {
 	// 承認フローの設計:
 	// 5万円未満: 管理部のみ
 	// 5万円以上: 管理部 → 役員
 
 	r.logger.Info("Starting CreateApprovalFlow",
-		zap.String("expense_id", expenseID),
+		zap.Stringp("expense_id", target.ExpenseID),
+		zap.Stringp("cash_advance_id", target.CashAdvanceID),
 		zap.Int("amount", amount))
 
 	var approvals []model.ExpenseApproval
@@ -428,13 +498,11 @@ func (r *ExpenseApprovalRepositoryImpl) CreateApprovalFlow(ctx context.Context,
 	// 管理部承認を作成（優先順位順）
 	approvalOrder := 1
 	for _, setting := range managerSettings {
-		approval := model.ExpenseApproval{
-			ExpenseID:     expenseID,
-			ApproverID:    setting.ApproverID,
-			ApprovalType:  model.ApprovalTypeManager,
-			ApprovalOrder: approvalOrder,
-			Status:        model.ApprovalStatusPending,
-		}
+		approval := target
+		approval.ApproverID = setting.ApproverID
+		approval.ApprovalType = model.ApprovalTypeManager
+		approval.ApprovalOrder = approvalOrder
+		approval.Status = model.ApprovalStatusPending
 		approvals = append(approvals, approval)
 		approvalOrder++
 	}
@@ -454,13 +522,11 @@ func (r *ExpenseApprovalRepositoryImpl) CreateApprovalFlow(ctx context.Context,
 
 		// 役員承認を作成（優先順位順）
 		for _, setting := range executiveSettings {
-			approval := model.ExpenseApproval{
-				ExpenseID:     expenseID,
-				ApproverID:    setting.ApproverID,
-				ApprovalType:  model.ApprovalTypeExecutive,
-				ApprovalOrder: approvalOrder,
-				Status:        model.ApprovalStatusPending,
-			}
+			approval := target
+			approval.ApproverID = setting.ApproverID
+			approval.ApprovalType = model.ApprovalTypeExecutive
+			approval.ApprovalOrder = approvalOrder
+			approval.Status = model.ApprovalStatusPending
 			approvals = append(approvals, approval)
 			approvalOrder++
 		}
@@ -470,13 +536,15 @@ func (r *ExpenseApprovalRepositoryImpl) CreateApprovalFlow(ctx context.Context,
 	if err := r.CreateBatch(ctx, approvals); err != nil {
 		r.logger.Error("Failed to create approval flow",
 			zap.Error(err),
-			zap.String("expense_id", expenseID),
+			zap.Stringp("expense_id", target.ExpenseID),
+			zap.Stringp("cash_advance_id", target.CashAdvanceID),
 			zap.Int("amount", amount))
 		return err
 	}
 
 	r.logger.Info("Approval flow created successfully",
-		zap.String("expense_id", expenseID),
+		zap.Stringp("expense_id", target.ExpenseID),
+		zap.Stringp("cash_advance_id", target.CashAdvanceID),
 		zap.Int("amount", amount),
 		zap.Int("approval_steps", len(approvals)))
 	return nil
@@ -518,6 +586,46 @@ func (r *ExpenseApprovalRepositoryImpl) UpdateApprovalStatus(ctx context.Context
 	return nil
 }
 
+// UpdateApprovalStatusOnBehalf 代理承認者が承認者の代理で承認ステータスを更新
+// 承認者を代理承認者に置き換え、本来の承認者をOnBehalfOfIDに記録する
+func (r *ExpenseApprovalRepositoryImpl) UpdateApprovalStatusOnBehalf(ctx context.Context, approvalID string, status model.ApprovalStatus, comment string, approverID string, delegateID string) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.ExpenseApproval{}).
+		Where("id = ? AND approver_id = ? AND status = ?", approvalID, approverID, model.ApprovalStatusPending).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"comment":         comment,
+			"approved_at":     time.Now(),
+			"approver_id":     delegateID,
+			"on_behalf_of_id": approverID,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to update approval status on behalf",
+			zap.Error(result.Error),
+			zap.String("approval_id", approvalID),
+			zap.String("approver_id", approverID),
+			zap.String("delegate_id", delegateID),
+			zap.String("status", string(status)))
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Warn("No rows affected when updating approval status on behalf - possible concurrent modification",
+			zap.String("approval_id", approvalID),
+			zap.String("approver_id", approverID),
+			zap.String("delegate_id", delegateID))
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Info("Approval status updated on behalf of approver",
+		zap.String("approval_id", approvalID),
+		zap.String("approver_id", approverID),
+		zap.String("delegate_id", delegateID),
+		zap.String("status", string(status)))
+	return nil
+}
+
 // GetNextPendingApproval 次の承認待ち承認者を取得
 func (r *ExpenseApprovalRepositoryImpl) GetNextPendingApproval(ctx context.Context, expenseID string) (*model.ExpenseApproval, error) {
 	return r.GetCurrentPendingApproval(ctx, expenseID)
@@ -609,12 +717,13 @@ func (r *ExpenseApprovalRepositoryImpl) GetApprovalStatistics(ctx context.Contex
 			COUNT(CASE WHEN ea.status = 'approved' THEN 1 END) as approved_count,
 			COUNT(CASE WHEN ea.status = 'rejected' THEN 1 END) as rejected_count,
 			COUNT(CASE WHEN ea.status = 'pending' THEN 1 END) as pending_count,
-			COALESCE(SUM(e.amount), 0) as total_amount,
-			COALESCE(SUM(CASE WHEN ea.status = 'approved' THEN e.amount ELSE 0 END), 0) as approved_amount,
-			COALESCE(SUM(CASE WHEN ea.status = 'rejected' THEN e.amount ELSE 0 END), 0) as rejected_amount,
+			COALESCE(SUM(COALESCE(e.amount, ca.amount)), 0) as total_amount,
+			COALESCE(SUM(CASE WHEN ea.status = 'approved' THEN COALESCE(e.amount, ca.amount) ELSE 0 END), 0) as approved_amount,
+			COALESCE(SUM(CASE WHEN ea.status = 'rejected' THEN COALESCE(e.amount, ca.amount) ELSE 0 END), 0) as rejected_amount,
 			COALESCE(AVG(CASE WHEN ea.approved_at IS NOT NULL THEN TIMESTAMPDIFF(HOUR, ea.created_at, ea.approved_at) END), 0) as average_process_time
 		`).
 		Joins("LEFT JOIN expenses e ON ea.expense_id = e.id").
+		Joins("LEFT JOIN cash_advances ca ON ea.cash_advance_id = ca.id").
 		Where("ea.approver_id = ? AND ea.created_at BETWEEN ? AND ?", approverID, fromDate, toDate).
 		Scan(&result).Error
 
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ExpenseApprovalRuleRepository 経費申請の承認ルールのリポジトリインターフェース
+type ExpenseApprovalRuleRepository interface {
+	// 基本CRUD操作
+	Create(ctx context.Context, rule *model.ExpenseApprovalRule) error
+	GetByID(ctx context.Context, id string) (*model.ExpenseApprovalRule, error)
+	Update(ctx context.Context, rule *model.ExpenseApprovalRule) error
+	Delete(ctx context.Context, id string) error
+
+	// 検索
+	List(ctx context.Context, includeInactive bool) ([]model.ExpenseApprovalRule, error)
+	GetActiveRules(ctx context.Context) ([]model.ExpenseApprovalRule, error)
+}
+
+// ExpenseApprovalRuleRepositoryImpl 経費申請の承認ルールのリポジトリ実装
+type ExpenseApprovalRuleRepositoryImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewExpenseApprovalRuleRepository 経費申請の承認ルールのリポジトリのインスタンスを生成
+func NewExpenseApprovalRuleRepository(db *gorm.DB, logger *zap.Logger) ExpenseApprovalRuleRepository {
+	return &ExpenseApprovalRuleRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create 承認ルールを段階とともに作成
+func (r *ExpenseApprovalRuleRepositoryImpl) Create(ctx context.Context, rule *model.ExpenseApprovalRule) error {
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		r.logger.Error("Failed to create expense approval rule",
+			zap.Error(err),
+			zap.String("name", rule.Name))
+		return err
+	}
+	return nil
+}
+
+// GetByID 承認ルールを段階とともに取得
+func (r *ExpenseApprovalRuleRepositoryImpl) GetByID(ctx context.Context, id string) (*model.ExpenseApprovalRule, error) {
+	var rule model.ExpenseApprovalRule
+	err := r.db.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("step_order")
+		}).
+		Where("id = ?", id).
+		First(&rule).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to get expense approval rule",
+				zap.Error(err),
+				zap.String("rule_id", id))
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update 承認ルールを更新し、段階を置き換える
+// 複数の更新を行うためトランザクション内で呼び出すこと
+func (r *ExpenseApprovalRuleRepositoryImpl) Update(ctx context.Context, rule *model.ExpenseApprovalRule) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Omit("Steps").Save(rule).Error; err != nil {
+		r.logger.Error("Failed to update expense approval rule",
+			zap.Error(err),
+			zap.String("rule_id", rule.ID))
+		return err
+	}
+	if err := db.Where("rule_id = ?", rule.ID).Delete(&model.ExpenseApprovalRuleStep{}).Error; err != nil {
+		r.logger.Error("Failed to delete expense approval rule steps",
+			zap.Error(err),
+			zap.String("rule_id", rule.ID))
+		return err
+	}
+	if len(rule.Steps) == 0 {
+		return nil
+	}
+	for i := range rule.Steps {
+		rule.Steps[i].ID = ""
+		rule.Steps[i].RuleID = rule.ID
+	}
+	if err := db.Create(&rule.Steps).Error; err != nil {
+		r.logger.Error("Failed to create expense approval rule steps",
+			zap.Error(err),
+			zap.String("rule_id", rule.ID))
+		return err
+	}
+	return nil
+}
+
+// Delete 承認ルールを削除（論理削除。適用済みの経費申請からは引き続き参照できる）
+func (r *ExpenseApprovalRuleRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.ExpenseApprovalRule{})
+	if result.Error != nil {
+		r.logger.Error("Failed to delete expense approval rule",
+			zap.Error(result.Error),
+			zap.String("rule_id", id))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// List 承認ルールを優先度順に取得
+func (r *ExpenseApprovalRuleRepositoryImpl) List(ctx context.Context, includeInactive bool) ([]model.ExpenseApprovalRule, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("step_order")
+		})
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []model.ExpenseApprovalRule
+	if err := query.Order("priority, created_at").Find(&rules).Error; err != nil {
+		r.logger.Error("Failed to list expense approval rules", zap.Error(err))
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetActiveRules 有効な承認ルールを優先度順に取得
+func (r *ExpenseApprovalRuleRepositoryImpl) GetActiveRules(ctx context.Context) ([]model.ExpenseApprovalRule, error) {
+	return r.List(ctx, false)
+}
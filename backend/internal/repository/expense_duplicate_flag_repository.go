@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ExpenseDuplicateFlagRepository 経費申請の重複フラグのリポジトリインターフェース
+type ExpenseDuplicateFlagRepository interface {
+	// 検出結果の登録
+	ReplaceUnresolved(ctx context.Context, expenseID string, flags []model.ExpenseDuplicateFlag) error
+
+	// 取得
+	GetByExpenseID(ctx context.Context, expenseID string) ([]model.ExpenseDuplicateFlag, error)
+	CountUnresolved(ctx context.Context, expenseID string) (int64, error)
+
+	// 承認者による確認
+	OverrideUnresolved(ctx context.Context, expenseID, userID, reason string) error
+
+	// 重複の可能性がある経費申請の検索
+	FindSimilarExpenses(ctx context.Context, expense *model.Expense) ([]model.Expense, error)
+}
+
+// ExpenseDuplicateFlagRepositoryImpl 経費申請の重複フラグのリポジトリ実装
+type ExpenseDuplicateFlagRepositoryImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewExpenseDuplicateFlagRepository 経費申請の重複フラグのリポジトリのインスタンスを生成
+func NewExpenseDuplicateFlagRepository(db *gorm.DB, logger *zap.Logger) ExpenseDuplicateFlagRepository {
+	return &ExpenseDuplicateFlagRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ReplaceUnresolved 未確認の重複フラグを検出結果で置き換える（承認者が確認済みのフラグは残す）
+func (r *ExpenseDuplicateFlagRepositoryImpl) ReplaceUnresolved(ctx context.Context, expenseID string, flags []model.ExpenseDuplicateFlag) error {
+	err := r.db.WithContext(ctx).
+		Where("expense_id = ? AND overridden_at IS NULL", expenseID).
+		Delete(&model.ExpenseDuplicateFlag{}).Error
+	if err != nil {
+		r.logger.Error("Failed to delete unresolved expense duplicate flags",
+			zap.Error(err),
+			zap.String("expense_id", expenseID))
+		return err
+	}
+
+	if len(flags) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Omit("DuplicateExpense").Create(&flags).Error; err != nil {
+		r.logger.Error("Failed to create expense duplicate flags",
+			zap.Error(err),
+			zap.String("expense_id", expenseID),
+			zap.Int("count", len(flags)))
+		return err
+	}
+	return nil
+}
+
+// GetByExpenseID 経費申請の重複フラグを重複の可能性がある経費申請とともに取得
+func (r *ExpenseDuplicateFlagRepositoryImpl) GetByExpenseID(ctx context.Context, expenseID string) ([]model.ExpenseDuplicateFlag, error) {
+	var flags []model.ExpenseDuplicateFlag
+	err := r.db.WithContext(ctx).
+		Preload("DuplicateExpense").
+		Where("expense_id = ?", expenseID).
+		Order("detected_at ASC").
+		Find(&flags).Error
+	if err != nil {
+		r.logger.Error("Failed to get expense duplicate flags",
+			zap.Error(err),
+			zap.String("expense_id", expenseID))
+		return nil, err
+	}
+	return flags, nil
+}
+
+// CountUnresolved 承認者が未確認の重複フラグの件数を取得
+func (r *ExpenseDuplicateFlagRepositoryImpl) CountUnresolved(ctx context.Context, expenseID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.ExpenseDuplicateFlag{}).
+		Where("expense_id = ? AND overridden_at IS NULL", expenseID).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count unresolved expense duplicate flags",
+			zap.Error(err),
+			zap.String("expense_id", expenseID))
+		return 0, err
+	}
+	return count, nil
+}
+
+// OverrideUnresolved 未確認の重複フラグに承認者の確認理由を記録
+func (r *ExpenseDuplicateFlagRepositoryImpl) OverrideUnresolved(ctx context.Context, expenseID, userID, reason string) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.ExpenseDuplicateFlag{}).
+		Where("expense_id = ? AND overridden_at IS NULL", expenseID).
+		Updates(map[string]interface{}{
+			"override_reason": reason,
+			"overridden_by":   userID,
+			"overridden_at":   time.Now(),
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to override expense duplicate flags",
+			zap.Error(err),
+			zap.String("expense_id", expenseID),
+			zap.String("user_id", userID))
+		return err
+	}
+	return nil
+}
+
+// FindSimilarExpenses 同じ申請者・金額・使用日・カテゴリの別の経費申請を取得
+// 下書き・却下・取消・期限切れの経費申請は除く
+func (r *ExpenseDuplicateFlagRepositoryImpl) FindSimilarExpenses(ctx context.Context, expense *model.Expense) ([]model.Expense, error) {
+	var expenses []model.Expense
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND amount = ? AND category_id = ?", expense.UserID, expense.Amount, expense.CategoryID).
+		Where("DATE(expense_date) = ?", expense.ExpenseDate.Format("2006-01-02")).
+		Where("id <> ?", expense.ID).
+		Where("status NOT IN ?", model.DuplicateCheckExcludedStatuses).
+		Find(&expenses).Error
+	if err != nil {
+		r.logger.Error("Failed to find similar expenses",
+			zap.Error(err),
+			zap.String("expense_id", expense.ID))
+		return nil, err
+	}
+	return expenses, nil
+}
@@ -23,6 +23,10 @@ type ExpenseReceiptRepository interface {
 	// 表示順序関連
 	UpdateDisplayOrder(ctx context.Context, id string, displayOrder int) error
 	GetMaxDisplayOrder(ctx context.Context, expenseID string) (int, error)
+
+	// 重複検出
+	FindByContentHashes(ctx context.Context, contentHashes []string, excludeExpenseID string) ([]*model.ExpenseReceipt, error)
+	FindWithPerceptualHashByUserID(ctx context.Context, userID string, excludeExpenseID string) ([]*model.ExpenseReceipt, error)
 }
 
 // expenseReceiptRepository 経費領収書リポジトリの実装
@@ -200,3 +204,48 @@ func (r *expenseReceiptRepository) GetMaxDisplayOrder(ctx context.Context, expen
 
 	return maxOrder, nil
 }
+
+// FindByContentHashes ファイル内容のハッシュが一致する他の経費申請の領収書を取得
+// 下書き・却下・取消・期限切れの経費申請の領収書は除く
+func (r *expenseReceiptRepository) FindByContentHashes(ctx context.Context, contentHashes []string, excludeExpenseID string) ([]*model.ExpenseReceipt, error) {
+	if len(contentHashes) == 0 {
+		return nil, nil
+	}
+
+	var receipts []*model.ExpenseReceipt
+	err := r.db.WithContext(ctx).
+		Joins("JOIN expenses ON expenses.id = expense_receipts.expense_id").
+		Where("expense_receipts.content_hash IN ?", contentHashes).
+		Where("expense_receipts.expense_id <> ?", excludeExpenseID).
+		Where("expenses.status NOT IN ? AND expenses.deleted_at IS NULL", model.DuplicateCheckExcludedStatuses).
+		Find(&receipts).Error
+	if err != nil {
+		r.logger.Error("Failed to find expense receipts by content hashes",
+			zap.Error(err),
+			zap.String("exclude_expense_id", excludeExpenseID),
+		)
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// FindWithPerceptualHashByUserID ユーザーの他の経費申請の画像の領収書を取得（知覚ハッシュの比較用）
+// 下書き・却下・取消・期限切れの経費申請の領収書は除く
+func (r *expenseReceiptRepository) FindWithPerceptualHashByUserID(ctx context.Context, userID string, excludeExpenseID string) ([]*model.ExpenseReceipt, error) {
+	var receipts []*model.ExpenseReceipt
+	err := r.db.WithContext(ctx).
+		Joins("JOIN expenses ON expenses.id = expense_receipts.expense_id").
+		Where("expenses.user_id = ?", userID).
+		Where("expense_receipts.expense_id <> ?", excludeExpenseID).
+		Where("expense_receipts.perceptual_hash IS NOT NULL").
+		Where("expenses.status NOT IN ? AND expenses.deleted_at IS NULL", model.DuplicateCheckExcludedStatuses).
+		Find(&receipts).Error
+	if err != nil {
+		r.logger.Error("Failed to find expense receipts with perceptual hash",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, err
+	}
+	return receipts, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/model"
+)
+
+// FreeeSettingsRepository freee設定リポジトリインターフェース
+type FreeeSettingsRepository interface {
+	GetByUserID(ctx context.Context, userID string) (*model.FreeeSettings, error)
+	Save(ctx context.Context, settings *model.FreeeSettings) error
+	UpdateTokens(ctx context.Context, userID, accessToken, refreshToken string, expiresAt time.Time) error
+	UpdateCompany(ctx context.Context, userID string, companyID int, companyName string) error
+	UpdateSyncStatus(ctx context.Context, settings *model.FreeeSettings) error
+	DeleteByUserID(ctx context.Context, userID string) error
+	ListAutoSyncEnabled(ctx context.Context) ([]*model.FreeeSettings, error)
+}
+
+// freeeSettingsRepository freee設定リポジトリ実装
+type freeeSettingsRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewFreeeSettingsRepository freee設定リポジトリのコンストラクタ
+func NewFreeeSettingsRepository(db *gorm.DB, logger *zap.Logger) FreeeSettingsRepository {
+	return &freeeSettingsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByUserID ユーザーIDでfreee設定を取得（未設定の場合はnilを返す）
+func (r *freeeSettingsRepository) GetByUserID(ctx context.Context, userID string) (*model.FreeeSettings, error) {
+	var settings model.FreeeSettings
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&settings).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get freee settings", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("freee設定の取得に失敗しました: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// Save freee設定を保存（ユーザーごとに1件）
+func (r *freeeSettingsRepository) Save(ctx context.Context, settings *model.FreeeSettings) error {
+	if err := r.db.WithContext(ctx).Save(settings).Error; err != nil {
+		r.logger.Error("Failed to save freee settings", zap.Error(err), zap.String("user_id", settings.UserID))
+		return fmt.Errorf("freee設定の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// UpdateTokens 暗号化済みのトークンと有効期限を更新
+func (r *freeeSettingsRepository) UpdateTokens(ctx context.Context, userID, accessToken, refreshToken string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.FreeeSettings{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"access_token":     accessToken,
+			"refresh_token":    refreshToken,
+			"token_expires_at": expiresAt,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to update freee tokens", zap.Error(result.Error), zap.String("user_id", userID))
+		return fmt.Errorf("freeeトークンの更新に失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("freee設定が見つかりません")
+	}
+	return nil
+}
+
+// UpdateCompany 連携する事業所を更新
+func (r *freeeSettingsRepository) UpdateCompany(ctx context.Context, userID string, companyID int, companyName string) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.FreeeSettings{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"company_id":   companyID,
+			"company_name": companyName,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("Failed to update freee company", zap.Error(result.Error), zap.String("user_id", userID))
+		return fmt.Errorf("freee事業所の更新に失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("freee設定が見つかりません")
+	}
+	return nil
+}
+
+// UpdateSyncStatus 同期ステータスを更新
+func (r *freeeSettingsRepository) UpdateSyncStatus(ctx context.Context, settings *model.FreeeSettings) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.FreeeSettings{}).
+		Where("user_id = ?", settings.UserID).
+		Updates(map[string]interface{}{
+			"last_sync_at":     settings.LastSyncAt,
+			"last_sync_status": settings.LastSyncStatus,
+			"last_sync_error":  settings.LastSyncError,
+			"sync_retry_count": settings.SyncRetryCount,
+		}).Error
+
+	if err != nil {
+		r.logger.Error("Failed to update freee sync status", zap.Error(err), zap.String("user_id", settings.UserID))
+		return fmt.Errorf("freee同期ステータスの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUserID freee設定を削除
+// 再連携時に同じユーザーで作成し直せるよう物理削除する
+func (r *freeeSettingsRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&model.FreeeSettings{}).Error
+
+	if err != nil {
+		r.logger.Error("Failed to delete freee settings", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("freee設定の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListAutoSyncEnabled 自動同期が有効なfreee設定を取得
+func (r *freeeSettingsRepository) ListAutoSyncEnabled(ctx context.Context) ([]*model.FreeeSettings, error) {
+	var settings []*model.FreeeSettings
+	err := r.db.WithContext(ctx).
+		Where("auto_sync_enabled = ?", true).
+		Find(&settings).Error
+
+	if err != nil {
+		r.logger.Error("Failed to list auto sync freee settings", zap.Error(err))
+		return nil, fmt.Errorf("freee設定一覧の取得に失敗しました: %w", err)
+	}
+	return settings, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/duesk/monstera/internal/common/repository"
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -34,14 +35,17 @@ type InvoiceRepository interface {
 
 // InvoiceSummary 請求書サマリ
 type InvoiceSummary struct {
-	TotalAmount   float64
-	PaidAmount    float64
-	UnpaidAmount  float64
-	OverdueAmount float64
+	TotalAmount   money.Amount
+	PaidAmount    money.Amount
+	UnpaidAmount  money.Amount
+	OverdueAmount money.Amount
 	DraftCount    int
 	SentCount     int
 	PaidCount     int
 	OverdueCount  int
+
+	CreditNoteAmount money.Amount // 赤伝の合計（マイナス）
+	CreditNoteCount  int
 }
 
 // BillingStats 請求統計
@@ -147,6 +151,7 @@ func (r *invoiceRepository) FindOverdue(ctx context.Context) ([]*model.Invoice,
 }
 
 // GetSummary 請求書サマリを取得
+// 金額は発行済みの請求書を対象とし、赤伝で取り消した請求書は赤伝のマイナス金額と相殺して集計する
 func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, dateFrom, dateTo *time.Time) (*InvoiceSummary, error) {
 	summary := &InvoiceSummary{}
 
@@ -165,12 +170,21 @@ func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, da
 		query = query.Where("invoice_date <= ?", *dateTo)
 	}
 
+	// 集計ごとに条件が積み重ならないよう、共通条件を確定させてから使い回す
+	query = query.Session(&gorm.Session{})
+
+	// 計上対象（下書きと、赤伝を伴わないキャンセルを除く）
+	booked := query.Where("status <> ?", model.InvoiceStatusDraft).
+		Where("status <> ? OR EXISTS (SELECT 1 FROM invoices cn WHERE cn.original_invoice_id = invoices.id AND cn.invoice_type = ? AND cn.deleted_at IS NULL)",
+			model.InvoiceStatusCancelled, model.InvoiceTypeCreditNote).
+		Session(&gorm.Session{})
+
 	// 合計金額
 	var totalResult struct {
-		Total float64
+		Total money.Amount
 		Count int
 	}
-	if err := query.
+	if err := booked.
 		Select("COALESCE(SUM(total_amount), 0) as total, COUNT(*) as count").
 		Scan(&totalResult).Error; err != nil {
 		r.logger.Error("Failed to get total amount", zap.Error(err))
@@ -178,13 +192,14 @@ func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, da
 	}
 	summary.TotalAmount = totalResult.Total
 
-	// 支払済み金額
+	// 支払済み金額（支払後に赤伝で取り消した請求書を含む）
 	var paidResult struct {
-		Total float64
+		Total money.Amount
 		Count int
 	}
-	if err := query.
-		Where("status = ?", model.InvoiceStatusPaid).
+	if err := booked.
+		Where("invoice_type = ?", model.InvoiceTypeStandard).
+		Where("status = ? OR (status = ? AND paid_date IS NOT NULL)", model.InvoiceStatusPaid, model.InvoiceStatusCancelled).
 		Select("COALESCE(SUM(total_amount), 0) as total, COUNT(*) as count").
 		Scan(&paidResult).Error; err != nil {
 		r.logger.Error("Failed to get paid amount", zap.Error(err))
@@ -194,14 +209,15 @@ func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, da
 	summary.PaidCount = paidResult.Count
 
 	// 未払い金額
-	summary.UnpaidAmount = summary.TotalAmount - summary.PaidAmount
+	summary.UnpaidAmount = summary.TotalAmount.Sub(summary.PaidAmount)
 
 	// 期限超過金額
 	var overdueResult struct {
-		Total float64
+		Total money.Amount
 		Count int
 	}
 	if err := query.
+		Where("invoice_type = ?", model.InvoiceTypeStandard).
 		Where("status IN (?) AND due_date < ?",
 			[]model.InvoiceStatus{model.InvoiceStatusSent, model.InvoiceStatusOverdue},
 			time.Now()).
@@ -213,6 +229,21 @@ func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, da
 	summary.OverdueAmount = overdueResult.Total
 	summary.OverdueCount = overdueResult.Count
 
+	// 赤伝
+	var creditNoteResult struct {
+		Total money.Amount
+		Count int
+	}
+	if err := query.
+		Where("invoice_type = ?", model.InvoiceTypeCreditNote).
+		Select("COALESCE(SUM(total_amount), 0) as total, COUNT(*) as count").
+		Scan(&creditNoteResult).Error; err != nil {
+		r.logger.Error("Failed to get credit note amount", zap.Error(err))
+		return nil, err
+	}
+	summary.CreditNoteAmount = creditNoteResult.Total
+	summary.CreditNoteCount = creditNoteResult.Count
+
 	// ステータス別件数
 	type statusCount struct {
 		Status string
@@ -220,6 +251,7 @@ func (r *invoiceRepository) GetSummary(ctx context.Context, clientID *string, da
 	}
 	var statusCounts []statusCount
 	if err := query.
+		Where("invoice_type = ?", model.InvoiceTypeStandard).
 		Select("status, COUNT(*) as count").
 		Group("status").
 		Scan(&statusCounts).Error; err != nil {
@@ -400,9 +432,12 @@ func (r *invoiceRepository) FindByFreeSyncStatus(ctx context.Context, status mod
 func (r *invoiceRepository) FindNeedingSync(ctx context.Context, limit int) ([]*model.Invoice, error) {
 	var invoices []*model.Invoice
 	err := r.db.WithContext(ctx).
-		Where("freee_sync_status IN (?) AND status != ? AND deleted_at IS NULL",
+		Where("freee_sync_status IN (?) AND status NOT IN (?) AND deleted_at IS NULL",
 			[]model.FreeSyncStatus{model.FreeSyncStatusNotSynced, model.FreeSyncStatusFailed},
-			model.InvoiceStatusDraft).
+			[]model.InvoiceStatus{model.InvoiceStatusDraft, model.InvoiceStatusCancelled}).
+		// 赤伝は取消対象の請求書がfreeeに登録済みの場合のみ同期する
+		Where("invoice_type <> ? OR EXISTS (SELECT 1 FROM invoices o WHERE o.id = invoices.original_invoice_id AND o.freee_invoice_id IS NOT NULL)",
+			model.InvoiceTypeCreditNote).
 		Preload("Client").
 		Preload("Details").
 		Order("created_at ASC").
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/duesk/monstera/internal/common/repository"
+	"github.com/duesk/monstera/internal/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ProjectAssignmentRepository 案件アサインリポジトリのインターフェース
+type ProjectAssignmentRepository interface {
+	CrudRepository[model.ProjectAssignment]
+	FindActiveByProjectID(ctx context.Context, projectID string, date time.Time) ([]*model.ProjectAssignment, error)
+	FindActiveInPeriod(ctx context.Context, start, end time.Time, clientIDs []string) ([]*model.ProjectAssignment, error)
+}
+
+// projectAssignmentRepository 案件アサインリポジトリの実装
+type projectAssignmentRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewProjectAssignmentRepository 案件アサインリポジトリのインスタンスを生成
+func NewProjectAssignmentRepository(base BaseRepository) ProjectAssignmentRepository {
+	return &projectAssignmentRepository{
+		db:     base.GetDB(),
+		logger: base.GetLogger(),
+	}
+}
+
+// FindActiveByProjectID 指定日にアサイン期間中の案件アサインを取得
+func (r *projectAssignmentRepository) FindActiveByProjectID(ctx context.Context, projectID string, date time.Time) ([]*model.ProjectAssignment, error) {
+	var assignments []*model.ProjectAssignment
+	err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Where("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", date, date).
+		Order("start_date ASC").
+		Find(&assignments).Error
+	if err != nil {
+		r.logger.Error("Failed to find active assignments",
+			zap.String("project_id", projectID),
+			zap.Error(err))
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// FindActiveInPeriod 期間中に1日でもアサインされている案件アサインを案件付きで取得
+// clientIDsを指定した場合はその取引先の案件に限定する
+func (r *projectAssignmentRepository) FindActiveInPeriod(ctx context.Context, start, end time.Time, clientIDs []string) ([]*model.ProjectAssignment, error) {
+	query := r.db.WithContext(ctx).
+		Joins("JOIN projects ON projects.id = project_assignments.project_id AND projects.deleted_at IS NULL").
+		Where("project_assignments.start_date <= ?", end).
+		Where("project_assignments.end_date IS NULL OR project_assignments.end_date >= ?", start)
+	if len(clientIDs) > 0 {
+		query = query.Where("projects.client_id IN ?", clientIDs)
+	}
+
+	var assignments []*model.ProjectAssignment
+	err := query.
+		Preload("Project").
+		Order("projects.client_id ASC, project_assignments.project_id ASC, project_assignments.start_date ASC").
+		Find(&assignments).Error
+	if err != nil {
+		r.logger.Error("Failed to find assignments in period", zap.Error(err))
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// Create 案件アサインを作成（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Create(ctx context.Context, entity *model.ProjectAssignment) error {
+	return r.db.WithContext(ctx).Create(entity).Error
+}
+
+// Update 案件アサインを更新（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Update(ctx context.Context, entity *model.ProjectAssignment) error {
+	return r.db.WithContext(ctx).Save(entity).Error
+}
+
+// Delete 案件アサインを削除（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Delete(ctx context.Context, entity *model.ProjectAssignment) error {
+	return r.db.WithContext(ctx).Delete(entity).Error
+}
+
+// FindByID IDで案件アサインを検索（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) FindByID(ctx context.Context, id interface{}) (*model.ProjectAssignment, error) {
+	var entity model.ProjectAssignment
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// FindAll すべての案件アサインを検索（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) FindAll(ctx context.Context, opts ...repository.QueryOptions) ([]*model.ProjectAssignment, error) {
+	var entities []*model.ProjectAssignment
+	query := r.db.WithContext(ctx)
+
+	// オプションがある場合は適用
+	if len(opts) > 0 {
+		query = repository.ApplyOptions(query, opts[0])
+	}
+
+	if err := query.Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// Count 案件アサイン数をカウント（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Count(ctx context.Context, opts ...repository.QueryOptions) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&model.ProjectAssignment{})
+
+	// オプションがある場合は適用（ただしページネーションは除く）
+	if len(opts) > 0 {
+		opt := opts[0]
+		if opt.Search != "" && len(opt.SearchKeys) > 0 {
+			query = repository.ApplySearch(query, opt.Search, opt.SearchKeys)
+		}
+	}
+
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// Exists 案件アサインの存在確認（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Exists(ctx context.Context, id interface{}) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.ProjectAssignment{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// Transaction トランザクション処理（CrudRepositoryインターフェース実装）
+func (r *projectAssignmentRepository) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/handler"
 	"github.com/duesk/monstera/internal/middleware"
 	"github.com/duesk/monstera/internal/service"
@@ -36,6 +37,9 @@ func RegisterAccountingRoutes(
 		dashboard.GET("/ranking/clients", dashboardHandler.GetClientBillingRanking)
 		dashboard.GET("/ranking/projects", dashboardHandler.GetProjectBillingRanking)
 		dashboard.GET("/payment-status", dashboardHandler.GetPaymentStatus)
+		dashboard.GET("/aging", dashboardHandler.GetReceivablesAging)
+		dashboard.GET("/dso", dashboardHandler.GetDSO)
+		dashboard.GET("/upcoming-billings", dashboardHandler.GetUpcomingBillings)
 	}
 
 	// プロジェクトグループ関連
@@ -163,40 +167,27 @@ func RegisterAccountingWebhooks(
 	webhooks := r.Group("/webhooks")
 
 	// freee Webhook
+	// 処理に失敗した場合は5xxを返し、freeeの再送で再処理させる
 	webhooks.POST("/freee", middleware.VerifyWebhookSignature(webhookSecret, logger), func(c *gin.Context) {
-		var payload map[string]interface{}
-		if err := c.ShouldBindJSON(&payload); err != nil {
+		var req dto.FreeeWebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
 			logger.Error("Invalid webhook payload", zap.Error(err))
 			c.JSON(400, gin.H{"error": "Invalid payload"})
 			return
 		}
 
-		eventType, ok := payload["event_type"].(string)
-		if !ok {
-			c.JSON(400, gin.H{"error": "Missing event_type"})
-			return
-		}
-
 		logger.Info("Received freee webhook",
-			zap.String("event_type", eventType),
-			zap.Any("payload", payload))
-
-		// イベントタイプに応じた処理
-		switch eventType {
-		case "invoice.created":
-			// 請求書作成通知
-			logger.Info("Invoice created webhook received")
-		case "invoice.updated":
-			// 請求書更新通知
-			logger.Info("Invoice updated webhook received")
-		case "payment.created":
-			// 入金通知
-			logger.Info("Payment created webhook received")
-		default:
-			logger.Warn("Unknown webhook event type", zap.String("type", eventType))
+			zap.String("event_id", req.EventID),
+			zap.String("event_type", req.EventType),
+			zap.Int("company_id", req.CompanyID))
+
+		resp, err := freeeService.ProcessWebhook(c.Request.Context(), &req)
+		if err != nil {
+			c.JSON(500, gin.H{"error": "Webhookの処理に失敗しました"})
+			return
 		}
 
-		c.JSON(200, gin.H{"status": "ok"})
+		c.JSON(200, resp)
 	})
 }
 
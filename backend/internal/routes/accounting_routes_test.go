@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/service"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+// setupWebhookTestDB Webhookの処理に必要なテーブルを作成したインメモリDB
+func setupWebhookTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	for _, stmt := range []string{
+		`CREATE TABLE invoices (
+			id varchar(36) PRIMARY KEY,
+			client_id varchar(36),
+			invoice_number varchar(50),
+			invoice_date datetime,
+			due_date datetime,
+			billing_month varchar(7),
+			subtotal decimal(10,2),
+			tax_rate decimal(5,2),
+			tax_amount decimal(10,2),
+			total_amount decimal(10,2),
+			status varchar(50),
+			paid_date datetime,
+			payment_method varchar(50),
+			freee_invoice_id integer,
+			created_at datetime,
+			updated_at datetime,
+			deleted_at datetime
+		)`,
+		`CREATE TABLE freee_sync_logs (
+			id varchar(36) PRIMARY KEY,
+			sync_type varchar(20) NOT NULL,
+			target_id varchar(36),
+			freee_id integer,
+			status varchar(20) NOT NULL,
+			error_message text,
+			request_data text,
+			response_data text,
+			event_id varchar(100) UNIQUE,
+			created_at datetime
+		)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	return db
+}
+
+// postFreeeWebhook 署名付きでfreeeのWebhookを送信
+func postFreeeWebhook(t *testing.T, router *gin.Engine, payload map[string]interface{}) *httptest.ResponseRecorder {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/freee", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestFreeeWebhook_PaymentRedelivery 入金の反映に失敗したイベントは5xxを返し、再送で処理される
+func TestFreeeWebhook_PaymentRedelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupWebhookTestDB(t)
+	logger := zap.NewNop()
+
+	freeeService := service.NewFreeeService(db, &config.FreeeConfig{}, nil, nil, nil, nil, nil, "postgres", logger)
+	router := gin.New()
+	RegisterAccountingWebhooks(router.Group("/api/v1"), freeeService, testWebhookSecret, logger)
+
+	payload := map[string]interface{}{
+		"event_id":      "evt-payment-1",
+		"event_type":    "payment.created",
+		"resource_type": "invoice",
+		"resource_id":   1001,
+		"occurred_at":   "2026-10-01T10:00:00+09:00",
+		"data": map[string]interface{}{
+			"date":                 "2026-10-01",
+			"from_walletable_type": "bank_account",
+		},
+	}
+
+	// 請求書の同期前に入金が届いた場合は再送させる
+	w := postFreeeWebhook(t, router, payload)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var log model.FreeSyncLog
+	require.NoError(t, db.Where("event_id = ?", "evt-payment-1").First(&log).Error)
+	assert.Equal(t, model.FreeSyncStatusFailed, log.Status)
+	assert.NotNil(t, log.ErrorMessage)
+
+	// 請求書の同期後に再送されたイベントは処理される
+	require.NoError(t, db.Exec(
+		`INSERT INTO invoices (id, invoice_number, total_amount, status, freee_invoice_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+		"invoice-1", "INV-202610-0001", 110000, model.InvoiceStatusSent, 1001).Error)
+
+	w = postFreeeWebhook(t, router, payload)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "processed", resp["status"])
+
+	var invoice model.Invoice
+	require.NoError(t, db.Where("id = ?", "invoice-1").First(&invoice).Error)
+	assert.Equal(t, model.InvoiceStatusPaid, invoice.Status)
+	assert.Equal(t, "bank_transfer", invoice.PaymentMethod)
+
+	require.NoError(t, db.Where("event_id = ?", "evt-payment-1").First(&log).Error)
+	assert.Equal(t, model.FreeSyncStatusSuccess, log.Status)
+	assert.Nil(t, log.ErrorMessage)
+
+	// 処理済みのイベントが再送されても何もしない
+	w = postFreeeWebhook(t, router, payload)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "ignored", resp["status"])
+}
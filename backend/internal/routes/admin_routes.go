@@ -28,6 +28,33 @@ type AdminHandlers struct {
 	BillingHandler             *handler.BillingHandler
 	FreeeHandler               *handler.FreeeHandler
 	AccountingDashboardHandler *handler.AccountingDashboardHandler
+	BankReconciliationHandler  *handler.BankReconciliationHandler
+	InvoiceDunningHandler      *handler.InvoiceDunningHandler
+	BillingRunHandler          *handler.BillingRunHandler
+	AssignmentRateHandler      *handler.ProjectAssignmentRateHandler
+	BusinessPartnerHandler     *handler.BusinessPartnerHandler
+	JournalExportHandler       *handler.JournalExportHandler
+
+	// 経費精算（総合振込）
+	ExpenseReimbursementHandler *handler.ExpenseReimbursementHandler
+
+	// 請求書番号台帳
+	InvoiceNumberHandler *handler.InvoiceNumberHandler
+
+	// 電子帳簿保存
+	DocumentStoreHandler *handler.DocumentStoreHandler
+
+	// 経費申請の承認ルール
+	ExpenseApprovalRuleHandler *handler.ExpenseApprovalRuleHandler
+
+	// 不在時の代理承認
+	ApprovalDelegationHandler *handler.ApprovalDelegationHandler
+
+	// 出張申請・出張精算
+	BusinessTripHandler *handler.BusinessTripHandler
+
+	// 仮払金
+	CashAdvanceHandler *handler.CashAdvanceHandler
 }
 
 // SetupAdminRoutes 管理者用ルートの設定
@@ -124,6 +151,33 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 					expenses.GET("/export", handlers.ExpenseHandler.ExportExpensesCSVAdmin) // 管理者用CSVエクスポート
 				}
 		}
+
+		// 出張申請・出張精算の承認エンドポイント
+		if handlers.BusinessTripHandler != nil {
+			businessTrips := engineers.Group("/business-trips")
+			{
+				businessTrips.GET("", handlers.BusinessTripHandler.ListTrips)
+				businessTrips.GET("/:id", handlers.BusinessTripHandler.GetTrip)
+				businessTrips.PUT("/:id/approve", handlers.BusinessTripHandler.ApproveTrip)
+				businessTrips.PUT("/:id/reject", handlers.BusinessTripHandler.RejectTrip)
+				businessTrips.PUT("/:id/settlement/approve", handlers.BusinessTripHandler.ApproveSettlement)
+				businessTrips.PUT("/:id/settlement/return", handlers.BusinessTripHandler.ReturnSettlement)
+			}
+		}
+
+		// 仮払金・仮払金の精算の承認エンドポイント
+		// 仮払金の承認・却下は経費申請と同じ承認フローの現在の段階の承認者のみ（承認待ちは経費の承認待ち一覧に含まれる）
+		if handlers.CashAdvanceHandler != nil {
+			cashAdvances := engineers.Group("/cash-advances")
+			{
+				cashAdvances.GET("", handlers.CashAdvanceHandler.GetAllCashAdvances)
+				cashAdvances.GET("/:id", handlers.CashAdvanceHandler.GetCashAdvanceForAdmin)
+				cashAdvances.PUT("/:id/approve", handlers.CashAdvanceHandler.ApproveCashAdvance)
+				cashAdvances.PUT("/:id/reject", handlers.CashAdvanceHandler.RejectCashAdvance)
+				cashAdvances.PUT("/:id/settlement/approve", handlers.CashAdvanceHandler.ApproveSettlement)
+				cashAdvances.PUT("/:id/settlement/return", handlers.CashAdvanceHandler.ReturnSettlement)
+			}
+		}
 	}
 
 	// 経費申請上限管理エンドポイント（管理者のみ）
@@ -147,6 +201,39 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 		}
 	}
 
+	// 経費申請の承認ルールエンドポイント（管理者のみ）
+	if handlers.ExpenseApprovalRuleHandler != nil {
+		expenseApprovalRules := admin.Group("/expense-approval-rules")
+		{
+			expenseApprovalRules.GET("", handlers.ExpenseApprovalRuleHandler.GetRules)
+			expenseApprovalRules.POST("", handlers.ExpenseApprovalRuleHandler.CreateRule)
+			expenseApprovalRules.GET("/:id", handlers.ExpenseApprovalRuleHandler.GetRule)
+			expenseApprovalRules.PUT("/:id", handlers.ExpenseApprovalRuleHandler.UpdateRule)
+			expenseApprovalRules.DELETE("/:id", handlers.ExpenseApprovalRuleHandler.DeleteRule)
+		}
+	}
+
+	// 不在時の代理承認エンドポイント（経費申請・休暇申請・週報の承認で共通）
+	if handlers.ApprovalDelegationHandler != nil {
+		approvalDelegations := admin.Group("/approval-delegations")
+		{
+			approvalDelegations.GET("", handlers.ApprovalDelegationHandler.GetDelegations)
+			approvalDelegations.POST("", handlers.ApprovalDelegationHandler.CreateDelegation)
+			approvalDelegations.DELETE("/:id", handlers.ApprovalDelegationHandler.RevokeDelegation)
+		}
+	}
+
+	// 出張の日当の単価表エンドポイント（管理者のみ）
+	if handlers.BusinessTripHandler != nil {
+		perDiemRates := admin.Group("/business-trip-per-diem-rates")
+		{
+			perDiemRates.GET("", handlers.BusinessTripHandler.GetPerDiemRates)
+			perDiemRates.POST("", handlers.BusinessTripHandler.CreatePerDiemRate)
+			perDiemRates.PUT("/:id", handlers.BusinessTripHandler.UpdatePerDiemRate)
+			perDiemRates.DELETE("/:id", handlers.BusinessTripHandler.DeletePerDiemRate)
+		}
+	}
+
 	// 承認催促管理
 	if handlers.ApprovalReminderHandler != nil {
 		approvalReminder := admin.Group("/approval-reminder")
@@ -195,7 +282,11 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 			{
 				invoices.GET("", handlers.InvoiceHandler.GetInvoices)
 				invoices.GET("/summary", handlers.InvoiceHandler.GetInvoiceSummary)
+				if handlers.InvoiceNumberHandler != nil {
+					invoices.GET("/numbers", handlers.InvoiceNumberHandler.GetLedger)
+				}
 				invoices.GET("/:id", handlers.InvoiceHandler.GetInvoice)
+				invoices.GET("/:id/revisions", handlers.InvoiceHandler.GetInvoiceRevisions)
 				// 請求書PDF（初期スコープ外・無効化）
 				// invoices.GET("/:id/pdf", handlers.InvoiceHandler.ExportInvoicePDF)
 			}
@@ -207,6 +298,8 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 				invoicesWrite.PUT("/:id", handlers.InvoiceHandler.UpdateInvoice)
 				invoicesWrite.PUT("/:id/status", handlers.InvoiceHandler.UpdateInvoiceStatus)
 				invoicesWrite.DELETE("/:id", handlers.InvoiceHandler.DeleteInvoice)
+				invoicesWrite.POST("/:id/cancel", handlers.InvoiceHandler.CancelInvoice)
+				invoicesWrite.POST("/:id/revise", handlers.InvoiceHandler.ReviseInvoice)
 			}
 		}
 	}
@@ -260,6 +353,9 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 				dashboard.GET("/ranking/clients", handlers.AccountingDashboardHandler.GetClientBillingRanking)
 				dashboard.GET("/ranking/projects", handlers.AccountingDashboardHandler.GetProjectBillingRanking)
 				dashboard.GET("/payment-status", handlers.AccountingDashboardHandler.GetPaymentStatus)
+				dashboard.GET("/aging", handlers.AccountingDashboardHandler.GetReceivablesAging)
+				dashboard.GET("/dso", handlers.AccountingDashboardHandler.GetDSO)
+				dashboard.GET("/upcoming-billings", handlers.AccountingDashboardHandler.GetUpcomingBillings)
 			}
 		}
 
@@ -302,8 +398,15 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 				if handlers.BillingHandler != nil {
 					billingRead.GET("/summary", handlers.BillingHandler.GetBillingSummary)
 					billingRead.GET("/history", handlers.BillingHandler.GetBillingHistory)
+					billingRead.GET("/history/assignments/:id", handlers.BillingHandler.GetBillingCalculationRevisions)
 					billingRead.GET("/scheduled", handlers.BillingHandler.GetScheduledBillings)
 				}
+				if handlers.BillingRunHandler != nil {
+					billingRead.GET("/runs/:month", handlers.BillingRunHandler.GetRun)
+				}
+				if handlers.AssignmentRateHandler != nil {
+					billingRead.GET("/assignments/:id/rates", handlers.AssignmentRateHandler.ListRates)
+				}
 			}
 
 			// 書き込み
@@ -319,6 +422,130 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 					billingWrite.POST("/scheduled", handlers.BillingHandler.ScheduleBilling)
 					billingWrite.DELETE("/scheduled/:id", handlers.BillingHandler.CancelScheduledBilling)
 				}
+				if handlers.BillingRunHandler != nil {
+					billingWrite.POST("/runs", handlers.BillingRunHandler.Execute)
+					billingWrite.POST("/runs/:month/approve", handlers.BillingRunHandler.Approve)
+				}
+				if handlers.AssignmentRateHandler != nil {
+					billingWrite.POST("/assignments/:id/rates", handlers.AssignmentRateHandler.CreateRate)
+					billingWrite.PUT("/assignments/:id/rates/:rate_id", handlers.AssignmentRateHandler.UpdateRate)
+					billingWrite.DELETE("/assignments/:id/rates/:rate_id", handlers.AssignmentRateHandler.DeleteRate)
+				}
+			}
+		}
+
+		// 仕入（ビジネスパートナー）・粗利管理
+		purchasing := accounting.Group("/purchasing")
+		{
+			// 読み取り
+			purchasingRead := purchasing.Group("")
+			purchasingRead.Use(accountingMiddleware)
+			{
+				if handlers.BusinessPartnerHandler != nil {
+					purchasingRead.GET("/partners", handlers.BusinessPartnerHandler.ListPartners)
+					purchasingRead.GET("/assignments/:id/costs", handlers.BusinessPartnerHandler.ListAssignmentCosts)
+					purchasingRead.GET("/orders", handlers.BusinessPartnerHandler.ListPurchaseOrders)
+					purchasingRead.GET("/invoices", handlers.BusinessPartnerHandler.ListPartnerInvoices)
+					purchasingRead.GET("/margins", handlers.BusinessPartnerHandler.GetMarginReport)
+				}
+			}
+
+			// 書き込み
+			purchasingWrite := purchasing.Group("")
+			purchasingWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.BusinessPartnerHandler != nil {
+					purchasingWrite.POST("/partners", handlers.BusinessPartnerHandler.CreatePartner)
+					purchasingWrite.PUT("/partners/:id", handlers.BusinessPartnerHandler.UpdatePartner)
+					purchasingWrite.POST("/assignments/:id/costs", handlers.BusinessPartnerHandler.CreateAssignmentCost)
+					purchasingWrite.PUT("/assignments/:id/costs/:cost_id", handlers.BusinessPartnerHandler.UpdateAssignmentCost)
+					purchasingWrite.DELETE("/assignments/:id/costs/:cost_id", handlers.BusinessPartnerHandler.DeleteAssignmentCost)
+					purchasingWrite.POST("/orders", handlers.BusinessPartnerHandler.IssuePurchaseOrders)
+					purchasingWrite.POST("/invoices", handlers.BusinessPartnerHandler.RegisterPartnerInvoice)
+					purchasingWrite.POST("/invoices/:id/approve", handlers.BusinessPartnerHandler.ApprovePartnerInvoice)
+					purchasingWrite.POST("/invoices/:id/reject", handlers.BusinessPartnerHandler.RejectPartnerInvoice)
+				}
+			}
+		}
+
+		// 仕訳出力・月次締め
+		journals := accounting.Group("/journals")
+		{
+			// 読み取り
+			journalsRead := journals.Group("")
+			journalsRead.Use(accountingMiddleware)
+			{
+				if handlers.JournalExportHandler != nil {
+					journalsRead.GET("/mappings", handlers.JournalExportHandler.ListMappings)
+					journalsRead.GET("/entries", handlers.JournalExportHandler.GetEntries)
+					journalsRead.GET("/export", handlers.JournalExportHandler.Export)
+					journalsRead.GET("/exports", handlers.JournalExportHandler.ListExports)
+					journalsRead.GET("/closes", handlers.JournalExportHandler.ListCloses)
+				}
+			}
+
+			// 書き込み
+			journalsWrite := journals.Group("")
+			journalsWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.JournalExportHandler != nil {
+					journalsWrite.PUT("/mappings/:key", handlers.JournalExportHandler.UpdateMapping)
+					journalsWrite.POST("/closes", handlers.JournalExportHandler.CloseMonth)
+				}
+			}
+		}
+
+		// 経費精算（総合振込）
+		reimbursements := accounting.Group("/reimbursements")
+		{
+			// 読み取り
+			reimbursementsRead := reimbursements.Group("")
+			reimbursementsRead.Use(accountingMiddleware)
+			{
+				if handlers.ExpenseReimbursementHandler != nil {
+					reimbursementsRead.GET("/batches", handlers.ExpenseReimbursementHandler.ListBatches)
+					reimbursementsRead.GET("/batches/:id", handlers.ExpenseReimbursementHandler.GetBatch)
+					reimbursementsRead.GET("/batches/:id/zengin", handlers.ExpenseReimbursementHandler.DownloadTransferFile)
+					reimbursementsRead.GET("/batches/:id/statements", handlers.ExpenseReimbursementHandler.ListStatements)
+					reimbursementsRead.GET("/batches/:id/statements/:user_id", handlers.ExpenseReimbursementHandler.GetStatement)
+				}
+			}
+
+			// 書き込み
+			reimbursementsWrite := reimbursements.Group("")
+			reimbursementsWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.ExpenseReimbursementHandler != nil {
+					reimbursementsWrite.POST("/batches", handlers.ExpenseReimbursementHandler.CreateBatch)
+					reimbursementsWrite.POST("/batches/:id/confirm", handlers.ExpenseReimbursementHandler.ConfirmBatch)
+					reimbursementsWrite.POST("/batches/:id/cancel", handlers.ExpenseReimbursementHandler.CancelBatch)
+				}
+			}
+		}
+
+		// 仮払金の支払・精算
+		cashAdvances := accounting.Group("/cash-advances")
+		{
+			// 読み取り
+			cashAdvancesRead := cashAdvances.Group("")
+			cashAdvancesRead.Use(accountingMiddleware)
+			{
+				if handlers.CashAdvanceHandler != nil {
+					cashAdvancesRead.GET("", handlers.CashAdvanceHandler.GetAllCashAdvances)
+					cashAdvancesRead.GET("/balances", handlers.CashAdvanceHandler.GetBalances)
+					cashAdvancesRead.GET("/:id", handlers.CashAdvanceHandler.GetCashAdvanceForAdmin)
+				}
+			}
+
+			// 書き込み
+			cashAdvancesWrite := cashAdvances.Group("")
+			cashAdvancesWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.CashAdvanceHandler != nil {
+					cashAdvancesWrite.POST("/:id/pay", handlers.CashAdvanceHandler.RecordPayment)
+					cashAdvancesWrite.POST("/:id/close", handlers.CashAdvanceHandler.CloseSettlement)
+					cashAdvancesWrite.POST("/reminders", handlers.CashAdvanceHandler.SendOverdueReminders)
+				}
 			}
 		}
 
@@ -334,6 +561,9 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 					freeeRead.GET("/companies", handlers.FreeeHandler.GetCompanies)
 					freeeRead.GET("/sync/history", handlers.FreeeHandler.GetSyncHistory)
 					freeeRead.GET("/sync/summary", handlers.FreeeHandler.GetSyncSummary)
+					freeeRead.GET("/sync/jobs", handlers.FreeeHandler.GetSyncJobs)
+					freeeRead.GET("/invoices", handlers.FreeeHandler.GetInvoices)
+					freeeRead.GET("/payments", handlers.FreeeHandler.GetPayments)
 				}
 			}
 
@@ -349,6 +579,92 @@ func SetupAdminRoutes(r *gin.RouterGroup, cfg *config.Config, handlers *AdminHan
 					freeeWrite.POST("/companies/select", handlers.FreeeHandler.SelectCompany)
 					freeeWrite.POST("/sync/partners", handlers.FreeeHandler.SyncPartners)
 					freeeWrite.POST("/sync/invoices", handlers.FreeeHandler.SyncInvoices)
+					freeeWrite.POST("/sync/transactions", handlers.FreeeHandler.SyncTransactions)
+					freeeWrite.POST("/sync/queue", handlers.FreeeHandler.EnqueueSync)
+					freeeWrite.POST("/sync/jobs/:id/requeue", handlers.FreeeHandler.RequeueSyncJob)
+					freeeWrite.POST("/invoices", handlers.FreeeHandler.CreateInvoice)
+					freeeWrite.PUT("/invoices/:id", handlers.FreeeHandler.UpdateInvoice)
+					freeeWrite.POST("/payments", handlers.FreeeHandler.CreatePayment)
+				}
+			}
+		}
+
+		// 入金消込
+		reconciliation := accounting.Group("/reconciliation")
+		{
+			// 読み取り
+			reconciliationRead := reconciliation.Group("")
+			reconciliationRead.Use(accountingMiddleware)
+			{
+				if handlers.BankReconciliationHandler != nil {
+					reconciliationRead.GET("/imports", handlers.BankReconciliationHandler.ListImports)
+					reconciliationRead.GET("/transactions", handlers.BankReconciliationHandler.ListTransactions)
+					reconciliationRead.GET("/transactions/:id", handlers.BankReconciliationHandler.GetTransaction)
+					reconciliationRead.GET("/review-queue", handlers.BankReconciliationHandler.GetReviewQueue)
+				}
+			}
+
+			// 書き込み
+			reconciliationWrite := reconciliation.Group("")
+			reconciliationWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.BankReconciliationHandler != nil {
+					reconciliationWrite.POST("/imports", handlers.BankReconciliationHandler.ImportStatement)
+					reconciliationWrite.POST("/transactions/:id/confirm", handlers.BankReconciliationHandler.ConfirmMatch)
+					reconciliationWrite.POST("/transactions/:id/ignore", handlers.BankReconciliationHandler.IgnoreTransaction)
+				}
+			}
+		}
+
+		// 期限超過・督促
+		dunning := accounting.Group("/dunning")
+		{
+			// 読み取り
+			dunningRead := dunning.Group("")
+			dunningRead.Use(accountingMiddleware)
+			{
+				if handlers.InvoiceDunningHandler != nil {
+					dunningRead.GET("/schedule", handlers.InvoiceDunningHandler.GetSchedule)
+					dunningRead.GET("/invoices/:id/timeline", handlers.InvoiceDunningHandler.GetInvoiceTimeline)
+				}
+			}
+
+			// 書き込み
+			dunningWrite := dunning.Group("")
+			dunningWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.InvoiceDunningHandler != nil {
+					dunningWrite.PUT("/schedule", handlers.InvoiceDunningHandler.UpdateSchedule)
+					dunningWrite.POST("/run", handlers.InvoiceDunningHandler.Run)
+				}
+			}
+		}
+
+		// 電子帳簿保存（請求書・領収書の保存と検索）
+		documents := accounting.Group("/documents")
+		{
+			// 読み取り
+			documentsRead := documents.Group("")
+			documentsRead.Use(accountingMiddleware)
+			{
+				if handlers.DocumentStoreHandler != nil {
+					documentsRead.GET("", handlers.DocumentStoreHandler.SearchDocuments)
+					documentsRead.GET("/verify", handlers.DocumentStoreHandler.VerifyChain)
+					documentsRead.GET("/:id", handlers.DocumentStoreHandler.GetDocument)
+					documentsRead.GET("/:id/content", handlers.DocumentStoreHandler.DownloadDocument)
+				}
+			}
+
+			// 書き込み
+			documentsWrite := documents.Group("")
+			documentsWrite.Use(accountingWriteMiddleware)
+			{
+				if handlers.DocumentStoreHandler != nil {
+					documentsWrite.POST("", handlers.DocumentStoreHandler.StoreDocument)
+					documentsWrite.POST("/:id/replace", handlers.DocumentStoreHandler.ReplaceDocument)
+					documentsWrite.DELETE("/:id", handlers.DocumentStoreHandler.DisposeDocument)
+					documentsWrite.POST("/invoices/:invoice_id", handlers.DocumentStoreHandler.ArchiveInvoice)
+					documentsWrite.POST("/expense-receipts/:receipt_id", handlers.DocumentStoreHandler.ArchiveExpenseReceipt)
 				}
 			}
 		}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/duesk/monstera/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBusinessTripRoutes 出張申請・出張精算（社員向け）を登録
+func SetupBusinessTripRoutes(api *gin.RouterGroup, authRequired gin.HandlerFunc, tripHandler *handler.BusinessTripHandler) {
+	trips := api.Group("/business-trips")
+	trips.Use(authRequired)
+	{
+		// 出張申請（事前承認）
+		trips.GET("", tripHandler.ListMyTrips)
+		trips.POST("", tripHandler.CreateTrip)
+		trips.GET("/:id", tripHandler.GetMyTrip)
+		trips.PUT("/:id", tripHandler.UpdateTrip)
+		trips.POST("/:id/cancel", tripHandler.CancelTrip)
+
+		// 出張精算
+		trips.POST("/:id/settlement", tripHandler.SubmitSettlement)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/duesk/monstera/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCashAdvanceRoutes 仮払金（社員向け）を登録
+func SetupCashAdvanceRoutes(api *gin.RouterGroup, authRequired gin.HandlerFunc, advanceHandler *handler.CashAdvanceHandler) {
+	advances := api.Group("/cash-advances")
+	advances.Use(authRequired)
+	{
+		// 仮払金の申請
+		advances.GET("", advanceHandler.GetCashAdvanceList)
+		advances.POST("", advanceHandler.CreateCashAdvance)
+		advances.GET("/:id", advanceHandler.GetCashAdvance)
+		advances.PUT("/:id", advanceHandler.UpdateCashAdvance)
+		advances.POST("/:id/cancel", advanceHandler.CancelCashAdvance)
+
+		// 仮払金の精算
+		advances.POST("/:id/settlement", advanceHandler.SubmitSettlement)
+	}
+}
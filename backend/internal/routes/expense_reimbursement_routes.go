@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/duesk/monstera/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupExpenseReimbursementRoutes 振込先口座と経費精算の振込（社員向け）を登録
+func SetupExpenseReimbursementRoutes(api *gin.RouterGroup, authRequired gin.HandlerFunc, reimbursementHandler *handler.ExpenseReimbursementHandler) {
+	profile := api.Group("/profile")
+	profile.Use(authRequired)
+	{
+		// 振込先口座
+		profile.GET("/bank-account", reimbursementHandler.GetBankAccount)
+		profile.PUT("/bank-account", reimbursementHandler.SaveBankAccount)
+	}
+
+	reimbursements := api.Group("/expenses/reimbursements")
+	reimbursements.Use(authRequired)
+	{
+		// 振込一覧・精算明細
+		reimbursements.GET("", reimbursementHandler.ListMyReimbursements)
+		reimbursements.GET("/:id/statement", reimbursementHandler.GetMyStatement)
+	}
+}
@@ -0,0 +1,621 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// issuedInvoiceStatuses 売上・売掛金の集計対象（発行済みで取り消されていない請求書）
+// 赤伝を発行した請求書はキャンセル済みになるため、赤伝自体も集計しない
+var issuedInvoiceStatuses = []model.InvoiceStatus{
+	model.InvoiceStatusSent,
+	model.InvoiceStatusPaid,
+	model.InvoiceStatusOverdue,
+}
+
+const (
+	// dashboardTrendMonths ダッシュボードに表示する請求・回収推移の月数
+	dashboardTrendMonths = 6
+	// dashboardRankingLimit ダッシュボードに表示する取引先ランキングの件数
+	dashboardRankingLimit = 5
+	// defaultDSOPeriodDays DSOの算出に使う請求額の集計日数
+	defaultDSOPeriodDays = 90
+	// maxAnalyticsMonths 推移・ランキングで指定できる最大の月数
+	maxAnalyticsMonths = 24
+)
+
+// AccountingAnalyticsServiceInterface 経理分析サービスのインターフェース
+type AccountingAnalyticsServiceInterface interface {
+	// ダッシュボード
+	GetDashboard(ctx context.Context, month string, asOf time.Time) (*dto.AccountingDashboardResponse, error)
+
+	// 売掛金
+	GetReceivablesAging(ctx context.Context, asOf time.Time) (*dto.ReceivablesAgingResponse, error)
+	GetDSO(ctx context.Context, asOf time.Time, periodDays int) (*dto.DSODTO, error)
+	GetPaymentStatus(ctx context.Context, month, status string, asOf time.Time) (*dto.PaymentStatusResponse, error)
+
+	// 請求・回収
+	GetMonthlyCollections(ctx context.Context, startMonth, endMonth string) (*dto.MonthlyCollectionTrendResponse, error)
+	GetClientRevenueRanking(ctx context.Context, startMonth, endMonth string, limit int) (*dto.RevenueRankingResponse, error)
+	GetProjectRevenueRanking(ctx context.Context, startMonth, endMonth string, limit int) (*dto.RevenueRankingResponse, error)
+	GetUpcomingBillings(ctx context.Context, billingMonth string) ([]dto.UpcomingBillingDTO, error)
+}
+
+// accountingAnalyticsService 経理分析サービス実装
+type accountingAnalyticsService struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAccountingAnalyticsService 経理分析サービスのコンストラクタ
+func NewAccountingAnalyticsService(db *gorm.DB, logger *zap.Logger) AccountingAnalyticsServiceInterface {
+	return &accountingAnalyticsService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetDashboard 対象月の請求・回収と基準日時点の売掛金から経理ダッシュボードを作成
+func (s *accountingAnalyticsService) GetDashboard(ctx context.Context, month string, asOf time.Time) (*dto.AccountingDashboardResponse, error) {
+	target, err := parseAnalyticsMonth(month, "対象月")
+	if err != nil {
+		return nil, err
+	}
+
+	trend, err := s.GetMonthlyCollections(ctx, target.AddDate(0, -(dashboardTrendMonths-1), 0).Format("2006-01"), month)
+	if err != nil {
+		return nil, err
+	}
+	aging, receivables, err := s.receivablesAging(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	dso, err := s.calculateDSO(ctx, asOf, defaultDSOPeriodDays, aging.TotalOutstanding)
+	if err != nil {
+		return nil, err
+	}
+	ranking, err := s.GetClientRevenueRanking(ctx, month, month, dashboardRankingLimit)
+	if err != nil {
+		return nil, err
+	}
+	upcoming, err := s.GetUpcomingBillings(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var activeClients int64
+	if err := s.issuedInvoices(ctx).
+		Where("billing_month = ?", month).
+		Distinct("client_id").
+		Count(&activeClients).Error; err != nil {
+		s.logger.Error("Failed to count billed clients", zap.String("month", month), zap.Error(err))
+		return nil, fmt.Errorf("請求先の集計に失敗しました: %w", err)
+	}
+
+	current := trend.Months[len(trend.Months)-1]
+	kpis := dto.AccountingKPIDTO{
+		BilledAmount:      current.Billed,
+		CollectedAmount:   current.Collected,
+		OutstandingAmount: aging.TotalOutstanding,
+		OverdueAmount:     aging.OverdueAmount,
+		DSO:               dso.DSO,
+		CollectionRate:    current.CollectionRate,
+		InvoiceCount:      current.InvoiceCount,
+		ActiveClientCount: int(activeClients),
+	}
+	if current.InvoiceCount > 0 {
+		kpis.AverageInvoiceAmount = current.Billed.MulDiv(1, float64(current.InvoiceCount), money.RoundingHalfUp)
+	}
+	if len(trend.Months) > 1 {
+		previous := trend.Months[len(trend.Months)-2]
+		if previous.Billed.Sign() > 0 {
+			kpis.MonthOverMonthGrowth = analyticsPercentage(current.Billed.Sub(previous.Billed), previous.Billed)
+		}
+	}
+	for _, r := range receivables {
+		if model.DaysOverdue(r.DueDate, asOf) > 0 {
+			kpis.OverdueInvoiceCount++
+		}
+	}
+
+	return &dto.AccountingDashboardResponse{
+		Month:            month,
+		KPIs:             kpis,
+		Aging:            *aging,
+		MonthlyTrend:     trend.Months,
+		ClientRanking:    ranking.Ranking,
+		UpcomingBillings: upcoming,
+		LastUpdated:      time.Now(),
+	}, nil
+}
+
+// GetReceivablesAging 基準日時点の売掛金年齢表（残高は現在の消込状況から計算する）
+func (s *accountingAnalyticsService) GetReceivablesAging(ctx context.Context, asOf time.Time) (*dto.ReceivablesAgingResponse, error) {
+	aging, _, err := s.receivablesAging(ctx, asOf)
+	return aging, err
+}
+
+// receivablesAging 売掛金年齢表と集計元の売掛金
+func (s *accountingAnalyticsService) receivablesAging(ctx context.Context, asOf time.Time) (*dto.ReceivablesAgingResponse, []model.Receivable, error) {
+	receivables, err := s.loadReceivables(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, clients := model.BuildAgingReport(receivables, asOf)
+	buckets := make([]dto.AgingBucketDTO, 0, len(model.AgingBuckets))
+	for _, bucket := range model.AgingBuckets {
+		buckets = append(buckets, dto.AgingBucketDTO{
+			Bucket: string(bucket),
+			Label:  bucket.Label(),
+			Amount: total.Amount(bucket),
+		})
+	}
+
+	return &dto.ReceivablesAgingResponse{
+		AsOf:             asOf.Format("2006-01-02"),
+		TotalOutstanding: total.Total,
+		OverdueAmount:    total.Overdue(),
+		InvoiceCount:     total.InvoiceCount,
+		Buckets:          buckets,
+		Clients:          clients,
+	}, receivables, nil
+}
+
+// GetDSO 売上債権回転日数（基準日時点の売掛金残高 ÷ 直近の期間の請求額 × 期間の日数）
+func (s *accountingAnalyticsService) GetDSO(ctx context.Context, asOf time.Time, periodDays int) (*dto.DSODTO, error) {
+	receivables, err := s.loadReceivables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var outstanding money.Amount
+	for _, r := range receivables {
+		outstanding = outstanding.Add(r.Outstanding)
+	}
+	return s.calculateDSO(ctx, asOf, periodDays, outstanding)
+}
+
+// calculateDSO 売掛金残高と直近の期間の請求額からDSOを計算
+func (s *accountingAnalyticsService) calculateDSO(ctx context.Context, asOf time.Time, periodDays int, outstanding money.Amount) (*dto.DSODTO, error) {
+	if periodDays <= 0 {
+		periodDays = defaultDSOPeriodDays
+	}
+
+	end := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -periodDays)
+	var billed money.Amount
+	if err := s.issuedInvoices(ctx).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Where("invoice_date >= ? AND invoice_date < ?", start, end).
+		Scan(&billed).Error; err != nil {
+		s.logger.Error("Failed to sum billed amount for DSO", zap.Error(err))
+		return nil, fmt.Errorf("請求額の集計に失敗しました: %w", err)
+	}
+
+	return &dto.DSODTO{
+		AsOf:        asOf.Format("2006-01-02"),
+		PeriodDays:  periodDays,
+		Receivables: outstanding,
+		Billed:      billed,
+		DSO:         model.CalculateDSO(outstanding, billed, periodDays),
+	}, nil
+}
+
+// GetPaymentStatus 請求月の請求書ごとの入金状況（status: paid, unpaid, overdue で絞り込み）
+func (s *accountingAnalyticsService) GetPaymentStatus(ctx context.Context, month, status string, asOf time.Time) (*dto.PaymentStatusResponse, error) {
+	if _, err := parseAnalyticsMonth(month, "対象月"); err != nil {
+		return nil, err
+	}
+
+	var invoices []*model.Invoice
+	if err := s.issuedInvoices(ctx).
+		Preload("Client").
+		Where("billing_month = ?", month).
+		Order("due_date, invoice_number").
+		Find(&invoices).Error; err != nil {
+		s.logger.Error("Failed to get invoices for payment status", zap.String("month", month), zap.Error(err))
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+
+	ids := make([]string, 0, len(invoices))
+	for _, inv := range invoices {
+		ids = append(ids, inv.ID)
+	}
+	settled, err := allocatedAmounts(s.db.WithContext(ctx), ids, model.BankTransactionStatusConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PaymentStatusResponse{
+		Month: month,
+		Items: make([]dto.PaymentStatusItemDTO, 0, len(invoices)),
+	}
+	for _, inv := range invoices {
+		item := dto.PaymentStatusItemDTO{
+			InvoiceID:     inv.ID,
+			InvoiceNumber: inv.InvoiceNumber,
+			ClientID:      inv.ClientID,
+			ClientName:    inv.Client.CompanyName,
+			TotalAmount:   inv.TotalAmount,
+			Collected:     money.Yen(settled[inv.ID]),
+			DueDate:       inv.DueDate.Format("2006-01-02"),
+			DaysOverdue:   model.DaysOverdue(inv.DueDate, asOf),
+			Status:        "unpaid",
+			PaidDate:      inv.PaidDate,
+		}
+		switch {
+		case inv.Status == model.InvoiceStatusPaid:
+			// 消込を経ずに支払済みにした請求書は全額回収済みとして扱う
+			item.Status = "paid"
+			item.Collected = inv.TotalAmount
+		case item.DaysOverdue > 0:
+			item.Status = "overdue"
+		}
+		if item.DaysOverdue < 0 || item.Status == "paid" {
+			item.DaysOverdue = 0
+		}
+		item.Outstanding = inv.TotalAmount.Sub(item.Collected)
+		if status != "" && item.Status != status {
+			continue
+		}
+
+		response.TotalAmount = response.TotalAmount.Add(item.TotalAmount)
+		response.TotalCollected = response.TotalCollected.Add(item.Collected)
+		response.TotalOutstanding = response.TotalOutstanding.Add(item.Outstanding)
+		response.Items = append(response.Items, item)
+	}
+	return response, nil
+}
+
+// GetMonthlyCollections 月別の請求額（請求月）と回収額（入金日）の推移
+// 回収額は確定した消込の額（振込手数料を含む）と、消込を経ずに支払済みにした請求書の額の合計
+func (s *accountingAnalyticsService) GetMonthlyCollections(ctx context.Context, startMonth, endMonth string) (*dto.MonthlyCollectionTrendResponse, error) {
+	start, end, err := parseAnalyticsMonthRange(startMonth, endMonth)
+	if err != nil {
+		return nil, err
+	}
+	next := end.AddDate(0, 1, 0)
+
+	var billed []struct {
+		Month  string
+		Amount money.Amount
+		Count  int
+	}
+	if err := s.issuedInvoices(ctx).
+		Select("billing_month AS month, SUM(total_amount) AS amount, COUNT(*) AS count").
+		Where("billing_month BETWEEN ? AND ?", startMonth, endMonth).
+		Group("billing_month").
+		Scan(&billed).Error; err != nil {
+		s.logger.Error("Failed to sum billed amount by month", zap.Error(err))
+		return nil, fmt.Errorf("請求額の集計に失敗しました: %w", err)
+	}
+
+	var reconciled []struct {
+		Month  string
+		Amount int64
+	}
+	if err := s.db.WithContext(ctx).
+		Table("bank_transaction_allocations AS a").
+		Select("TO_CHAR(bt.transaction_date, 'YYYY-MM') AS month, SUM(a.amount + a.fee_amount) AS amount").
+		Joins("JOIN bank_transactions bt ON bt.id = a.bank_transaction_id").
+		Where("bt.status = ?", model.BankTransactionStatusConfirmed).
+		Where("bt.transaction_date >= ? AND bt.transaction_date < ?", start, next).
+		Group("TO_CHAR(bt.transaction_date, 'YYYY-MM')").
+		Scan(&reconciled).Error; err != nil {
+		s.logger.Error("Failed to sum reconciled amount by month", zap.Error(err))
+		return nil, fmt.Errorf("回収額の集計に失敗しました: %w", err)
+	}
+
+	var manual []struct {
+		Month  string
+		Amount money.Amount
+	}
+	if err := s.db.WithContext(ctx).
+		Model(&model.Invoice{}).
+		Select("TO_CHAR(paid_date, 'YYYY-MM') AS month, SUM(total_amount) AS amount").
+		Where("invoice_type = ? AND status = ?", model.InvoiceTypeStandard, model.InvoiceStatusPaid).
+		Where("paid_date >= ? AND paid_date < ?", start, next).
+		Where(`NOT EXISTS (SELECT 1 FROM bank_transaction_allocations a
+			JOIN bank_transactions bt ON bt.id = a.bank_transaction_id
+			WHERE a.invoice_id = invoices.id AND bt.status = ?)`, model.BankTransactionStatusConfirmed).
+		Group("TO_CHAR(paid_date, 'YYYY-MM')").
+		Scan(&manual).Error; err != nil {
+		s.logger.Error("Failed to sum manually settled invoices by month", zap.Error(err))
+		return nil, fmt.Errorf("回収額の集計に失敗しました: %w", err)
+	}
+
+	months := make(map[string]*dto.MonthlyCollectionDTO)
+	response := &dto.MonthlyCollectionTrendResponse{StartMonth: startMonth, EndMonth: endMonth}
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		response.Months = append(response.Months, dto.MonthlyCollectionDTO{Month: m.Format("2006-01")})
+	}
+	for i := range response.Months {
+		months[response.Months[i].Month] = &response.Months[i]
+	}
+	for _, row := range billed {
+		if m, ok := months[row.Month]; ok {
+			m.Billed, m.InvoiceCount = row.Amount, row.Count
+		}
+	}
+	for _, row := range reconciled {
+		if m, ok := months[row.Month]; ok {
+			m.Collected = m.Collected.Add(money.Yen(row.Amount))
+		}
+	}
+	for _, row := range manual {
+		if m, ok := months[row.Month]; ok {
+			m.Collected = m.Collected.Add(row.Amount)
+		}
+	}
+	for i := range response.Months {
+		m := &response.Months[i]
+		m.CollectionRate = analyticsPercentage(m.Collected, m.Billed)
+		response.TotalBilled = response.TotalBilled.Add(m.Billed)
+		response.TotalCollected = response.TotalCollected.Add(m.Collected)
+	}
+	return response, nil
+}
+
+// GetClientRevenueRanking 期間の取引先別売上（税抜）ランキング
+func (s *accountingAnalyticsService) GetClientRevenueRanking(ctx context.Context, startMonth, endMonth string, limit int) (*dto.RevenueRankingResponse, error) {
+	if _, _, err := parseAnalyticsMonthRange(startMonth, endMonth); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).
+		Table("invoices AS i").
+		Joins("LEFT JOIN clients c ON c.id = i.client_id").
+		Where("i.deleted_at IS NULL AND i.invoice_type = ? AND i.status IN ?", model.InvoiceTypeStandard, issuedInvoiceStatuses).
+		Where("i.billing_month BETWEEN ? AND ?", startMonth, endMonth)
+
+	var rows []revenueRankingRow
+	if err := query.Session(&gorm.Session{}).
+		Select("i.client_id AS id, c.company_name AS name, SUM(i.subtotal) AS revenue, COUNT(*) AS invoice_count").
+		Group("i.client_id, c.company_name").
+		Order("revenue DESC, c.company_name").
+		Limit(analyticsLimit(limit)).
+		Scan(&rows).Error; err != nil {
+		s.logger.Error("Failed to rank clients by revenue", zap.Error(err))
+		return nil, fmt.Errorf("取引先別売上の集計に失敗しました: %w", err)
+	}
+
+	var total money.Amount
+	if err := query.Session(&gorm.Session{}).Select("COALESCE(SUM(i.subtotal), 0)").Scan(&total).Error; err != nil {
+		s.logger.Error("Failed to sum revenue for ranking", zap.Error(err))
+		return nil, fmt.Errorf("売上の集計に失敗しました: %w", err)
+	}
+
+	return buildRevenueRanking(startMonth, endMonth, total, rows), nil
+}
+
+// GetProjectRevenueRanking 期間の案件別売上（請求明細の税抜額）ランキング
+func (s *accountingAnalyticsService) GetProjectRevenueRanking(ctx context.Context, startMonth, endMonth string, limit int) (*dto.RevenueRankingResponse, error) {
+	if _, _, err := parseAnalyticsMonthRange(startMonth, endMonth); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).
+		Table("invoice_details AS d").
+		Joins("JOIN invoices i ON i.id = d.invoice_id AND i.deleted_at IS NULL").
+		Joins("LEFT JOIN projects p ON p.id = d.project_id").
+		Joins("LEFT JOIN clients c ON c.id = i.client_id").
+		Where("d.deleted_at IS NULL AND d.project_id IS NOT NULL").
+		Where("i.invoice_type = ? AND i.status IN ?", model.InvoiceTypeStandard, issuedInvoiceStatuses).
+		Where("i.billing_month BETWEEN ? AND ?", startMonth, endMonth)
+
+	var rows []revenueRankingRow
+	if err := query.Session(&gorm.Session{}).
+		Select("d.project_id AS id, p.project_name AS name, c.company_name AS client_name, " +
+			"SUM(d.amount) AS revenue, COUNT(DISTINCT i.id) AS invoice_count").
+		Group("d.project_id, p.project_name, c.company_name").
+		Order("revenue DESC, p.project_name").
+		Limit(analyticsLimit(limit)).
+		Scan(&rows).Error; err != nil {
+		s.logger.Error("Failed to rank projects by revenue", zap.Error(err))
+		return nil, fmt.Errorf("案件別売上の集計に失敗しました: %w", err)
+	}
+
+	var total money.Amount
+	if err := query.Session(&gorm.Session{}).Select("COALESCE(SUM(d.amount), 0)").Scan(&total).Error; err != nil {
+		s.logger.Error("Failed to sum revenue for ranking", zap.Error(err))
+		return nil, fmt.Errorf("売上の集計に失敗しました: %w", err)
+	}
+
+	return buildRevenueRanking(startMonth, endMonth, total, rows), nil
+}
+
+// GetUpcomingBillings 請求月に稼働中のアサインがあり、まだ請求書を作成していない取引先
+// 見込額は請求月末時点の単価（単価履歴がなければアサインの単価）の合計で、精算・日割りは反映しない
+func (s *accountingAnalyticsService) GetUpcomingBillings(ctx context.Context, billingMonth string) ([]dto.UpcomingBillingDTO, error) {
+	month, err := parseAnalyticsMonth(billingMonth, "請求月")
+	if err != nil {
+		return nil, err
+	}
+	monthEnd := month.AddDate(0, 1, -1)
+
+	var rows []struct {
+		ClientID          string
+		ClientName        string
+		BillingClosingDay *int
+		AssignmentCount   int
+		EstimatedAmount   money.Amount
+	}
+	err = s.db.WithContext(ctx).
+		Table("project_assignments AS pa").
+		Select(`p.client_id, c.company_name AS client_name, c.billing_closing_day,
+			COUNT(pa.id) AS assignment_count,
+			SUM(COALESCE((SELECT r.billing_rate FROM project_assignment_rates r
+				WHERE r.assignment_id = pa.id AND r.deleted_at IS NULL
+				AND r.effective_from <= ? AND (r.effective_to IS NULL OR r.effective_to >= ?)
+				ORDER BY r.effective_from DESC LIMIT 1), pa.billing_rate, 0)) AS estimated_amount`, monthEnd, monthEnd).
+		Joins("JOIN projects p ON p.id = pa.project_id AND p.deleted_at IS NULL").
+		Joins("JOIN clients c ON c.id = p.client_id AND c.deleted_at IS NULL").
+		Where("pa.deleted_at IS NULL AND pa.start_date <= ? AND (pa.end_date IS NULL OR pa.end_date >= ?)", monthEnd, month).
+		Where(`NOT EXISTS (SELECT 1 FROM invoices i
+			WHERE i.client_id = p.client_id AND i.billing_month = ? AND i.status <> ? AND i.deleted_at IS NULL)`,
+			billingMonth, model.InvoiceStatusCancelled).
+		Group("p.client_id, c.company_name, c.billing_closing_day").
+		Scan(&rows).Error
+	if err != nil {
+		s.logger.Error("Failed to get upcoming billings", zap.String("billing_month", billingMonth), zap.Error(err))
+		return nil, fmt.Errorf("請求予定の取得に失敗しました: %w", err)
+	}
+
+	upcoming := make([]dto.UpcomingBillingDTO, 0, len(rows))
+	for _, row := range rows {
+		upcoming = append(upcoming, dto.UpcomingBillingDTO{
+			ClientID:        row.ClientID,
+			ClientName:      row.ClientName,
+			BillingMonth:    billingMonth,
+			ClosingDate:     billingClosingDate(month.Year(), int(month.Month()), row.BillingClosingDay).Format("2006-01-02"),
+			AssignmentCount: row.AssignmentCount,
+			EstimatedAmount: row.EstimatedAmount,
+		})
+	}
+	// 締め日の早い順（同日は取引先名順）
+	sort.Slice(upcoming, func(i, j int) bool {
+		if upcoming[i].ClosingDate != upcoming[j].ClosingDate {
+			return upcoming[i].ClosingDate < upcoming[j].ClosingDate
+		}
+		return upcoming[i].ClientName < upcoming[j].ClientName
+	})
+	return upcoming, nil
+}
+
+// loadReceivables 入金待ちの請求書の未回収残高（確定した消込を差し引く）
+func (s *accountingAnalyticsService) loadReceivables(ctx context.Context) ([]model.Receivable, error) {
+	var invoices []*model.Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Client").
+		Where("invoice_type = ? AND status IN ?", model.InvoiceTypeStandard,
+			[]model.InvoiceStatus{model.InvoiceStatusSent, model.InvoiceStatusOverdue}).
+		Order("due_date").
+		Find(&invoices).Error; err != nil {
+		s.logger.Error("Failed to get open invoices", zap.Error(err))
+		return nil, fmt.Errorf("入金待ちの請求書の取得に失敗しました: %w", err)
+	}
+
+	ids := make([]string, 0, len(invoices))
+	for _, inv := range invoices {
+		ids = append(ids, inv.ID)
+	}
+	settled, err := allocatedAmounts(s.db.WithContext(ctx), ids, model.BankTransactionStatusConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	receivables := make([]model.Receivable, 0, len(invoices))
+	for _, inv := range invoices {
+		outstanding := inv.TotalAmount.Sub(money.Yen(settled[inv.ID]))
+		if outstanding.Sign() <= 0 {
+			continue
+		}
+		receivables = append(receivables, model.Receivable{
+			InvoiceID:     inv.ID,
+			InvoiceNumber: inv.InvoiceNumber,
+			ClientID:      inv.ClientID,
+			ClientName:    inv.Client.CompanyName,
+			DueDate:       inv.DueDate,
+			Outstanding:   outstanding,
+		})
+	}
+	return receivables, nil
+}
+
+// issuedInvoices 売上の集計対象の請求書
+func (s *accountingAnalyticsService) issuedInvoices(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).
+		Model(&model.Invoice{}).
+		Where("invoice_type = ? AND status IN ?", model.InvoiceTypeStandard, issuedInvoiceStatuses)
+}
+
+// revenueRankingRow 売上ランキングの集計結果
+type revenueRankingRow struct {
+	ID           string
+	Name         string
+	ClientName   string
+	Revenue      money.Amount
+	InvoiceCount int
+}
+
+// buildRevenueRanking 集計結果に順位と構成比を付ける
+func buildRevenueRanking(startMonth, endMonth string, total money.Amount, rows []revenueRankingRow) *dto.RevenueRankingResponse {
+	response := &dto.RevenueRankingResponse{
+		StartMonth: startMonth,
+		EndMonth:   endMonth,
+		Total:      total,
+		Ranking:    make([]dto.RevenueRankingDTO, 0, len(rows)),
+	}
+	for i, row := range rows {
+		response.Ranking = append(response.Ranking, dto.RevenueRankingDTO{
+			Rank:         i + 1,
+			ID:           row.ID,
+			Name:         row.Name,
+			ClientName:   row.ClientName,
+			Revenue:      row.Revenue,
+			InvoiceCount: row.InvoiceCount,
+			Share:        analyticsPercentage(row.Revenue, total),
+		})
+	}
+	return response
+}
+
+// analyticsPercentage 割合（%、小数点第1位まで）。分母が0以下の場合は0
+func analyticsPercentage(part, whole money.Amount) float64 {
+	if whole.Sign() <= 0 {
+		return 0
+	}
+	return math.Round(part.Float64()/whole.Float64()*1000) / 10
+}
+
+// analyticsLimit ランキングの件数（既定10件、最大50件）
+func analyticsLimit(limit int) int {
+	if limit <= 0 {
+		return 10
+	}
+	if limit > 50 {
+		return 50
+	}
+	return limit
+}
+
+// parseAnalyticsMonth YYYY-MM形式の月をパース
+func parseAnalyticsMonth(month, label string) (time.Time, error) {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+			fmt.Sprintf("%sはYYYY-MM形式で指定してください", label))
+	}
+	return t, nil
+}
+
+// parseAnalyticsMonthRange 集計期間の開始月・終了月をパース（最大24ヶ月）
+func parseAnalyticsMonthRange(startMonth, endMonth string) (time.Time, time.Time, error) {
+	start, err := parseAnalyticsMonth(startMonth, "開始月")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseAnalyticsMonth(endMonth, "終了月")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "開始月は終了月以前を指定してください")
+	}
+	if !end.Before(start.AddDate(0, maxAnalyticsMonths, 0)) {
+		return time.Time{}, time.Time{}, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+			fmt.Sprintf("集計期間は%dヶ月以内で指定してください", maxAnalyticsMonths))
+	}
+	return start, end, nil
+}
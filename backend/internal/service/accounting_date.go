@@ -0,0 +1,22 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+)
+
+// accountingDateLayout 経理系のリクエストで受け付ける日付の形式
+const accountingDateLayout = "2006-01-02"
+
+// parseAccountingDate YYYY-MM-DD形式の日付をローカル時刻でパース
+// 形式が正しくない場合はlabelを含むAccountingErrorを返す
+func parseAccountingDate(value, label string) (time.Time, error) {
+	t, err := time.ParseInLocation(accountingDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+			fmt.Sprintf("%sはYYYY-MM-DD形式で指定してください", label))
+	}
+	return t, nil
+}
@@ -248,6 +248,14 @@ func (s *adminWeeklyReportService) ApproveWeeklyReport(ctx context.Context, repo
     report.CommentedBy = &approverID
     report.CommentedAt = &now
 
+    // 上長の代理で承認した場合は本来の承認者を記録
+    onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, report.UserID, approverID, model.ApprovalDelegationScopeWeeklyReport)
+    if err != nil {
+        tx.Rollback()
+        return err
+    }
+    report.OnBehalfOfID = onBehalfOfID
+
     if err := tx.Save(&report).Error; err != nil {
         tx.Rollback()
         s.logger.Error("Failed to approve weekly report", zap.Error(err))
@@ -299,6 +307,14 @@ func (s *adminWeeklyReportService) RejectWeeklyReport(ctx context.Context, repor
     report.CommentedBy = &approverID
     report.CommentedAt = &now
 
+    // 上長の代理で却下した場合は本来の承認者を記録
+    onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, report.UserID, approverID, model.ApprovalDelegationScopeWeeklyReport)
+    if err != nil {
+        tx.Rollback()
+        return err
+    }
+    report.OnBehalfOfID = onBehalfOfID
+
     if err := tx.Save(&report).Error; err != nil {
         tx.Rollback()
         s.logger.Error("Failed to reject weekly report", zap.Error(err))
@@ -347,6 +363,14 @@ func (s *adminWeeklyReportService) ReturnWeeklyReport(ctx context.Context, repor
     report.CommentedBy = &approverID
     report.CommentedAt = &now
 
+    // 上長の代理で差し戻した場合は本来の承認者を記録
+    onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, report.UserID, approverID, model.ApprovalDelegationScopeWeeklyReport)
+    if err != nil {
+        tx.Rollback()
+        return err
+    }
+    report.OnBehalfOfID = onBehalfOfID
+
     if err := tx.Save(&report).Error; err != nil {
         tx.Rollback()
         s.logger.Error("Failed to return weekly report", zap.Error(err))
@@ -3,8 +3,8 @@ package service_test
 import (
 	"context"
 	"testing"
-	"time"
 
+	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/repository"
 	"github.com/duesk/monstera/internal/service"
@@ -19,31 +19,29 @@ import (
 func setupAlertTestService(t *testing.T) (
 	service.AlertService,
 	*MockAlertSettingsRepository,
-	*MockAlertHistoryRepository,
-	*MockWeeklyReportRepository,
-	*MockNotificationRepository,
+	*MockAlertUserRepository,
 ) {
 	logger := zap.NewNop()
 
 	mockAlertSettingsRepo := new(MockAlertSettingsRepository)
-	mockAlertHistoryRepo := new(MockAlertHistoryRepository)
-	mockWeeklyReportRepo := new(MockWeeklyReportRepository)
-	mockNotificationRepo := new(MockNotificationRepository)
+	mockUserRepo := new(MockAlertUserRepository)
 
 	alertService := service.NewAlertService(
+		nil,
+		nil,
+		nil,
 		mockAlertSettingsRepo,
-		mockAlertHistoryRepo,
-		mockWeeklyReportRepo,
-		mockNotificationRepo,
+		repository.WeeklyReportRepository{},
+		mockUserRepo,
 		logger,
 	)
 
-	return alertService, mockAlertSettingsRepo, mockAlertHistoryRepo, mockWeeklyReportRepo, mockNotificationRepo
+	return alertService, mockAlertSettingsRepo, mockUserRepo
 }
 
 func TestAlertService_GetAlertSettings(t *testing.T) {
 	t.Run("正常にアラート設定を取得できる", func(t *testing.T) {
-		service, mockAlertSettingsRepo, _, _, _ := setupAlertTestService(t)
+		service, mockAlertSettingsRepo, _ := setupAlertTestService(t)
 
 		expectedSettings := &model.AlertSettings{
 			ID:                          uuid.New().String(),
@@ -69,7 +67,7 @@ func TestAlertService_GetAlertSettings(t *testing.T) {
 	})
 
 	t.Run("アラート設定が存在しない場合エラーを返す", func(t *testing.T) {
-		service, mockAlertSettingsRepo, _, _, _ := setupAlertTestService(t)
+		service, mockAlertSettingsRepo, _ := setupAlertTestService(t)
 
 		mockAlertSettingsRepo.On("GetSettings", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
 
@@ -83,7 +81,7 @@ func TestAlertService_GetAlertSettings(t *testing.T) {
 	})
 
 	t.Run("リポジトリでエラーが発生した場合エラーを返す", func(t *testing.T) {
-		service, mockAlertSettingsRepo, _, _, _ := setupAlertTestService(t)
+		service, mockAlertSettingsRepo, _ := setupAlertTestService(t)
 
 		mockAlertSettingsRepo.On("GetSettings", mock.Anything).Return(nil, assert.AnError)
 
@@ -99,35 +97,46 @@ func TestAlertService_GetAlertSettings(t *testing.T) {
 
 func TestAlertService_UpdateAlertSettings(t *testing.T) {
 	t.Run("正常にアラート設定を更新できる", func(t *testing.T) {
-		service, mockAlertSettingsRepo, _, _, _ := setupAlertTestService(t)
+		service, mockAlertSettingsRepo, mockUserRepo := setupAlertTestService(t)
 
 		settingsID := uuid.New().String()
+		limit := 70
+		current := &model.AlertSettings{ID: settingsID, WeeklyHoursLimit: 60}
 		updates := map[string]interface{}{
 			"weekly_hours_limit": 70,
 			"updated_by":         "user123",
 		}
 
+		mockAlertSettingsRepo.On("GetSettings", mock.Anything).Return(current, nil)
+		mockUserRepo.On("FindByID", "user123").Return(&model.User{ID: "user123"}, nil)
 		mockAlertSettingsRepo.On("Update", mock.Anything, settingsID, updates).Return(nil)
 
-		err := service.UpdateAlertSettings(context.Background(), settingsID, updates)
+		settings, err := service.UpdateAlertSettings(context.Background(), settingsID, &dto.UpdateAlertSettingsRequest{WeeklyHoursLimit: &limit}, "user123")
 
 		assert.NoError(t, err)
+		assert.Equal(t, 70, settings.WeeklyHoursLimit)
+		assert.Equal(t, "user123", settings.UpdatedBy)
 		mockAlertSettingsRepo.AssertExpectations(t)
 	})
 
 	t.Run("リポジトリでエラーが発生した場合エラーを返す", func(t *testing.T) {
-		service, mockAlertSettingsRepo, _, _, _ := setupAlertTestService(t)
+		service, mockAlertSettingsRepo, mockUserRepo := setupAlertTestService(t)
 
 		settingsID := uuid.New().String()
+		limit := 70
 		updates := map[string]interface{}{
 			"weekly_hours_limit": 70,
+			"updated_by":         "user123",
 		}
 
+		mockAlertSettingsRepo.On("GetSettings", mock.Anything).Return(&model.AlertSettings{ID: settingsID}, nil)
+		mockUserRepo.On("FindByID", "user123").Return(&model.User{ID: "user123"}, nil)
 		mockAlertSettingsRepo.On("Update", mock.Anything, settingsID, updates).Return(assert.AnError)
 
-		err := service.UpdateAlertSettings(context.Background(), settingsID, updates)
+		settings, err := service.UpdateAlertSettings(context.Background(), settingsID, &dto.UpdateAlertSettingsRequest{WeeklyHoursLimit: &limit}, "user123")
 
 		assert.Error(t, err)
+		assert.Nil(t, settings)
 		assert.Contains(t, err.Error(), "アラート設定の更新に失敗しました")
 		mockAlertSettingsRepo.AssertExpectations(t)
 	})
@@ -177,183 +186,16 @@ func (m *MockAlertSettingsRepository) Delete(ctx context.Context, id string) err
 	return args.Error(0)
 }
 
-// MockAlertHistoryRepository モックリポジトリの実装
-type MockAlertHistoryRepository struct {
+// MockAlertUserRepository モックユーザーリポジトリの実装
+type MockAlertUserRepository struct {
 	mock.Mock
+	repository.UserRepository
 }
 
-func (m *MockAlertHistoryRepository) Create(ctx context.Context, alertHistory *model.AlertHistory) error {
-	args := m.Called(ctx, alertHistory)
-	return args.Error(0)
-}
-
-func (m *MockAlertHistoryRepository) CreateBatch(ctx context.Context, alertHistories []*model.AlertHistory) error {
-	args := m.Called(ctx, alertHistories)
-	return args.Error(0)
-}
-
-func (m *MockAlertHistoryRepository) GetByID(ctx context.Context, id string) (*model.AlertHistory, error) {
-	args := m.Called(ctx, id)
+func (m *MockAlertUserRepository) FindByID(id string) (*model.User, error) {
+	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*model.AlertHistory), args.Error(1)
-}
-
-func (m *MockAlertHistoryRepository) GetList(ctx context.Context, filters map[string]interface{}, page, limit int) ([]*model.AlertHistory, int64, error) {
-	args := m.Called(ctx, filters, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.AlertHistory), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockAlertHistoryRepository) GetByUser(ctx context.Context, userID string, filters map[string]interface{}, page, limit int) ([]*model.AlertHistory, int64, error) {
-	args := m.Called(ctx, userID, filters, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.AlertHistory), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockAlertHistoryRepository) GetByWeeklyReport(ctx context.Context, weeklyReportID string) ([]*model.AlertHistory, error) {
-	args := m.Called(ctx, weeklyReportID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*model.AlertHistory), args.Error(1)
-}
-
-func (m *MockAlertHistoryRepository) GetUnresolvedByUser(ctx context.Context, userID string, page, limit int) ([]*model.AlertHistory, int64, error) {
-	args := m.Called(ctx, userID, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.AlertHistory), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockAlertHistoryRepository) UpdateStatus(ctx context.Context, id string, status model.AlertStatus, resolvedBy *string) error {
-	args := m.Called(ctx, id, status, resolvedBy)
-	return args.Error(0)
-}
-
-func (m *MockAlertHistoryRepository) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-// MockWeeklyReportRepository モックリポジトリの実装
-type MockWeeklyReportRepository struct {
-	mock.Mock
-}
-
-func (m *MockWeeklyReportRepository) Create(ctx context.Context, report *model.WeeklyReport) error {
-	args := m.Called(ctx, report)
-	return args.Error(0)
-}
-
-func (m *MockWeeklyReportRepository) GetByID(ctx context.Context, id string) (*model.WeeklyReport, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*model.WeeklyReport), args.Error(1)
-}
-
-func (m *MockWeeklyReportRepository) GetByUserAndWeek(ctx context.Context, userID string, weekStart time.Time) (*model.WeeklyReport, error) {
-	args := m.Called(ctx, userID, weekStart)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*model.WeeklyReport), args.Error(1)
-}
-
-func (m *MockWeeklyReportRepository) GetByWeek(ctx context.Context, weekStart time.Time) ([]*model.WeeklyReport, error) {
-	args := m.Called(ctx, weekStart)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*model.WeeklyReport), args.Error(1)
-}
-
-func (m *MockWeeklyReportRepository) GetUserReports(ctx context.Context, userID string, filters map[string]interface{}, page, limit int) ([]*model.WeeklyReport, int64, error) {
-	args := m.Called(ctx, userID, filters, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.WeeklyReport), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockWeeklyReportRepository) GetList(ctx context.Context, filters map[string]interface{}, page, limit int) ([]*model.WeeklyReport, int64, error) {
-	args := m.Called(ctx, filters, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.WeeklyReport), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockWeeklyReportRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
-	args := m.Called(ctx, id, updates)
-	return args.Error(0)
-}
-
-func (m *MockWeeklyReportRepository) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-// MockNotificationRepository モックリポジトリの実装
-type MockNotificationRepository struct {
-	mock.Mock
-}
-
-func (m *MockNotificationRepository) Create(ctx context.Context, notification *model.Notification) error {
-	args := m.Called(ctx, notification)
-	return args.Error(0)
-}
-
-func (m *MockNotificationRepository) CreateBatch(ctx context.Context, notifications []*model.Notification) error {
-	args := m.Called(ctx, notifications)
-	return args.Error(0)
-}
-
-func (m *MockNotificationRepository) GetByID(ctx context.Context, id string) (*model.Notification, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*model.Notification), args.Error(1)
-}
-
-func (m *MockNotificationRepository) GetUnreadCount(ctx context.Context, userID string) (int64, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).(int64), args.Error(1)
-}
-
-func (m *MockNotificationRepository) GetList(ctx context.Context, userID string, filters map[string]interface{}, page, limit int) ([]*model.Notification, int64, error) {
-	args := m.Called(ctx, userID, filters, page, limit)
-	if args.Get(0) == nil {
-		return nil, 0, args.Error(2)
-	}
-	return args.Get(0).([]*model.Notification), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockNotificationRepository) MarkAsRead(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockNotificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
-	args := m.Called(ctx, userID)
-	return args.Error(0)
-}
-
-func (m *MockNotificationRepository) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockNotificationRepository) DeleteOldNotifications(ctx context.Context, days int) (int64, error) {
-	args := m.Called(ctx, days)
-	return args.Get(0).(int64), args.Error(1)
+	return args.Get(0).(*model.User), args.Error(1)
 }
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+var (
+	// ErrApprovalDelegationNotFound 代理承認が見つからない
+	ErrApprovalDelegationNotFound = errors.New("代理承認が見つかりません")
+	// ErrInvalidApprovalDelegation 代理承認の設定が不正
+	ErrInvalidApprovalDelegation = errors.New("代理承認の設定が不正です")
+)
+
+// ApprovalDelegationService 不在時の代理承認サービスのインターフェース
+type ApprovalDelegationService interface {
+	ListDelegations(ctx context.Context, filter repository.ApprovalDelegationFilter) (*dto.ApprovalDelegationsResponse, error)
+	CreateDelegation(ctx context.Context, userID string, req *dto.ApprovalDelegationRequest) (*dto.ApprovalDelegationResponse, error)
+	RevokeDelegation(ctx context.Context, delegationID string) error
+}
+
+// approvalDelegationService 不在時の代理承認サービスの実装
+type approvalDelegationService struct {
+	db             *gorm.DB
+	delegationRepo repository.ApprovalDelegationRepository
+	logger         *zap.Logger
+}
+
+// NewApprovalDelegationService 不在時の代理承認サービスのインスタンスを生成
+func NewApprovalDelegationService(db *gorm.DB, delegationRepo repository.ApprovalDelegationRepository, logger *zap.Logger) ApprovalDelegationService {
+	return &approvalDelegationService{
+		db:             db,
+		delegationRepo: delegationRepo,
+		logger:         logger,
+	}
+}
+
+// ListDelegations 代理承認を取得
+func (s *approvalDelegationService) ListDelegations(ctx context.Context, filter repository.ApprovalDelegationFilter) (*dto.ApprovalDelegationsResponse, error) {
+	delegations, err := s.delegationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("代理承認の取得に失敗しました: %w", err)
+	}
+
+	response := &dto.ApprovalDelegationsResponse{
+		Delegations: make([]dto.ApprovalDelegationResponse, len(delegations)),
+	}
+	for i := range delegations {
+		response.Delegations[i].FromModel(&delegations[i])
+	}
+	return response, nil
+}
+
+// CreateDelegation 代理承認を登録（承認者を省略した場合は登録者本人の代理承認）
+func (s *approvalDelegationService) CreateDelegation(ctx context.Context, userID string, req *dto.ApprovalDelegationRequest) (*dto.ApprovalDelegationResponse, error) {
+	startDate, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 開始日の形式が不正です", ErrInvalidApprovalDelegation)
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 終了日の形式が不正です", ErrInvalidApprovalDelegation)
+	}
+
+	delegation := &model.ApprovalDelegation{
+		DelegatorID: userID,
+		DelegateID:  strings.TrimSpace(req.DelegateID),
+		Scope:       model.ApprovalDelegationScopeAll,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Source:      model.ApprovalDelegationSourceManual,
+		Reason:      req.Reason,
+		CreatedBy:   userID,
+	}
+	if delegatorID := normalizeOptionalID(req.DelegatorID); delegatorID != nil {
+		delegation.DelegatorID = *delegatorID
+	}
+	if req.Scope != "" {
+		delegation.Scope = model.ApprovalDelegationScope(req.Scope)
+	}
+	if err := delegation.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidApprovalDelegation, err.Error())
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.User{}).
+		Where("id IN ?", []string{delegation.DelegatorID, delegation.DelegateID}).
+		Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("ユーザーの確認に失敗しました: %w", err)
+	}
+	if count != 2 {
+		return nil, fmt.Errorf("%w: 承認者または代理承認者が見つかりません", ErrInvalidApprovalDelegation)
+	}
+
+	if err := s.delegationRepo.Create(ctx, delegation); err != nil {
+		return nil, fmt.Errorf("代理承認の登録に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Approval delegation created",
+		zap.String("delegation_id", delegation.ID),
+		zap.String("delegator_id", delegation.DelegatorID),
+		zap.String("delegate_id", delegation.DelegateID),
+		zap.String("scope", string(delegation.Scope)),
+		zap.String("created_by", userID))
+
+	created, err := s.delegationRepo.GetByID(ctx, delegation.ID)
+	if err != nil {
+		created = delegation
+	}
+	var response dto.ApprovalDelegationResponse
+	response.FromModel(created)
+	return &response, nil
+}
+
+// RevokeDelegation 代理承認を取り消す
+func (s *approvalDelegationService) RevokeDelegation(ctx context.Context, delegationID string) error {
+	if err := s.delegationRepo.Revoke(ctx, delegationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrApprovalDelegationNotFound
+		}
+		return fmt.Errorf("代理承認の取り消しに失敗しました: %w", err)
+	}
+
+	s.logger.Info("Approval delegation revoked", zap.String("delegation_id", delegationID))
+	return nil
+}
+
+// delegatorIDsForDelegate 代理承認者が現在代理で承認できる承認者を取得
+func delegatorIDsForDelegate(ctx context.Context, db *gorm.DB, logger *zap.Logger, delegateID string, scope model.ApprovalDelegationScope) ([]string, error) {
+	now := time.Now()
+	delegations, err := repository.NewApprovalDelegationRepository(db, logger).GetActiveByDelegate(ctx, delegateID, scope, now)
+	if err != nil {
+		return nil, fmt.Errorf("代理承認の取得に失敗しました: %w", err)
+	}
+	return model.DelegatorIDsFor(delegations, delegateID, scope, now), nil
+}
+
+// resolveOnBehalfOf 申請者の上長に代わって承認する場合に上長のIDを取得
+// 承認者本人が上長の場合や、上長から代理承認を任されていない場合はnil
+func resolveOnBehalfOf(ctx context.Context, db *gorm.DB, logger *zap.Logger, requesterID, approverID string, scope model.ApprovalDelegationScope) (*string, error) {
+	var requester model.User
+	if err := db.WithContext(ctx).Select("id", "manager_id").Where("id = ?", requesterID).First(&requester).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("申請者の取得に失敗しました: %w", err)
+	}
+	if requester.ManagerID == nil || *requester.ManagerID == "" || *requester.ManagerID == approverID {
+		return nil, nil
+	}
+
+	delegatorIDs, err := delegatorIDsForDelegate(ctx, db, logger, approverID, scope)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegatorID := range delegatorIDs {
+		if delegatorID == *requester.ManagerID {
+			managerID := *requester.ManagerID
+			return &managerID, nil
+		}
+	}
+	return nil, nil
+}
+
+// registerLeaveDelegation 承認済みの休暇申請から休暇期間中の代理承認を登録
+// 申請者が承認者（部下がいる、経費申請の承認者に設定されている、部署長である）の場合に、申請者の上長を代理承認者とする
+func registerLeaveDelegation(ctx context.Context, tx *gorm.DB, logger *zap.Logger, request *model.LeaveRequest, approverID string) error {
+	if len(request.Details) == 0 {
+		return nil
+	}
+
+	delegationRepo := repository.NewApprovalDelegationRepository(tx, logger)
+	exists, err := delegationRepo.ExistsByLeaveRequestID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("代理承認の確認に失敗しました: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	isApprover, err := isApproverUser(ctx, tx, request.UserID)
+	if err != nil {
+		return err
+	}
+	if !isApprover {
+		return nil
+	}
+
+	var requester model.User
+	if err := tx.WithContext(ctx).Select("id", "manager_id").Where("id = ?", request.UserID).First(&requester).Error; err != nil {
+		return fmt.Errorf("申請者の取得に失敗しました: %w", err)
+	}
+	if requester.ManagerID == nil || *requester.ManagerID == "" || *requester.ManagerID == request.UserID {
+		logger.Info("Skip leave delegation: requester has no manager",
+			zap.String("leave_request_id", request.ID),
+			zap.String("user_id", request.UserID))
+		return nil
+	}
+
+	startDate, endDate := request.Details[0].LeaveDate, request.Details[0].LeaveDate
+	for _, detail := range request.Details[1:] {
+		if detail.LeaveDate.Before(startDate) {
+			startDate = detail.LeaveDate
+		}
+		if detail.LeaveDate.After(endDate) {
+			endDate = detail.LeaveDate
+		}
+	}
+
+	leaveRequestID := request.ID
+	delegation := &model.ApprovalDelegation{
+		DelegatorID:    request.UserID,
+		DelegateID:     *requester.ManagerID,
+		Scope:          model.ApprovalDelegationScopeAll,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Source:         model.ApprovalDelegationSourceLeaveRequest,
+		LeaveRequestID: &leaveRequestID,
+		Reason:         "休暇による不在",
+		CreatedBy:      approverID,
+	}
+	if err := delegationRepo.Create(ctx, delegation); err != nil {
+		return fmt.Errorf("代理承認の登録に失敗しました: %w", err)
+	}
+
+	logger.Info("Approval delegation registered from leave request",
+		zap.String("delegation_id", delegation.ID),
+		zap.String("leave_request_id", request.ID),
+		zap.String("delegator_id", delegation.DelegatorID),
+		zap.String("delegate_id", delegation.DelegateID))
+	return nil
+}
+
+// isApproverUser ユーザーが承認を担当しているかチェック
+func isApproverUser(ctx context.Context, db *gorm.DB, userID string) (bool, error) {
+	var count int64
+	if err := db.WithContext(ctx).Model(&model.User{}).Where("manager_id = ?", userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("部下の確認に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := db.WithContext(ctx).Model(&model.ExpenseApproverSetting{}).
+		Where("approver_id = ? AND is_active = ?", userID, true).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("承認者設定の確認に失敗しました: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := db.WithContext(ctx).Model(&model.Department{}).Where("manager_id = ? AND deleted_at IS NULL", userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("部署長の確認に失敗しました: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
+)
+
+// setupDelegationTest 代理承認・ユーザー・経費承認のテーブルを持つSQLiteで代理承認サービスを作成
+// 社員user-1の上長はmanager-1、manager-1の上長はdirector-1
+func setupDelegationTest(t *testing.T) (*approvalDelegationService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.ApprovalDelegation{}, &model.User{}, &model.ExpenseApproval{},
+		&model.ExpenseApproverSetting{}, &model.Department{}))
+
+	managerID, directorID := "manager-1", "director-1"
+	require.NoError(t, db.Omit(clause.Associations).Create([]*model.User{
+		{ID: "user-1", LastName: "山田", FirstName: "太郎", Email: "yamada@example.com", EmployeeNumber: "000001", ManagerID: &managerID},
+		{ID: managerID, LastName: "鈴木", FirstName: "課長", Email: "manager@example.com", EmployeeNumber: "000002", ManagerID: &directorID},
+		{ID: directorID, LastName: "佐藤", FirstName: "部長", Email: "director@example.com", EmployeeNumber: "000003"},
+	}).Error)
+
+	logger := zap.NewNop()
+	return &approvalDelegationService{db: db, delegationRepo: repository.NewApprovalDelegationRepository(db, logger), logger: logger}, db
+}
+
+// delegationRequest 昨日から1週間の代理承認の登録リクエスト
+func delegationRequest(delegateID, scope string) *dto.ApprovalDelegationRequest {
+	now := time.Now()
+	return &dto.ApprovalDelegationRequest{
+		DelegateID: delegateID,
+		Scope:      scope,
+		StartDate:  now.AddDate(0, 0, -1).Format("2006-01-02"),
+		EndDate:    now.AddDate(0, 0, 6).Format("2006-01-02"),
+	}
+}
+
+func TestApprovalDelegationService_CreateDelegation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("登録者本人の代理承認を登録し、期間中は対象の承認を代理で行える", func(t *testing.T) {
+		s, db := setupDelegationTest(t)
+
+		created, err := s.CreateDelegation(ctx, "manager-1", delegationRequest("director-1", "expense"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "manager-1", created.DelegatorID)
+		assert.Equal(t, string(model.ApprovalDelegationSourceManual), created.Source)
+
+		delegatorIDs, err := delegatorIDsForDelegate(ctx, db, zap.NewNop(), "director-1", model.ApprovalDelegationScopeExpense)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"manager-1"}, delegatorIDs)
+
+		// 経費申請だけを任されているため、休暇申請は代理で承認できない
+		delegatorIDs, err = delegatorIDsForDelegate(ctx, db, zap.NewNop(), "director-1", model.ApprovalDelegationScopeLeave)
+		require.NoError(t, err)
+		assert.Empty(t, delegatorIDs)
+	})
+
+	tests := []struct {
+		name string
+		req  *dto.ApprovalDelegationRequest
+	}{
+		{name: "自分自身を代理承認者に指定", req: delegationRequest("manager-1", "")},
+		{name: "存在しない代理承認者", req: delegationRequest("unknown-user", "")},
+		{
+			name: "終了日が開始日より前",
+			req:  &dto.ApprovalDelegationRequest{DelegateID: "director-1", StartDate: "2026-10-20", EndDate: "2026-10-19"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := setupDelegationTest(t)
+
+			_, err := s.CreateDelegation(ctx, "manager-1", tt.req)
+
+			assert.True(t, errors.Is(err, ErrInvalidApprovalDelegation))
+		})
+	}
+}
+
+func TestApprovalDelegationService_RevokeDelegation(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupDelegationTest(t)
+	created, err := s.CreateDelegation(ctx, "manager-1", delegationRequest("director-1", ""))
+	require.NoError(t, err)
+
+	require.NoError(t, s.RevokeDelegation(ctx, created.ID))
+
+	delegatorIDs, err := delegatorIDsForDelegate(ctx, db, zap.NewNop(), "director-1", model.ApprovalDelegationScopeExpense)
+	require.NoError(t, err)
+	assert.Empty(t, delegatorIDs)
+	assert.True(t, errors.Is(s.RevokeDelegation(ctx, created.ID), ErrApprovalDelegationNotFound))
+}
+
+func TestFindActionableApproval(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupDelegationTest(t)
+	_, err := s.CreateDelegation(ctx, "manager-1", delegationRequest("director-1", "expense"))
+	require.NoError(t, err)
+
+	expenseID := "expense-1"
+	approval := &model.ExpenseApproval{ExpenseID: &expenseID, ApproverID: "manager-1", ApprovalType: model.ApprovalTypeManager,
+		ApprovalOrder: 1, Status: model.ApprovalStatusPending}
+	require.NoError(t, db.Omit(clause.Associations).Create(approval).Error)
+	approvals := []model.ExpenseApproval{*approval}
+
+	t.Run("代理承認者は承認者に代わって承認し、本来の承認者を記録する", func(t *testing.T) {
+		target, err := findActionableApproval(ctx, db, zap.NewNop(), "user-1", approvals, "director-1")
+		require.NoError(t, err)
+		require.NotNil(t, target)
+		assert.Equal(t, approval.ID, target.ID)
+
+		target.Status = model.ApprovalStatusApproved
+		require.NoError(t, updateApprovalStatusBy(ctx, repository.NewExpenseApprovalRepository(db, zap.NewNop()), target, "代理で承認", "director-1"))
+
+		var updated model.ExpenseApproval
+		require.NoError(t, db.Where("id = ?", approval.ID).First(&updated).Error)
+		assert.Equal(t, model.ApprovalStatusApproved, updated.Status)
+		assert.Equal(t, "director-1", updated.ApproverID)
+		assert.Equal(t, "manager-1", *updated.OnBehalfOfID)
+	})
+
+	t.Run("自分の申請は代理でも承認できない", func(t *testing.T) {
+		target, err := findActionableApproval(ctx, db, zap.NewNop(), "director-1", approvals, "director-1")
+
+		require.NoError(t, err)
+		assert.Nil(t, target)
+	})
+
+	t.Run("代理承認を任されていない承認者の承認はできない", func(t *testing.T) {
+		target, err := findActionableApproval(ctx, db, zap.NewNop(), "user-1", approvals, "user-1")
+
+		require.NoError(t, err)
+		assert.Nil(t, target)
+	})
+}
+
+func TestResolveOnBehalfOf(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupDelegationTest(t)
+	_, err := s.CreateDelegation(ctx, "manager-1", delegationRequest("director-1", "leave"))
+	require.NoError(t, err)
+
+	onBehalfOf, err := resolveOnBehalfOf(ctx, db, zap.NewNop(), "user-1", "director-1", model.ApprovalDelegationScopeLeave)
+	require.NoError(t, err)
+	require.NotNil(t, onBehalfOf)
+	assert.Equal(t, "manager-1", *onBehalfOf)
+
+	// 上長本人の承認と、任されていない種類の承認は代理承認にならない
+	onBehalfOf, err = resolveOnBehalfOf(ctx, db, zap.NewNop(), "user-1", "manager-1", model.ApprovalDelegationScopeLeave)
+	require.NoError(t, err)
+	assert.Nil(t, onBehalfOf)
+	onBehalfOf, err = resolveOnBehalfOf(ctx, db, zap.NewNop(), "user-1", "director-1", model.ApprovalDelegationScopeWeeklyReport)
+	require.NoError(t, err)
+	assert.Nil(t, onBehalfOf)
+}
+
+func TestRegisterLeaveDelegation(t *testing.T) {
+	ctx := context.Background()
+	leaveRequest := func(userID string) *model.LeaveRequest {
+		return &model.LeaveRequest{
+			ID:     "leave-" + userID,
+			UserID: userID,
+			Details: []model.LeaveRequestDetail{
+				{LeaveDate: time.Date(2026, 11, 4, 0, 0, 0, 0, time.Local)},
+				{LeaveDate: time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local)},
+				{LeaveDate: time.Date(2026, 11, 3, 0, 0, 0, 0, time.Local)},
+			},
+		}
+	}
+
+	t.Run("部下のいる承認者の休暇期間は上長を代理承認者にする", func(t *testing.T) {
+		_, db := setupDelegationTest(t)
+
+		// 承認時の再実行でも二重に登録しない
+		for i := 0; i < 2; i++ {
+			require.NoError(t, registerLeaveDelegation(ctx, db, zap.NewNop(), leaveRequest("manager-1"), "director-1"))
+		}
+
+		var delegations []model.ApprovalDelegation
+		require.NoError(t, db.Find(&delegations).Error)
+		require.Len(t, delegations, 1)
+		assert.Equal(t, "manager-1", delegations[0].DelegatorID)
+		assert.Equal(t, "director-1", delegations[0].DelegateID)
+		assert.Equal(t, model.ApprovalDelegationSourceLeaveRequest, delegations[0].Source)
+		assert.Equal(t, "2026-11-02", delegations[0].StartDate.Format("2006-01-02"))
+		assert.Equal(t, "2026-11-04", delegations[0].EndDate.Format("2006-01-02"))
+	})
+
+	t.Run("承認を担当していない社員の休暇は代理承認を登録しない", func(t *testing.T) {
+		_, db := setupDelegationTest(t)
+
+		require.NoError(t, registerLeaveDelegation(ctx, db, zap.NewNop(), leaveRequest("user-1"), "manager-1"))
+
+		var count int64
+		require.NoError(t, db.Model(&model.ApprovalDelegation{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
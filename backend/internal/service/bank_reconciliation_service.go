@@ -0,0 +1,803 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/pkg/bankstatement"
+	"github.com/duesk/monstera/pkg/money"
+	"github.com/duesk/monstera/pkg/reconciliation"
+)
+
+// bankMatchTypeManual 消込確定時に割当を手動で指定した場合の照合種別
+const bankMatchTypeManual = "manual"
+
+// bankPaymentMethod 入金消込で支払済みにした請求書の支払方法
+const bankPaymentMethod = "bank_transfer"
+
+// BankReconciliationServiceInterface 入金消込サービスインターフェース
+type BankReconciliationServiceInterface interface {
+	// 明細取込
+	ImportStatement(ctx context.Context, userID, fileName string, r io.Reader, req *dto.ImportBankStatementRequest) (*dto.BankStatementImportDTO, error)
+	ListImports(ctx context.Context, page, limit int) ([]*dto.BankStatementImportDTO, int64, error)
+
+	// 入出金明細
+	ListTransactions(ctx context.Context, req *dto.BankTransactionSearchRequest) ([]*dto.BankTransactionDTO, int64, error)
+	GetTransaction(ctx context.Context, transactionID string) (*dto.BankTransactionDTO, error)
+	GetReviewQueue(ctx context.Context, page, limit int) ([]*dto.BankTransactionDTO, int64, error)
+
+	// 消込
+	ConfirmMatch(ctx context.Context, userID, transactionID string, req *dto.ConfirmBankMatchRequest) (*dto.BankTransactionDTO, error)
+	IgnoreTransaction(ctx context.Context, userID, transactionID string, req *dto.IgnoreBankTransactionRequest) (*dto.BankTransactionDTO, error)
+}
+
+// bankReconciliationService 入金消込サービス実装
+type bankReconciliationService struct {
+	db     *gorm.DB
+	repo   repository.BankReconciliationRepository
+	logger *zap.Logger
+}
+
+// NewBankReconciliationService 入金消込サービスのコンストラクタ
+func NewBankReconciliationService(
+	db *gorm.DB,
+	repo repository.BankReconciliationRepository,
+	logger *zap.Logger,
+) BankReconciliationServiceInterface {
+	return &bankReconciliationService{
+		db:     db,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ImportStatement 入出金明細を取込み、入金を請求書と照合する
+// 取込済みの明細は読み飛ばし、一意に照合できなかった入金は確認待ちにする
+func (s *bankReconciliationService) ImportStatement(ctx context.Context, userID, fileName string, r io.Reader, req *dto.ImportBankStatementRequest) (*dto.BankStatementImportDTO, error) {
+	stmt, err := parseBankStatement(r, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 二重取込の判定
+	keys := bankTransactionDedupeKeys(stmt)
+	existing, err := s.repo.FindExistingDedupeKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := reconciliation.NewMatcher()
+	if req.MaxBankFee != nil {
+		matcher.MaxBankFee = *req.MaxBankFee
+	}
+
+	imp := &model.BankStatementImport{
+		FileName:      fileName,
+		Format:        model.BankStatementFormat(stmt.Format),
+		BankCode:      stmt.BankCode,
+		BankName:      stmt.BankName,
+		BranchName:    stmt.BranchName,
+		AccountNumber: stmt.AccountNumber,
+		PeriodFrom:    stmt.PeriodFrom,
+		PeriodTo:      stmt.PeriodTo,
+		TotalCount:    len(stmt.Transactions),
+		ImportedBy:    userID,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		openInvoices, err := loadOpenInvoices(tx)
+		if err != nil {
+			return err
+		}
+
+		// 新しい明細を入金日順に照合する
+		var rows []*model.BankTransaction
+		for i, st := range stmt.Transactions {
+			if existing[keys[i]] {
+				imp.DuplicateCount++
+				continue
+			}
+			rows = append(rows, &model.BankTransaction{
+				DedupeKey:       keys[i],
+				TransactionDate: st.Date,
+				Direction:       model.BankTransactionDirection(st.Direction),
+				Amount:          st.Amount,
+				PayerName:       st.PayerName,
+				PayerKana:       reconciliation.NormalizeKana(st.PayerName),
+				Description:     st.Description,
+				Reference:       st.Reference,
+			})
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].TransactionDate.Before(rows[j].TransactionDate)
+		})
+
+		if err := tx.Create(imp).Error; err != nil {
+			return fmt.Errorf("取込履歴の作成に失敗しました: %w", err)
+		}
+
+		for _, row := range rows {
+			row.ImportID = imp.ID
+			if !row.IsDeposit() {
+				// 出金は入金消込の対象外
+				row.Status = model.BankTransactionStatusIgnored
+				row.MatchType = string(reconciliation.MatchTypeNone)
+				if err := tx.Create(row).Error; err != nil {
+					return fmt.Errorf("入出金明細の登録に失敗しました: %w", err)
+				}
+				continue
+			}
+			imp.DepositCount++
+
+			res := matcher.Match(reconciliation.Deposit{
+				Date:      row.TransactionDate,
+				Amount:    row.Amount,
+				PayerName: row.PayerName,
+			}, openInvoices)
+			applyMatchResult(row, res, req.AutoConfirm)
+			if row.Status == model.BankTransactionStatusConfirmed {
+				now := time.Now()
+				row.ConfirmedBy = &userID
+				row.ConfirmedAt = &now
+			}
+
+			if err := tx.Create(row).Error; err != nil {
+				return fmt.Errorf("入出金明細の登録に失敗しました: %w", err)
+			}
+
+			switch row.Status {
+			case model.BankTransactionStatusConfirmed, model.BankTransactionStatusMatched:
+				imp.MatchedCount++
+				// 同じ取込内の後続の入金が同じ請求書に重ねて割り当てられないよう残高を減らす
+				reserveOpenInvoices(openInvoices, row.Allocations)
+			case model.BankTransactionStatusNeedsReview:
+				imp.ReviewCount++
+			default:
+				imp.UnmatchedCount++
+			}
+
+			if row.Status == model.BankTransactionStatusConfirmed {
+				if err := settleInvoices(tx, row); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Save(imp).Error; err != nil {
+			return fmt.Errorf("取込履歴の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to import bank statement",
+			zap.String("file_name", fileName),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Bank statement imported",
+		zap.String("import_id", imp.ID),
+		zap.Int("total", imp.TotalCount),
+		zap.Int("duplicates", imp.DuplicateCount),
+		zap.Int("matched", imp.MatchedCount),
+		zap.Int("review", imp.ReviewCount),
+		zap.Int("unmatched", imp.UnmatchedCount))
+
+	return bankStatementImportToDTO(imp), nil
+}
+
+// ListImports 取込履歴を取得
+func (s *bankReconciliationService) ListImports(ctx context.Context, page, limit int) ([]*dto.BankStatementImportDTO, int64, error) {
+	page, limit = normalizePage(page, limit)
+	imports, total, err := s.repo.ListImports(ctx, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]*dto.BankStatementImportDTO, 0, len(imports))
+	for _, imp := range imports {
+		dtos = append(dtos, bankStatementImportToDTO(imp))
+	}
+	return dtos, total, nil
+}
+
+// ListTransactions 入出金明細を検索
+func (s *bankReconciliationService) ListTransactions(ctx context.Context, req *dto.BankTransactionSearchRequest) ([]*dto.BankTransactionDTO, int64, error) {
+	req.Page, req.Limit = normalizePage(req.Page, req.Limit)
+
+	filter := repository.BankTransactionFilter{
+		ImportID: req.ImportID,
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	}
+	if req.Status != "" {
+		filter.Statuses = []model.BankTransactionStatus{model.BankTransactionStatus(req.Status)}
+	}
+
+	txs, total, err := s.repo.ListTransactions(ctx, filter, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]*dto.BankTransactionDTO, 0, len(txs))
+	for _, t := range txs {
+		dtos = append(dtos, bankTransactionToDTO(t))
+	}
+	return dtos, total, nil
+}
+
+// GetTransaction 入出金明細を候補の請求書を含めて取得
+func (s *bankReconciliationService) GetTransaction(ctx context.Context, transactionID string) (*dto.BankTransactionDTO, error) {
+	t, err := s.repo.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "入出金明細が見つかりません").
+			WithDetail("transaction_id", transactionID)
+	}
+
+	dtos := []*dto.BankTransactionDTO{bankTransactionToDTO(t)}
+	if err := s.attachCandidates(ctx, []*model.BankTransaction{t}, dtos); err != nil {
+		return nil, err
+	}
+	return dtos[0], nil
+}
+
+// GetReviewQueue 確認待ちと未照合の入金を取得
+func (s *bankReconciliationService) GetReviewQueue(ctx context.Context, page, limit int) ([]*dto.BankTransactionDTO, int64, error) {
+	page, limit = normalizePage(page, limit)
+	filter := repository.BankTransactionFilter{
+		Statuses: []model.BankTransactionStatus{
+			model.BankTransactionStatusNeedsReview,
+			model.BankTransactionStatusUnmatched,
+		},
+	}
+
+	txs, total, err := s.repo.ListTransactions(ctx, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]*dto.BankTransactionDTO, 0, len(txs))
+	for _, t := range txs {
+		dtos = append(dtos, bankTransactionToDTO(t))
+	}
+	if err := s.attachCandidates(ctx, txs, dtos); err != nil {
+		return nil, 0, err
+	}
+	return dtos, total, nil
+}
+
+// ConfirmMatch 入金の割当を確定し、全額入金された請求書を支払済みにする
+// 割当を指定しない場合は照合結果の割当をそのまま確定する
+func (s *bankReconciliationService) ConfirmMatch(ctx context.Context, userID, transactionID string, req *dto.ConfirmBankMatchRequest) (*dto.BankTransactionDTO, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, err := lockBankTransaction(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if !row.IsDeposit() {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "出金は消込できません")
+		}
+
+		allocations := row.Allocations
+		if len(req.Allocations) > 0 {
+			allocations = make([]model.BankTransactionAllocation, 0, len(req.Allocations))
+			for _, a := range req.Allocations {
+				allocations = append(allocations, model.BankTransactionAllocation{
+					InvoiceID: a.InvoiceID,
+					Amount:    a.Amount,
+					FeeAmount: a.FeeAmount,
+				})
+			}
+			row.MatchType = bankMatchTypeManual
+		}
+		if err := validateAllocations(tx, row, allocations); err != nil {
+			return err
+		}
+
+		// 提案されていた割当を確定した割当で置き換える
+		if err := tx.Where("bank_transaction_id = ?", row.ID).Delete(&model.BankTransactionAllocation{}).Error; err != nil {
+			return fmt.Errorf("割当の削除に失敗しました: %w", err)
+		}
+		row.Allocations = nil
+		var allocated, fee int64
+		for _, a := range allocations {
+			a.ID = ""
+			a.BankTransactionID = row.ID
+			if err := tx.Create(&a).Error; err != nil {
+				return fmt.Errorf("割当の登録に失敗しました: %w", err)
+			}
+			row.Allocations = append(row.Allocations, a)
+			allocated += a.Amount
+			fee += a.FeeAmount
+		}
+
+		now := time.Now()
+		row.Status = model.BankTransactionStatusConfirmed
+		row.FeeAmount = fee
+		row.OverpaidAmount = row.Amount - allocated
+		row.ConfirmedBy = &userID
+		row.ConfirmedAt = &now
+		err = tx.Model(&model.BankTransaction{}).
+			Where("id = ?", row.ID).
+			Updates(map[string]interface{}{
+				"status":          row.Status,
+				"match_type":      row.MatchType,
+				"fee_amount":      row.FeeAmount,
+				"overpaid_amount": row.OverpaidAmount,
+				"confirmed_by":    userID,
+				"confirmed_at":    now,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("入出金明細の更新に失敗しました: %w", err)
+		}
+
+		return settleInvoices(tx, row)
+	})
+	if err != nil {
+		s.logger.Error("Failed to confirm bank match",
+			zap.String("transaction_id", transactionID),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Bank match confirmed",
+		zap.String("transaction_id", transactionID),
+		zap.String("user_id", userID))
+
+	return s.GetTransaction(ctx, transactionID)
+}
+
+// IgnoreTransaction 入金を消込対象外にする（請求書以外の入金など）
+func (s *bankReconciliationService) IgnoreTransaction(ctx context.Context, userID, transactionID string, req *dto.IgnoreBankTransactionRequest) (*dto.BankTransactionDTO, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, err := lockBankTransaction(tx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("bank_transaction_id = ?", row.ID).Delete(&model.BankTransactionAllocation{}).Error; err != nil {
+			return fmt.Errorf("割当の削除に失敗しました: %w", err)
+		}
+		err = tx.Model(&model.BankTransaction{}).
+			Where("id = ?", row.ID).
+			Updates(map[string]interface{}{
+				"status":        model.BankTransactionStatusIgnored,
+				"review_reason": req.Reason,
+				"confirmed_by":  userID,
+				"confirmed_at":  time.Now(),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("入出金明細の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to ignore bank transaction",
+			zap.String("transaction_id", transactionID),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetTransaction(ctx, transactionID)
+}
+
+// attachCandidates 確認待ちの入金に候補の請求書を付ける
+func (s *bankReconciliationService) attachCandidates(ctx context.Context, txs []*model.BankTransaction, dtos []*dto.BankTransactionDTO) error {
+	var ids []string
+	for _, t := range txs {
+		ids = append(ids, splitCandidateIDs(t.CandidateIDs)...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	invoices, err := s.repo.GetInvoicesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	settled, err := allocatedAmounts(s.db.WithContext(ctx), ids, model.BankTransactionStatusConfirmed)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*model.Invoice, len(invoices))
+	for _, inv := range invoices {
+		byID[inv.ID] = inv
+	}
+	for i, t := range txs {
+		for _, id := range splitCandidateIDs(t.CandidateIDs) {
+			if inv, ok := byID[id]; ok {
+				dtos[i].Candidates = append(dtos[i].Candidates, reconciliationInvoiceToDTO(inv, settled[id]))
+			}
+		}
+	}
+	return nil
+}
+
+// parseBankStatement 形式に応じて明細ファイルを解析
+func parseBankStatement(r io.Reader, req *dto.ImportBankStatementRequest) (*bankstatement.Statement, error) {
+	var (
+		stmt *bankstatement.Statement
+		err  error
+	)
+	switch bankstatement.Format(req.Format) {
+	case bankstatement.FormatZengin:
+		stmt, err = bankstatement.ParseZengin(r)
+	case bankstatement.FormatCSV:
+		if req.Mapping == nil {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidStatement, "CSVの列対応が指定されていません")
+		}
+		stmt, err = bankstatement.ParseCSV(r, *req.Mapping)
+	default:
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidStatement, "対応していない明細形式です").
+			WithDetail("format", req.Format)
+	}
+	if err != nil {
+		return nil, accountingerrors.NewAccountingErrorWithCause(accountingerrors.ErrReconciliationInvalidStatement, "明細ファイルを読み込めません", err)
+	}
+	return stmt, nil
+}
+
+// bankTransactionDedupeKeys 明細ごとの二重取込防止キーを作成
+// 同じファイル内で内容が同一の取引は出現順で区別する
+func bankTransactionDedupeKeys(stmt *bankstatement.Statement) []string {
+	account := strings.Join([]string{stmt.BankCode, stmt.BranchCode, stmt.AccountNumber}, "-")
+	seen := make(map[string]int)
+	keys := make([]string, 0, len(stmt.Transactions))
+	for _, t := range stmt.Transactions {
+		base := strings.Join([]string{
+			account,
+			t.Date.Format("2006-01-02"),
+			string(t.Direction),
+			fmt.Sprintf("%d", t.Amount),
+			t.PayerName,
+			t.Description,
+			t.Reference,
+		}, "|")
+		seen[base]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", base, seen[base])))
+		keys = append(keys, hex.EncodeToString(sum[:]))
+	}
+	return keys
+}
+
+// loadOpenInvoices 入金待ちの請求書を照合用に取得
+// 残高は確定済みの消込と、自動照合済みで確定待ちの割当を差し引いて計算する
+func loadOpenInvoices(tx *gorm.DB) ([]reconciliation.OpenInvoice, error) {
+	var invoices []*model.Invoice
+	err := tx.Preload("Client").
+		Where("status IN ?", []model.InvoiceStatus{model.InvoiceStatusSent, model.InvoiceStatusOverdue}).
+		Where("invoice_type = ?", model.InvoiceTypeStandard).
+		Order("due_date ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, fmt.Errorf("入金待ちの請求書の取得に失敗しました: %w", err)
+	}
+
+	ids := make([]string, 0, len(invoices))
+	for _, inv := range invoices {
+		ids = append(ids, inv.ID)
+	}
+	reserved, err := allocatedAmounts(tx, ids, model.BankTransactionStatusConfirmed, model.BankTransactionStatusMatched)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]reconciliation.OpenInvoice, 0, len(invoices))
+	for _, inv := range invoices {
+		remaining := yen(inv.TotalAmount) - reserved[inv.ID]
+		if remaining <= 0 {
+			continue
+		}
+		kana := inv.Client.CompanyNameKana
+		if kana == "" {
+			kana = inv.Client.CompanyName
+		}
+		open = append(open, reconciliation.OpenInvoice{
+			ID:            inv.ID,
+			InvoiceNumber: inv.InvoiceNumber,
+			ClientID:      inv.ClientID,
+			PayerKana:     kana,
+			Remaining:     remaining,
+			DueDate:       inv.DueDate,
+		})
+	}
+	return open, nil
+}
+
+// applyMatchResult 照合結果を入出金明細に反映
+func applyMatchResult(row *model.BankTransaction, res reconciliation.Result, autoConfirm bool) {
+	row.MatchType = string(res.Type)
+	row.Confidence = res.Confidence
+	row.FeeAmount = res.FeeAmount
+	row.OverpaidAmount = res.OverpaidAmount
+	row.ReviewReason = res.Reason
+	row.CandidateIDs = strings.Join(res.CandidateIDs, ",")
+	for _, a := range res.Allocations {
+		row.Allocations = append(row.Allocations, model.BankTransactionAllocation{
+			InvoiceID: a.InvoiceID,
+			Amount:    a.Amount,
+			FeeAmount: a.FeeAmount,
+		})
+	}
+
+	switch {
+	case res.Type == reconciliation.MatchTypeNone:
+		row.Status = model.BankTransactionStatusUnmatched
+	case res.NeedsReview:
+		row.Status = model.BankTransactionStatusNeedsReview
+	case autoConfirm:
+		row.Status = model.BankTransactionStatusConfirmed
+	default:
+		row.Status = model.BankTransactionStatusMatched
+	}
+}
+
+// reserveOpenInvoices 割り当てた額を照合用の残高から差し引く
+func reserveOpenInvoices(open []reconciliation.OpenInvoice, allocations []model.BankTransactionAllocation) {
+	for _, a := range allocations {
+		for i := range open {
+			if open[i].ID == a.InvoiceID {
+				open[i].Remaining -= a.Amount + a.FeeAmount
+			}
+		}
+	}
+}
+
+// lockBankTransaction 入出金明細を行ロックして取得（確定済み・対象外はエラー）
+func lockBankTransaction(tx *gorm.DB, transactionID string) (*model.BankTransaction, error) {
+	var row model.BankTransaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", transactionID).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "入出金明細が見つかりません").
+				WithDetail("transaction_id", transactionID)
+		}
+		return nil, fmt.Errorf("入出金明細の取得に失敗しました: %w", err)
+	}
+	if row.IsSettled() {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationAlreadySettled, "この明細は処理済みです").
+			WithDetail("status", row.Status)
+	}
+	if err := tx.Where("bank_transaction_id = ?", row.ID).Find(&row.Allocations).Error; err != nil {
+		return nil, fmt.Errorf("割当の取得に失敗しました: %w", err)
+	}
+	return &row, nil
+}
+
+// validateAllocations 割当が入金額と請求書の残高を超えていないかチェック
+func validateAllocations(tx *gorm.DB, row *model.BankTransaction, allocations []model.BankTransactionAllocation) error {
+	if len(allocations) == 0 {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "割り当てる請求書を指定してください")
+	}
+
+	perInvoice := make(map[string]int64)
+	var allocated int64
+	for _, a := range allocations {
+		if a.Amount < 0 || a.FeeAmount < 0 || a.Amount+a.FeeAmount == 0 {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "割当額が正しくありません").
+				WithDetail("invoice_id", a.InvoiceID)
+		}
+		perInvoice[a.InvoiceID] += a.Amount + a.FeeAmount
+		allocated += a.Amount
+	}
+	if allocated > row.Amount {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "割当額の合計が入金額を超えています").
+			WithDetail("amount", row.Amount).
+			WithDetail("allocated", allocated)
+	}
+
+	ids := make([]string, 0, len(perInvoice))
+	for id := range perInvoice {
+		ids = append(ids, id)
+	}
+	var invoices []*model.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Find(&invoices).Error; err != nil {
+		return fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	if len(invoices) != len(ids) {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "割当先の請求書が見つかりません")
+	}
+	settled, err := allocatedAmounts(tx, ids, model.BankTransactionStatusConfirmed)
+	if err != nil {
+		return err
+	}
+
+	for _, inv := range invoices {
+		if inv.IsCreditNote() || (inv.Status != model.InvoiceStatusSent && inv.Status != model.InvoiceStatusOverdue) {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "入金待ちではない請求書には割り当てられません").
+				WithDetail("invoice_number", inv.InvoiceNumber).
+				WithDetail("status", inv.Status)
+		}
+		remaining := yen(inv.TotalAmount) - settled[inv.ID]
+		if perInvoice[inv.ID] > remaining {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrReconciliationInvalidAllocation, "割当額が請求書の残高を超えています").
+				WithDetail("invoice_number", inv.InvoiceNumber).
+				WithDetail("remaining", remaining)
+		}
+	}
+	return nil
+}
+
+// settleInvoices 確定した入金で全額消込された請求書を支払済みにする
+func settleInvoices(tx *gorm.DB, row *model.BankTransaction) error {
+	ids := make([]string, 0, len(row.Allocations))
+	for _, a := range row.Allocations {
+		ids = append(ids, a.InvoiceID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var invoices []*model.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Find(&invoices).Error; err != nil {
+		return fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	settled, err := allocatedAmounts(tx, ids, model.BankTransactionStatusConfirmed)
+	if err != nil {
+		return err
+	}
+
+	for _, inv := range invoices {
+		if inv.Status == model.InvoiceStatusPaid || settled[inv.ID] < yen(inv.TotalAmount) {
+			continue
+		}
+		err := tx.Model(&model.Invoice{}).
+			Where("id = ?", inv.ID).
+			Updates(map[string]interface{}{
+				"status":         model.InvoiceStatusPaid,
+				"paid_date":      row.TransactionDate,
+				"payment_method": bankPaymentMethod,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("請求書の支払状態の更新に失敗しました: %w", err)
+		}
+	}
+	return nil
+}
+
+// allocatedAmounts 指定ステータスの入金から請求書ごとに割り当てた額（振込手数料を含む）を集計
+func allocatedAmounts(db *gorm.DB, invoiceIDs []string, statuses ...model.BankTransactionStatus) (map[string]int64, error) {
+	amounts := make(map[string]int64)
+	if len(invoiceIDs) == 0 {
+		return amounts, nil
+	}
+
+	var rows []struct {
+		InvoiceID string
+		Total     int64
+	}
+	err := db.Model(&model.BankTransactionAllocation{}).
+		Select("bank_transaction_allocations.invoice_id, SUM(bank_transaction_allocations.amount + bank_transaction_allocations.fee_amount) AS total").
+		Joins("JOIN bank_transactions ON bank_transactions.id = bank_transaction_allocations.bank_transaction_id").
+		Where("bank_transactions.status IN ?", statuses).
+		Where("bank_transaction_allocations.invoice_id IN ?", invoiceIDs).
+		Group("bank_transaction_allocations.invoice_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("消込額の集計に失敗しました: %w", err)
+	}
+	for _, row := range rows {
+		amounts[row.InvoiceID] = row.Total
+	}
+	return amounts, nil
+}
+
+// yen 請求金額を円単位の整数に変換
+func yen(amount money.Amount) int64 {
+	return amount.Round(money.RoundingHalfUp).Yen()
+}
+
+// normalizePage ページ番号と件数の既定値を設定
+func normalizePage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+// splitCandidateIDs カンマ区切りの候補請求書IDを分割
+func splitCandidateIDs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// bankStatementImportToDTO 取込履歴をDTOに変換
+func bankStatementImportToDTO(imp *model.BankStatementImport) *dto.BankStatementImportDTO {
+	return &dto.BankStatementImportDTO{
+		ID:             imp.ID,
+		FileName:       imp.FileName,
+		Format:         string(imp.Format),
+		BankName:       imp.BankName,
+		BranchName:     imp.BranchName,
+		AccountNumber:  imp.AccountNumber,
+		PeriodFrom:     imp.PeriodFrom,
+		PeriodTo:       imp.PeriodTo,
+		TotalCount:     imp.TotalCount,
+		DepositCount:   imp.DepositCount,
+		DuplicateCount: imp.DuplicateCount,
+		MatchedCount:   imp.MatchedCount,
+		ReviewCount:    imp.ReviewCount,
+		UnmatchedCount: imp.UnmatchedCount,
+		ImportedBy:     imp.ImportedBy,
+		CreatedAt:      imp.CreatedAt,
+	}
+}
+
+// bankTransactionToDTO 入出金明細をDTOに変換
+func bankTransactionToDTO(t *model.BankTransaction) *dto.BankTransactionDTO {
+	d := &dto.BankTransactionDTO{
+		ID:              t.ID,
+		ImportID:        t.ImportID,
+		TransactionDate: t.TransactionDate,
+		Direction:       string(t.Direction),
+		Amount:          t.Amount,
+		PayerName:       t.PayerName,
+		Description:     t.Description,
+		Reference:       t.Reference,
+		Status:          string(t.Status),
+		MatchType:       t.MatchType,
+		Confidence:      t.Confidence,
+		FeeAmount:       t.FeeAmount,
+		OverpaidAmount:  t.OverpaidAmount,
+		ReviewReason:    t.ReviewReason,
+		Allocations:     make([]dto.BankAllocationDTO, 0, len(t.Allocations)),
+		ConfirmedBy:     t.ConfirmedBy,
+		ConfirmedAt:     t.ConfirmedAt,
+	}
+	for _, a := range t.Allocations {
+		alloc := dto.BankAllocationDTO{
+			InvoiceID: a.InvoiceID,
+			Amount:    a.Amount,
+			FeeAmount: a.FeeAmount,
+		}
+		if a.Invoice != nil {
+			alloc.InvoiceNumber = a.Invoice.InvoiceNumber
+			alloc.ClientName = a.Invoice.Client.CompanyName
+		}
+		d.Allocations = append(d.Allocations, alloc)
+	}
+	return d
+}
+
+// reconciliationInvoiceToDTO 消込候補の請求書をDTOに変換
+func reconciliationInvoiceToDTO(inv *model.Invoice, settled int64) dto.ReconciliationInvoiceDTO {
+	return dto.ReconciliationInvoiceDTO{
+		InvoiceID:       inv.ID,
+		InvoiceNumber:   inv.InvoiceNumber,
+		ClientID:        inv.ClientID,
+		ClientName:      inv.Client.CompanyName,
+		ClientNameKana:  inv.Client.CompanyNameKana,
+		TotalAmount:     yen(inv.TotalAmount),
+		RemainingAmount: yen(inv.TotalAmount) - settled,
+		DueDate:         inv.DueDate,
+	}
+}
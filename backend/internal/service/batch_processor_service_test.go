@@ -565,7 +565,7 @@ func testBatchJobManagement(t *testing.T) {
 		service := setupBatchProcessorTestService(t)
 		ctx := context.Background()
 
-		nonExistentID := uuid.New()
+		nonExistentID := uuid.New().String()
 
 		// 存在しないジョブの取得
 		_, err := service.GetBatchJob(ctx, nonExistentID)
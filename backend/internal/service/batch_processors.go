@@ -7,30 +7,31 @@ import (
 	"gorm.io/gorm"
 	"time"
 
+	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/repository"
 )
 
 // InvoiceBatchProcessor 請求書バッチプロセッサー
 type InvoiceBatchProcessor struct {
-	billingService BillingServiceInterface
-	logger         *zap.Logger
-	targetMonth    string
-	createdBy      string
+	billingRunService BillingRunServiceInterface
+	logger            *zap.Logger
+	targetMonth       string // YYYY-MM
+	createdBy         string
 }
 
 // NewInvoiceBatchProcessor 請求書バッチプロセッサーのコンストラクタ
 func NewInvoiceBatchProcessor(
-	billingService BillingServiceInterface,
+	billingRunService BillingRunServiceInterface,
 	logger *zap.Logger,
 	targetMonth string,
 	createdBy string,
 ) BatchProcessor {
 	return &InvoiceBatchProcessor{
-		billingService: billingService,
-		logger:         logger,
-		targetMonth:    targetMonth,
-		createdBy:      createdBy,
+		billingRunService: billingRunService,
+		logger:            logger,
+		targetMonth:       targetMonth,
+		createdBy:         createdBy,
 	}
 }
 
@@ -45,11 +46,20 @@ func (p *InvoiceBatchProcessor) Process(ctx context.Context, item interface{}) e
 		zap.String("client_id", client.ID),
 		zap.String("client_name", client.Name))
 
-	// 請求書を作成 (TODO: ProcessClientメソッドを実装)
-	// _, err := p.billingService.ProcessClient(ctx, client.ID, p.targetMonth, p.createdBy)
-	// if err != nil {
-	//	return fmt.Errorf("クライアント %s の請求処理に失敗しました: %w", client.Name, err)
-	// }
+	month, err := time.Parse("2006-01", p.targetMonth)
+	if err != nil {
+		return fmt.Errorf("請求月の形式が正しくありません: %s", p.targetMonth)
+	}
+
+	// 月次請求で取引先の請求書の下書きを作成（作成済みの下書きは作り直す）
+	req := &dto.ExecuteBillingRunRequest{
+		BillingYear:  month.Year(),
+		BillingMonth: int(month.Month()),
+		ClientIDs:    []string{client.ID},
+	}
+	if _, err := p.billingRunService.Execute(ctx, req, p.createdBy); err != nil {
+		return fmt.Errorf("クライアント %s の請求処理に失敗しました: %w", client.Name, err)
+	}
 
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // BillingCalculatorServiceInterface 精算計算サービスインターフェース
@@ -19,20 +20,20 @@ type BillingCalculatorServiceInterface interface {
 	GetMonthlyWorkDays(year, month int) int
 	GetBusinessDaysInMonth(year, month int) int
 
-	// 請求額計算
-	CalculateFixedBilling(monthlyRate float64) float64
-	CalculateUpperLowerBilling(monthlyRate, actualHours, lowerLimit, upperLimit float64) (*UpperLowerBillingResult, error)
-	CalculateMiddleValueBilling(monthlyRate, actualHours, lowerLimit, upperLimit float64) (*MiddleValueBillingResult, error)
+	// 請求額計算（金額は端数処理前の銭単位。円未満は RoundAmount で処理する）
+	CalculateFixedBilling(monthlyRate money.Amount) money.Amount
+	CalculateUpperLowerBilling(monthlyRate money.Amount, actualHours, lowerLimit, upperLimit float64) (*UpperLowerBillingResult, error)
+	CalculateMiddleValueBilling(monthlyRate money.Amount, actualHours, lowerLimit, upperLimit float64) (*MiddleValueBillingResult, error)
 
 	// 精算調整計算
-	CalculateAdjustment(baseAmount, adjustmentRate float64, adjustmentType AdjustmentType) float64
-	CalculateOvertime(regularHours, overtimeHours, hourlyRate, overtimeRate float64) float64
-	CalculateDeduction(baseAmount float64, absenceDays, totalDays int) float64
+	CalculateAdjustment(baseAmount money.Amount, adjustmentRate float64, adjustmentType AdjustmentType) money.Amount
+	CalculateOvertime(regularHours, overtimeHours float64, hourlyRate money.Amount, overtimeRate float64) money.Amount
+	CalculateDeduction(baseAmount money.Amount, absenceDays, totalDays int) money.Amount
 
 	// 税額計算
-	CalculateTax(amount, taxRate float64) float64
-	CalculateTaxIncluded(amount, taxRate float64) float64
-	RoundAmount(amount float64, roundingType RoundingType) float64
+	CalculateTax(amount money.Amount, taxRate float64) money.Amount
+	CalculateTaxIncluded(amount money.Amount, taxRate float64) money.Amount
+	RoundAmount(amount money.Amount, roundingType RoundingType) money.Amount
 }
 
 // ActualHoursResult 実稼働時間計算結果
@@ -50,21 +51,21 @@ type ActualHoursResult struct {
 
 // UpperLowerBillingResult 上下割請求計算結果
 type UpperLowerBillingResult struct {
-	BillingAmount  float64
+	BillingAmount  money.Amount
 	BillingType    string // "fixed", "lower", "upper"
 	ActualHours    float64
 	ThresholdHours float64
-	HourlyRate     float64
+	HourlyRate     money.Amount // 表示用（請求額は時間単価を丸めずに計算する）
 	Explanation    string
 }
 
 // MiddleValueBillingResult 中間値請求計算結果
 type MiddleValueBillingResult struct {
-	BillingAmount   float64
+	BillingAmount   money.Amount
 	ActualHours     float64
 	MiddleHours     float64
-	HourlyRate      float64
-	Variance        float64 // 中間値からの差異
+	HourlyRate      money.Amount // 表示用（請求額は時間単価を丸めずに計算する）
+	Variance        float64      // 中間値からの差異
 	VariancePercent float64
 	Explanation     string
 }
@@ -87,6 +88,20 @@ const (
 	RoundingTypeNoRound RoundingType = "no_round" // 端数処理なし
 )
 
+// Mode 端数処理タイプに対応する金額の端数処理方法
+func (t RoundingType) Mode() money.Rounding {
+	switch t {
+	case RoundingTypeFloor:
+		return money.RoundingFloor
+	case RoundingTypeCeil:
+		return money.RoundingCeil
+	case RoundingTypeRound:
+		return money.RoundingHalfUp
+	default:
+		return money.RoundingNone
+	}
+}
+
 // billingCalculatorService 精算計算サービス実装
 type billingCalculatorService struct {
 	db             *gorm.DB
@@ -236,12 +251,12 @@ func (s *billingCalculatorService) GetBusinessDaysInMonth(year, month int) int {
 }
 
 // CalculateFixedBilling 固定額請求を計算
-func (s *billingCalculatorService) CalculateFixedBilling(monthlyRate float64) float64 {
+func (s *billingCalculatorService) CalculateFixedBilling(monthlyRate money.Amount) money.Amount {
 	return monthlyRate
 }
 
 // CalculateUpperLowerBilling 上下割請求を計算
-func (s *billingCalculatorService) CalculateUpperLowerBilling(monthlyRate, actualHours, lowerLimit, upperLimit float64) (*UpperLowerBillingResult, error) {
+func (s *billingCalculatorService) CalculateUpperLowerBilling(monthlyRate money.Amount, actualHours, lowerLimit, upperLimit float64) (*UpperLowerBillingResult, error) {
 	if lowerLimit >= upperLimit {
 		return nil, fmt.Errorf("下限値は上限値より小さくなければなりません")
 	}
@@ -252,23 +267,23 @@ func (s *billingCalculatorService) CalculateUpperLowerBilling(monthlyRate, actua
 
 	if actualHours < lowerLimit {
 		// 下限を下回る場合
-		hourlyRate := monthlyRate / lowerLimit
-		result.BillingAmount = hourlyRate * actualHours
+		hourlyRate := monthlyRate.MulDiv(1, lowerLimit, money.RoundingNone)
+		result.BillingAmount = monthlyRate.MulDiv(actualHours, lowerLimit, money.RoundingNone)
 		result.BillingType = "lower"
 		result.ThresholdHours = lowerLimit
 		result.HourlyRate = hourlyRate
 		result.Explanation = fmt.Sprintf("実績（%.1fh）が下限（%.1fh）を下回るため、時間単価（¥%.0f）で計算",
-			actualHours, lowerLimit, hourlyRate)
+			actualHours, lowerLimit, hourlyRate.Float64())
 
 	} else if actualHours > upperLimit {
 		// 上限を上回る場合
-		hourlyRate := monthlyRate / upperLimit
-		result.BillingAmount = hourlyRate * actualHours
+		hourlyRate := monthlyRate.MulDiv(1, upperLimit, money.RoundingNone)
+		result.BillingAmount = monthlyRate.MulDiv(actualHours, upperLimit, money.RoundingNone)
 		result.BillingType = "upper"
 		result.ThresholdHours = upperLimit
 		result.HourlyRate = hourlyRate
 		result.Explanation = fmt.Sprintf("実績（%.1fh）が上限（%.1fh）を上回るため、時間単価（¥%.0f）で計算",
-			actualHours, upperLimit, hourlyRate)
+			actualHours, upperLimit, hourlyRate.Float64())
 
 	} else {
 		// 範囲内の場合は固定額
@@ -284,20 +299,19 @@ func (s *billingCalculatorService) CalculateUpperLowerBilling(monthlyRate, actua
 }
 
 // CalculateMiddleValueBilling 中間値請求を計算
-func (s *billingCalculatorService) CalculateMiddleValueBilling(monthlyRate, actualHours, lowerLimit, upperLimit float64) (*MiddleValueBillingResult, error) {
+func (s *billingCalculatorService) CalculateMiddleValueBilling(monthlyRate money.Amount, actualHours, lowerLimit, upperLimit float64) (*MiddleValueBillingResult, error) {
 	if lowerLimit >= upperLimit {
 		return nil, fmt.Errorf("下限値は上限値より小さくなければなりません")
 	}
 
 	// 中間値を計算
 	middleHours := (lowerLimit + upperLimit) / 2
-	hourlyRate := monthlyRate / middleHours
 
 	result := &MiddleValueBillingResult{
 		ActualHours:   actualHours,
 		MiddleHours:   middleHours,
-		HourlyRate:    hourlyRate,
-		BillingAmount: hourlyRate * actualHours,
+		HourlyRate:    monthlyRate.MulDiv(1, middleHours, money.RoundingNone),
+		BillingAmount: monthlyRate.MulDiv(actualHours, middleHours, money.RoundingNone),
 		Variance:      actualHours - middleHours,
 	}
 
@@ -322,8 +336,8 @@ func (s *billingCalculatorService) CalculateMiddleValueBilling(monthlyRate, actu
 }
 
 // CalculateAdjustment 精算調整を計算
-func (s *billingCalculatorService) CalculateAdjustment(baseAmount, adjustmentRate float64, adjustmentType AdjustmentType) float64 {
-	adjustment := baseAmount * (adjustmentRate / 100)
+func (s *billingCalculatorService) CalculateAdjustment(baseAmount money.Amount, adjustmentRate float64, adjustmentType AdjustmentType) money.Amount {
+	adjustment := baseAmount.Percent(adjustmentRate, money.RoundingNone)
 
 	switch adjustmentType {
 	case AdjustmentTypeIncrease:
@@ -336,47 +350,36 @@ func (s *billingCalculatorService) CalculateAdjustment(baseAmount, adjustmentRat
 }
 
 // CalculateOvertime 残業代を計算
-func (s *billingCalculatorService) CalculateOvertime(regularHours, overtimeHours, hourlyRate, overtimeRate float64) float64 {
-	regularAmount := regularHours * hourlyRate
-	overtimeAmount := overtimeHours * hourlyRate * (overtimeRate / 100)
+func (s *billingCalculatorService) CalculateOvertime(regularHours, overtimeHours float64, hourlyRate money.Amount, overtimeRate float64) money.Amount {
+	regularAmount := hourlyRate.Mul(regularHours, money.RoundingNone)
+	overtimeAmount := hourlyRate.Mul(overtimeHours, money.RoundingNone).Percent(overtimeRate, money.RoundingNone)
 	return regularAmount + overtimeAmount
 }
 
 // CalculateDeduction 欠勤控除を計算
-func (s *billingCalculatorService) CalculateDeduction(baseAmount float64, absenceDays, totalDays int) float64 {
+func (s *billingCalculatorService) CalculateDeduction(baseAmount money.Amount, absenceDays, totalDays int) money.Amount {
 	if totalDays == 0 || absenceDays <= 0 {
 		return baseAmount
 	}
 
-	dailyRate := baseAmount / float64(totalDays)
-	deduction := dailyRate * float64(absenceDays)
+	// 日割り額を丸めずに 基本額 × 欠勤日数 ÷ 営業日数 で控除額を計算
+	deduction := baseAmount.MulDiv(float64(absenceDays), float64(totalDays), money.RoundingNone)
 	return baseAmount - deduction
 }
 
 // CalculateTax 税額を計算（税抜き金額から）
-func (s *billingCalculatorService) CalculateTax(amount, taxRate float64) float64 {
-	return amount * (taxRate / 100)
+func (s *billingCalculatorService) CalculateTax(amount money.Amount, taxRate float64) money.Amount {
+	return amount.Percent(taxRate, money.RoundingNone)
 }
 
 // CalculateTaxIncluded 税込み金額を計算
-func (s *billingCalculatorService) CalculateTaxIncluded(amount, taxRate float64) float64 {
-	return amount * (1 + taxRate/100)
+func (s *billingCalculatorService) CalculateTaxIncluded(amount money.Amount, taxRate float64) money.Amount {
+	return amount + s.CalculateTax(amount, taxRate)
 }
 
 // RoundAmount 金額の端数処理
-func (s *billingCalculatorService) RoundAmount(amount float64, roundingType RoundingType) float64 {
-	switch roundingType {
-	case RoundingTypeFloor:
-		return math.Floor(amount)
-	case RoundingTypeCeil:
-		return math.Ceil(amount)
-	case RoundingTypeRound:
-		return math.Round(amount)
-	case RoundingTypeNoRound:
-		return amount
-	default:
-		return amount
-	}
+func (s *billingCalculatorService) RoundAmount(amount money.Amount, roundingType RoundingType) money.Amount {
+	return amount.Round(roundingType.Mode())
 }
 
 // calculateDailyHoursFromRecord 勤務記録から日次稼働時間を計算
@@ -12,6 +12,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ========== モック実装 ==========
@@ -247,18 +248,18 @@ func TestGetMonthlyWorkDays(t *testing.T) {
 func TestCalculateFixedBilling(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateFixedBilling(500000)
-	assert.Equal(t, 500000.0, result)
+	result := service.CalculateFixedBilling(money.Yen(500000))
+	assert.Equal(t, money.Yen(500000), result)
 }
 
 func TestCalculateUpperLowerBilling_InRange(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateUpperLowerBilling(500000, 170, 160, 180)
+	result, err := service.CalculateUpperLowerBilling(money.Yen(500000), 170, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 500000.0, result.BillingAmount)
+	assert.Equal(t, money.Yen(500000), result.BillingAmount)
 	assert.Equal(t, "fixed", result.BillingType)
 	assert.Equal(t, 170.0, result.ActualHours)
 	assert.Contains(t, result.Explanation, "範囲内")
@@ -267,25 +268,25 @@ func TestCalculateUpperLowerBilling_InRange(t *testing.T) {
 func TestCalculateUpperLowerBilling_BelowLower(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateUpperLowerBilling(500000, 150, 160, 180)
+	result, err := service.CalculateUpperLowerBilling(money.Yen(500000), 150, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 468750.0, result.BillingAmount) // (500000/160)*150
+	assert.Equal(t, money.Yen(468750), result.BillingAmount) // (500000/160)*150
 	assert.Equal(t, "lower", result.BillingType)
 	assert.Equal(t, 160.0, result.ThresholdHours)
-	assert.Equal(t, 3125.0, result.HourlyRate) // 500000/160
+	assert.Equal(t, money.Yen(3125), result.HourlyRate) // 500000/160
 	assert.Contains(t, result.Explanation, "下限")
 }
 
 func TestCalculateUpperLowerBilling_AboveUpper(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateUpperLowerBilling(500000, 190, 160, 180)
+	result, err := service.CalculateUpperLowerBilling(money.Yen(500000), 190, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, 527777.7777777778, result.BillingAmount) // (500000/180)*190
+	assert.Equal(t, money.FromFloat(527777.78), result.BillingAmount) // 500000*190/180（銭未満は四捨五入）
 	assert.Equal(t, "upper", result.BillingType)
 	assert.Equal(t, 180.0, result.ThresholdHours)
 	assert.Contains(t, result.Explanation, "上限")
@@ -294,7 +295,7 @@ func TestCalculateUpperLowerBilling_AboveUpper(t *testing.T) {
 func TestCalculateUpperLowerBilling_InvalidRange(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateUpperLowerBilling(500000, 170, 180, 160) // 下限 > 上限
+	result, err := service.CalculateUpperLowerBilling(money.Yen(500000), 170, 180, 160) // 下限 > 上限
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -304,7 +305,7 @@ func TestCalculateUpperLowerBilling_InvalidRange(t *testing.T) {
 func TestCalculateMiddleValueBilling_AtMiddle(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateMiddleValueBilling(500000, 170, 160, 180)
+	result, err := service.CalculateMiddleValueBilling(money.Yen(500000), 170, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -318,7 +319,7 @@ func TestCalculateMiddleValueBilling_AtMiddle(t *testing.T) {
 func TestCalculateMiddleValueBilling_AboveMiddle(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateMiddleValueBilling(500000, 180, 160, 180)
+	result, err := service.CalculateMiddleValueBilling(money.Yen(500000), 180, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -332,7 +333,7 @@ func TestCalculateMiddleValueBilling_AboveMiddle(t *testing.T) {
 func TestCalculateMiddleValueBilling_BelowMiddle(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result, err := service.CalculateMiddleValueBilling(500000, 160, 160, 180)
+	result, err := service.CalculateMiddleValueBilling(money.Yen(500000), 160, 160, 180)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -348,45 +349,45 @@ func TestCalculateMiddleValueBilling_BelowMiddle(t *testing.T) {
 func TestCalculateAdjustment_Increase(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateAdjustment(500000, 10, AdjustmentTypeIncrease)
-	assert.Equal(t, 550000.0, result) // 500000 + (500000 * 0.1)
+	result := service.CalculateAdjustment(money.Yen(500000), 10, AdjustmentTypeIncrease)
+	assert.Equal(t, money.Yen(550000), result) // 500000 + (500000 * 0.1)
 }
 
 func TestCalculateAdjustment_Decrease(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateAdjustment(500000, 10, AdjustmentTypeDecrease)
-	assert.Equal(t, 450000.0, result) // 500000 - (500000 * 0.1)
+	result := service.CalculateAdjustment(money.Yen(500000), 10, AdjustmentTypeDecrease)
+	assert.Equal(t, money.Yen(450000), result) // 500000 - (500000 * 0.1)
 }
 
 func TestCalculateOvertime(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateOvertime(8, 2, 3000, 125) // 8時間通常勤務、2時間残業、時給3000円、残業率125%
+	result := service.CalculateOvertime(8, 2, money.Yen(3000), 125) // 8時間通常勤務、2時間残業、時給3000円、残業率125%
 	// expected := (8 * 3000) + (2 * 3000 * 1.25) // 24000 + 7500 = 31500
-	assert.Equal(t, 31500.0, result)
+	assert.Equal(t, money.Yen(31500), result)
 }
 
 func TestCalculateDeduction(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateDeduction(500000, 2, 20) // 基本額50万円、欠勤2日、営業日20日
+	result := service.CalculateDeduction(money.Yen(500000), 2, 20) // 基本額50万円、欠勤2日、営業日20日
 	// expected := 500000 - (500000/20)*2 // 500000 - 50000 = 450000
-	assert.Equal(t, 450000.0, result)
+	assert.Equal(t, money.Yen(450000), result)
 }
 
 func TestCalculateDeduction_NoAbsence(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateDeduction(500000, 0, 20)
-	assert.Equal(t, 500000.0, result)
+	result := service.CalculateDeduction(money.Yen(500000), 0, 20)
+	assert.Equal(t, money.Yen(500000), result)
 }
 
 func TestCalculateDeduction_InvalidDays(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateDeduction(500000, 2, 0) // 営業日0日
-	assert.Equal(t, 500000.0, result)
+	result := service.CalculateDeduction(money.Yen(500000), 2, 0) // 営業日0日
+	assert.Equal(t, money.Yen(500000), result)
 }
 
 // ========== 税額計算のテスト ==========
@@ -394,15 +395,15 @@ func TestCalculateDeduction_InvalidDays(t *testing.T) {
 func TestCalculateTax(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateTax(500000, 10) // 50万円、税率10%
-	assert.Equal(t, 50000.0, result)
+	result := service.CalculateTax(money.Yen(500000), 10) // 50万円、税率10%
+	assert.Equal(t, money.Yen(50000), result)
 }
 
 func TestCalculateTaxIncluded(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.CalculateTaxIncluded(500000, 10) // 50万円、税率10%
-	assert.Equal(t, 550000.0, result)
+	result := service.CalculateTaxIncluded(money.Yen(500000), 10) // 50万円、税率10%
+	assert.Equal(t, money.Yen(550000), result)
 }
 
 // ========== 端数処理のテスト ==========
@@ -410,32 +411,32 @@ func TestCalculateTaxIncluded(t *testing.T) {
 func TestRoundAmount_Floor(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.RoundAmount(123.789, RoundingTypeFloor)
-	assert.Equal(t, 123.0, result)
+	result := service.RoundAmount(money.FromFloat(123.789), RoundingTypeFloor)
+	assert.Equal(t, money.Yen(123), result)
 }
 
 func TestRoundAmount_Ceil(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.RoundAmount(123.123, RoundingTypeCeil)
-	assert.Equal(t, 124.0, result)
+	result := service.RoundAmount(money.FromFloat(123.123), RoundingTypeCeil)
+	assert.Equal(t, money.Yen(124), result)
 }
 
 func TestRoundAmount_Round(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.RoundAmount(123.456, RoundingTypeRound)
-	assert.Equal(t, 123.0, result)
+	result := service.RoundAmount(money.FromFloat(123.456), RoundingTypeRound)
+	assert.Equal(t, money.Yen(123), result)
 
-	result = service.RoundAmount(123.567, RoundingTypeRound)
-	assert.Equal(t, 124.0, result)
+	result = service.RoundAmount(money.FromFloat(123.567), RoundingTypeRound)
+	assert.Equal(t, money.Yen(124), result)
 }
 
 func TestRoundAmount_NoRound(t *testing.T) {
 	service, _, _, _ := setupBillingCalculatorService()
 
-	result := service.RoundAmount(123.456789, RoundingTypeNoRound)
-	assert.Equal(t, 123.456789, result)
+	result := service.RoundAmount(money.FromFloat(123.456789), RoundingTypeNoRound)
+	assert.Equal(t, money.FromFloat(123.46), result) // 金額は銭単位で保持
 }
 
 // ========== エラーケースのテスト ==========
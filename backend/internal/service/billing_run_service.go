@@ -0,0 +1,919 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// BillingRunServiceInterface 月次請求サービスインターフェース
+type BillingRunServiceInterface interface {
+	// 実行
+	Execute(ctx context.Context, req *dto.ExecuteBillingRunRequest, executedBy string) (*dto.BillingRunDTO, error)
+	Approve(ctx context.Context, year, month int, userID string) (*dto.BillingRunDTO, error)
+
+	// 参照
+	GetRun(ctx context.Context, year, month int) (*dto.BillingRunDTO, error)
+
+	// スケジュール実行
+	RunScheduledJobs(ctx context.Context) (int, error)
+}
+
+// billingRunService 月次請求サービス実装
+type billingRunService struct {
+	db             *gorm.DB
+	billingService BillingServiceInterface
+	assignmentRepo repository.ProjectAssignmentRepository
+	numbering      InvoiceNumberingServiceInterface
+	company        *config.CompanyConfig
+	logger         *zap.Logger
+}
+
+// NewBillingRunService 月次請求サービスのコンストラクタ
+func NewBillingRunService(
+	db *gorm.DB,
+	billingService BillingServiceInterface,
+	assignmentRepo repository.ProjectAssignmentRepository,
+	numbering InvoiceNumberingServiceInterface,
+	company *config.CompanyConfig,
+	logger *zap.Logger,
+) BillingRunServiceInterface {
+	s := &billingRunService{
+		db:             db,
+		billingService: billingService,
+		assignmentRepo: assignmentRepo,
+		numbering:      numbering,
+		company:        company,
+		logger:         logger,
+	}
+	delegateClientBilling(billingService, s)
+	return s
+}
+
+// billingTarget 請求書1件分の明細
+type billingTarget struct {
+	key          model.BillingTargetKey
+	details      []*model.InvoiceDetail
+	calculations []*model.BillingCalculation // detailsと同じ順の計算結果のスナップショット
+}
+
+// Execute 指定月の請求書の下書きを取引先・プロジェクトグループごとに作成する
+// 承認前であれば何度でも実行でき、前回作成した下書きを作り直す
+func (s *billingRunService) Execute(ctx context.Context, req *dto.ExecuteBillingRunRequest, executedBy string) (*dto.BillingRunDTO, error) {
+	if err := s.billingService.ValidateBillingPeriod(req.BillingYear, req.BillingMonth); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingInvalidMonth, err.Error())
+	}
+
+	registrationNumber := s.company.InvoiceRegistrationNumber
+	if registrationNumber != "" && !model.IsValidInvoiceRegistrationNumber(registrationNumber) {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "適格請求書発行事業者登録番号の形式が正しくありません")
+	}
+
+	billingMonth := model.BillingMonthString(req.BillingYear, req.BillingMonth)
+
+	// 承認済みであれば明細の計算前に止める
+	var current model.BillingRun
+	err := s.db.WithContext(ctx).Where("billing_month = ?", billingMonth).First(&current).Error
+	if err == nil && current.IsLocked() {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingAlreadyProcessed, "承認済みの月次請求は再実行できません")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("月次請求の取得に失敗しました: %w", err)
+	}
+
+	targets, warnings, err := s.buildTargets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []dto.BillingRunSkippedTarget
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		run, err := s.lockOrCreateRun(tx, billingMonth, executedBy)
+		if err != nil {
+			return err
+		}
+		if run.IsLocked() {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrBillingAlreadyProcessed, "承認済みの月次請求は再実行できません")
+		}
+
+		freedNumbers, err := s.deleteDraftInvoices(tx, run.ID, req.ClientIDs)
+		if err != nil {
+			return err
+		}
+
+		skipped, err = s.createInvoices(tx, run, req, targets, freedNumbers, executedBy, registrationNumber)
+		if err != nil {
+			return err
+		}
+
+		return s.refreshRunTotals(tx, run, executedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Billing run executed",
+		zap.String("billing_month", billingMonth),
+		zap.Int("targets", len(targets)),
+		zap.Int("skipped", len(skipped)),
+		zap.Int("warnings", len(warnings)))
+
+	result, err := s.GetRun(ctx, req.BillingYear, req.BillingMonth)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = skipped
+	result.Warnings = warnings
+	return result, nil
+}
+
+// buildTargets 請求期間中の案件アサインから請求単位ごとの明細を作成する
+// 計算できないアサインがある請求単位は、一部の明細だけで請求しないよう丸ごと除外する
+func (s *billingRunService) buildTargets(ctx context.Context, req *dto.ExecuteBillingRunRequest) ([]*billingTarget, []string, error) {
+	start, end := model.BillingMonthRange(req.BillingYear, req.BillingMonth)
+	assignments, err := s.assignmentRepo.FindActiveInPeriod(ctx, start, end, req.ClientIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("案件アサインの取得に失敗しました: %w", err)
+	}
+
+	groupByProject, err := s.projectGroups(ctx, assignments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	failed := make(map[model.BillingTargetKey]bool)
+	targets := make(map[model.BillingTargetKey]*billingTarget)
+	var order []model.BillingTargetKey
+
+	for _, assignment := range assignments {
+		key := model.BillingTargetKey{
+			ClientID:       assignment.Project.ClientID,
+			ProjectGroupID: groupByProject[assignment.ProjectID],
+		}
+
+		detail, err := s.billingService.CalculateProjectBilling(ctx, assignment, req.BillingYear, req.BillingMonth)
+		if err != nil {
+			failed[key] = true
+			warnings = append(warnings, fmt.Sprintf("%s（アサインID: %s）の請求額を計算できませんでした: %v",
+				assignment.Project.ProjectName, assignment.ID, err))
+			continue
+		}
+		if detail.BillingAmount.IsZero() {
+			continue
+		}
+
+		target, ok := targets[key]
+		if !ok {
+			target = &billingTarget{key: key}
+			targets[key] = target
+			order = append(order, key)
+		}
+
+		projectID := detail.ProjectID
+		userID := detail.UserID
+		description := fmt.Sprintf("%s %d年%d月分", detail.ProjectName, req.BillingYear, req.BillingMonth)
+		if detail.ProrationBasis != "" {
+			description += "（" + detail.ProrationBasis + "）"
+		}
+		target.details = append(target.details, &model.InvoiceDetail{
+			ProjectID:   &projectID,
+			UserID:      &userID,
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   detail.BillingAmount,
+			Amount:      detail.BillingAmount,
+			TaxCategory: model.TaxCategoryStandard,
+
+			ActualHours:   detail.ActualHours,
+			HoursEvidence: detail.HoursEvidence,
+		})
+		target.calculations = append(target.calculations, newBillingCalculation(key, detail, model.BillingMonthString(req.BillingYear, req.BillingMonth)))
+	}
+
+	result := make([]*billingTarget, 0, len(order))
+	for _, key := range order {
+		if failed[key] {
+			continue
+		}
+		result = append(result, targets[key])
+	}
+	return result, warnings, nil
+}
+
+// projectGroups 案件が属するプロジェクトグループ（案件ID→グループID）
+func (s *billingRunService) projectGroups(ctx context.Context, assignments []*model.ProjectAssignment) (map[string]string, error) {
+	groups := make(map[string]string)
+	if len(assignments) == 0 {
+		return groups, nil
+	}
+
+	projectIDs := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		projectIDs = append(projectIDs, assignment.ProjectID)
+	}
+
+	var mappings []model.ProjectGroupMapping
+	err := s.db.WithContext(ctx).
+		Joins("JOIN project_groups ON project_groups.id = project_group_mappings.project_group_id AND project_groups.deleted_at IS NULL").
+		Where("project_group_mappings.project_id IN ?", projectIDs).
+		Order("project_group_mappings.created_at ASC").
+		Find(&mappings).Error
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトグループの取得に失敗しました: %w", err)
+	}
+
+	// 複数のグループに属する場合は最初に登録したグループで請求する
+	for _, mapping := range mappings {
+		if _, ok := groups[mapping.ProjectID]; !ok {
+			groups[mapping.ProjectID] = mapping.ProjectGroupID
+		}
+	}
+	return groups, nil
+}
+
+// lockOrCreateRun 請求月の月次請求を行ロックして取得し、なければ作成する
+func (s *billingRunService) lockOrCreateRun(tx *gorm.DB, billingMonth, executedBy string) (*model.BillingRun, error) {
+	var run model.BillingRun
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("billing_month = ?", billingMonth).
+		First(&run).Error
+	if err == nil {
+		return &run, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("月次請求の取得に失敗しました: %w", err)
+	}
+
+	run = model.BillingRun{
+		BillingMonth: billingMonth,
+		Status:       model.BillingRunStatusDraft,
+		ExecutedBy:   executedBy,
+		ExecutedAt:   time.Now(),
+	}
+	if err := tx.Create(&run).Error; err != nil {
+		return nil, fmt.Errorf("月次請求の作成に失敗しました: %w", err)
+	}
+	return &run, nil
+}
+
+// deleteDraftInvoices 前回の実行で作成した下書きを削除し、空いた請求書番号を請求単位ごとに返す
+// 送付などで下書きでなくなった請求書がある場合は作り直さずにエラーとする
+func (s *billingRunService) deleteDraftInvoices(tx *gorm.DB, runID string, clientIDs []string) (map[model.BillingTargetKey]string, error) {
+	query := tx.Where("billing_run_id = ?", runID)
+	if len(clientIDs) > 0 {
+		query = query.Where("client_id IN ?", clientIDs)
+	}
+
+	var invoices []model.Invoice
+	if err := query.Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("前回作成した請求書の取得に失敗しました: %w", err)
+	}
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(invoices))
+	freed := make(map[model.BillingTargetKey]string, len(invoices))
+	for i := range invoices {
+		invoice := &invoices[i]
+		if invoice.Status != model.InvoiceStatusDraft || invoice.HasFreeeInvoiceID() {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict,
+				fmt.Sprintf("請求書 %s は送付済みまたはfreee連携済みのため作り直せません", invoice.InvoiceNumber))
+		}
+		ids = append(ids, invoice.ID)
+		freed[model.BillingTargetKeyOf(invoice)] = invoice.InvoiceNumber
+	}
+
+	// 同じ請求書番号で作り直せるよう物理削除する（計算結果のスナップショットは承認時に記録するため下書きには紐付かない）
+	if err := tx.Unscoped().Where("invoice_id IN ?", ids).Delete(&model.InvoiceDetail{}).Error; err != nil {
+		return nil, fmt.Errorf("請求明細の削除に失敗しました: %w", err)
+	}
+	if err := tx.Where("invoice_id IN ?", ids).Delete(&model.InvoiceTaxSummary{}).Error; err != nil {
+		return nil, fmt.Errorf("税率別小計の削除に失敗しました: %w", err)
+	}
+	if err := tx.Unscoped().Where("id IN ?", ids).Delete(&model.Invoice{}).Error; err != nil {
+		return nil, fmt.Errorf("請求書の削除に失敗しました: %w", err)
+	}
+	return freed, nil
+}
+
+// createInvoices 請求単位ごとに請求書の下書きを作成する
+// 手動で作成済みの請求単位は二重請求にならないよう作成しない。
+// 作り直した請求単位は前回の請求書番号を引き継ぎ、使われなくなった番号は欠番にする
+func (s *billingRunService) createInvoices(tx *gorm.DB, run *model.BillingRun, req *dto.ExecuteBillingRunRequest, targets []*billingTarget, freedNumbers map[model.BillingTargetKey]string, executedBy, registrationNumber string) ([]dto.BillingRunSkippedTarget, error) {
+	if len(targets) == 0 {
+		return nil, s.voidFreedNumbers(tx, freedNumbers, executedBy)
+	}
+
+	clientIDs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		clientIDs = append(clientIDs, target.key.ClientID)
+	}
+	var clients []model.Client
+	if err := tx.Where("id IN ?", clientIDs).Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("取引先の取得に失敗しました: %w", err)
+	}
+	clientByID := make(map[string]model.Client, len(clients))
+	for _, client := range clients {
+		clientByID[client.ID] = client
+	}
+
+	var manual []model.Invoice
+	err := tx.
+		Where("billing_month = ? AND billing_run_id IS NULL", run.BillingMonth).
+		Where("invoice_type = ? AND status <> ?", model.InvoiceTypeStandard, model.InvoiceStatusCancelled).
+		Where("client_id IN ?", clientIDs).
+		Find(&manual).Error
+	if err != nil {
+		return nil, fmt.Errorf("既存の請求書の取得に失敗しました: %w", err)
+	}
+	invoiced := make(map[model.BillingTargetKey]string, len(manual))
+	for i := range manual {
+		invoiced[model.BillingTargetKeyOf(&manual[i])] = manual[i].InvoiceNumber
+	}
+
+	_, invoiceDate := model.BillingMonthRange(req.BillingYear, req.BillingMonth)
+
+	var skipped []dto.BillingRunSkippedTarget
+	for _, target := range targets {
+		client, ok := clientByID[target.key.ClientID]
+		if !ok {
+			skipped = append(skipped, billingRunSkipped(target.key, "", "取引先が見つかりません"))
+			continue
+		}
+		if number, ok := invoiced[target.key]; ok {
+			skipped = append(skipped, billingRunSkipped(target.key, client.CompanyName,
+				fmt.Sprintf("請求書 %s が作成済みです", number)))
+			continue
+		}
+
+		paymentTerms := client.PaymentTerms
+		if paymentTerms <= 0 {
+			paymentTerms = 30
+		}
+
+		invoiceNumber, ok := freedNumbers[target.key]
+		if ok {
+			delete(freedNumbers, target.key)
+		} else {
+			invoiceNumber, err = s.numbering.Allocate(tx, client.ID, invoiceDate, executedBy)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		runID := run.ID
+		invoice := &model.Invoice{
+			ClientID:           client.ID,
+			InvoiceNumber:      invoiceNumber,
+			InvoiceDate:        invoiceDate,
+			DueDate:            invoiceDate.AddDate(0, 0, paymentTerms),
+			BillingMonth:       run.BillingMonth,
+			Status:             model.InvoiceStatusDraft,
+			CreatedBy:          executedBy,
+			RegistrationNumber: registrationNumber,
+			InvoiceType:        model.InvoiceTypeStandard,
+			BillingRunID:       &runID,
+		}
+		if target.key.ProjectGroupID != "" {
+			groupID := target.key.ProjectGroupID
+			invoice.ProjectGroupID = &groupID
+		}
+		applyInvoiceTax(invoice, target.details)
+
+		if err := tx.Create(invoice).Error; err != nil {
+			return nil, fmt.Errorf("請求書の作成に失敗しました: %w", err)
+		}
+		for i, detail := range target.details {
+			detail.InvoiceID = invoice.ID
+			detail.OrderIndex = i + 1
+		}
+		if err := tx.Create(&target.details).Error; err != nil {
+			return nil, fmt.Errorf("請求明細の作成に失敗しました: %w", err)
+		}
+		if err := s.numbering.Assign(tx, invoice.InvoiceNumber, invoice.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.voidFreedNumbers(tx, freedNumbers, executedBy); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
+// voidFreedNumbers 作り直しで使われなくなった請求書番号を欠番にする
+func (s *billingRunService) voidFreedNumbers(tx *gorm.DB, freedNumbers map[model.BillingTargetKey]string, executedBy string) error {
+	numbers := make([]string, 0, len(freedNumbers))
+	for _, number := range freedNumbers {
+		numbers = append(numbers, number)
+	}
+	sort.Strings(numbers)
+	for _, number := range numbers {
+		if err := s.numbering.Void(tx, number, "月次請求の再実行で請求単位がなくなった", executedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordCalculations 承認した請求書の明細の計算結果をスナップショットとして記録する
+// 同じアサイン・請求月の計算は確定のたびに版を重ね、以前の版は変更しない
+func (s *billingRunService) recordCalculations(tx *gorm.DB, run *model.BillingRun, invoice *model.Invoice, target *billingTarget, approvedBy string) error {
+	assignmentIDs := make([]string, len(target.calculations))
+	for i, calculation := range target.calculations {
+		assignmentIDs[i] = calculation.AssignmentID
+	}
+	var latest []struct {
+		AssignmentID string
+		Revision     int
+	}
+	err := tx.Model(&model.BillingCalculation{}).
+		Select("assignment_id, MAX(revision) AS revision").
+		Where("billing_month = ? AND assignment_id IN ?", run.BillingMonth, assignmentIDs).
+		Group("assignment_id").
+		Scan(&latest).Error
+	if err != nil {
+		return fmt.Errorf("請求計算の履歴の取得に失敗しました: %w", err)
+	}
+	revisions := make(map[string]int, len(latest))
+	for _, l := range latest {
+		revisions[l.AssignmentID] = l.Revision
+	}
+
+	runID := run.ID
+	now := time.Now()
+	for i, calculation := range target.calculations {
+		revisions[calculation.AssignmentID]++
+		calculation.Revision = revisions[calculation.AssignmentID]
+		calculation.BillingRunID = &runID
+		calculation.InvoiceID = invoice.ID
+		calculation.InvoiceDetailID = target.details[i].ID
+		calculation.InvoiceNumber = invoice.InvoiceNumber
+		calculation.CalculatedBy = approvedBy
+		calculation.CalculatedAt = now
+	}
+	if err := tx.Create(&target.calculations).Error; err != nil {
+		return fmt.Errorf("請求計算の履歴の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// recordApprovedCalculations 月次請求の請求書ごとに計算結果のスナップショットを記録する
+// 下書きの作成後に計算結果と請求明細が食い違った請求書がある場合は、確定前に再実行するようエラーとする
+func (s *billingRunService) recordApprovedCalculations(tx *gorm.DB, run *model.BillingRun, targets []*billingTarget, approvedBy string) error {
+	var invoices []model.Invoice
+	err := tx.Preload("Details").
+		Where("billing_run_id = ?", run.ID).
+		Order("invoice_number ASC").
+		Find(&invoices).Error
+	if err != nil {
+		return fmt.Errorf("月次請求の請求書の取得に失敗しました: %w", err)
+	}
+
+	targetByKey := make(map[model.BillingTargetKey]*billingTarget, len(targets))
+	for _, target := range targets {
+		targetByKey[target.key] = target
+	}
+
+	for i := range invoices {
+		invoice := &invoices[i]
+		target, ok := targetByKey[model.BillingTargetKeyOf(invoice)]
+		if !ok || !target.linkDetails(invoice.Details) {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict,
+				fmt.Sprintf("請求書 %s の作成後に請求額の計算結果が変わっています。月次請求を再実行してから承認してください", invoice.InvoiceNumber)).
+				WithDetail("invoice_id", invoice.ID)
+		}
+		if err := s.recordCalculations(tx, run, invoice, target, approvedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkDetails 計算し直した明細を同じ案件・担当者・金額の請求明細に対応付ける
+// 対応する請求明細がない計算結果がある場合はfalse
+func (t *billingTarget) linkDetails(details []model.InvoiceDetail) bool {
+	used := make([]bool, len(details))
+	for _, expected := range t.details {
+		expected.ID = ""
+		for n, detail := range details {
+			if used[n] || detail.ProjectID == nil || detail.UserID == nil {
+				continue
+			}
+			if *detail.ProjectID == *expected.ProjectID && *detail.UserID == *expected.UserID && detail.Amount == expected.Amount {
+				expected.ID = detail.ID
+				used[n] = true
+				break
+			}
+		}
+		if expected.ID == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshRunTotals 月次請求の件数・合計額を作成済みの請求書から集計し直す
+func (s *billingRunService) refreshRunTotals(tx *gorm.DB, run *model.BillingRun, executedBy string) error {
+	var totals struct {
+		InvoiceCount int
+		TotalAmount  money.Amount
+	}
+	err := tx.Model(&model.Invoice{}).
+		Select("COUNT(*) AS invoice_count, COALESCE(SUM(total_amount), 0) AS total_amount").
+		Where("billing_run_id = ?", run.ID).
+		Scan(&totals).Error
+	if err != nil {
+		return fmt.Errorf("月次請求の集計に失敗しました: %w", err)
+	}
+
+	return tx.Model(run).Updates(map[string]interface{}{
+		"run_count":     gorm.Expr("run_count + 1"),
+		"invoice_count": totals.InvoiceCount,
+		"total_amount":  totals.TotalAmount,
+		"executed_by":   executedBy,
+		"executed_at":   time.Now(),
+	}).Error
+}
+
+// Approve 月次請求を承認し、再実行と請求書の編集をロックする
+// 下書きの明細を計算し直して一致を確認し、確定した計算結果をスナップショットとして記録する
+func (s *billingRunService) Approve(ctx context.Context, year, month int, userID string) (*dto.BillingRunDTO, error) {
+	billingMonth := model.BillingMonthString(year, month)
+
+	targets, _, err := s.buildTargets(ctx, &dto.ExecuteBillingRunRequest{BillingYear: year, BillingMonth: month})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var run model.BillingRun
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("billing_month = ?", billingMonth).
+			First(&run).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "月次請求が実行されていません")
+		}
+		if err != nil {
+			return fmt.Errorf("月次請求の取得に失敗しました: %w", err)
+		}
+		if run.IsLocked() {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "この月次請求は承認済みです")
+		}
+
+		if err := s.recordApprovedCalculations(tx, &run, targets, userID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&run).Updates(map[string]interface{}{
+			"status":      model.BillingRunStatusApproved,
+			"approved_by": userID,
+			"approved_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Billing run approved",
+		zap.String("billing_month", billingMonth),
+		zap.String("approved_by", userID))
+
+	return s.GetRun(ctx, year, month)
+}
+
+// GetRun 月次請求と作成した請求書、前月との差分を取得する
+func (s *billingRunService) GetRun(ctx context.Context, year, month int) (*dto.BillingRunDTO, error) {
+	billingMonth := model.BillingMonthString(year, month)
+
+	var run model.BillingRun
+	err := s.db.WithContext(ctx).
+		Preload("Invoices", func(db *gorm.DB) *gorm.DB {
+			return db.Order("invoice_number ASC")
+		}).
+		Preload("Invoices.Client").
+		Preload("Invoices.ProjectGroup").
+		Where("billing_month = ?", billingMonth).
+		First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "月次請求が実行されていません")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("月次請求の取得に失敗しました: %w", err)
+	}
+
+	prevYear, prevMonth := model.PreviousBillingMonth(year, month)
+	previousMonth := model.BillingMonthString(prevYear, prevMonth)
+	var previous model.BillingRun
+	err = s.db.WithContext(ctx).
+		Preload("Invoices").
+		Preload("Invoices.Client").
+		Preload("Invoices.ProjectGroup").
+		Where("billing_month = ?", previousMonth).
+		First(&previous).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("前月の月次請求の取得に失敗しました: %w", err)
+	}
+
+	detailCounts, err := s.detailCounts(ctx, run.Invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.BillingRunDTO{
+		ID:                  run.ID,
+		BillingMonth:        run.BillingMonth,
+		Status:              string(run.Status),
+		RunCount:            run.RunCount,
+		InvoiceCount:        run.InvoiceCount,
+		TotalAmount:         run.TotalAmount,
+		PreviousMonth:       previousMonth,
+		PreviousTotalAmount: previous.TotalAmount,
+		ExecutedBy:          run.ExecutedBy,
+		ExecutedAt:          run.ExecutedAt,
+		ApprovedBy:          run.ApprovedBy,
+		ApprovedAt:          run.ApprovedAt,
+		Invoices:            make([]dto.BillingRunInvoiceDTO, 0, len(run.Invoices)),
+	}
+
+	names := make(map[model.BillingTargetKey][2]string)
+	for i := range run.Invoices {
+		invoice := &run.Invoices[i]
+		item := dto.BillingRunInvoiceDTO{
+			ID:             invoice.ID,
+			InvoiceNumber:  invoice.InvoiceNumber,
+			ClientID:       invoice.ClientID,
+			ClientName:     invoice.Client.CompanyName,
+			ProjectGroupID: invoice.ProjectGroupID,
+			Status:         string(invoice.Status),
+			DetailCount:    detailCounts[invoice.ID],
+			Subtotal:       invoice.Subtotal,
+			TaxAmount:      invoice.TaxAmount,
+			TotalAmount:    invoice.TotalAmount,
+		}
+		if invoice.ProjectGroup != nil {
+			item.ProjectGroupName = invoice.ProjectGroup.GroupName
+		}
+		result.Invoices = append(result.Invoices, item)
+	}
+
+	current := billingRunTotals(run.Invoices, names)
+	prevTotals := billingRunTotals(previous.Invoices, names)
+	for _, change := range model.DiffBillingTotals(prevTotals, current) {
+		item := dto.BillingRunChangeDTO{
+			ClientID:         change.ClientID,
+			ClientName:       names[change.BillingTargetKey][0],
+			ProjectGroupName: names[change.BillingTargetKey][1],
+			ChangeType:       string(change.Type),
+			PreviousAmount:   change.Previous,
+			CurrentAmount:    change.Current,
+			Difference:       change.Difference(),
+		}
+		if change.ProjectGroupID != "" {
+			groupID := change.ProjectGroupID
+			item.ProjectGroupID = &groupID
+		}
+		result.Changes = append(result.Changes, item)
+	}
+
+	return result, nil
+}
+
+// RunScheduledJobs 有効な月次請求のスケジュールジョブ（job_type: billing）を実行する
+// 1件の失敗で他のジョブを止めず、失敗したジョブがあればまとめてエラーを返す
+func (s *billingRunService) RunScheduledJobs(ctx context.Context) (int, error) {
+	var jobs []model.ScheduledJob
+	err := s.db.WithContext(ctx).
+		Where("job_type = ? AND status = ?", model.ScheduledJobTypeBilling, model.ScheduledJobStatusActive).
+		Order("created_at ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return 0, fmt.Errorf("月次請求ジョブの取得に失敗しました: %w", err)
+	}
+
+	handler := NewBillingRunJobHandler(s)
+	var failed []error
+	for i := range jobs {
+		job := &jobs[i]
+		runErr := handler.Execute(ctx, job)
+		if runErr != nil {
+			s.logger.Error("Scheduled billing run failed",
+				zap.String("job_id", job.ID),
+				zap.Error(runErr))
+			failed = append(failed, fmt.Errorf("%s: %w", job.JobName, runErr))
+		}
+
+		if err := s.db.WithContext(ctx).Model(job).UpdateColumn("last_run_at", time.Now()).Error; err != nil {
+			s.logger.Warn("Failed to update last run time of billing job",
+				zap.String("job_id", job.ID),
+				zap.Error(err))
+		}
+	}
+	return len(jobs), errors.Join(failed...)
+}
+
+// detailCounts 請求書ごとの明細数
+func (s *billingRunService) detailCounts(ctx context.Context, invoices []model.Invoice) (map[string]int, error) {
+	counts := make(map[string]int, len(invoices))
+	if len(invoices) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]string, 0, len(invoices))
+	for _, invoice := range invoices {
+		ids = append(ids, invoice.ID)
+	}
+
+	var rows []struct {
+		InvoiceID string
+		Count     int
+	}
+	err := s.db.WithContext(ctx).
+		Model(&model.InvoiceDetail{}).
+		Select("invoice_id, COUNT(*) AS count").
+		Where("invoice_id IN ?", ids).
+		Group("invoice_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("請求明細の集計に失敗しました: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.InvoiceID] = row.Count
+	}
+	return counts, nil
+}
+
+// isBillingRunLocked 請求書が承認済みの月次請求で作成したものかチェック
+func isBillingRunLocked(db *gorm.DB, invoice *model.Invoice) (bool, error) {
+	if invoice.BillingRunID == nil {
+		return false, nil
+	}
+	var count int64
+	err := db.Model(&model.BillingRun{}).
+		Where("id = ? AND status = ?", *invoice.BillingRunID, model.BillingRunStatusApproved).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("月次請求の確認に失敗しました: %w", err)
+	}
+	return count > 0, nil
+}
+
+// billingRunTotals 請求単位ごとの請求額（税込）を集計し、取引先名・グループ名をnamesに記録する
+// 取り消した請求書は差分の対象外とする
+func billingRunTotals(invoices []model.Invoice, names map[model.BillingTargetKey][2]string) map[model.BillingTargetKey]money.Amount {
+	totals := make(map[model.BillingTargetKey]money.Amount)
+	for i := range invoices {
+		invoice := &invoices[i]
+		if invoice.Status == model.InvoiceStatusCancelled {
+			continue
+		}
+		key := model.BillingTargetKeyOf(invoice)
+		totals[key] = totals[key].Add(invoice.TotalAmount)
+
+		groupName := ""
+		if invoice.ProjectGroup != nil {
+			groupName = invoice.ProjectGroup.GroupName
+		}
+		names[key] = [2]string{invoice.Client.CompanyName, groupName}
+	}
+	return totals
+}
+
+// newBillingCalculation 請求額の計算結果のスナップショット（版・請求書・確定者は承認時に設定する）
+func newBillingCalculation(key model.BillingTargetKey, detail *dto.ProjectBillingDetail, billingMonth string) *model.BillingCalculation {
+	calculation := &model.BillingCalculation{
+		BillingMonth:   billingMonth,
+		AssignmentID:   detail.AssignmentID,
+		ClientID:       key.ClientID,
+		ProjectID:      detail.ProjectID,
+		UserID:         detail.UserID,
+		BillingType:    model.ProjectBillingType(detail.BillingType),
+		MonthlyRate:    detail.MonthlyRate,
+		ProrationBasis: detail.ProrationBasis,
+		ActualHours:    detail.ActualHours,
+		RateSegments:   detail.RateSegments,
+		HoursEvidence:  detail.HoursEvidence,
+		BillingAmount:  detail.BillingAmount,
+		Notes:          detail.Notes,
+	}
+	if key.ProjectGroupID != "" {
+		groupID := key.ProjectGroupID
+		calculation.ProjectGroupID = &groupID
+	}
+	// 精算幅・日割り方法は月末時点の区間のもの
+	if n := len(detail.RateSegments); n > 0 {
+		current := detail.RateSegments[n-1]
+		calculation.MinHours = current.MinHours
+		calculation.MaxHours = current.MaxHours
+		calculation.ProrationMethod = current.ProrationMethod
+	}
+	return calculation
+}
+
+// billingRunSkipped 請求書を作成しなかった請求単位
+func billingRunSkipped(key model.BillingTargetKey, clientName, reason string) dto.BillingRunSkippedTarget {
+	skipped := dto.BillingRunSkippedTarget{
+		ClientID:   key.ClientID,
+		ClientName: clientName,
+		Reason:     reason,
+	}
+	if key.ProjectGroupID != "" {
+		groupID := key.ProjectGroupID
+		skipped.ProjectGroupID = &groupID
+	}
+	return skipped
+}
+
+// BillingRunJobHandler 月次請求のスケジュールジョブ（job_type: billing）
+// パラメータで billing_year / billing_month を指定しない場合は実行日の前月分を作成する
+type BillingRunJobHandler struct {
+	service BillingRunServiceInterface
+	now     func() time.Time
+}
+
+// NewBillingRunJobHandler 月次請求ジョブハンドラーのコンストラクタ
+func NewBillingRunJobHandler(service BillingRunServiceInterface) *BillingRunJobHandler {
+	return &BillingRunJobHandler{service: service, now: time.Now}
+}
+
+// Execute ジョブの作成者を実行者として月次請求を実行する
+func (h *BillingRunJobHandler) Execute(ctx context.Context, job *model.ScheduledJob) error {
+	var config map[string]interface{}
+	if job.Parameters != nil {
+		config = *job.Parameters
+	}
+	if err := h.Validate(config); err != nil {
+		return err
+	}
+
+	now := h.now()
+	year, month := model.PreviousBillingMonth(now.Year(), int(now.Month()))
+	if v, ok := jobParameterInt(config, "billing_year"); ok {
+		year = v
+	}
+	if v, ok := jobParameterInt(config, "billing_month"); ok {
+		month = v
+	}
+
+	req := &dto.ExecuteBillingRunRequest{BillingYear: year, BillingMonth: month}
+	if clientIDs, ok := config["client_ids"].([]interface{}); ok {
+		for _, id := range clientIDs {
+			if s, ok := id.(string); ok {
+				req.ClientIDs = append(req.ClientIDs, s)
+			}
+		}
+	}
+
+	_, err := h.service.Execute(ctx, req, job.CreatedBy)
+	return err
+}
+
+// Validate ジョブパラメータを検証する
+func (h *BillingRunJobHandler) Validate(config map[string]interface{}) error {
+	if _, ok := config["billing_year"]; ok {
+		if _, ok := jobParameterInt(config, "billing_year"); !ok {
+			return fmt.Errorf("billing_yearは整数で指定してください")
+		}
+	}
+	if _, ok := config["billing_month"]; ok {
+		month, ok := jobParameterInt(config, "billing_month")
+		if !ok || month < 1 || month > 12 {
+			return fmt.Errorf("billing_monthは1から12の整数で指定してください")
+		}
+	}
+	if ids, ok := config["client_ids"]; ok {
+		if _, ok := ids.([]interface{}); !ok {
+			return fmt.Errorf("client_idsは取引先IDの配列で指定してください")
+		}
+	}
+	return nil
+}
+
+// jobParameterInt JSONから読み込んだジョブパラメータを整数として取得
+func jobParameterInt(config map[string]interface{}, key string) (int, bool) {
+	switch v := config[key].(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
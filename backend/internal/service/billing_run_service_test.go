@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// MockBillingRunCalculator 月次請求が使う請求計算のモック
+type MockBillingRunCalculator struct {
+	mock.Mock
+	BillingServiceInterface // テストで使わないメソッドは未実装
+}
+
+func (m *MockBillingRunCalculator) ValidateBillingPeriod(year, month int) error {
+	return nil
+}
+
+func (m *MockBillingRunCalculator) CalculateProjectBilling(ctx context.Context, assignment *model.ProjectAssignment, year, month int) (*dto.ProjectBillingDetail, error) {
+	args := m.Called(assignment.ID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectBillingDetail), args.Error(1)
+}
+
+// MockActiveAssignmentRepository 請求期間のアサインを返すリポジトリのモック
+type MockActiveAssignmentRepository struct {
+	repository.ProjectAssignmentRepository // テストで使わないメソッドは未実装
+	assignments                            []*model.ProjectAssignment
+}
+
+func (m *MockActiveAssignmentRepository) FindActiveInPeriod(ctx context.Context, start, end time.Time, clientIDs []string) ([]*model.ProjectAssignment, error) {
+	return m.assignments, nil
+}
+
+// setupBillingRunTest 月次請求のテーブルを持つSQLiteで月次請求サービスを作成
+// 取引先Aは案件1・2（案件2はグループ所属）、取引先Bは案件3にアサインがある
+func setupBillingRunTest(t *testing.T) (*billingRunService, *MockBillingRunCalculator, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.BillingRun{}, &model.Invoice{}, &model.InvoiceDetail{}, &model.InvoiceTaxSummary{},
+		&model.Client{}, &model.ProjectGroup{}, &model.ProjectGroupMapping{}, &model.BillingCalculation{},
+		&model.InvoiceNumberSequence{}, &model.InvoiceNumberAllocation{}))
+
+	require.NoError(t, db.Create([]*model.Client{
+		{ID: "client-a", CompanyName: "A商事", PaymentTerms: 30},
+		{ID: "client-b", CompanyName: "B工業", PaymentTerms: 45},
+	}).Error)
+	require.NoError(t, db.Omit("Client", "Projects").Create(&model.ProjectGroup{ID: "group-1", GroupName: "保守案件", ClientID: "client-a", CreatedBy: "admin-1"}).Error)
+	require.NoError(t, db.Omit("ProjectGroup", "Project").Create(&model.ProjectGroupMapping{ID: "mapping-1", ProjectGroupID: "group-1", ProjectID: "project-2"}).Error)
+
+	assignmentRepo := &MockActiveAssignmentRepository{assignments: []*model.ProjectAssignment{
+		{ID: "assignment-1", ProjectID: "project-1", UserID: "user-1", Project: model.Project{ID: "project-1", ClientID: "client-a", ProjectName: "開発案件"}},
+		{ID: "assignment-2", ProjectID: "project-2", UserID: "user-2", Project: model.Project{ID: "project-2", ClientID: "client-a", ProjectName: "保守案件"}},
+		{ID: "assignment-3", ProjectID: "project-3", UserID: "user-3", Project: model.Project{ID: "project-3", ClientID: "client-b", ProjectName: "移行案件"}},
+	}}
+	numbering, err := NewInvoiceNumberingService(db, &config.InvoiceNumberingConfig{Pattern: "INV-{FY}-{seq:05}", FiscalYearStartMonth: 4}, "mysql", zap.NewNop())
+	require.NoError(t, err)
+
+	calculator := new(MockBillingRunCalculator)
+	s := &billingRunService{
+		db:             db,
+		billingService: calculator,
+		assignmentRepo: assignmentRepo,
+		numbering:      numbering,
+		company:        &config.CompanyConfig{},
+		logger:         zap.NewNop(),
+	}
+	return s, calculator, db
+}
+
+// billingRunDetail 固定額の請求計算の結果
+func billingRunDetail(assignmentID, projectID, userID string, amount int64) *dto.ProjectBillingDetail {
+	return &dto.ProjectBillingDetail{
+		ProjectID:     projectID,
+		ProjectName:   projectID,
+		AssignmentID:  assignmentID,
+		UserID:        userID,
+		BillingType:   string(model.ProjectBillingTypeFixed),
+		MonthlyRate:   money.Yen(amount),
+		BillingAmount: money.Yen(amount),
+	}
+}
+
+func TestBillingRunService_Execute(t *testing.T) {
+	ctx := context.Background()
+	req := &dto.ExecuteBillingRunRequest{BillingYear: 2026, BillingMonth: 9}
+
+	t.Run("取引先・プロジェクトグループごとに下書きを作成し、計算できない取引先は除外する", func(t *testing.T) {
+		s, calculator, db := setupBillingRunTest(t)
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 800000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-2").Return(billingRunDetail("assignment-2", "project-2", "user-2", 300000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-3").Return(nil, errors.New("週報が未承認です"))
+
+		result, err := s.Execute(ctx, req, "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2026-09", result.BillingMonth)
+		assert.Equal(t, string(model.BillingRunStatusDraft), result.Status)
+		require.Len(t, result.Invoices, 2)
+		assert.Len(t, result.Warnings, 1)
+		assert.Equal(t, money.Yen(880000+330000), result.TotalAmount)
+
+		var invoices []model.Invoice
+		require.NoError(t, db.Order("invoice_number ASC").Find(&invoices).Error)
+		require.Len(t, invoices, 2)
+		assert.Equal(t, "INV-2026-00001", invoices[0].InvoiceNumber)
+		assert.Nil(t, invoices[0].ProjectGroupID)
+		assert.Equal(t, money.Yen(880000), invoices[0].TotalAmount)
+		assert.Equal(t, "group-1", *invoices[1].ProjectGroupID)
+		assert.Equal(t, model.InvoiceStatusDraft, invoices[1].Status)
+
+		// 計算結果のスナップショットは承認まで記録しない
+		var calculations int64
+		require.NoError(t, db.Model(&model.BillingCalculation{}).Count(&calculations).Error)
+		assert.Zero(t, calculations)
+	})
+
+	t.Run("再実行は下書きを作り直し、請求書番号を引き継ぐ", func(t *testing.T) {
+		s, calculator, db := setupBillingRunTest(t)
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 800000), nil).Once()
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 820000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-2").Return(billingRunDetail("assignment-2", "project-2", "user-2", 300000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-3").Return(billingRunDetail("assignment-3", "project-3", "user-3", 500000), nil)
+
+		first, err := s.Execute(ctx, req, "admin-1")
+		require.NoError(t, err)
+		second, err := s.Execute(ctx, req, "admin-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, second.RunCount)
+		require.Len(t, second.Invoices, 3)
+		for i := range first.Invoices {
+			assert.Equal(t, first.Invoices[i].InvoiceNumber, second.Invoices[i].InvoiceNumber)
+		}
+		assert.Equal(t, money.Yen(902000), second.Invoices[0].TotalAmount)
+		var count int64
+		require.NoError(t, db.Model(&model.Invoice{}).Count(&count).Error)
+		assert.Equal(t, int64(3), count)
+	})
+}
+
+func TestBillingRunService_Approve(t *testing.T) {
+	ctx := context.Background()
+	req := &dto.ExecuteBillingRunRequest{BillingYear: 2026, BillingMonth: 9}
+
+	t.Run("承認時に計算結果のスナップショットを明細に紐付けて記録し、再実行をロックする", func(t *testing.T) {
+		s, calculator, db := setupBillingRunTest(t)
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 800000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-2").Return(billingRunDetail("assignment-2", "project-2", "user-2", 300000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-3").Return(billingRunDetail("assignment-3", "project-3", "user-3", 500000), nil)
+		_, err := s.Execute(ctx, req, "admin-1")
+		require.NoError(t, err)
+
+		result, err := s.Approve(ctx, 2026, 9, "manager-1")
+		require.NoError(t, err)
+		assert.Equal(t, string(model.BillingRunStatusApproved), result.Status)
+
+		var calculations []model.BillingCalculation
+		require.NoError(t, db.Order("assignment_id ASC").Find(&calculations).Error)
+		require.Len(t, calculations, 3)
+		for _, calculation := range calculations {
+			assert.Equal(t, 1, calculation.Revision)
+			assert.Equal(t, "manager-1", calculation.CalculatedBy)
+			var detail model.InvoiceDetail
+			require.NoError(t, db.Where("id = ?", calculation.InvoiceDetailID).First(&detail).Error)
+			assert.Equal(t, calculation.InvoiceID, detail.InvoiceID)
+		}
+
+		_, err = s.Execute(ctx, req, "admin-1")
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrBillingAlreadyProcessed, accErr.Code)
+	})
+
+	t.Run("下書きの作成後に計算結果が変わっていれば承認しない", func(t *testing.T) {
+		s, calculator, db := setupBillingRunTest(t)
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 800000), nil).Once()
+		calculator.On("CalculateProjectBilling", "assignment-1").Return(billingRunDetail("assignment-1", "project-1", "user-1", 750000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-2").Return(billingRunDetail("assignment-2", "project-2", "user-2", 300000), nil)
+		calculator.On("CalculateProjectBilling", "assignment-3").Return(billingRunDetail("assignment-3", "project-3", "user-3", 500000), nil)
+		_, err := s.Execute(ctx, req, "admin-1")
+		require.NoError(t, err)
+
+		_, err = s.Approve(ctx, 2026, 9, "manager-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrAccountingDataConflict, accErr.Code)
+		var run model.BillingRun
+		require.NoError(t, db.Where("billing_month = ?", "2026-09").First(&run).Error)
+		assert.Equal(t, model.BillingRunStatusDraft, run.Status)
+		var calculations int64
+		require.NoError(t, db.Model(&model.BillingCalculation{}).Count(&calculations).Error)
+		assert.Zero(t, calculations)
+	})
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,8 +13,10 @@ import (
 
 	"github.com/duesk/monstera/internal/common/transaction"
 	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // TransactionManager エイリアス
@@ -33,11 +37,13 @@ type BillingServiceInterface interface {
 
 	// 請求計算
 	CalculateProjectBilling(ctx context.Context, assignment *model.ProjectAssignment, year, month int) (*dto.ProjectBillingDetail, error)
-	CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate float64, actualHours, lowerLimit, upperLimit *float64) (float64, error)
+	CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate money.Amount, actualHours, lowerLimit, upperLimit *float64) (money.Amount, error)
+	CalculateAssignmentCost(ctx context.Context, assignment *model.ProjectAssignment, year, month int) (*dto.AssignmentCostDetail, error)
 
 	// 請求履歴
 	GetBillingHistory(ctx context.Context, req *dto.BillingHistoryRequest) (*dto.BillingHistoryResponse, error)
 	GetClientBillingHistory(ctx context.Context, clientID string, limit int) ([]dto.BillingHistoryItemDTO, error)
+	GetBillingCalculationRevisions(ctx context.Context, assignmentID, billingMonth string) (*dto.BillingCalculationRevisionsResponse, error)
 
 	// バリデーション
 	ValidateBillingPeriod(year, month int) error
@@ -71,6 +77,8 @@ type billingService struct {
 	invoiceRepo        repository.InvoiceRepository
 	groupRepo          repository.ProjectGroupRepositoryInterface
 	transactionManager TransactionManager
+	calculator         BillingCalculatorServiceInterface
+	billingRun         BillingRunServiceInterface // クライアント単位の請求の委譲先（NewBillingRunServiceで設定）
 }
 
 // NewBillingService 請求サービスのコンストラクタ
@@ -93,6 +101,8 @@ func NewBillingService(
 		invoiceRepo:        invoiceRepo,
 		groupRepo:          groupRepo,
 		transactionManager: transactionManager,
+		// 上下割・中間割の計算のみ使うため、勤務記録・祝日のリポジトリは不要
+		calculator: NewBillingCalculatorService(db, logger, nil, nil, nil),
 	}
 }
 
@@ -292,62 +302,413 @@ func (s *billingService) CalculateProjectBilling(ctx context.Context, assignment
 	// ユーザー情報を取得（必要に応じて）
 	// user, err := s.userRepo.GetByID(ctx, assignment.UserID)
 
+	start, end, err := assignmentBillingPeriod(assignment, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	// 請求期間に適用される単価履歴（月の途中で改定された場合は複数の区間になる）
+	segments, err := s.resolveBillingRates(ctx, assignment, year, month, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// 月の途中の参画・離任・単価改定は、案件または取引先に設定された方法で請求月を基準に日割りする
+	method, holidays, err := s.prorationSettings(ctx, project, year, month)
+	if err != nil {
+		return nil, err
+	}
+	model.ApplyProration(segments, method, year, month, holidays)
+	current := segments[len(segments)-1]
+
 	detail := &dto.ProjectBillingDetail{
 		ProjectID:     project.ID,
 		ProjectName:   project.ProjectName,
 		AssignmentID:  assignment.ID,
 		UserID:        assignment.UserID,
 		UserName:      "", // ユーザー名は別途取得
-		BillingType:   string(assignment.GetBillingType()),
-		MonthlyRate:   assignment.BillingRate,
+		BillingType:   string(current.BillingType),
+		MonthlyRate:   current.BillingRate,
 		ActualHours:   nil,
 		BillingAmount: 0,
 		Notes:         "",
 	}
 
-	// 請求タイプに応じて金額を計算
-	var actualHours float64
-	// TODO: 実際の稼働時間を取得する処理を実装
-	// actualHours = s.getActualHours(ctx, assignment.UserID, project.ID, year, month)
+	result, err := s.settleAssignment(ctx, assignment, segments, year, month, start, end)
+	if err != nil {
+		return nil, err
+	}
+	detail.BillingAmount = result.Amount
+	detail.Notes = result.Notes
+	detail.RateSegments = result.Settlements
+	if result.Evidence != nil {
+		detail.ActualHours = &result.Evidence.ActualHours
+		detail.HoursEvidence = result.Evidence
+	}
+	detail.ProrationBasis = model.ProrationBasis(segments)
+
+	return detail, nil
+}
 
-	if assignment.GetBillingType() != model.ProjectBillingTypeFixed {
-		detail.ActualHours = &actualHours
+// CalculateAssignmentCost 案件アサインの仕入額を計算
+// ビジネスパートナーとの精算条件（仕入単価・精算幅）で、請求と同じく週報の稼働時間から精算する
+func (s *billingService) CalculateAssignmentCost(ctx context.Context, assignment *model.ProjectAssignment, year, month int) (*dto.AssignmentCostDetail, error) {
+	start, end, err := assignmentBillingPeriod(assignment, year, month)
+	if err != nil {
+		return nil, err
 	}
 
-	amount, err := s.CalculateBillingAmount(
-		assignment.GetBillingType(),
-		assignment.BillingRate,
-		detail.ActualHours,
-		assignment.MinHours,
-		assignment.MaxHours,
-	)
+	var costs []*model.ProjectAssignmentCost
+	err = s.db.WithContext(ctx).
+		Preload("Partner").
+		Where("assignment_id = ?", assignment.ID).
+		Order("effective_from ASC").
+		Find(&costs).Error
 	if err != nil {
-		return nil, fmt.Errorf("請求金額の計算に失敗しました: %w", err)
+		s.logger.Error("Failed to get assignment costs",
+			zap.String("assignment_id", assignment.ID),
+			zap.Error(err))
+		return nil, fmt.Errorf("仕入単価の取得に失敗しました: %w", err)
 	}
 
-	detail.BillingAmount = amount
+	rates := make([]*model.ProjectAssignmentRate, len(costs))
+	for i, cost := range costs {
+		rates[i] = cost.AsRate()
+	}
+	segments, err := model.ResolveBillingRates(rates, start, end)
+	if err != nil {
+		var uncovered *model.UncoveredRateError
+		if errors.As(err, &uncovered) {
+			return nil, accountingerrors.ErrPurchaseCostUndefinedError(model.BillingMonthString(year, month), assignment.ID, err)
+		}
+		return nil, err
+	}
 
-	// 計算メモを生成
-	switch assignment.GetBillingType() {
-	case model.ProjectBillingTypeFixed:
-		detail.Notes = "固定額請求"
+	// 月末時点の仕入単価のパートナーの日割り方法で日割りする
+	current := segments[len(segments)-1]
+	cost, err := assignmentCostForRate(costs, current.RateID, assignment.ID)
+	if err != nil {
+		return nil, err
+	}
+	method := model.DefaultProrationMethod
+	if cost.Partner != nil && cost.Partner.ProrationMethod.IsValid() {
+		method = cost.Partner.ProrationMethod
+	}
+	holidays, err := s.loadHolidays(ctx, method, year, month)
+	if err != nil {
+		return nil, err
+	}
+	model.ApplyProration(segments, method, year, month, holidays)
+
+	result, err := s.settleAssignment(ctx, assignment, segments, year, month, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &dto.AssignmentCostDetail{
+		AssignmentID:   assignment.ID,
+		UserID:         assignment.UserID,
+		PartnerID:      cost.PartnerID,
+		CostID:         cost.ID,
+		CostType:       string(current.BillingType),
+		CostRate:       current.BillingRate,
+		CostAmount:     result.Amount,
+		Notes:          result.Notes,
+		HoursEvidence:  result.Evidence,
+		ProrationBasis: model.ProrationBasis(segments),
+	}
+	if cost.Partner != nil {
+		detail.PartnerName = cost.Partner.CompanyName
+	}
+	if result.Evidence != nil {
+		detail.ActualHours = &result.Evidence.ActualHours
+	}
+	return detail, nil
+}
+
+// assignmentCostForRate 単価履歴の区間に対応する仕入単価を取得
+func assignmentCostForRate(costs []*model.ProjectAssignmentCost, rateID, assignmentID string) (*model.ProjectAssignmentCost, error) {
+	for _, cost := range costs {
+		if cost.ID == rateID {
+			return cost, nil
+		}
+	}
+	return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingCalculationFailed,
+		"精算期間の仕入単価が見つかりません").
+		WithDetail("assignment_id", assignmentID).
+		WithDetail("cost_id", rateID)
+}
+
+// assignmentSettlement 単価履歴の区間ごとの精算結果の合計
+type assignmentSettlement struct {
+	Amount      money.Amount
+	Notes       string
+	Evidence    *model.BillingHoursEvidence
+	Settlements model.BillingRateSettlements
+}
+
+// settleAssignment 日割りを設定した単価履歴の区間ごとに精算して合計する
+func (s *billingService) settleAssignment(ctx context.Context, assignment *model.ProjectAssignment, segments []model.BillingRateSegment, year, month int, start, end time.Time) (*assignmentSettlement, error) {
+	// 固定額のみ（稼働時間で日割りしない場合）であれば週報の稼働実績は不要
+	var reports []*model.WeeklyReport
+	var evidence *model.BillingHoursEvidence
+	var err error
+	for _, segment := range segments {
+		hourly := segment.ProrationMethod == model.ProrationMethodHours && segment.IsProrated()
+		if segment.BillingType == model.ProjectBillingTypeFixed && !hourly {
+			continue
+		}
+		if segment.MinHours == nil || segment.MaxHours == nil {
+			return nil, fmt.Errorf("精算幅（下限・上限時間）が設定されていません")
+		}
+		if evidence == nil {
+			reports, evidence, err = s.collectActualHours(ctx, assignment, year, month, start, end)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &assignmentSettlement{Evidence: evidence}
+	settlements := make([]model.BillingRateSettlement, 0, len(segments))
+	for _, segment := range segments {
+		settlement, err := s.settleRateSegment(segment, reports)
+		if err != nil {
+			return nil, err
+		}
+		result.Amount = result.Amount.Add(settlement.Amount)
+		settlements = append(settlements, *settlement)
+	}
+
+	if len(settlements) == 1 {
+		settlement := settlements[0]
+		result.Notes = settlement.Explanation
+		if evidence != nil {
+			evidence.LowerLimit = settlement.LowerLimit
+			evidence.UpperLimit = settlement.UpperLimit
+			evidence.SettlementType = settlement.SettlementType
+			evidence.Explanation = settlement.Explanation
+		}
+	} else {
+		notes := make([]string, len(settlements))
+		for i, settlement := range settlements {
+			notes[i] = fmt.Sprintf("%s〜%s: %s", settlement.Start.Format("1/2"), settlement.End.Format("1/2"), settlement.Explanation)
+		}
+		result.Notes = "日割り（" + strings.Join(notes, "、") + "）"
+		if evidence != nil {
+			for _, settlement := range settlements {
+				evidence.LowerLimit += settlement.LowerLimit
+				evidence.UpperLimit += settlement.UpperLimit
+			}
+			evidence.SettlementType = model.BillingSettlementProrated
+			evidence.Explanation = result.Notes
+		}
+	}
+
+	if evidence != nil {
+		evidence.RateSegments = settlements
+	}
+	result.Settlements = settlements
+	return result, nil
+}
+
+// settleRateSegment 単価履歴の区間ごとに請求額を計算する
+// 区間が請求月の一部の場合は、単価と精算幅を日割りする（稼働時間で日割りする場合は時間単価 × 稼働時間）
+func (s *billingService) settleRateSegment(segment model.BillingRateSegment, reports []*model.WeeklyReport) (*model.BillingRateSettlement, error) {
+	settlement := &model.BillingRateSettlement{BillingRateSegment: segment}
+	rate := segment.ProratedRate()
+	prorated := segment.IsProrated()
+	hourly := prorated && segment.ProrationMethod == model.ProrationMethodHours
+
+	if segment.BillingType == model.ProjectBillingTypeFixed && !hourly {
+		settlement.Amount = rate
+		settlement.SettlementType = model.BillingSettlementFixed
+		settlement.Explanation = "固定額請求"
+		if prorated {
+			settlement.Explanation += prorationSuffix(segment)
+		}
+		return settlement, nil
+	}
+
+	// 区間の稼働時間（週報は請求期間全体で承認済みであることを確認済み）
+	hours, err := model.CollectBillingHours(reports, segment.Start, segment.End)
+	if err != nil {
+		return nil, err
+	}
+	settlement.ActualHours = hours.ActualHours
+
+	if hourly {
+		// 精算幅は月単位のため、日割りする区間は時間単価 × 稼働時間で請求する
+		baseHours := prorationBaseHours(segment)
+		settlement.Amount = segment.BillingRate.MulDiv(settlement.ActualHours, baseHours, money.RoundingNone)
+		settlement.SettlementType = model.BillingSettlementProrated
+		settlement.Explanation = fmt.Sprintf("時間単価請求（%s円 ÷ %.1fh × 実績: %.1fh）", segment.BillingRate.String(), baseHours, settlement.ActualHours)
+		return settlement, nil
+	}
+
+	settlement.LowerLimit = segment.ProratedHours(*segment.MinHours)
+	settlement.UpperLimit = segment.ProratedHours(*segment.MaxHours)
+
+	switch segment.BillingType {
 	case model.ProjectBillingTypeVariableUpperLower:
-		if assignment.MinHours != nil && assignment.MaxHours != nil {
-			detail.Notes = fmt.Sprintf("上下割請求（下限: %.1fh, 上限: %.1fh, 実績: %.1fh）",
-				*assignment.MinHours, *assignment.MaxHours, actualHours)
+		result, err := s.calculator.CalculateUpperLowerBilling(rate, settlement.ActualHours, settlement.LowerLimit, settlement.UpperLimit)
+		if err != nil {
+			return nil, fmt.Errorf("請求金額の計算に失敗しました: %w", err)
 		}
+		settlement.Amount = result.BillingAmount
+		settlement.SettlementType = result.BillingType
+		settlement.Explanation = fmt.Sprintf("上下割請求（下限: %.1fh, 上限: %.1fh, 実績: %.1fh）",
+			settlement.LowerLimit, settlement.UpperLimit, settlement.ActualHours)
 	case model.ProjectBillingTypeVariableMiddle:
-		if assignment.MinHours != nil && assignment.MaxHours != nil {
-			detail.Notes = fmt.Sprintf("中間値請求（基準: %.1fh, 実績: %.1fh）",
-				(*assignment.MinHours+*assignment.MaxHours)/2, actualHours)
+		result, err := s.calculator.CalculateMiddleValueBilling(rate, settlement.ActualHours, settlement.LowerLimit, settlement.UpperLimit)
+		if err != nil {
+			return nil, fmt.Errorf("請求金額の計算に失敗しました: %w", err)
 		}
+		settlement.Amount = result.BillingAmount
+		settlement.SettlementType = model.BillingSettlementMiddle
+		settlement.Explanation = fmt.Sprintf("中間値請求（基準: %.1fh, 実績: %.1fh）", result.MiddleHours, settlement.ActualHours)
+	default:
+		return nil, fmt.Errorf("不明な請求タイプ: %s", segment.BillingType)
+	}
+	if prorated {
+		settlement.Explanation += prorationSuffix(segment)
 	}
+	return settlement, nil
+}
 
-	return detail, nil
+// prorationSuffix 日割りした区間の説明（単価 × 区間の日数 / 月の日数）
+func prorationSuffix(segment model.BillingRateSegment) string {
+	return fmt.Sprintf("（%s円 × %s%d/%d日）", segment.BillingRate.String(), segment.ProrationMethod.Label(), segment.Units, segment.MonthUnits)
+}
+
+// prorationBaseHours 稼働時間で日割りする場合の時間単価の基準時間
+// 中間割は下限と上限の中間、それ以外は下限時間（控除単価）とする
+func prorationBaseHours(segment model.BillingRateSegment) float64 {
+	if segment.BillingType == model.ProjectBillingTypeVariableMiddle {
+		return (*segment.MinHours + *segment.MaxHours) / 2
+	}
+	return *segment.MinHours
+}
+
+// prorationSettings 日割り方法と請求月の祝日マスタの休日
+func (s *billingService) prorationSettings(ctx context.Context, project *model.Project, year, month int) (model.ProrationMethod, model.HolidaySet, error) {
+	var client model.Client
+	if err := s.db.WithContext(ctx).Where("id = ?", project.ClientID).First(&client).Error; err != nil {
+		return "", nil, fmt.Errorf("取引先の取得に失敗しました: %w", err)
+	}
+	method := model.ResolveProrationMethod(project, &client)
+	holidays, err := s.loadHolidays(ctx, method, year, month)
+	if err != nil {
+		return "", nil, err
+	}
+	return method, holidays, nil
+}
+
+// loadHolidays 営業日で日割りする場合の請求月の祝日マスタの休日
+func (s *billingService) loadHolidays(ctx context.Context, method model.ProrationMethod, year, month int) (model.HolidaySet, error) {
+	if method != model.ProrationMethodBusinessDays {
+		return nil, nil
+	}
+
+	start, end := model.BillingMonthRange(year, month)
+	var holidays []model.Holiday
+	err := s.db.WithContext(ctx).
+		Where("holiday_date BETWEEN ? AND ? AND deleted_at IS NULL", start, end).
+		Find(&holidays).Error
+	if err != nil {
+		s.logger.Error("Failed to get holidays for billing proration", zap.Error(err))
+		return nil, fmt.Errorf("祝日マスタの取得に失敗しました: %w", err)
+	}
+	return model.NewHolidaySet(holidays), nil
+}
+
+// resolveBillingRates 請求期間に適用される単価履歴を区間に分割する
+// 単価履歴が1件も登録されていないアサインは、アサインに設定された現在の単価を使う
+func (s *billingService) resolveBillingRates(ctx context.Context, assignment *model.ProjectAssignment, year, month int, start, end time.Time) ([]model.BillingRateSegment, error) {
+	var rates []*model.ProjectAssignmentRate
+	err := s.db.WithContext(ctx).
+		Where("assignment_id = ?", assignment.ID).
+		Order("effective_from ASC").
+		Find(&rates).Error
+	if err != nil {
+		s.logger.Error("Failed to get assignment rates for billing",
+			zap.String("assignment_id", assignment.ID),
+			zap.Error(err))
+		return nil, fmt.Errorf("単価履歴の取得に失敗しました: %w", err)
+	}
+	if len(rates) == 0 {
+		rates = append(rates, model.NewProjectAssignmentRateFromAssignment(assignment))
+	}
+
+	segments, err := model.ResolveBillingRates(rates, start, end)
+	if err != nil {
+		var uncovered *model.UncoveredRateError
+		if errors.As(err, &uncovered) {
+			return nil, accountingerrors.ErrBillingRateUndefinedError(model.BillingMonthString(year, month), assignment.ID, err)
+		}
+		return nil, err
+	}
+	return segments, nil
+}
+
+// assignmentBillingPeriod 案件アサイン期間と請求月が重なる期間
+func assignmentBillingPeriod(assignment *model.ProjectAssignment, year, month int) (time.Time, time.Time, error) {
+	start, end := model.BillingMonthRange(year, month)
+	if assignment.StartDate.After(start) {
+		start = assignment.StartDate
+	}
+	if assignment.EndDate != nil && assignment.EndDate.Before(end) {
+		end = *assignment.EndDate
+	}
+	if start.After(end) {
+		return start, end, fmt.Errorf("請求月にアサイン期間がありません")
+	}
+	return start, end, nil
+}
+
+// collectActualHours 請求期間の稼働実績を承認済みの週報から集計する
+// 週報は案件別ではないため、同じ期間に他の案件にもアサインされている日は日次勤怠で指定した案件の稼働だけを使う
+func (s *billingService) collectActualHours(ctx context.Context, assignment *model.ProjectAssignment, year, month int, start, end time.Time) ([]*model.WeeklyReport, *model.BillingHoursEvidence, error) {
+	var reports []*model.WeeklyReport
+	err := s.db.WithContext(ctx).
+		Preload("DailyRecords").
+		Where("user_id = ? AND start_date <= ? AND end_date >= ?", assignment.UserID, end, start).
+		Order("start_date ASC").
+		Find(&reports).Error
+	if err != nil {
+		s.logger.Error("Failed to get weekly reports for billing",
+			zap.String("assignment_id", assignment.ID),
+			zap.Error(err))
+		return nil, nil, fmt.Errorf("週報の取得に失敗しました: %w", err)
+	}
+
+	var concurrent []*model.ProjectAssignment
+	err = s.db.WithContext(ctx).
+		Where("user_id = ? AND project_id <> ? AND start_date <= ? AND (end_date IS NULL OR end_date >= ?)",
+			assignment.UserID, assignment.ProjectID, end, start).
+		Find(&concurrent).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("並行するアサインの取得に失敗しました: %w", err)
+	}
+
+	reports, err = model.FilterProjectDailyRecords(reports, assignment.ProjectID, concurrent, start, end)
+	if err != nil {
+		return nil, nil, accountingerrors.ErrBillingReportsUnattributedError(model.BillingMonthString(year, month), assignment.UserID, err)
+	}
+
+	evidence, err := model.CollectBillingHours(reports, start, end)
+	if err != nil {
+		var unsettled *model.UnsettledWeeklyReportsError
+		if errors.As(err, &unsettled) {
+			return nil, nil, accountingerrors.ErrBillingReportsUnsettledError(model.BillingMonthString(year, month), assignment.UserID, err)
+		}
+		return nil, nil, err
+	}
+	return reports, evidence, nil
 }
 
 // CalculateBillingAmount 請求金額を計算
-func (s *billingService) CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate float64, actualHours, lowerLimit, upperLimit *float64) (float64, error) {
+func (s *billingService) CalculateBillingAmount(billingType model.ProjectBillingType, monthlyRate money.Amount, actualHours, lowerLimit, upperLimit *float64) (money.Amount, error) {
 	switch billingType {
 	case model.ProjectBillingTypeFixed:
 		// 固定額請求
@@ -360,13 +721,11 @@ func (s *billingService) CalculateBillingAmount(billingType model.ProjectBilling
 		}
 
 		if *actualHours < *lowerLimit {
-			// 下限を下回る場合
-			hourlyRate := monthlyRate / *lowerLimit
-			return hourlyRate * *actualHours, nil
+			// 下限を下回る場合（時間単価を丸めずに計算）
+			return monthlyRate.MulDiv(*actualHours, *lowerLimit, money.RoundingNone), nil
 		} else if *actualHours > *upperLimit {
-			// 上限を上回る場合
-			hourlyRate := monthlyRate / *upperLimit
-			return hourlyRate * *actualHours, nil
+			// 上限を上回る場合（時間単価を丸めずに計算）
+			return monthlyRate.MulDiv(*actualHours, *upperLimit, money.RoundingNone), nil
 		} else {
 			// 範囲内の場合は固定額
 			return monthlyRate, nil
@@ -379,55 +738,180 @@ func (s *billingService) CalculateBillingAmount(billingType model.ProjectBilling
 		}
 
 		middleHours := (*lowerLimit + *upperLimit) / 2
-		hourlyRate := monthlyRate / middleHours
-		return hourlyRate * *actualHours, nil
+		return monthlyRate.MulDiv(*actualHours, middleHours, money.RoundingNone), nil
 
 	default:
 		return 0, fmt.Errorf("不明な請求タイプ: %s", billingType)
 	}
 }
 
-// GetBillingHistory 請求履歴を取得
+// billingCalculationRow 請求計算のスナップショットと取引先・案件・エンジニアの名前
+type billingCalculationRow struct {
+	model.BillingCalculation
+	ClientName  string
+	ProjectName string
+	UserName    string
+}
+
+// billingCalculationQuery 名前付きで請求計算のスナップショットを取得するクエリ
+func (s *billingService) billingCalculationQuery(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).
+		Table("billing_calculations AS bc").
+		Joins("LEFT JOIN clients c ON c.id = bc.client_id").
+		Joins("LEFT JOIN projects p ON p.id = bc.project_id").
+		Joins("LEFT JOIN users u ON u.id = bc.user_id")
+}
+
+// GetBillingHistory 確定した請求計算のスナップショットから請求履歴を取得
+// 既定では同じアサイン・請求月の最新の版のみを返し、各版には前の版からの変更点を付ける
 func (s *billingService) GetBillingHistory(ctx context.Context, req *dto.BillingHistoryRequest) (*dto.BillingHistoryResponse, error) {
-	// デフォルト値の設定
-	if req.Limit == 0 {
-		req.Limit = 20
+	req.SetDefaults()
+
+	query := s.billingCalculationQuery(ctx)
+	if req.ClientID != nil && *req.ClientID != "" {
+		query = query.Where("bc.client_id = ?", *req.ClientID)
 	}
-	if req.Page < 1 {
-		req.Page = 1
+	if req.ProjectGroupID != nil && *req.ProjectGroupID != "" {
+		query = query.Where("bc.project_group_id = ?", *req.ProjectGroupID)
+	}
+	if req.ProjectID != nil && *req.ProjectID != "" {
+		query = query.Where("bc.project_id = ?", *req.ProjectID)
+	}
+	if req.UserID != nil && *req.UserID != "" {
+		query = query.Where("bc.user_id = ?", *req.UserID)
+	}
+	if req.StartMonth != nil && *req.StartMonth != "" {
+		if _, err := time.Parse("2006-01", *req.StartMonth); err != nil {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "開始月はYYYY-MM形式で指定してください")
+		}
+		query = query.Where("bc.billing_month >= ?", *req.StartMonth)
+	}
+	if req.EndMonth != nil && *req.EndMonth != "" {
+		if _, err := time.Parse("2006-01", *req.EndMonth); err != nil {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "終了月はYYYY-MM形式で指定してください")
+		}
+		query = query.Where("bc.billing_month <= ?", *req.EndMonth)
+	}
+	if !req.AllRevisions {
+		query = query.Where(`bc.revision = (SELECT MAX(latest.revision) FROM billing_calculations latest
+			WHERE latest.assignment_id = bc.assignment_id AND latest.billing_month = bc.billing_month)`)
 	}
 
-	// 日付範囲の設定（デフォルトは過去6ヶ月）
-	if req.EndDate == nil {
-		now := time.Now()
-		req.EndDate = &now
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		s.logger.Error("Failed to count billing calculations", zap.Error(err))
+		return nil, fmt.Errorf("請求履歴の取得に失敗しました: %w", err)
 	}
-	if req.StartDate == nil {
-		sixMonthsAgo := req.EndDate.AddDate(0, -6, 0)
-		req.StartDate = &sixMonthsAgo
+
+	var rows []*billingCalculationRow
+	err := query.
+		Select("bc.*, c.company_name AS client_name, p.project_name AS project_name, " +
+			"COALESCE(NULLIF(u.name, ''), u.last_name || ' ' || u.first_name) AS user_name").
+		Order("bc.billing_month DESC, c.company_name, bc.assignment_id, bc.revision DESC").
+		Offset((req.Page - 1) * req.Limit).
+		Limit(req.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		s.logger.Error("Failed to get billing calculations", zap.Error(err))
+		return nil, fmt.Errorf("請求履歴の取得に失敗しました: %w", err)
 	}
 
-	// 統計情報を取得
-	_, err := s.invoiceRepo.GetBillingStats(ctx, *req.StartDate, *req.EndDate)
+	items, err := s.billingCalculationSnapshots(ctx, rows)
 	if err != nil {
-		s.logger.Error("Failed to get billing stats", zap.Error(err))
-		return nil, fmt.Errorf("請求統計の取得に失敗しました")
+		return nil, err
 	}
 
-	response := &dto.BillingHistoryResponse{
-		Items: make([]dto.BillingHistoryItemDTO, 0),
-		Total: 0,
+	return &dto.BillingHistoryResponse{
+		Items: items,
+		Total: int(total),
 		Page:  req.Page,
 		Limit: req.Limit,
+	}, nil
+}
+
+// GetBillingCalculationRevisions 同じアサイン・請求月の請求計算を古い版から順に取得
+func (s *billingService) GetBillingCalculationRevisions(ctx context.Context, assignmentID, billingMonth string) (*dto.BillingCalculationRevisionsResponse, error) {
+	if _, err := time.Parse("2006-01", billingMonth); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "請求月はYYYY-MM形式で指定してください")
 	}
 
-	// 請求履歴を取得
-	_ = (req.Page - 1) * req.Limit // offset（現在未使用）
+	var rows []*billingCalculationRow
+	err := s.billingCalculationQuery(ctx).
+		Select("bc.*, c.company_name AS client_name, p.project_name AS project_name, "+
+			"COALESCE(NULLIF(u.name, ''), u.last_name || ' ' || u.first_name) AS user_name").
+		Where("bc.assignment_id = ? AND bc.billing_month = ?", assignmentID, billingMonth).
+		Order("bc.revision").
+		Scan(&rows).Error
+	if err != nil {
+		s.logger.Error("Failed to get billing calculation revisions",
+			zap.String("assignment_id", assignmentID), zap.String("billing_month", billingMonth), zap.Error(err))
+		return nil, fmt.Errorf("請求計算の履歴の取得に失敗しました: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "請求計算の履歴が見つかりません").
+			WithDetail("assignment_id", assignmentID).
+			WithDetail("billing_month", billingMonth)
+	}
 
-	// TODO: 実際の請求履歴取得処理を実装
-	// invoices, err := s.invoiceRepo.FindWithFilters(ctx, query, req.Limit, offset)
+	revisions, err := s.billingCalculationSnapshots(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
 
-	return response, nil
+	return &dto.BillingCalculationRevisionsResponse{
+		AssignmentID: assignmentID,
+		BillingMonth: billingMonth,
+		Revisions:    revisions,
+	}, nil
+}
+
+// billingCalculationSnapshots スナップショットに前の版からの変更点と最新の版かどうかを付ける
+func (s *billingService) billingCalculationSnapshots(ctx context.Context, rows []*billingCalculationRow) ([]dto.BillingCalculationSnapshotDTO, error) {
+	snapshots := make([]dto.BillingCalculationSnapshotDTO, 0, len(rows))
+	if len(rows) == 0 {
+		return snapshots, nil
+	}
+
+	assignmentIDs := make([]string, 0, len(rows))
+	months := make([]string, 0, len(rows))
+	for _, row := range rows {
+		assignmentIDs = append(assignmentIDs, row.AssignmentID)
+		months = append(months, row.BillingMonth)
+	}
+
+	// 同じアサイン・請求月の全ての版（ページ外の版も含む）
+	var revisions []*model.BillingCalculation
+	err := s.db.WithContext(ctx).
+		Where("assignment_id IN ? AND billing_month IN ?", assignmentIDs, months).
+		Find(&revisions).Error
+	if err != nil {
+		s.logger.Error("Failed to get billing calculation revisions", zap.Error(err))
+		return nil, fmt.Errorf("請求計算の履歴の取得に失敗しました: %w", err)
+	}
+
+	byRevision := make(map[string]*model.BillingCalculation, len(revisions))
+	latest := make(map[string]int)
+	for _, revision := range revisions {
+		key := revision.AssignmentID + "|" + revision.BillingMonth
+		byRevision[fmt.Sprintf("%s|%d", key, revision.Revision)] = revision
+		if revision.Revision > latest[key] {
+			latest[key] = revision.Revision
+		}
+	}
+
+	for _, row := range rows {
+		key := row.AssignmentID + "|" + row.BillingMonth
+		previous := byRevision[fmt.Sprintf("%s|%d", key, row.Revision-1)]
+		snapshots = append(snapshots, dto.BillingCalculationSnapshotDTO{
+			BillingCalculation: row.BillingCalculation,
+			ClientName:         row.ClientName,
+			ProjectName:        row.ProjectName,
+			UserName:           row.UserName,
+			IsLatest:           row.Revision == latest[key],
+			Changes:            model.DiffBillingCalculations(previous, &row.BillingCalculation),
+		})
+	}
+	return snapshots, nil
 }
 
 // GetClientBillingHistory クライアントの請求履歴を取得
@@ -498,6 +982,11 @@ func (s *billingService) CheckDuplicateBilling(ctx context.Context, clientID str
 
 // calculateBillingDeadline 請求締め日を計算
 func (s *billingService) calculateBillingDeadline(year, month int, closingDay *int) time.Time {
+	return billingClosingDate(year, month, closingDay)
+}
+
+// billingClosingDate 取引先の締め日から請求月の締め日時を計算（未設定・月末超えは月末）
+func billingClosingDate(year, month int, closingDay *int) time.Time {
 	// デフォルトは月末
 	if closingDay == nil {
 		// 翌月の1日から1日引いて月末を取得
@@ -566,10 +1055,67 @@ func (s *billingService) ExecuteBilling(ctx context.Context, req *dto.ExecuteBil
 	return response, nil
 }
 
+// delegateClientBilling クライアント単位の請求を月次請求に委譲する
+func delegateClientBilling(service BillingServiceInterface, billingRun BillingRunServiceInterface) {
+	if s, ok := service.(*billingService); ok {
+		s.billingRun = billingRun
+	}
+}
+
 // ExecuteClientBilling クライアント単位の請求処理を実行
+// 請求書の作成と請求計算のスナップショットの記録は月次請求（billing run）に一本化している
 func (s *billingService) ExecuteClientBilling(ctx context.Context, clientID string, billingYear, billingMonth int, executorID string) (*dto.InvoiceResponse, error) {
-	// TODO: 実装予定
-	return nil, fmt.Errorf("未実装")
+	if s.billingRun == nil {
+		return nil, fmt.Errorf("月次請求サービスが設定されていません")
+	}
+
+	// 請求書の作成・採番・下書きの作り直しは月次請求に任せ、対象の取引先だけを実行する
+	run, err := s.billingRun.Execute(ctx, &dto.ExecuteBillingRunRequest{
+		BillingYear:  billingYear,
+		BillingMonth: billingMonth,
+		ClientIDs:    []string{clientID},
+	}, executorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 案件グループ別の請求書がある場合はグループなしの請求書を優先する
+	var invoiceID string
+	for _, invoice := range run.Invoices {
+		if invoice.ClientID != clientID {
+			continue
+		}
+		if invoiceID == "" || invoice.ProjectGroupID == nil {
+			invoiceID = invoice.ID
+		}
+	}
+	if invoiceID == "" {
+		reasons := append([]string{}, run.Warnings...)
+		for _, skipped := range run.Skipped {
+			if skipped.ClientID == clientID {
+				reasons = append(reasons, skipped.Reason)
+			}
+		}
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingCalculationFailed,
+			"請求対象の明細がないため請求書を作成できませんでした").
+			WithDetail("client_id", clientID).
+			WithDetail("billing_month", model.BillingMonthString(billingYear, billingMonth)).
+			WithDetail("reasons", reasons)
+	}
+
+	var invoice model.Invoice
+	err = s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("Details", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC")
+		}).
+		First(&invoice, "id = ?", invoiceID).Error
+	if err != nil {
+		return nil, fmt.Errorf("作成した請求書の取得に失敗しました: %w", err)
+	}
+
+	result := invoiceToDTO(&invoice)
+	return &result, nil
 }
 
 // 残りのメソッドは未実装として定義
@@ -605,19 +1151,110 @@ func (s *billingService) RetryFailedBillings(ctx context.Context, billingMonth s
 }
 
 // GetBillingSummary 請求サマリーを取得
+// 取消以外の請求書を取引先別に、最新の請求計算のスナップショットを精算タイプ別に集計する
 func (s *billingService) GetBillingSummary(ctx context.Context, year, month int) (*dto.BillingSummaryResponse, error) {
-	// TODO: 実装予定
-	return &dto.BillingSummaryResponse{
+	if month < 1 || month > 12 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingInvalidMonth, "月は1から12の間で指定してください")
+	}
+	billingMonth := fmt.Sprintf("%04d-%02d", year, month)
+
+	var invoices []*model.Invoice
+	err := s.db.WithContext(ctx).
+		Preload("Client").
+		Where("billing_month = ? AND status <> ?", billingMonth, model.InvoiceStatusCancelled).
+		Order("client_id, invoice_number").
+		Find(&invoices).Error
+	if err != nil {
+		s.logger.Error("Failed to get invoices for billing summary", zap.String("billing_month", billingMonth), zap.Error(err))
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+
+	response := &dto.BillingSummaryResponse{
 		Year:            year,
 		Month:           month,
-		TotalAmount:     0,
-		TotalInvoices:   0,
-		PaidInvoices:    0,
-		UnpaidInvoices:  0,
-		OverdueInvoices: 0,
-		TotalClients:    0,
-		ActiveProjects:  0,
 		BillingByClient: []dto.ClientBillingSummary{},
 		BillingByType:   []dto.BillingTypeSummary{},
-	}, nil
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	clientIndex := make(map[string]int)
+	for _, invoice := range invoices {
+		response.TotalAmount = response.TotalAmount.Add(invoice.TotalAmount)
+		response.TotalInvoices++
+
+		status := invoice.Status
+		if status == model.InvoiceStatusSent && invoice.DueDate.Before(today) {
+			status = model.InvoiceStatusOverdue
+		}
+		switch status {
+		case model.InvoiceStatusPaid:
+			response.PaidInvoices++
+		case model.InvoiceStatusOverdue:
+			response.OverdueInvoices++
+			response.UnpaidInvoices++
+		default:
+			response.UnpaidInvoices++
+		}
+
+		index, ok := clientIndex[invoice.ClientID]
+		if !ok {
+			index = len(response.BillingByClient)
+			clientIndex[invoice.ClientID] = index
+			response.BillingByClient = append(response.BillingByClient, dto.ClientBillingSummary{
+				ClientID:   invoice.ClientID,
+				ClientName: invoice.Client.CompanyName,
+				Status:     string(model.InvoiceStatusPaid),
+			})
+		}
+		summary := &response.BillingByClient[index]
+		summary.TotalAmount = summary.TotalAmount.Add(invoice.TotalAmount)
+		summary.InvoiceCount++
+		// 取引先の状態は未入金・延滞を優先する
+		if status == model.InvoiceStatusOverdue || (status != model.InvoiceStatusPaid && summary.Status == string(model.InvoiceStatusPaid)) {
+			summary.Status = string(status)
+		}
+	}
+	response.TotalClients = len(response.BillingByClient)
+
+	// 精算タイプ別は再計算前の版を除いた最新の請求計算から集計する
+	var types []struct {
+		BillingType string
+		Amount      money.Amount
+		Count       int
+	}
+	err = s.db.WithContext(ctx).
+		Table("billing_calculations AS bc").
+		Select("bc.billing_type, SUM(bc.billing_amount) AS amount, COUNT(*) AS count").
+		Where("bc.billing_month = ?", billingMonth).
+		Where(`bc.revision = (SELECT MAX(latest.revision) FROM billing_calculations latest
+			WHERE latest.assignment_id = bc.assignment_id AND latest.billing_month = bc.billing_month)`).
+		Group("bc.billing_type").
+		Order("bc.billing_type").
+		Scan(&types).Error
+	if err != nil {
+		s.logger.Error("Failed to summarize billing calculations", zap.String("billing_month", billingMonth), zap.Error(err))
+		return nil, fmt.Errorf("請求計算の集計に失敗しました: %w", err)
+	}
+
+	for _, t := range types {
+		response.BillingByType = append(response.BillingByType, dto.BillingTypeSummary{
+			BillingType:   t.BillingType,
+			TotalAmount:   t.Amount,
+			InvoiceCount:  t.Count,
+			AverageAmount: t.Amount.MulDiv(1, float64(t.Count), money.RoundingNone),
+		})
+	}
+
+	var activeProjects int64
+	if err := s.db.WithContext(ctx).
+		Model(&model.BillingCalculation{}).
+		Where("billing_month = ?", billingMonth).
+		Distinct("project_id").
+		Count(&activeProjects).Error; err != nil {
+		s.logger.Error("Failed to count billed projects", zap.String("billing_month", billingMonth), zap.Error(err))
+		return nil, fmt.Errorf("請求計算の集計に失敗しました: %w", err)
+	}
+	response.ActiveProjects = int(activeProjects)
+
+	return response, nil
 }
@@ -2,19 +2,24 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	commonRepo "github.com/duesk/monstera/internal/common/repository"
 	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/pkg/money"
 )
 
 // ========== モック実装 ==========
@@ -668,43 +673,43 @@ func TestBillingService_CalculateBillingAmount_Fixed(t *testing.T) {
 	// Test fixed billing
 	amount, err := service.CalculateBillingAmount(
 		model.ProjectBillingTypeFixed,
-		500000,
+		money.Yen(500000),
 		nil,
 		nil,
 		nil,
 	)
 
 	assert.NoError(t, err)
-	assert.Equal(t, float64(500000), amount)
+	assert.Equal(t, money.Yen(500000), amount)
 }
 
 func TestBillingService_CalculateBillingAmount_UpperLower(t *testing.T) {
 	service := &billingService{logger: zap.NewNop()}
 
 	// Test data
-	monthlyRate := float64(500000)
+	monthlyRate := money.Yen(500000)
 	lowerLimit := float64(140)
 	upperLimit := float64(180)
 
 	testCases := []struct {
 		name           string
 		actualHours    float64
-		expectedAmount float64
+		expectedAmount money.Amount
 	}{
 		{
 			name:           "範囲内",
 			actualHours:    160,
-			expectedAmount: 500000,
+			expectedAmount: money.Yen(500000),
 		},
 		{
 			name:           "下限を下回る",
 			actualHours:    120,
-			expectedAmount: 428571.43, // (500000 / 140) * 120 = 428571.4285714286
+			expectedAmount: money.FromFloat(428571.43), // 500000 * 120 / 140 = 428571.4285714286
 		},
 		{
 			name:           "上限を上回る",
 			actualHours:    200,
-			expectedAmount: 555555.56, // (500000 / 180) * 200 = 555555.5555555556
+			expectedAmount: money.FromFloat(555555.56), // 500000 * 200 / 180 = 555555.5555555556
 		},
 	}
 
@@ -719,7 +724,7 @@ func TestBillingService_CalculateBillingAmount_UpperLower(t *testing.T) {
 			)
 
 			assert.NoError(t, err)
-			assert.InDelta(t, tc.expectedAmount, amount, 1.0)
+			assert.Equal(t, tc.expectedAmount, amount)
 		})
 	}
 }
@@ -728,7 +733,7 @@ func TestBillingService_CalculateBillingAmount_Middle(t *testing.T) {
 	service := &billingService{logger: zap.NewNop()}
 
 	// Test data
-	monthlyRate := float64(500000)
+	monthlyRate := money.Yen(500000)
 	lowerLimit := float64(140)
 	upperLimit := float64(180)
 	actualHours := float64(160)
@@ -746,7 +751,7 @@ func TestBillingService_CalculateBillingAmount_Middle(t *testing.T) {
 	)
 
 	assert.NoError(t, err)
-	assert.Equal(t, float64(500000), amount)
+	assert.Equal(t, money.Yen(500000), amount)
 }
 
 func TestBillingService_ValidateBillingPeriod(t *testing.T) {
@@ -824,3 +829,81 @@ func TestBillingService_CheckDuplicateBilling(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, isDuplicate)
 }
+
+func TestAssignmentCostForRate(t *testing.T) {
+	costs := []*model.ProjectAssignmentCost{
+		{ID: "cost-1", PartnerID: "partner-1"},
+		{ID: "cost-2", PartnerID: "partner-2"},
+	}
+
+	t.Run("単価履歴の区間に対応する仕入単価を返す", func(t *testing.T) {
+		cost, err := assignmentCostForRate(costs, "cost-2", "assignment-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "partner-2", cost.PartnerID)
+	})
+
+	t.Run("対応する仕入単価がない場合は請求計算エラー", func(t *testing.T) {
+		cost, err := assignmentCostForRate(costs, "cost-3", "assignment-1")
+
+		assert.Nil(t, cost)
+		var accErr *accountingerrors.AccountingError
+		if assert.True(t, errors.As(err, &accErr)) {
+			assert.Equal(t, accountingerrors.ErrBillingCalculationFailed, accErr.Code)
+			assert.Equal(t, "assignment-1", accErr.Details["assignment_id"])
+			assert.Equal(t, "cost-3", accErr.Details["cost_id"])
+		}
+	})
+}
+
+func TestCollectActualHours_ConcurrentAssignments(t *testing.T) {
+	ctx := context.Background()
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	require.NoError(t, testutils.CreateTables(db, &model.WeeklyReport{}, &model.DailyRecord{}, &model.ProjectAssignment{}))
+	s := &billingService{db: db, logger: zap.NewNop()}
+
+	// 2024/4/1(月)〜4/7(日)、案件Aに週を通して、案件Bに4/3から並行してアサイン
+	monday := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	sunday := monday.AddDate(0, 0, 6)
+	assignmentA := &model.ProjectAssignment{ID: "assignment-a", ProjectID: "project-a", UserID: "user-1", StartDate: monday}
+	assignmentB := &model.ProjectAssignment{ID: "assignment-b", ProjectID: "project-b", UserID: "user-1", StartDate: monday.AddDate(0, 0, 2)}
+	require.NoError(t, db.Create([]*model.ProjectAssignment{assignmentA, assignmentB}).Error)
+
+	report := &model.WeeklyReport{ID: "report-1", UserID: "user-1", StartDate: monday, EndDate: sunday, Status: model.WeeklyReportStatusApproved}
+	require.NoError(t, db.Omit("User").Create(report).Error)
+	projectA, projectB := "project-a", "project-b"
+	records := []*model.DailyRecord{
+		{Date: monday, WorkHours: 8},
+		{Date: monday.AddDate(0, 0, 1), WorkHours: 8},
+		{Date: monday.AddDate(0, 0, 2), WorkHours: 5, ProjectID: &projectA},
+		{Date: monday.AddDate(0, 0, 2), WorkHours: 3, ProjectID: &projectB},
+		{Date: monday.AddDate(0, 0, 3), WorkHours: 2, ProjectID: &projectA},
+		{Date: monday.AddDate(0, 0, 3), WorkHours: 6, HasClientWork: true, ClientWorkHours: 6.5, ProjectID: &projectB},
+	}
+	for _, record := range records {
+		record.WeeklyReportID = report.ID
+	}
+	require.NoError(t, db.Omit("WeeklyReport").Create(records).Error)
+
+	t.Run("アサインごとに指定した案件の稼働だけを集計する", func(t *testing.T) {
+		_, evidenceA, err := s.collectActualHours(ctx, assignmentA, 2024, 4, monday, sunday)
+		require.NoError(t, err)
+		assert.Equal(t, 8+8+5+2.0, evidenceA.ActualHours)
+
+		_, evidenceB, err := s.collectActualHours(ctx, assignmentB, 2024, 4, monday.AddDate(0, 0, 2), sunday)
+		require.NoError(t, err)
+		assert.Equal(t, 3+6.5, evidenceB.ActualHours)
+	})
+
+	t.Run("並行するアサインの日に案件の指定がない稼働があれば請求しない", func(t *testing.T) {
+		require.NoError(t, db.Create(&model.DailyRecord{WeeklyReportID: report.ID, Date: monday.AddDate(0, 0, 4), WorkHours: 8}).Error)
+
+		_, _, err := s.collectActualHours(ctx, assignmentA, 2024, 4, monday, sunday)
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrBillingReportsUnsettled, accErr.Code)
+		assert.Equal(t, "2024-04", accErr.Details["month"])
+	})
+}
@@ -0,0 +1,739 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// BusinessPartnerServiceInterface ビジネスパートナー（仕入）サービスインターフェース
+type BusinessPartnerServiceInterface interface {
+	// ビジネスパートナー
+	ListPartners(ctx context.Context, activeOnly bool) ([]dto.BusinessPartnerDTO, error)
+	CreatePartner(ctx context.Context, req *dto.BusinessPartnerRequest) (*dto.BusinessPartnerDTO, error)
+	UpdatePartner(ctx context.Context, partnerID string, req *dto.BusinessPartnerRequest) (*dto.BusinessPartnerDTO, error)
+
+	// 仕入単価
+	ListAssignmentCosts(ctx context.Context, assignmentID string) ([]dto.ProjectAssignmentCostDTO, error)
+	CreateAssignmentCost(ctx context.Context, assignmentID string, req *dto.ProjectAssignmentCostRequest, userID string) (*dto.ProjectAssignmentCostDTO, error)
+	UpdateAssignmentCost(ctx context.Context, assignmentID, costID string, req *dto.ProjectAssignmentCostRequest, userID string) (*dto.ProjectAssignmentCostDTO, error)
+	DeleteAssignmentCost(ctx context.Context, assignmentID, costID string) error
+
+	// 注文書
+	IssuePurchaseOrders(ctx context.Context, year, month int, userID string) (*dto.IssuePurchaseOrdersResponse, error)
+	ListPurchaseOrders(ctx context.Context, orderMonth, partnerID string) ([]dto.PurchaseOrderDTO, error)
+
+	// 受領した請求書
+	RegisterPartnerInvoice(ctx context.Context, req *dto.PartnerInvoiceRequest, userID string) (*dto.PartnerInvoiceDTO, error)
+	ListPartnerInvoices(ctx context.Context, billingMonth, status string) ([]dto.PartnerInvoiceDTO, error)
+	ApprovePartnerInvoice(ctx context.Context, invoiceID, userID string) (*dto.PartnerInvoiceDTO, error)
+	RejectPartnerInvoice(ctx context.Context, invoiceID, reason, userID string) (*dto.PartnerInvoiceDTO, error)
+
+	// 粗利
+	GetMarginReport(ctx context.Context, req *dto.MarginReportRequest) (*dto.MarginReportResponse, error)
+}
+
+// businessPartnerService ビジネスパートナー（仕入）サービス実装
+type businessPartnerService struct {
+	db             *gorm.DB
+	logger         *zap.Logger
+	billingService BillingServiceInterface
+}
+
+// NewBusinessPartnerService ビジネスパートナー（仕入）サービスのコンストラクタ
+func NewBusinessPartnerService(db *gorm.DB, logger *zap.Logger, billingService BillingServiceInterface) BusinessPartnerServiceInterface {
+	return &businessPartnerService{
+		db:             db,
+		logger:         logger,
+		billingService: billingService,
+	}
+}
+
+// ListPartners ビジネスパートナー一覧を取得
+func (s *businessPartnerService) ListPartners(ctx context.Context, activeOnly bool) ([]dto.BusinessPartnerDTO, error) {
+	query := s.db.WithContext(ctx).Order("company_name_kana ASC, company_name ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var partners []*model.BusinessPartner
+	if err := query.Find(&partners).Error; err != nil {
+		return nil, fmt.Errorf("ビジネスパートナーの取得に失敗しました: %w", err)
+	}
+
+	result := make([]dto.BusinessPartnerDTO, 0, len(partners))
+	for _, partner := range partners {
+		result = append(result, dto.BusinessPartnerToDTO(partner))
+	}
+	return result, nil
+}
+
+// CreatePartner ビジネスパートナーを登録
+func (s *businessPartnerService) CreatePartner(ctx context.Context, req *dto.BusinessPartnerRequest) (*dto.BusinessPartnerDTO, error) {
+	partner := &model.BusinessPartner{
+		PaymentTerms:    30,
+		ProrationMethod: model.DefaultProrationMethod,
+		IsActive:        true,
+	}
+	applyPartnerRequest(partner, req)
+	if err := s.db.WithContext(ctx).Create(partner).Error; err != nil {
+		return nil, fmt.Errorf("ビジネスパートナーの登録に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Business partner created", zap.String("partner_id", partner.ID))
+
+	result := dto.BusinessPartnerToDTO(partner)
+	return &result, nil
+}
+
+// UpdatePartner ビジネスパートナーを更新
+func (s *businessPartnerService) UpdatePartner(ctx context.Context, partnerID string, req *dto.BusinessPartnerRequest) (*dto.BusinessPartnerDTO, error) {
+	partner, err := s.findPartner(s.db.WithContext(ctx), partnerID)
+	if err != nil {
+		return nil, err
+	}
+	applyPartnerRequest(partner, req)
+	if err := s.db.WithContext(ctx).Save(partner).Error; err != nil {
+		return nil, fmt.Errorf("ビジネスパートナーの更新に失敗しました: %w", err)
+	}
+
+	result := dto.BusinessPartnerToDTO(partner)
+	return &result, nil
+}
+
+// ListAssignmentCosts 案件アサインの仕入単価を取得
+func (s *businessPartnerService) ListAssignmentCosts(ctx context.Context, assignmentID string) ([]dto.ProjectAssignmentCostDTO, error) {
+	if _, err := s.findAssignment(s.db.WithContext(ctx), assignmentID); err != nil {
+		return nil, err
+	}
+
+	var costs []*model.ProjectAssignmentCost
+	err := s.db.WithContext(ctx).
+		Preload("Partner").
+		Where("assignment_id = ?", assignmentID).
+		Order("effective_from ASC").
+		Find(&costs).Error
+	if err != nil {
+		return nil, fmt.Errorf("仕入単価の取得に失敗しました: %w", err)
+	}
+
+	result := make([]dto.ProjectAssignmentCostDTO, 0, len(costs))
+	for _, cost := range costs {
+		result = append(result, dto.ProjectAssignmentCostToDTO(cost))
+	}
+	return result, nil
+}
+
+// CreateAssignmentCost 仕入単価を登録する
+// 終了日のない直前の仕入単価は、新しい仕入単価の適用開始日の前日で終了させる（単価改定）
+func (s *businessPartnerService) CreateAssignmentCost(ctx context.Context, assignmentID string, req *dto.ProjectAssignmentCostRequest, userID string) (*dto.ProjectAssignmentCostDTO, error) {
+	cost := &model.ProjectAssignmentCost{AssignmentID: assignmentID, CreatedBy: userID}
+	if err := applyCostRequest(cost, req, userID); err != nil {
+		return nil, err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := s.findAssignment(tx, assignmentID); err != nil {
+			return err
+		}
+		partner, err := s.findPartner(tx, cost.PartnerID)
+		if err != nil {
+			return err
+		}
+		costs, err := s.lockCosts(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+
+		for _, previous := range costs {
+			if previous.EffectiveTo != nil || !previous.EffectiveFrom.Before(cost.EffectiveFrom) {
+				continue
+			}
+			closedAt := cost.EffectiveFrom.AddDate(0, 0, -1)
+			previous.EffectiveTo = &closedAt
+			previous.UpdatedBy = userID
+			if err := tx.Save(previous).Error; err != nil {
+				return fmt.Errorf("仕入単価の更新に失敗しました: %w", err)
+			}
+		}
+
+		if err := checkCostOverlap(cost, costs); err != nil {
+			return err
+		}
+		if err := tx.Create(cost).Error; err != nil {
+			return fmt.Errorf("仕入単価の登録に失敗しました: %w", err)
+		}
+		cost.Partner = partner
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Project assignment cost created",
+		zap.String("assignment_id", assignmentID),
+		zap.String("cost_id", cost.ID),
+		zap.String("user_id", userID))
+
+	result := dto.ProjectAssignmentCostToDTO(cost)
+	return &result, nil
+}
+
+// UpdateAssignmentCost 仕入単価を変更する
+// 発行済みの注文書は発行時点の条件を保持するため、変更は以降に発行する注文書から反映される
+func (s *businessPartnerService) UpdateAssignmentCost(ctx context.Context, assignmentID, costID string, req *dto.ProjectAssignmentCostRequest, userID string) (*dto.ProjectAssignmentCostDTO, error) {
+	var cost *model.ProjectAssignmentCost
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		costs, err := s.lockCosts(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+		others := make([]*model.ProjectAssignmentCost, 0, len(costs))
+		for _, c := range costs {
+			if c.ID == costID {
+				cost = c
+			} else {
+				others = append(others, c)
+			}
+		}
+		if cost == nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "仕入単価が見つかりません")
+		}
+
+		if err := applyCostRequest(cost, req, userID); err != nil {
+			return err
+		}
+		partner, err := s.findPartner(tx, cost.PartnerID)
+		if err != nil {
+			return err
+		}
+		if err := checkCostOverlap(cost, others); err != nil {
+			return err
+		}
+		if err := tx.Save(cost).Error; err != nil {
+			return fmt.Errorf("仕入単価の更新に失敗しました: %w", err)
+		}
+		cost.Partner = partner
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := dto.ProjectAssignmentCostToDTO(cost)
+	return &result, nil
+}
+
+// DeleteAssignmentCost 仕入単価を削除する
+func (s *businessPartnerService) DeleteAssignmentCost(ctx context.Context, assignmentID, costID string) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND assignment_id = ?", costID, assignmentID).
+		Delete(&model.ProjectAssignmentCost{})
+	if result.Error != nil {
+		return fmt.Errorf("仕入単価の削除に失敗しました: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "仕入単価が見つかりません")
+	}
+	return nil
+}
+
+// IssuePurchaseOrders 対象月に仕入単価が適用されるアサインの注文書を発行する
+// 注文書は仕入単価ごとに発行し、発行済みのものは再発行しない
+func (s *businessPartnerService) IssuePurchaseOrders(ctx context.Context, year, month int, userID string) (*dto.IssuePurchaseOrdersResponse, error) {
+	orderMonth := model.BillingMonthString(year, month)
+	monthStart, monthEnd := model.BillingMonthRange(year, month)
+	response := &dto.IssuePurchaseOrdersResponse{
+		OrderMonth: orderMonth,
+		Orders:     []dto.PurchaseOrderDTO{},
+		Skipped:    []dto.PurchaseOrderSkip{},
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var costs []*model.ProjectAssignmentCost
+		err := tx.Preload("Partner").
+			Where("effective_from <= ? AND (effective_to IS NULL OR effective_to >= ?)", monthEnd, monthStart).
+			Order("assignment_id ASC, effective_from ASC").
+			Find(&costs).Error
+		if err != nil {
+			return fmt.Errorf("仕入単価の取得に失敗しました: %w", err)
+		}
+
+		var issued []string
+		err = tx.Model(&model.PurchaseOrder{}).
+			Where("order_month = ? AND status = ?", orderMonth, model.PurchaseOrderStatusIssued).
+			Pluck("cost_id", &issued).Error
+		if err != nil {
+			return fmt.Errorf("注文書の取得に失敗しました: %w", err)
+		}
+		issuedCosts := make(map[string]bool, len(issued))
+		for _, costID := range issued {
+			issuedCosts[costID] = true
+		}
+
+		var sequence int64
+		prefix := fmt.Sprintf("PO-%04d%02d-", year, month)
+		if err := tx.Model(&model.PurchaseOrder{}).Where("order_number LIKE ?", prefix+"%").Count(&sequence).Error; err != nil {
+			return fmt.Errorf("注文番号の採番に失敗しました: %w", err)
+		}
+
+		now := time.Now()
+		for _, cost := range costs {
+			if issuedCosts[cost.ID] {
+				continue
+			}
+			if cost.Partner != nil && !cost.Partner.IsActive {
+				response.Skipped = append(response.Skipped, dto.PurchaseOrderSkip{AssignmentID: cost.AssignmentID, Reason: "ビジネスパートナーが無効です"})
+				continue
+			}
+			assignment, err := s.findAssignment(tx, cost.AssignmentID)
+			if err != nil {
+				response.Skipped = append(response.Skipped, dto.PurchaseOrderSkip{AssignmentID: cost.AssignmentID, Reason: err.Error()})
+				continue
+			}
+
+			// 作業期間はアサイン期間・仕入単価の適用期間・対象月が重なる期間
+			start, end, err := assignmentBillingPeriod(assignment, year, month)
+			if err != nil {
+				response.Skipped = append(response.Skipped, dto.PurchaseOrderSkip{AssignmentID: cost.AssignmentID, Reason: err.Error()})
+				continue
+			}
+			if cost.EffectiveFrom.After(start) {
+				start = cost.EffectiveFrom
+			}
+			if cost.EffectiveTo != nil && cost.EffectiveTo.Before(end) {
+				end = *cost.EffectiveTo
+			}
+			if start.After(end) {
+				response.Skipped = append(response.Skipped, dto.PurchaseOrderSkip{AssignmentID: cost.AssignmentID, Reason: "対象月にアサイン期間と仕入単価の適用期間が重なる期間がありません"})
+				continue
+			}
+
+			sequence++
+			order := &model.PurchaseOrder{
+				OrderNumber:  fmt.Sprintf("%s%03d", prefix, sequence),
+				PartnerID:    cost.PartnerID,
+				AssignmentID: cost.AssignmentID,
+				CostID:       cost.ID,
+				OrderMonth:   orderMonth,
+				PeriodStart:  start,
+				PeriodEnd:    end,
+				CostType:     cost.CostType,
+				CostRate:     cost.CostRate,
+				MinHours:     cost.MinHours,
+				MaxHours:     cost.MaxHours,
+				Status:       model.PurchaseOrderStatusIssued,
+				IssuedBy:     userID,
+				IssuedAt:     now,
+			}
+			if err := tx.Create(order).Error; err != nil {
+				return fmt.Errorf("注文書の発行に失敗しました: %w", err)
+			}
+			order.Partner = cost.Partner
+			response.Orders = append(response.Orders, dto.PurchaseOrderToDTO(order))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Purchase orders issued",
+		zap.String("order_month", orderMonth),
+		zap.Int("issued", len(response.Orders)),
+		zap.Int("skipped", len(response.Skipped)),
+		zap.String("user_id", userID))
+
+	return response, nil
+}
+
+// ListPurchaseOrders 注文書一覧を取得
+func (s *businessPartnerService) ListPurchaseOrders(ctx context.Context, orderMonth, partnerID string) ([]dto.PurchaseOrderDTO, error) {
+	query := s.db.WithContext(ctx).Preload("Partner").Order("order_number ASC")
+	if orderMonth != "" {
+		query = query.Where("order_month = ?", orderMonth)
+	}
+	if partnerID != "" {
+		query = query.Where("partner_id = ?", partnerID)
+	}
+	var orders []*model.PurchaseOrder
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("注文書の取得に失敗しました: %w", err)
+	}
+
+	result := make([]dto.PurchaseOrderDTO, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, dto.PurchaseOrderToDTO(order))
+	}
+	return result, nil
+}
+
+// RegisterPartnerInvoice 受領した請求書を登録し、注文条件・週報・取引先への請求と突合する
+func (s *businessPartnerService) RegisterPartnerInvoice(ctx context.Context, req *dto.PartnerInvoiceRequest, userID string) (*dto.PartnerInvoiceDTO, error) {
+	var order model.PurchaseOrder
+	err := s.db.WithContext(ctx).Preload("Partner").Where("id = ?", req.PurchaseOrderID).First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "注文書が見つかりません")
+		}
+		return nil, fmt.Errorf("注文書の取得に失敗しました: %w", err)
+	}
+	if order.Status != model.PurchaseOrderStatusIssued {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "取消された注文書には請求書を登録できません").
+			WithDetail("purchase_order_id", order.ID)
+	}
+
+	var existing int64
+	err = s.db.WithContext(ctx).Model(&model.PartnerInvoice{}).
+		Where("purchase_order_id = ? AND status <> ?", order.ID, model.PartnerInvoiceStatusRejected).
+		Count(&existing).Error
+	if err != nil {
+		return nil, fmt.Errorf("受領した請求書の取得に失敗しました: %w", err)
+	}
+	if existing > 0 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "この注文書の請求書は既に登録されています").
+			WithDetail("purchase_order_id", order.ID)
+	}
+
+	invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+	if err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "請求日はYYYY-MM-DD形式で指定してください")
+	}
+	var dueDate time.Time
+	if order.Partner != nil {
+		dueDate = invoiceDate.AddDate(0, 0, order.Partner.PaymentTerms)
+	}
+	if req.DueDate != nil && *req.DueDate != "" {
+		dueDate, err = time.Parse("2006-01-02", *req.DueDate)
+		if err != nil {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "支払期日はYYYY-MM-DD形式で指定してください")
+		}
+	}
+	if req.Subtotal.Sign() < 0 || req.TaxAmount.Sign() < 0 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrBillingInvalidAmount, "請求額に負の値は指定できません")
+	}
+
+	assignment, err := s.findAssignment(s.db.WithContext(ctx), order.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	month, err := time.Parse("2006-01", order.OrderMonth)
+	if err != nil {
+		return nil, fmt.Errorf("注文書の対象月が不正です: %w", err)
+	}
+
+	// 注文条件と承認済みの週報から見込みの仕入額を計算する
+	expected, err := s.billingService.CalculateAssignmentCost(ctx, assignment, month.Year(), int(month.Month()))
+	if err != nil {
+		return nil, err
+	}
+	billedHours, err := s.billedHours(ctx, assignment, order.OrderMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &model.PartnerInvoice{
+		PartnerID:       order.PartnerID,
+		PurchaseOrderID: order.ID,
+		AssignmentID:    order.AssignmentID,
+		BillingMonth:    order.OrderMonth,
+		InvoiceNumber:   req.InvoiceNumber,
+		InvoiceDate:     invoiceDate,
+		DueDate:         dueDate,
+		Hours:           req.Hours,
+		Subtotal:        req.Subtotal,
+		TaxAmount:       req.TaxAmount,
+		TotalAmount:     req.Subtotal.Add(req.TaxAmount),
+		CreatedBy:       userID,
+	}
+	invoice.Match(expected.CostAmount, expected.ActualHours, billedHours)
+	if err := s.db.WithContext(ctx).Create(invoice).Error; err != nil {
+		return nil, fmt.Errorf("受領した請求書の登録に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Partner invoice registered",
+		zap.String("partner_invoice_id", invoice.ID),
+		zap.String("purchase_order_id", order.ID),
+		zap.String("status", string(invoice.Status)))
+
+	invoice.Partner = order.Partner
+	invoice.PurchaseOrder = &order
+	result := dto.PartnerInvoiceToDTO(invoice)
+	return &result, nil
+}
+
+// ListPartnerInvoices 受領した請求書一覧を取得
+func (s *businessPartnerService) ListPartnerInvoices(ctx context.Context, billingMonth, status string) ([]dto.PartnerInvoiceDTO, error) {
+	query := s.db.WithContext(ctx).Preload("Partner").Preload("PurchaseOrder").Order("invoice_date ASC")
+	if billingMonth != "" {
+		query = query.Where("billing_month = ?", billingMonth)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var invoices []*model.PartnerInvoice
+	if err := query.Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("受領した請求書の取得に失敗しました: %w", err)
+	}
+
+	result := make([]dto.PartnerInvoiceDTO, 0, len(invoices))
+	for _, invoice := range invoices {
+		result = append(result, dto.PartnerInvoiceToDTO(invoice))
+	}
+	return result, nil
+}
+
+// ApprovePartnerInvoice 受領した請求書を承認する（突合で差異がある場合も確認の上で承認できる）
+func (s *businessPartnerService) ApprovePartnerInvoice(ctx context.Context, invoiceID, userID string) (*dto.PartnerInvoiceDTO, error) {
+	return s.settlePartnerInvoice(ctx, invoiceID, func(invoice *model.PartnerInvoice) {
+		now := time.Now()
+		invoice.Status = model.PartnerInvoiceStatusApproved
+		invoice.ApprovedBy = &userID
+		invoice.ApprovedAt = &now
+	})
+}
+
+// RejectPartnerInvoice 受領した請求書を差し戻す（差戻し後は同じ注文書で再登録できる）
+func (s *businessPartnerService) RejectPartnerInvoice(ctx context.Context, invoiceID, reason, userID string) (*dto.PartnerInvoiceDTO, error) {
+	return s.settlePartnerInvoice(ctx, invoiceID, func(invoice *model.PartnerInvoice) {
+		invoice.Status = model.PartnerInvoiceStatusRejected
+		if invoice.MatchNotes != "" {
+			invoice.MatchNotes += "\n"
+		}
+		invoice.MatchNotes += "差戻し理由: " + reason
+	})
+}
+
+// settlePartnerInvoice 突合済みの受領した請求書を承認・差戻しする
+func (s *businessPartnerService) settlePartnerInvoice(ctx context.Context, invoiceID string, apply func(invoice *model.PartnerInvoice)) (*dto.PartnerInvoiceDTO, error) {
+	var invoice model.PartnerInvoice
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", invoiceID).First(&invoice).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "受領した請求書が見つかりません")
+			}
+			return fmt.Errorf("受領した請求書の取得に失敗しました: %w", err)
+		}
+		if invoice.Status != model.PartnerInvoiceStatusMatched && invoice.Status != model.PartnerInvoiceStatusMismatched {
+			return accountingerrors.ErrPartnerInvoiceAlreadySettledError(invoice.ID, string(invoice.Status))
+		}
+
+		apply(&invoice)
+		if err := tx.Save(&invoice).Error; err != nil {
+			return fmt.Errorf("受領した請求書の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := dto.PartnerInvoiceToDTO(&invoice)
+	return &result, nil
+}
+
+// marginEntryColumns 粗利の集計元の取引先・案件・エンジニア
+const marginEntryColumns = `p.client_id AS client_id, c.company_name AS client_name,
+	p.id AS project_id, p.project_name AS project_name,
+	u.id AS user_id, COALESCE(NULLIF(u.name, ''), u.last_name || ' ' || u.first_name) AS user_name`
+
+// GetMarginReport 請求明細（売上）と受領した請求書（仕入）から粗利レポートを作成する
+// 売上は取消以外の請求書、仕入は差戻し以外の受領した請求書の税抜額を集計する
+func (s *businessPartnerService) GetMarginReport(ctx context.Context, req *dto.MarginReportRequest) (*dto.MarginReportResponse, error) {
+	startMonth, err := time.Parse("2006-01", req.StartMonth)
+	if err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "開始月はYYYY-MM形式で指定してください")
+	}
+	endMonth, err := time.Parse("2006-01", req.EndMonth)
+	if err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "終了月はYYYY-MM形式で指定してください")
+	}
+	if endMonth.Before(startMonth) {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "終了月は開始月以降を指定してください")
+	}
+	groupBy := model.MarginGroupBy(req.GroupBy)
+	if groupBy == "" {
+		groupBy = model.MarginGroupByMonth
+	}
+
+	var revenues []model.MarginEntry
+	query := s.db.WithContext(ctx).
+		Table("invoice_details d").
+		Select("i.billing_month AS month, "+marginEntryColumns+", d.amount AS amount").
+		Joins("JOIN invoices i ON i.id = d.invoice_id AND i.deleted_at IS NULL").
+		Joins("LEFT JOIN projects p ON p.id = d.project_id").
+		Joins("LEFT JOIN clients c ON c.id = p.client_id").
+		Joins("LEFT JOIN users u ON u.id = d.user_id").
+		Where("d.deleted_at IS NULL AND i.status <> ?", model.InvoiceStatusCancelled).
+		Where("i.billing_month BETWEEN ? AND ?", req.StartMonth, req.EndMonth)
+	if err := marginFilter(query, req).Scan(&revenues).Error; err != nil {
+		s.logger.Error("Failed to get revenues for margin report", zap.Error(err))
+		return nil, fmt.Errorf("売上の集計に失敗しました: %w", err)
+	}
+
+	var costs []model.MarginEntry
+	query = s.db.WithContext(ctx).
+		Table("partner_invoices pi").
+		Select("pi.billing_month AS month, "+marginEntryColumns+", pi.subtotal AS amount").
+		Joins("JOIN project_assignments pa ON pa.id = pi.assignment_id").
+		Joins("LEFT JOIN projects p ON p.id = pa.project_id").
+		Joins("LEFT JOIN clients c ON c.id = p.client_id").
+		Joins("LEFT JOIN users u ON u.id = pa.user_id").
+		Where("pi.status <> ?", model.PartnerInvoiceStatusRejected).
+		Where("pi.billing_month BETWEEN ? AND ?", req.StartMonth, req.EndMonth)
+	if err := marginFilter(query, req).Scan(&costs).Error; err != nil {
+		s.logger.Error("Failed to get costs for margin report", zap.Error(err))
+		return nil, fmt.Errorf("仕入の集計に失敗しました: %w", err)
+	}
+
+	rows := model.BuildMarginReport(groupBy, revenues, costs)
+	return &dto.MarginReportResponse{
+		GroupBy:    string(groupBy),
+		StartMonth: req.StartMonth,
+		EndMonth:   req.EndMonth,
+		Rows:       rows,
+		Total:      model.SumMarginRows(rows),
+	}, nil
+}
+
+// marginFilter 粗利レポートの取引先・案件・エンジニアの絞り込み
+func marginFilter(query *gorm.DB, req *dto.MarginReportRequest) *gorm.DB {
+	if req.ClientID != "" {
+		query = query.Where("p.client_id = ?", req.ClientID)
+	}
+	if req.ProjectID != "" {
+		query = query.Where("p.id = ?", req.ProjectID)
+	}
+	if req.UserID != "" {
+		query = query.Where("u.id = ?", req.UserID)
+	}
+	return query
+}
+
+// billedHours 取引先に請求した稼働時間（請求明細の精算実績）
+// 取引先への請求がまだない場合はnil
+func (s *businessPartnerService) billedHours(ctx context.Context, assignment *model.ProjectAssignment, billingMonth string) (*float64, error) {
+	var result struct {
+		Hours *float64
+	}
+	err := s.db.WithContext(ctx).
+		Table("invoice_details d").
+		Select("SUM(d.actual_hours) AS hours").
+		Joins("JOIN invoices i ON i.id = d.invoice_id AND i.deleted_at IS NULL").
+		Where("d.deleted_at IS NULL AND i.status <> ?", model.InvoiceStatusCancelled).
+		Where("i.billing_month = ? AND d.project_id = ? AND d.user_id = ?", billingMonth, assignment.ProjectID, assignment.UserID).
+		Scan(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("取引先への請求の取得に失敗しました: %w", err)
+	}
+	return result.Hours, nil
+}
+
+// findPartner ビジネスパートナーを取得
+func (s *businessPartnerService) findPartner(tx *gorm.DB, partnerID string) (*model.BusinessPartner, error) {
+	var partner model.BusinessPartner
+	if err := tx.Where("id = ?", partnerID).First(&partner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "ビジネスパートナーが見つかりません")
+		}
+		return nil, fmt.Errorf("ビジネスパートナーの取得に失敗しました: %w", err)
+	}
+	return &partner, nil
+}
+
+// findAssignment 案件アサインを取得
+func (s *businessPartnerService) findAssignment(tx *gorm.DB, assignmentID string) (*model.ProjectAssignment, error) {
+	var assignment model.ProjectAssignment
+	if err := tx.Where("id = ?", assignmentID).First(&assignment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "案件アサインが見つかりません")
+		}
+		return nil, fmt.Errorf("案件アサインの取得に失敗しました: %w", err)
+	}
+	return &assignment, nil
+}
+
+// lockCosts 案件アサインの仕入単価を更新ロック付きで取得
+func (s *businessPartnerService) lockCosts(tx *gorm.DB, assignmentID string) ([]*model.ProjectAssignmentCost, error) {
+	var costs []*model.ProjectAssignmentCost
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("assignment_id = ?", assignmentID).
+		Order("effective_from ASC").
+		Find(&costs).Error
+	if err != nil {
+		return nil, fmt.Errorf("仕入単価の取得に失敗しました: %w", err)
+	}
+	return costs, nil
+}
+
+// applyPartnerRequest リクエストの内容をビジネスパートナーに反映
+func applyPartnerRequest(partner *model.BusinessPartner, req *dto.BusinessPartnerRequest) {
+	partner.CompanyName = req.CompanyName
+	partner.CompanyNameKana = req.CompanyNameKana
+	partner.RegistrationNumber = req.RegistrationNumber
+	partner.ContactPerson = req.ContactPerson
+	partner.ContactEmail = req.ContactEmail
+	partner.ContactPhone = req.ContactPhone
+	partner.Address = req.Address
+	partner.Notes = req.Notes
+	if req.PaymentTerms != nil {
+		partner.PaymentTerms = *req.PaymentTerms
+	}
+	if req.ProrationMethod != "" {
+		partner.ProrationMethod = model.ProrationMethod(req.ProrationMethod)
+	}
+	if req.IsActive != nil {
+		partner.IsActive = *req.IsActive
+	}
+}
+
+// applyCostRequest リクエストの内容を仕入単価に反映して検証
+func applyCostRequest(cost *model.ProjectAssignmentCost, req *dto.ProjectAssignmentCostRequest, userID string) error {
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "適用開始日はYYYY-MM-DD形式で指定してください")
+	}
+	var effectiveTo *time.Time
+	if req.EffectiveTo != nil && *req.EffectiveTo != "" {
+		parsed, err := time.Parse("2006-01-02", *req.EffectiveTo)
+		if err != nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "適用終了日はYYYY-MM-DD形式で指定してください")
+		}
+		effectiveTo = &parsed
+	}
+
+	cost.PartnerID = req.PartnerID
+	cost.EffectiveFrom = effectiveFrom
+	cost.EffectiveTo = effectiveTo
+	cost.CostType = model.ProjectBillingType(req.CostType)
+	cost.CostRate = req.CostRate
+	cost.MinHours = req.MinHours
+	cost.MaxHours = req.MaxHours
+	cost.Notes = req.Notes
+	cost.UpdatedBy = userID
+
+	// 精算条件の検証は請求単価と共通
+	if err := cost.AsRate().Validate(); err != nil {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// checkCostOverlap 適用期間が他の仕入単価と重ならないことを確認
+func checkCostOverlap(cost *model.ProjectAssignmentCost, others []*model.ProjectAssignmentCost) error {
+	for _, other := range others {
+		if other.ID != cost.ID && cost.AsRate().Overlaps(other.AsRate()) {
+			return accountingerrors.ErrPurchaseCostOverlapError(cost.AssignmentID, other.ID)
+		}
+	}
+	return nil
+}
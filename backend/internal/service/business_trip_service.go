@@ -0,0 +1,536 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+// BusinessTripService 出張申請・出張精算サービスのインターフェース
+type BusinessTripService interface {
+	// 申請者
+	ListMyTrips(ctx context.Context, userID string, filter repository.BusinessTripFilter) (*dto.BusinessTripsResponse, error)
+	GetMyTrip(ctx context.Context, userID, tripID string) (*dto.BusinessTripResponse, error)
+	CreateTrip(ctx context.Context, userID string, req *dto.BusinessTripRequest) (*dto.BusinessTripResponse, error)
+	UpdateTrip(ctx context.Context, userID, tripID string, req *dto.BusinessTripRequest) (*dto.BusinessTripResponse, error)
+	CancelTrip(ctx context.Context, userID, tripID string) (*dto.BusinessTripResponse, error)
+	SubmitSettlement(ctx context.Context, userID, tripID string, req *dto.BusinessTripSettlementRequest) (*dto.BusinessTripResponse, error)
+
+	// 承認者
+	ListTrips(ctx context.Context, filter repository.BusinessTripFilter) (*dto.BusinessTripsResponse, error)
+	GetTrip(ctx context.Context, tripID string) (*dto.BusinessTripResponse, error)
+	ApproveTrip(ctx context.Context, approverID, tripID string, req *dto.BusinessTripReviewRequest) (*dto.BusinessTripResponse, error)
+	RejectTrip(ctx context.Context, approverID, tripID string, req *dto.BusinessTripRejectRequest) (*dto.BusinessTripResponse, error)
+	ApproveSettlement(ctx context.Context, approverID, tripID string, req *dto.BusinessTripReviewRequest) (*dto.BusinessTripResponse, error)
+	ReturnSettlement(ctx context.Context, approverID, tripID string, req *dto.BusinessTripRejectRequest) (*dto.BusinessTripResponse, error)
+
+	// 日当の単価表
+	ListPerDiemRates(ctx context.Context) (*dto.BusinessTripPerDiemRatesResponse, error)
+	CreatePerDiemRate(ctx context.Context, userID string, req *dto.BusinessTripPerDiemRateRequest) (*dto.BusinessTripPerDiemRateResponse, error)
+	UpdatePerDiemRate(ctx context.Context, rateID string, req *dto.BusinessTripPerDiemRateRequest) (*dto.BusinessTripPerDiemRateResponse, error)
+	DeletePerDiemRate(ctx context.Context, rateID string) error
+}
+
+// businessTripService 出張申請・出張精算サービスの実装
+type businessTripService struct {
+	db       *gorm.DB
+	tripRepo repository.BusinessTripRepository
+	logger   *zap.Logger
+}
+
+// NewBusinessTripService 出張申請・出張精算サービスのインスタンスを生成
+func NewBusinessTripService(db *gorm.DB, tripRepo repository.BusinessTripRepository, logger *zap.Logger) BusinessTripService {
+	return &businessTripService{
+		db:       db,
+		tripRepo: tripRepo,
+		logger:   logger,
+	}
+}
+
+// ListMyTrips 申請者の出張申請を取得
+func (s *businessTripService) ListMyTrips(ctx context.Context, userID string, filter repository.BusinessTripFilter) (*dto.BusinessTripsResponse, error) {
+	filter.UserID = &userID
+	return s.ListTrips(ctx, filter)
+}
+
+// GetMyTrip 申請者の出張申請を取得
+func (s *businessTripService) GetMyTrip(ctx context.Context, userID, tripID string) (*dto.BusinessTripResponse, error) {
+	trip, err := s.getTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.UserID != userID {
+		return nil, errBusinessTripNotFound(tripID)
+	}
+	return toBusinessTripResponse(trip), nil
+}
+
+// CreateTrip 出張申請を作成（事前承認待ち）
+func (s *businessTripService) CreateTrip(ctx context.Context, userID string, req *dto.BusinessTripRequest) (*dto.BusinessTripResponse, error) {
+	trip := &model.BusinessTrip{
+		UserID: userID,
+		Status: model.BusinessTripStatusPending,
+	}
+	if err := s.applyRequest(ctx, trip, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.tripRepo.Create(ctx, trip); err != nil {
+		return nil, fmt.Errorf("出張申請の作成に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Business trip requested",
+		zap.String("business_trip_id", trip.ID),
+		zap.String("user_id", userID),
+		zap.Int("estimated_cost", trip.EstimatedCost))
+
+	return s.GetMyTrip(ctx, userID, trip.ID)
+}
+
+// UpdateTrip 事前承認前の出張申請を更新
+func (s *businessTripService) UpdateTrip(ctx context.Context, userID, tripID string, req *dto.BusinessTripRequest) (*dto.BusinessTripResponse, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trip, err := s.lockOwnTrip(ctx, tx, userID, tripID)
+		if err != nil {
+			return err
+		}
+		if !trip.CanEdit() {
+			return accountingerrors.ErrBusinessTripStatusInvalidError(trip.ID, string(trip.Status))
+		}
+		if err := s.applyRequest(ctx, trip, req); err != nil {
+			return err
+		}
+		if err := repository.NewBusinessTripRepository(tx, s.logger).Update(ctx, trip); err != nil {
+			return fmt.Errorf("出張申請の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetMyTrip(ctx, userID, tripID)
+}
+
+// CancelTrip 出張申請を取り消す（精算の申請前まで）
+func (s *businessTripService) CancelTrip(ctx context.Context, userID, tripID string) (*dto.BusinessTripResponse, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trip, err := s.lockOwnTrip(ctx, tx, userID, tripID)
+		if err != nil {
+			return err
+		}
+		if !trip.CanCancel() {
+			return accountingerrors.ErrBusinessTripStatusInvalidError(trip.ID, string(trip.Status))
+		}
+
+		txTripRepo := repository.NewBusinessTripRepository(tx, s.logger)
+		trip.Status = model.BusinessTripStatusCancelled
+		if err := txTripRepo.Update(ctx, trip); err != nil {
+			return fmt.Errorf("出張申請の取り消しに失敗しました: %w", err)
+		}
+		// 差し戻された精算で紐付けていた経費申請を解除
+		if err := txTripRepo.ReplaceExpenses(ctx, trip.ID, nil); err != nil {
+			return fmt.Errorf("経費申請の紐付けの解除に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Business trip cancelled",
+		zap.String("business_trip_id", tripID),
+		zap.String("user_id", userID))
+	return s.GetMyTrip(ctx, userID, tripID)
+}
+
+// SubmitSettlement 出張後に経費申請と日当で精算を申請
+func (s *businessTripService) SubmitSettlement(ctx context.Context, userID, tripID string, req *dto.BusinessTripSettlementRequest) (*dto.BusinessTripResponse, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trip, err := s.lockOwnTrip(ctx, tx, userID, tripID)
+		if err != nil {
+			return err
+		}
+		if !trip.CanSubmitSettlement() {
+			return accountingerrors.ErrBusinessTripStatusInvalidError(trip.ID, string(trip.Status))
+		}
+
+		txTripRepo := repository.NewBusinessTripRepository(tx, s.logger)
+		expenseIDs := uniqueIDs(req.ExpenseIDs)
+		expenses, err := txTripRepo.GetExpensesByIDs(ctx, userID, expenseIDs)
+		if err != nil {
+			return fmt.Errorf("経費申請の取得に失敗しました: %w", err)
+		}
+		if len(expenses) != len(expenseIDs) {
+			return invalidBusinessTrip("経費申請が見つかりません")
+		}
+		for i := range expenses {
+			expense := &expenses[i]
+			if !model.IsBusinessTripSettlementExpense(expense) {
+				return invalidBusinessTrip(fmt.Sprintf("提出済みの経費申請を選択してください（%s）", expense.Title))
+			}
+			if expense.BusinessTripID != nil && *expense.BusinessTripID != trip.ID {
+				return invalidBusinessTrip(fmt.Sprintf("他の出張で精算済みの経費申請です（%s）", expense.Title))
+			}
+		}
+
+		// 出張の開始日時点の日当の単価で日当を計算
+		rates, err := txTripRepo.ListPerDiemRates(ctx)
+		if err != nil {
+			return fmt.Errorf("日当の単価の取得に失敗しました: %w", err)
+		}
+		dailyRate := 0
+		if rate := model.PerDiemRateFor(rates, trip.TripType(), trip.StartDate); rate != nil {
+			dailyRate = rate.DailyAmount
+		} else {
+			s.logger.Warn("No per diem rate configured for business trip",
+				zap.String("business_trip_id", trip.ID),
+				zap.String("trip_type", string(trip.TripType())))
+		}
+
+		now := time.Now()
+		trip.ApplyExpenses(expenses)
+		trip.ApplyAllowance(dailyRate)
+		trip.Status = model.BusinessTripStatusSettlementPending
+		trip.SettlementSubmittedAt = &now
+		trip.SettlementComment = ""
+		if err := txTripRepo.Update(ctx, trip); err != nil {
+			return fmt.Errorf("出張精算の申請に失敗しました: %w", err)
+		}
+		if err := txTripRepo.ReplaceExpenses(ctx, trip.ID, expenseIDs); err != nil {
+			return fmt.Errorf("経費申請の紐付けに失敗しました: %w", err)
+		}
+
+		s.logger.Info("Business trip settlement submitted",
+			zap.String("business_trip_id", trip.ID),
+			zap.String("user_id", userID),
+			zap.Int("settlement_total", trip.SettlementTotal()),
+			zap.Int("variance", trip.Variance()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetMyTrip(ctx, userID, tripID)
+}
+
+// ListTrips 出張申請を取得
+func (s *businessTripService) ListTrips(ctx context.Context, filter repository.BusinessTripFilter) (*dto.BusinessTripsResponse, error) {
+	trips, total, err := s.tripRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("出張申請の取得に失敗しました: %w", err)
+	}
+
+	response := &dto.BusinessTripsResponse{
+		Trips: make([]dto.BusinessTripResponse, len(trips)),
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}
+	for i := range trips {
+		response.Trips[i].FromModel(&trips[i])
+	}
+	return response, nil
+}
+
+// GetTrip 出張申請を精算の内容とともに取得
+func (s *businessTripService) GetTrip(ctx context.Context, tripID string) (*dto.BusinessTripResponse, error) {
+	trip, err := s.getTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return toBusinessTripResponse(trip), nil
+}
+
+// ApproveTrip 出張を事前承認
+func (s *businessTripService) ApproveTrip(ctx context.Context, approverID, tripID string, req *dto.BusinessTripReviewRequest) (*dto.BusinessTripResponse, error) {
+	return s.review(ctx, approverID, tripID, model.BusinessTripStatusPending, func(tx *gorm.DB, trip *model.BusinessTrip) error {
+		onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, trip.UserID, approverID, model.ApprovalDelegationScopeExpense)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		trip.Status = model.BusinessTripStatusApproved
+		trip.ApproverID = &approverID
+		trip.OnBehalfOfID = onBehalfOfID
+		trip.ApprovedAt = &now
+		trip.ApprovalComment = req.Comment
+		return nil
+	})
+}
+
+// RejectTrip 出張申請を却下
+func (s *businessTripService) RejectTrip(ctx context.Context, approverID, tripID string, req *dto.BusinessTripRejectRequest) (*dto.BusinessTripResponse, error) {
+	return s.review(ctx, approverID, tripID, model.BusinessTripStatusPending, func(tx *gorm.DB, trip *model.BusinessTrip) error {
+		onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, trip.UserID, approverID, model.ApprovalDelegationScopeExpense)
+		if err != nil {
+			return err
+		}
+		trip.Status = model.BusinessTripStatusRejected
+		trip.ApproverID = &approverID
+		trip.OnBehalfOfID = onBehalfOfID
+		trip.ApprovalComment = req.Comment
+		return nil
+	})
+}
+
+// ApproveSettlement 出張精算を承認
+// 承認時点で却下・取消された経費申請は精算額から除く
+func (s *businessTripService) ApproveSettlement(ctx context.Context, approverID, tripID string, req *dto.BusinessTripReviewRequest) (*dto.BusinessTripResponse, error) {
+	return s.review(ctx, approverID, tripID, model.BusinessTripStatusSettlementPending, func(tx *gorm.DB, trip *model.BusinessTrip) error {
+		var expenses []model.Expense
+		if err := tx.WithContext(ctx).Where("business_trip_id = ?", trip.ID).Find(&expenses).Error; err != nil {
+			return fmt.Errorf("経費申請の取得に失敗しました: %w", err)
+		}
+		now := time.Now()
+		trip.ApplyExpenses(expenses)
+		trip.Status = model.BusinessTripStatusSettled
+		trip.SettledBy = &approverID
+		trip.SettledAt = &now
+		trip.SettlementComment = req.Comment
+		return nil
+	})
+}
+
+// ReturnSettlement 出張精算を差し戻す（申請者は経費申請を選び直して再申請する）
+func (s *businessTripService) ReturnSettlement(ctx context.Context, approverID, tripID string, req *dto.BusinessTripRejectRequest) (*dto.BusinessTripResponse, error) {
+	return s.review(ctx, approverID, tripID, model.BusinessTripStatusSettlementPending, func(tx *gorm.DB, trip *model.BusinessTrip) error {
+		trip.Status = model.BusinessTripStatusApproved
+		trip.SettlementComment = req.Comment
+		return nil
+	})
+}
+
+// review 承認者の操作を排他ロックした出張申請に適用（自分の出張申請は操作できない）
+func (s *businessTripService) review(ctx context.Context, approverID, tripID string, expected model.BusinessTripStatus, apply func(tx *gorm.DB, trip *model.BusinessTrip) error) (*dto.BusinessTripResponse, error) {
+	var status model.BusinessTripStatus
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txTripRepo := repository.NewBusinessTripRepository(tx, s.logger)
+		trip, err := txTripRepo.GetByIDForUpdate(ctx, tripID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errBusinessTripNotFound(tripID)
+			}
+			return fmt.Errorf("出張申請の取得に失敗しました: %w", err)
+		}
+		if trip.UserID == approverID {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "自分の出張申請は承認できません").
+				WithDetail("business_trip_id", tripID)
+		}
+		if trip.Status != expected {
+			return accountingerrors.ErrBusinessTripStatusInvalidError(trip.ID, string(trip.Status))
+		}
+
+		if err := apply(tx, trip); err != nil {
+			return err
+		}
+		if err := txTripRepo.Update(ctx, trip); err != nil {
+			return fmt.Errorf("出張申請の更新に失敗しました: %w", err)
+		}
+		status = trip.Status
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Business trip reviewed",
+		zap.String("business_trip_id", tripID),
+		zap.String("approver_id", approverID),
+		zap.String("status", string(status)))
+	return s.GetTrip(ctx, tripID)
+}
+
+// ListPerDiemRates 日当の単価表を取得
+func (s *businessTripService) ListPerDiemRates(ctx context.Context) (*dto.BusinessTripPerDiemRatesResponse, error) {
+	rates, err := s.tripRepo.ListPerDiemRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("日当の単価の取得に失敗しました: %w", err)
+	}
+
+	response := &dto.BusinessTripPerDiemRatesResponse{
+		Rates: make([]dto.BusinessTripPerDiemRateResponse, len(rates)),
+	}
+	for i := range rates {
+		response.Rates[i].FromModel(&rates[i])
+	}
+	return response, nil
+}
+
+// CreatePerDiemRate 日当の単価を登録
+func (s *businessTripService) CreatePerDiemRate(ctx context.Context, userID string, req *dto.BusinessTripPerDiemRateRequest) (*dto.BusinessTripPerDiemRateResponse, error) {
+	rate := &model.BusinessTripPerDiemRate{CreatedBy: userID}
+	if err := applyPerDiemRateRequest(rate, req); err != nil {
+		return nil, err
+	}
+	if err := s.tripRepo.CreatePerDiemRate(ctx, rate); err != nil {
+		return nil, fmt.Errorf("日当の単価の登録に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Business trip per diem rate created",
+		zap.String("per_diem_rate_id", rate.ID),
+		zap.String("trip_type", string(rate.TripType)),
+		zap.Int("daily_amount", rate.DailyAmount),
+		zap.String("created_by", userID))
+
+	var response dto.BusinessTripPerDiemRateResponse
+	response.FromModel(rate)
+	return &response, nil
+}
+
+// UpdatePerDiemRate 日当の単価を更新（精算済みの出張申請には影響しない）
+func (s *businessTripService) UpdatePerDiemRate(ctx context.Context, rateID string, req *dto.BusinessTripPerDiemRateRequest) (*dto.BusinessTripPerDiemRateResponse, error) {
+	rate, err := s.tripRepo.GetPerDiemRate(ctx, rateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errPerDiemRateNotFound(rateID)
+		}
+		return nil, fmt.Errorf("日当の単価の取得に失敗しました: %w", err)
+	}
+	if err := applyPerDiemRateRequest(rate, req); err != nil {
+		return nil, err
+	}
+	if err := s.tripRepo.UpdatePerDiemRate(ctx, rate); err != nil {
+		return nil, fmt.Errorf("日当の単価の更新に失敗しました: %w", err)
+	}
+
+	var response dto.BusinessTripPerDiemRateResponse
+	response.FromModel(rate)
+	return &response, nil
+}
+
+// DeletePerDiemRate 日当の単価を削除
+func (s *businessTripService) DeletePerDiemRate(ctx context.Context, rateID string) error {
+	if err := s.tripRepo.DeletePerDiemRate(ctx, rateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errPerDiemRateNotFound(rateID)
+		}
+		return fmt.Errorf("日当の単価の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// getTrip 出張申請を取得
+func (s *businessTripService) getTrip(ctx context.Context, tripID string) (*model.BusinessTrip, error) {
+	trip, err := s.tripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errBusinessTripNotFound(tripID)
+		}
+		return nil, fmt.Errorf("出張申請の取得に失敗しました: %w", err)
+	}
+	return trip, nil
+}
+
+// lockOwnTrip 申請者本人の出張申請を排他ロックして取得
+func (s *businessTripService) lockOwnTrip(ctx context.Context, tx *gorm.DB, userID, tripID string) (*model.BusinessTrip, error) {
+	trip, err := repository.NewBusinessTripRepository(tx, s.logger).GetByIDForUpdate(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errBusinessTripNotFound(tripID)
+		}
+		return nil, fmt.Errorf("出張申請の取得に失敗しました: %w", err)
+	}
+	if trip.UserID != userID {
+		return nil, errBusinessTripNotFound(tripID)
+	}
+	return trip, nil
+}
+
+// applyRequest リクエストの内容を出張申請に設定して検証
+func (s *businessTripService) applyRequest(ctx context.Context, trip *model.BusinessTrip, req *dto.BusinessTripRequest) error {
+	startDate, err := parseAccountingDate(req.StartDate, "開始日")
+	if err != nil {
+		return err
+	}
+	endDate, err := parseAccountingDate(req.EndDate, "終了日")
+	if err != nil {
+		return err
+	}
+
+	trip.ProjectID = normalizeOptionalID(req.ProjectID)
+	trip.Purpose = strings.TrimSpace(req.Purpose)
+	trip.Destination = strings.TrimSpace(req.Destination)
+	trip.StartDate = startDate
+	trip.EndDate = endDate
+	trip.EstimatedCost = req.EstimatedCost
+	if err := trip.Validate(); err != nil {
+		return invalidBusinessTrip(err.Error())
+	}
+
+	if trip.ProjectID != nil {
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&model.Project{}).Where("id = ?", *trip.ProjectID).Count(&count).Error; err != nil {
+			return fmt.Errorf("案件の確認に失敗しました: %w", err)
+		}
+		if count == 0 {
+			return invalidBusinessTrip("案件が見つかりません")
+		}
+	}
+	return nil
+}
+
+// applyPerDiemRateRequest リクエストの内容を日当の単価に設定して検証
+func applyPerDiemRateRequest(rate *model.BusinessTripPerDiemRate, req *dto.BusinessTripPerDiemRateRequest) error {
+	effectiveFrom, err := parseAccountingDate(req.EffectiveFrom, "適用開始日")
+	if err != nil {
+		return err
+	}
+	rate.TripType = model.BusinessTripType(req.TripType)
+	rate.DailyAmount = req.DailyAmount
+	rate.EffectiveFrom = effectiveFrom
+	rate.Description = strings.TrimSpace(req.Description)
+	if err := rate.Validate(); err != nil {
+		return invalidBusinessTrip(err.Error())
+	}
+	return nil
+}
+
+// errBusinessTripNotFound 出張申請が見つからないエラー
+// 他人の出張申請も存在を明かさないよう同じエラーにする
+func errBusinessTripNotFound(tripID string) error {
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "出張申請が見つかりません").
+		WithDetail("business_trip_id", tripID)
+}
+
+// errPerDiemRateNotFound 日当の単価が見つからないエラー
+func errPerDiemRateNotFound(rateID string) error {
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "日当の単価が見つかりません").
+		WithDetail("per_diem_rate_id", rateID)
+}
+
+// invalidBusinessTrip 出張申請・日当の単価の内容が不正なエラー
+func invalidBusinessTrip(message string) error {
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, message)
+}
+
+// toBusinessTripResponse 出張申請をレスポンスに変換
+func toBusinessTripResponse(trip *model.BusinessTrip) *dto.BusinessTripResponse {
+	var response dto.BusinessTripResponse
+	response.FromModel(trip)
+	return &response
+}
+
+// uniqueIDs 重複を除いたIDの一覧（順序は保持）
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,908 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+// CashAdvanceService 仮払金サービスのインターフェース
+type CashAdvanceService interface {
+	// 基本CRUD操作
+	Create(ctx context.Context, userID string, req *dto.CashAdvanceRequest) (*model.CashAdvance, error)
+	GetByID(ctx context.Context, id string, userID string) (*model.CashAdvance, error)
+	GetByIDForAdmin(ctx context.Context, id string) (*model.CashAdvance, error)
+	Update(ctx context.Context, id string, userID string, req *dto.CashAdvanceRequest) (*model.CashAdvance, error)
+	CancelCashAdvance(ctx context.Context, id string, userID string) (*model.CashAdvance, error)
+
+	// 一覧取得
+	List(ctx context.Context, userID string, filter *dto.CashAdvanceFilterRequest) (*dto.CashAdvanceListResponse, error)
+	ListAll(ctx context.Context, filter *dto.CashAdvanceFilterRequest) (*dto.CashAdvanceListResponse, error)
+
+	// 承認フロー
+	ApproveCashAdvance(ctx context.Context, id string, approverID string, req *dto.CashAdvanceReviewRequest) (*model.CashAdvance, error)
+	RejectCashAdvance(ctx context.Context, id string, approverID string, req *dto.CashAdvanceRejectRequest) (*model.CashAdvance, error)
+
+	// 精算
+	SubmitSettlement(ctx context.Context, id string, userID string, req *dto.CashAdvanceSettlementRequest) (*model.CashAdvance, error)
+	ApproveSettlement(ctx context.Context, id string, approverID string, req *dto.CashAdvanceReviewRequest) (*model.CashAdvance, error)
+	ReturnSettlement(ctx context.Context, id string, approverID string, req *dto.CashAdvanceRejectRequest) (*model.CashAdvance, error)
+
+	// 支払（経理）
+	RecordPayment(ctx context.Context, id string, userID string, req *dto.CashAdvancePaymentRequest) (*model.CashAdvance, error)
+	CloseSettlement(ctx context.Context, id string, userID string, req *dto.CashAdvancePaymentRequest) (*model.CashAdvance, error)
+
+	// 未精算の管理
+	GetBalances(ctx context.Context) (*dto.CashAdvanceBalancesResponse, error)
+	SendOverdueReminders(ctx context.Context) (*dto.CashAdvanceReminderResult, error)
+}
+
+// cashAdvanceService 仮払金サービスの実装
+type cashAdvanceService struct {
+	db                  *gorm.DB
+	advanceRepo         repository.CashAdvanceRepository
+	notificationService NotificationService
+	logger              *zap.Logger
+}
+
+// NewCashAdvanceService 仮払金サービスのインスタンスを生成
+func NewCashAdvanceService(db *gorm.DB, advanceRepo repository.CashAdvanceRepository, notificationService NotificationService, logger *zap.Logger) CashAdvanceService {
+	return &cashAdvanceService{
+		db:                  db,
+		advanceRepo:         advanceRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// Create 仮払金を申請（経費申請と同じ承認フローの承認待ちになる）
+func (s *cashAdvanceService) Create(ctx context.Context, userID string, req *dto.CashAdvanceRequest) (*model.CashAdvance, error) {
+	advance := &model.CashAdvance{
+		UserID: userID,
+		Status: model.CashAdvanceStatusPending,
+	}
+	if err := applyCashAdvanceRequest(advance, req); err != nil {
+		return nil, err
+	}
+
+	// トランザクション内で仮払金と承認フローを作成
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		if err := txAdvanceRepo.Create(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の作成に失敗しました: %w", err)
+		}
+
+		// 承認フローを作成（適用した承認ルールを仮払金に記録する）
+		if err := s.createApprovalFlow(ctx, tx, advance); err != nil {
+			return err
+		}
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to create cash advance",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance created successfully",
+		zap.String("cash_advance_id", advance.ID),
+		zap.String("user_id", userID),
+		zap.String("amount", advance.Amount.String()))
+
+	return s.GetByID(ctx, advance.ID, userID)
+}
+
+// GetByID 仮払金を取得（本人の申請のみ）
+func (s *cashAdvanceService) GetByID(ctx context.Context, id string, userID string) (*model.CashAdvance, error) {
+	advance, err := s.GetByIDForAdmin(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// アクセス権限チェック（他人の仮払金は存在しないものとして扱う）
+	if advance.UserID != userID {
+		return nil, errCashAdvanceNotFound(id)
+	}
+
+	return advance, nil
+}
+
+// GetByIDForAdmin 承認者・経理用の仮払金詳細取得（本人以外も可）
+func (s *cashAdvanceService) GetByIDForAdmin(ctx context.Context, id string) (*model.CashAdvance, error) {
+	advance, err := s.advanceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errCashAdvanceNotFound(id)
+		}
+		return nil, fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+	}
+	return advance, nil
+}
+
+// Update 承認前の仮払金を更新（承認フローは変更後の金額で作り直す）
+func (s *cashAdvanceService) Update(ctx context.Context, id string, userID string, req *dto.CashAdvanceRequest) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+		txApprovalRepo := repository.NewExpenseApprovalRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+		if advance.UserID != userID {
+			return errCashAdvanceNotFound(id)
+		}
+
+		// ステータスチェック
+		if !advance.CanEdit() {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		if err := applyCashAdvanceRequest(advance, req); err != nil {
+			return err
+		}
+
+		// 承認フローを作り直す
+		if err := txApprovalRepo.DeleteByCashAdvanceID(ctx, advance.ID); err != nil {
+			return fmt.Errorf("承認フローの削除に失敗しました: %w", err)
+		}
+		if err := s.createApprovalFlow(ctx, tx, advance); err != nil {
+			return err
+		}
+
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to update cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+// CancelCashAdvance 仮払金を取り消す（支払前まで）
+func (s *cashAdvanceService) CancelCashAdvance(ctx context.Context, id string, userID string) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+		txApprovalRepo := repository.NewExpenseApprovalRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+		if advance.UserID != userID {
+			return errCashAdvanceNotFound(id)
+		}
+
+		// ステータスチェック
+		if !advance.CanCancel() {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 承認途中の承認フローは取り下げる
+		if advance.Status == model.CashAdvanceStatusPending {
+			if err := txApprovalRepo.DeleteByCashAdvanceID(ctx, advance.ID); err != nil {
+				return fmt.Errorf("承認フローの削除に失敗しました: %w", err)
+			}
+		}
+
+		advance.Status = model.CashAdvanceStatusCancelled
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to cancel cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance cancelled successfully",
+		zap.String("cash_advance_id", id),
+		zap.String("user_id", userID))
+
+	return s.GetByID(ctx, id, userID)
+}
+
+// List 自分の仮払金一覧を取得
+func (s *cashAdvanceService) List(ctx context.Context, userID string, filter *dto.CashAdvanceFilterRequest) (*dto.CashAdvanceListResponse, error) {
+	// ユーザーIDをフィルターに設定
+	filter.UserID = &userID
+	return s.ListAll(ctx, filter)
+}
+
+// ListAll 全社員の仮払金一覧を取得（承認者・経理用）
+func (s *cashAdvanceService) ListAll(ctx context.Context, filter *dto.CashAdvanceFilterRequest) (*dto.CashAdvanceListResponse, error) {
+	filter.SetDefaults()
+
+	advances, total, err := s.advanceRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list cash advances", zap.Error(err))
+		return nil, fmt.Errorf("仮払金一覧の取得に失敗しました: %w", err)
+	}
+
+	// レスポンス作成
+	response := &dto.CashAdvanceListResponse{
+		Items:      make([]dto.CashAdvanceResponse, len(advances)),
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalPages: int((total + int64(filter.Limit) - 1) / int64(filter.Limit)),
+	}
+	for i := range advances {
+		response.Items[i] = dto.CashAdvanceToResponse(&advances[i])
+	}
+
+	return response, nil
+}
+
+// ApproveCashAdvance 承認フローの現在の段階の承認者として仮払金を承認
+// 全段階の承認が完了すると経理の支払待ちになる
+func (s *cashAdvanceService) ApproveCashAdvance(ctx context.Context, id string, approverID string, req *dto.CashAdvanceReviewRequest) (*model.CashAdvance, error) {
+	var isFullyApproved bool
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+		txApprovalRepo := repository.NewExpenseApprovalRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// 自分の仮払金は承認できない
+		if advance.UserID == approverID {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "自分の仮払金は承認できません")
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusPending {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 承認者の承認履歴を取得
+		approvals, err := txApprovalRepo.GetByCashAdvanceID(ctx, advance.ID)
+		if err != nil {
+			return fmt.Errorf("承認履歴の取得に失敗しました: %w", err)
+		}
+
+		// 承認者が承認権限を持っているかチェック（代理承認者は不在の承認者に代わって承認できる）
+		targetApproval, err := findActionableApproval(ctx, tx, s.logger, advance.UserID, approvals, approverID)
+		if err != nil {
+			return err
+		}
+		if targetApproval == nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "この仮払金を承認する権限がありません")
+		}
+
+		// 前の承認が完了しているかチェック（順序承認の場合）
+		for _, approval := range approvals {
+			if approval.ApprovalOrder < targetApproval.ApprovalOrder && approval.Status == model.ApprovalStatusPending {
+				return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status)).
+					WithDetail("reason", "前の承認が完了していません")
+			}
+		}
+
+		// 承認処理
+		targetApproval.Approve(req.Comment)
+		if err := updateApprovalStatusBy(ctx, txApprovalRepo, targetApproval, req.Comment, approverID); err != nil {
+			return fmt.Errorf("承認ステータスの更新に失敗しました: %w", err)
+		}
+
+		// 全ての承認が完了したかチェック
+		for _, approval := range approvals {
+			if approval.ID != targetApproval.ID && approval.Status == model.ApprovalStatusPending {
+				return nil
+			}
+		}
+
+		// 全承認完了の場合は支払待ちにする
+		now := time.Now()
+		advance.Status = model.CashAdvanceStatusApproved
+		advance.ApproverID = &approverID
+		advance.OnBehalfOfID = targetApproval.OnBehalfOfID
+		advance.ApprovedAt = &now
+		advance.ApprovalComment = req.Comment
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+		isFullyApproved = true
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to approve cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("approver_id", approverID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance approved successfully",
+		zap.String("cash_advance_id", id),
+		zap.String("approver_id", approverID),
+		zap.Bool("fully_approved", isFullyApproved))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// RejectCashAdvance 承認フローの現在の段階の承認者として仮払金を却下
+func (s *cashAdvanceService) RejectCashAdvance(ctx context.Context, id string, approverID string, req *dto.CashAdvanceRejectRequest) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+		txApprovalRepo := repository.NewExpenseApprovalRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// 自分の仮払金は却下できない
+		if advance.UserID == approverID {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "自分の仮払金は却下できません")
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusPending {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 承認者の承認履歴を取得
+		approvals, err := txApprovalRepo.GetByCashAdvanceID(ctx, advance.ID)
+		if err != nil {
+			return fmt.Errorf("承認履歴の取得に失敗しました: %w", err)
+		}
+
+		// 承認者が却下権限を持っているかチェック（代理承認者は不在の承認者に代わって却下できる）
+		targetApproval, err := findActionableApproval(ctx, tx, s.logger, advance.UserID, approvals, approverID)
+		if err != nil {
+			return err
+		}
+		if targetApproval == nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "この仮払金を却下する権限がありません")
+		}
+
+		// 却下処理
+		targetApproval.Reject(req.Comment)
+		if err := updateApprovalStatusBy(ctx, txApprovalRepo, targetApproval, req.Comment, approverID); err != nil {
+			return fmt.Errorf("承認ステータスの更新に失敗しました: %w", err)
+		}
+
+		// 仮払金のステータスを却下に更新
+		advance.Status = model.CashAdvanceStatusRejected
+		advance.ApproverID = &approverID
+		advance.OnBehalfOfID = targetApproval.OnBehalfOfID
+		advance.ApprovalComment = req.Comment
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		// 他の承認履歴も却下扱いにする
+		for i := range approvals {
+			if approvals[i].ID != targetApproval.ID && approvals[i].Status == model.ApprovalStatusPending {
+				if err := txApprovalRepo.UpdateApprovalStatus(ctx, approvals[i].ID, model.ApprovalStatusRejected, "他の承認者により却下", approvals[i].ApproverID); err != nil {
+					return fmt.Errorf("承認ステータスの更新に失敗しました: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to reject cash advance",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("approver_id", approverID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance rejected successfully",
+		zap.String("cash_advance_id", id),
+		zap.String("approver_id", approverID),
+		zap.String("reason", req.Comment))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// SubmitSettlement 実際の経費申請を紐付けて仮払金の精算を申請
+// 紐付けた経費申請は振込バッチの対象から外れ、仮払金との差額で精算する
+func (s *cashAdvanceService) SubmitSettlement(ctx context.Context, id string, userID string, req *dto.CashAdvanceSettlementRequest) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+		if advance.UserID != userID {
+			return errCashAdvanceNotFound(id)
+		}
+
+		// ステータスチェック（差し戻し後の再申請を含む）
+		if !advance.CanSubmitSettlement() {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 精算に紐付ける経費申請をチェック
+		expenseIDs := uniqueIDs(req.ExpenseIDs)
+		expenses, err := txAdvanceRepo.GetExpensesByIDs(ctx, userID, expenseIDs)
+		if err != nil {
+			return fmt.Errorf("経費申請の取得に失敗しました: %w", err)
+		}
+		if len(expenses) != len(expenseIDs) {
+			return invalidCashAdvance("経費申請が見つかりません")
+		}
+		for i := range expenses {
+			if expenses[i].CashAdvanceID != nil && *expenses[i].CashAdvanceID != advance.ID {
+				return invalidCashAdvance(fmt.Sprintf("他の仮払金で精算済みの経費申請です（%s）", expenses[i].Title))
+			}
+			if !model.IsCashAdvanceSettlementExpense(&expenses[i]) {
+				return invalidCashAdvance(fmt.Sprintf("提出済みで未精算の経費申請を選択してください（%s）", expenses[i].Title))
+			}
+		}
+
+		// 精算の承認待ちに更新
+		now := time.Now()
+		advance.ApplyExpenses(expenses)
+		advance.Status = model.CashAdvanceStatusSettlementPending
+		advance.SettlementSubmittedAt = &now
+		advance.SettlementComment = ""
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+		if err := txAdvanceRepo.ReplaceExpenses(ctx, advance.ID, expenseIDs); err != nil {
+			return fmt.Errorf("経費申請の紐付けに失敗しました: %w", err)
+		}
+
+		s.logger.Info("Cash advance settlement submitted",
+			zap.String("cash_advance_id", advance.ID),
+			zap.String("user_id", userID),
+			zap.String("expense_total", advance.ExpenseTotal.String()),
+			zap.String("difference", advance.Difference().String()))
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to submit cash advance settlement",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+// ApproveSettlement 仮払金の精算を承認
+// 紐付けた経費申請がすべて承認済みである必要がある。差額がなければそのまま精算完了とする
+func (s *cashAdvanceService) ApproveSettlement(ctx context.Context, id string, approverID string, req *dto.CashAdvanceReviewRequest) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// 自分の精算は承認できない
+		if advance.UserID == approverID {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "自分の仮払金の精算は承認できません")
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusSettlementPending {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 紐付けた経費申請の承認が済んでいるかチェック
+		expenses, err := txAdvanceRepo.GetExpenses(ctx, advance.ID)
+		if err != nil {
+			return fmt.Errorf("経費申請の取得に失敗しました: %w", err)
+		}
+		for i := range expenses {
+			if expenses[i].Status == model.ExpenseStatusSubmitted {
+				return invalidCashAdvance(fmt.Sprintf("承認待ちの経費申請があります（%s）", expenses[i].Title))
+			}
+		}
+
+		// 精算承認済みに更新（差額がなければ精算完了）
+		now := time.Now()
+		advance.ApplyExpenses(expenses)
+		advance.Status = model.CashAdvanceStatusSettled
+		advance.SettledBy = &approverID
+		advance.SettledAt = &now
+		advance.SettlementComment = req.Comment
+		if advance.DifferenceType() == model.CashAdvanceDifferenceNone {
+			if err := closeCashAdvance(ctx, txAdvanceRepo, advance, approverID, now); err != nil {
+				return err
+			}
+		}
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to approve cash advance settlement",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("approver_id", approverID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance settlement approved successfully",
+		zap.String("cash_advance_id", id),
+		zap.String("approver_id", approverID))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// ReturnSettlement 仮払金の精算を差し戻す（申請者は経費申請を選び直して再申請する）
+func (s *cashAdvanceService) ReturnSettlement(ctx context.Context, id string, approverID string, req *dto.CashAdvanceRejectRequest) (*model.CashAdvance, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// 自分の精算は差し戻せない
+		if advance.UserID == approverID {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingPermission, "自分の仮払金の精算は差し戻せません")
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusSettlementPending {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		// 精算待ちに戻す
+		advance.Status = model.CashAdvanceStatusPaid
+		advance.SettlementComment = req.Comment
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to return cash advance settlement",
+			zap.Error(err),
+			zap.String("cash_advance_id", id),
+			zap.String("approver_id", approverID))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance settlement returned",
+		zap.String("cash_advance_id", id),
+		zap.String("approver_id", approverID),
+		zap.String("reason", req.Comment))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// RecordPayment 承認済みの仮払金の支払を記録（精算期限まで精算待ちになる）
+func (s *cashAdvanceService) RecordPayment(ctx context.Context, id string, userID string, req *dto.CashAdvancePaymentRequest) (*model.CashAdvance, error) {
+	paidAt, err := parseAccountingDate(req.Date, "支払日")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusApproved {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		advance.Status = model.CashAdvanceStatusPaid
+		advance.PaidAt = &paidAt
+		advance.PaidBy = &userID
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to record cash advance payment",
+			zap.Error(err),
+			zap.String("cash_advance_id", id))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance paid",
+		zap.String("cash_advance_id", id),
+		zap.String("paid_by", userID))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// CloseSettlement 精算差額の返金・追加支払の完了を記録
+func (s *cashAdvanceService) CloseSettlement(ctx context.Context, id string, userID string, req *dto.CashAdvancePaymentRequest) (*model.CashAdvance, error) {
+	closedAt, err := parseAccountingDate(req.Date, "精算日")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdvanceRepo := repository.NewCashAdvanceRepository(tx, s.logger)
+
+		// 仮払金を取得（排他ロック）
+		advance, err := txAdvanceRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCashAdvanceNotFound(id)
+			}
+			return fmt.Errorf("仮払金の取得に失敗しました: %w", err)
+		}
+
+		// ステータスチェック
+		if advance.Status != model.CashAdvanceStatusSettled {
+			return accountingerrors.ErrCashAdvanceStatusInvalidError(advance.ID, string(advance.Status))
+		}
+
+		if err := closeCashAdvance(ctx, txAdvanceRepo, advance, userID, closedAt); err != nil {
+			return err
+		}
+		if err := txAdvanceRepo.Update(ctx, advance); err != nil {
+			return fmt.Errorf("仮払金の更新に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to close cash advance settlement",
+			zap.Error(err),
+			zap.String("cash_advance_id", id))
+		return nil, err
+	}
+
+	s.logger.Info("Cash advance settlement closed",
+		zap.String("cash_advance_id", id),
+		zap.String("closed_by", userID))
+
+	return s.GetByIDForAdmin(ctx, id)
+}
+
+// GetBalances 未精算の仮払金を社員ごとに集計
+func (s *cashAdvanceService) GetBalances(ctx context.Context) (*dto.CashAdvanceBalancesResponse, error) {
+	advances, err := s.advanceRepo.ListOutstanding(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("未精算の仮払金の取得に失敗しました: %w", err)
+	}
+
+	balances := model.SummarizeCashAdvanceBalances(advances, time.Now())
+	response := &dto.CashAdvanceBalancesResponse{
+		Balances: make([]dto.CashAdvanceBalanceResponse, len(balances)),
+	}
+	for i := range balances {
+		response.Balances[i].FromModel(&balances[i])
+		response.OutstandingAmount = response.OutstandingAmount.Add(balances[i].OutstandingAmount)
+		response.OverdueCount += balances[i].OverdueCount
+	}
+	return response, nil
+}
+
+// SendOverdueReminders 精算期限を過ぎた仮払金の申請者に督促を送る
+// 前回の督促から一定期間が経っていない仮払金には送らない
+func (s *cashAdvanceService) SendOverdueReminders(ctx context.Context) (*dto.CashAdvanceReminderResult, error) {
+	now := time.Now()
+	advances, err := s.advanceRepo.ListOverdue(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("精算期限を過ぎた仮払金の取得に失敗しました: %w", err)
+	}
+
+	result := &dto.CashAdvanceReminderResult{OverdueCount: len(advances)}
+	for i := range advances {
+		advance := &advances[i]
+		if !advance.NeedsReminder(now) {
+			continue
+		}
+		if err := s.notificationService.NotifyCashAdvanceSettlementOverdue(ctx, advance, advance.DaysOverdue(now)); err != nil {
+			s.logger.Warn("Failed to send cash advance settlement reminder",
+				zap.Error(err),
+				zap.String("cash_advance_id", advance.ID),
+				zap.String("user_id", advance.UserID))
+			result.FailedCount++
+			continue
+		}
+		if err := s.advanceRepo.RecordReminder(ctx, advance.ID, now); err != nil {
+			return nil, fmt.Errorf("督促の記録に失敗しました: %w", err)
+		}
+		result.ReminderCount++
+	}
+
+	s.logger.Info("Cash advance settlement reminders sent",
+		zap.Int("overdue", result.OverdueCount),
+		zap.Int("reminded", result.ReminderCount),
+		zap.Int("failed", result.FailedCount))
+	return result, nil
+}
+
+// createApprovalFlow 経費申請と同じ承認ルール・承認者設定で仮払金の承認フローを作成
+// 一致する承認ルールがなければ承認者設定による承認フローとする。
+// 仮払金は事前に支払うため、自動承認のルールに一致しても承認者設定による承認フローで確認する
+func (s *cashAdvanceService) createApprovalFlow(ctx context.Context, tx *gorm.DB, advance *model.CashAdvance) error {
+	requester, err := repository.NewUserRepository(tx).GetByID(ctx, advance.UserID)
+	if err != nil {
+		return fmt.Errorf("申請者の取得に失敗しました: %w", err)
+	}
+
+	// 承認ルールの金額条件・承認者設定の金額は円単位
+	amount := int(advance.Amount.Yen())
+	rule, err := selectApprovalRule(ctx, tx, s.logger, model.ExpenseRoutingTarget{
+		Amount:       amount,
+		DepartmentID: requester.DepartmentID,
+	})
+	if err != nil {
+		return err
+	}
+	if rule != nil && rule.AutoApprove {
+		rule = nil
+	}
+
+	advance.ApprovalRuleID = nil
+	if rule != nil {
+		advance.ApprovalRuleID = &rule.ID
+		err = createRuleApprovalFlow(ctx, tx, s.logger, model.ExpenseApproval{CashAdvanceID: &advance.ID}, requester, rule)
+	} else {
+		err = repository.NewExpenseApprovalRepository(tx, s.logger).CreateCashAdvanceApprovalFlow(ctx, advance.ID, amount,
+			repository.NewExpenseApproverSettingRepository(tx, s.logger))
+	}
+	if err != nil {
+		// 承認者未設定の場合は申請者に設定の依頼を促すメッセージを返す
+		var expenseErr *dto.ExpenseError
+		if errors.As(err, &expenseErr) && expenseErr.Code == dto.ErrCodeNoApproversConfigured {
+			return invalidCashAdvance(expenseErr.Message)
+		}
+		return fmt.Errorf("承認フローの作成に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// closeCashAdvance 仮払金を精算完了にし、紐付けた経費申請を支払済みにする
+func closeCashAdvance(ctx context.Context, txAdvanceRepo repository.CashAdvanceRepository, advance *model.CashAdvance, userID string, closedAt time.Time) error {
+	advance.Status = model.CashAdvanceStatusClosed
+	advance.ClosedAt = &closedAt
+	advance.ClosedBy = &userID
+	if err := txAdvanceRepo.MarkExpensesPaid(ctx, advance.ID, closedAt); err != nil {
+		return fmt.Errorf("経費申請の更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// cashAdvanceApprovalItem 承認待ちの仮払金を承認待ち一覧の項目に変換
+// 自分の仮払金を代理で承認することになる場合はfalseを返す
+func cashAdvanceApprovalItem(approval *model.ExpenseApproval, approverID string) (dto.ApprovalItemResponse, bool) {
+	advance := approval.CashAdvance
+	if advance == nil {
+		return dto.ApprovalItemResponse{}, false
+	}
+
+	item := dto.ApprovalItemResponse{
+		ApprovalID:    approval.ID,
+		RequestType:   dto.ApprovalRequestTypeCashAdvance,
+		CashAdvanceID: &advance.ID,
+		Title:         "仮払金",
+		Amount:        int(advance.Amount.Yen()),
+		ApprovalType:  string(approval.ApprovalType),
+		ApprovalOrder: approval.ApprovalOrder,
+		RequestedAt:   advance.CreatedAt,
+		Description:   advance.Purpose,
+		ReceiptURLs:   []string{},
+	}
+	if advance.User != nil && advance.User.ID != "" {
+		item.User = &dto.UserSummary{
+			ID:   advance.User.ID,
+			Name: advance.User.Name,
+		}
+	}
+	if approval.ApproverID != approverID {
+		if advance.UserID == approverID {
+			return dto.ApprovalItemResponse{}, false
+		}
+		item.OnBehalfOf = &dto.UserSummary{
+			ID:   approval.ApproverID,
+			Name: approval.Approver.Name,
+		}
+	}
+	return item, true
+}
+
+// applyCashAdvanceRequest リクエストの内容を仮払金に設定して検証
+func applyCashAdvanceRequest(advance *model.CashAdvance, req *dto.CashAdvanceRequest) error {
+	dueDate, err := parseAccountingDate(req.SettlementDueDate, "精算期限")
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if dueDate.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)) {
+		return invalidCashAdvance("精算期限は今日以降の日付を指定してください")
+	}
+
+	advance.Purpose = strings.TrimSpace(req.Purpose)
+	advance.Amount = req.Amount
+	advance.SettlementDueDate = dueDate
+	if err := advance.Validate(); err != nil {
+		return invalidCashAdvance(err.Error())
+	}
+	return nil
+}
+
+// errCashAdvanceNotFound 仮払金が見つからないエラー
+func errCashAdvanceNotFound(advanceID string) error {
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "仮払金が見つかりません").
+		WithDetail("cash_advance_id", advanceID)
+}
+
+// invalidCashAdvance 仮払金の申請内容が不正なエラー
+func invalidCashAdvance(message string) error {
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, message)
+}
@@ -143,6 +143,13 @@ func (s *clientService) CreateClient(ctx context.Context, req *dto.CreateClientR
 		ContactPhone:    req.ContactPhone,
 		Address:         req.Address,
 		Notes:           req.Notes,
+		InvoiceTemplate: req.InvoiceTemplate,
+		ProrationMethod: model.ProrationMethod(req.ProrationMethod),
+
+		InvoiceNumberPrefix: req.InvoiceNumberPrefix,
+	}
+	if client.ProrationMethod == "" {
+		client.ProrationMethod = model.DefaultProrationMethod
 	}
 
 	// 作成
@@ -209,6 +216,15 @@ func (s *clientService) UpdateClient(ctx context.Context, clientID string, req *
 	if req.Notes != nil {
 		updates["notes"] = *req.Notes
 	}
+	if req.InvoiceTemplate != nil {
+		updates["invoice_template"] = *req.InvoiceTemplate
+	}
+	if req.ProrationMethod != nil {
+		updates["proration_method"] = *req.ProrationMethod
+	}
+	if req.InvoiceNumberPrefix != nil {
+		updates["invoice_number_prefix"] = *req.InvoiceNumberPrefix
+	}
 
 	if err := tx.Model(&client).Updates(updates).Error; err != nil {
 		tx.Rollback()
@@ -334,7 +350,11 @@ func (s *clientService) modelToDTO(client *model.Client) dto.ClientDTO {
 		ContactPhone:    client.ContactPhone,
 		Address:         client.Address,
 		Notes:           client.Notes,
+		InvoiceTemplate: client.InvoiceTemplate,
+		ProrationMethod: string(client.ProrationMethod),
 		CreatedAt:       client.CreatedAt,
 		UpdatedAt:       client.UpdatedAt,
+
+		InvoiceNumberPrefix: client.InvoiceNumberPrefix,
 	}
 }
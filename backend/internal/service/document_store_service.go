@@ -0,0 +1,679 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/docstore"
+)
+
+// documentChainHeadID ハッシュチェーンの末尾の行のID
+const documentChainHeadID = 1
+
+// documentVerifyBatchSize ハッシュチェーンの検証で一度に読み込む件数
+const documentVerifyBatchSize = 500
+
+// DocumentStoreServiceInterface 電子帳簿保存法に対応した文書保存サービスのインターフェース
+type DocumentStoreServiceInterface interface {
+	// 登録・差し替え
+	Store(ctx context.Context, userID string, req *dto.StoreDocumentRequest, fileName, contentType string, content []byte) (*dto.StoredDocumentDTO, error)
+	Replace(ctx context.Context, documentID, userID string, req *dto.ReplaceDocumentRequest, fileName, contentType string, content []byte) (*dto.StoredDocumentDTO, error)
+	ArchiveInvoice(ctx context.Context, invoiceID, userID string) (*dto.StoredDocumentDTO, error)
+	ArchiveExpenseReceipt(ctx context.Context, receiptID, userID string, req *dto.ArchiveReceiptRequest) (*dto.StoredDocumentDTO, error)
+
+	// 検索・取得
+	Search(ctx context.Context, req *dto.DocumentSearchRequest) (*dto.DocumentSearchResponse, error)
+	GetDocument(ctx context.Context, documentID string) (*dto.StoredDocumentDetailDTO, error)
+	GetContent(ctx context.Context, documentID string) (*dto.StoredDocumentContent, error)
+
+	// 廃棄・検証
+	Dispose(ctx context.Context, documentID, userID string) (*dto.StoredDocumentDTO, error)
+	VerifyChain(ctx context.Context, verifyContent bool) (*dto.DocumentChainVerificationDTO, error)
+}
+
+// documentStoreService 文書保存サービスの実装
+type documentStoreService struct {
+	db                   *gorm.DB
+	backend              docstore.Backend
+	pdfService           InvoicePDFService
+	s3Service            S3Service
+	retentionYears       int
+	fiscalYearStartMonth int
+	logger               *zap.Logger
+}
+
+// NewDocumentStoreService 文書保存サービスのインスタンスを生成
+// 保存期間は取引が属する事業年度（fiscalYearStartMonthから始まる）の申告期限から数える
+func NewDocumentStoreService(
+	db *gorm.DB,
+	backend docstore.Backend,
+	pdfService InvoicePDFService,
+	s3Service S3Service,
+	cfg *config.DocumentStoreConfig,
+	fiscalYearStartMonth int,
+	logger *zap.Logger,
+) DocumentStoreServiceInterface {
+	return &documentStoreService{
+		db:                   db,
+		backend:              backend,
+		pdfService:           pdfService,
+		s3Service:            s3Service,
+		retentionYears:       cfg.RetentionYears,
+		fiscalYearStartMonth: fiscalYearStartMonth,
+		logger:               logger,
+	}
+}
+
+// NewDocumentBackend 設定に応じた文書の保存先を生成（local / s3）
+func NewDocumentBackend(cfg *config.DocumentStoreConfig, region string) (docstore.Backend, error) {
+	switch cfg.Backend {
+	case "s3":
+		if cfg.S3Bucket == "" {
+			return nil, fmt.Errorf("DOCUMENT_STORE_S3_BUCKET が設定されていません")
+		}
+		client, err := newS3Client(region)
+		if err != nil {
+			return nil, err
+		}
+		return docstore.NewS3Backend(client, cfg.S3Bucket, cfg.S3Prefix), nil
+	case "local", "":
+		return docstore.NewLocalBackend(cfg.LocalDir)
+	default:
+		return nil, fmt.Errorf("対応していない文書の保存先です: %s", cfg.Backend)
+	}
+}
+
+// Store 文書を登録
+func (s *documentStoreService) Store(ctx context.Context, userID string, req *dto.StoreDocumentRequest, fileName, contentType string, content []byte) (*dto.StoredDocumentDTO, error) {
+	transactionDate, err := parseAccountingDate(req.TransactionDate, "取引年月日")
+	if err != nil {
+		return nil, err
+	}
+
+	document := &model.StoredDocument{
+		DocumentType:    model.StoredDocumentType(req.DocumentType),
+		TransactionDate: transactionDate,
+		Amount:          req.Amount,
+		Counterparty:    strings.TrimSpace(req.Counterparty),
+		FileName:        fileName,
+		ContentType:     contentType,
+		RegisteredBy:    userID,
+	}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.register(ctx, tx, document, content)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Document stored",
+		zap.String("document_id", document.ID),
+		zap.Int64("chain_seq", document.ChainSeq),
+		zap.String("user_id", userID))
+	result := storedDocumentToDTO(document)
+	return &result, nil
+}
+
+// Replace 文書を差し替える
+// 差し替え前の版は削除せず、新しい版から参照できるようにする
+func (s *documentStoreService) Replace(ctx context.Context, documentID, userID string, req *dto.ReplaceDocumentRequest, fileName, contentType string, content []byte) (*dto.StoredDocumentDTO, error) {
+	var replacement *model.StoredDocument
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := s.lockDocument(tx, documentID)
+		if err != nil {
+			return err
+		}
+		if !current.IsLatest() {
+			return accountingerrors.ErrDocumentSupersededError(current.ID, *current.SupersededByID)
+		}
+		if current.IsDisposed() {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "廃棄済みの文書は差し替えできません").
+				WithDetail("document_id", current.ID)
+		}
+
+		reason := req.Reason
+		replacement = &model.StoredDocument{
+			GroupID:         current.GroupID,
+			Version:         current.Version + 1,
+			DocumentType:    current.DocumentType,
+			SourceType:      current.SourceType,
+			SourceID:        current.SourceID,
+			TransactionDate: current.TransactionDate,
+			Amount:          current.Amount,
+			Counterparty:    current.Counterparty,
+			FileName:        fileName,
+			ContentType:     contentType,
+			ReplaceReason:   &reason,
+			RegisteredBy:    userID,
+		}
+		if req.TransactionDate != "" {
+			transactionDate, err := parseAccountingDate(req.TransactionDate, "取引年月日")
+			if err != nil {
+				return err
+			}
+			replacement.TransactionDate = transactionDate
+		}
+		if req.Amount != nil {
+			replacement.Amount = *req.Amount
+		}
+		if req.Counterparty != nil {
+			replacement.Counterparty = strings.TrimSpace(*req.Counterparty)
+		}
+
+		if err := s.register(ctx, tx, replacement, content); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&model.StoredDocument{}).
+			Where("id = ?", current.ID).
+			Updates(map[string]interface{}{
+				"superseded_by_id": replacement.ID,
+				"superseded_at":    now,
+			}).Error; err != nil {
+			return fmt.Errorf("差し替え前の文書の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Document replaced",
+		zap.String("document_id", documentID),
+		zap.String("replacement_id", replacement.ID),
+		zap.Int("version", replacement.Version),
+		zap.String("user_id", userID))
+	result := storedDocumentToDTO(replacement)
+	return &result, nil
+}
+
+// ArchiveInvoice 発行済みの請求書のPDFを控えとして保存する
+// 保存済みの場合は最新の版を返す
+func (s *documentStoreService) ArchiveInvoice(ctx context.Context, invoiceID, userID string) (*dto.StoredDocumentDTO, error) {
+	if existing, err := s.findLatestBySource(ctx, model.StoredDocumentSourceInvoice, invoiceID); err != nil || existing != nil {
+		return existing, err
+	}
+
+	var invoice model.Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("Details", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index")
+		}).
+		Preload("TaxSummaries").
+		Where("id = ?", invoiceID).
+		First(&invoice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "請求書が見つかりません").
+				WithDetail("invoice_id", invoiceID)
+		}
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	if !invoice.IsIssued() && !invoice.IsCreditNote() {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "発行済みの請求書のみ保存できます").
+			WithDetail("invoice_id", invoiceID).
+			WithDetail("status", string(invoice.Status))
+	}
+
+	content, err := s.pdfService.GeneratePDF(&invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceType := model.StoredDocumentSourceInvoice
+	document := &model.StoredDocument{
+		DocumentType:    model.StoredDocumentTypeInvoice,
+		SourceType:      &sourceType,
+		SourceID:        &invoice.ID,
+		TransactionDate: invoice.InvoiceDate,
+		Amount:          yen(invoice.TotalAmount),
+		Counterparty:    invoice.Client.CompanyName,
+		FileName:        fmt.Sprintf("invoice_%s.pdf", invoice.InvoiceNumber),
+		ContentType:     "application/pdf",
+		RegisteredBy:    userID,
+	}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.register(ctx, tx, document, content)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invoice archived",
+		zap.String("invoice_id", invoiceID),
+		zap.String("document_id", document.ID),
+		zap.String("user_id", userID))
+	result := storedDocumentToDTO(document)
+	return &result, nil
+}
+
+// ArchiveExpenseReceipt 経費の領収書を保存する
+// 保存済みの場合は最新の版を返す
+func (s *documentStoreService) ArchiveExpenseReceipt(ctx context.Context, receiptID, userID string, req *dto.ArchiveReceiptRequest) (*dto.StoredDocumentDTO, error) {
+	if existing, err := s.findLatestBySource(ctx, model.StoredDocumentSourceExpenseReceipt, receiptID); err != nil || existing != nil {
+		return existing, err
+	}
+
+	var receipt model.ExpenseReceipt
+	if err := s.db.WithContext(ctx).Preload("Expense").Where("id = ?", receiptID).First(&receipt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "領収書が見つかりません").
+				WithDetail("receipt_id", receiptID)
+		}
+		return nil, fmt.Errorf("領収書の取得に失敗しました: %w", err)
+	}
+
+	content, err := s.s3Service.DownloadFile(ctx, receipt.S3Key)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceType := model.StoredDocumentSourceExpenseReceipt
+	document := &model.StoredDocument{
+		DocumentType:    model.StoredDocumentTypeReceipt,
+		SourceType:      &sourceType,
+		SourceID:        &receipt.ID,
+		TransactionDate: receipt.Expense.ExpenseDate,
+		Amount:          int64(receipt.Expense.Amount),
+		Counterparty:    strings.TrimSpace(req.Counterparty),
+		FileName:        receipt.FileName,
+		ContentType:     receipt.ContentType,
+		RegisteredBy:    userID,
+	}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.register(ctx, tx, document, content)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Expense receipt archived",
+		zap.String("receipt_id", receiptID),
+		zap.String("document_id", document.ID),
+		zap.String("user_id", userID))
+	result := storedDocumentToDTO(document)
+	return &result, nil
+}
+
+// register 文書本体を保存し、ハッシュチェーンの末尾に繋げて登録する
+// チェーンの末尾の行をロックするため、同時に登録しても連番とハッシュの順序は崩れない
+func (s *documentStoreService) register(ctx context.Context, tx *gorm.DB, document *model.StoredDocument, content []byte) error {
+	if len(content) == 0 {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "文書ファイルが空です")
+	}
+	if document.Counterparty == "" {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "取引先を指定してください")
+	}
+
+	head, err := s.lockChainHead(tx)
+	if err != nil {
+		return err
+	}
+
+	if document.ID == "" {
+		document.ID = uuid.New().String()
+	}
+	if document.GroupID == "" {
+		document.GroupID = document.ID
+	}
+	if document.Version == 0 {
+		document.Version = 1
+	}
+	document.ContentHash = docstore.ContentHash(content)
+	document.FileSize = int64(len(content))
+	document.StorageKey = fmt.Sprintf("%04d/%s/v%d-%s%s",
+		document.TransactionDate.Year(), document.GroupID, document.Version,
+		document.ContentHash[:12], strings.ToLower(path.Ext(document.FileName)))
+	document.RetentionUntil = model.DocumentRetentionUntil(document.TransactionDate, s.fiscalYearStartMonth, s.retentionYears)
+	document.ChainSeq = head.LastSeq + 1
+	document.PrevChainHash = head.LastHash
+	document.ChainHash = docstore.ChainHash(head.LastHash, document.ChainEntry())
+
+	if err := s.backend.Put(ctx, document.StorageKey, content, document.ContentType); err != nil {
+		return fmt.Errorf("文書ファイルの保存に失敗しました: %w", err)
+	}
+	if err := tx.Create(document).Error; err != nil {
+		return fmt.Errorf("文書の登録に失敗しました: %w", err)
+	}
+	if err := tx.Model(&model.DocumentChainHead{}).
+		Where("id = ?", documentChainHeadID).
+		Updates(map[string]interface{}{
+			"last_seq":  document.ChainSeq,
+			"last_hash": document.ChainHash,
+		}).Error; err != nil {
+		return fmt.Errorf("ハッシュチェーンの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// lockChainHead ハッシュチェーンの末尾を行ロックして取得（なければ作成）
+func (s *documentStoreService) lockChainHead(tx *gorm.DB) (*model.DocumentChainHead, error) {
+	genesis := &model.DocumentChainHead{ID: documentChainHeadID, LastHash: docstore.GenesisHash}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(genesis).Error; err != nil {
+		return nil, fmt.Errorf("ハッシュチェーンの初期化に失敗しました: %w", err)
+	}
+
+	var head model.DocumentChainHead
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", documentChainHeadID).
+		First(&head).Error; err != nil {
+		return nil, fmt.Errorf("ハッシュチェーンの取得に失敗しました: %w", err)
+	}
+	return &head, nil
+}
+
+// lockDocument 文書を行ロックして取得
+func (s *documentStoreService) lockDocument(tx *gorm.DB, documentID string) (*model.StoredDocument, error) {
+	var document model.StoredDocument
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", documentID).
+		First(&document).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "文書が見つかりません").
+				WithDetail("document_id", documentID)
+		}
+		return nil, fmt.Errorf("文書の取得に失敗しました: %w", err)
+	}
+	return &document, nil
+}
+
+// findLatestBySource 元データから保存済みの最新の版を取得
+func (s *documentStoreService) findLatestBySource(ctx context.Context, sourceType, sourceID string) (*dto.StoredDocumentDTO, error) {
+	var document model.StoredDocument
+	err := s.db.WithContext(ctx).
+		Where("source_type = ? AND source_id = ? AND superseded_by_id IS NULL", sourceType, sourceID).
+		First(&document).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("保存済みの文書の取得に失敗しました: %w", err)
+	}
+	result := storedDocumentToDTO(&document)
+	return &result, nil
+}
+
+// Search 取引年月日・金額の範囲と取引先で文書を検索
+func (s *documentStoreService) Search(ctx context.Context, req *dto.DocumentSearchRequest) (*dto.DocumentSearchResponse, error) {
+	page, limit := normalizePage(req.Page, req.Limit)
+	query := s.db.WithContext(ctx).Model(&model.StoredDocument{})
+
+	if req.DateFrom != "" {
+		dateFrom, err := parseAccountingDate(req.DateFrom, "取引年月日（開始）")
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("transaction_date >= ?", dateFrom)
+	}
+	if req.DateTo != "" {
+		dateTo, err := parseAccountingDate(req.DateTo, "取引年月日（終了）")
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("transaction_date <= ?", dateTo)
+	}
+	if req.AmountMin != nil {
+		query = query.Where("amount >= ?", *req.AmountMin)
+	}
+	if req.AmountMax != nil {
+		query = query.Where("amount <= ?", *req.AmountMax)
+	}
+	if counterparty := strings.TrimSpace(req.Counterparty); counterparty != "" {
+		query = query.Where("counterparty LIKE ?", "%"+counterparty+"%")
+	}
+	if req.DocumentType != "" {
+		query = query.Where("document_type = ?", req.DocumentType)
+	}
+	if !req.IncludeSuperseded {
+		query = query.Where("superseded_by_id IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("文書の件数の取得に失敗しました: %w", err)
+	}
+	var documents []model.StoredDocument
+	if err := query.
+		Order("transaction_date DESC, chain_seq DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&documents).Error; err != nil {
+		return nil, fmt.Errorf("文書の検索に失敗しました: %w", err)
+	}
+
+	result := &dto.DocumentSearchResponse{
+		Documents: make([]dto.StoredDocumentDTO, len(documents)),
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}
+	for i := range documents {
+		result.Documents[i] = storedDocumentToDTO(&documents[i])
+	}
+	return result, nil
+}
+
+// GetDocument 文書と全ての版を取得
+func (s *documentStoreService) GetDocument(ctx context.Context, documentID string) (*dto.StoredDocumentDetailDTO, error) {
+	db := s.db.WithContext(ctx)
+	document, err := s.lockDocument(db, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []model.StoredDocument
+	if err := db.Where("group_id = ?", document.GroupID).Order("version").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("文書の版の取得に失敗しました: %w", err)
+	}
+
+	result := &dto.StoredDocumentDetailDTO{
+		StoredDocumentDTO: storedDocumentToDTO(document),
+		Versions:          make([]dto.StoredDocumentDTO, len(versions)),
+	}
+	for i := range versions {
+		result.Versions[i] = storedDocumentToDTO(&versions[i])
+	}
+	return result, nil
+}
+
+// GetContent 文書本体を取得
+// 登録時のハッシュと一致しない場合は改ざんとしてエラーにする
+func (s *documentStoreService) GetContent(ctx context.Context, documentID string) (*dto.StoredDocumentContent, error) {
+	var document model.StoredDocument
+	if err := s.db.WithContext(ctx).Where("id = ?", documentID).First(&document).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "文書が見つかりません").
+				WithDetail("document_id", documentID)
+		}
+		return nil, fmt.Errorf("文書の取得に失敗しました: %w", err)
+	}
+	if document.IsDisposed() {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "文書は保存期間の経過により廃棄されています").
+			WithDetail("document_id", documentID)
+	}
+
+	content, err := s.backend.Get(ctx, document.StorageKey)
+	if err != nil {
+		if errors.Is(err, docstore.ErrNotFound) {
+			s.logger.Error("Stored document file is missing",
+				zap.String("document_id", documentID),
+				zap.String("storage_key", document.StorageKey))
+			return nil, accountingerrors.ErrDocumentTamperedError(documentID)
+		}
+		return nil, fmt.Errorf("文書ファイルの取得に失敗しました: %w", err)
+	}
+	if docstore.ContentHash(content) != document.ContentHash {
+		s.logger.Error("Stored document hash mismatch",
+			zap.String("document_id", documentID),
+			zap.String("storage_key", document.StorageKey))
+		return nil, accountingerrors.ErrDocumentTamperedError(documentID)
+	}
+
+	return &dto.StoredDocumentContent{
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Content:     content,
+	}, nil
+}
+
+// Dispose 保存期間が経過した文書の本体を廃棄する
+// 検索項目とハッシュは残し、ハッシュチェーンの検証は引き続き行えるようにする
+func (s *documentStoreService) Dispose(ctx context.Context, documentID, userID string) (*dto.StoredDocumentDTO, error) {
+	var document *model.StoredDocument
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := s.lockDocument(tx, documentID)
+		if err != nil {
+			return err
+		}
+		document = current
+		if current.IsDisposed() {
+			return nil
+		}
+		now := time.Now()
+		if !current.CanDispose(now) {
+			return accountingerrors.ErrDocumentRetentionActiveError(current.ID, current.RetentionUntil.Format("2006-01-02"))
+		}
+
+		current.DisposedAt = &now
+		current.DisposedBy = &userID
+		if err := tx.Model(&model.StoredDocument{}).
+			Where("id = ?", current.ID).
+			Updates(map[string]interface{}{
+				"disposed_at": now,
+				"disposed_by": userID,
+			}).Error; err != nil {
+			return fmt.Errorf("文書の廃棄の記録に失敗しました: %w", err)
+		}
+		return s.backend.Delete(ctx, current.StorageKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Document disposed",
+		zap.String("document_id", documentID),
+		zap.String("user_id", userID))
+	result := storedDocumentToDTO(document)
+	return &result, nil
+}
+
+// VerifyChain ハッシュチェーンを先頭から再計算し、登録内容の改ざんや欠落がないか検証する
+// verifyContentを指定すると文書本体のハッシュも照合する（廃棄済みの文書は除く）
+func (s *documentStoreService) VerifyChain(ctx context.Context, verifyContent bool) (*dto.DocumentChainVerificationDTO, error) {
+	db := s.db.WithContext(ctx)
+	result := &dto.DocumentChainVerificationDTO{
+		ContentChecked: verifyContent,
+		Violations:     []dto.DocumentChainViolation{},
+	}
+	violation := func(document *model.StoredDocument, reason string) {
+		result.Violations = append(result.Violations, dto.DocumentChainViolation{
+			ChainSeq:   document.ChainSeq,
+			DocumentID: document.ID,
+			Reason:     reason,
+		})
+	}
+
+	prevHash := docstore.GenesisHash
+	var lastSeq int64
+	for {
+		var documents []model.StoredDocument
+		if err := db.Where("chain_seq > ?", lastSeq).
+			Order("chain_seq").
+			Limit(documentVerifyBatchSize).
+			Find(&documents).Error; err != nil {
+			return nil, fmt.Errorf("文書の取得に失敗しました: %w", err)
+		}
+		for i := range documents {
+			document := &documents[i]
+			if document.ChainSeq != lastSeq+1 {
+				violation(document, fmt.Sprintf("連番 %d〜%d の文書がありません", lastSeq+1, document.ChainSeq-1))
+			}
+			if document.PrevChainHash != prevHash {
+				violation(document, "直前の文書のハッシュと一致しません")
+			}
+			if docstore.ChainHash(document.PrevChainHash, document.ChainEntry()) != document.ChainHash {
+				violation(document, "登録内容がチェーンハッシュと一致しません")
+			}
+			if verifyContent && !document.IsDisposed() {
+				content, err := s.backend.Get(ctx, document.StorageKey)
+				switch {
+				case errors.Is(err, docstore.ErrNotFound):
+					violation(document, "文書ファイルがありません")
+				case err != nil:
+					return nil, fmt.Errorf("文書ファイルの取得に失敗しました: %w", err)
+				case docstore.ContentHash(content) != document.ContentHash:
+					violation(document, "文書ファイルのハッシュが登録時と一致しません")
+				}
+			}
+			prevHash = document.ChainHash
+			lastSeq = document.ChainSeq
+			result.CheckedCount++
+		}
+		if len(documents) < documentVerifyBatchSize {
+			break
+		}
+	}
+
+	var head model.DocumentChainHead
+	err := db.Where("id = ?", documentChainHeadID).First(&head).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		head = model.DocumentChainHead{LastHash: docstore.GenesisHash}
+	case err != nil:
+		return nil, fmt.Errorf("ハッシュチェーンの取得に失敗しました: %w", err)
+	}
+	if head.LastSeq != lastSeq || head.LastHash != prevHash {
+		result.Violations = append(result.Violations, dto.DocumentChainViolation{
+			ChainSeq: head.LastSeq,
+			Reason:   "ハッシュチェーンの末尾が最後の文書と一致しません",
+		})
+	}
+
+	result.LastSeq = lastSeq
+	result.Valid = len(result.Violations) == 0
+	result.VerifiedAt = time.Now()
+	if !result.Valid {
+		s.logger.Error("Document hash chain verification failed",
+			zap.Int("violations", len(result.Violations)))
+	}
+	return result, nil
+}
+
+// storedDocumentToDTO 保存文書をDTOに変換
+func storedDocumentToDTO(document *model.StoredDocument) dto.StoredDocumentDTO {
+	return dto.StoredDocumentDTO{
+		ID:              document.ID,
+		GroupID:         document.GroupID,
+		Version:         document.Version,
+		DocumentType:    string(document.DocumentType),
+		SourceType:      document.SourceType,
+		SourceID:        document.SourceID,
+		TransactionDate: document.TransactionDate.Format("2006-01-02"),
+		Amount:          document.Amount,
+		Counterparty:    document.Counterparty,
+		FileName:        document.FileName,
+		ContentType:     document.ContentType,
+		FileSize:        document.FileSize,
+		ContentHash:     document.ContentHash,
+		ChainSeq:        document.ChainSeq,
+		ChainHash:       document.ChainHash,
+		RetentionUntil:  document.RetentionUntil.Format("2006-01-02"),
+		IsLatest:        document.IsLatest(),
+		SupersededByID:  document.SupersededByID,
+		ReplaceReason:   document.ReplaceReason,
+		DisposedAt:      document.DisposedAt,
+		RegisteredBy:    document.RegisteredBy,
+		RegisteredAt:    document.CreatedAt,
+	}
+}
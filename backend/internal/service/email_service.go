@@ -10,6 +10,7 @@ import (
 
 	"github.com/duesk/monstera/internal/config"
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/invoicepdf"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +40,10 @@ type EmailService interface {
 	SendExpenseApprovedNotice(ctx context.Context, to string, expense *model.Expense, approverName string, isFullyApproved bool) error
 	SendExpenseRejectedNotice(ctx context.Context, to string, expense *model.Expense, rejectorName string, reason string) error
 	SendExpenseLimitWarning(ctx context.Context, to string, userName string, limitType string, usageRate float64, currentAmount, limitAmount int) error
+
+	// 請求書督促関連メール
+	SendInvoiceDunningNotice(ctx context.Context, to []string, invoice *model.Invoice, step model.DunningStepType, outstanding int64) error
+	SendInvoiceDunningEscalation(ctx context.Context, to string, salesRepName string, invoice *model.Invoice, outstanding int64, daysOverdue int) error
 }
 
 // emailService メール送信サービスの実装
@@ -404,6 +409,151 @@ func (s *emailService) initializeTemplates() error {
 	}
 	s.templates["expense_limit_warning"] = tmpl
 
+	// 請求書督促（お支払いのお願い・再通知）テンプレート
+	invoiceDunningNoticeTmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Title}}</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: {{if .IsSecondNotice}}#e65100{{else}}#2c3e50{{end}}; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f4f4f4; }
+        .invoice-info { background-color: white; padding: 15px; border-radius: 5px; margin: 10px 0; }
+        .invoice-info table { width: 100%; border-collapse: collapse; }
+        .invoice-info td { padding: 8px; border-bottom: 1px solid #eee; }
+        .invoice-info td:first-child { font-weight: bold; width: 40%; }
+        .footer { text-align: center; padding: 20px; font-size: 12px; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h2>{{.Title}}</h2>
+        </div>
+        <div class="content">
+            <p>{{.ClientName}}<br>{{if .ContactPerson}}{{.ContactPerson}} 様{{else}}ご担当者様{{end}}</p>
+            <p>平素より格別のお引き立てを賜り、厚く御礼申し上げます。</p>
+            {{if .IsSecondNotice}}
+            <p>先日ご案内いたしました下記請求書につきまして、本日時点でお支払いの確認が取れておりません。</p>
+            <p>お手数ではございますが、至急お支払い状況をご確認のうえ、お手続きくださいますようお願い申し上げます。</p>
+            {{else}}
+            <p>下記請求書につきまして、お支払期日を過ぎておりますが、本日時点でご入金の確認が取れておりません。</p>
+            <p>ご多忙のところ恐縮ですが、お支払い状況をご確認いただけますと幸いです。</p>
+            {{end}}
+            <div class="invoice-info">
+                <table>
+                    <tr>
+                        <td>請求書番号</td>
+                        <td>{{.InvoiceNumber}}</td>
+                    </tr>
+                    <tr>
+                        <td>請求金額</td>
+                        <td>{{.TotalAmount}}</td>
+                    </tr>
+                    <tr>
+                        <td>未入金額</td>
+                        <td>{{.Outstanding}}</td>
+                    </tr>
+                    <tr>
+                        <td>お支払期日</td>
+                        <td>{{.DueDate}}</td>
+                    </tr>
+                </table>
+            </div>
+            <p>なお、本メールと行き違いでお支払いいただいている場合は、ご容赦くださいますようお願い申し上げます。</p>
+        </div>
+        <div class="footer">
+            <p>このメールは自動送信されています。</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	// 請求書督促エスカレーション通知テンプレート（営業担当向け）
+	invoiceDunningEscalationTmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>未入金請求書のエスカレーション</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #f44336; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f4f4f4; }
+        .invoice-info { background-color: white; padding: 15px; border-radius: 5px; margin: 10px 0; }
+        .invoice-info table { width: 100%; border-collapse: collapse; }
+        .invoice-info td { padding: 8px; border-bottom: 1px solid #eee; }
+        .invoice-info td:first-child { font-weight: bold; width: 40%; }
+        .button { display: inline-block; padding: 10px 20px; background-color: #3498db; color: white; text-decoration: none; border-radius: 5px; }
+        .footer { text-align: center; padding: 20px; font-size: 12px; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h2>未入金請求書のエスカレーション</h2>
+        </div>
+        <div class="content">
+            <p>{{.SalesRepName}} さん</p>
+            <p>担当取引先の請求書が支払期日から<strong>{{.DaysOverdue}}日</strong>経過しても未入金です。</p>
+            <p>取引先へのお支払いのお願い・再通知は送付済みです。取引先へ直接ご連絡のうえ、入金予定を確認してください。</p>
+            <div class="invoice-info">
+                <table>
+                    <tr>
+                        <td>取引先</td>
+                        <td>{{.ClientName}}</td>
+                    </tr>
+                    <tr>
+                        <td>取引先担当者</td>
+                        <td>{{.ContactPerson}} {{.ContactEmail}}</td>
+                    </tr>
+                    <tr>
+                        <td>請求書番号</td>
+                        <td>{{.InvoiceNumber}}</td>
+                    </tr>
+                    <tr>
+                        <td>請求金額</td>
+                        <td>{{.TotalAmount}}</td>
+                    </tr>
+                    <tr>
+                        <td>未入金額</td>
+                        <td>{{.Outstanding}}</td>
+                    </tr>
+                    <tr>
+                        <td>お支払期日</td>
+                        <td>{{.DueDate}}</td>
+                    </tr>
+                </table>
+            </div>
+            <p style="text-align: center; margin-top: 30px;">
+                <a href="{{.SystemURL}}/accounting/invoices?invoice_id={{.InvoiceID}}" class="button">請求書を確認する</a>
+            </p>
+        </div>
+        <div class="footer">
+            <p>このメールは自動送信されています。</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err = template.New("invoice_dunning_notice").Parse(invoiceDunningNoticeTmpl)
+	if err != nil {
+		return fmt.Errorf("請求書督促テンプレートの解析に失敗: %w", err)
+	}
+	s.templates["invoice_dunning_notice"] = tmpl
+
+	tmpl, err = template.New("invoice_dunning_escalation").Parse(invoiceDunningEscalationTmpl)
+	if err != nil {
+		return fmt.Errorf("請求書督促エスカレーションテンプレートの解析に失敗: %w", err)
+	}
+	s.templates["invoice_dunning_escalation"] = tmpl
+
 	return nil
 }
 
@@ -651,6 +801,51 @@ func (s *emailService) SendExpenseLimitWarning(ctx context.Context, to string, u
 	return s.SendTemplatedEmail(ctx, []string{to}, subject, "expense_limit_warning", data)
 }
 
+// SendInvoiceDunningNotice 請求書の督促（お支払いのお願い・再通知）を送信（取引先向け）
+// outstandingには一部入金済みの場合の未入金額を指定する
+func (s *emailService) SendInvoiceDunningNotice(ctx context.Context, to []string, invoice *model.Invoice, step model.DunningStepType, outstanding int64) error {
+	title := "お支払いのお願い"
+	if step == model.DunningStepSecondNotice {
+		title = "お支払いについての再度のお願い"
+	}
+	subject := fmt.Sprintf("【%s】請求書 %s", title, invoice.InvoiceNumber)
+
+	data := map[string]interface{}{
+		"Title":          title,
+		"IsSecondNotice": step == model.DunningStepSecondNotice,
+		"ClientName":     invoice.Client.CompanyName,
+		"ContactPerson":  invoice.Client.ContactPerson,
+		"InvoiceNumber":  invoice.InvoiceNumber,
+		"TotalAmount":    invoicepdf.FormatYen(invoice.TotalAmount.Float64()),
+		"Outstanding":    invoicepdf.FormatYen(float64(outstanding)),
+		"DueDate":        invoice.DueDate.Format("2006年01月02日"),
+		"SystemURL":      s.config.SystemURL,
+	}
+
+	return s.SendTemplatedEmail(ctx, to, subject, "invoice_dunning_notice", data)
+}
+
+// SendInvoiceDunningEscalation 未入金請求書のエスカレーションを送信（営業担当向け）
+func (s *emailService) SendInvoiceDunningEscalation(ctx context.Context, to string, salesRepName string, invoice *model.Invoice, outstanding int64, daysOverdue int) error {
+	subject := fmt.Sprintf("【要対応】未入金請求書 %s（%s）", invoice.InvoiceNumber, invoice.Client.CompanyName)
+
+	data := map[string]interface{}{
+		"SalesRepName":  salesRepName,
+		"DaysOverdue":   daysOverdue,
+		"ClientName":    invoice.Client.CompanyName,
+		"ContactPerson": invoice.Client.ContactPerson,
+		"ContactEmail":  invoice.Client.ContactEmail,
+		"InvoiceID":     invoice.ID,
+		"InvoiceNumber": invoice.InvoiceNumber,
+		"TotalAmount":   invoicepdf.FormatYen(invoice.TotalAmount.Float64()),
+		"Outstanding":   invoicepdf.FormatYen(float64(outstanding)),
+		"DueDate":       invoice.DueDate.Format("2006年01月02日"),
+		"SystemURL":     s.config.SystemURL,
+	}
+
+	return s.SendTemplatedEmail(ctx, []string{to}, subject, "invoice_dunning_escalation", data)
+}
+
 // getCategoryDisplayName カテゴリの表示名を取得
 func getCategoryDisplayName(category model.ExpenseCategory) string {
 	switch category {
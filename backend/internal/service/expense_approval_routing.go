@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+// maxDepartmentDepth 部署長を探すときに遡る部署階層の上限
+const maxDepartmentDepth = 10
+
+// selectApprovalRule 経費申請・仮払金に適用する承認ルールを判定（一致するルールがなければnil）
+func selectApprovalRule(ctx context.Context, tx *gorm.DB, logger *zap.Logger, target model.ExpenseRoutingTarget) (*model.ExpenseApprovalRule, error) {
+	rules, err := repository.NewExpenseApprovalRuleRepository(tx, logger).GetActiveRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("承認ルールの取得に失敗しました: %w", err)
+	}
+
+	rule := model.SelectExpenseApprovalRule(rules, target)
+	if rule != nil {
+		logger.Info("Expense approval rule selected",
+			zap.Int("amount", target.Amount),
+			zap.String("rule_id", rule.ID),
+			zap.String("rule_name", rule.Name),
+			zap.Bool("auto_approve", rule.AutoApprove))
+	}
+	return rule, nil
+}
+
+// createRuleApprovalFlow 承認ルールの段階に従って承認フローを作成
+// targetには承認対象（経費申請または仮払金）のIDを設定して渡す。
+// 申請者本人と、前の段階で既に承認者になっている人は承認者から除く
+func createRuleApprovalFlow(ctx context.Context, tx *gorm.DB, logger *zap.Logger, target model.ExpenseApproval, requester *model.User, rule *model.ExpenseApprovalRule) error {
+	settingRepo := repository.NewExpenseApproverSettingRepository(tx, logger)
+	assigned := map[string]bool{requester.ID: true}
+
+	var approvals []model.ExpenseApproval
+	approvalOrder := 1
+	for i, step := range rule.Steps {
+		approverIDs, err := resolveStepApprovers(ctx, tx, settingRepo, requester, step)
+		if err != nil {
+			return err
+		}
+
+		added := 0
+		for _, approverID := range approverIDs {
+			if assigned[approverID] {
+				continue
+			}
+			assigned[approverID] = true
+			approval := target
+			approval.ApproverID = approverID
+			approval.ApprovalType = step.ApprovalType
+			approval.ApprovalOrder = approvalOrder
+			approval.Status = model.ApprovalStatusPending
+			approvals = append(approvals, approval)
+			approvalOrder++
+			added++
+		}
+		if added == 0 && len(approverIDs) == 0 {
+			return dto.NewExpenseError(dto.ErrCodeNoApproversConfigured,
+				fmt.Sprintf("承認ルール「%s」の%d段階目の承認者が見つかりません。システム管理者に承認ルールの確認を依頼してください", rule.Name, i+1))
+		}
+	}
+	if len(approvals) == 0 {
+		return dto.NewExpenseError(dto.ErrCodeNoApproversConfigured,
+			fmt.Sprintf("承認ルール「%s」に申請者以外の承認者がいません。システム管理者に承認ルールの確認を依頼してください", rule.Name))
+	}
+
+	if err := repository.NewExpenseApprovalRepository(tx, logger).CreateBatch(ctx, approvals); err != nil {
+		return fmt.Errorf("承認フローの作成に失敗しました: %w", err)
+	}
+
+	logger.Info("Rule-based approval flow created",
+		zap.Stringp("expense_id", target.ExpenseID),
+		zap.Stringp("cash_advance_id", target.CashAdvanceID),
+		zap.String("rule_id", rule.ID),
+		zap.Int("approval_steps", len(approvals)))
+	return nil
+}
+
+// resolveStepApprovers 承認ルールの段階の承認者を取得
+func resolveStepApprovers(ctx context.Context, tx *gorm.DB, settingRepo repository.ExpenseApproverSettingRepository, requester *model.User, step model.ExpenseApprovalRuleStep) ([]string, error) {
+	switch step.ApprovalType {
+	case model.ApprovalTypeManager, model.ApprovalTypeExecutive:
+		settings, err := settingRepo.GetActiveByApprovalType(ctx, step.ApprovalType)
+		if err != nil {
+			return nil, fmt.Errorf("承認者設定の取得に失敗しました: %w", err)
+		}
+		approverIDs := make([]string, 0, len(settings))
+		for _, setting := range settings {
+			approverIDs = append(approverIDs, setting.ApproverID)
+		}
+		return approverIDs, nil
+	case model.ApprovalTypeDepartmentHead:
+		headID, err := findDepartmentHead(ctx, tx, requester)
+		if err != nil || headID == "" {
+			return nil, err
+		}
+		return []string{headID}, nil
+	case model.ApprovalTypeDesignated:
+		if step.ApproverID == nil || *step.ApproverID == "" {
+			return nil, nil
+		}
+		return []string{*step.ApproverID}, nil
+	default:
+		return nil, fmt.Errorf("未対応の承認種別です: %s", step.ApprovalType)
+	}
+}
+
+// findDepartmentHead 申請者の部署長を取得
+// 部署長が未設定または申請者本人の場合は上位の部署の部署長とする
+func findDepartmentHead(ctx context.Context, tx *gorm.DB, requester *model.User) (string, error) {
+	departmentID := requester.DepartmentID
+	for depth := 0; departmentID != nil && depth < maxDepartmentDepth; depth++ {
+		var department model.Department
+		if err := tx.WithContext(ctx).Where("id = ?", *departmentID).First(&department).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", nil
+			}
+			return "", fmt.Errorf("部署の取得に失敗しました: %w", err)
+		}
+		if department.ManagerID != nil && *department.ManagerID != "" && *department.ManagerID != requester.ID {
+			return *department.ManagerID, nil
+		}
+		departmentID = department.ParentID
+	}
+	return "", nil
+}
+
+// currentStepApproverIDs 承認待ちのうち現在の段階（承認順序が最も小さい）の承認者を取得
+func currentStepApproverIDs(pendingApprovals []model.ExpenseApproval) []string {
+	currentOrder := 0
+	for _, approval := range pendingApprovals {
+		if currentOrder == 0 || approval.ApprovalOrder < currentOrder {
+			currentOrder = approval.ApprovalOrder
+		}
+	}
+
+	approverIDs := make([]string, 0, 1)
+	for _, approval := range pendingApprovals {
+		if approval.ApprovalOrder == currentOrder && approval.ApproverID != "" {
+			approverIDs = append(approverIDs, approval.ApproverID)
+		}
+	}
+	return approverIDs
+}
+
+// normalizeOptionalID 任意指定のIDを正規化（空文字は未指定として扱う）
+func normalizeOptionalID(id *string) *string {
+	if id == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*id)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// findActionableApproval 承認者が承認・却下できる承認待ちの承認を取得（権限がなければnil）
+// 自分に割り当てられた承認がなければ、代理承認を任されている承認者の承認を対象とする。
+// 自分の経費申請・仮払金は代理でも承認できない
+func findActionableApproval(ctx context.Context, tx *gorm.DB, logger *zap.Logger, requesterID string, approvals []model.ExpenseApproval, approverID string) (*model.ExpenseApproval, error) {
+	for i := range approvals {
+		if approvals[i].ApproverID == approverID && approvals[i].Status == model.ApprovalStatusPending {
+			return &approvals[i], nil
+		}
+	}
+	if requesterID == approverID {
+		return nil, nil
+	}
+
+	delegatorIDs, err := delegatorIDsForDelegate(ctx, tx, logger, approverID, model.ApprovalDelegationScopeExpense)
+	if err != nil {
+		return nil, err
+	}
+	delegators := make(map[string]bool, len(delegatorIDs))
+	for _, delegatorID := range delegatorIDs {
+		delegators[delegatorID] = true
+	}
+
+	var target *model.ExpenseApproval
+	for i := range approvals {
+		if approvals[i].Status != model.ApprovalStatusPending || !delegators[approvals[i].ApproverID] {
+			continue
+		}
+		if target == nil || approvals[i].ApprovalOrder < target.ApprovalOrder {
+			target = &approvals[i]
+		}
+	}
+	if target != nil {
+		logger.Info("Expense approval acted on behalf of approver",
+			zap.String("approval_id", target.ID),
+			zap.String("approver_id", target.ApproverID),
+			zap.String("delegate_id", approverID))
+	}
+	return target, nil
+}
+
+// updateApprovalStatusBy 承認者の操作で承認ステータスを更新（代理承認の場合は本来の承認者を記録）
+func updateApprovalStatusBy(ctx context.Context, approvalRepo repository.ExpenseApprovalRepository, approval *model.ExpenseApproval, comment string, approverID string) error {
+	if approval.ApproverID == approverID {
+		return approvalRepo.UpdateApprovalStatus(ctx, approval.ID, approval.Status, comment, approverID)
+	}
+	onBehalfOfID := approval.ApproverID
+	if err := approvalRepo.UpdateApprovalStatusOnBehalf(ctx, approval.ID, approval.Status, comment, onBehalfOfID, approverID); err != nil {
+		return err
+	}
+	approval.ApproverID = approverID
+	approval.OnBehalfOfID = &onBehalfOfID
+	return nil
+}
+
+// withDelegateApproverIDs 承認者に、現在代理承認を任されている代理承認者を加える
+func withDelegateApproverIDs(ctx context.Context, db *gorm.DB, logger *zap.Logger, approverIDs []string) []string {
+	result := append([]string{}, approverIDs...)
+	seen := make(map[string]bool, len(approverIDs))
+	for _, approverID := range approverIDs {
+		seen[approverID] = true
+	}
+
+	delegationRepo := repository.NewApprovalDelegationRepository(db, logger)
+	for _, approverID := range approverIDs {
+		delegations, err := delegationRepo.GetActiveByDelegator(ctx, approverID, model.ApprovalDelegationScopeExpense, time.Now())
+		if err != nil {
+			logger.Warn("Failed to get approval delegations for notification",
+				zap.Error(err),
+				zap.String("approver_id", approverID))
+			continue
+		}
+		for _, delegation := range delegations {
+			if !seen[delegation.DelegateID] {
+				seen[delegation.DelegateID] = true
+				result = append(result, delegation.DelegateID)
+			}
+		}
+	}
+	return result
+}
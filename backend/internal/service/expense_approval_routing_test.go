@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/testutils"
+)
+
+// setupApprovalRoutingTest 承認ルール・承認者設定・部署のテーブルを持つSQLiteを作成
+// 申請者は自分が部署長の開発課に所属し、上位の開発部の部署長はhead-1。
+// 上長承認者にはmanager-1とhead-1、最終承認者にはexec-1を設定している
+func setupApprovalRoutingTest(t *testing.T) (*gorm.DB, *model.User) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.ExpenseApprovalRule{}, &model.ExpenseApprovalRuleStep{}, &model.ExpenseApproverSetting{},
+		&model.ExpenseApproval{}, &model.Department{}, &model.User{}))
+
+	parentID, requesterID, headID := "dept-parent", "user-1", "head-1"
+	require.NoError(t, db.Create([]*model.Department{
+		{ID: parentID, Name: "開発部", ManagerID: &headID, IsActive: true},
+		{ID: "dept-child", Name: "開発課", ParentID: &parentID, ManagerID: &requesterID, IsActive: true},
+	}).Error)
+	departmentID := "dept-child"
+	requester := &model.User{ID: requesterID, LastName: "山田", FirstName: "太郎", Email: "yamada@example.com", EmployeeNumber: "000001", DepartmentID: &departmentID}
+	require.NoError(t, db.Omit(clause.Associations).Create([]*model.User{
+		requester,
+		{ID: headID, LastName: "佐藤", FirstName: "部長", Email: "head@example.com", EmployeeNumber: "000002"},
+		{ID: "manager-1", LastName: "鈴木", FirstName: "課長", Email: "manager@example.com", EmployeeNumber: "000003"},
+		{ID: "exec-1", LastName: "高橋", FirstName: "役員", Email: "exec@example.com", EmployeeNumber: "000004"},
+	}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create([]*model.ExpenseApproverSetting{
+		{ID: "setting-1", ApprovalType: model.ApprovalTypeManager, ApproverID: "manager-1", IsActive: true, Priority: 1, CreatedBy: "admin-1"},
+		{ID: "setting-2", ApprovalType: model.ApprovalTypeManager, ApproverID: headID, IsActive: true, Priority: 2, CreatedBy: "admin-1"},
+		{ID: "setting-3", ApprovalType: model.ApprovalTypeExecutive, ApproverID: "exec-1", IsActive: true, Priority: 1, CreatedBy: "admin-1"},
+	}).Error)
+	return db, requester
+}
+
+// createApprovalRule 承認ルールを段階とともに登録
+func createApprovalRule(t *testing.T, db *gorm.DB, rule *model.ExpenseApprovalRule) *model.ExpenseApprovalRule {
+	rule.CreatedBy = "admin-1"
+	isActive := rule.IsActive
+	require.NoError(t, db.Create(rule).Error)
+	if !isActive {
+		// 既定値のtrueで作成されるため、無効のルールは作成後に更新する
+		require.NoError(t, db.Model(rule).Update("is_active", false).Error)
+	}
+	return rule
+}
+
+func TestSelectApprovalRule(t *testing.T) {
+	ctx := context.Background()
+	db, _ := setupApprovalRoutingTest(t)
+	highAmount, travel := 100000, "category-travel"
+	createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "少額は自動承認", Priority: 100, IsActive: true, AutoApprove: true})
+	createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "高額", Priority: 10, IsActive: true, MinAmount: &highAmount,
+		Steps: []model.ExpenseApprovalRuleStep{{StepOrder: 1, ApprovalType: model.ApprovalTypeExecutive}}})
+	createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "出張費", Priority: 20, IsActive: true, CategoryID: &travel,
+		Steps: []model.ExpenseApprovalRuleStep{{StepOrder: 1, ApprovalType: model.ApprovalTypeManager}}})
+	createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "停止中", Priority: 1, IsActive: false, AutoApprove: true})
+
+	tests := []struct {
+		name     string
+		target   model.ExpenseRoutingTarget
+		wantRule string
+	}{
+		{name: "高額の出張費は優先度の高い高額のルール", target: model.ExpenseRoutingTarget{Amount: 150000, CategoryID: travel}, wantRule: "高額"},
+		{name: "少額の出張費は出張費のルール", target: model.ExpenseRoutingTarget{Amount: 30000, CategoryID: travel}, wantRule: "出張費"},
+		{name: "その他は条件のないルール", target: model.ExpenseRoutingTarget{Amount: 3000, CategoryID: "category-other"}, wantRule: "少額は自動承認"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := selectApprovalRule(ctx, db, zap.NewNop(), tt.target)
+
+			require.NoError(t, err)
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.wantRule, rule.Name)
+		})
+	}
+}
+
+func TestCreateRuleApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+	expenseID := "expense-1"
+
+	t.Run("段階の順に承認者を割り当て、前の段階の承認者は重複させない", func(t *testing.T) {
+		db, requester := setupApprovalRoutingTest(t)
+		rule := createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "高額", IsActive: true, Steps: []model.ExpenseApprovalRuleStep{
+			{StepOrder: 1, ApprovalType: model.ApprovalTypeDepartmentHead},
+			{StepOrder: 2, ApprovalType: model.ApprovalTypeManager},
+			{StepOrder: 3, ApprovalType: model.ApprovalTypeExecutive},
+		}})
+
+		require.NoError(t, createRuleApprovalFlow(ctx, db, zap.NewNop(), model.ExpenseApproval{ExpenseID: &expenseID}, requester, rule))
+
+		var approvals []model.ExpenseApproval
+		require.NoError(t, db.Where("expense_id = ?", expenseID).Order("approval_order").Find(&approvals).Error)
+		require.Len(t, approvals, 3)
+		// 申請者が部署長の部署は上位の部署の部署長が承認し、上長承認ではhead-1を重ねない
+		assert.Equal(t, "head-1", approvals[0].ApproverID)
+		assert.Equal(t, model.ApprovalTypeDepartmentHead, approvals[0].ApprovalType)
+		assert.Equal(t, "manager-1", approvals[1].ApproverID)
+		assert.Equal(t, "exec-1", approvals[2].ApproverID)
+		assert.Equal(t, 3, approvals[2].ApprovalOrder)
+		for _, approval := range approvals {
+			assert.Equal(t, model.ApprovalStatusPending, approval.Status)
+		}
+		assert.Equal(t, []string{"head-1"}, currentStepApproverIDs(approvals))
+	})
+
+	t.Run("申請者本人しか承認者がいない場合は承認フローを作らない", func(t *testing.T) {
+		db, requester := setupApprovalRoutingTest(t)
+		rule := createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "本人指名", IsActive: true, Steps: []model.ExpenseApprovalRuleStep{
+			{StepOrder: 1, ApprovalType: model.ApprovalTypeDesignated, ApproverID: &requester.ID},
+		}})
+
+		err := createRuleApprovalFlow(ctx, db, zap.NewNop(), model.ExpenseApproval{ExpenseID: &expenseID}, requester, rule)
+
+		var expenseErr *dto.ExpenseError
+		require.True(t, errors.As(err, &expenseErr))
+		assert.Equal(t, dto.ErrCodeNoApproversConfigured, expenseErr.Code)
+		var count int64
+		require.NoError(t, db.Model(&model.ExpenseApproval{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("部署長が見つからない段階があれば承認フローを作らない", func(t *testing.T) {
+		db, requester := setupApprovalRoutingTest(t)
+		requester.DepartmentID = nil
+		rule := createApprovalRule(t, db, &model.ExpenseApprovalRule{Name: "部署長", IsActive: true, Steps: []model.ExpenseApprovalRuleStep{
+			{StepOrder: 1, ApprovalType: model.ApprovalTypeManager},
+			{StepOrder: 2, ApprovalType: model.ApprovalTypeDepartmentHead},
+		}})
+
+		err := createRuleApprovalFlow(ctx, db, zap.NewNop(), model.ExpenseApproval{ExpenseID: &expenseID}, requester, rule)
+
+		var expenseErr *dto.ExpenseError
+		require.True(t, errors.As(err, &expenseErr))
+		assert.Equal(t, dto.ErrCodeNoApproversConfigured, expenseErr.Code)
+		assert.Contains(t, expenseErr.Message, "2段階目")
+	})
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+// defaultExpenseApprovalRulePriority 優先度を指定しない場合の承認ルールの優先度
+const defaultExpenseApprovalRulePriority = 100
+
+// ExpenseApprovalRuleService 経費申請の承認ルールサービスのインターフェース
+type ExpenseApprovalRuleService interface {
+	ListRules(ctx context.Context, includeInactive bool) (*dto.ExpenseApprovalRulesResponse, error)
+	GetRule(ctx context.Context, ruleID string) (*dto.ExpenseApprovalRuleResponse, error)
+	CreateRule(ctx context.Context, userID string, req *dto.ExpenseApprovalRuleRequest) (*dto.ExpenseApprovalRuleResponse, error)
+	UpdateRule(ctx context.Context, ruleID string, req *dto.ExpenseApprovalRuleRequest) (*dto.ExpenseApprovalRuleResponse, error)
+	DeleteRule(ctx context.Context, ruleID string) error
+}
+
+// expenseApprovalRuleService 経費申請の承認ルールサービスの実装
+type expenseApprovalRuleService struct {
+	db       *gorm.DB
+	ruleRepo repository.ExpenseApprovalRuleRepository
+	logger   *zap.Logger
+}
+
+// NewExpenseApprovalRuleService 経費申請の承認ルールサービスのインスタンスを生成
+func NewExpenseApprovalRuleService(db *gorm.DB, ruleRepo repository.ExpenseApprovalRuleRepository, logger *zap.Logger) ExpenseApprovalRuleService {
+	return &expenseApprovalRuleService{
+		db:       db,
+		ruleRepo: ruleRepo,
+		logger:   logger,
+	}
+}
+
+// ListRules 承認ルールを優先度順に取得
+func (s *expenseApprovalRuleService) ListRules(ctx context.Context, includeInactive bool) (*dto.ExpenseApprovalRulesResponse, error) {
+	rules, err := s.ruleRepo.List(ctx, includeInactive)
+	if err != nil {
+		return nil, dto.NewExpenseError(dto.ErrCodeInternalError, "承認ルールの取得に失敗しました")
+	}
+
+	response := &dto.ExpenseApprovalRulesResponse{
+		Rules: make([]dto.ExpenseApprovalRuleResponse, len(rules)),
+	}
+	for i := range rules {
+		response.Rules[i].FromModel(&rules[i])
+	}
+	return response, nil
+}
+
+// GetRule 承認ルールを取得
+func (s *expenseApprovalRuleService) GetRule(ctx context.Context, ruleID string) (*dto.ExpenseApprovalRuleResponse, error) {
+	rule, err := s.getRule(ctx, s.ruleRepo, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response dto.ExpenseApprovalRuleResponse
+	response.FromModel(rule)
+	return &response, nil
+}
+
+// CreateRule 承認ルールを作成
+func (s *expenseApprovalRuleService) CreateRule(ctx context.Context, userID string, req *dto.ExpenseApprovalRuleRequest) (*dto.ExpenseApprovalRuleResponse, error) {
+	rule := &model.ExpenseApprovalRule{
+		IsActive:  true,
+		Priority:  defaultExpenseApprovalRulePriority,
+		CreatedBy: userID,
+	}
+	applyExpenseApprovalRuleRequest(rule, req)
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, dto.NewExpenseError(dto.ErrCodeInternalError, "承認ルールの作成に失敗しました")
+	}
+
+	s.logger.Info("Expense approval rule created",
+		zap.String("rule_id", rule.ID),
+		zap.String("name", rule.Name),
+		zap.String("user_id", userID))
+
+	var response dto.ExpenseApprovalRuleResponse
+	response.FromModel(rule)
+	return &response, nil
+}
+
+// UpdateRule 承認ルールを更新（提出済みの経費申請の承認フローは変わらない）
+func (s *expenseApprovalRuleService) UpdateRule(ctx context.Context, ruleID string, req *dto.ExpenseApprovalRuleRequest) (*dto.ExpenseApprovalRuleResponse, error) {
+	var rule *model.ExpenseApprovalRule
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRuleRepo := repository.NewExpenseApprovalRuleRepository(tx, s.logger)
+
+		var err error
+		rule, err = s.getRule(ctx, txRuleRepo, ruleID)
+		if err != nil {
+			return err
+		}
+		applyExpenseApprovalRuleRequest(rule, req)
+		if err := s.validateRule(ctx, rule); err != nil {
+			return err
+		}
+
+		if err := txRuleRepo.Update(ctx, rule); err != nil {
+			return dto.NewExpenseError(dto.ErrCodeInternalError, "承認ルールの更新に失敗しました")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Expense approval rule updated",
+		zap.String("rule_id", rule.ID),
+		zap.String("name", rule.Name))
+
+	var response dto.ExpenseApprovalRuleResponse
+	response.FromModel(rule)
+	return &response, nil
+}
+
+// DeleteRule 承認ルールを削除
+func (s *expenseApprovalRuleService) DeleteRule(ctx context.Context, ruleID string) error {
+	if err := s.ruleRepo.Delete(ctx, ruleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.NewExpenseError(dto.ErrCodeApprovalRuleNotFound, "承認ルールが見つかりません")
+		}
+		return dto.NewExpenseError(dto.ErrCodeInternalError, "承認ルールの削除に失敗しました")
+	}
+
+	s.logger.Info("Expense approval rule deleted", zap.String("rule_id", ruleID))
+	return nil
+}
+
+// getRule 承認ルールを取得（見つからない場合はExpenseError）
+func (s *expenseApprovalRuleService) getRule(ctx context.Context, ruleRepo repository.ExpenseApprovalRuleRepository, ruleID string) (*model.ExpenseApprovalRule, error) {
+	rule, err := ruleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dto.NewExpenseError(dto.ErrCodeApprovalRuleNotFound, "承認ルールが見つかりません")
+		}
+		return nil, dto.NewExpenseError(dto.ErrCodeInternalError, "承認ルールの取得に失敗しました")
+	}
+	return rule, nil
+}
+
+// validateRule 承認ルールの設定内容と指名した承認者の存在をチェック
+func (s *expenseApprovalRuleService) validateRule(ctx context.Context, rule *model.ExpenseApprovalRule) error {
+	if err := rule.Validate(); err != nil {
+		return dto.NewExpenseError(dto.ErrCodeInvalidRequest, err.Error())
+	}
+
+	for i, step := range rule.Steps {
+		if step.ApprovalType != model.ApprovalTypeDesignated {
+			continue
+		}
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", *step.ApproverID).Count(&count).Error; err != nil {
+			return dto.NewExpenseError(dto.ErrCodeInternalError, "承認者の確認に失敗しました")
+		}
+		if count == 0 {
+			return dto.NewExpenseError(dto.ErrCodeInvalidRequest, fmt.Sprintf("%d段階目の承認者が見つかりません", i+1))
+		}
+	}
+	return nil
+}
+
+// applyExpenseApprovalRuleRequest リクエストの内容を承認ルールに反映
+func applyExpenseApprovalRuleRequest(rule *model.ExpenseApprovalRule, req *dto.ExpenseApprovalRuleRequest) {
+	rule.Name = req.Name
+	rule.Description = req.Description
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	rule.MinAmount = req.MinAmount
+	rule.MaxAmount = req.MaxAmount
+	rule.CategoryID = normalizeOptionalID(req.CategoryID)
+	rule.DepartmentID = normalizeOptionalID(req.DepartmentID)
+	rule.ProjectID = normalizeOptionalID(req.ProjectID)
+	rule.AutoApprove = req.AutoApprove
+
+	rule.Steps = make([]model.ExpenseApprovalRuleStep, len(req.Steps))
+	for i, step := range req.Steps {
+		rule.Steps[i] = model.ExpenseApprovalRuleStep{
+			RuleID:       rule.ID,
+			StepOrder:    i + 1,
+			ApprovalType: model.ApprovalType(step.ApprovalType),
+			ApproverID:   step.ApproverID,
+		}
+	}
+}
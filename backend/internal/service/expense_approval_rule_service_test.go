@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+)
+
+func TestExpenseApprovalRuleService_CreateRule(t *testing.T) {
+	ctx := context.Background()
+	db, requester := setupApprovalRoutingTest(t)
+	s := NewExpenseApprovalRuleService(db, repository.NewExpenseApprovalRuleRepository(db, zap.NewNop()), zap.NewNop())
+
+	t.Run("作成したルールの段階の順に承認フローを作成する", func(t *testing.T) {
+		minAmount := 50000
+		created, err := s.CreateRule(ctx, "admin-1", &dto.ExpenseApprovalRuleRequest{
+			Name:      "5万円以上",
+			MinAmount: &minAmount,
+			Steps: []dto.ExpenseApprovalRuleStepRequest{
+				{ApprovalType: string(model.ApprovalTypeDesignated), ApproverID: stringPtr("head-1")},
+				{ApprovalType: string(model.ApprovalTypeExecutive)},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, defaultExpenseApprovalRulePriority, created.Priority)
+		assert.True(t, created.IsActive)
+
+		rule, err := selectApprovalRule(ctx, db, zap.NewNop(), model.ExpenseRoutingTarget{Amount: 80000})
+		require.NoError(t, err)
+		require.NotNil(t, rule)
+		assert.Equal(t, created.ID, rule.ID)
+
+		expenseID := "expense-1"
+		require.NoError(t, createRuleApprovalFlow(ctx, db, zap.NewNop(), model.ExpenseApproval{ExpenseID: &expenseID}, requester, rule))
+		var approvals []model.ExpenseApproval
+		require.NoError(t, db.Where("expense_id = ?", expenseID).Order("approval_order").Find(&approvals).Error)
+		require.Len(t, approvals, 2)
+		assert.Equal(t, "head-1", approvals[0].ApproverID)
+		assert.Equal(t, "exec-1", approvals[1].ApproverID)
+	})
+
+	tests := []struct {
+		name string
+		req  *dto.ExpenseApprovalRuleRequest
+	}{
+		{
+			name: "指名した承認者が存在しない",
+			req: &dto.ExpenseApprovalRuleRequest{Name: "指名", Steps: []dto.ExpenseApprovalRuleStepRequest{
+				{ApprovalType: string(model.ApprovalTypeDesignated), ApproverID: stringPtr("unknown-user")},
+			}},
+		},
+		{
+			name: "自動承認のルールに承認者を設定している",
+			req: &dto.ExpenseApprovalRuleRequest{Name: "自動承認", AutoApprove: true, Steps: []dto.ExpenseApprovalRuleStepRequest{
+				{ApprovalType: string(model.ApprovalTypeManager)},
+			}},
+		},
+		{
+			name: "承認者の段階がない",
+			req:  &dto.ExpenseApprovalRuleRequest{Name: "段階なし"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateRule(ctx, "admin-1", tt.req)
+
+			var expenseErr *dto.ExpenseError
+			require.True(t, errors.As(err, &expenseErr))
+			assert.Equal(t, dto.ErrCodeInvalidRequest, expenseErr.Code)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -175,6 +177,7 @@ func (m *MockCategoryRepository) SetLogger(logger *zap.Logger) {
 // MockExpenseRepository is a mock implementation of the expense repository
 type MockExpenseRepository struct {
 	mock.Mock
+	repository.ExpenseRepository // methods not used by the tests are left unimplemented
 }
 
 func (m *MockExpenseRepository) Create(ctx context.Context, expense *model.Expense) error {
@@ -200,14 +203,39 @@ func (m *MockExpenseRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+// setupCategoryCodeTestDB creates an in-memory DB with the tables written when creating an expense
+func setupCategoryCodeTestDB(t *testing.T) *gorm.DB {
+	db, err := testutils.SetupInMemoryTestDB()
+	assert.NoError(t, err)
+	assert.NoError(t, testutils.CreateTables(db, &model.Expense{}, &model.ExpenseReceipt{}))
+	return db
+}
+
+// MockExpenseLimitRepository is a mock implementation of the expense limit repository
+type MockExpenseLimitRepository struct {
+	mock.Mock
+	repository.ExpenseLimitRepository // methods not used by the tests are left unimplemented
+}
+
+func (m *MockExpenseLimitRepository) CheckMonthlyLimit(ctx context.Context, userID string, amount int, targetMonth time.Time) (bool, int, error) {
+	args := m.Called(ctx, userID, amount, targetMonth)
+	return args.Bool(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockExpenseLimitRepository) CheckYearlyLimit(ctx context.Context, userID string, amount int, targetYear int) (bool, int, error) {
+	args := m.Called(ctx, userID, amount, targetYear)
+	return args.Bool(0), args.Int(1), args.Error(2)
+}
+
 // TestCreateExpenseWithCategoryCode tests creating an expense using category code
 func TestCreateExpenseWithCategoryCode(t *testing.T) {
 	ctx := context.Background()
 	logger := zap.NewNop()
-	mockDB := &gorm.DB{}
+	db := setupCategoryCodeTestDB(t)
 
 	mockCategoryRepo := new(MockCategoryRepository)
 	mockExpenseRepo := new(MockExpenseRepository)
+	mockLimitRepo := new(MockExpenseLimitRepository)
 
 	// Create test category
 	testCategory := &model.ExpenseCategoryMaster{
@@ -215,20 +243,21 @@ func TestCreateExpenseWithCategoryCode(t *testing.T) {
 		Code:         "transport",
 		Name:         "旅費交通費",
 		IsActive:     true,
-		IsDefault:    false,
 		DisplayOrder: 1,
 	}
 
 	// Setup mock expectations
 	mockCategoryRepo.On("GetByCode", ctx, "transport").Return(testCategory, nil)
-	mockExpenseRepo.On("Create", ctx, mock.AnythingOfType("*model.Expense")).Return(nil)
+	mockLimitRepo.On("CheckMonthlyLimit", ctx, mock.Anything, 1000, mock.Anything).Return(true, 0, nil)
+	mockLimitRepo.On("CheckYearlyLimit", ctx, mock.Anything, 1000, mock.Anything).Return(true, 0, nil)
 
 	// Create service with mocks
 	service := &expenseService{
-		db:           mockDB,
+		db:           db,
 		logger:       logger,
 		categoryRepo: mockCategoryRepo,
 		expenseRepo:  mockExpenseRepo,
+		limitRepo:    mockLimitRepo,
 	}
 
 	// Test data
@@ -250,19 +279,25 @@ func TestCreateExpenseWithCategoryCode(t *testing.T) {
 	assert.Equal(t, testCategory.ID, expense.CategoryID)
 	assert.Equal(t, model.ExpenseCategory("transport"), expense.Category)
 
+	// The expense is saved in the transaction
+	var saved model.Expense
+	assert.NoError(t, db.First(&saved, "id = ?", expense.ID).Error)
+	assert.Equal(t, expense.CategoryID, saved.CategoryID)
+
 	// Verify mock expectations
 	mockCategoryRepo.AssertExpectations(t)
-	mockExpenseRepo.AssertExpectations(t)
+	mockLimitRepo.AssertExpectations(t)
 }
 
 // TestCreateExpenseWithCategoryID tests backward compatibility with category ID
 func TestCreateExpenseWithCategoryID(t *testing.T) {
 	ctx := context.Background()
 	logger := zap.NewNop()
-	mockDB := &gorm.DB{}
+	db := setupCategoryCodeTestDB(t)
 
 	mockCategoryRepo := new(MockCategoryRepository)
 	mockExpenseRepo := new(MockExpenseRepository)
+	mockLimitRepo := new(MockExpenseLimitRepository)
 
 	// Create test category
 	testCategoryID := uuid.New().String()
@@ -271,20 +306,21 @@ func TestCreateExpenseWithCategoryID(t *testing.T) {
 		Code:         "transport",
 		Name:         "旅費交通費",
 		IsActive:     true,
-		IsDefault:    false,
 		DisplayOrder: 1,
 	}
 
 	// Setup mock expectations
 	mockCategoryRepo.On("GetByID", ctx, testCategoryID).Return(testCategory, nil)
-	mockExpenseRepo.On("Create", ctx, mock.AnythingOfType("*model.Expense")).Return(nil)
+	mockLimitRepo.On("CheckMonthlyLimit", ctx, mock.Anything, 1000, mock.Anything).Return(true, 0, nil)
+	mockLimitRepo.On("CheckYearlyLimit", ctx, mock.Anything, 1000, mock.Anything).Return(true, 0, nil)
 
 	// Create service with mocks
 	service := &expenseService{
-		db:           mockDB,
+		db:           db,
 		logger:       logger,
 		categoryRepo: mockCategoryRepo,
 		expenseRepo:  mockExpenseRepo,
+		limitRepo:    mockLimitRepo,
 	}
 
 	// Test data
@@ -307,9 +343,14 @@ func TestCreateExpenseWithCategoryID(t *testing.T) {
 	assert.Equal(t, testCategoryID, expense.CategoryID)
 	assert.Equal(t, model.ExpenseCategory("transport"), expense.Category)
 
+	// The expense is saved in the transaction
+	var saved model.Expense
+	assert.NoError(t, db.First(&saved, "id = ?", expense.ID).Error)
+	assert.Equal(t, expense.CategoryID, saved.CategoryID)
+
 	// Verify mock expectations
 	mockCategoryRepo.AssertExpectations(t)
-	mockExpenseRepo.AssertExpectations(t)
+	mockLimitRepo.AssertExpectations(t)
 }
 
 // TestCreateExpenseWithInvalidCategoryCode tests error handling for invalid category code
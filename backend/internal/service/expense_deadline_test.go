@@ -179,54 +179,48 @@ func TestExpense_ShouldExpire(t *testing.T) {
 }
 
 func TestExpense_NeedsReminder(t *testing.T) {
-	deadline := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
-	reminderDate := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	deadline := time.Now().AddDate(0, 0, 3)
+	sentAt := time.Now().AddDate(0, 0, -1)
 
 	tests := []struct {
 		name         string
 		expense      model.Expense
 		reminderDate time.Time
-		now          time.Time
 		expected     bool
 	}{
 		{
 			name: "リマインダー必要（リマインダー日を過ぎた）",
 			expense: model.Expense{
-				Status:                 model.ExpenseStatusSubmitted,
-				DeadlineAt:             &deadline,
-				ExpiryNotificationSent: false,
+				Status:     model.ExpenseStatusSubmitted,
+				DeadlineAt: &deadline,
 			},
-			reminderDate: reminderDate,
-			now:          time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			reminderDate: time.Now().AddDate(0, 0, -1),
 			expected:     true,
 		},
 		{
 			name: "リマインダー不要（リマインダー日前）",
 			expense: model.Expense{
-				Status:                 model.ExpenseStatusSubmitted,
-				DeadlineAt:             &deadline,
-				ExpiryNotificationSent: false,
+				Status:     model.ExpenseStatusSubmitted,
+				DeadlineAt: &deadline,
 			},
-			reminderDate: reminderDate,
-			now:          time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			reminderDate: time.Now().AddDate(0, 0, 1),
 			expected:     false,
 		},
 		{
 			name: "リマインダー不要（既に送信済み）",
 			expense: model.Expense{
-				Status:                 model.ExpenseStatusSubmitted,
-				DeadlineAt:             &deadline,
-				ExpiryNotificationSent: true,
+				Status:         model.ExpenseStatusSubmitted,
+				DeadlineAt:     &deadline,
+				ReminderSentAt: &sentAt,
 			},
-			reminderDate: reminderDate,
-			now:          time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			reminderDate: time.Now().AddDate(0, 0, -1),
 			expected:     false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := tt.expense.NeedsReminder(tt.reminderDate, tt.now)
+			result := tt.expense.NeedsReminder(tt.reminderDate)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
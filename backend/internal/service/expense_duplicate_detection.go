@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/pkg/receipthash"
+)
+
+// receiptHash 領収書ファイルの重複検出用ハッシュ
+type receiptHash struct {
+	contentHash    string
+	perceptualHash *string
+}
+
+// computeReceiptHash 領収書ファイルを取得してハッシュを計算
+// 取得に失敗した場合は重複検出の対象外とし、領収書の保存は続行する
+func (s *expenseService) computeReceiptHash(ctx context.Context, s3Key string) receiptHash {
+	content, err := s.s3Service.DownloadFile(ctx, s3Key)
+	if err != nil {
+		s.logger.Warn("Failed to download receipt for duplicate detection",
+			zap.Error(err),
+			zap.String("s3_key", s3Key))
+		return receiptHash{}
+	}
+
+	hash := receiptHash{contentHash: receipthash.ContentHash(content)}
+	perceptualHash, err := receipthash.PerceptualHash(content)
+	switch {
+	case err == nil:
+		hash.perceptualHash = &perceptualHash
+	case !errors.Is(err, receipthash.ErrUnsupportedImage):
+		s.logger.Warn("Failed to compute perceptual hash of receipt",
+			zap.Error(err),
+			zap.String("s3_key", s3Key))
+	}
+	return hash
+}
+
+// computeReceiptHashes 領収書ファイルのハッシュをS3キーごとに計算（トランザクションの外で呼び出す）
+func (s *expenseService) computeReceiptHashes(ctx context.Context, receipts []dto.CreateExpenseReceiptRequest) map[string]receiptHash {
+	hashes := make(map[string]receiptHash, len(receipts))
+	for _, receipt := range receipts {
+		if _, ok := hashes[receipt.S3Key]; ok {
+			continue
+		}
+		hashes[receipt.S3Key] = s.computeReceiptHash(ctx, receipt.S3Key)
+	}
+	return hashes
+}
+
+// applyReceiptHash 計算済みのハッシュを領収書に設定
+func applyReceiptHash(receipt *model.ExpenseReceipt, hashes map[string]receiptHash) {
+	hash, ok := hashes[receipt.S3Key]
+	if !ok {
+		return
+	}
+	receipt.ContentHash = hash.contentHash
+	receipt.PerceptualHash = hash.perceptualHash
+}
+
+// backfillReceiptHashes ハッシュ未計算の領収書（機能追加前に保存されたものなど）のハッシュを計算して保存
+func (s *expenseService) backfillReceiptHashes(ctx context.Context, receipts []*model.ExpenseReceipt) {
+	for _, receipt := range receipts {
+		if receipt.ContentHash != "" {
+			continue
+		}
+		hash := s.computeReceiptHash(ctx, receipt.S3Key)
+		if hash.contentHash == "" {
+			continue
+		}
+		receipt.ContentHash = hash.contentHash
+		receipt.PerceptualHash = hash.perceptualHash
+		if err := s.receiptRepo.Update(ctx, receipt); err != nil {
+			s.logger.Warn("Failed to save receipt hash",
+				zap.Error(err),
+				zap.String("receipt_id", receipt.ID))
+		}
+	}
+}
+
+// isPossibleDuplicateUpload アップロードされた領収書が提出済みの経費申請の領収書と重複する可能性があるかチェック
+func (s *expenseService) isPossibleDuplicateUpload(ctx context.Context, userID string, hash receiptHash) (bool, error) {
+	if hash.contentHash == "" {
+		return false, nil
+	}
+
+	sameReceipts, err := s.receiptRepo.FindByContentHashes(ctx, []string{hash.contentHash}, "")
+	if err != nil {
+		return false, err
+	}
+	if len(sameReceipts) > 0 {
+		return true, nil
+	}
+
+	if hash.perceptualHash == nil {
+		return false, nil
+	}
+	userReceipts, err := s.receiptRepo.FindWithPerceptualHashByUserID(ctx, userID, "")
+	if err != nil {
+		return false, err
+	}
+	for _, receipt := range userReceipts {
+		if receipthash.IsSimilar(*hash.perceptualHash, *receipt.PerceptualHash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// detectDuplicates 経費申請と重複する可能性がある提出済みの経費申請を検出
+// 同じ領収書ファイル（申請者を問わない）、同じ領収書を撮り直した画像（同じ申請者）、
+// 同じ申請者・金額・使用日・カテゴリの経費申請を対象とし、経費申請と理由の組み合わせごとに1件にまとめる
+func (s *expenseService) detectDuplicates(ctx context.Context, tx *gorm.DB, expense *model.Expense, receipts []*model.ExpenseReceipt) ([]model.ExpenseDuplicateFlag, error) {
+	txReceiptRepo := repository.NewExpenseReceiptRepository(tx, s.logger)
+	txFlagRepo := repository.NewExpenseDuplicateFlagRepository(tx, s.logger)
+
+	now := time.Now()
+	flags := make([]model.ExpenseDuplicateFlag, 0)
+	seen := make(map[string]bool)
+	addFlag := func(duplicateExpenseID string, reason model.DuplicateReason, receiptID, duplicateReceiptID *string) {
+		key := duplicateExpenseID + "/" + string(reason)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		flags = append(flags, model.ExpenseDuplicateFlag{
+			ExpenseID:          expense.ID,
+			DuplicateExpenseID: duplicateExpenseID,
+			Reason:             reason,
+			ReceiptID:          receiptID,
+			DuplicateReceiptID: duplicateReceiptID,
+			DetectedAt:         now,
+		})
+	}
+
+	// 同じ領収書ファイル
+	contentHashes := make([]string, 0, len(receipts))
+	receiptByContentHash := make(map[string]*model.ExpenseReceipt, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.ContentHash == "" {
+			continue
+		}
+		contentHashes = append(contentHashes, receipt.ContentHash)
+		receiptByContentHash[receipt.ContentHash] = receipt
+	}
+	sameReceipts, err := txReceiptRepo.FindByContentHashes(ctx, contentHashes, expense.ID)
+	if err != nil {
+		return nil, fmt.Errorf("同じ領収書の検索に失敗しました: %w", err)
+	}
+	for _, duplicate := range sameReceipts {
+		receipt := receiptByContentHash[duplicate.ContentHash]
+		addFlag(duplicate.ExpenseID, model.DuplicateReasonSameReceipt, &receipt.ID, &duplicate.ID)
+	}
+
+	// 同じ領収書を撮り直した画像
+	var imageReceipts []*model.ExpenseReceipt
+	for _, receipt := range receipts {
+		if receipt.PerceptualHash != nil {
+			imageReceipts = append(imageReceipts, receipt)
+		}
+	}
+	if len(imageReceipts) > 0 {
+		userReceipts, err := txReceiptRepo.FindWithPerceptualHashByUserID(ctx, expense.UserID, expense.ID)
+		if err != nil {
+			return nil, fmt.Errorf("類似する領収書の検索に失敗しました: %w", err)
+		}
+		for _, receipt := range imageReceipts {
+			for _, duplicate := range userReceipts {
+				if duplicate.ContentHash == receipt.ContentHash {
+					continue // 同じ領収書ファイルとして検出済み
+				}
+				if receipthash.IsSimilar(*receipt.PerceptualHash, *duplicate.PerceptualHash) {
+					addFlag(duplicate.ExpenseID, model.DuplicateReasonSimilarReceipt, &receipt.ID, &duplicate.ID)
+				}
+			}
+		}
+	}
+
+	// 同じ申請者・金額・使用日・カテゴリの経費申請
+	similarExpenses, err := txFlagRepo.FindSimilarExpenses(ctx, expense)
+	if err != nil {
+		return nil, fmt.Errorf("類似する経費申請の検索に失敗しました: %w", err)
+	}
+	for i := range similarExpenses {
+		addFlag(similarExpenses[i].ID, model.DuplicateReasonSimilarExpense, nil, nil)
+	}
+
+	return flags, nil
+}
+
+// flagDuplicates 経費申請の重複の可能性を検出して記録し、承認者の確認が必要なフラグの件数を返す
+// 再提出の場合、承認者が確認済みの重複は改めてフラグを立てない
+func (s *expenseService) flagDuplicates(ctx context.Context, tx *gorm.DB, expense *model.Expense, receipts []*model.ExpenseReceipt) (int, error) {
+	detected, err := s.detectDuplicates(ctx, tx, expense, receipts)
+	if err != nil {
+		return 0, err
+	}
+
+	txFlagRepo := repository.NewExpenseDuplicateFlagRepository(tx, s.logger)
+	existing, err := txFlagRepo.GetByExpenseID(ctx, expense.ID)
+	if err != nil {
+		return 0, fmt.Errorf("重複フラグの取得に失敗しました: %w", err)
+	}
+	overridden := make(map[string]bool, len(existing))
+	for _, flag := range existing {
+		if flag.IsOverridden() {
+			overridden[flag.DuplicateExpenseID+"/"+string(flag.Reason)] = true
+		}
+	}
+	flags := make([]model.ExpenseDuplicateFlag, 0, len(detected))
+	for _, flag := range detected {
+		if !overridden[flag.DuplicateExpenseID+"/"+string(flag.Reason)] {
+			flags = append(flags, flag)
+		}
+	}
+
+	if err := txFlagRepo.ReplaceUnresolved(ctx, expense.ID, flags); err != nil {
+		return 0, fmt.Errorf("重複フラグの保存に失敗しました: %w", err)
+	}
+
+	if len(flags) > 0 {
+		s.logger.Info("Possible duplicate expense detected",
+			zap.String("expense_id", expense.ID),
+			zap.String("user_id", expense.UserID),
+			zap.Int("flag_count", len(flags)))
+	}
+	return len(flags), nil
+}
+
+// resolveDuplicateFlags 承認時に未確認の重複フラグへ承認者の理由を記録（理由がない場合は承認できない）
+func (s *expenseService) resolveDuplicateFlags(ctx context.Context, tx *gorm.DB, expenseID, approverID, reason string) error {
+	txFlagRepo := repository.NewExpenseDuplicateFlagRepository(tx, s.logger)
+	count, err := txFlagRepo.CountUnresolved(ctx, expenseID)
+	if err != nil {
+		return fmt.Errorf("重複フラグの取得に失敗しました: %w", err)
+	}
+	if count == 0 {
+		return nil
+	}
+	if reason == "" {
+		return dto.NewExpenseError(dto.ErrCodeDuplicateOverrideRequired, "重複の可能性がある経費申請です。承認する理由を入力してください")
+	}
+	if err := txFlagRepo.OverrideUnresolved(ctx, expenseID, approverID, reason); err != nil {
+		return fmt.Errorf("重複フラグの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// duplicateFlagResponses 承認待ち一覧に表示する重複フラグを取得
+func (s *expenseService) duplicateFlagResponses(ctx context.Context, expense *model.Expense) ([]dto.ExpenseDuplicateFlagResponse, bool, error) {
+	flags, err := repository.NewExpenseDuplicateFlagRepository(s.db, s.logger).GetByExpenseID(ctx, expense.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	responses := make([]dto.ExpenseDuplicateFlagResponse, len(flags))
+	requiresOverride := false
+	for i := range flags {
+		responses[i].FromModel(&flags[i], expense.UserID)
+		if !flags[i].IsOverridden() {
+			requiresOverride = true
+		}
+	}
+	return responses, requiresOverride, nil
+}
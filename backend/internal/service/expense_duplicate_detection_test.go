@@ -0,0 +1,251 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
+)
+
+// MockReceiptS3Service 領収書ファイルを返すS3サービスのモック
+type MockReceiptS3Service struct {
+	mock.Mock
+	S3Service // テストで使わないメソッドは未実装
+}
+
+func (m *MockReceiptS3Service) DownloadFile(ctx context.Context, s3Key string) ([]byte, error) {
+	args := m.Called(s3Key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// receiptTestImage 領収書風の画像（明るい背景に濃い帯）
+func receiptTestImage(stripes []int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 450))
+	for y := 0; y < 450; y++ {
+		for x := 0; x < 300; x++ {
+			img.Set(x, y, color.RGBA{R: 240, G: 240, B: 235, A: 255})
+		}
+	}
+	for _, top := range stripes {
+		for y := top * 450 / 100; y < (top+4)*450/100; y++ {
+			for x := 30; x < 300*(4+top%5)/10; x++ {
+				img.Set(x, y, color.RGBA{R: 30, G: 30, B: 30, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+// setupDuplicateDetectionTest 経費・領収書・重複フラグのテーブルを持つSQLiteで経費申請サービスを作成
+// 社員user-1は領収書receipt.pngを付けた経費申請を提出済みで、user-2はshared.pdfを付けた経費申請が承認済み。
+// user-1の下書きの経費申請に付けたdraft.pdfは重複検出の対象外
+func setupDuplicateDetectionTest(t *testing.T) (*expenseService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db, &model.Expense{}, &model.ExpenseReceipt{}, &model.ExpenseDuplicateFlag{}))
+
+	original := receiptTestImage([]int{10, 22, 35, 61, 80})
+	var pngFile, jpegFile bytes.Buffer
+	require.NoError(t, png.Encode(&pngFile, original))
+	require.NoError(t, jpeg.Encode(&jpegFile, original, &jpeg.Options{Quality: 60}))
+	var otherFile bytes.Buffer
+	require.NoError(t, png.Encode(&otherFile, receiptTestImage([]int{5, 40, 47, 52, 90})))
+
+	s3Service := new(MockReceiptS3Service)
+	s3Service.On("DownloadFile", "receipts/receipt.png").Return(pngFile.Bytes(), nil)
+	s3Service.On("DownloadFile", "receipts/receipt-copy.png").Return(pngFile.Bytes(), nil)
+	s3Service.On("DownloadFile", "receipts/retaken.jpg").Return(jpegFile.Bytes(), nil)
+	s3Service.On("DownloadFile", "receipts/other.png").Return(otherFile.Bytes(), nil)
+	s3Service.On("DownloadFile", "receipts/shared.pdf").Return([]byte("%PDF-1.4 shared receipt"), nil)
+	s3Service.On("DownloadFile", "receipts/draft.pdf").Return([]byte("%PDF-1.4 draft receipt"), nil)
+	s3Service.On("DownloadFile", "receipts/missing.pdf").Return(nil, errors.New("not found"))
+
+	logger := zap.NewNop()
+	s := &expenseService{db: db, receiptRepo: repository.NewExpenseReceiptRepository(db, logger), s3Service: s3Service, logger: logger}
+
+	createDuplicateExpense(t, db, "expense-old", "user-1", 5000, model.ExpenseStatusSubmitted)
+	createDuplicateReceipt(t, s, db, "expense-old", "receipts/receipt.png")
+	createDuplicateExpense(t, db, "expense-other", "user-2", 8000, model.ExpenseStatusApproved)
+	createDuplicateReceipt(t, s, db, "expense-other", "receipts/shared.pdf")
+	createDuplicateExpense(t, db, "expense-draft", "user-1", 5000, model.ExpenseStatusDraft)
+	createDuplicateReceipt(t, s, db, "expense-draft", "receipts/draft.pdf")
+	return s, db
+}
+
+// createDuplicateExpense 2026年10月10日の交通費の経費申請を登録
+func createDuplicateExpense(t *testing.T, db *gorm.DB, id, userID string, amount int, status model.ExpenseStatus) *model.Expense {
+	expense := &model.Expense{
+		ID:          id,
+		UserID:      userID,
+		Title:       "客先訪問",
+		Category:    model.ExpenseCategoryTransport,
+		CategoryID:  "category-1",
+		Amount:      amount,
+		ExpenseDate: time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+		Status:      status,
+	}
+	require.NoError(t, db.Omit(clause.Associations).Create(expense).Error)
+	return expense
+}
+
+// createDuplicateReceipt ハッシュを計算して領収書を登録
+func createDuplicateReceipt(t *testing.T, s *expenseService, db *gorm.DB, expenseID, s3Key string) *model.ExpenseReceipt {
+	receipt := &model.ExpenseReceipt{ExpenseID: expenseID, S3Key: s3Key}
+	hashes := s.computeReceiptHashes(context.Background(), []dto.CreateExpenseReceiptRequest{{S3Key: s3Key}})
+	applyReceiptHash(receipt, hashes)
+	require.NoError(t, db.Omit(clause.Associations).Create(receipt).Error)
+	return receipt
+}
+
+func flagDuplicatesInTx(t *testing.T, s *expenseService, db *gorm.DB, expense *model.Expense, receipts []*model.ExpenseReceipt) int {
+	var count int
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		count, err = s.flagDuplicates(context.Background(), tx, expense, receipts)
+		return err
+	}))
+	return count
+}
+
+func duplicateReasons(t *testing.T, db *gorm.DB, expenseID string) map[string]model.DuplicateReason {
+	var flags []model.ExpenseDuplicateFlag
+	require.NoError(t, db.Where("expense_id = ?", expenseID).Find(&flags).Error)
+	reasons := make(map[string]model.DuplicateReason, len(flags))
+	for _, flag := range flags {
+		reasons[flag.DuplicateExpenseID+"/"+string(flag.Reason)] = flag.Reason
+	}
+	return reasons
+}
+
+func TestExpenseService_FlagDuplicates(t *testing.T) {
+	t.Run("同じ領収書ファイルは申請者を問わず、同じ金額・使用日・カテゴリは同じ申請者の経費申請を検出する", func(t *testing.T) {
+		s, db := setupDuplicateDetectionTest(t)
+		expense := createDuplicateExpense(t, db, "expense-new", "user-1", 5000, model.ExpenseStatusSubmitted)
+		receipts := []*model.ExpenseReceipt{
+			createDuplicateReceipt(t, s, db, expense.ID, "receipts/receipt-copy.png"),
+			createDuplicateReceipt(t, s, db, expense.ID, "receipts/shared.pdf"),
+			createDuplicateReceipt(t, s, db, expense.ID, "receipts/draft.pdf"),
+		}
+
+		count := flagDuplicatesInTx(t, s, db, expense, receipts)
+
+		assert.Equal(t, 3, count)
+		reasons := duplicateReasons(t, db, expense.ID)
+		assert.Len(t, reasons, 3)
+		assert.Contains(t, reasons, "expense-old/"+string(model.DuplicateReasonSameReceipt))
+		assert.Contains(t, reasons, "expense-other/"+string(model.DuplicateReasonSameReceipt))
+		assert.Contains(t, reasons, "expense-old/"+string(model.DuplicateReasonSimilarExpense))
+	})
+
+	t.Run("撮り直した領収書の画像は同じ申請者の経費申請だけを検出する", func(t *testing.T) {
+		s, db := setupDuplicateDetectionTest(t)
+		expense := createDuplicateExpense(t, db, "expense-new", "user-1", 1200, model.ExpenseStatusSubmitted)
+		receipt := createDuplicateReceipt(t, s, db, expense.ID, "receipts/retaken.jpg")
+
+		count := flagDuplicatesInTx(t, s, db, expense, []*model.ExpenseReceipt{receipt})
+
+		assert.Equal(t, 1, count)
+		assert.Contains(t, duplicateReasons(t, db, expense.ID), "expense-old/"+string(model.DuplicateReasonSimilarReceipt))
+
+		otherUser := createDuplicateExpense(t, db, "expense-user-2", "user-2", 1200, model.ExpenseStatusSubmitted)
+		otherReceipt := createDuplicateReceipt(t, s, db, otherUser.ID, "receipts/retaken.jpg")
+		// user-1の撮り直した領収書とは同じファイルとして検出し、user-1の元の画像は類似として検出しない
+		flagDuplicatesInTx(t, s, db, otherUser, []*model.ExpenseReceipt{otherReceipt})
+		assert.Equal(t, map[string]model.DuplicateReason{
+			"expense-new/" + string(model.DuplicateReasonSameReceipt): model.DuplicateReasonSameReceipt,
+		}, duplicateReasons(t, db, otherUser.ID))
+	})
+
+	t.Run("ハッシュを計算できない領収書と別の領収書は検出しない", func(t *testing.T) {
+		s, db := setupDuplicateDetectionTest(t)
+		expense := createDuplicateExpense(t, db, "expense-new", "user-1", 1200, model.ExpenseStatusSubmitted)
+		receipts := []*model.ExpenseReceipt{
+			createDuplicateReceipt(t, s, db, expense.ID, "receipts/missing.pdf"),
+			createDuplicateReceipt(t, s, db, expense.ID, "receipts/other.png"),
+		}
+		assert.Empty(t, receipts[0].ContentHash)
+
+		assert.Zero(t, flagDuplicatesInTx(t, s, db, expense, receipts))
+	})
+}
+
+func TestExpenseService_ResolveDuplicateFlags(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupDuplicateDetectionTest(t)
+	expense := createDuplicateExpense(t, db, "expense-new", "user-1", 5000, model.ExpenseStatusSubmitted)
+	receipts := []*model.ExpenseReceipt{createDuplicateReceipt(t, s, db, expense.ID, "receipts/receipt-copy.png")}
+	require.Equal(t, 2, flagDuplicatesInTx(t, s, db, expense, receipts))
+
+	t.Run("理由がなければ重複の可能性がある経費申請を承認できない", func(t *testing.T) {
+		err := s.resolveDuplicateFlags(ctx, db, expense.ID, "manager-1", "")
+
+		var expenseErr *dto.ExpenseError
+		require.True(t, errors.As(err, &expenseErr))
+		assert.Equal(t, dto.ErrCodeDuplicateOverrideRequired, expenseErr.Code)
+	})
+
+	t.Run("承認者が確認した重複は再提出で改めて検出しない", func(t *testing.T) {
+		require.NoError(t, s.resolveDuplicateFlags(ctx, db, expense.ID, "manager-1", "往復の交通費のため"))
+
+		var flags []model.ExpenseDuplicateFlag
+		require.NoError(t, db.Where("expense_id = ?", expense.ID).Find(&flags).Error)
+		require.Len(t, flags, 2)
+		for _, flag := range flags {
+			assert.True(t, flag.IsOverridden())
+			assert.Equal(t, "manager-1", *flag.OverriddenBy)
+		}
+
+		assert.Zero(t, flagDuplicatesInTx(t, s, db, expense, receipts))
+		responses, requiresOverride, err := s.duplicateFlagResponses(ctx, expense)
+		require.NoError(t, err)
+		assert.Len(t, responses, 2)
+		assert.False(t, requiresOverride)
+	})
+}
+
+func TestExpenseService_IsPossibleDuplicateUpload(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupDuplicateDetectionTest(t)
+	tests := []struct {
+		name   string
+		userID string
+		s3Key  string
+		want   bool
+	}{
+		{name: "他の社員が提出済みの領収書と同じファイル", userID: "user-2", s3Key: "receipts/receipt-copy.png", want: true},
+		{name: "自分が提出済みの領収書を撮り直した画像", userID: "user-1", s3Key: "receipts/retaken.jpg", want: true},
+		{name: "他の社員の領収書に似た画像", userID: "user-2", s3Key: "receipts/retaken.jpg", want: false},
+		{name: "下書きの経費申請の領収書と同じファイル", userID: "user-1", s3Key: "receipts/draft.pdf", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.isPossibleDuplicateUpload(ctx, tt.userID, s.computeReceiptHash(ctx, tt.s3Key))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
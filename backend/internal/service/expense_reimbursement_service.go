@@ -0,0 +1,634 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/utils"
+	"github.com/duesk/monstera/pkg/banktransfer"
+)
+
+// reimbursementUserName 社員名のSQL式（usersテーブルの別名 u）
+const reimbursementUserName = "COALESCE(NULLIF(u.name, ''), u.last_name || ' ' || u.first_name)"
+
+// ExpenseReimbursementServiceInterface 経費精算（総合振込）サービスのインターフェース
+type ExpenseReimbursementServiceInterface interface {
+	// 振込先口座
+	GetBankAccount(ctx context.Context, userID string) (*dto.BankAccountDTO, error)
+	SaveBankAccount(ctx context.Context, userID string, req *dto.BankAccountRequest) (*dto.BankAccountDTO, error)
+
+	// 振込バッチ
+	CreateBatch(ctx context.Context, req *dto.CreateReimbursementBatchRequest, userID string) (*dto.ReimbursementBatchDTO, error)
+	ListBatches(ctx context.Context, status string) ([]dto.ReimbursementBatchDTO, error)
+	GetBatch(ctx context.Context, batchID string) (*dto.ReimbursementBatchDTO, error)
+	GenerateTransferFile(ctx context.Context, batchID string) (*dto.ReimbursementTransferFile, error)
+	ConfirmBatch(ctx context.Context, batchID, userID string) (*dto.ReimbursementBatchDTO, error)
+	CancelBatch(ctx context.Context, batchID, userID string) (*dto.ReimbursementBatchDTO, error)
+
+	// 精算明細
+	ListStatements(ctx context.Context, batchID string) ([]dto.ReimbursementStatementDTO, error)
+	GetStatement(ctx context.Context, batchID, userID string) (*dto.ReimbursementStatementDTO, error)
+	ListUserReimbursements(ctx context.Context, userID string) ([]dto.ReimbursementItemDTO, error)
+}
+
+// expenseReimbursementService 経費精算（総合振込）サービス実装
+type expenseReimbursementService struct {
+	db         *gorm.DB
+	encryption *utils.TokenEncryption
+	company    *config.CompanyConfig
+	logger     *zap.Logger
+}
+
+// NewExpenseReimbursementService 経費精算（総合振込）サービスのコンストラクタ
+func NewExpenseReimbursementService(db *gorm.DB, encryption *utils.TokenEncryption, company *config.CompanyConfig, logger *zap.Logger) ExpenseReimbursementServiceInterface {
+	return &expenseReimbursementService{
+		db:         db,
+		encryption: encryption,
+		company:    company,
+		logger:     logger,
+	}
+}
+
+// GetBankAccount 社員の振込先口座を取得（未登録の場合はnil）
+func (s *expenseReimbursementService) GetBankAccount(ctx context.Context, userID string) (*dto.BankAccountDTO, error) {
+	var account model.ProfileBankAccount
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("振込先口座の取得に失敗しました: %w", err)
+	}
+	return s.bankAccountToDTO(&account)
+}
+
+// SaveBankAccount 社員の振込先口座を登録・更新する
+// 作成済みの振込バッチは作成時点の口座に振り込み、変更は以降のバッチから反映される
+func (s *expenseReimbursementService) SaveBankAccount(ctx context.Context, userID string, req *dto.BankAccountRequest) (*dto.BankAccountDTO, error) {
+	if err := banktransfer.ValidateAccount(req.BankCode, req.BranchCode, req.AccountType, req.AccountNumber); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+	// 全銀協フォーマットで出力できる名義・名称か確認する
+	for _, name := range []string{req.BankName, req.BranchName, req.AccountHolder} {
+		if _, err := banktransfer.ToKana(name); err != nil {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+				"金融機関名・支店名・口座名義はカナで入力してください").
+				WithDetail("value", name)
+		}
+	}
+
+	accountNumber, err := s.encryption.Encrypt(req.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+	holder, err := s.encryption.Encrypt(req.AccountHolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var account model.ProfileBankAccount
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).First(&account).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("振込先口座の取得に失敗しました: %w", err)
+			}
+			account = model.ProfileBankAccount{UserID: userID}
+		}
+		account.BankCode = req.BankCode
+		account.BankName = req.BankName
+		account.BranchCode = req.BranchCode
+		account.BranchName = req.BranchName
+		account.AccountType = req.AccountType
+		account.AccountNumberEncrypted = accountNumber
+		account.AccountHolderEncrypted = holder
+		account.AccountNumberLast4 = model.AccountNumberLast4(req.AccountNumber)
+		if err := tx.Save(&account).Error; err != nil {
+			return fmt.Errorf("振込先口座の保存に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Profile bank account saved", zap.String("user_id", userID))
+	return s.bankAccountToDTO(&account)
+}
+
+// CreateBatch 承認済みの経費を社員ごとにまとめて振込バッチを作成する
+// 振込先口座が未登録の社員の経費は含めず、次回以降のバッチで精算する
+// 仮払金の精算に紐付けた経費は仮払金との差額で精算するため含めない
+func (s *expenseReimbursementService) CreateBatch(ctx context.Context, req *dto.CreateReimbursementBatchRequest, userID string) (*dto.ReimbursementBatchDTO, error) {
+	paymentDate, err := parseAccountingDate(req.PaymentDate, "振込日")
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if paymentDate.Before(today) {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "振込日は今日以降の日付を指定してください")
+	}
+	var approvedBefore *time.Time
+	if req.ApprovedBefore != "" {
+		cutoff, err := parseAccountingDate(req.ApprovedBefore, "承認日")
+		if err != nil {
+			return nil, err
+		}
+		cutoff = cutoff.AddDate(0, 0, 1)
+		approvedBefore = &cutoff
+	}
+
+	batch := &model.ExpenseReimbursementBatch{
+		PaymentDate: paymentDate,
+		Status:      model.ReimbursementBatchStatusDraft,
+		CreatedBy:   userID,
+	}
+	var skipped []dto.ReimbursementSkippedDTO
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&model.Expense{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status IN ? AND payment_batch_id IS NULL AND paid_at IS NULL AND cash_advance_id IS NULL", model.ReimbursableExpenseStatuses)
+		if approvedBefore != nil {
+			query = query.Where("approved_at < ?", *approvedBefore)
+		}
+		var expenses []model.Expense
+		if err := query.Order("user_id, expense_date").Find(&expenses).Error; err != nil {
+			return fmt.Errorf("精算対象の経費の取得に失敗しました: %w", err)
+		}
+
+		totals := make(map[string]*dto.ReimbursementSkippedDTO)
+		expenseIDs := make(map[string][]string)
+		var userIDs []string
+		for _, e := range expenses {
+			total, ok := totals[e.UserID]
+			if !ok {
+				total = &dto.ReimbursementSkippedDTO{UserID: e.UserID}
+				totals[e.UserID] = total
+				userIDs = append(userIDs, e.UserID)
+			}
+			total.Amount += int64(e.Amount)
+			total.ExpenseCount++
+			expenseIDs[e.UserID] = append(expenseIDs[e.UserID], e.ID)
+		}
+		if len(userIDs) == 0 {
+			return accountingerrors.ErrReimbursementNoExpensesError(req.PaymentDate)
+		}
+
+		var accounts []model.ProfileBankAccount
+		if err := tx.Where("user_id IN ?", userIDs).Find(&accounts).Error; err != nil {
+			return fmt.Errorf("振込先口座の取得に失敗しました: %w", err)
+		}
+		accountByUser := make(map[string]model.ProfileBankAccount, len(accounts))
+		for _, a := range accounts {
+			accountByUser[a.UserID] = a
+		}
+		names, err := reimbursementUserNames(tx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		var items []model.ExpenseReimbursementItem
+		var batchExpenseIDs []string
+		for _, uid := range userIDs {
+			total := totals[uid]
+			account, ok := accountByUser[uid]
+			if !ok || total.Amount <= 0 {
+				total.UserName = names[uid]
+				skipped = append(skipped, *total)
+				continue
+			}
+			items = append(items, model.ExpenseReimbursementItem{
+				UserID:                 uid,
+				Amount:                 total.Amount,
+				ExpenseCount:           total.ExpenseCount,
+				BankCode:               account.BankCode,
+				BankName:               account.BankName,
+				BranchCode:             account.BranchCode,
+				BranchName:             account.BranchName,
+				AccountType:            account.AccountType,
+				AccountNumberEncrypted: account.AccountNumberEncrypted,
+				AccountHolderEncrypted: account.AccountHolderEncrypted,
+				AccountNumberLast4:     account.AccountNumberLast4,
+			})
+			batch.TotalAmount += total.Amount
+			batch.ExpenseCount += total.ExpenseCount
+			batchExpenseIDs = append(batchExpenseIDs, expenseIDs[uid]...)
+		}
+		if len(items) == 0 {
+			return accountingerrors.ErrReimbursementNoExpensesError(req.PaymentDate)
+		}
+		batch.EmployeeCount = len(items)
+
+		if err := tx.Create(batch).Error; err != nil {
+			return fmt.Errorf("振込バッチの作成に失敗しました: %w", err)
+		}
+		for i := range items {
+			items[i].BatchID = batch.ID
+		}
+		if err := tx.Create(&items).Error; err != nil {
+			return fmt.Errorf("振込バッチの明細の作成に失敗しました: %w", err)
+		}
+		if err := tx.Model(&model.Expense{}).
+			Where("id IN ?", batchExpenseIDs).
+			Update("payment_batch_id", batch.ID).Error; err != nil {
+			return fmt.Errorf("経費申請の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Expense reimbursement batch created",
+		zap.String("batch_id", batch.ID),
+		zap.String("payment_date", req.PaymentDate),
+		zap.Int("employee_count", batch.EmployeeCount),
+		zap.Int64("total_amount", batch.TotalAmount),
+		zap.Int("skipped_count", len(skipped)),
+		zap.String("user_id", userID))
+
+	result, err := s.GetBatch(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = skipped
+	return result, nil
+}
+
+// ListBatches 振込バッチの一覧（振込日の新しい順）
+func (s *expenseReimbursementService) ListBatches(ctx context.Context, status string) ([]dto.ReimbursementBatchDTO, error) {
+	query := s.db.WithContext(ctx).Model(&model.ExpenseReimbursementBatch{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var batches []model.ExpenseReimbursementBatch
+	if err := query.Order("payment_date DESC, created_at DESC").Limit(100).Find(&batches).Error; err != nil {
+		return nil, fmt.Errorf("振込バッチの取得に失敗しました: %w", err)
+	}
+	result := make([]dto.ReimbursementBatchDTO, len(batches))
+	for i := range batches {
+		result[i] = reimbursementBatchToDTO(&batches[i])
+	}
+	return result, nil
+}
+
+// GetBatch 振込バッチと社員ごとの振込を取得
+func (s *expenseReimbursementService) GetBatch(ctx context.Context, batchID string) (*dto.ReimbursementBatchDTO, error) {
+	db := s.db.WithContext(ctx)
+	batch, err := findReimbursementBatch(db, batchID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.batchItems(db, "i.batch_id = ?", batchID)
+	if err != nil {
+		return nil, err
+	}
+	result := reimbursementBatchToDTO(batch)
+	result.Items = items
+	return &result, nil
+}
+
+// GenerateTransferFile 振込バッチから全銀協フォーマットの総合振込ファイルを作成
+func (s *expenseReimbursementService) GenerateTransferFile(ctx context.Context, batchID string) (*dto.ReimbursementTransferFile, error) {
+	db := s.db.WithContext(ctx)
+	batch, err := findReimbursementBatch(db, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status == model.ReimbursementBatchStatusCancelled {
+		return nil, accountingerrors.ErrReimbursementBatchNotDraftError(batch.ID, string(batch.Status))
+	}
+	if s.company == nil || s.company.TransferRequesterCode == "" || s.company.TransferAccountNumber == "" {
+		return nil, accountingerrors.ErrReimbursementTransferInvalidError(errors.New("振込元口座が設定されていません"))
+	}
+
+	var items []model.ExpenseReimbursementItem
+	if err := db.Where("batch_id = ?", batch.ID).Order("created_at, user_id").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("振込バッチの明細の取得に失敗しました: %w", err)
+	}
+	transfers := make([]banktransfer.Transfer, len(items))
+	for i, item := range items {
+		accountNumber, err := s.encryption.Decrypt(item.AccountNumberEncrypted)
+		if err != nil {
+			return nil, err
+		}
+		holder, err := s.encryption.Decrypt(item.AccountHolderEncrypted)
+		if err != nil {
+			return nil, err
+		}
+		transfers[i] = banktransfer.Transfer{
+			BankCode:      item.BankCode,
+			BankName:      item.BankName,
+			BranchCode:    item.BranchCode,
+			BranchName:    item.BranchName,
+			AccountType:   item.AccountType,
+			AccountNumber: accountNumber,
+			RecipientName: holder,
+			Amount:        item.Amount,
+		}
+	}
+
+	requester := banktransfer.Requester{
+		Code:          s.company.TransferRequesterCode,
+		Name:          s.company.TransferRequesterName,
+		BankCode:      s.company.TransferBankCode,
+		BankName:      s.company.TransferBankName,
+		BranchCode:    s.company.TransferBranchCode,
+		BranchName:    s.company.TransferBranchName,
+		AccountType:   s.company.TransferAccountType,
+		AccountNumber: s.company.TransferAccountNumber,
+	}
+	var buf bytes.Buffer
+	if err := banktransfer.WriteZengin(&buf, requester, batch.PaymentDate, transfers); err != nil {
+		return nil, accountingerrors.ErrReimbursementTransferInvalidError(err)
+	}
+
+	return &dto.ReimbursementTransferFile{
+		FileName:    fmt.Sprintf("sogofurikomi_%s.txt", batch.PaymentDate.Format("20060102")),
+		ContentType: "text/plain; charset=Shift_JIS",
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// ConfirmBatch 振込を確定し、バッチの経費申請を振込日付で支払済みにする
+func (s *expenseReimbursementService) ConfirmBatch(ctx context.Context, batchID, userID string) (*dto.ReimbursementBatchDTO, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		batch, err := lockDraftReimbursementBatch(tx, batchID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.Expense{}).
+			Where("payment_batch_id = ? AND status IN ?", batch.ID, model.ReimbursableExpenseStatuses).
+			Updates(map[string]interface{}{
+				"status":  model.ExpenseStatusPaid,
+				"paid_at": batch.PaymentDate,
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("経費申請の更新に失敗しました: %w", result.Error)
+		}
+		if result.RowsAffected != int64(batch.ExpenseCount) {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict,
+				"振込バッチ作成後に状態が変わった経費申請があります。バッチを取り消して作り直してください").
+				WithDetail("batch_id", batch.ID)
+		}
+
+		now := time.Now()
+		return tx.Model(batch).Updates(map[string]interface{}{
+			"status":       model.ReimbursementBatchStatusConfirmed,
+			"confirmed_by": userID,
+			"confirmed_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Expense reimbursement batch confirmed",
+		zap.String("batch_id", batchID),
+		zap.String("user_id", userID))
+
+	return s.GetBatch(ctx, batchID)
+}
+
+// CancelBatch 振込バッチを取り消し、経費申請を再び精算対象に戻す
+func (s *expenseReimbursementService) CancelBatch(ctx context.Context, batchID, userID string) (*dto.ReimbursementBatchDTO, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		batch, err := lockDraftReimbursementBatch(tx, batchID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Expense{}).
+			Where("payment_batch_id = ?", batch.ID).
+			Update("payment_batch_id", nil).Error; err != nil {
+			return fmt.Errorf("経費申請の更新に失敗しました: %w", err)
+		}
+		now := time.Now()
+		return tx.Model(batch).Updates(map[string]interface{}{
+			"status":       model.ReimbursementBatchStatusCancelled,
+			"cancelled_by": userID,
+			"cancelled_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Expense reimbursement batch cancelled",
+		zap.String("batch_id", batchID),
+		zap.String("user_id", userID))
+
+	return s.GetBatch(ctx, batchID)
+}
+
+// ListStatements 振込バッチの全社員の精算明細
+func (s *expenseReimbursementService) ListStatements(ctx context.Context, batchID string) ([]dto.ReimbursementStatementDTO, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := findReimbursementBatch(db, batchID); err != nil {
+		return nil, err
+	}
+	items, err := s.batchItems(db, "i.batch_id = ?", batchID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statements(db, batchID, items)
+}
+
+// GetStatement 社員1人分の精算明細
+func (s *expenseReimbursementService) GetStatement(ctx context.Context, batchID, userID string) (*dto.ReimbursementStatementDTO, error) {
+	db := s.db.WithContext(ctx)
+	items, err := s.batchItems(db, "i.batch_id = ? AND i.user_id = ?", batchID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "精算明細が見つかりません").
+			WithDetail("batch_id", batchID)
+	}
+	statements, err := s.statements(db, batchID, items)
+	if err != nil {
+		return nil, err
+	}
+	return &statements[0], nil
+}
+
+// ListUserReimbursements 社員の振込一覧（取り消したバッチを除く、振込日の新しい順）
+func (s *expenseReimbursementService) ListUserReimbursements(ctx context.Context, userID string) ([]dto.ReimbursementItemDTO, error) {
+	return s.batchItems(s.db.WithContext(ctx), "i.user_id = ? AND b.status <> ?", userID, model.ReimbursementBatchStatusCancelled)
+}
+
+// batchItems 振込バッチの社員ごとの振込を社員名付きで取得
+func (s *expenseReimbursementService) batchItems(db *gorm.DB, where string, args ...interface{}) ([]dto.ReimbursementItemDTO, error) {
+	var rows []struct {
+		model.ExpenseReimbursementItem
+		PaymentDate time.Time
+		BatchStatus string
+		UserName    string
+	}
+	err := db.Table("expense_reimbursement_items AS i").
+		Select("i.*, b.payment_date, b.status AS batch_status, "+reimbursementUserName+" AS user_name").
+		Joins("JOIN expense_reimbursement_batches b ON b.id = i.batch_id").
+		Joins("LEFT JOIN users u ON u.id = i.user_id").
+		Where(where, args...).
+		Order("b.payment_date DESC, user_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("振込バッチの明細の取得に失敗しました: %w", err)
+	}
+
+	items := make([]dto.ReimbursementItemDTO, len(rows))
+	for i, row := range rows {
+		items[i] = dto.ReimbursementItemDTO{
+			BatchID:       row.BatchID,
+			PaymentDate:   row.PaymentDate.Format("2006-01-02"),
+			BatchStatus:   row.BatchStatus,
+			UserID:        row.UserID,
+			UserName:      row.UserName,
+			Amount:        row.Amount,
+			ExpenseCount:  row.ExpenseCount,
+			BankName:      row.BankName,
+			BranchName:    row.BranchName,
+			AccountType:   row.AccountType,
+			AccountNumber: model.MaskAccountNumber(row.AccountNumberLast4),
+		}
+	}
+	return items, nil
+}
+
+// statements 社員ごとの振込に精算した経費を付けて精算明細にする
+func (s *expenseReimbursementService) statements(db *gorm.DB, batchID string, items []dto.ReimbursementItemDTO) ([]dto.ReimbursementStatementDTO, error) {
+	var rows []struct {
+		ID           string
+		UserID       string
+		ExpenseDate  time.Time
+		Title        string
+		CategoryName *string
+		Category     string
+		Amount       int64
+		ApprovedAt   *time.Time
+	}
+	err := db.Table("expenses AS e").
+		Select("e.id, e.user_id, e.expense_date, e.title, ec.name AS category_name, e.category, e.amount, e.approved_at").
+		Joins("LEFT JOIN expense_categories ec ON ec.id = e.category_id").
+		Where("e.payment_batch_id = ?", batchID).
+		Order("e.expense_date, e.created_at").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("精算した経費の取得に失敗しました: %w", err)
+	}
+
+	lines := make(map[string][]dto.ReimbursementStatementLineDTO)
+	for _, row := range rows {
+		category := row.Category
+		if row.CategoryName != nil {
+			category = *row.CategoryName
+		}
+		lines[row.UserID] = append(lines[row.UserID], dto.ReimbursementStatementLineDTO{
+			ExpenseID:    row.ID,
+			ExpenseDate:  row.ExpenseDate,
+			Title:        row.Title,
+			CategoryName: category,
+			Amount:       row.Amount,
+			ApprovedAt:   row.ApprovedAt,
+		})
+	}
+
+	statements := make([]dto.ReimbursementStatementDTO, len(items))
+	for i, item := range items {
+		statements[i] = dto.ReimbursementStatementDTO{
+			ReimbursementItemDTO: item,
+			Expenses:             lines[item.UserID],
+		}
+	}
+	return statements, nil
+}
+
+// bankAccountToDTO 振込先口座を復号してDTOに変換（口座番号は下4桁のみ）
+func (s *expenseReimbursementService) bankAccountToDTO(account *model.ProfileBankAccount) (*dto.BankAccountDTO, error) {
+	holder, err := s.encryption.Decrypt(account.AccountHolderEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.BankAccountDTO{
+		BankCode:      account.BankCode,
+		BankName:      account.BankName,
+		BranchCode:    account.BranchCode,
+		BranchName:    account.BranchName,
+		AccountType:   account.AccountType,
+		AccountNumber: account.MaskedAccountNumber(),
+		AccountHolder: holder,
+		UpdatedAt:     account.UpdatedAt,
+	}, nil
+}
+
+// findReimbursementBatch 振込バッチを取得
+func findReimbursementBatch(db *gorm.DB, batchID string) (*model.ExpenseReimbursementBatch, error) {
+	var batch model.ExpenseReimbursementBatch
+	if err := db.Where("id = ?", batchID).First(&batch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "振込バッチが見つかりません").
+				WithDetail("batch_id", batchID)
+		}
+		return nil, fmt.Errorf("振込バッチの取得に失敗しました: %w", err)
+	}
+	return &batch, nil
+}
+
+// lockDraftReimbursementBatch 確定・取消前の振込バッチを行ロックして取得
+func lockDraftReimbursementBatch(tx *gorm.DB, batchID string) (*model.ExpenseReimbursementBatch, error) {
+	batch, err := findReimbursementBatch(tx.Clauses(clause.Locking{Strength: "UPDATE"}), batchID)
+	if err != nil {
+		return nil, err
+	}
+	if !batch.IsDraft() {
+		return nil, accountingerrors.ErrReimbursementBatchNotDraftError(batch.ID, string(batch.Status))
+	}
+	return batch, nil
+}
+
+// reimbursementUserNames 社員名を取得
+func reimbursementUserNames(db *gorm.DB, userIDs []string) (map[string]string, error) {
+	var rows []struct {
+		ID       string
+		UserName string
+	}
+	if err := db.Table("users AS u").
+		Select("u.id, "+reimbursementUserName+" AS user_name").
+		Where("u.id IN ?", userIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("社員名の取得に失敗しました: %w", err)
+	}
+	names := make(map[string]string, len(rows))
+	for _, row := range rows {
+		names[row.ID] = row.UserName
+	}
+	return names, nil
+}
+
+// reimbursementBatchToDTO 振込バッチをDTOに変換
+func reimbursementBatchToDTO(batch *model.ExpenseReimbursementBatch) dto.ReimbursementBatchDTO {
+	return dto.ReimbursementBatchDTO{
+		ID:            batch.ID,
+		PaymentDate:   batch.PaymentDate.Format("2006-01-02"),
+		Status:        string(batch.Status),
+		TotalAmount:   batch.TotalAmount,
+		EmployeeCount: batch.EmployeeCount,
+		ExpenseCount:  batch.ExpenseCount,
+		CreatedBy:     batch.CreatedBy,
+		CreatedAt:     batch.CreatedAt,
+		ConfirmedAt:   batch.ConfirmedAt,
+		CancelledAt:   batch.CancelledAt,
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/internal/utils"
+)
+
+// setupReimbursementTest 経費・振込先口座・振込バッチのテーブルを持つSQLiteで経費精算サービスを作成
+// 社員1は振込先口座を登録済みで承認済みの経費が2件、社員2は口座が未登録で承認済みの経費が1件ある
+func setupReimbursementTest(t *testing.T) (*expenseReimbursementService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.Expense{}, &model.ExpenseCategoryMaster{}, &model.User{}, &model.ProfileBankAccount{},
+		&model.ExpenseReimbursementBatch{}, &model.ExpenseReimbursementItem{}))
+
+	require.NoError(t, db.Omit(clause.Associations).Create([]*model.User{
+		{ID: "user-1", LastName: "山田", FirstName: "太郎", Email: "yamada@example.com", EmployeeNumber: "000001"},
+		{ID: "user-2", LastName: "鈴木", FirstName: "花子", Email: "suzuki@example.com", EmployeeNumber: "000002"},
+	}).Error)
+
+	encryption, err := utils.NewTokenEncryption("reimbursement-test-key")
+	require.NoError(t, err)
+	s := &expenseReimbursementService{
+		db:         db,
+		encryption: encryption,
+		company: &config.CompanyConfig{
+			TransferRequesterCode: "1234567890",
+			TransferRequesterName: "カ）デュエスク",
+			TransferBankCode:      "0001",
+			TransferBankName:      "ミズホ",
+			TransferBranchCode:    "100",
+			TransferBranchName:    "ホンテン",
+			TransferAccountType:   "1",
+			TransferAccountNumber: "7654321",
+		},
+		logger: zap.NewNop(),
+	}
+
+	_, err = s.SaveBankAccount(context.Background(), "user-1", &dto.BankAccountRequest{
+		BankCode:      "0005",
+		BankName:      "ミツビシユーエフジェイ",
+		BranchCode:    "001",
+		BranchName:    "ホンテン",
+		AccountType:   "1",
+		AccountNumber: "1234567",
+		AccountHolder: "ヤマダ タロウ",
+	})
+	require.NoError(t, err)
+
+	createReimbursementExpense(t, db, "expense-1", "user-1", 3000, model.ExpenseStatusApproved)
+	createReimbursementExpense(t, db, "expense-2", "user-1", 2000, model.ExpenseStatusApproved)
+	createReimbursementExpense(t, db, "expense-3", "user-2", 1500, model.ExpenseStatusApproved)
+	return s, db
+}
+
+// createReimbursementExpense 経費申請を登録
+func createReimbursementExpense(t *testing.T, db *gorm.DB, id, userID string, amount int, status model.ExpenseStatus) *model.Expense {
+	approvedAt := time.Now().AddDate(0, 0, -1)
+	expense := &model.Expense{
+		ID:          id,
+		UserID:      userID,
+		Title:       "客先訪問",
+		Category:    model.ExpenseCategoryTransport,
+		Amount:      amount,
+		ExpenseDate: time.Now().AddDate(0, 0, -3),
+		Status:      status,
+		ApprovedAt:  &approvedAt,
+	}
+	require.NoError(t, db.Omit(clause.Associations).Create(expense).Error)
+	return expense
+}
+
+func findReimbursementExpense(t *testing.T, db *gorm.DB, id string) *model.Expense {
+	var expense model.Expense
+	require.NoError(t, db.Where("id = ?", id).First(&expense).Error)
+	return &expense
+}
+
+func reimbursementBatchRequest() *dto.CreateReimbursementBatchRequest {
+	return &dto.CreateReimbursementBatchRequest{PaymentDate: time.Now().AddDate(0, 0, 7).Format("2006-01-02")}
+}
+
+func TestExpenseReimbursementService_CreateBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("口座を登録した社員の承認済みの経費を社員ごとにまとめ、未登録の社員は除外する", func(t *testing.T) {
+		s, db := setupReimbursementTest(t)
+		// 下書きの経費と仮払金の精算に紐付けた経費は振込で精算しない
+		createReimbursementExpense(t, db, "expense-4", "user-1", 9000, model.ExpenseStatusDraft)
+		advanced := createReimbursementExpense(t, db, "expense-5", "user-1", 8000, model.ExpenseStatusApproved)
+		require.NoError(t, db.Model(advanced).Update("cash_advance_id", "advance-1").Error)
+
+		batch, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, string(model.ReimbursementBatchStatusDraft), batch.Status)
+		assert.Equal(t, int64(5000), batch.TotalAmount)
+		assert.Equal(t, 1, batch.EmployeeCount)
+		assert.Equal(t, 2, batch.ExpenseCount)
+		require.Len(t, batch.Items, 1)
+		assert.Equal(t, "山田 太郎", batch.Items[0].UserName)
+		assert.Equal(t, "***4567", batch.Items[0].AccountNumber)
+		require.Len(t, batch.Skipped, 1)
+		assert.Equal(t, "user-2", batch.Skipped[0].UserID)
+		assert.Equal(t, int64(1500), batch.Skipped[0].Amount)
+
+		assert.Equal(t, batch.ID, *findReimbursementExpense(t, db, "expense-1").PaymentBatchID)
+		assert.Nil(t, findReimbursementExpense(t, db, "expense-3").PaymentBatchID)
+		assert.Nil(t, findReimbursementExpense(t, db, "expense-5").PaymentBatchID)
+
+		// バッチに含めた経費は次のバッチの対象にならない
+		_, err = s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrReimbursementNoExpenses, accErr.Code)
+	})
+
+	t.Run("過去の振込日は指定できない", func(t *testing.T) {
+		s, _ := setupReimbursementTest(t)
+		req := &dto.CreateReimbursementBatchRequest{PaymentDate: time.Now().AddDate(0, 0, -1).Format("2006-01-02")}
+
+		_, err := s.CreateBatch(ctx, req, "admin-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrAccountingInvalidRequest, accErr.Code)
+	})
+}
+
+func TestExpenseReimbursementService_GenerateTransferFile(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupReimbursementTest(t)
+	batch, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+	require.NoError(t, err)
+
+	t.Run("復号した口座へ振り込む全銀協フォーマットのファイルを作成する", func(t *testing.T) {
+		file, err := s.GenerateTransferFile(ctx, batch.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "sogofurikomi_"+strings.ReplaceAll(batch.PaymentDate, "-", "")+".txt", file.FileName)
+		lines := strings.Split(strings.TrimSuffix(string(file.Content), "\r\n"), "\r\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, "0005", lines[1][1:5])
+		assert.Equal(t, "11234567", lines[1][42:50])
+		assert.Equal(t, "0000005000", lines[1][80:90])
+	})
+
+	t.Run("振込元口座が未設定の場合は作成しない", func(t *testing.T) {
+		company := *s.company
+		company.TransferAccountNumber = ""
+		unconfigured := *s
+		unconfigured.company = &company
+
+		_, err := unconfigured.GenerateTransferFile(ctx, batch.ID)
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrReimbursementTransferInvalid, accErr.Code)
+	})
+}
+
+func TestExpenseReimbursementService_ConfirmBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("経費申請を振込日付で支払済みにし、確定後は取り消せない", func(t *testing.T) {
+		s, db := setupReimbursementTest(t)
+		batch, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+		require.NoError(t, err)
+
+		confirmed, err := s.ConfirmBatch(ctx, batch.ID, "admin-2")
+
+		require.NoError(t, err)
+		assert.Equal(t, string(model.ReimbursementBatchStatusConfirmed), confirmed.Status)
+		for _, id := range []string{"expense-1", "expense-2"} {
+			expense := findReimbursementExpense(t, db, id)
+			assert.Equal(t, model.ExpenseStatusPaid, expense.Status)
+			require.NotNil(t, expense.PaidAt)
+			assert.Equal(t, batch.PaymentDate, expense.PaidAt.Format("2006-01-02"))
+			assert.Equal(t, 2, expense.Version)
+		}
+
+		_, err = s.CancelBatch(ctx, batch.ID, "admin-2")
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrReimbursementBatchNotDraft, accErr.Code)
+	})
+
+	t.Run("バッチ作成後に状態が変わった経費申請があれば確定しない", func(t *testing.T) {
+		s, db := setupReimbursementTest(t)
+		batch, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&model.Expense{}).Where("id = ?", "expense-2").Update("status", model.ExpenseStatusCancelled).Error)
+
+		_, err = s.ConfirmBatch(ctx, batch.ID, "admin-2")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrAccountingDataConflict, accErr.Code)
+		assert.Equal(t, model.ExpenseStatusApproved, findReimbursementExpense(t, db, "expense-1").Status)
+	})
+}
+
+func TestExpenseReimbursementService_CancelBatch(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupReimbursementTest(t)
+	batch, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+	require.NoError(t, err)
+
+	cancelled, err := s.CancelBatch(ctx, batch.ID, "admin-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, string(model.ReimbursementBatchStatusCancelled), cancelled.Status)
+	assert.Nil(t, findReimbursementExpense(t, db, "expense-1").PaymentBatchID)
+
+	// 取り消したバッチの経費は次のバッチで精算し、社員の振込一覧には取り消したバッチを出さない
+	recreated, err := s.CreateBatch(ctx, reimbursementBatchRequest(), "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), recreated.TotalAmount)
+
+	items, err := s.ListUserReimbursements(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, recreated.ID, items[0].BatchID)
+}
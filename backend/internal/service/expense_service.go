@@ -231,6 +231,7 @@ func (s *expenseService) Create(ctx context.Context, userID string, req *dto.Cre
 		Status:      model.ExpenseStatusDraft,
 		Version:     1,
 	}
+	expense.ProjectID = normalizeOptionalID(req.ProjectID)
 
 	// トランザクション内で作成
 	err = s.db.Transaction(func(tx *gorm.DB) error {
@@ -422,6 +423,9 @@ func (s *expenseService) Update(ctx context.Context, id string, userID string, r
 	if req.Description != nil {
 		expense.Description = *req.Description
 	}
+	if req.ProjectID != nil {
+		expense.ProjectID = normalizeOptionalID(req.ProjectID)
+	}
 	// 複数レシートの更新は別途expense_receiptsテーブルで管理
 
 	// バージョンチェック（楽観的ロック）
@@ -943,6 +947,9 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 		return nil, dto.NewExpenseError(dto.ErrCodeReceiptRequired, "領収書の添付が必要です")
 	}
 
+	// 重複検出用に領収書のハッシュを補完
+	s.backfillReceiptHashes(ctx, receipts)
+
 	// 最終的な上限チェック
 	limitCheck, err := s.CheckLimits(ctx, userID, expense.Amount, expense.ExpenseDate)
 	if err != nil {
@@ -998,6 +1005,39 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 		deadline := deadlineSetting.CalculateDeadline(time.Now())
 		expense.SetDeadline(deadline)
 
+		// 重複の可能性を検出
+		duplicateCount, err := s.flagDuplicates(ctx, tx, expense, receipts)
+		if err != nil {
+			return err
+		}
+
+		// 承認ルールを判定（自動承認のルールは承認済みにする）
+		rule, err := selectApprovalRule(ctx, tx, s.logger, model.ExpenseRoutingTarget{
+			Amount:       expense.Amount,
+			CategoryID:   expense.CategoryID,
+			DepartmentID: departmentID,
+			ProjectID:    expense.ProjectID,
+		})
+		if err != nil {
+			return err
+		}
+		if rule != nil && rule.AutoApprove && duplicateCount > 0 {
+			// 重複の可能性がある場合は自動承認せず、承認者設定による承認フローで確認する
+			s.logger.Info("Skip auto approval for possible duplicate expense",
+				zap.String("expense_id", expense.ID),
+				zap.String("approval_rule_id", rule.ID))
+			rule = nil
+		}
+		expense.ApprovalRuleID = nil
+		if rule != nil {
+			expense.ApprovalRuleID = &rule.ID
+			if rule.AutoApprove {
+				now := time.Now()
+				expense.Status = model.ExpenseStatusApproved
+				expense.ApprovedAt = &now
+			}
+		}
+
 		// 経費申請を更新
 		if err := txExpenseRepo.Update(ctx, expense); err != nil {
 			return fmt.Errorf("経費申請の更新に失敗しました: %w", err)
@@ -1008,17 +1048,27 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 			zap.String("expense_id", expense.ID),
 			zap.Int("amount", expense.Amount))
 
-		if err := txApprovalRepo.CreateApprovalFlow(ctx, expense.ID, expense.Amount, txApproverSettingRepo); err != nil {
-			s.logger.Error("CreateApprovalFlow failed",
-				zap.Error(err),
-				zap.String("expense_id", expense.ID))
-
-			// 承認者未設定エラーの場合は、ユーザーフレンドリーなメッセージをそのまま返す
-			var expenseErr *dto.ExpenseError
-			if errors.As(err, &expenseErr) && expenseErr.Code == dto.ErrCodeNoApproversConfigured {
+		switch {
+		case rule != nil && rule.AutoApprove:
+			// 承認フローは不要
+		case rule != nil:
+			if err := createRuleApprovalFlow(ctx, tx, s.logger, model.ExpenseApproval{ExpenseID: &expense.ID}, user, rule); err != nil {
 				return err
 			}
-			return fmt.Errorf("承認フローの作成に失敗しました: %w", err)
+		default:
+			// ルールに一致しない場合は承認者設定による従来の承認フロー
+			if err := txApprovalRepo.CreateApprovalFlow(ctx, expense.ID, expense.Amount, txApproverSettingRepo); err != nil {
+				s.logger.Error("CreateApprovalFlow failed",
+					zap.Error(err),
+					zap.String("expense_id", expense.ID))
+
+				// 承認者未設定エラーの場合は、ユーザーフレンドリーなメッセージをそのまま返す
+				var expenseErr *dto.ExpenseError
+				if errors.As(err, &expenseErr) && expenseErr.Code == dto.ErrCodeNoApproversConfigured {
+					return err
+				}
+				return fmt.Errorf("承認フローの作成に失敗しました: %w", err)
+			}
 		}
 
 		// 月次集計を更新
@@ -1028,6 +1078,13 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 				zap.String("expense_id", expense.ID))
 			// エラーでもトランザクションは継続（集計は非クリティカル）
 		}
+		if expense.Status == model.ExpenseStatusApproved {
+			if err := s.updateMonthlySummary(ctx, tx, expense.UserID, expense.ExpenseDate, expense.Amount, "approve"); err != nil {
+				s.logger.Warn("Failed to update monthly summary",
+					zap.Error(err),
+					zap.String("expense_id", expense.ID))
+			}
+		}
 
 		return nil
 	})
@@ -1048,9 +1105,10 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 	if s.auditService != nil {
 		resourceIDStr := id
 		additionalInfo := map[string]interface{}{
-			"amount":      expense.Amount,
-			"status":      expense.Status,
-			"category_id": expense.CategoryID,
+			"amount":           expense.Amount,
+			"status":           expense.Status,
+			"category_id":      expense.CategoryID,
+			"approval_rule_id": expense.ApprovalRuleID,
 		}
 		if err := s.auditService.LogActivity(ctx, LogActivityParams{
 			UserID:       userID,
@@ -1073,20 +1131,22 @@ func (s *expenseService) SubmitExpense(ctx context.Context, id string, userID st
 		zap.String("expense_id", expense.ID),
 		zap.String("user_id", userID))
 
-	// 承認者を取得して通知を送信
+	// 承認者を取得して通知を送信（自動承認の場合は申請者に承認を通知）
 	pendingApprovals, err := s.approvalRepo.GetPendingApprovals(ctx, expense.ID)
-	if err != nil {
+	if expense.Status == model.ExpenseStatusApproved {
+		if err := s.notificationService.NotifyExpenseApproved(ctx, expense, "自動承認", true); err != nil {
+			s.logger.Error("Failed to send expense auto-approved notification",
+				zap.Error(err),
+				zap.String("expense_id", expense.ID))
+		}
+	} else if err != nil {
 		s.logger.Error("Failed to get pending approvals for notification",
 			zap.Error(err),
 			zap.String("expense_id", expense.ID))
 		// 通知エラーは無視して続行
 	} else {
-		approverIDs := make([]string, 0, len(pendingApprovals))
-		for _, approval := range pendingApprovals {
-			if approval.ApproverID != "" {
-				approverIDs = append(approverIDs, approval.ApproverID)
-			}
-		}
+		// 現在の段階の承認者（不在の場合は代理承認者も）のみに通知する
+		approverIDs := withDelegateApproverIDs(ctx, s.db, s.logger, currentStepApproverIDs(pendingApprovals))
 
 		// 承認者に通知を送信
 		if len(approverIDs) > 0 {
@@ -1360,13 +1420,10 @@ func (s *expenseService) ApproveExpense(ctx context.Context, id string, approver
 			return fmt.Errorf("承認履歴の取得に失敗しました: %w", err)
 		}
 
-		// 承認者が承認権限を持っているかチェック
-		var targetApproval *model.ExpenseApproval
-		for i := range approvals {
-			if approvals[i].ApproverID == approverID && approvals[i].Status == model.ApprovalStatusPending {
-				targetApproval = &approvals[i]
-				break
-			}
+		// 承認者が承認権限を持っているかチェック（代理承認者は不在の承認者に代わって承認できる）
+		targetApproval, err := findActionableApproval(ctx, tx, s.logger, expense.UserID, approvals, approverID)
+		if err != nil {
+			return err
 		}
 
 		if targetApproval == nil {
@@ -1380,9 +1437,14 @@ func (s *expenseService) ApproveExpense(ctx context.Context, id string, approver
 			}
 		}
 
+		// 重複の可能性がある場合は承認者の理由が必要
+		if err := s.resolveDuplicateFlags(ctx, tx, expense.ID, approverID, req.DuplicateOverrideReason); err != nil {
+			return err
+		}
+
 		// 承認処理
 		targetApproval.Approve(req.Comment)
-		if err := txApprovalRepo.UpdateApprovalStatus(ctx, targetApproval.ID, targetApproval.Status, req.Comment, approverID); err != nil {
+		if err := updateApprovalStatusBy(ctx, txApprovalRepo, targetApproval, req.Comment, approverID); err != nil {
 			return fmt.Errorf("承認ステータスの更新に失敗しました: %w", err)
 		}
 
@@ -1426,6 +1488,11 @@ func (s *expenseService) ApproveExpense(ctx context.Context, id string, approver
 			zap.Error(err),
 			zap.String("expense_id", id),
 			zap.String("approver_id", approverID))
+		// 重複フラグの確認理由が未入力の場合は、そのエラーをそのまま返す
+		var expenseErr *dto.ExpenseError
+		if errors.As(err, &expenseErr) && expenseErr.Code == dto.ErrCodeDuplicateOverrideRequired {
+			return nil, err
+		}
 		return nil, dto.NewExpenseError(dto.ErrCodeInternalError, "経費申請の承認に失敗しました")
 	}
 
@@ -1437,6 +1504,9 @@ func (s *expenseService) ApproveExpense(ctx context.Context, id string, approver
 			"amount":  expense.Amount,
 			"status":  expense.Status,
 		}
+		if req.DuplicateOverrideReason != "" {
+			additionalInfo["duplicate_override_reason"] = req.DuplicateOverrideReason
+		}
 		if err := s.auditService.LogActivity(ctx, LogActivityParams{
 			UserID:       approverID,
 			Action:       model.AuditActionExpenseApprove,
@@ -1489,12 +1559,7 @@ func (s *expenseService) ApproveExpense(ctx context.Context, id string, approver
 						zap.Error(err),
 						zap.String("expense_id", expense.ID))
 				} else if len(pendingApprovals) > 0 {
-					nextApproverIDs := make([]string, 0, len(pendingApprovals))
-					for _, approval := range pendingApprovals {
-						if approval.ApproverID != "" {
-							nextApproverIDs = append(nextApproverIDs, approval.ApproverID)
-						}
-					}
+					nextApproverIDs := withDelegateApproverIDs(ctx, s.db, s.logger, currentStepApproverIDs(pendingApprovals))
 
 					if len(nextApproverIDs) > 0 {
 						if err := s.notificationService.NotifyExpenseSubmitted(ctx, &expenseWithDetails.Expense, nextApproverIDs); err != nil {
@@ -1555,13 +1620,10 @@ func (s *expenseService) RejectExpense(ctx context.Context, id string, approverI
 			return fmt.Errorf("承認履歴の取得に失敗しました: %w", err)
 		}
 
-		// 承認者が却下権限を持っているかチェック
-		var targetApproval *model.ExpenseApproval
-		for i := range approvals {
-			if approvals[i].ApproverID == approverID && approvals[i].Status == model.ApprovalStatusPending {
-				targetApproval = &approvals[i]
-				break
-			}
+		// 承認者が却下権限を持っているかチェック（代理承認者は不在の承認者に代わって却下できる）
+		targetApproval, err := findActionableApproval(ctx, tx, s.logger, expense.UserID, approvals, approverID)
+		if err != nil {
+			return err
 		}
 
 		if targetApproval == nil {
@@ -1570,7 +1632,7 @@ func (s *expenseService) RejectExpense(ctx context.Context, id string, approverI
 
 		// 却下処理
 		targetApproval.Reject(req.Comment)
-		if err := txApprovalRepo.UpdateApprovalStatus(ctx, targetApproval.ID, targetApproval.Status, req.Comment, approverID); err != nil {
+		if err := updateApprovalStatusBy(ctx, txApprovalRepo, targetApproval, req.Comment, approverID); err != nil {
 			return fmt.Errorf("承認ステータスの更新に失敗しました: %w", err)
 		}
 
@@ -1678,8 +1740,19 @@ func (s *expenseService) GetPendingApprovals(ctx context.Context, approverID str
 	pendingStatus := string(model.ApprovalStatusPending)
 	filter.Status = &pendingStatus
 
+	// 代理承認を任されている承認者の承認待ちも含める
+	approverIDs := []string{approverID}
+	delegatorIDs, err := delegatorIDsForDelegate(ctx, s.db, s.logger, approverID, model.ApprovalDelegationScopeExpense)
+	if err != nil {
+		s.logger.Warn("Failed to get approval delegations",
+			zap.Error(err),
+			zap.String("approver_id", approverID))
+	} else {
+		approverIDs = append(approverIDs, delegatorIDs...)
+	}
+
 	// 承認待ち一覧を取得
-	approvals, total, err := s.approvalRepo.GetPendingByApproverID(ctx, approverID, filter)
+	approvals, total, err := s.approvalRepo.GetPendingByApproverIDs(ctx, approverIDs, filter)
 	if err != nil {
 		s.logger.Error("Failed to get pending approvals",
 			zap.Error(err),
@@ -1690,17 +1763,29 @@ func (s *expenseService) GetPendingApprovals(ctx context.Context, approverID str
 	// レスポンスを構築
 	items := make([]dto.ApprovalItemResponse, 0, len(approvals))
 	for _, approval := range approvals {
+		// 仮払金の承認
+		if approval.CashAdvanceID != nil {
+			if item, ok := cashAdvanceApprovalItem(&approval, approverID); ok {
+				items = append(items, item)
+			}
+			continue
+		}
+		if approval.ExpenseID == nil {
+			continue
+		}
+
 		// 経費申請詳細を取得
-		expense, err := s.expenseRepo.GetDetailByID(ctx, approval.ExpenseID)
+		expense, err := s.expenseRepo.GetDetailByID(ctx, *approval.ExpenseID)
 		if err != nil {
 			s.logger.Warn("Failed to get expense detail",
 				zap.Error(err),
-				zap.String("expense_id", approval.ExpenseID))
+				zap.String("expense_id", *approval.ExpenseID))
 			continue
 		}
 
 		item := dto.ApprovalItemResponse{
 			ApprovalID:    approval.ID,
+			RequestType:   dto.ApprovalRequestTypeExpense,
 			ExpenseID:     expense.ID,
 			Title:         expense.Title,
 			Amount:        expense.Amount,
@@ -1721,6 +1806,28 @@ func (s *expenseService) GetPendingApprovals(ctx context.Context, approverID str
 			}
 		}
 
+		// 代理で承認する場合は本来の承認者を設定（自分の経費申請は代理で承認できない）
+		if approval.ApproverID != approverID {
+			if expense.UserID == approverID {
+				continue
+			}
+			item.OnBehalfOf = &dto.UserSummary{
+				ID:   approval.ApproverID,
+				Name: approval.Approver.Name,
+			}
+		}
+
+		// 重複の可能性を設定
+		duplicateFlags, requiresOverride, err := s.duplicateFlagResponses(ctx, &expense.Expense)
+		if err != nil {
+			s.logger.Warn("Failed to get expense duplicate flags",
+				zap.Error(err),
+				zap.String("expense_id", expense.ID))
+		} else {
+			item.DuplicateFlags = duplicateFlags
+			item.RequiresDuplicateOverride = requiresOverride
+		}
+
 		// 前の承認情報を取得（2段階承認の場合）
 		if approval.ApprovalOrder > 1 {
 			for _, prevApproval := range expense.Approvals {
@@ -1805,6 +1912,17 @@ func (s *expenseService) CompleteUpload(ctx context.Context, userID string, req
 		CreatedAt:  time.Now(),
 	}
 
+	// 提出済みの経費申請に同じ領収書がないかチェック（提出時に改めて検出する）
+	possibleDuplicate, err := s.isPossibleDuplicateUpload(ctx, userID, s.computeReceiptHash(ctx, req.S3Key))
+	if err != nil {
+		s.logger.Warn("Failed to check duplicate receipt",
+			zap.Error(err),
+			zap.String("s3_key", req.S3Key))
+	} else if possibleDuplicate {
+		response.PossibleDuplicate = true
+		response.DuplicateMessage = "提出済みの経費申請に同じ領収書が添付されている可能性があります"
+	}
+
 	s.logger.Info("File upload completed successfully",
 		zap.String("s3_key", req.S3Key),
 		zap.String("user_id", userID),
@@ -2903,6 +3021,9 @@ func (s *expenseService) getApproverSetting(ctx context.Context) (*model.Expense
 
 // CreateWithReceipts 複数領収書を含む経費申請を作成
 func (s *expenseService) CreateWithReceipts(ctx context.Context, userID string, req *dto.CreateExpenseWithReceiptsRequest) (*dto.ExpenseWithReceiptsResponse, error) {
+	// 重複検出用に領収書のハッシュを計算
+	receiptHashes := s.computeReceiptHashes(ctx, req.Receipts)
+
 	// トランザクション開始
 	var result *dto.ExpenseWithReceiptsResponse
 	err := s.db.Transaction(func(tx *gorm.DB) error {
@@ -2962,6 +3083,7 @@ func (s *expenseService) CreateWithReceipts(ctx context.Context, userID string,
 			Status:      model.ExpenseStatusDraft,
 			Version:     1,
 		}
+		expense.ProjectID = normalizeOptionalID(req.ProjectID)
 
 		// トランザクション用のリポジトリを作成
 		txExpenseRepo := repository.NewExpenseRepository(tx, s.logger)
@@ -2984,6 +3106,7 @@ func (s *expenseService) CreateWithReceipts(ctx context.Context, userID string,
 				ContentType:  receiptReq.ContentType,
 				DisplayOrder: i + 1,
 			}
+			applyReceiptHash(receipts[i], receiptHashes)
 		}
 
 		if err := txReceiptRepo.CreateBatch(ctx, receipts); err != nil {
@@ -3060,6 +3183,9 @@ func (s *expenseService) CreateWithReceipts(ctx context.Context, userID string,
 
 // UpdateWithReceipts 複数領収書を含む経費申請を更新
 func (s *expenseService) UpdateWithReceipts(ctx context.Context, id string, userID string, req *dto.UpdateExpenseWithReceiptsRequest) (*dto.ExpenseWithReceiptsResponse, error) {
+	// 重複検出用に領収書のハッシュを計算
+	receiptHashes := s.computeReceiptHashes(ctx, req.Receipts)
+
 	var result *dto.ExpenseWithReceiptsResponse
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		// 既存の経費申請を取得
@@ -3102,6 +3228,9 @@ func (s *expenseService) UpdateWithReceipts(ctx context.Context, id string, user
 		if req.Description != nil {
 			expense.Description = *req.Description
 		}
+		if req.ProjectID != nil {
+			expense.ProjectID = normalizeOptionalID(req.ProjectID)
+		}
 
 		// バージョンをインクリメント
 		expense.Version++
@@ -3134,6 +3263,7 @@ func (s *expenseService) UpdateWithReceipts(ctx context.Context, id string, user
 					ContentType:  receiptReq.ContentType,
 					DisplayOrder: i + 1,
 				}
+				applyReceiptHash(receipts[i], receiptHashes)
 			}
 
 			if err := txReceiptRepo.CreateBatch(ctx, receipts); err != nil {
@@ -12,6 +12,7 @@ import (
 
     "github.com/duesk/monstera/internal/model"
     "github.com/duesk/monstera/internal/repository"
+    "github.com/duesk/monstera/internal/testutils"
 )
 
 // setupAdminTestDB creates an in-memory sqlite DB and migrates minimal tables
@@ -20,7 +21,7 @@ func setupAdminTestDB(t *testing.T) *gorm.DB {
     assert.NoError(t, err)
 
     // migrate minimal tables needed for retrieval and LoadDetails
-    err = db.AutoMigrate(
+    err = testutils.CreateTables(db,
         &model.User{},
         &model.Expense{},
         &model.ExpenseApproval{},
@@ -14,6 +14,7 @@ import (
 
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/testutils"
 )
 
 // テスト用のデータベースをセットアップ
@@ -22,12 +23,12 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	assert.NoError(t, err)
 
 	// テーブルを作成
-	err = db.AutoMigrate(
+	err = testutils.CreateTables(db,
 		&model.User{},
 		&model.Expense{},
 		&model.ExpenseSummary{},
 		&model.ExpenseApproval{},
-		&model.ExpenseCategory{},
+		&model.ExpenseCategoryMaster{},
 		&model.ExpenseLimit{},
 		&model.ExpenseApproverSetting{},
 	)
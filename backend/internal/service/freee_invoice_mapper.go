@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// freeeTaxCodes 税区分ごとのfreee税区分コード
+var freeeTaxCodes = map[model.TaxCategory]int{
+	model.TaxCategoryStandard:   129, // 課税売上10%
+	model.TaxCategoryReduced:    156, // 課税売上8%（軽）
+	model.TaxCategoryExempt:     22,  // 輸出売上
+	model.TaxCategoryNonTaxable: 23,  // 非課税売上
+}
+
+// freeeTaxEntryMethodExclusive freeeの税計算方法（外税）
+const freeeTaxEntryMethodExclusive = "out"
+
+// BuildFreeeInvoiceRequest 請求書モデルからfreee請求書作成リクエストを組み立てる
+// 明細ごとの消費税額は請求書単位で端数処理した税率別の税額と一致するよう按分する
+func BuildFreeeInvoiceRequest(invoice *model.Invoice, companyID, partnerID int) *dto.CreateFreeeInvoiceRequest {
+	req := &dto.CreateFreeeInvoiceRequest{
+		CompanyID:      companyID,
+		IssueDate:      invoice.InvoiceDate.Format("2006-01-02"),
+		PartnerID:      partnerID,
+		InvoiceNumber:  invoice.InvoiceNumber,
+		DueDate:        invoice.DueDate.Format("2006-01-02"),
+		Notes:          invoice.Notes,
+		TaxEntryMethod: freeeTaxEntryMethodExclusive,
+	}
+	if invoice.IsCreditNote() {
+		req.Title = "請求書（赤伝）"
+	}
+
+	taxes := model.DistributeTaxToDetails(invoice.Details, invoice.TaxBreakdown())
+	for i, detail := range invoice.Details {
+		category := detail.TaxCategory
+		if category == "" {
+			category = model.TaxCategoryStandard
+		}
+		req.InvoiceContents = append(req.InvoiceContents, dto.FreeeInvoiceContentDTO{
+			Order:       i + 1,
+			Type:        "normal",
+			Qty:         detail.Quantity,
+			UnitPrice:   int(detail.UnitPrice.Round(money.RoundingHalfUp).Yen()),
+			Amount:      int(detail.Amount.Round(money.RoundingHalfUp).Yen()),
+			Vat:         int(taxes[i].Yen()),
+			ReducedVat:  category.IsReduced(),
+			Description: detail.Description,
+			TaxCode:     freeeTaxCodes[category],
+		})
+	}
+	return req
+}
@@ -0,0 +1,517 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/freee"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/utils"
+)
+
+const (
+	// freeeOAuthStateTTL OAuthのstateの有効期間
+	freeeOAuthStateTTL = 10 * time.Minute
+	// freeeTokenRefreshWindow 定期更新でトークンを前倒し更新する残り時間
+	freeeTokenRefreshWindow = 30 * time.Minute
+	// freeeTokenExpiryBuffer 期限切れとみなす余裕時間（model.FreeeSettingsと同じ）
+	freeeTokenExpiryBuffer = 5 * time.Minute
+)
+
+// freeeService freee連携サービスの実装
+type freeeService struct {
+	db           *gorm.DB
+	config       *config.FreeeConfig
+	client       *freee.Client
+	encryption   *utils.TokenEncryption
+	settingsRepo repository.FreeeSettingsRepository
+	syncLogRepo  repository.FreeSyncLogRepositoryInterface
+	invoiceRepo  repository.InvoiceRepository
+	driver       string
+	logger       *zap.Logger
+
+	// refreshLocks ユーザーごとのトークン更新ロック
+	// freeeのリフレッシュトークンは使い捨てのため、同時に更新すると片方が失効する
+	refreshLocks sync.Map
+}
+
+// NewFreeeService freee連携サービスのインスタンスを生成
+// driverはconfig.Database.Driver（mysql / postgres）
+func NewFreeeService(
+	db *gorm.DB,
+	cfg *config.FreeeConfig,
+	client *freee.Client,
+	encryption *utils.TokenEncryption,
+	settingsRepo repository.FreeeSettingsRepository,
+	syncLogRepo repository.FreeSyncLogRepositoryInterface,
+	invoiceRepo repository.InvoiceRepository,
+	driver string,
+	logger *zap.Logger,
+) FreeeServiceInterface {
+	return &freeeService{
+		db:           db,
+		config:       cfg,
+		client:       client,
+		encryption:   encryption,
+		settingsRepo: settingsRepo,
+		syncLogRepo:  syncLogRepo,
+		invoiceRepo:  invoiceRepo,
+		driver:       driver,
+		logger:       logger,
+	}
+}
+
+// GenerateAuthURL 認可画面のURLを生成
+// stateにはユーザーIDと有効期限を暗号化して埋め込み、コールバック時に検証する
+func (s *freeeService) GenerateAuthURL(ctx context.Context, userID, redirectURI string) (*dto.FreeeAuthURLResponse, error) {
+	if redirectURI == "" {
+		redirectURI = s.config.RedirectURI
+	}
+
+	state, err := s.encryption.Encrypt(fmt.Sprintf("%s|%d", userID, time.Now().Add(freeeOAuthStateTTL).Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.FreeeAuthURLResponse{
+		AuthURL: s.client.AuthorizeURL(redirectURI, state),
+		State:   state,
+	}, nil
+}
+
+// ExchangeCodeForToken 認可コードをトークンに交換して保存
+func (s *freeeService) ExchangeCodeForToken(ctx context.Context, userID string, req *dto.FreeeOAuthRequest) (*dto.FreeeOAuthResponse, error) {
+	if err := s.verifyOAuthState(req.State, userID); err != nil {
+		return nil, err
+	}
+
+	redirectURI := req.RedirectURI
+	if redirectURI == "" {
+		redirectURI = s.config.RedirectURI
+	}
+
+	token, err := s.client.ExchangeCode(ctx, req.Code, redirectURI)
+	if err != nil {
+		s.logger.Error("Failed to exchange freee authorization code", zap.String("user_id", userID), zap.Error(err))
+		return nil, accountingerrors.NewAccountingErrorWithCause(accountingerrors.ErrFreeeAuthFailed, "freeeの認証に失敗しました", err)
+	}
+
+	companies, err := s.client.GetCompanies(ctx, token.AccessToken)
+	if err != nil {
+		return nil, s.wrapAPIError(err)
+	}
+
+	existing, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 認可時に選択された事業所 → 既存設定の事業所 → 先頭の事業所の順で選ぶ
+	preferredID := token.CompanyID
+	if preferredID == 0 && existing != nil {
+		preferredID = existing.CompanyID
+	}
+	company := selectFreeeCompany(companies, preferredID)
+	if company == nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrFreeeCompanyNotSelected, "連携可能なfreee事業所がありません")
+	}
+
+	settings := existing
+	if settings == nil {
+		settings = &model.FreeeSettings{UserID: userID}
+	}
+	settings.CompanyID = company.ID
+	settings.CompanyName = freeeCompanyName(company)
+	if err := s.storeTokens(settings, token); err != nil {
+		return nil, err
+	}
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("freee connected",
+		zap.String("user_id", userID),
+		zap.Int("company_id", settings.CompanyID))
+
+	// トークン自体はクライアントに返さない
+	return &dto.FreeeOAuthResponse{
+		ExpiresIn:   token.ExpiresIn,
+		TokenType:   token.TokenType,
+		Scope:       token.Scope,
+		ExpiresAt:   settings.TokenExpiresAt,
+		CompanyID:   settings.CompanyID,
+		CompanyName: settings.CompanyName,
+	}, nil
+}
+
+// RefreshAccessToken アクセストークンを更新
+func (s *freeeService) RefreshAccessToken(ctx context.Context, userID string) error {
+	_, _, err := s.refreshToken(ctx, userID, true, "")
+	return err
+}
+
+// RevokeToken freee側でトークンを失効させる
+func (s *freeeService) RevokeToken(ctx context.Context, userID string) error {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	refreshToken, err := s.encryption.DecryptRefreshToken(settings.RefreshToken)
+	if err != nil {
+		return err
+	}
+	if err := s.client.RevokeToken(ctx, refreshToken); err != nil {
+		return s.wrapAPIError(err)
+	}
+	return nil
+}
+
+// GetFreeeSettings freee設定を取得
+func (s *freeeService) GetFreeeSettings(ctx context.Context, userID string) (*dto.FreeeSettingsDTO, error) {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return freeeSettingsToDTO(settings), nil
+}
+
+// UpdateFreeeSettings freee設定を更新
+func (s *freeeService) UpdateFreeeSettings(ctx context.Context, userID string, req *dto.UpdateFreeeSettingsRequest) error {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if req.AutoSyncEnabled != nil {
+		settings.AutoSyncEnabled = *req.AutoSyncEnabled
+	}
+	if req.SyncInterval != nil {
+		settings.SyncInterval = *req.SyncInterval
+	}
+	if req.DefaultAccountItemID != nil {
+		settings.DefaultAccountItemID = req.DefaultAccountItemID
+	}
+	if req.DefaultTaxCode != nil {
+		settings.DefaultTaxCode = *req.DefaultTaxCode
+	}
+	if req.InvoiceLayout != nil {
+		settings.InvoiceLayout = *req.InvoiceLayout
+	}
+	if req.TaxEntryMethod != nil {
+		settings.TaxEntryMethod = *req.TaxEntryMethod
+	}
+
+	if err := settings.Validate(); err != nil {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "freee設定の値が不正です")
+	}
+	return s.settingsRepo.Save(ctx, settings)
+}
+
+// DeleteFreeeSettings freee連携を解除
+// freee側の失効に失敗しても連携情報は削除する
+func (s *freeeService) DeleteFreeeSettings(ctx context.Context, userID string) error {
+	if err := s.RevokeToken(ctx, userID); err != nil {
+		var accErr *accountingerrors.AccountingError
+		if errors.As(err, &accErr) && accErr.Code == accountingerrors.ErrFreeeNotConnected {
+			return err
+		}
+		s.logger.Warn("Failed to revoke freee token", zap.String("user_id", userID), zap.Error(err))
+	}
+	return s.settingsRepo.DeleteByUserID(ctx, userID)
+}
+
+// GetConnectionStatus 接続状態を取得
+// アクセストークンの期限が切れていてもリフレッシュトークンで更新できるため、設定があれば接続中とみなす
+func (s *freeeService) GetConnectionStatus(ctx context.Context, userID string) (*dto.FreeeConnectionStatusDTO, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return &dto.FreeeConnectionStatusDTO{IsConnected: false}, nil
+	}
+
+	return &dto.FreeeConnectionStatusDTO{
+		IsConnected:    true,
+		CompanyID:      &settings.CompanyID,
+		CompanyName:    &settings.CompanyName,
+		TokenExpiresAt: &settings.TokenExpiresAt,
+		LastSyncAt:     settings.LastSyncAt,
+	}, nil
+}
+
+// TestConnection freee APIへの疎通を確認
+func (s *freeeService) TestConnection(ctx context.Context, userID string) (*dto.FreeeConnectionTestResult, error) {
+	result := &dto.FreeeConnectionTestResult{TestedAt: time.Now()}
+
+	var company *dto.FreeeCompanyDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		company, err = s.client.GetCompany(ctx, token, settings.CompanyID)
+		return err
+	})
+	if err != nil {
+		var accErr *accountingerrors.AccountingError
+		if !errors.As(err, &accErr) {
+			return nil, err
+		}
+		result.TestResult = "failed"
+		switch accErr.Code {
+		case accountingerrors.ErrFreeeNotConnected:
+			return nil, err
+		case accountingerrors.ErrFreeeAuthFailed, accountingerrors.ErrFreeeTokenRefreshFailed:
+			result.TestResult = "unauthorized"
+		}
+		result.Message = accErr.Message
+		return result, nil
+	}
+
+	name := freeeCompanyName(company)
+	result.IsConnected = true
+	result.CompanyID = &company.ID
+	result.CompanyName = &name
+	result.TestResult = "success"
+	result.Message = "freee APIへの接続に成功しました"
+	return result, nil
+}
+
+// GetCompanies アクセス可能な事業所一覧を取得
+func (s *freeeService) GetCompanies(ctx context.Context, userID string) ([]*dto.FreeeCompanyDTO, error) {
+	var companies []*dto.FreeeCompanyDTO
+	err := s.callAPI(ctx, userID, func(token string, _ *model.FreeeSettings) error {
+		var err error
+		companies, err = s.client.GetCompanies(ctx, token)
+		return err
+	})
+	return companies, err
+}
+
+// SelectCompany 連携する事業所を切り替える
+func (s *freeeService) SelectCompany(ctx context.Context, userID string, companyID int) error {
+	companies, err := s.GetCompanies(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, company := range companies {
+		if company.ID == companyID {
+			return s.settingsRepo.UpdateCompany(ctx, userID, company.ID, freeeCompanyName(company))
+		}
+	}
+	return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "指定された事業所にアクセスできません").
+		WithDetail("company_id", companyID)
+}
+
+// GetValidAccessToken 有効なアクセストークンを取得（期限切れの場合は更新する）
+func (s *freeeService) GetValidAccessToken(ctx context.Context, userID string) (string, error) {
+	token, _, err := s.refreshToken(ctx, userID, false, "")
+	return token, err
+}
+
+// IsTokenExpired トークンが期限切れかチェック
+func (s *freeeService) IsTokenExpired(tokenExpiresAt time.Time) bool {
+	return time.Now().Add(freeeTokenExpiryBuffer).After(tokenExpiresAt)
+}
+
+// ScheduleTokenRefresh 有効期限が近いトークンを前倒しで更新
+// 定期バッチから呼び出し、長期間APIを呼ばなくてもリフレッシュトークンを失効させないようにする
+func (s *freeeService) ScheduleTokenRefresh(ctx context.Context, userID string) error {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if time.Until(settings.TokenExpiresAt) > freeeTokenRefreshWindow {
+		return nil
+	}
+	return s.RefreshAccessToken(ctx, userID)
+}
+
+// callAPI 有効なアクセストークンでAPIを呼び出す
+// 401が返った場合はトークンを更新して1回だけ再試行する
+func (s *freeeService) callAPI(ctx context.Context, userID string, fn func(token string, settings *model.FreeeSettings) error) error {
+	token, settings, err := s.refreshToken(ctx, userID, false, "")
+	if err != nil {
+		return err
+	}
+
+	err = fn(token, settings)
+	var apiErr *freee.APIError
+	if errors.As(err, &apiErr) && apiErr.IsUnauthorized() {
+		s.logger.Info("freee access token rejected, refreshing", zap.String("user_id", userID))
+		if token, settings, err = s.refreshToken(ctx, userID, false, token); err != nil {
+			return err
+		}
+		err = fn(token, settings)
+	}
+	return s.wrapAPIError(err)
+}
+
+// refreshToken 必要に応じてトークンを更新し、有効なアクセストークンを返す
+// staleTokenを指定した場合、保存済みのトークンがまだそれと同じであれば期限に関係なく更新する
+func (s *freeeService) refreshToken(ctx context.Context, userID string, force bool, staleToken string) (string, *model.FreeeSettings, error) {
+	lock, _ := s.refreshLocks.LoadOrStore(userID, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	// ロック待ちの間に他のリクエストが更新している可能性があるため読み直す
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	accessToken, err := s.encryption.DecryptAccessToken(settings.AccessToken)
+	if err != nil {
+		return "", nil, err
+	}
+
+	needsRefresh := force || s.IsTokenExpired(settings.TokenExpiresAt) || (staleToken != "" && staleToken == accessToken)
+	if !needsRefresh {
+		return accessToken, settings, nil
+	}
+
+	refreshToken, err := s.encryption.DecryptRefreshToken(settings.RefreshToken)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := s.client.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		s.logger.Error("Failed to refresh freee token", zap.String("user_id", userID), zap.Error(err))
+		return "", nil, accountingerrors.NewAccountingErrorWithCause(
+			accountingerrors.ErrFreeeTokenRefreshFailed,
+			"freeeトークンの更新に失敗しました。再度freeeと連携してください",
+			err,
+		).WithUserID(userID).WithHTTPCode(401)
+	}
+
+	if err := s.storeTokens(settings, token); err != nil {
+		return "", nil, err
+	}
+	if err := s.settingsRepo.UpdateTokens(ctx, userID, settings.AccessToken, settings.RefreshToken, settings.TokenExpiresAt); err != nil {
+		return "", nil, err
+	}
+
+	s.logger.Info("freee token refreshed", zap.String("user_id", userID), zap.Time("expires_at", settings.TokenExpiresAt))
+	return token.AccessToken, settings, nil
+}
+
+// storeTokens トークンを暗号化して設定に格納
+func (s *freeeService) storeTokens(settings *model.FreeeSettings, token *freee.Token) error {
+	pair, err := s.encryption.EncryptTokenPair(&utils.TokenPair{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	})
+	if err != nil {
+		return err
+	}
+	settings.AccessToken = pair.AccessToken
+	settings.RefreshToken = pair.RefreshToken
+	settings.TokenExpiresAt = token.ExpiresAt()
+	return nil
+}
+
+// getSettings freee設定を取得（未連携の場合はエラー）
+func (s *freeeService) getSettings(ctx context.Context, userID string) (*model.FreeeSettings, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, accountingerrors.ErrFreeeNotConnectedError(userID).WithHTTPCode(404)
+	}
+	return settings, nil
+}
+
+// verifyOAuthState stateを検証
+func (s *freeeService) verifyOAuthState(state, userID string) error {
+	invalid := accountingerrors.NewAccountingError(accountingerrors.ErrFreeeAuthFailed, "認証リクエストが無効です。もう一度やり直してください")
+
+	plain, err := s.encryption.Decrypt(state)
+	if err != nil {
+		return invalid
+	}
+	stateUserID, expiresStr, ok := strings.Cut(plain, "|")
+	if !ok || stateUserID != userID {
+		return invalid
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return invalid
+	}
+	return nil
+}
+
+// wrapAPIError freee APIのエラーを経理エラーに変換
+func (s *freeeService) wrapAPIError(err error) error {
+	var apiErr *freee.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case apiErr.IsUnauthorized():
+		return accountingerrors.NewAccountingErrorWithCause(accountingerrors.ErrFreeeAuthFailed, "freee APIの認証に失敗しました", err)
+	case apiErr.IsRateLimited():
+		accErr := accountingerrors.ErrFreeeRateLimitError(apiErr.RetryAfter)
+		accErr.Cause = err
+		return accErr
+	default:
+		accErr := accountingerrors.ErrFreeeAPIErrorWithDetails(apiErr.Endpoint, apiErr.StatusCode, strings.Join(apiErr.Messages, ", "))
+		accErr.Cause = err
+		return accErr
+	}
+}
+
+// selectFreeeCompany 事業所一覧から連携先を選ぶ
+func selectFreeeCompany(companies []*dto.FreeeCompanyDTO, preferredID int) *dto.FreeeCompanyDTO {
+	for _, company := range companies {
+		if company.ID == preferredID {
+			return company
+		}
+	}
+	if len(companies) > 0 {
+		return companies[0]
+	}
+	return nil
+}
+
+// freeeCompanyName 事業所の表示名を取得
+func freeeCompanyName(company *dto.FreeeCompanyDTO) string {
+	if company.DisplayName != "" {
+		return company.DisplayName
+	}
+	return company.Name
+}
+
+// freeeSettingsToDTO freee設定をDTOに変換（トークンは含めない）
+func freeeSettingsToDTO(settings *model.FreeeSettings) *dto.FreeeSettingsDTO {
+	return &dto.FreeeSettingsDTO{
+		CompanyID:            settings.CompanyID,
+		CompanyName:          settings.CompanyName,
+		TokenExpiresAt:       settings.TokenExpiresAt,
+		IsConnected:          true,
+		LastSyncAt:           settings.LastSyncAt,
+		AutoSyncEnabled:      settings.AutoSyncEnabled,
+		SyncInterval:         settings.SyncInterval,
+		DefaultAccountItemID: settings.DefaultAccountItemID,
+		DefaultTaxCode:       settings.DefaultTaxCode,
+		InvoiceLayout:        settings.InvoiceLayout,
+		TaxEntryMethod:       settings.TaxEntryMethod,
+		CreatedAt:            settings.CreatedAt,
+		UpdatedAt:            settings.UpdatedAt,
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"time"
 
 	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
 )
 
-// FreeeServiceInterface freeeサービスインターフェース（一時的な定義）
+// FreeeServiceInterface freeeサービスインターフェース
 type FreeeServiceInterface interface {
 	// OAuth認証
-	GenerateAuthURL(ctx context.Context, redirectURI string) (*dto.FreeeAuthURLResponse, error)
-	ExchangeCodeForToken(ctx context.Context, req *dto.FreeeOAuthRequest) (*dto.FreeeOAuthResponse, error)
+	GenerateAuthURL(ctx context.Context, userID, redirectURI string) (*dto.FreeeAuthURLResponse, error)
+	ExchangeCodeForToken(ctx context.Context, userID string, req *dto.FreeeOAuthRequest) (*dto.FreeeOAuthResponse, error)
 	RefreshAccessToken(ctx context.Context, userID string) error
 	RevokeToken(ctx context.Context, userID string) error
 
@@ -24,6 +25,10 @@ type FreeeServiceInterface interface {
 	GetConnectionStatus(ctx context.Context, userID string) (*dto.FreeeConnectionStatusDTO, error)
 	TestConnection(ctx context.Context, userID string) (*dto.FreeeConnectionTestResult, error)
 
+	// 事業所管理
+	GetCompanies(ctx context.Context, userID string) ([]*dto.FreeeCompanyDTO, error)
+	SelectCompany(ctx context.Context, userID string, companyID int) error
+
 	// トークン管理
 	GetValidAccessToken(ctx context.Context, userID string) (string, error)
 	IsTokenExpired(tokenExpiresAt time.Time) bool
@@ -46,12 +51,28 @@ type FreeeServiceInterface interface {
 	// 支払い情報管理
 	GetPayments(ctx context.Context, userID string, from, to *time.Time) ([]*dto.FreeePaymentDTO, error)
 	GetInvoicePayments(ctx context.Context, userID string, invoiceID int) ([]*dto.FreeePaymentDTO, error)
+	CreatePayment(ctx context.Context, userID string, req *dto.CreateFreeePaymentRequest) (*dto.FreeePaymentDTO, error)
 
 	// データ同期
 	SyncAllData(ctx context.Context, userID string) (*dto.FreeSyncResult, error)
 	SyncClients(ctx context.Context, userID string) (*dto.FreeSyncResult, error)
 	SyncInvoices(ctx context.Context, userID string) (*dto.FreeSyncResult, error)
+	SyncSelectedClients(ctx context.Context, userID string, req *dto.FreeeClientSyncRequest) (*dto.FreeeClientSyncResponse, error)
+	SyncSelectedInvoices(ctx context.Context, userID string, req *dto.FreeeInvoiceSyncRequest) (*dto.FreeeInvoiceSyncResponse, error)
+
+	// 同期履歴
+	GetSyncHistory(ctx context.Context, syncType *model.FreeSyncType, status *model.FreeSyncStatus, limit, offset int) ([]*model.FreeSyncLog, int64, error)
+	GetSyncSummary(ctx context.Context, userID string) (*dto.FreeeStatusDTO, error)
+
+	// Webhook
+	ProcessWebhook(ctx context.Context, req *dto.FreeeWebhookRequest) (*dto.FreeeWebhookResponse, error)
 
 	// バッチ処理
 	ProcessBatchSync(ctx context.Context, userIDs []string) ([]*dto.FreeBatchSyncResult, error)
+
+	// 同期キュー
+	EnqueueSync(ctx context.Context, userID string, req *dto.FreeeSyncEnqueueRequest) (*dto.FreeeSyncEnqueueResponse, error)
+	ProcessSyncQueue(ctx context.Context, workerID string, limit int) (int, error)
+	ListSyncJobs(ctx context.Context, status *model.FreeeSyncJobStatus, limit, offset int) ([]*model.FreeeSyncJob, int64, error)
+	RequeueSyncJob(ctx context.Context, jobID, userID string) (*model.FreeeSyncJob, error)
 }
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// EnqueueSync 請求書・取引先のfreeeへの送信を同期キューに投入
+// 同じ対象のジョブが実行待ち・実行中の場合は投入しない
+func (s *freeeService) EnqueueSync(ctx context.Context, userID string, req *dto.FreeeSyncEnqueueRequest) (*dto.FreeeSyncEnqueueResponse, error) {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetType := model.FreeeSyncTargetType(req.TargetType)
+	if err := s.checkSyncTargets(ctx, targetType, req.TargetIDs); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FreeeSyncEnqueueResponse{CompanyID: settings.CompanyID, TotalRequests: len(req.TargetIDs)}
+	var queuedInvoiceIDs []string
+	now := time.Now()
+	for _, targetID := range req.TargetIDs {
+		job := &model.FreeeSyncJob{
+			CompanyID:   settings.CompanyID,
+			UserID:      userID,
+			TargetType:  targetType,
+			TargetID:    targetID,
+			Status:      model.FreeeSyncJobStatusQueued,
+			MaxAttempts: model.FreeeSyncJobDefaultMaxAttempts,
+			NextRunAt:   now,
+			CreatedBy:   userID,
+		}
+		job.Activate()
+		// 未完了ジョブの一意キーに当たった場合は投入済み
+		result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+		if result.Error != nil {
+			return nil, fmt.Errorf("freee同期ジョブの登録に失敗しました: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			resp.SkippedIDs = append(resp.SkippedIDs, targetID)
+			continue
+		}
+		resp.QueuedCount++
+		if targetType == model.FreeeSyncTargetInvoice {
+			queuedInvoiceIDs = append(queuedInvoiceIDs, targetID)
+		}
+	}
+
+	if len(queuedInvoiceIDs) > 0 {
+		if err := s.db.WithContext(ctx).Model(&model.Invoice{}).
+			Where("id IN ?", queuedInvoiceIDs).
+			Update("freee_sync_status", model.FreeSyncStatusPending).Error; err != nil {
+			return nil, fmt.Errorf("請求書の同期状態の更新に失敗しました: %w", err)
+		}
+	}
+
+	s.logger.Info("freee sync jobs enqueued",
+		zap.String("target_type", req.TargetType),
+		zap.Int("queued", resp.QueuedCount),
+		zap.Int("skipped", len(resp.SkippedIDs)),
+		zap.Int("company_id", settings.CompanyID),
+		zap.String("user_id", userID))
+	return resp, nil
+}
+
+// ProcessSyncQueue 実行時期が来たジョブを最大limit件処理し、処理した件数を返す
+// 複数のワーカーが同時に動いても、同じ事業所のジョブは1件ずつ実行される
+func (s *freeeService) ProcessSyncQueue(ctx context.Context, workerID string, limit int) (int, error) {
+	if err := s.recoverStaleSyncJobs(ctx); err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for processed < limit {
+		job, err := s.claimSyncJob(ctx, workerID)
+		if err != nil {
+			return processed, err
+		}
+		if job == nil {
+			break
+		}
+		s.runSyncJob(ctx, workerID, job)
+		processed++
+	}
+	return processed, nil
+}
+
+// ListSyncJobs 同期キューのジョブ一覧を取得（新しい順）
+func (s *freeeService) ListSyncJobs(ctx context.Context, status *model.FreeeSyncJobStatus, limit, offset int) ([]*model.FreeeSyncJob, int64, error) {
+	query := s.db.WithContext(ctx).Model(&model.FreeeSyncJob{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("freee同期ジョブ数の取得に失敗しました: %w", err)
+	}
+	var jobs []*model.FreeeSyncJob
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&jobs).Error; err != nil {
+		return nil, 0, fmt.Errorf("freee同期ジョブの取得に失敗しました: %w", err)
+	}
+	return jobs, total, nil
+}
+
+// RequeueSyncJob 再試行上限に達したジョブを再投入
+func (s *freeeService) RequeueSyncJob(ctx context.Context, jobID, userID string) (*model.FreeeSyncJob, error) {
+	var job model.FreeeSyncJob
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", jobID).First(&job).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "freee同期ジョブが見つかりません").
+					WithDetail("job_id", jobID)
+			}
+			return fmt.Errorf("freee同期ジョブの取得に失敗しました: %w", err)
+		}
+		if !job.IsDead() {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "再試行上限に達したジョブのみ再投入できます").
+				WithDetail("job_id", jobID).
+				WithDetail("status", string(job.Status))
+		}
+
+		var active int64
+		if err := tx.Model(&model.FreeeSyncJob{}).
+			Where("target_type = ? AND target_id = ? AND status IN ?", job.TargetType, job.TargetID,
+				[]model.FreeeSyncJobStatus{model.FreeeSyncJobStatusQueued, model.FreeeSyncJobStatusRunning}).
+			Count(&active).Error; err != nil {
+			return fmt.Errorf("freee同期ジョブの取得に失敗しました: %w", err)
+		}
+		if active > 0 {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingDataConflict, "同じ対象のジョブが実行待ちです").
+				WithDetail("job_id", jobID)
+		}
+
+		job.Requeue(time.Now(), userID)
+		if err := tx.Save(&job).Error; err != nil {
+			return fmt.Errorf("freee同期ジョブの更新に失敗しました: %w", err)
+		}
+		if job.TargetType == model.FreeeSyncTargetInvoice {
+			return tx.Model(&model.Invoice{}).Where("id = ?", job.TargetID).
+				Update("freee_sync_status", model.FreeSyncStatusPending).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("freee sync job requeued", zap.String("job_id", jobID), zap.String("user_id", userID))
+	return &job, nil
+}
+
+// checkSyncTargets 同期対象の請求書・取引先が存在するか確認
+func (s *freeeService) checkSyncTargets(ctx context.Context, targetType model.FreeeSyncTargetType, targetIDs []string) error {
+	var table interface{}
+	switch targetType {
+	case model.FreeeSyncTargetInvoice:
+		table = &model.Invoice{}
+	case model.FreeeSyncTargetPartner:
+		table = &model.Client{}
+	default:
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "同期対象の種類が正しくありません").
+			WithDetail("target_type", string(targetType))
+	}
+
+	var found []string
+	if err := s.db.WithContext(ctx).Model(table).Where("id IN ?", targetIDs).Pluck("id", &found).Error; err != nil {
+		return fmt.Errorf("同期対象の取得に失敗しました: %w", err)
+	}
+	exists := make(map[string]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+	for _, id := range targetIDs {
+		if !exists[id] {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "同期対象が見つかりません").
+				WithDetail("target_id", id)
+		}
+	}
+	return nil
+}
+
+// recoverStaleSyncJobs 占有期限を過ぎた実行中のジョブを中断とみなして戻す
+// 再試行上限に達したジョブは完了扱い（dead）、それ以外は実行待ちに戻す
+func (s *freeeService) recoverStaleSyncJobs(ctx context.Context) error {
+	now := time.Now()
+	var dead, requeued int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := func() *gorm.DB {
+			return tx.Model(&model.FreeeSyncJob{}).
+				Where("status = ? AND locked_until < ?", model.FreeeSyncJobStatusRunning, now)
+		}
+		result := stale().Where("attempts >= max_attempts").Updates(map[string]interface{}{
+			"status":       model.FreeeSyncJobStatusDead,
+			"active_key":   nil,
+			"completed_at": now,
+			"next_run_at":  now,
+			"locked_by":    nil,
+			"locked_until": nil,
+			"last_error":   "ワーカーの処理が中断されました",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		dead = result.RowsAffected
+
+		result = stale().Updates(map[string]interface{}{
+			"status":       model.FreeeSyncJobStatusQueued,
+			"completed_at": nil,
+			"next_run_at":  now,
+			"locked_by":    nil,
+			"locked_until": nil,
+			"last_error":   "ワーカーの処理が中断されました",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		requeued = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("中断したfreee同期ジョブの回復に失敗しました: %w", err)
+	}
+	if dead+requeued > 0 {
+		s.logger.Warn("Recovered stale freee sync jobs", zap.Int64("requeued", requeued), zap.Int64("dead", dead))
+	}
+	return nil
+}
+
+// lockSyncCompany 事業所のジョブの確認と取得をトランザクション終了まで直列化する
+func (s *freeeService) lockSyncCompany(tx *gorm.DB, companyID int) error {
+	switch s.driver {
+	case "mysql":
+		// MySQLはトランザクション単位のアドバイザリロックがないため、事業所の未完了のジョブの行をロックする
+		var ids []string
+		return tx.Model(&model.FreeeSyncJob{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND status IN ?", companyID,
+				[]model.FreeeSyncJobStatus{model.FreeeSyncJobStatusQueued, model.FreeeSyncJobStatusRunning}).
+			Pluck("id", &ids).Error
+	default:
+		return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf("freee_sync:%d", companyID)).Error
+	}
+}
+
+// claimSyncJob 実行時期が来たジョブを1件取り、実行中にする（なければnil）
+// 事業所ごとのロックで確認と取得を直列化し、実行中のジョブがある事業所は飛ばす
+func (s *freeeService) claimSyncJob(ctx context.Context, workerID string) (*model.FreeeSyncJob, error) {
+	now := time.Now()
+	var companyIDs []int
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT j.company_id FROM freee_sync_jobs j
+		WHERE j.status = ? AND j.next_run_at <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM freee_sync_jobs r
+				WHERE r.company_id = j.company_id AND r.status = ?
+			)`,
+		model.FreeeSyncJobStatusQueued, now, model.FreeeSyncJobStatusRunning).
+		Scan(&companyIDs).Error; err != nil {
+		return nil, fmt.Errorf("freee同期ジョブの取得に失敗しました: %w", err)
+	}
+
+	for _, companyID := range companyIDs {
+		var claimed *model.FreeeSyncJob
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := s.lockSyncCompany(tx, companyID); err != nil {
+				return err
+			}
+			var running int64
+			if err := tx.Model(&model.FreeeSyncJob{}).
+				Where("company_id = ? AND status = ?", companyID, model.FreeeSyncJobStatusRunning).
+				Count(&running).Error; err != nil {
+				return err
+			}
+			if running > 0 {
+				return nil
+			}
+
+			var job model.FreeeSyncJob
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("company_id = ? AND status = ? AND next_run_at <= ?", companyID, model.FreeeSyncJobStatusQueued, now).
+				Order("next_run_at ASC, created_at ASC").
+				First(&job).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			lockedUntil := now.Add(model.FreeeSyncJobLease)
+			job.Status = model.FreeeSyncJobStatusRunning
+			job.Attempts++
+			job.LockedBy = &workerID
+			job.LockedUntil = &lockedUntil
+			if err := tx.Model(&job).Updates(map[string]interface{}{
+				"status":       job.Status,
+				"attempts":     job.Attempts,
+				"locked_by":    workerID,
+				"locked_until": lockedUntil,
+			}).Error; err != nil {
+				return err
+			}
+			claimed = &job
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("freee同期ジョブの取得に失敗しました: %w", err)
+		}
+		if claimed != nil {
+			return claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+// runSyncJob ジョブを実行して結果を記録
+func (s *freeeService) runSyncJob(ctx context.Context, workerID string, job *model.FreeeSyncJob) {
+	freeeID, err := s.executeSyncJob(ctx, job)
+
+	now := time.Now()
+	if err == nil {
+		job.RecordSuccess(now, freeeID)
+	} else {
+		job.RecordFailure(now, err.Error(), syncRetryAfter(err))
+	}
+
+	// 占有期限切れで他のワーカーに渡ったジョブは上書きしない
+	result := s.db.WithContext(ctx).Model(&model.FreeeSyncJob{}).
+		Where("id = ? AND locked_by = ?", job.ID, workerID).
+		Updates(map[string]interface{}{
+			"status":       job.Status,
+			"active_key":   job.ActiveKey,
+			"next_run_at":  job.NextRunAt,
+			"locked_by":    nil,
+			"locked_until": nil,
+			"last_error":   job.LastError,
+			"freee_id":     job.FreeeID,
+			"completed_at": job.CompletedAt,
+		})
+	switch {
+	case result.Error != nil:
+		s.logger.Error("Failed to record freee sync job result", zap.String("job_id", job.ID), zap.Error(result.Error))
+	case result.RowsAffected == 0:
+		s.logger.Warn("freee sync job lease was lost before completion", zap.String("job_id", job.ID), zap.String("worker_id", workerID))
+	}
+
+	fields := []zap.Field{
+		zap.String("job_id", job.ID),
+		zap.String("target_type", string(job.TargetType)),
+		zap.String("target_id", job.TargetID),
+		zap.Int("attempts", job.Attempts),
+	}
+	switch {
+	case err == nil:
+		s.logger.Info("freee sync job succeeded", fields...)
+	case job.IsDead():
+		s.logger.Error("freee sync job moved to dead letter", append(fields, zap.Error(err))...)
+	default:
+		s.logger.Warn("freee sync job failed, retry scheduled", append(fields, zap.Time("next_run_at", job.NextRunAt), zap.Error(err))...)
+	}
+}
+
+// executeSyncJob ジョブの対象をfreeeに送信し、freee側のIDを返す
+func (s *freeeService) executeSyncJob(ctx context.Context, job *model.FreeeSyncJob) (*int, error) {
+	switch job.TargetType {
+	case model.FreeeSyncTargetInvoice:
+		invoice, err := s.loadInvoiceForSync(ctx, job.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		err = s.callAPI(ctx, job.UserID, func(token string, settings *model.FreeeSettings) error {
+			if err := checkSyncJobCompany(job, settings); err != nil {
+				return err
+			}
+			// 前回の送信がタイムアウトしてもfreee側で作成済みの場合があるため、作り直す前に探す
+			if job.Attempts > 1 && !invoice.HasFreeeInvoiceID() {
+				if err := s.adoptFreeeInvoice(ctx, token, settings, invoice); err != nil {
+					return err
+				}
+			}
+			_, _, err := s.syncInvoice(ctx, token, settings, invoice, freeeSyncModeCreateUpdate, false)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return invoice.FreeeInvoiceID, nil
+
+	case model.FreeeSyncTargetPartner:
+		var client model.Client
+		if err := s.db.WithContext(ctx).Where("id = ?", job.TargetID).First(&client).Error; err != nil {
+			return nil, fmt.Errorf("取引先の取得に失敗しました: %w", err)
+		}
+		// 取引先は名前で既存の登録を探すため、再送しても重複して作成されない
+		var partnerID *int
+		err := s.callAPI(ctx, job.UserID, func(token string, settings *model.FreeeSettings) error {
+			if err := checkSyncJobCompany(job, settings); err != nil {
+				return err
+			}
+			existing, err := s.partnerIDsByName(ctx, token, settings)
+			if err != nil {
+				return err
+			}
+			partner, _, err := s.upsertPartner(ctx, token, settings, &client, freeeSyncModeCreateUpdate, existing)
+			if partner != nil {
+				partnerID = &partner.ID
+			}
+			return err
+		})
+		return partnerID, err
+	}
+	return nil, fmt.Errorf("不明な同期対象です: %s", job.TargetType)
+}
+
+// adoptFreeeInvoice 請求書番号が一致するfreeeの請求書があれば、そのIDを保存して更新対象にする
+func (s *freeeService) adoptFreeeInvoice(ctx context.Context, token string, settings *model.FreeeSettings, invoice *model.Invoice) error {
+	issueDate := invoice.InvoiceDate
+	existing, err := s.client.GetInvoices(ctx, token, settings.CompanyID, &issueDate, &issueDate)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range existing {
+		if candidate.InvoiceNumber != invoice.InvoiceNumber {
+			continue
+		}
+		freeeID := candidate.ID
+		if err := s.invoiceRepo.UpdateFreeSyncStatus(ctx, invoice.ID, model.FreeSyncStatusPending, &freeeID); err != nil {
+			return fmt.Errorf("請求書の同期状態の更新に失敗しました: %w", err)
+		}
+		invoice.FreeeInvoiceID = &freeeID
+		s.logger.Info("Adopted invoice already created in freee",
+			zap.String("invoice_id", invoice.ID),
+			zap.Int("freee_invoice_id", freeeID))
+		return nil
+	}
+	return nil
+}
+
+// checkSyncJobCompany 投入後に連携先の事業所が変わっていないか確認
+func checkSyncJobCompany(job *model.FreeeSyncJob, settings *model.FreeeSettings) error {
+	if settings.CompanyID != job.CompanyID {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "ジョブ投入後に連携中の事業所が変更されました").
+			WithDetail("company_id", job.CompanyID)
+	}
+	return nil
+}
+
+// syncRetryAfter freeeのレート制限で指定された待ち時間
+func syncRetryAfter(err error) time.Duration {
+	accErr, ok := accountingerrors.AsAccountingError(err)
+	if !ok || accErr.Code != accountingerrors.ErrFreeeRateLimit {
+		return 0
+	}
+	if seconds, ok := accErr.Details["retry_after_seconds"].(int); ok {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
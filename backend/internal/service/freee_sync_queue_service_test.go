@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/internal/testutils"
+)
+
+// MockFreeeSettingsRepository freee設定リポジトリのモック
+type MockFreeeSettingsRepository struct {
+	mock.Mock
+	repository.FreeeSettingsRepository // テストで使わないメソッドは未実装
+}
+
+func (m *MockFreeeSettingsRepository) GetByUserID(ctx context.Context, userID string) (*model.FreeeSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FreeeSettings), args.Error(1)
+}
+
+// setupSyncQueueTest 同期キューのテーブルを持つSQLiteでfreee連携サービスを作成
+// SQLiteは行ロックを無視するため、事業所の直列化はMySQLと同じ行ロックの経路を通す
+func setupSyncQueueTest(t *testing.T) (*freeeService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db, &model.FreeeSyncJob{}, &model.Client{}))
+
+	settingsRepo := new(MockFreeeSettingsRepository)
+	settingsRepo.On("GetByUserID", mock.Anything, "user-1").Return(&model.FreeeSettings{UserID: "user-1", CompanyID: 100}, nil)
+
+	return &freeeService{db: db, settingsRepo: settingsRepo, driver: "mysql", logger: zap.NewNop()}, db
+}
+
+// createSyncJob 実行待ちのジョブを登録
+func createSyncJob(t *testing.T, db *gorm.DB, companyID int, targetID string, nextRunAt time.Time) *model.FreeeSyncJob {
+	job := &model.FreeeSyncJob{
+		CompanyID:   companyID,
+		UserID:      "user-1",
+		TargetType:  model.FreeeSyncTargetPartner,
+		TargetID:    targetID,
+		Status:      model.FreeeSyncJobStatusQueued,
+		MaxAttempts: 2,
+		NextRunAt:   nextRunAt,
+		CreatedBy:   "user-1",
+	}
+	job.Activate()
+	require.NoError(t, db.Create(job).Error)
+	return job
+}
+
+func findSyncJob(t *testing.T, db *gorm.DB, id string) *model.FreeeSyncJob {
+	var job model.FreeeSyncJob
+	require.NoError(t, db.Where("id = ?", id).First(&job).Error)
+	return &job
+}
+
+func TestFreeeService_EnqueueSync(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupSyncQueueTest(t)
+	require.NoError(t, db.Create(&model.Client{ID: "client-1", CompanyName: "テスト商事"}).Error)
+	req := &dto.FreeeSyncEnqueueRequest{TargetType: "partner", TargetIDs: []string{"client-1"}}
+
+	resp, err := s.EnqueueSync(ctx, "user-1", req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.QueuedCount)
+	assert.Equal(t, 100, resp.CompanyID)
+
+	t.Run("実行待ちの対象は二重に投入しない", func(t *testing.T) {
+		resp, err := s.EnqueueSync(ctx, "user-1", req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.QueuedCount)
+		assert.Equal(t, []string{"client-1"}, resp.SkippedIDs)
+	})
+
+	t.Run("完了したジョブの対象は再び投入できる", func(t *testing.T) {
+		var job model.FreeeSyncJob
+		require.NoError(t, db.Where("target_id = ?", "client-1").First(&job).Error)
+		job.RecordSuccess(time.Now(), nil)
+		require.NoError(t, db.Save(&job).Error)
+
+		resp, err := s.EnqueueSync(ctx, "user-1", req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.QueuedCount)
+		var count int64
+		require.NoError(t, db.Model(&model.FreeeSyncJob{}).Where("target_id = ?", "client-1").Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func TestFreeeService_ClaimSyncJob(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupSyncQueueTest(t)
+	now := time.Now()
+	first := createSyncJob(t, db, 100, "client-1", now.Add(-2*time.Minute))
+	createSyncJob(t, db, 100, "client-2", now.Add(-time.Minute))
+	createSyncJob(t, db, 200, "client-3", now.Add(time.Hour))
+
+	job, err := s.claimSyncJob(ctx, "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, first.ID, job.ID)
+
+	claimed := findSyncJob(t, db, first.ID)
+	assert.Equal(t, model.FreeeSyncJobStatusRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.Equal(t, "worker-1", *claimed.LockedBy)
+	assert.NotNil(t, claimed.LockedUntil)
+
+	// 同じ事業所のジョブが実行中で、他の事業所のジョブは実行時期が来ていない
+	job, err = s.claimSyncJob(ctx, "worker-2")
+	require.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestFreeeService_ProcessSyncQueue_BackoffAndDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupSyncQueueTest(t)
+	// 取引先が存在しないため、送信の前に失敗する
+	job := createSyncJob(t, db, 100, "missing-client", time.Now().Add(-time.Minute))
+
+	processed, err := s.ProcessSyncQueue(ctx, "worker-1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	retried := findSyncJob(t, db, job.ID)
+	assert.Equal(t, model.FreeeSyncJobStatusQueued, retried.Status)
+	assert.Equal(t, 1, retried.Attempts)
+	assert.Nil(t, retried.LockedBy)
+	assert.NotNil(t, retried.LastError)
+	assert.NotNil(t, retried.ActiveKey)
+	assert.WithinDuration(t, time.Now().Add(model.FreeeSyncBackoff(1)), retried.NextRunAt, 5*time.Second)
+
+	// 再試行の時期が来るまでは実行しない
+	processed, err = s.ProcessSyncQueue(ctx, "worker-1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	require.NoError(t, db.Model(&model.FreeeSyncJob{}).Where("id = ?", job.ID).
+		Update("next_run_at", time.Now().Add(-time.Second)).Error)
+	processed, err = s.ProcessSyncQueue(ctx, "worker-1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	dead := findSyncJob(t, db, job.ID)
+	assert.True(t, dead.IsDead())
+	assert.Equal(t, 2, dead.Attempts)
+	assert.NotNil(t, dead.CompletedAt)
+	assert.Nil(t, dead.ActiveKey)
+
+	requeued, err := s.RequeueSyncJob(ctx, job.ID, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.FreeeSyncJobStatusQueued, requeued.Status)
+	assert.Equal(t, 0, requeued.Attempts)
+	assert.Equal(t, "admin-1", *requeued.RequeuedBy)
+	assert.NotNil(t, findSyncJob(t, db, job.ID).ActiveKey)
+}
+
+func TestFreeeService_RecoverStaleSyncJobs(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupSyncQueueTest(t)
+	expired := time.Now().Add(-time.Minute)
+	worker := "worker-1"
+	interrupted := createSyncJob(t, db, 100, "client-1", expired)
+	exhausted := createSyncJob(t, db, 200, "client-2", expired)
+	require.NoError(t, db.Model(&model.FreeeSyncJob{}).Where("id = ?", interrupted.ID).
+		Updates(map[string]interface{}{"status": model.FreeeSyncJobStatusRunning, "attempts": 1, "locked_by": worker, "locked_until": expired}).Error)
+	require.NoError(t, db.Model(&model.FreeeSyncJob{}).Where("id = ?", exhausted.ID).
+		Updates(map[string]interface{}{"status": model.FreeeSyncJobStatusRunning, "attempts": 2, "locked_by": worker, "locked_until": expired}).Error)
+
+	require.NoError(t, s.recoverStaleSyncJobs(ctx))
+
+	requeued := findSyncJob(t, db, interrupted.ID)
+	assert.Equal(t, model.FreeeSyncJobStatusQueued, requeued.Status)
+	assert.Nil(t, requeued.LockedBy)
+	assert.NotNil(t, requeued.ActiveKey)
+
+	dead := findSyncJob(t, db, exhausted.ID)
+	assert.True(t, dead.IsDead())
+	assert.Nil(t, dead.ActiveKey)
+}
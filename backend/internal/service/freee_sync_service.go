@@ -0,0 +1,925 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/freee"
+	"github.com/duesk/monstera/internal/model"
+)
+
+const (
+	// freeeSyncModeCreateOnly 未連携のもののみ作成
+	freeeSyncModeCreateOnly = "create_only"
+	// freeeSyncModeUpdateOnly 連携済みのもののみ更新
+	freeeSyncModeUpdateOnly = "update_only"
+	// freeeSyncModeCreateUpdate 作成・更新の両方
+	freeeSyncModeCreateUpdate = "create_update"
+
+	// freeeSyncBatchSize 一括同期で1回に処理する請求書数
+	freeeSyncBatchSize = 100
+)
+
+// GetPartners 取引先一覧を取得
+func (s *freeeService) GetPartners(ctx context.Context, userID string) ([]*dto.FreeePartnerDTO, error) {
+	var partners []*dto.FreeePartnerDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		partners, err = s.client.GetPartners(ctx, token, settings.CompanyID)
+		return err
+	})
+	return partners, err
+}
+
+// CreatePartner 取引先を作成
+func (s *freeeService) CreatePartner(ctx context.Context, userID string, req *dto.CreateFreeePartnerRequest) (*dto.FreeePartnerDTO, error) {
+	var partner *dto.FreeePartnerDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		if req.CompanyID == 0 {
+			req.CompanyID = settings.CompanyID
+		}
+		var err error
+		partner, err = s.client.CreatePartner(ctx, token, req)
+		return err
+	})
+	return partner, err
+}
+
+// UpdatePartner 取引先を更新
+func (s *freeeService) UpdatePartner(ctx context.Context, userID string, partnerID int, req *dto.UpdateFreeePartnerRequest) (*dto.FreeePartnerDTO, error) {
+	var partner *dto.FreeePartnerDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		partner, err = s.client.UpdatePartner(ctx, token, settings.CompanyID, partnerID, req)
+		return err
+	})
+	return partner, err
+}
+
+// SyncPartner 取引先（クライアント）をfreeeに同期
+func (s *freeeService) SyncPartner(ctx context.Context, userID string, clientID string) (*dto.FreeePartnerDTO, error) {
+	var client model.Client
+	if err := s.db.WithContext(ctx).Where("id = ?", clientID).First(&client).Error; err != nil {
+		return nil, fmt.Errorf("取引先の取得に失敗しました: %w", err)
+	}
+
+	var partner *dto.FreeePartnerDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		existing, err := s.partnerIDsByName(ctx, token, settings)
+		if err != nil {
+			return err
+		}
+		partner, _, err = s.upsertPartner(ctx, token, settings, &client, freeeSyncModeCreateUpdate, existing)
+		return err
+	})
+	return partner, err
+}
+
+// GetInvoices freeeの請求書一覧を取得
+func (s *freeeService) GetInvoices(ctx context.Context, userID string, from, to *time.Time) ([]*dto.FreeeInvoiceDTO, error) {
+	var invoices []*dto.FreeeInvoiceDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		invoices, err = s.client.GetInvoices(ctx, token, settings.CompanyID, from, to)
+		return err
+	})
+	return invoices, err
+}
+
+// CreateInvoice freeeに請求書を作成
+func (s *freeeService) CreateInvoice(ctx context.Context, userID string, req *dto.CreateFreeeInvoiceRequest) (*dto.FreeeInvoiceDTO, error) {
+	var invoice *dto.FreeeInvoiceDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		if req.CompanyID == 0 {
+			req.CompanyID = settings.CompanyID
+		}
+		var err error
+		invoice, err = s.client.CreateInvoice(ctx, token, req)
+		return err
+	})
+	return invoice, err
+}
+
+// UpdateInvoice freeeの請求書を更新
+func (s *freeeService) UpdateInvoice(ctx context.Context, userID string, invoiceID int, req *dto.UpdateFreeeInvoiceRequest) (*dto.FreeeInvoiceDTO, error) {
+	var invoice *dto.FreeeInvoiceDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		invoice, err = s.client.UpdateInvoice(ctx, token, settings.CompanyID, invoiceID, req)
+		return err
+	})
+	return invoice, err
+}
+
+// DeleteInvoice freeeの請求書を削除
+func (s *freeeService) DeleteInvoice(ctx context.Context, userID string, invoiceID int) error {
+	return s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		return s.client.DeleteInvoice(ctx, token, settings.CompanyID, invoiceID)
+	})
+}
+
+// SyncInvoice 請求書をfreeeに同期
+func (s *freeeService) SyncInvoice(ctx context.Context, userID string, invoiceID string) (*dto.FreeeInvoiceDTO, error) {
+	invoice, err := s.loadInvoiceForSync(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *dto.FreeeInvoiceDTO
+	err = s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		var err error
+		result, _, err = s.syncInvoice(ctx, token, settings, invoice, freeeSyncModeCreateUpdate, false)
+		return err
+	})
+	return result, err
+}
+
+// GetPayments 期間内の入金を取得
+// freeeの入金は請求書に紐づく取引（deal）の支払行として記録される
+func (s *freeeService) GetPayments(ctx context.Context, userID string, from, to *time.Time) ([]*dto.FreeePaymentDTO, error) {
+	var payments []*dto.FreeePaymentDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		// 入金日は発生日より後になるため、発生日は終了日のみで絞り込む
+		deals, err := s.client.GetIncomeDeals(ctx, token, settings.CompanyID, nil, to)
+		if err != nil {
+			return err
+		}
+		invoices, err := s.client.GetInvoices(ctx, token, settings.CompanyID, nil, to)
+		if err != nil {
+			return err
+		}
+
+		invoiceByDeal := make(map[int]int, len(invoices))
+		for _, invoice := range invoices {
+			if invoice.DealID != nil {
+				invoiceByDeal[*invoice.DealID] = invoice.ID
+			}
+		}
+
+		payments = payments[:0]
+		for _, deal := range deals {
+			for _, payment := range deal.Payments {
+				if !freeePaymentInRange(payment.Date, from, to) {
+					continue
+				}
+				payments = append(payments, freeePaymentToDTO(deal, payment, invoiceByDeal[deal.ID]))
+			}
+		}
+		return nil
+	})
+	return payments, err
+}
+
+// GetInvoicePayments 請求書の入金を取得
+func (s *freeeService) GetInvoicePayments(ctx context.Context, userID string, invoiceID int) ([]*dto.FreeePaymentDTO, error) {
+	var payments []*dto.FreeePaymentDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		deal, err := s.invoiceDeal(ctx, token, settings.CompanyID, invoiceID)
+		if err != nil {
+			return err
+		}
+		payments = make([]*dto.FreeePaymentDTO, 0, len(deal.Payments))
+		for _, payment := range deal.Payments {
+			payments = append(payments, freeePaymentToDTO(deal, payment, invoiceID))
+		}
+		return nil
+	})
+	return payments, err
+}
+
+// CreatePayment 請求書に入金を登録
+func (s *freeeService) CreatePayment(ctx context.Context, userID string, req *dto.CreateFreeePaymentRequest) (*dto.FreeePaymentDTO, error) {
+	var payment *dto.FreeePaymentDTO
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		deal, err := s.invoiceDeal(ctx, token, settings.CompanyID, req.InvoiceID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := s.client.CreateDealPayment(ctx, token, settings.CompanyID, deal.ID, &freee.DealPayment{
+			Date:               req.Date,
+			FromWalletableType: req.FromWalletableType,
+			FromWalletableID:   req.FromWalletAccountID,
+			Amount:             req.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		// 登録した支払行はIDが最大のもの
+		var created *freee.DealPayment
+		for i := range updated.Payments {
+			if created == nil || updated.Payments[i].ID > created.ID {
+				created = &updated.Payments[i]
+			}
+		}
+		if created == nil {
+			return fmt.Errorf("登録した入金がfreeeの応答に含まれていません")
+		}
+		payment = freeePaymentToDTO(updated, *created, req.InvoiceID)
+		return nil
+	})
+	return payment, err
+}
+
+// SyncAllData 取引先と請求書をまとめて同期
+func (s *freeeService) SyncAllData(ctx context.Context, userID string) (*dto.FreeSyncResult, error) {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.FreeSyncResult{}
+	clientResult, err := s.SyncClients(ctx, userID)
+	if err == nil {
+		mergeFreeSyncResult(result, clientResult)
+		var invoiceResult *dto.FreeSyncResult
+		if invoiceResult, err = s.SyncInvoices(ctx, userID); err == nil {
+			mergeFreeSyncResult(result, invoiceResult)
+		}
+	}
+
+	result.SyncedAt = time.Now()
+	result.Success = err == nil && result.FailedItems == 0
+	status := "success"
+	var errMsg *string
+	switch {
+	case err != nil:
+		status = "failed"
+		msg := err.Error()
+		errMsg = &msg
+	case result.FailedItems > 0:
+		status = "failed"
+		msg := fmt.Sprintf("%d件の同期に失敗しました", result.FailedItems)
+		errMsg = &msg
+	}
+	settings.UpdateSyncStatus(status, errMsg)
+	if updateErr := s.settingsRepo.UpdateSyncStatus(ctx, settings); updateErr != nil {
+		s.logger.Error("Failed to update freee sync status", zap.String("user_id", userID), zap.Error(updateErr))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	result.Message = fmt.Sprintf("%d件中%d件を同期しました", result.TotalItems, result.SyncedItems)
+	return result, nil
+}
+
+// SyncClients 全取引先を同期
+func (s *freeeService) SyncClients(ctx context.Context, userID string) (*dto.FreeSyncResult, error) {
+	var clients []*model.Client
+	if err := s.db.WithContext(ctx).Order("created_at ASC").Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("取引先一覧の取得に失敗しました: %w", err)
+	}
+
+	result := &dto.FreeSyncResult{TotalItems: len(clients)}
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		existing, err := s.partnerIDsByName(ctx, token, settings)
+		if err != nil {
+			return err
+		}
+		for _, client := range clients {
+			if _, _, err := s.upsertPartner(ctx, token, settings, client, freeeSyncModeCreateUpdate, existing); err != nil {
+				if isFreeeAuthError(err) {
+					return err
+				}
+				result.FailedItems++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", client.CompanyName, err))
+				continue
+			}
+			result.SyncedItems++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Success = result.FailedItems == 0
+	result.SyncedAt = time.Now()
+	result.Message = fmt.Sprintf("取引先 %d件中%d件を同期しました", result.TotalItems, result.SyncedItems)
+	return result, nil
+}
+
+// SyncInvoices 未同期・同期失敗の請求書を同期
+func (s *freeeService) SyncInvoices(ctx context.Context, userID string) (*dto.FreeSyncResult, error) {
+	invoices, err := s.invoiceRepo.FindNeedingSync(ctx, freeeSyncBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("同期対象の請求書の取得に失敗しました: %w", err)
+	}
+
+	result := &dto.FreeSyncResult{TotalItems: len(invoices)}
+	for _, candidate := range invoices {
+		invoice, err := s.loadInvoiceForSync(ctx, candidate.ID)
+		if err == nil {
+			err = s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+				_, _, err := s.syncInvoice(ctx, token, settings, invoice, freeeSyncModeCreateUpdate, false)
+				return err
+			})
+		}
+		if err != nil {
+			if isFreeeAuthError(err) {
+				return nil, err
+			}
+			result.FailedItems++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", candidate.InvoiceNumber, err))
+			continue
+		}
+		result.SyncedItems++
+	}
+
+	result.Success = result.FailedItems == 0
+	result.SyncedAt = time.Now()
+	result.Message = fmt.Sprintf("請求書 %d件中%d件を同期しました", result.TotalItems, result.SyncedItems)
+	return result, nil
+}
+
+// SyncSelectedClients 指定した取引先を同期
+func (s *freeeService) SyncSelectedClients(ctx context.Context, userID string, req *dto.FreeeClientSyncRequest) (*dto.FreeeClientSyncResponse, error) {
+	if req.SyncMode == "" {
+		req.SyncMode = freeeSyncModeCreateUpdate
+	}
+	if err := req.Validate(); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+	if err := s.checkCompany(ctx, userID, req.CompanyID); err != nil {
+		return nil, err
+	}
+
+	var clients []*model.Client
+	if err := s.db.WithContext(ctx).Where("id IN ?", req.ClientIDs).Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("取引先の取得に失敗しました: %w", err)
+	}
+	clientByID := make(map[string]*model.Client, len(clients))
+	for _, client := range clients {
+		clientByID[client.ID] = client
+	}
+
+	resp := &dto.FreeeClientSyncResponse{TotalClients: len(req.ClientIDs), SyncMode: req.SyncMode}
+	err := s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+		existing, err := s.partnerIDsByName(ctx, token, settings)
+		if err != nil {
+			return err
+		}
+
+		resp.Results = resp.Results[:0]
+		resp.SyncedClients, resp.FailedClients = 0, 0
+		for _, clientID := range req.ClientIDs {
+			item := dto.FreeeClientSyncResultDTO{ClientID: clientID}
+			client, ok := clientByID[clientID]
+			if !ok {
+				msg := "取引先が見つかりません"
+				item.Status, item.Operation, item.Error = "failed", "skipped", &msg
+				resp.FailedClients++
+				resp.Results = append(resp.Results, item)
+				continue
+			}
+
+			item.ClientName = client.CompanyName
+			partner, operation, err := s.upsertPartner(ctx, token, settings, client, req.SyncMode, existing)
+			switch {
+			case err != nil:
+				if isFreeeAuthError(err) {
+					return err
+				}
+				msg := err.Error()
+				item.Status, item.Operation, item.Error = "failed", operation, &msg
+				resp.FailedClients++
+			case operation == "skipped":
+				item.Status, item.Operation = "skipped", operation
+			default:
+				item.Status, item.Operation, item.FreeePartnerID = "success", operation, &partner.ID
+				resp.SyncedClients++
+			}
+			resp.Results = append(resp.Results, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.SyncedAt = time.Now()
+	return resp, nil
+}
+
+// SyncSelectedInvoices 指定した請求書を同期
+func (s *freeeService) SyncSelectedInvoices(ctx context.Context, userID string, req *dto.FreeeInvoiceSyncRequest) (*dto.FreeeInvoiceSyncResponse, error) {
+	if req.SyncMode == "" {
+		req.SyncMode = freeeSyncModeCreateUpdate
+	}
+	if err := req.Validate(); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+	if err := s.checkCompany(ctx, userID, req.CompanyID); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FreeeInvoiceSyncResponse{TotalInvoices: len(req.InvoiceIDs), SyncMode: req.SyncMode}
+	for _, invoiceID := range req.InvoiceIDs {
+		item := dto.FreeeInvoiceSyncResultDTO{InvoiceID: invoiceID}
+
+		invoice, err := s.loadInvoiceForSync(ctx, invoiceID)
+		var freeeInvoice *dto.FreeeInvoiceDTO
+		operation := "skipped"
+		if err == nil {
+			item.InvoiceNumber = invoice.InvoiceNumber
+			item.ClientName = invoice.Client.CompanyName
+			err = s.callAPI(ctx, userID, func(token string, settings *model.FreeeSettings) error {
+				var err error
+				freeeInvoice, operation, err = s.syncInvoice(ctx, token, settings, invoice, req.SyncMode, req.AutoIssue)
+				return err
+			})
+		}
+
+		switch {
+		case err != nil:
+			if isFreeeAuthError(err) {
+				return nil, err
+			}
+			msg := err.Error()
+			item.Status, item.Operation, item.Error = "failed", operation, &msg
+			resp.FailedInvoices++
+		case operation == "skipped":
+			item.Status, item.Operation = "skipped", operation
+		default:
+			item.Status, item.Operation, item.FreeeInvoiceID = "success", operation, &freeeInvoice.ID
+			for _, summary := range invoice.TaxBreakdown() {
+				item.TaxSummaries = append(item.TaxSummaries, taxSummaryToDTO(summary))
+			}
+			resp.SyncedInvoices++
+		}
+		resp.Results = append(resp.Results, item)
+	}
+
+	resp.SyncedAt = time.Now()
+	return resp, nil
+}
+
+// GetSyncHistory 同期履歴を取得
+func (s *freeeService) GetSyncHistory(ctx context.Context, syncType *model.FreeSyncType, status *model.FreeSyncStatus, limit, offset int) ([]*model.FreeSyncLog, int64, error) {
+	logs, err := s.syncLogRepo.List(ctx, syncType, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.syncLogRepo.Count(ctx, syncType, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// GetSyncSummary 接続状態と同期状況のサマリーを取得
+func (s *freeeService) GetSyncSummary(ctx context.Context, userID string) (*dto.FreeeStatusDTO, error) {
+	summary := &dto.FreeeStatusDTO{}
+
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings != nil {
+		expiresIn := settings.GetExpiresInSeconds()
+		summary.IsConnected = true
+		summary.CompanyID = &settings.CompanyID
+		summary.CompanyName = &settings.CompanyName
+		summary.TokenExpiresAt = &settings.TokenExpiresAt
+		summary.TokenExpiresIn = &expiresIn
+		summary.IsTokenExpired = settings.IsTokenExpired()
+		summary.LastSyncAt = settings.LastSyncAt
+		summary.LastSyncStatus = settings.LastSyncStatus
+	}
+
+	var pending, syncedClients, syncedInvoices int64
+	if err := s.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("freee_sync_status IN ? AND status != ?",
+			[]model.FreeSyncStatus{model.FreeSyncStatusNotSynced, model.FreeSyncStatusFailed, model.FreeSyncStatusPending},
+			model.InvoiceStatusDraft).
+		Count(&pending).Error; err != nil {
+		return nil, fmt.Errorf("未同期の請求書数の取得に失敗しました: %w", err)
+	}
+	if err := s.db.WithContext(ctx).Model(&model.Client{}).
+		Where("freee_sync_status = ?", model.FreeSyncStatusSynced).
+		Count(&syncedClients).Error; err != nil {
+		return nil, fmt.Errorf("同期済み取引先数の取得に失敗しました: %w", err)
+	}
+	if err := s.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("freee_sync_status = ?", model.FreeSyncStatusSynced).
+		Count(&syncedInvoices).Error; err != nil {
+		return nil, fmt.Errorf("同期済み請求書数の取得に失敗しました: %w", err)
+	}
+	summary.PendingSyncs = int(pending)
+	summary.TotalSyncedClients = int(syncedClients)
+	summary.TotalSyncedInvoices = int(syncedInvoices)
+
+	failed := model.FreeSyncStatusFailed
+	recentFailures, err := s.syncLogRepo.List(ctx, nil, &failed, 5, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range recentFailures {
+		if log.ErrorMessage != nil {
+			summary.SyncErrors = append(summary.SyncErrors, *log.ErrorMessage)
+		}
+	}
+
+	return summary, nil
+}
+
+// ProcessBatchSync 複数ユーザーの連携データを一括同期
+func (s *freeeService) ProcessBatchSync(ctx context.Context, userIDs []string) ([]*dto.FreeBatchSyncResult, error) {
+	batchID := uuid.New().String()
+	results := make([]*dto.FreeBatchSyncResult, 0, len(userIDs))
+
+	for _, userID := range userIDs {
+		batch := &dto.FreeBatchSyncResult{
+			BatchID:       batchID,
+			TotalRequests: 1,
+			StartedAt:     time.Now(),
+			Summary:       map[string]int{},
+		}
+
+		result, err := s.SyncAllData(ctx, userID)
+		if err != nil {
+			s.logger.Error("freee batch sync failed", zap.String("batch_id", batchID), zap.String("user_id", userID), zap.Error(err))
+			result = &dto.FreeSyncResult{Message: err.Error(), SyncedAt: time.Now(), Errors: []string{err.Error()}}
+		}
+
+		batch.SyncResults = []dto.FreeSyncResult{*result}
+		batch.Summary["synced_items"] = result.SyncedItems
+		batch.Summary["failed_items"] = result.FailedItems
+		switch {
+		case err != nil:
+			batch.Status = "failed"
+			batch.FailedSyncs = 1
+		case result.FailedItems > 0:
+			batch.Status = "partial"
+			batch.SuccessfulSyncs = 1
+		default:
+			batch.Status = "completed"
+			batch.SuccessfulSyncs = 1
+		}
+		batch.CompletedAt = time.Now()
+		results = append(results, batch)
+	}
+
+	return results, nil
+}
+
+// upsertPartner クライアントをfreeeの取引先として作成・更新
+// 戻り値の操作種別は created, updated, skipped のいずれか
+func (s *freeeService) upsertPartner(ctx context.Context, token string, settings *model.FreeeSettings, client *model.Client, mode string, existingByName map[string]int) (*dto.FreeePartnerDTO, string, error) {
+	partnerID := clientPartnerID(client)
+	if partnerID == 0 {
+		partnerID = existingByName[client.CompanyName]
+	}
+
+	if (partnerID == 0 && mode == freeeSyncModeUpdateOnly) || (partnerID != 0 && mode == freeeSyncModeCreateOnly) {
+		return nil, "skipped", nil
+	}
+
+	var (
+		partner   *dto.FreeePartnerDTO
+		operation string
+		request   interface{}
+		err       error
+	)
+	if partnerID == 0 {
+		operation = "created"
+		req := &dto.CreateFreeePartnerRequest{
+			CompanyID:   settings.CompanyID,
+			Name:        client.CompanyName,
+			LongName:    client.CompanyName,
+			NameKana:    client.CompanyNameKana,
+			ContactName: client.ContactPerson,
+			Email:       client.ContactEmail,
+			Phone:       client.ContactPhone,
+		}
+		if client.Address != "" {
+			req.AddressAttributes = &dto.FreeeAddressDTO{StreetName1: client.Address}
+		}
+		request = req
+		partner, err = s.client.CreatePartner(ctx, token, req)
+	} else {
+		operation = "updated"
+		req := &dto.UpdateFreeePartnerRequest{
+			Name:        &client.CompanyName,
+			LongName:    &client.CompanyName,
+			NameKana:    &client.CompanyNameKana,
+			ContactName: &client.ContactPerson,
+			Email:       &client.ContactEmail,
+			Phone:       &client.ContactPhone,
+		}
+		if client.Address != "" {
+			req.AddressAttributes = &dto.FreeeAddressDTO{StreetName1: client.Address}
+		}
+		request = req
+		partner, err = s.client.UpdatePartner(ctx, token, settings.CompanyID, partnerID, req)
+	}
+
+	var freeeID *int
+	if partner != nil {
+		freeeID = &partner.ID
+	}
+	s.recordSyncLog(ctx, model.FreeSyncTypeClientSync, client.ID, freeeID, request, partner, err)
+
+	status := model.FreeSyncStatusSynced
+	if err != nil {
+		status = model.FreeSyncStatusFailed
+	}
+	updates := map[string]interface{}{
+		"freee_sync_status": status,
+		"freee_synced_at":   time.Now(),
+	}
+	if partner != nil {
+		updates["freee_client_id"] = partner.ID
+		existingByName[client.CompanyName] = partner.ID
+	}
+	if dbErr := s.db.WithContext(ctx).Model(&model.Client{}).Where("id = ?", client.ID).Updates(updates).Error; dbErr != nil {
+		s.logger.Error("Failed to update client freee sync status", zap.String("client_id", client.ID), zap.Error(dbErr))
+		if err == nil {
+			err = fmt.Errorf("取引先の同期状態の更新に失敗しました: %w", dbErr)
+		}
+	}
+	if err != nil {
+		return nil, operation, s.wrapAPIError(err)
+	}
+
+	client.FreeeClientID = &partner.ID
+	client.FreeSyncStatus = status
+	return partner, operation, nil
+}
+
+// syncInvoice 請求書をfreeeに作成・更新
+// 戻り値の操作種別は created, updated, issued, skipped のいずれか
+func (s *freeeService) syncInvoice(ctx context.Context, token string, settings *model.FreeeSettings, invoice *model.Invoice, mode string, autoIssue bool) (*dto.FreeeInvoiceDTO, string, error) {
+	if invoice.Status == model.InvoiceStatusCancelled {
+		return nil, "skipped", fmt.Errorf("キャンセルされた請求書は同期できません")
+	}
+	if invoice.IsCreditNote() && invoice.OriginalInvoiceID != nil {
+		// 取消対象がfreeeに登録されていなければ、相殺する取引がないため赤伝も登録しない
+		var original model.Invoice
+		if err := s.db.WithContext(ctx).Select("id", "freee_invoice_id").Where("id = ?", *invoice.OriginalInvoiceID).First(&original).Error; err != nil {
+			return nil, "skipped", fmt.Errorf("赤伝の取消対象の請求書の取得に失敗しました: %w", err)
+		}
+		if !original.HasFreeeInvoiceID() {
+			return nil, "skipped", nil
+		}
+	}
+	if (!invoice.HasFreeeInvoiceID() && mode == freeeSyncModeUpdateOnly) || (invoice.HasFreeeInvoiceID() && mode == freeeSyncModeCreateOnly) {
+		return nil, "skipped", nil
+	}
+
+	partnerID := clientPartnerID(&invoice.Client)
+	if partnerID == 0 {
+		existing, err := s.partnerIDsByName(ctx, token, settings)
+		if err != nil {
+			return nil, "skipped", err
+		}
+		partner, _, err := s.upsertPartner(ctx, token, settings, &invoice.Client, freeeSyncModeCreateUpdate, existing)
+		if err != nil {
+			return nil, "skipped", accountingerrors.NewAccountingErrorWithCause(accountingerrors.ErrFreeePartnerNotFound, "取引先をfreeeに登録できませんでした", err)
+		}
+		partnerID = partner.ID
+	}
+
+	req := BuildFreeeInvoiceRequest(invoice, settings.CompanyID, partnerID)
+	req.InvoiceLayout = settings.InvoiceLayout
+	if autoIssue {
+		req.InvoiceStatus = "issued"
+	}
+
+	var (
+		result    *dto.FreeeInvoiceDTO
+		operation string
+		syncType  model.FreeSyncType
+		err       error
+	)
+	if invoice.HasFreeeInvoiceID() {
+		operation, syncType = "updated", model.FreeSyncTypeInvoiceUpdate
+		result, err = s.client.UpdateInvoice(ctx, token, settings.CompanyID, *invoice.FreeeInvoiceID, freeeUpdateInvoiceRequest(req))
+	} else {
+		operation, syncType = "created", model.FreeSyncTypeInvoiceCreate
+		result, err = s.client.CreateInvoice(ctx, token, req)
+	}
+	if err == nil && autoIssue {
+		operation = "issued"
+	}
+
+	var freeeID *int
+	if result != nil {
+		freeeID = &result.ID
+	}
+	s.recordSyncLog(ctx, syncType, invoice.ID, freeeID, req, result, err)
+
+	status := model.FreeSyncStatusSynced
+	if err != nil {
+		status = model.FreeSyncStatusFailed
+	}
+	if dbErr := s.invoiceRepo.UpdateFreeSyncStatus(ctx, invoice.ID, status, freeeID); dbErr != nil {
+		s.logger.Error("Failed to update invoice freee sync status", zap.String("invoice_id", invoice.ID), zap.Error(dbErr))
+		if err == nil {
+			err = fmt.Errorf("請求書の同期状態の更新に失敗しました: %w", dbErr)
+		}
+	}
+	if err != nil {
+		wrapped := s.wrapAPIError(err)
+		if accErr, ok := accountingerrors.AsAccountingError(wrapped); ok && accErr.Code == accountingerrors.ErrFreeeAPIError {
+			return nil, operation, accountingerrors.NewAccountingErrorWithCause(accountingerrors.ErrFreeeInvoiceCreateFailed, "freeeへの請求書連携に失敗しました", wrapped)
+		}
+		return nil, operation, wrapped
+	}
+
+	invoice.FreeeInvoiceID = freeeID
+	invoice.FreeSyncStatus = status
+	return result, operation, nil
+}
+
+// loadInvoiceForSync 同期に必要な関連データを含めて請求書を取得
+func (s *freeeService) loadInvoiceForSync(ctx context.Context, invoiceID string) (*model.Invoice, error) {
+	var invoice model.Invoice
+	err := s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("Details", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC")
+		}).
+		Preload("TaxSummaries").
+		Where("id = ?", invoiceID).
+		First(&invoice).Error
+	if err != nil {
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	return &invoice, nil
+}
+
+// partnerIDsByName freeeの取引先を名前で引けるようにする
+// 連携前から手動登録されている取引先と重複して作成しないために使う
+func (s *freeeService) partnerIDsByName(ctx context.Context, token string, settings *model.FreeeSettings) (map[string]int, error) {
+	partners, err := s.client.GetPartners(ctx, token, settings.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]int, len(partners))
+	for _, partner := range partners {
+		byName[partner.Name] = partner.ID
+	}
+	return byName, nil
+}
+
+// invoiceDeal 請求書に紐づく取引を取得
+func (s *freeeService) invoiceDeal(ctx context.Context, token string, companyID, invoiceID int) (*freee.Deal, error) {
+	invoice, err := s.client.GetInvoice(ctx, token, companyID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.DealID == nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "発行前の請求書には入金を登録できません").
+			WithDetail("freee_invoice_id", invoiceID)
+	}
+	return s.client.GetDeal(ctx, token, companyID, *invoice.DealID)
+}
+
+// checkCompany リクエストの事業所が連携中の事業所と一致するか確認
+func (s *freeeService) checkCompany(ctx context.Context, userID string, companyID int) error {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if settings.CompanyID != companyID {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "連携中の事業所と異なる事業所が指定されています").
+			WithDetail("company_id", companyID)
+	}
+	return nil
+}
+
+// recordSyncLog 同期ログを記録（失敗しても同期処理は継続する）
+func (s *freeeService) recordSyncLog(ctx context.Context, syncType model.FreeSyncType, targetID string, freeeID *int, request, response interface{}, syncErr error) {
+	log := &model.FreeSyncLog{
+		SyncType: syncType,
+		TargetID: &targetID,
+		FreeeID:  freeeID,
+		Status:   model.FreeSyncStatusSuccess,
+	}
+	if syncErr != nil {
+		msg := syncErr.Error()
+		log.Status = model.FreeSyncStatusFailed
+		log.ErrorMessage = &msg
+	}
+	if data := toSyncLogData(request); data != nil {
+		requestData := model.FreeSyncRequestData(data)
+		log.RequestData = &requestData
+	}
+	if data := toSyncLogData(response); data != nil {
+		responseData := model.FreeSyncResponseData(data)
+		log.ResponseData = &responseData
+	}
+
+	if err := s.syncLogRepo.Create(ctx, log); err != nil {
+		s.logger.Error("Failed to create freee sync log", zap.String("target_id", targetID), zap.Error(err))
+	}
+}
+
+// toSyncLogData 構造体を同期ログ用のmapに変換
+func toSyncLogData(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil
+	}
+	return data
+}
+
+// clientPartnerID クライアントに紐づくfreee取引先IDを取得
+func clientPartnerID(client *model.Client) int {
+	if client.FreeeClientID != nil {
+		return *client.FreeeClientID
+	}
+	if client.FreeePartnerID != nil {
+		if id, err := strconv.Atoi(*client.FreeePartnerID); err == nil {
+			return id
+		}
+	}
+	return 0
+}
+
+// freeeUpdateInvoiceRequest 作成リクエストを更新リクエストに変換
+func freeeUpdateInvoiceRequest(req *dto.CreateFreeeInvoiceRequest) *dto.UpdateFreeeInvoiceRequest {
+	update := &dto.UpdateFreeeInvoiceRequest{
+		IssueDate:       &req.IssueDate,
+		PartnerID:       &req.PartnerID,
+		InvoiceNumber:   &req.InvoiceNumber,
+		DueDate:         &req.DueDate,
+		Notes:           &req.Notes,
+		TaxEntryMethod:  &req.TaxEntryMethod,
+		InvoiceContents: req.InvoiceContents,
+	}
+	if req.InvoiceLayout != "" {
+		update.InvoiceLayout = &req.InvoiceLayout
+	}
+	if req.InvoiceStatus != "" {
+		update.InvoiceStatus = &req.InvoiceStatus
+	}
+	return update
+}
+
+// freeePaymentToDTO 取引の支払行を入金DTOに変換
+func freeePaymentToDTO(deal *freee.Deal, payment freee.DealPayment, invoiceID int) *dto.FreeePaymentDTO {
+	return &dto.FreeePaymentDTO{
+		ID:                  payment.ID,
+		CompanyID:           deal.CompanyID,
+		InvoiceID:           invoiceID,
+		DealID:              deal.ID,
+		Date:                payment.Date,
+		Amount:              payment.Amount,
+		FromWalletableType:  payment.FromWalletableType,
+		FromWalletAccountID: payment.FromWalletableID,
+	}
+}
+
+// freeePaymentInRange 入金日が期間内かチェック
+func freeePaymentInRange(date string, from, to *time.Time) bool {
+	// 日付はYYYY-MM-DD形式のため文字列で比較できる
+	if from != nil && date < from.Format("2006-01-02") {
+		return false
+	}
+	if to != nil && date > to.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// mergeFreeSyncResult 同期結果を集計
+func mergeFreeSyncResult(dst, src *dto.FreeSyncResult) {
+	dst.TotalItems += src.TotalItems
+	dst.SyncedItems += src.SyncedItems
+	dst.FailedItems += src.FailedItems
+	dst.Details = append(dst.Details, src.Message)
+	dst.Errors = append(dst.Errors, src.Errors...)
+}
+
+// isFreeeAuthError 連携を継続できない認証系のエラーかチェック
+func isFreeeAuthError(err error) bool {
+	accErr, ok := accountingerrors.AsAccountingError(err)
+	if !ok {
+		return false
+	}
+	switch accErr.Code {
+	case accountingerrors.ErrFreeeNotConnected, accountingerrors.ErrFreeeAuthFailed, accountingerrors.ErrFreeeTokenRefreshFailed:
+		return true
+	}
+	return false
+}
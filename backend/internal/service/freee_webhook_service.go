@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+const (
+	// freeeWebhookInvoiceCreated 請求書作成イベント
+	freeeWebhookInvoiceCreated = "invoice.created"
+	// freeeWebhookInvoiceUpdated 請求書更新イベント
+	freeeWebhookInvoiceUpdated = "invoice.updated"
+	// freeeWebhookPaymentCreated 入金イベント
+	freeeWebhookPaymentCreated = "payment.created"
+)
+
+// freeePaymentMethods freeeの口座種別から請求書の支払方法への対応
+var freeePaymentMethods = map[string]string{
+	"bank_account": "bank_transfer",
+	"credit_card":  "credit_card",
+	"wallet":       "cash",
+}
+
+// webhookOutcome Webhookイベントの処理結果
+type webhookOutcome struct {
+	targetID *string
+	freeeID  *int
+	message  string
+	ignored  bool
+	// rejected 再送しても反映できないイベントの理由（同期ログに失敗として残し、受信は成功で返す）
+	rejected error
+}
+
+// ProcessWebhook freeeのWebhookイベントを処理
+// イベントは同期ログにイベントIDで記録し、成功済みのイベントが再送された場合は何もしない
+// 反映に失敗した場合は請求書への変更をロールバックしてエラーを返し、freeeの再送で再処理させる
+// キャンセル済みの請求書への入金など、再送しても反映できないイベントは失敗として記録し、受信は成功で返す
+func (s *freeeService) ProcessWebhook(ctx context.Context, req *dto.FreeeWebhookRequest) (*dto.FreeeWebhookResponse, error) {
+	if req.EventID == "" {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "イベントIDがありません")
+	}
+
+	resp := &dto.FreeeWebhookResponse{}
+	var outcome webhookOutcome
+	var applyErr error
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		log, found, err := lockWebhookSyncLog(tx, req.EventID)
+		if err != nil {
+			return err
+		}
+		if found && log.IsSuccess() {
+			resp.Status = "ignored"
+			resp.Message = "処理済みのイベントです"
+			return nil
+		}
+
+		outcome, applyErr = s.applyWebhookEvent(tx, req)
+		if applyErr != nil {
+			return applyErr
+		}
+
+		fillWebhookSyncLog(log, req, outcome, outcome.rejected)
+		if err := saveWebhookSyncLog(tx, log, found); err != nil {
+			return err
+		}
+
+		if outcome.ignored || outcome.rejected != nil {
+			resp.Status = "ignored"
+		} else {
+			resp.Status = "processed"
+		}
+		resp.Message = outcome.message
+		return nil
+	})
+	if applyErr != nil {
+		// 請求書への変更はロールバック済みのため、失敗の記録は別のトランザクションで残す
+		if logErr := s.recordWebhookFailure(ctx, req, outcome, applyErr); logErr != nil {
+			s.logger.Error("Failed to record freee webhook failure",
+				zap.String("event_id", req.EventID),
+				zap.Error(logErr))
+		}
+	}
+	if err != nil {
+		s.logger.Error("Failed to process freee webhook",
+			zap.String("event_id", req.EventID),
+			zap.String("event_type", req.EventType),
+			zap.Error(err))
+		return nil, err
+	}
+
+	if outcome.rejected != nil {
+		s.logger.Warn("freee webhook event could not be applied",
+			zap.String("event_id", req.EventID),
+			zap.String("event_type", req.EventType),
+			zap.Error(outcome.rejected))
+	}
+	s.logger.Info("freee webhook processed",
+		zap.String("event_id", req.EventID),
+		zap.String("event_type", req.EventType),
+		zap.String("status", resp.Status),
+		zap.String("message", resp.Message))
+
+	resp.ProcessedAt = time.Now()
+	return resp, nil
+}
+
+// recordWebhookFailure 反映に失敗したイベントを同期ログに記録
+func (s *freeeService) recordWebhookFailure(ctx context.Context, req *dto.FreeeWebhookRequest, outcome webhookOutcome, applyErr error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		log, found, err := lockWebhookSyncLog(tx, req.EventID)
+		if err != nil {
+			return err
+		}
+		// 同時に受信した同じイベントが成功していれば上書きしない
+		if found && log.IsSuccess() {
+			return nil
+		}
+		fillWebhookSyncLog(log, req, outcome, applyErr)
+		return saveWebhookSyncLog(tx, log, found)
+	})
+}
+
+// lockWebhookSyncLog イベントIDの同期ログを取得
+// 同じイベントの同時受信に備えて行ロックを取る
+func lockWebhookSyncLog(tx *gorm.DB, eventID string) (*model.FreeSyncLog, bool, error) {
+	var log model.FreeSyncLog
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ?", eventID).
+		First(&log).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &log, false, nil
+		}
+		return nil, false, fmt.Errorf("同期ログの取得に失敗しました: %w", err)
+	}
+	return &log, true, nil
+}
+
+// fillWebhookSyncLog イベントの処理結果を同期ログに設定
+func fillWebhookSyncLog(log *model.FreeSyncLog, req *dto.FreeeWebhookRequest, outcome webhookOutcome, applyErr error) {
+	eventID := req.EventID
+	requestData := model.FreeSyncRequestData(toSyncLogData(req))
+	log.EventID = &eventID
+	log.SyncType = freeeWebhookSyncType(req.EventType)
+	log.TargetID = outcome.targetID
+	log.FreeeID = outcome.freeeID
+	log.RequestData = &requestData
+	log.Status = model.FreeSyncStatusSuccess
+	log.ErrorMessage = nil
+	if applyErr != nil {
+		msg := applyErr.Error()
+		log.Status = model.FreeSyncStatusFailed
+		log.ErrorMessage = &msg
+	}
+}
+
+// saveWebhookSyncLog 同期ログを保存
+func saveWebhookSyncLog(tx *gorm.DB, log *model.FreeSyncLog, found bool) error {
+	var err error
+	if found {
+		err = tx.Save(log).Error
+	} else {
+		err = tx.Create(log).Error
+	}
+	if err != nil {
+		return fmt.Errorf("同期ログの記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// applyWebhookEvent イベントを請求書に反映
+func (s *freeeService) applyWebhookEvent(tx *gorm.DB, req *dto.FreeeWebhookRequest) (webhookOutcome, error) {
+	switch req.EventType {
+	case freeeWebhookInvoiceCreated, freeeWebhookInvoiceUpdated:
+		return s.applyInvoiceEvent(tx, req)
+	case freeeWebhookPaymentCreated:
+		return s.applyPaymentEvent(tx, req)
+	default:
+		return webhookOutcome{ignored: true, message: "対象外のイベントです"}, nil
+	}
+}
+
+// applyInvoiceEvent 請求書の作成・更新イベントを反映
+// freee側で確定した内容を正とし、同期状態を同期済みに戻す
+func (s *freeeService) applyInvoiceEvent(tx *gorm.DB, req *dto.FreeeWebhookRequest) (webhookOutcome, error) {
+	freeeInvoiceID := webhookInvoiceID(req)
+	if freeeInvoiceID == 0 {
+		return webhookOutcome{}, fmt.Errorf("請求書IDがありません")
+	}
+	outcome := webhookOutcome{freeeID: &freeeInvoiceID}
+
+	invoice, err := findInvoiceByFreeeID(tx, freeeInvoiceID)
+	if err != nil {
+		return outcome, err
+	}
+	if invoice == nil {
+		// freee上で直接作成された請求書は管理対象外
+		outcome.ignored = true
+		outcome.message = "連携している請求書がありません"
+		return outcome, nil
+	}
+	outcome.targetID = &invoice.ID
+
+	err = tx.Model(&model.Invoice{}).
+		Where("id = ?", invoice.ID).
+		Updates(map[string]interface{}{
+			"freee_sync_status": model.FreeSyncStatusSynced,
+			"freee_synced_at":   time.Now(),
+		}).Error
+	if err != nil {
+		return outcome, fmt.Errorf("請求書の同期状態の更新に失敗しました: %w", err)
+	}
+
+	outcome.message = fmt.Sprintf("請求書 %s の同期状態を更新しました", invoice.InvoiceNumber)
+	return outcome, nil
+}
+
+// applyPaymentEvent 入金イベントを反映し、請求書を支払済みにする
+func (s *freeeService) applyPaymentEvent(tx *gorm.DB, req *dto.FreeeWebhookRequest) (webhookOutcome, error) {
+	freeeInvoiceID := webhookInvoiceID(req)
+	if freeeInvoiceID == 0 {
+		return webhookOutcome{}, fmt.Errorf("入金に対応する請求書IDがありません")
+	}
+	outcome := webhookOutcome{freeeID: &freeeInvoiceID}
+
+	invoice, err := findInvoiceByFreeeID(tx, freeeInvoiceID)
+	if err != nil {
+		return outcome, err
+	}
+	if invoice == nil {
+		// 請求書の同期前に入金が届いた場合は、再送時に処理できるよう失敗として記録する
+		return outcome, fmt.Errorf("freee請求書ID %d に対応する請求書が見つかりません", freeeInvoiceID)
+	}
+	outcome.targetID = &invoice.ID
+
+	switch invoice.Status {
+	case model.InvoiceStatusPaid:
+		outcome.ignored = true
+		outcome.message = "請求書は支払済みです"
+		return outcome, nil
+	case model.InvoiceStatusCancelled:
+		// 再送しても結果は変わらないため、同期ログに失敗として残して経理の確認に回す
+		outcome.rejected = fmt.Errorf("キャンセルされた請求書 %s に入金がありました", invoice.InvoiceNumber)
+		outcome.message = "キャンセルされた請求書への入金のため反映しません"
+		return outcome, nil
+	}
+
+	// 分割入金は過去の入金イベントと合算し、請求額に達するまでは支払済みにしない
+	amount, hasAmount := webhookNumber(req.Data, "amount")
+	settled := webhookString(req.Data, "payment_status") == "settled"
+	if hasAmount && !settled {
+		paid, err := paidAmountFromWebhooks(tx, invoice.ID, req.EventID)
+		if err != nil {
+			return outcome, err
+		}
+		if paid+amount < invoice.TotalAmount.Round(money.RoundingHalfUp).Float64() {
+			outcome.ignored = true
+			outcome.message = fmt.Sprintf("一部入金のため支払済みにしません（入金累計 %.0f円 / 請求額 %.0f円）", paid+amount, invoice.TotalAmount.Float64())
+			return outcome, nil
+		}
+	}
+
+	paidDate := req.OccurredAt
+	if date := webhookString(req.Data, "date"); date != "" {
+		if parsed, err := time.ParseInLocation("2006-01-02", date, time.Local); err == nil {
+			paidDate = parsed
+		}
+	}
+	if paidDate.IsZero() {
+		paidDate = time.Now()
+	}
+
+	paymentMethod := webhookString(req.Data, "from_walletable_type")
+	if method, ok := freeePaymentMethods[paymentMethod]; ok {
+		paymentMethod = method
+	}
+
+	err = tx.Model(&model.Invoice{}).
+		Where("id = ?", invoice.ID).
+		Updates(map[string]interface{}{
+			"status":         model.InvoiceStatusPaid,
+			"paid_date":      paidDate,
+			"payment_method": paymentMethod,
+		}).Error
+	if err != nil {
+		return outcome, fmt.Errorf("請求書の支払状態の更新に失敗しました: %w", err)
+	}
+
+	outcome.message = fmt.Sprintf("請求書 %s を支払済みにしました", invoice.InvoiceNumber)
+	return outcome, nil
+}
+
+// findInvoiceByFreeeID freeeの請求書IDで請求書を取得（見つからない場合はnil）
+func findInvoiceByFreeeID(tx *gorm.DB, freeeInvoiceID int) (*model.Invoice, error) {
+	var invoice model.Invoice
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("freee_invoice_id = ?", freeeInvoiceID).
+		First(&invoice).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	return &invoice, nil
+}
+
+// paidAmountFromWebhooks 処理済みの入金イベントの入金額を合算
+func paidAmountFromWebhooks(tx *gorm.DB, invoiceID, currentEventID string) (float64, error) {
+	var logs []*model.FreeSyncLog
+	err := tx.Where("target_id = ? AND sync_type = ? AND status = ? AND event_id IS NOT NULL AND event_id <> ?",
+		invoiceID, model.FreeSyncTypePaymentSync, model.FreeSyncStatusSuccess, currentEventID).
+		Find(&logs).Error
+	if err != nil {
+		return 0, fmt.Errorf("入金履歴の取得に失敗しました: %w", err)
+	}
+
+	var total float64
+	for _, log := range logs {
+		if log.RequestData == nil {
+			continue
+		}
+		data, _ := (*log.RequestData)["data"].(map[string]interface{})
+		if amount, ok := webhookNumber(data, "amount"); ok {
+			total += amount
+		}
+	}
+	return total, nil
+}
+
+// freeeWebhookSyncType イベント種別を同期ログの種別に変換
+func freeeWebhookSyncType(eventType string) model.FreeSyncType {
+	switch eventType {
+	case freeeWebhookInvoiceCreated:
+		return model.FreeSyncTypeInvoiceCreate
+	case freeeWebhookInvoiceUpdated:
+		return model.FreeSyncTypeInvoiceUpdate
+	case freeeWebhookPaymentCreated:
+		return model.FreeSyncTypePaymentSync
+	default:
+		return model.FreeSyncTypeWebhook
+	}
+}
+
+// webhookInvoiceID イベントからfreeeの請求書IDを取得
+func webhookInvoiceID(req *dto.FreeeWebhookRequest) int {
+	if req.ResourceType == "invoice" && req.ResourceID > 0 {
+		return req.ResourceID
+	}
+	if id, ok := webhookNumber(req.Data, "invoice_id"); ok {
+		return int(id)
+	}
+	return 0
+}
+
+// webhookNumber イベントデータから数値を取得
+func webhookNumber(data map[string]interface{}, key string) (float64, bool) {
+	switch v := data[key].(type) {
+	case float64:
+		return v, true
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// webhookString イベントデータから文字列を取得
+func webhookString(data map[string]interface{}, key string) string {
+	if v, ok := data[key].(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// setupWebhookTest 請求書と同期ログのテーブルを持つSQLiteでfreee連携サービスを作成
+func setupWebhookTest(t *testing.T) (*freeeService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db, &model.Invoice{}, &model.FreeSyncLog{}))
+
+	return &freeeService{db: db, logger: zap.NewNop()}, db
+}
+
+// createWebhookInvoice freee連携済みの請求書を登録
+func createWebhookInvoice(t *testing.T, db *gorm.DB, freeeInvoiceID int, status model.InvoiceStatus) *model.Invoice {
+	now := time.Now()
+	invoice := &model.Invoice{
+		ClientID:       "client-1",
+		InvoiceNumber:  "INV-2026-0001",
+		InvoiceDate:    now,
+		DueDate:        now.AddDate(0, 1, 0),
+		BillingMonth:   now.Format("2006-01"),
+		TotalAmount:    money.Yen(110000),
+		Status:         status,
+		FreeeInvoiceID: &freeeInvoiceID,
+	}
+	require.NoError(t, db.Create(invoice).Error)
+	return invoice
+}
+
+func paymentWebhook(eventID string, freeeInvoiceID int) *dto.FreeeWebhookRequest {
+	return &dto.FreeeWebhookRequest{
+		EventID:      eventID,
+		EventType:    freeeWebhookPaymentCreated,
+		ResourceType: "payment",
+		OccurredAt:   time.Now(),
+		Data:         map[string]interface{}{"invoice_id": float64(freeeInvoiceID), "amount": float64(110000)},
+	}
+}
+
+func TestFreeeService_ProcessWebhook_Payment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("入金で請求書を支払済みにする", func(t *testing.T) {
+		s, db := setupWebhookTest(t)
+		invoice := createWebhookInvoice(t, db, 501, model.InvoiceStatusSent)
+
+		resp, err := s.ProcessWebhook(ctx, paymentWebhook("evt-1", 501))
+
+		require.NoError(t, err)
+		assert.Equal(t, "processed", resp.Status)
+		var updated model.Invoice
+		require.NoError(t, db.Where("id = ?", invoice.ID).First(&updated).Error)
+		assert.Equal(t, model.InvoiceStatusPaid, updated.Status)
+		assert.NotNil(t, updated.PaidDate)
+	})
+
+	t.Run("キャンセル済みの請求書への入金は失敗として記録し、受信は成功で返す", func(t *testing.T) {
+		s, db := setupWebhookTest(t)
+		invoice := createWebhookInvoice(t, db, 502, model.InvoiceStatusCancelled)
+
+		// freeeの再送でも同じ結果になり、エラーを返し続けない
+		for i := 0; i < 2; i++ {
+			resp, err := s.ProcessWebhook(ctx, paymentWebhook("evt-2", 502))
+
+			require.NoError(t, err)
+			assert.Equal(t, "ignored", resp.Status)
+		}
+
+		var logs []model.FreeSyncLog
+		require.NoError(t, db.Where("event_id = ?", "evt-2").Find(&logs).Error)
+		require.Len(t, logs, 1)
+		assert.Equal(t, model.FreeSyncStatusFailed, logs[0].Status)
+		assert.Equal(t, invoice.ID, *logs[0].TargetID)
+		assert.Contains(t, *logs[0].ErrorMessage, invoice.InvoiceNumber)
+
+		var unchanged model.Invoice
+		require.NoError(t, db.Where("id = ?", invoice.ID).First(&unchanged).Error)
+		assert.Equal(t, model.InvoiceStatusCancelled, unchanged.Status)
+	})
+
+	t.Run("連携前の請求書への入金はエラーを返して再送させる", func(t *testing.T) {
+		s, db := setupWebhookTest(t)
+
+		_, err := s.ProcessWebhook(ctx, paymentWebhook("evt-3", 503))
+
+		assert.Error(t, err)
+		var log model.FreeSyncLog
+		require.NoError(t, db.Where("event_id = ?", "evt-3").First(&log).Error)
+		assert.Equal(t, model.FreeSyncStatusFailed, log.Status)
+	})
+}
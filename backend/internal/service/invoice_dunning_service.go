@@ -0,0 +1,485 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// dunningJobName 督促スケジュールを保持するジョブ名
+const dunningJobName = "請求書督促（日次）"
+
+// dunningJobCron 督促バッチの実行時刻（batch.Schedulerの登録内容と合わせる）
+const dunningJobCron = "0 9 * * *"
+
+// InvoiceDunningServiceInterface 支払期限超過の検知と督促サービスインターフェース
+type InvoiceDunningServiceInterface interface {
+	// 実行
+	Run(ctx context.Context, executedBy *string) (*dto.DunningRunResultDTO, error)
+	MarkOverdueInvoices(ctx context.Context, now time.Time) (int64, error)
+
+	// 督促スケジュール
+	GetSchedule(ctx context.Context) (*dto.DunningScheduleDTO, error)
+	UpdateSchedule(ctx context.Context, userID string, req *dto.UpdateDunningScheduleRequest) (*dto.DunningScheduleDTO, error)
+
+	// タイムライン
+	GetInvoiceTimeline(ctx context.Context, invoiceID string) ([]*dto.InvoiceTimelineEntryDTO, error)
+}
+
+// invoiceDunningService 支払期限超過の検知と督促サービス実装
+type invoiceDunningService struct {
+	db           *gorm.DB
+	emailService EmailService
+	logger       *zap.Logger
+}
+
+// NewInvoiceDunningService 督促サービスのコンストラクタ
+func NewInvoiceDunningService(db *gorm.DB, emailService EmailService, logger *zap.Logger) InvoiceDunningServiceInterface {
+	return &invoiceDunningService{
+		db:           db,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// dunningSettings 督促ジョブの設定
+type dunningSettings struct {
+	jobID     string
+	enabled   bool
+	schedule  model.DunningSchedule
+	updatedAt *time.Time
+}
+
+// Run 支払期日を過ぎた請求書を期限超過にし、督促が有効であればスケジュールに従って督促する
+func (s *invoiceDunningService) Run(ctx context.Context, executedBy *string) (*dto.DunningRunResultDTO, error) {
+	now := time.Now()
+
+	marked, err := s.MarkOverdueInvoices(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.DunningRunResultDTO{
+		MarkedOverdue:  marked,
+		DunningEnabled: settings.enabled,
+	}
+	if !settings.enabled {
+		return result, nil
+	}
+
+	var invoiceIDs []string
+	err = s.db.WithContext(ctx).
+		Model(&model.Invoice{}).
+		Where("status = ?", model.InvoiceStatusOverdue).
+		Order("due_date ASC").
+		Pluck("id", &invoiceIDs).Error
+	if err != nil {
+		s.logger.Error("Failed to list overdue invoices", zap.Error(err))
+		return nil, fmt.Errorf("期限超過の請求書の取得に失敗しました: %w", err)
+	}
+	result.TargetCount = len(invoiceIDs)
+
+	// 1件の送信失敗で他の請求書の督促を止めない
+	for _, invoiceID := range invoiceIDs {
+		sent, err := s.dunInvoice(ctx, invoiceID, settings.schedule, now, executedBy)
+		switch {
+		case err != nil:
+			result.FailedCount++
+			s.logger.Error("Failed to send dunning notice",
+				zap.String("invoice_id", invoiceID),
+				zap.Error(err))
+		case sent:
+			result.SentCount++
+		default:
+			result.SkippedCount++
+		}
+	}
+
+	s.logger.Info("Invoice dunning completed",
+		zap.Int64("marked_overdue", result.MarkedOverdue),
+		zap.Int("targets", result.TargetCount),
+		zap.Int("sent", result.SentCount),
+		zap.Int("failed", result.FailedCount))
+
+	return result, nil
+}
+
+// MarkOverdueInvoices 支払期日を過ぎた送付済みの請求書を期限超過にする（赤伝は入金を待たないため対象外）
+// ステータスの変更はトリガーにより請求書のタイムラインに記録される
+func (s *invoiceDunningService) MarkOverdueInvoices(ctx context.Context, now time.Time) (int64, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	res := s.db.WithContext(ctx).
+		Model(&model.Invoice{}).
+		Where("status = ? AND due_date < ?", model.InvoiceStatusSent, today).
+		Where("invoice_type = ?", model.InvoiceTypeStandard).
+		Update("status", model.InvoiceStatusOverdue)
+	if res.Error != nil {
+		s.logger.Error("Failed to mark overdue invoices", zap.Error(res.Error))
+		return 0, fmt.Errorf("期限超過の請求書の更新に失敗しました: %w", res.Error)
+	}
+
+	if res.RowsAffected > 0 {
+		s.logger.Info("Marked invoices as overdue", zap.Int64("count", res.RowsAffected))
+	}
+	return res.RowsAffected, nil
+}
+
+// dunInvoice 請求書1件について次の督促ステップを実行する
+// 請求書をロックしてタイムラインを確認するため、同じステップが二重に送られることはない
+func (s *invoiceDunningService) dunInvoice(ctx context.Context, invoiceID string, schedule model.DunningSchedule, now time.Time, executedBy *string) (bool, error) {
+	sent := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invoice model.Invoice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", invoiceID).
+			First(&invoice).Error
+		if err != nil {
+			return fmt.Errorf("請求書の取得に失敗しました: %w", err)
+		}
+		// ロック待ちの間に入金消込されていれば督促しない
+		if invoice.Status != model.InvoiceStatusOverdue {
+			return nil
+		}
+		if err := tx.Where("id = ?", invoice.ClientID).First(&invoice.Client).Error; err != nil {
+			return fmt.Errorf("取引先の取得に失敗しました: %w", err)
+		}
+
+		done, err := doneDunningSteps(tx, invoiceID)
+		if err != nil {
+			return err
+		}
+		daysOverdue := model.DaysOverdue(invoice.DueDate, now)
+		step := schedule.NextStep(daysOverdue, done)
+		if step == nil {
+			return nil
+		}
+
+		paid, err := allocatedAmounts(tx, []string{invoiceID}, model.BankTransactionStatusConfirmed)
+		if err != nil {
+			return err
+		}
+		outstanding := yen(invoice.TotalAmount) - paid[invoiceID]
+
+		// 送信に失敗した場合はタイムラインの記録ごとロールバックし、次回に再送する
+		recipients, notes, err := s.sendDunning(ctx, tx, &invoice, step.Type, outstanding, daysOverdue)
+		if err != nil {
+			return err
+		}
+
+		newValues, err := json.Marshal(map[string]interface{}{
+			"step":               step.Type,
+			"days_overdue":       daysOverdue,
+			"outstanding_amount": outstanding,
+			"recipients":         recipients,
+		})
+		if err != nil {
+			return fmt.Errorf("督促内容の変換に失敗しました: %w", err)
+		}
+		entry := &model.InvoiceAuditLog{
+			InvoiceID:      invoice.ID,
+			Action:         step.Type.AuditAction(),
+			NewValues:      newValues,
+			ChangedBy:      executedBy,
+			ChangedAt:      now,
+			FreeeInvoiceID: invoice.FreeeInvoiceID,
+			Notes:          notes,
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("督促履歴の記録に失敗しました: %w", err)
+		}
+
+		sent = true
+		return nil
+	})
+	return sent, err
+}
+
+// sendDunning 督促ステップに応じたメールを送信し、送信先とタイムラインの備考を返す
+func (s *invoiceDunningService) sendDunning(ctx context.Context, tx *gorm.DB, invoice *model.Invoice, step model.DunningStepType, outstanding int64, daysOverdue int) ([]string, string, error) {
+	if step == model.DunningStepEscalation {
+		salesRep, err := primarySalesRep(tx, invoice.ClientID)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := s.emailService.SendInvoiceDunningEscalation(ctx, salesRep.Email, salesRep.FullName(), invoice, outstanding, daysOverdue); err != nil {
+			return nil, "", fmt.Errorf("エスカレーションの送信に失敗しました: %w", err)
+		}
+		return []string{salesRep.Email}, fmt.Sprintf("支払期日から%d日経過のため営業担当（%s）へエスカレーションしました", daysOverdue, salesRep.FullName()), nil
+	}
+
+	if invoice.Client.ContactEmail == "" {
+		return nil, "", fmt.Errorf("取引先の担当者メールアドレスが設定されていません: %s", invoice.Client.CompanyName)
+	}
+	to := []string{invoice.Client.ContactEmail}
+	if err := s.emailService.SendInvoiceDunningNotice(ctx, to, invoice, step, outstanding); err != nil {
+		return nil, "", fmt.Errorf("督促メールの送信に失敗しました: %w", err)
+	}
+
+	label := "お支払いのお願い"
+	if step == model.DunningStepSecondNotice {
+		label = "再通知"
+	}
+	return to, fmt.Sprintf("支払期日から%d日経過のため%sを送信しました", daysOverdue, label), nil
+}
+
+// GetSchedule 督促スケジュールを取得（未設定の場合は既定のスケジュールを無効の状態で返す）
+func (s *invoiceDunningService) GetSchedule(ctx context.Context) (*dto.DunningScheduleDTO, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dunningScheduleToDTO(settings), nil
+}
+
+// UpdateSchedule 督促スケジュールと有効・無効を更新
+func (s *invoiceDunningService) UpdateSchedule(ctx context.Context, userID string, req *dto.UpdateDunningScheduleRequest) (*dto.DunningScheduleDTO, error) {
+	schedule := model.DunningSchedule{Steps: make([]model.DunningStep, 0, len(req.Steps))}
+	for _, step := range req.Steps {
+		schedule.Steps = append(schedule.Steps, model.DunningStep{
+			Type:         model.DunningStepType(step.Type),
+			DaysAfterDue: step.DaysAfterDue,
+		})
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+
+	params, err := dunningScheduleToParameters(schedule)
+	if err != nil {
+		return nil, err
+	}
+	status := model.ScheduledJobStatusInactive
+	if *req.Enabled {
+		status = model.ScheduledJobStatusActive
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		settings, err := loadDunningSettings(tx)
+		if err != nil {
+			return err
+		}
+
+		if settings.jobID != "" {
+			return tx.Model(&model.ScheduledJob{}).
+				Where("id = ?", settings.jobID).
+				Updates(map[string]interface{}{
+					"status":     status,
+					"parameters": params,
+				}).Error
+		}
+
+		job := &model.ScheduledJob{
+			JobName:        dunningJobName,
+			JobType:        model.ScheduledJobTypeInvoiceReminder,
+			Description:    "支払期限を過ぎた請求書を期限超過にし、督促スケジュールに従って取引先・営業担当へ通知",
+			CronExpression: dunningJobCron,
+			Status:         status,
+			Parameters:     &params,
+			CreatedBy:      userID,
+		}
+		// scheduled_jobsに実行ログのカラムはないため、必要な項目のみ登録する
+		return tx.Select("ID", "JobName", "JobType", "Description", "CronExpression", "Status", "Parameters", "CreatedBy").
+			Create(job).Error
+	})
+	if err != nil {
+		s.logger.Error("Failed to update dunning schedule", zap.Error(err))
+		return nil, fmt.Errorf("督促スケジュールの更新に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Dunning schedule updated",
+		zap.String("user_id", userID),
+		zap.Bool("enabled", *req.Enabled))
+
+	return s.GetSchedule(ctx)
+}
+
+// GetInvoiceTimeline 請求書のタイムライン（作成・ステータス変更・督促などの履歴）を古い順に取得
+func (s *invoiceDunningService) GetInvoiceTimeline(ctx context.Context, invoiceID string) ([]*dto.InvoiceTimelineEntryDTO, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.Invoice{}).Where("id = ?", invoiceID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	if count == 0 {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "請求書が見つかりません").
+			WithDetail("invoice_id", invoiceID)
+	}
+
+	var logs []*model.InvoiceAuditLog
+	err := s.db.WithContext(ctx).
+		Where("invoice_id = ?", invoiceID).
+		Order("changed_at ASC").
+		Find(&logs).Error
+	if err != nil {
+		s.logger.Error("Failed to get invoice timeline", zap.Error(err), zap.String("invoice_id", invoiceID))
+		return nil, fmt.Errorf("請求書のタイムラインの取得に失敗しました: %w", err)
+	}
+
+	entries := make([]*dto.InvoiceTimelineEntryDTO, 0, len(logs))
+	for _, l := range logs {
+		entries = append(entries, &dto.InvoiceTimelineEntryDTO{
+			ID:        l.ID,
+			Action:    string(l.Action),
+			OldValues: l.OldValues,
+			NewValues: l.NewValues,
+			Notes:     l.Notes,
+			ChangedBy: l.ChangedBy,
+			ChangedAt: l.ChangedAt,
+		})
+	}
+	return entries, nil
+}
+
+// loadSettings 督促ジョブの設定を取得
+func (s *invoiceDunningService) loadSettings(ctx context.Context) (*dunningSettings, error) {
+	settings, err := loadDunningSettings(s.db.WithContext(ctx))
+	if err != nil {
+		s.logger.Error("Failed to load dunning schedule", zap.Error(err))
+		return nil, err
+	}
+	return settings, nil
+}
+
+// loadDunningSettings invoice_reminderジョブから督促スケジュールを読み込む
+// ジョブが未登録の場合は既定のスケジュールを無効の状態で返す
+func loadDunningSettings(db *gorm.DB) (*dunningSettings, error) {
+	var job model.ScheduledJob
+	err := db.Select("id", "status", "parameters", "updated_at").
+		Where("job_type = ?", model.ScheduledJobTypeInvoiceReminder).
+		Order("created_at ASC").
+		First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dunningSettings{schedule: model.DefaultDunningSchedule()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("督促スケジュールの取得に失敗しました: %w", err)
+	}
+
+	schedule := model.DefaultDunningSchedule()
+	if job.Parameters != nil && len(*job.Parameters) > 0 {
+		schedule, err = dunningScheduleFromParameters(*job.Parameters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updatedAt := job.UpdatedAt
+	return &dunningSettings{
+		jobID:     job.ID,
+		enabled:   job.Status == model.ScheduledJobStatusActive,
+		schedule:  schedule,
+		updatedAt: &updatedAt,
+	}, nil
+}
+
+// dunningScheduleFromParameters ジョブパラメータを督促スケジュールに変換
+func dunningScheduleFromParameters(params model.JobParameters) (model.DunningSchedule, error) {
+	var schedule model.DunningSchedule
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return schedule, fmt.Errorf("督促スケジュールの変換に失敗しました: %w", err)
+	}
+	if err := json.Unmarshal(raw, &schedule); err != nil {
+		return schedule, fmt.Errorf("督促スケジュールの形式が正しくありません: %w", err)
+	}
+	if err := schedule.Validate(); err != nil {
+		return schedule, fmt.Errorf("督促スケジュールが不正です: %w", err)
+	}
+	return schedule, nil
+}
+
+// dunningScheduleToParameters 督促スケジュールをジョブパラメータに変換
+func dunningScheduleToParameters(schedule model.DunningSchedule) (model.JobParameters, error) {
+	raw, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("督促スケジュールの変換に失敗しました: %w", err)
+	}
+	params := make(model.JobParameters)
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("督促スケジュールの変換に失敗しました: %w", err)
+	}
+	return params, nil
+}
+
+// doneDunningSteps タイムラインから実施済みの督促ステップを取得
+func doneDunningSteps(tx *gorm.DB, invoiceID string) (map[model.DunningStepType]bool, error) {
+	steps := []model.DunningStepType{model.DunningStepReminder, model.DunningStepSecondNotice, model.DunningStepEscalation}
+	actions := make([]model.InvoiceAuditAction, 0, len(steps))
+	for _, step := range steps {
+		actions = append(actions, step.AuditAction())
+	}
+
+	var recorded []model.InvoiceAuditAction
+	err := tx.Model(&model.InvoiceAuditLog{}).
+		Where("invoice_id = ? AND action IN ?", invoiceID, actions).
+		Distinct().
+		Pluck("action", &recorded).Error
+	if err != nil {
+		return nil, fmt.Errorf("督促履歴の取得に失敗しました: %w", err)
+	}
+
+	done := make(map[model.DunningStepType]bool)
+	for _, step := range steps {
+		for _, action := range recorded {
+			if step.AuditAction() == action {
+				done[step] = true
+			}
+		}
+	}
+	return done, nil
+}
+
+// primarySalesRep 取引先の主担当営業を取得
+func primarySalesRep(tx *gorm.DB, clientID string) (*model.User, error) {
+	var ext model.ClientSalesExtension
+	err := tx.Model(&model.Client{}).
+		Select("primary_sales_rep_id").
+		Where("id = ?", clientID).
+		Scan(&ext).Error
+	if err != nil {
+		return nil, fmt.Errorf("取引先の営業担当の取得に失敗しました: %w", err)
+	}
+	if ext.PrimarySalesRepID == nil || *ext.PrimarySalesRepID == "" {
+		return nil, fmt.Errorf("取引先に主担当営業が設定されていません: %s", clientID)
+	}
+
+	var user model.User
+	if err := tx.Where("id = ?", *ext.PrimarySalesRepID).First(&user).Error; err != nil {
+		return nil, fmt.Errorf("営業担当の取得に失敗しました: %w", err)
+	}
+	if user.Email == "" {
+		return nil, fmt.Errorf("営業担当のメールアドレスが設定されていません: %s", user.ID)
+	}
+	return &user, nil
+}
+
+// dunningScheduleToDTO 督促ジョブの設定をDTOに変換
+func dunningScheduleToDTO(settings *dunningSettings) *dto.DunningScheduleDTO {
+	steps := make([]dto.DunningStepDTO, 0, len(settings.schedule.Steps))
+	for _, step := range settings.schedule.Steps {
+		steps = append(steps, dto.DunningStepDTO{
+			Type:         string(step.Type),
+			DaysAfterDue: step.DaysAfterDue,
+		})
+	}
+	return &dto.DunningScheduleDTO{
+		Enabled:   settings.enabled,
+		Steps:     steps,
+		UpdatedAt: settings.updatedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// MockDunningEmailService 督促メールの送信を記録するメールサービスのモック
+type MockDunningEmailService struct {
+	mock.Mock
+	EmailService // テストで使わないメソッドは未実装
+}
+
+func (m *MockDunningEmailService) SendInvoiceDunningNotice(ctx context.Context, to []string, invoice *model.Invoice, step model.DunningStepType, outstanding int64) error {
+	args := m.Called(ctx, to, invoice.ID, step, outstanding)
+	return args.Error(0)
+}
+
+// setupDunningTest 請求書・取引先・タイムライン・消込のテーブルを持つSQLiteで督促サービスを作成
+func setupDunningTest(t *testing.T) (*invoiceDunningService, *MockDunningEmailService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.Invoice{}, &model.Client{}, &model.InvoiceAuditLog{}, &model.ScheduledJob{},
+		&model.BankTransaction{}, &model.BankTransactionAllocation{}))
+	require.NoError(t, db.Create(&model.Client{ID: "client-1", CompanyName: "テスト商事", ContactEmail: "billing@example.com"}).Error)
+
+	emailService := new(MockDunningEmailService)
+	return &invoiceDunningService{db: db, emailService: emailService, logger: zap.NewNop()}, emailService, db
+}
+
+// createDunningInvoice 送付済みの請求書を登録
+func createDunningInvoice(t *testing.T, db *gorm.DB, number string, dueDate time.Time) *model.Invoice {
+	invoice := &model.Invoice{
+		ClientID:      "client-1",
+		InvoiceNumber: number,
+		InvoiceType:   model.InvoiceTypeStandard,
+		InvoiceDate:   dueDate.AddDate(0, -1, 0),
+		DueDate:       dueDate,
+		BillingMonth:  dueDate.Format("2006-01"),
+		TotalAmount:   money.Yen(110000),
+		Status:        model.InvoiceStatusSent,
+	}
+	require.NoError(t, db.Create(invoice).Error)
+	return invoice
+}
+
+// enableDunning 既定の督促スケジュールを有効にする
+func enableDunning(t *testing.T, s *invoiceDunningService) {
+	enabled := true
+	req := &dto.UpdateDunningScheduleRequest{Enabled: &enabled}
+	for _, step := range model.DefaultDunningSchedule().Steps {
+		req.Steps = append(req.Steps, dto.DunningStepRequestDTO{Type: string(step.Type), DaysAfterDue: step.DaysAfterDue})
+	}
+	_, err := s.UpdateSchedule(context.Background(), "admin-1", req)
+	require.NoError(t, err)
+}
+
+func dunningLogs(t *testing.T, db *gorm.DB, invoiceID string) []model.InvoiceAuditLog {
+	var logs []model.InvoiceAuditLog
+	require.NoError(t, db.Where("invoice_id = ?", invoiceID).Find(&logs).Error)
+	return logs
+}
+
+func TestInvoiceDunningService_Run(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now()
+
+	t.Run("督促が無効の場合は期限超過にするだけで送信しない", func(t *testing.T) {
+		s, emailService, db := setupDunningTest(t)
+		invoice := createDunningInvoice(t, db, "INV-2026-0001", today.AddDate(0, 0, -3))
+		createDunningInvoice(t, db, "INV-2026-0002", today.AddDate(0, 0, 3))
+
+		result, err := s.Run(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.MarkedOverdue)
+		assert.False(t, result.DunningEnabled)
+		var updated model.Invoice
+		require.NoError(t, db.Where("id = ?", invoice.ID).First(&updated).Error)
+		assert.Equal(t, model.InvoiceStatusOverdue, updated.Status)
+		emailService.AssertNotCalled(t, "SendInvoiceDunningNotice")
+	})
+
+	t.Run("経過日数に応じたステップを未入金額で送信し、同じステップは二重に送らない", func(t *testing.T) {
+		s, emailService, db := setupDunningTest(t)
+		enableDunning(t, s)
+		// 期日から20日経過しているため、お支払いのお願いを飛ばして再通知を送る
+		invoice := createDunningInvoice(t, db, "INV-2026-0001", today.AddDate(0, 0, -20))
+		require.NoError(t, db.Create(&model.BankTransaction{ID: "txn-1", DedupeKey: "txn-1", Amount: 40000, Status: model.BankTransactionStatusConfirmed}).Error)
+		require.NoError(t, db.Omit("Invoice").Create(&model.BankTransactionAllocation{ID: "alloc-1", BankTransactionID: "txn-1", InvoiceID: invoice.ID, Amount: 40000}).Error)
+		emailService.On("SendInvoiceDunningNotice", mock.Anything, []string{"billing@example.com"}, invoice.ID, model.DunningStepSecondNotice, int64(70000)).
+			Return(nil).Once()
+
+		result, err := s.Run(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.TargetCount)
+		assert.Equal(t, 1, result.SentCount)
+
+		logs := dunningLogs(t, db, invoice.ID)
+		require.Len(t, logs, 1)
+		assert.Equal(t, model.DunningStepSecondNotice.AuditAction(), logs[0].Action)
+
+		result, err = s.Run(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.SentCount)
+		assert.Equal(t, 1, result.SkippedCount)
+		emailService.AssertExpectations(t)
+	})
+
+	t.Run("送信に失敗した請求書は履歴を残さず次回に再送する", func(t *testing.T) {
+		s, emailService, db := setupDunningTest(t)
+		enableDunning(t, s)
+		invoice := createDunningInvoice(t, db, "INV-2026-0001", today.AddDate(0, 0, -2))
+		emailService.On("SendInvoiceDunningNotice", mock.Anything, mock.Anything, invoice.ID, model.DunningStepReminder, int64(110000)).
+			Return(errors.New("smtp unavailable"))
+
+		result, err := s.Run(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.FailedCount)
+		assert.Empty(t, dunningLogs(t, db, invoice.ID))
+	})
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// InvoiceNumberingServiceInterface 請求書番号の採番サービスのインターフェース
+// 採番・紐づけ・欠番処理は請求書の作成・削除と同じトランザクションで呼び出す
+type InvoiceNumberingServiceInterface interface {
+	Allocate(tx *gorm.DB, clientID string, invoiceDate time.Time, allocatedBy string) (string, error)
+	Assign(tx *gorm.DB, invoiceNumber, invoiceID string) error
+	Void(tx *gorm.DB, invoiceNumber, reason, userID string) error
+	GetLedger(ctx context.Context, req *dto.InvoiceNumberLedgerRequest) (*dto.InvoiceNumberLedgerDTO, error)
+}
+
+// invoiceNumberingService 請求書番号の採番サービスの実装
+type invoiceNumberingService struct {
+	db                   *gorm.DB
+	pattern              *model.InvoiceNumberPattern
+	fiscalYearStartMonth int
+	driver               string
+	logger               *zap.Logger
+}
+
+// NewInvoiceNumberingService 請求書番号の採番サービスのインスタンスを生成
+// driverはconfig.Database.Driver（mysql / postgres）
+func NewInvoiceNumberingService(db *gorm.DB, cfg *config.InvoiceNumberingConfig, driver string, logger *zap.Logger) (InvoiceNumberingServiceInterface, error) {
+	pattern, err := model.ParseInvoiceNumberPattern(cfg.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.FiscalYearStartMonth < 1 || cfg.FiscalYearStartMonth > 12 {
+		return nil, fmt.Errorf("会計年度の開始月は1〜12で指定してください: %d", cfg.FiscalYearStartMonth)
+	}
+	return &invoiceNumberingService{
+		db:                   db,
+		pattern:              pattern,
+		fiscalYearStartMonth: cfg.FiscalYearStartMonth,
+		driver:               driver,
+		logger:               logger,
+	}, nil
+}
+
+// Allocate 採番パターンに従って次の請求書番号を払い出す
+// 連番の行をトランザクション終了までロックするため、同時に採番しても番号は重複・欠落しない。
+// 手入力などで既に使われている番号に当たった場合は、理由を付けて欠番として記録し次の番号に進む
+func (s *invoiceNumberingService) Allocate(tx *gorm.DB, clientID string, invoiceDate time.Time, allocatedBy string) (string, error) {
+	params := model.InvoiceNumberParams{
+		FiscalYear:  model.FiscalYearOf(invoiceDate, s.fiscalYearStartMonth),
+		InvoiceDate: invoiceDate,
+	}
+	if s.pattern.UsesClientPrefix() {
+		var client model.Client
+		if err := tx.Select("id", "invoice_number_prefix").Where("id = ?", clientID).First(&client).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "取引先が見つかりません").
+					WithDetail("client_id", clientID)
+			}
+			return "", fmt.Errorf("取引先の取得に失敗しました: %w", err)
+		}
+		if client.InvoiceNumberPrefix == "" {
+			return "", accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+				"取引先の請求書番号プレフィックスが設定されていません").
+				WithDetail("client_id", clientID)
+		}
+		params.ClientPrefix = client.InvoiceNumberPrefix
+	}
+	scopeKey := s.pattern.ScopeKey(params)
+
+	var clientRef *string
+	if clientID != "" {
+		clientRef = &clientID
+	}
+	for {
+		seq, err := s.nextSequence(tx, scopeKey, params.FiscalYear)
+		if err != nil {
+			return "", err
+		}
+		number := s.pattern.Format(params, seq)
+		if len(number) > model.InvoiceNumberMaxLength {
+			return "", accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+				fmt.Sprintf("請求書番号が%d文字を超えます", model.InvoiceNumberMaxLength)).
+				WithDetail("invoice_number", number)
+		}
+
+		allocation := &model.InvoiceNumberAllocation{
+			ScopeKey:      scopeKey,
+			FiscalYear:    params.FiscalYear,
+			Sequence:      seq,
+			InvoiceNumber: number,
+			ClientID:      clientRef,
+			Status:        model.InvoiceNumberAllocationStatusAllocated,
+			AllocatedBy:   allocatedBy,
+		}
+
+		var used int64
+		if err := tx.Unscoped().Model(&model.Invoice{}).Where("invoice_number = ?", number).Count(&used).Error; err != nil {
+			return "", fmt.Errorf("請求書番号の確認に失敗しました: %w", err)
+		}
+		if used > 0 {
+			allocation.Void(time.Now(), "採番前から請求書で使用されている番号", allocatedBy)
+		}
+		if err := tx.Create(allocation).Error; err != nil {
+			return "", fmt.Errorf("請求書番号の記録に失敗しました: %w", err)
+		}
+		if used > 0 {
+			s.logger.Warn("Invoice number already in use, recorded as voided",
+				zap.String("invoice_number", number))
+			continue
+		}
+		return number, nil
+	}
+}
+
+// nextSequence 採番範囲の連番を1つ進めて返す
+// 連番の行はトランザクションが終わるまでロックされ、ロールバックすると連番も元に戻る
+func (s *invoiceNumberingService) nextSequence(tx *gorm.DB, scopeKey string, fiscalYear int) (int, error) {
+	sequence := &model.InvoiceNumberSequence{ScopeKey: scopeKey, FiscalYear: fiscalYear}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(sequence).Error; err != nil {
+		return 0, fmt.Errorf("請求書番号の連番の作成に失敗しました: %w", err)
+	}
+
+	var next int
+	switch s.driver {
+	case "mysql":
+		// MySQLはRETURNINGがないため、行ロックを取ってから更新する
+		var current model.InvoiceNumberSequence
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope_key = ?", scopeKey).
+			First(&current).Error; err != nil {
+			return 0, fmt.Errorf("請求書番号の連番の取得に失敗しました: %w", err)
+		}
+		next = current.LastValue + 1
+		if err := tx.Model(&model.InvoiceNumberSequence{}).
+			Where("scope_key = ?", scopeKey).
+			Update("last_value", next).Error; err != nil {
+			return 0, fmt.Errorf("請求書番号の連番の更新に失敗しました: %w", err)
+		}
+	default:
+		if err := tx.Raw(
+			"UPDATE invoice_number_sequences SET last_value = last_value + 1, updated_at = ? WHERE scope_key = ? RETURNING last_value",
+			time.Now(), scopeKey,
+		).Scan(&next).Error; err != nil {
+			return 0, fmt.Errorf("請求書番号の連番の更新に失敗しました: %w", err)
+		}
+		if next == 0 {
+			return 0, fmt.Errorf("請求書番号の連番が見つかりません: %s", scopeKey)
+		}
+	}
+	return next, nil
+}
+
+// Assign 払い出した番号を請求書に紐づける
+// 台帳にない番号（手入力や採番導入前の番号）は何もしない
+func (s *invoiceNumberingService) Assign(tx *gorm.DB, invoiceNumber, invoiceID string) error {
+	err := tx.Model(&model.InvoiceNumberAllocation{}).
+		Where("invoice_number = ? AND status = ?", invoiceNumber, model.InvoiceNumberAllocationStatusAllocated).
+		Update("invoice_id", invoiceID).Error
+	if err != nil {
+		return fmt.Errorf("請求書番号の紐づけに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Void 番号を欠番にする
+// 台帳にない番号と既に欠番の番号は何もしない
+func (s *invoiceNumberingService) Void(tx *gorm.DB, invoiceNumber, reason, userID string) error {
+	var allocation model.InvoiceNumberAllocation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("invoice_number = ?", invoiceNumber).
+		First(&allocation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("請求書番号の取得に失敗しました: %w", err)
+	}
+	if allocation.IsVoided() {
+		return nil
+	}
+
+	allocation.Void(time.Now(), reason, userID)
+	if err := tx.Save(&allocation).Error; err != nil {
+		return fmt.Errorf("請求書番号の欠番処理に失敗しました: %w", err)
+	}
+	s.logger.Info("Invoice number voided",
+		zap.String("invoice_number", invoiceNumber),
+		zap.String("reason", reason),
+		zap.String("user_id", userID))
+	return nil
+}
+
+// GetLedger 請求書番号の台帳を取得
+// 採番範囲ごとに最終連番と台帳の件数を突き合わせ、記録のない連番がないか確認できるようにする
+func (s *invoiceNumberingService) GetLedger(ctx context.Context, req *dto.InvoiceNumberLedgerRequest) (*dto.InvoiceNumberLedgerDTO, error) {
+	db := s.db.WithContext(ctx)
+	page, limit := normalizePage(req.Page, req.Limit)
+
+	sequenceQuery := db.Model(&model.InvoiceNumberSequence{})
+	if req.FiscalYear > 0 {
+		sequenceQuery = sequenceQuery.Where("fiscal_year = ?", req.FiscalYear)
+	}
+	var sequences []model.InvoiceNumberSequence
+	if err := sequenceQuery.Order("fiscal_year DESC, scope_key").Find(&sequences).Error; err != nil {
+		return nil, fmt.Errorf("請求書番号の連番の取得に失敗しました: %w", err)
+	}
+
+	countQuery := db.Model(&model.InvoiceNumberAllocation{}).
+		Select("scope_key, status, COUNT(*) AS count")
+	if req.FiscalYear > 0 {
+		countQuery = countQuery.Where("fiscal_year = ?", req.FiscalYear)
+	}
+	var counts []struct {
+		ScopeKey string
+		Status   model.InvoiceNumberAllocationStatus
+		Count    int
+	}
+	if err := countQuery.Group("scope_key, status").Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("請求書番号の集計に失敗しました: %w", err)
+	}
+
+	sequenceByKey := make(map[string]*dto.InvoiceNumberSequenceDTO, len(sequences))
+	result := &dto.InvoiceNumberLedgerDTO{
+		Pattern:              s.pattern.String(),
+		FiscalYearStartMonth: s.fiscalYearStartMonth,
+		Sequences:            make([]dto.InvoiceNumberSequenceDTO, len(sequences)),
+		Page:                 page,
+		Limit:                limit,
+	}
+	for i, sequence := range sequences {
+		result.Sequences[i] = dto.InvoiceNumberSequenceDTO{
+			ScopeKey:   sequence.ScopeKey,
+			FiscalYear: sequence.FiscalYear,
+			LastValue:  sequence.LastValue,
+		}
+		sequenceByKey[sequence.ScopeKey] = &result.Sequences[i]
+	}
+	for _, count := range counts {
+		sequence, ok := sequenceByKey[count.ScopeKey]
+		if !ok {
+			continue
+		}
+		if count.Status == model.InvoiceNumberAllocationStatusVoided {
+			sequence.VoidedCount += count.Count
+		} else {
+			sequence.AllocatedCount += count.Count
+		}
+	}
+	for i := range result.Sequences {
+		sequence := &result.Sequences[i]
+		sequence.MissingCount = sequence.LastValue - sequence.AllocatedCount - sequence.VoidedCount
+	}
+
+	allocationQuery := db.Model(&model.InvoiceNumberAllocation{})
+	if req.FiscalYear > 0 {
+		allocationQuery = allocationQuery.Where("fiscal_year = ?", req.FiscalYear)
+	}
+	if req.Status != "" {
+		allocationQuery = allocationQuery.Where("status = ?", req.Status)
+	}
+	if err := allocationQuery.Count(&result.Total).Error; err != nil {
+		return nil, fmt.Errorf("請求書番号の件数の取得に失敗しました: %w", err)
+	}
+	var allocations []model.InvoiceNumberAllocation
+	if err := allocationQuery.
+		Order("fiscal_year DESC, scope_key, sequence").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&allocations).Error; err != nil {
+		return nil, fmt.Errorf("請求書番号の取得に失敗しました: %w", err)
+	}
+
+	result.Allocations = make([]dto.InvoiceNumberAllocationDTO, len(allocations))
+	for i, allocation := range allocations {
+		result.Allocations[i] = dto.InvoiceNumberAllocationDTO{
+			InvoiceNumber: allocation.InvoiceNumber,
+			FiscalYear:    allocation.FiscalYear,
+			Sequence:      allocation.Sequence,
+			Status:        string(allocation.Status),
+			InvoiceID:     allocation.InvoiceID,
+			ClientID:      allocation.ClientID,
+			AllocatedBy:   allocation.AllocatedBy,
+			AllocatedAt:   allocation.CreatedAt,
+			VoidReason:    allocation.VoidReason,
+			VoidedBy:      allocation.VoidedBy,
+			VoidedAt:      allocation.VoidedAt,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/duesk/monstera/internal/config"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/invoicepdf"
+	"go.uber.org/zap"
+)
+
+// InvoicePDFService 請求書PDF生成サービスのインターフェース
+type InvoicePDFService interface {
+	GeneratePDF(invoice *model.Invoice) ([]byte, error)
+}
+
+// invoicePDFService 請求書PDF生成サービスの実装
+type invoicePDFService struct {
+	pdfConfig *config.InvoicePDFConfig
+	company   *config.CompanyConfig
+	logger    *zap.Logger
+
+	once     sync.Once
+	renderer *invoicepdf.Renderer
+	initErr  error
+}
+
+// NewInvoicePDFService 請求書PDF生成サービスのインスタンスを生成
+// フォントとテンプレートは初回生成時に読み込む
+func NewInvoicePDFService(pdfConfig *config.InvoicePDFConfig, company *config.CompanyConfig, logger *zap.Logger) InvoicePDFService {
+	return &invoicePDFService{
+		pdfConfig: pdfConfig,
+		company:   company,
+		logger:    logger,
+	}
+}
+
+// GeneratePDF 請求書PDFを生成
+// invoiceにはClient・Details・TaxSummariesがPreloadされている必要がある
+func (s *invoicePDFService) GeneratePDF(invoice *model.Invoice) ([]byte, error) {
+	renderer, err := s.getRenderer()
+	if err != nil {
+		return nil, err
+	}
+
+	templateName := invoice.Client.InvoiceTemplate
+	if templateName != "" && !renderer.HasTemplate(templateName) {
+		s.logger.Warn("Invoice template not found, falling back to default",
+			zap.String("template", templateName),
+			zap.String("client_id", invoice.ClientID))
+	}
+
+	data, err := renderer.Render(s.buildDocument(invoice), templateName)
+	if err != nil {
+		s.logger.Error("Failed to render invoice PDF",
+			zap.String("invoice_id", invoice.ID),
+			zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// getRenderer フォントとテンプレートを読み込んでレンダラーを初期化
+func (s *invoicePDFService) getRenderer() (*invoicepdf.Renderer, error) {
+	s.once.Do(func() {
+		regular, err := os.ReadFile(s.pdfConfig.FontPath)
+		if err != nil {
+			s.initErr = fmt.Errorf("請求書PDF用フォントの読み込みに失敗しました: %w", err)
+			return
+		}
+		var bold []byte
+		if s.pdfConfig.BoldFontPath != "" {
+			if bold, err = os.ReadFile(s.pdfConfig.BoldFontPath); err != nil {
+				s.initErr = fmt.Errorf("請求書PDF用フォントの読み込みに失敗しました: %w", err)
+				return
+			}
+		}
+		templates, err := invoicepdf.LoadTemplates(s.pdfConfig.TemplateDir)
+		if err != nil {
+			s.initErr = err
+			return
+		}
+		s.renderer, s.initErr = invoicepdf.NewRenderer(regular, bold, templates)
+	})
+	if s.initErr != nil {
+		s.logger.Error("Failed to initialize invoice PDF renderer", zap.Error(s.initErr))
+	}
+	return s.renderer, s.initErr
+}
+
+// buildDocument 請求書モデルを描画データに変換
+func (s *invoicePDFService) buildDocument(invoice *model.Invoice) *invoicepdf.Document {
+	doc := &invoicepdf.Document{
+		InvoiceNumber: invoice.InvoiceNumber,
+		InvoiceDate:   invoice.InvoiceDate,
+		DueDate:       invoice.DueDate,
+		BillingMonth:  invoice.BillingMonth,
+		Recipient: invoicepdf.Recipient{
+			Name:          invoice.Client.CompanyName,
+			Address:       invoice.Client.Address,
+			ContactPerson: invoice.Client.ContactPerson,
+		},
+		Issuer: invoicepdf.Issuer{
+			Name:               s.company.Name,
+			PostalCode:         s.company.PostalCode,
+			Address:            s.company.Address,
+			Phone:              s.company.Phone,
+			Email:              s.company.Email,
+			SealImagePath:      s.company.SealImagePath,
+			RegistrationNumber: invoice.RegistrationNumber,
+		},
+		BankAccount: invoicepdf.BankAccount{
+			BankName:      s.company.BankName,
+			BranchName:    s.company.BankBranchName,
+			AccountType:   s.company.BankAccountType,
+			AccountNumber: s.company.BankAccountNumber,
+			AccountHolder: s.company.BankAccountHolder,
+		},
+		Subtotal:    invoice.Subtotal.Float64(),
+		TaxAmount:   invoice.TaxAmount.Float64(),
+		TotalAmount: invoice.TotalAmount.Float64(),
+		Notes:       invoice.Notes,
+	}
+
+	// 赤伝は入金を求める書類ではないため、支払期限と振込先を載せない
+	if invoice.IsCreditNote() {
+		doc.Title = "請求書（赤伝）"
+		doc.DueDate = time.Time{}
+		doc.BankAccount = invoicepdf.BankAccount{}
+	}
+
+	for _, detail := range invoice.Details {
+		doc.Lines = append(doc.Lines, invoicepdf.Line{
+			Description: detail.Description,
+			Quantity:    detail.Quantity,
+			UnitPrice:   detail.UnitPrice.Float64(),
+			Amount:      detail.Amount.Float64(),
+			ReducedRate: detail.TaxCategory.IsReduced(),
+		})
+	}
+
+	// 登録番号は発行時点の値を優先し、未保存の既存請求書は現在の設定値を使用する
+	if doc.Issuer.RegistrationNumber == "" {
+		doc.Issuer.RegistrationNumber = s.company.InvoiceRegistrationNumber
+	}
+
+	// 税率ごとの対価の額と消費税額
+	for _, summary := range invoice.TaxBreakdown() {
+		doc.TaxSummaries = append(doc.TaxSummaries, invoicepdf.TaxSummary{
+			Label:         summary.TaxCategory.Label(),
+			TaxRate:       summary.TaxRate,
+			TaxableAmount: summary.TaxableAmount.Float64(),
+			TaxAmount:     summary.TaxAmount.Float64(),
+		})
+	}
+
+	return doc
+}
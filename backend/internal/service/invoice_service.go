@@ -2,26 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/duesk/monstera/internal/config"
 	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
 	"github.com/duesk/monstera/internal/repository"
+	"github.com/duesk/monstera/pkg/money"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InvoiceService 請求書サービスのインターフェース
 type InvoiceService interface {
 	GetInvoices(ctx context.Context, req *dto.InvoiceSearchRequest) ([]dto.InvoiceDTO, int64, error)
 	GetInvoiceByID(ctx context.Context, invoiceID string) (*dto.InvoiceDetailDTO, error)
-	CreateInvoice(ctx context.Context, req *dto.CreateInvoiceRequest) (*dto.InvoiceDetailDTO, error)
+	CreateInvoice(ctx context.Context, userID string, req *dto.CreateInvoiceRequest) (*dto.InvoiceDetailDTO, error)
 	UpdateInvoice(ctx context.Context, invoiceID string, req *dto.UpdateInvoiceRequest) (*dto.InvoiceDTO, error)
 	UpdateInvoiceStatus(ctx context.Context, invoiceID string, req *dto.UpdateInvoiceStatusRequest) (*dto.InvoiceDTO, error)
-	DeleteInvoice(ctx context.Context, invoiceID string) error
+	DeleteInvoice(ctx context.Context, invoiceID, userID string) error
 	GetInvoiceSummary(ctx context.Context, clientID *string, dateFrom, dateTo *time.Time) (*dto.InvoiceSummaryDTO, error)
 	ExportInvoicePDF(ctx context.Context, invoiceID string) ([]byte, error)
+	CancelInvoice(ctx context.Context, invoiceID, userID string, req *dto.CancelInvoiceRequest) (*dto.InvoiceRevisionResultDTO, error)
+	ReviseInvoice(ctx context.Context, invoiceID, userID string, req *dto.ReviseInvoiceRequest) (*dto.InvoiceRevisionResultDTO, error)
+	GetInvoiceRevisions(ctx context.Context, invoiceID string) ([]dto.InvoiceDTO, error)
 }
 
 // invoiceService 請求書サービスの実装
@@ -31,6 +38,9 @@ type invoiceService struct {
 	clientRepo  repository.ClientRepository
 	projectRepo repository.ProjectRepository
 	userRepo    repository.UserRepository
+	pdfService  InvoicePDFService
+	numbering   InvoiceNumberingServiceInterface
+	company     *config.CompanyConfig
 	logger      *zap.Logger
 }
 
@@ -41,6 +51,9 @@ func NewInvoiceService(
 	clientRepo repository.ClientRepository,
 	projectRepo repository.ProjectRepository,
 	userRepo repository.UserRepository,
+	pdfService InvoicePDFService,
+	numbering InvoiceNumberingServiceInterface,
+	company *config.CompanyConfig,
 	logger *zap.Logger,
 ) InvoiceService {
 	return &invoiceService{
@@ -49,6 +62,9 @@ func NewInvoiceService(
 		clientRepo:  clientRepo,
 		projectRepo: projectRepo,
 		userRepo:    userRepo,
+		pdfService:  pdfService,
+		numbering:   numbering,
+		company:     company,
 		logger:      logger,
 	}
 }
@@ -68,6 +84,7 @@ func (s *invoiceService) GetInvoices(ctx context.Context, req *dto.InvoiceSearch
 	// クエリ構築
 	query := s.db.WithContext(ctx).Model(&model.Invoice{}).
 		Preload("Client").
+		Preload("TaxSummaries").
 		Where("invoices.deleted_at IS NULL")
 
 	// 検索条件
@@ -79,6 +96,10 @@ func (s *invoiceService) GetInvoices(ctx context.Context, req *dto.InvoiceSearch
 		query = query.Where("status = ?", req.Status)
 	}
 
+	if req.InvoiceType != "" {
+		query = query.Where("invoice_type = ?", req.InvoiceType)
+	}
+
 	if req.DateFrom != nil {
 		query = query.Where("invoice_date >= ?", *req.DateFrom)
 	}
@@ -124,6 +145,7 @@ func (s *invoiceService) GetInvoiceByID(ctx context.Context, invoiceID string) (
 		}).
 		Preload("Details.Project").
 		Preload("Details.User").
+		Preload("TaxSummaries").
 		Where("id = ? AND deleted_at IS NULL", invoiceID).
 		First(&invoice).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -147,7 +169,8 @@ func (s *invoiceService) GetInvoiceByID(ctx context.Context, invoiceID string) (
 }
 
 // CreateInvoice 請求書を作成
-func (s *invoiceService) CreateInvoice(ctx context.Context, req *dto.CreateInvoiceRequest) (*dto.InvoiceDetailDTO, error) {
+// 請求書番号を指定しない場合は採番パターンに従って払い出す
+func (s *invoiceService) CreateInvoice(ctx context.Context, userID string, req *dto.CreateInvoiceRequest) (*dto.InvoiceDetailDTO, error) {
 	// 取引先の存在確認
 	exists, err := s.clientRepo.Exists(ctx, req.ClientID)
 	if err != nil {
@@ -158,52 +181,63 @@ func (s *invoiceService) CreateInvoice(ctx context.Context, req *dto.CreateInvoi
 	}
 
 	// 請求書番号の重複確認
-	existing, err := s.invoiceRepo.FindByInvoiceNumber(ctx, req.InvoiceNumber)
-	if err != nil {
-		return nil, err
+	if req.InvoiceNumber != "" {
+		existing, err := s.invoiceRepo.FindByInvoiceNumber(ctx, req.InvoiceNumber)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("請求書番号が既に使用されています")
+		}
 	}
-	if existing != nil {
-		return nil, fmt.Errorf("請求書番号が既に使用されています")
+
+	// 登録番号の確認
+	registrationNumber := s.company.InvoiceRegistrationNumber
+	if registrationNumber != "" && !model.IsValidInvoiceRegistrationNumber(registrationNumber) {
+		return nil, fmt.Errorf("適格請求書発行事業者登録番号の形式が正しくありません")
 	}
 
 	// モデルに変換
 	invoice := &model.Invoice{
-		ClientID:      req.ClientID,
-		InvoiceNumber: req.InvoiceNumber,
-		InvoiceDate:   req.InvoiceDate,
-		DueDate:       req.DueDate,
-		Status:        model.InvoiceStatusDraft,
-		Notes:         req.Notes,
-	}
-
-	// 明細の作成と金額計算
-	details := make([]*model.InvoiceDetail, len(req.Details))
-	subtotal := 0.0
-
-	for i, item := range req.Details {
-		amount := item.Quantity * item.UnitPrice
-		subtotal += amount
-
-		details[i] = &model.InvoiceDetail{
-			ProjectID:   item.ProjectID,
-			UserID:      item.UserID,
-			Description: item.Description,
-			Quantity:    item.Quantity,
-			UnitPrice:   item.UnitPrice,
-			Amount:      amount,
+		ClientID:           req.ClientID,
+		InvoiceNumber:      req.InvoiceNumber,
+		InvoiceDate:        req.InvoiceDate,
+		DueDate:            req.DueDate,
+		Status:             model.InvoiceStatusDraft,
+		Notes:              req.Notes,
+		CreatedBy:          userID,
+		RegistrationNumber: registrationNumber,
+	}
+
+	// 明細の作成と税率別の金額計算
+	details := buildInvoiceDetails(req.Details)
+	applyInvoiceTax(invoice, details)
+
+	// 採番と作成を同じトランザクションで行い、失敗時に番号が欠けないようにする
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if invoice.InvoiceNumber == "" {
+			number, err := s.numbering.Allocate(tx, invoice.ClientID, invoice.InvoiceDate, userID)
+			if err != nil {
+				return err
+			}
+			invoice.InvoiceNumber = number
 		}
-	}
-
-	// 税額計算（10%）
-	tax := subtotal * 0.10
-	totalAmount := subtotal + tax
-
-	invoice.Subtotal = subtotal
-	invoice.TaxAmount = tax
-	invoice.TotalAmount = totalAmount
 
-	// トランザクションで作成
-	if err := s.invoiceRepo.CreateWithDetails(ctx, invoice, details); err != nil {
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+		if len(details) > 0 {
+			for i, detail := range details {
+				detail.InvoiceID = invoice.ID
+				detail.OrderIndex = i + 1
+			}
+			if err := tx.Create(&details).Error; err != nil {
+				return err
+			}
+		}
+		return s.numbering.Assign(tx, invoice.InvoiceNumber, invoice.ID)
+	})
+	if err != nil {
 		s.logger.Error("Failed to create invoice", zap.Error(err))
 		return nil, err
 	}
@@ -238,6 +272,17 @@ func (s *invoiceService) UpdateInvoice(ctx context.Context, invoiceID string, re
 		return nil, fmt.Errorf("ドラフト状態の請求書のみ更新できます")
 	}
 
+	// 承認済みの月次請求の請求書は変更不可
+	locked, err := isBillingRunLocked(tx, &invoice)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if locked {
+		tx.Rollback()
+		return nil, fmt.Errorf("承認済みの月次請求の請求書は変更できません")
+	}
+
 	// 更新
 	updates := map[string]interface{}{}
 	if req.InvoiceDate != nil {
@@ -250,7 +295,46 @@ func (s *invoiceService) UpdateInvoice(ctx context.Context, invoiceID string, re
 		updates["notes"] = *req.Notes
 	}
 
-	if err := tx.Model(&invoice).Updates(updates).Error; err != nil {
+	// 明細の置き換えと税額の再計算
+	if req.Details != nil {
+		details := buildInvoiceDetails(req.Details)
+		applyInvoiceTax(&invoice, details)
+
+		if err := tx.Where("invoice_id = ?", invoiceID).Delete(&model.InvoiceDetail{}).Error; err != nil {
+			tx.Rollback()
+			s.logger.Error("Failed to delete invoice details", zap.Error(err))
+			return nil, err
+		}
+		if err := tx.Where("invoice_id = ?", invoiceID).Delete(&model.InvoiceTaxSummary{}).Error; err != nil {
+			tx.Rollback()
+			s.logger.Error("Failed to delete invoice tax summaries", zap.Error(err))
+			return nil, err
+		}
+		for i, detail := range details {
+			detail.InvoiceID = invoiceID
+			detail.OrderIndex = i + 1
+		}
+		if err := tx.Create(&details).Error; err != nil {
+			tx.Rollback()
+			s.logger.Error("Failed to create invoice details", zap.Error(err))
+			return nil, err
+		}
+		for i := range invoice.TaxSummaries {
+			invoice.TaxSummaries[i].InvoiceID = invoiceID
+		}
+		if err := tx.Create(&invoice.TaxSummaries).Error; err != nil {
+			tx.Rollback()
+			s.logger.Error("Failed to create invoice tax summaries", zap.Error(err))
+			return nil, err
+		}
+
+		updates["subtotal"] = invoice.Subtotal
+		updates["tax_rate"] = invoice.TaxRate
+		updates["tax_amount"] = invoice.TaxAmount
+		updates["total_amount"] = invoice.TotalAmount
+	}
+
+	if err := tx.Model(&invoice).Omit("TaxSummaries").Updates(updates).Error; err != nil {
 		tx.Rollback()
 		s.logger.Error("Failed to update invoice", zap.Error(err))
 		return nil, err
@@ -260,18 +344,17 @@ func (s *invoiceService) UpdateInvoice(ctx context.Context, invoiceID string, re
 		return nil, err
 	}
 
-	// 更新後のデータを取得
-	updated, err := s.invoiceRepo.FindByID(ctx, invoiceID)
-	if err != nil {
-		return nil, err
-	}
-
-	// クライアント情報を取得
-	if err := s.db.WithContext(ctx).Preload("Client").First(updated, invoiceID).Error; err != nil {
+	// 更新後のデータを取引先・税率別小計とともに取得
+	var updated model.Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("TaxSummaries").
+		Where("id = ?", invoiceID).
+		First(&updated).Error; err != nil {
 		return nil, err
 	}
 
-	dto := s.modelToDTO(updated)
+	dto := s.modelToDTO(&updated)
 	return &dto, nil
 }
 
@@ -295,8 +378,20 @@ func (s *invoiceService) UpdateInvoiceStatus(ctx context.Context, invoiceID stri
 		return nil, err
 	}
 
-	// ステータス遷移の検証
+	// 赤伝は発行時点で確定しており、ステータスを変更しない
+	if invoice.IsCreditNote() {
+		tx.Rollback()
+		return nil, fmt.Errorf("赤伝のステータスは変更できません")
+	}
+
+	// 発行済みの請求書のキャンセルは赤伝の発行で行う
 	newStatus := model.InvoiceStatus(req.Status)
+	if newStatus == model.InvoiceStatusCancelled && invoice.IsIssued() {
+		tx.Rollback()
+		return nil, fmt.Errorf("発行済みの請求書は赤伝を発行して取り消してください")
+	}
+
+	// ステータス遷移の検証
 	if !s.isValidStatusTransition(invoice.Status, newStatus) {
 		tx.Rollback()
 		return nil, fmt.Errorf("無効なステータス遷移です")
@@ -310,9 +405,9 @@ func (s *invoiceService) UpdateInvoiceStatus(ctx context.Context, invoiceID stri
 	// 支払済みの場合は支払日を設定
 	if newStatus == model.InvoiceStatusPaid {
 		if req.PaymentDate != nil {
-			updates["payment_date"] = *req.PaymentDate
+			updates["paid_date"] = *req.PaymentDate
 		} else {
-			updates["payment_date"] = time.Now()
+			updates["paid_date"] = time.Now()
 		}
 	}
 
@@ -342,7 +437,8 @@ func (s *invoiceService) UpdateInvoiceStatus(ctx context.Context, invoiceID stri
 }
 
 // DeleteInvoice 請求書を削除
-func (s *invoiceService) DeleteInvoice(ctx context.Context, invoiceID string) error {
+// 払い出した請求書番号は欠番として台帳に残す
+func (s *invoiceService) DeleteInvoice(ctx context.Context, invoiceID, userID string) error {
 	// 既存の請求書を取得
 	invoice, err := s.invoiceRepo.FindByID(ctx, invoiceID)
 	if err != nil {
@@ -357,8 +453,23 @@ func (s *invoiceService) DeleteInvoice(ctx context.Context, invoiceID string) er
 		return fmt.Errorf("ドラフト状態の請求書のみ削除できます")
 	}
 
+	// 承認済みの月次請求の請求書は削除不可
+	locked, err := isBillingRunLocked(s.db.WithContext(ctx), invoice)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return fmt.Errorf("承認済みの月次請求の請求書は削除できません")
+	}
+
 	// 論理削除
-	if err := s.invoiceRepo.Delete(ctx, invoice); err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(invoice).Error; err != nil {
+			return err
+		}
+		return s.numbering.Void(tx, invoice.InvoiceNumber, "請求書の削除", userID)
+	})
+	if err != nil {
 		s.logger.Error("Failed to delete invoice", zap.Error(err))
 		return err
 	}
@@ -382,18 +493,341 @@ func (s *invoiceService) GetInvoiceSummary(ctx context.Context, clientID *string
 		SentCount:     summary.SentCount,
 		PaidCount:     summary.PaidCount,
 		OverdueCount:  summary.OverdueCount,
+
+		CreditNoteAmount: summary.CreditNoteAmount,
+		CreditNoteCount:  summary.CreditNoteCount,
 	}, nil
 }
 
 // ExportInvoicePDF 請求書をPDF形式でエクスポート
 func (s *invoiceService) ExportInvoicePDF(ctx context.Context, invoiceID string) ([]byte, error) {
-	// TODO: PDF生成の実装
-	// 現在は仮実装
-	return nil, fmt.Errorf("PDF export not implemented yet")
+	var invoice model.Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("Details", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index")
+		}).
+		Preload("TaxSummaries").
+		Where("id = ? AND deleted_at IS NULL", invoiceID).
+		First(&invoice).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("請求書が見つかりません")
+		}
+		s.logger.Error("Failed to get invoice", zap.Error(err))
+		return nil, err
+	}
+
+	return s.pdfService.GeneratePDF(&invoice)
+}
+
+// CancelInvoice 発行済みの請求書を取り消す
+// 請求書自体は変更せず、符号を反転した赤伝を発行して元の請求書をキャンセル済みにする
+func (s *invoiceService) CancelInvoice(ctx context.Context, invoiceID, userID string, req *dto.CancelInvoiceRequest) (*dto.InvoiceRevisionResultDTO, error) {
+	issueDate := time.Now()
+	if req.IssueDate != nil {
+		issueDate = *req.IssueDate
+	}
+
+	var credit *model.Invoice
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		_, credit, err = s.issueCreditNote(tx, invoiceID, userID, req.Reason, issueDate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invoice cancelled with credit note",
+		zap.String("invoice_id", invoiceID),
+		zap.String("credit_note_id", credit.ID),
+		zap.String("user_id", userID))
+
+	return s.revisionResult(ctx, invoiceID, credit.ID, nil)
+}
+
+// ReviseInvoice 発行済みの請求書を訂正する
+// 元の請求書を赤伝で取り消し、訂正後の請求書を新しい請求書番号の下書きとして作成する
+func (s *invoiceService) ReviseInvoice(ctx context.Context, invoiceID, userID string, req *dto.ReviseInvoiceRequest) (*dto.InvoiceRevisionResultDTO, error) {
+	registrationNumber := s.company.InvoiceRegistrationNumber
+	if registrationNumber != "" && !model.IsValidInvoiceRegistrationNumber(registrationNumber) {
+		return nil, fmt.Errorf("適格請求書発行事業者登録番号の形式が正しくありません")
+	}
+
+	var credit, revised *model.Invoice
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		original, creditNote, err := s.issueCreditNote(tx, invoiceID, userID, req.Reason, time.Now())
+		if err != nil {
+			return err
+		}
+		credit = creditNote
+
+		invoiceNumber := req.InvoiceNumber
+		if invoiceNumber == "" {
+			invoiceDate := original.InvoiceDate
+			if req.InvoiceDate != nil {
+				invoiceDate = *req.InvoiceDate
+			}
+			number, err := s.numbering.Allocate(tx, original.ClientID, invoiceDate, userID)
+			if err != nil {
+				return err
+			}
+			invoiceNumber = number
+		} else if err := s.ensureInvoiceNumberAvailable(tx, invoiceNumber, "請求書番号が既に使用されています"); err != nil {
+			return err
+		}
+
+		originalID := original.ID
+		revised = &model.Invoice{
+			ClientID:           original.ClientID,
+			InvoiceNumber:      invoiceNumber,
+			InvoiceDate:        original.InvoiceDate,
+			DueDate:            original.DueDate,
+			BillingMonth:       original.BillingMonth,
+			Status:             model.InvoiceStatusDraft,
+			Notes:              original.Notes,
+			CreatedBy:          userID,
+			RegistrationNumber: registrationNumber,
+			ProjectGroupID:     original.ProjectGroupID,
+			InvoiceType:        model.InvoiceTypeStandard,
+			OriginalInvoiceID:  &originalID,
+			Revision:           original.Revision + 1,
+		}
+		if req.InvoiceDate != nil {
+			revised.InvoiceDate = *req.InvoiceDate
+		}
+		if req.DueDate != nil {
+			revised.DueDate = *req.DueDate
+		}
+		if req.Notes != nil {
+			revised.Notes = *req.Notes
+		}
+
+		// 明細の指定がなければ元の請求書の明細を引き継ぎ、税額を再計算する
+		var details []*model.InvoiceDetail
+		if req.Details != nil {
+			details = buildInvoiceDetails(req.Details)
+		} else {
+			for _, detail := range original.Details {
+				details = append(details, &model.InvoiceDetail{
+					ProjectID:   detail.ProjectID,
+					UserID:      detail.UserID,
+					Description: detail.Description,
+					Quantity:    detail.Quantity,
+					UnitPrice:   detail.UnitPrice,
+					Amount:      detail.Amount,
+					TaxCategory: detail.TaxCategory,
+				})
+			}
+		}
+		applyInvoiceTax(revised, details)
+
+		if err := tx.Create(revised).Error; err != nil {
+			s.logger.Error("Failed to create revised invoice", zap.Error(err))
+			return err
+		}
+		if len(details) > 0 {
+			for i, detail := range details {
+				detail.InvoiceID = revised.ID
+				detail.OrderIndex = i + 1
+			}
+			if err := tx.Create(&details).Error; err != nil {
+				s.logger.Error("Failed to create revised invoice details", zap.Error(err))
+				return err
+			}
+		}
+		return s.numbering.Assign(tx, revised.InvoiceNumber, revised.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invoice revised",
+		zap.String("invoice_id", invoiceID),
+		zap.String("credit_note_id", credit.ID),
+		zap.String("revised_invoice_id", revised.ID),
+		zap.String("user_id", userID))
+
+	return s.revisionResult(ctx, invoiceID, credit.ID, &revised.ID)
+}
+
+// GetInvoiceRevisions 請求書の訂正履歴（元の請求書・赤伝・再発行）を取得
+// 指定した請求書が履歴のどこにあっても、最初の請求書から辿れるすべての請求書を返す
+func (s *invoiceService) GetInvoiceRevisions(ctx context.Context, invoiceID string) ([]dto.InvoiceDTO, error) {
+	db := s.db.WithContext(ctx)
+
+	// 訂正元を遡って最初の請求書を探す
+	rootID := invoiceID
+	visited := map[string]bool{}
+	for {
+		var invoice model.Invoice
+		if err := db.Select("id", "original_invoice_id").
+			Where("id = ? AND deleted_at IS NULL", rootID).
+			First(&invoice).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("請求書が見つかりません")
+			}
+			return nil, err
+		}
+		visited[invoice.ID] = true
+		if invoice.OriginalInvoiceID == nil || visited[*invoice.OriginalInvoiceID] {
+			break
+		}
+		rootID = *invoice.OriginalInvoiceID
+	}
+
+	// 最初の請求書から赤伝・再発行を辿る
+	ids := []string{rootID}
+	seen := map[string]bool{rootID: true}
+	frontier := []string{rootID}
+	for len(frontier) > 0 {
+		var children []string
+		if err := db.Model(&model.Invoice{}).
+			Where("original_invoice_id IN ? AND deleted_at IS NULL", frontier).
+			Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		frontier = frontier[:0]
+		for _, id := range children {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+				frontier = append(frontier, id)
+			}
+		}
+	}
+
+	var invoices []model.Invoice
+	if err := db.Preload("Client").
+		Preload("TaxSummaries").
+		Where("id IN ?", ids).
+		Order("revision, created_at").
+		Find(&invoices).Error; err != nil {
+		s.logger.Error("Failed to get invoice revisions", zap.Error(err))
+		return nil, err
+	}
+
+	dtos := make([]dto.InvoiceDTO, len(invoices))
+	for i := range invoices {
+		dtos[i] = s.modelToDTO(&invoices[i])
+	}
+	return dtos, nil
+}
+
+// issueCreditNote 請求書を取り消す赤伝を発行し、元の請求書をキャンセル済みにする
+func (s *invoiceService) issueCreditNote(tx *gorm.DB, invoiceID, userID, reason string, issueDate time.Time) (*model.Invoice, *model.Invoice, error) {
+	var original model.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Details", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index")
+		}).
+		Preload("TaxSummaries").
+		Where("id = ? AND deleted_at IS NULL", invoiceID).
+		First(&original).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, fmt.Errorf("請求書が見つかりません")
+		}
+		return nil, nil, err
+	}
+
+	switch {
+	case original.IsCreditNote():
+		return nil, nil, fmt.Errorf("赤伝は取り消せません")
+	case original.Status == model.InvoiceStatusCancelled:
+		return nil, nil, fmt.Errorf("既に取り消された請求書です")
+	case !original.CanIssueCreditNote():
+		return nil, nil, fmt.Errorf("発行済みの請求書のみ取り消せます")
+	}
+
+	// 赤伝も通常の請求書と同じ台帳から連番で採番する
+	creditNumber, err := s.numbering.Allocate(tx, original.ClientID, issueDate, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credit, details := original.BuildCreditNote(creditNumber, issueDate, reason)
+	credit.CreatedBy = userID
+	if err := tx.Create(credit).Error; err != nil {
+		s.logger.Error("Failed to create credit note", zap.Error(err))
+		return nil, nil, err
+	}
+	if len(details) > 0 {
+		for _, detail := range details {
+			detail.InvoiceID = credit.ID
+		}
+		if err := tx.Create(&details).Error; err != nil {
+			s.logger.Error("Failed to create credit note details", zap.Error(err))
+			return nil, nil, err
+		}
+	}
+	if err := s.numbering.Assign(tx, credit.InvoiceNumber, credit.ID); err != nil {
+		return nil, nil, err
+	}
+
+	// 支払済みの請求書は支払日を残したままキャンセル済みにする
+	if err := tx.Model(&model.Invoice{}).
+		Where("id = ?", original.ID).
+		Update("status", model.InvoiceStatusCancelled).Error; err != nil {
+		s.logger.Error("Failed to cancel invoice", zap.Error(err))
+		return nil, nil, err
+	}
+
+	return &original, credit, nil
+}
+
+// ensureInvoiceNumberAvailable 請求書番号が未使用か確認（削除済みの請求書を含む）
+func (s *invoiceService) ensureInvoiceNumberAvailable(tx *gorm.DB, invoiceNumber, message string) error {
+	var count int64
+	if err := tx.Unscoped().Model(&model.Invoice{}).
+		Where("invoice_number = ?", invoiceNumber).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// revisionResult 取消・訂正後の請求書を取得して結果DTOを組み立てる
+func (s *invoiceService) revisionResult(ctx context.Context, originalID, creditNoteID string, revisedID *string) (*dto.InvoiceRevisionResultDTO, error) {
+	ids := []string{originalID, creditNoteID}
+	if revisedID != nil {
+		ids = append(ids, *revisedID)
+	}
+
+	var invoices []model.Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Client").
+		Preload("TaxSummaries").
+		Where("id IN ?", ids).
+		Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	dtos := make(map[string]dto.InvoiceDTO, len(invoices))
+	for i := range invoices {
+		dtos[invoices[i].ID] = s.modelToDTO(&invoices[i])
+	}
+
+	result := &dto.InvoiceRevisionResultDTO{
+		Original:   dtos[originalID],
+		CreditNote: dtos[creditNoteID],
+	}
+	if revisedID != nil {
+		revised := dtos[*revisedID]
+		result.Revised = &revised
+	}
+	return result, nil
 }
 
 // modelToDTO モデルをDTOに変換
 func (s *invoiceService) modelToDTO(invoice *model.Invoice) dto.InvoiceDTO {
+	return invoiceToDTO(invoice)
+}
+
+// invoiceToDTO 請求書モデルをDTOに変換（請求サービスからも利用）
+func invoiceToDTO(invoice *model.Invoice) dto.InvoiceDTO {
 	dto := dto.InvoiceDTO{
 		ID:            invoice.ID,
 		ClientID:      invoice.ClientID,
@@ -408,6 +842,10 @@ func (s *invoiceService) modelToDTO(invoice *model.Invoice) dto.InvoiceDTO {
 		PaymentDate:   invoice.PaidDate,
 		CreatedAt:     invoice.CreatedAt,
 		UpdatedAt:     invoice.UpdatedAt,
+
+		InvoiceType:       string(invoice.InvoiceType),
+		OriginalInvoiceID: invoice.OriginalInvoiceID,
+		Revision:          invoice.Revision,
 	}
 
 	// ClientがPreloadされている場合、会社名を設定
@@ -415,6 +853,12 @@ func (s *invoiceService) modelToDTO(invoice *model.Invoice) dto.InvoiceDTO {
 		dto.ClientName = invoice.Client.CompanyName
 	}
 
+	// 適格請求書の記載事項
+	dto.RegistrationNumber = invoice.RegistrationNumber
+	for _, summary := range invoice.TaxBreakdown() {
+		dto.TaxSummaries = append(dto.TaxSummaries, taxSummaryToDTO(summary))
+	}
+
 	return dto
 }
 
@@ -429,7 +873,12 @@ func (s *invoiceService) detailToDTO(detail *model.InvoiceDetail) dto.InvoiceIte
 		Quantity:    detail.Quantity,
 		UnitPrice:   detail.UnitPrice,
 		Amount:      detail.Amount,
+		TaxCategory: string(detail.TaxCategory),
+		TaxRate:     detail.TaxCategory.Rate(),
 		OrderIndex:  detail.OrderIndex,
+
+		ActualHours:   detail.ActualHours,
+		HoursEvidence: detail.HoursEvidence,
 	}
 
 	if detail.Project != nil {
@@ -447,9 +896,9 @@ func (s *invoiceService) detailToDTO(detail *model.InvoiceDetail) dto.InvoiceIte
 func (s *invoiceService) isValidStatusTransition(current, new model.InvoiceStatus) bool {
 	transitions := map[model.InvoiceStatus][]model.InvoiceStatus{
 		model.InvoiceStatusDraft:     {model.InvoiceStatusSent, model.InvoiceStatusCancelled},
-		model.InvoiceStatusSent:      {model.InvoiceStatusPaid, model.InvoiceStatusOverdue, model.InvoiceStatusCancelled},
-		model.InvoiceStatusPaid:      {}, // 支払済みからの遷移なし
-		model.InvoiceStatusOverdue:   {model.InvoiceStatusPaid, model.InvoiceStatusCancelled},
+		model.InvoiceStatusSent:      {model.InvoiceStatusPaid, model.InvoiceStatusOverdue}, // 取消は赤伝の発行で行う
+		model.InvoiceStatusPaid:      {},                                                    // 支払済みからの遷移なし
+		model.InvoiceStatusOverdue:   {model.InvoiceStatusPaid},
 		model.InvoiceStatusCancelled: {}, // キャンセル済みからの遷移なし
 	}
 
@@ -466,3 +915,50 @@ func (s *invoiceService) isValidStatusTransition(current, new model.InvoiceStatu
 
 	return false
 }
+
+// buildInvoiceDetails 明細リクエストをモデルに変換
+func buildInvoiceDetails(items []dto.CreateInvoiceItemRequest) []*model.InvoiceDetail {
+	details := make([]*model.InvoiceDetail, len(items))
+	for i, item := range items {
+		category := model.TaxCategory(item.TaxCategory)
+		if category == "" {
+			category = model.TaxCategoryStandard
+		}
+		details[i] = &model.InvoiceDetail{
+			ProjectID:   item.ProjectID,
+			UserID:      item.UserID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.UnitPrice.Mul(item.Quantity, money.RoundingNone),
+			TaxCategory: category,
+		}
+	}
+	return details
+}
+
+// applyInvoiceTax 明細から税率別小計を計算して請求書の金額に反映
+func applyInvoiceTax(invoice *model.Invoice, details []*model.InvoiceDetail) {
+	values := make([]model.InvoiceDetail, len(details))
+	for i, detail := range details {
+		values[i] = *detail
+	}
+	calc := model.CalculateInvoiceTax(values)
+
+	invoice.Subtotal = calc.Subtotal
+	invoice.TaxRate = calc.PrimaryTaxRate()
+	invoice.TaxAmount = calc.TaxAmount
+	invoice.TotalAmount = calc.TotalAmount
+	invoice.TaxSummaries = calc.Summaries
+}
+
+// taxSummaryToDTO 税率別小計をDTOに変換
+func taxSummaryToDTO(summary model.InvoiceTaxSummary) dto.InvoiceTaxSummaryDTO {
+	return dto.InvoiceTaxSummaryDTO{
+		TaxCategory:   string(summary.TaxCategory),
+		Label:         summary.TaxCategory.Label(),
+		TaxRate:       summary.TaxRate,
+		TaxableAmount: summary.TaxableAmount,
+		TaxAmount:     summary.TaxAmount,
+	}
+}
@@ -0,0 +1,709 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/pkg/journal"
+)
+
+// journalExpenseStatuses 仕訳に計上する経費申請のステータス（承認済み以降）
+var journalExpenseStatuses = []model.ExpenseStatus{
+	model.ExpenseStatusApproved,
+	model.ExpenseStatusPaid,
+	model.ExpenseStatusClosed,
+}
+
+// journalGeneralMappingLabels 経費カテゴリ以外の勘定科目設定の表示名
+var journalGeneralMappingLabels = map[string]string{
+	model.JournalMappingInvoiceCollection:  "請求書の入金",
+	model.JournalMappingInvoiceTransferFee: "入金時の振込手数料",
+	model.JournalMappingExpensePayment:     "経費の精算",
+}
+
+// JournalExportServiceInterface 仕訳出力サービスのインターフェース
+type JournalExportServiceInterface interface {
+	// 勘定科目設定
+	ListMappings(ctx context.Context) ([]dto.JournalAccountMappingDTO, error)
+	UpdateMapping(ctx context.Context, mappingKey string, req *dto.JournalAccountMappingRequest, userID string) (*dto.JournalAccountMappingDTO, error)
+
+	// 仕訳
+	GetEntries(ctx context.Context, month string) (*dto.JournalEntriesResponse, error)
+	Export(ctx context.Context, month, format, userID string) (*dto.JournalExportFile, error)
+	ListExports(ctx context.Context, month string) ([]model.JournalExport, error)
+
+	// 月次締め
+	ListCloses(ctx context.Context) ([]model.AccountingPeriodClose, error)
+	CloseMonth(ctx context.Context, month, userID string) (*model.AccountingPeriodClose, error)
+}
+
+// journalExportService 仕訳出力サービス実装
+type journalExportService struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewJournalExportService 仕訳出力サービスのコンストラクタ
+func NewJournalExportService(db *gorm.DB, logger *zap.Logger) JournalExportServiceInterface {
+	return &journalExportService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListMappings 勘定科目設定の一覧（未登録の経費カテゴリを含む）
+func (s *journalExportService) ListMappings(ctx context.Context) ([]dto.JournalAccountMappingDTO, error) {
+	db := s.db.WithContext(ctx)
+	mappings, err := loadJournalMappings(db)
+	if err != nil {
+		return nil, err
+	}
+	var categories []model.ExpenseCategoryMaster
+	if err := db.Order("display_order").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("経費カテゴリの取得に失敗しました: %w", err)
+	}
+
+	result := make([]dto.JournalAccountMappingDTO, 0, len(model.JournalGeneralMappingKeys)+len(categories))
+	for _, key := range model.JournalGeneralMappingKeys {
+		result = append(result, journalMappingToDTO(key, journalMappingLabel(key, ""), mappings[key]))
+	}
+	for _, category := range categories {
+		key := model.ExpenseJournalMappingKey(category.Code)
+		result = append(result, journalMappingToDTO(key, journalMappingLabel(key, category.Name), mappings[key]))
+	}
+	return result, nil
+}
+
+// UpdateMapping 勘定科目設定を登録・更新する
+// 変更は未締めの月の仕訳から反映され、締め済みの仕訳は変わらない
+func (s *journalExportService) UpdateMapping(ctx context.Context, mappingKey string, req *dto.JournalAccountMappingRequest, userID string) (*dto.JournalAccountMappingDTO, error) {
+	db := s.db.WithContext(ctx)
+	label, err := s.mappingLabel(db, mappingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping model.JournalAccountMapping
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("mapping_key = ?", mappingKey).First(&mapping).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("勘定科目設定の取得に失敗しました: %w", err)
+			}
+			mapping = model.JournalAccountMapping{MappingKey: mappingKey}
+		}
+		mapping.DebitAccount = strings.TrimSpace(req.DebitAccount)
+		mapping.DebitSubAccount = strings.TrimSpace(req.DebitSubAccount)
+		mapping.DebitTaxCategory = strings.TrimSpace(req.DebitTaxCategory)
+		mapping.CreditAccount = strings.TrimSpace(req.CreditAccount)
+		mapping.CreditSubAccount = strings.TrimSpace(req.CreditSubAccount)
+		mapping.CreditTaxCategory = strings.TrimSpace(req.CreditTaxCategory)
+		mapping.UpdatedBy = &userID
+		if mapping.DebitAccount == "" || mapping.CreditAccount == "" {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "借方・貸方の勘定科目を指定してください")
+		}
+		if err := tx.Save(&mapping).Error; err != nil {
+			return fmt.Errorf("勘定科目設定の保存に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Journal account mapping updated",
+		zap.String("mapping_key", mappingKey),
+		zap.String("user_id", userID))
+
+	result := journalMappingToDTO(mappingKey, label, &mapping)
+	return &result, nil
+}
+
+// GetEntries 対象月の仕訳を取得
+// 締め済みの月は確定した仕訳を、未締めの月は現時点の経費・請求書から作成した仕訳を返す
+func (s *journalExportService) GetEntries(ctx context.Context, month string) (*dto.JournalEntriesResponse, error) {
+	entries, locked, err := s.monthEntries(s.db.WithContext(ctx), month)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.JournalEntriesResponse{
+		Month:       month,
+		Locked:      locked,
+		EntryCount:  len(entries),
+		TotalAmount: journalTotal(entries),
+		Entries:     entries,
+	}, nil
+}
+
+// Export 対象月の仕訳を会計ソフトの取込形式で出力し、出力履歴を記録する
+func (s *journalExportService) Export(ctx context.Context, month, format, userID string) (*dto.JournalExportFile, error) {
+	f, err := journal.ParseFormat(format)
+	if err != nil {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest,
+			"出力形式はfreee、moneyforward、yayoiのいずれかを指定してください")
+	}
+
+	db := s.db.WithContext(ctx)
+	entries, locked, err := s.monthEntries(db, month)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]journal.Entry, len(entries))
+	for i, e := range entries {
+		lines[i] = journalLine(e)
+	}
+	var buf bytes.Buffer
+	if err := journal.Write(&buf, f, lines); err != nil {
+		return nil, fmt.Errorf("仕訳ファイルの作成に失敗しました: %w", err)
+	}
+
+	fileName := fmt.Sprintf("journal_%s_%s", f, strings.ReplaceAll(month, "-", ""))
+	if !locked {
+		// 未締めの月は締め後に内容が変わり得るため、ファイル名で区別する
+		fileName += "_draft"
+	}
+	fileName += "." + f.FileExtension()
+
+	export := model.JournalExport{
+		Month:       month,
+		Format:      string(f),
+		Locked:      locked,
+		EntryCount:  len(entries),
+		TotalAmount: journalTotal(entries),
+		FileName:    fileName,
+		ExportedBy:  userID,
+	}
+	if err := db.Create(&export).Error; err != nil {
+		return nil, fmt.Errorf("仕訳の出力履歴の記録に失敗しました: %w", err)
+	}
+
+	s.logger.Info("Journal entries exported",
+		zap.String("month", month),
+		zap.String("format", string(f)),
+		zap.Bool("locked", locked),
+		zap.Int("entry_count", len(entries)),
+		zap.String("user_id", userID))
+
+	return &dto.JournalExportFile{
+		FileName:    fileName,
+		ContentType: f.ContentType(),
+		Content:     buf.Bytes(),
+		Export:      export,
+	}, nil
+}
+
+// ListExports 仕訳の出力履歴（新しい順、月の指定は任意）
+func (s *journalExportService) ListExports(ctx context.Context, month string) ([]model.JournalExport, error) {
+	query := s.db.WithContext(ctx).Model(&model.JournalExport{})
+	if month != "" {
+		if _, err := parseAnalyticsMonth(month, "対象月"); err != nil {
+			return nil, err
+		}
+		query = query.Where("month = ?", month)
+	}
+	var exports []model.JournalExport
+	if err := query.Order("created_at DESC").Limit(100).Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("仕訳の出力履歴の取得に失敗しました: %w", err)
+	}
+	return exports, nil
+}
+
+// ListCloses 仕訳の月次締めの一覧（新しい順）
+func (s *journalExportService) ListCloses(ctx context.Context) ([]model.AccountingPeriodClose, error) {
+	var closes []model.AccountingPeriodClose
+	if err := s.db.WithContext(ctx).Order("month DESC").Find(&closes).Error; err != nil {
+		return nil, fmt.Errorf("月次締めの取得に失敗しました: %w", err)
+	}
+	return closes, nil
+}
+
+// CloseMonth 対象月の仕訳を確定して締める
+// 締めは締め済みの月の翌月から順に行い、確定した仕訳は以降変更されない
+func (s *journalExportService) CloseMonth(ctx context.Context, month, userID string) (*model.AccountingPeriodClose, error) {
+	target, err := parseAnalyticsMonth(month, "対象月")
+	if err != nil {
+		return nil, err
+	}
+	if target.AddDate(0, 1, 0).After(time.Now()) {
+		return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "月末を過ぎていない月は締められません")
+	}
+
+	var closed *model.AccountingPeriodClose
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		closes, err := loadPeriodCloses(tx)
+		if err != nil {
+			return err
+		}
+		if closes.isClosed(month) {
+			return accountingerrors.ErrJournalMonthClosedError(month)
+		}
+		if next := closes.nextMonth(); next != "" && next != month {
+			return accountingerrors.ErrJournalCloseOutOfOrderError(month, next)
+		}
+
+		entries, err := s.openMonthEntries(tx, target, closes)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			if err := tx.CreateInBatches(entries, 500).Error; err != nil {
+				return fmt.Errorf("仕訳の確定に失敗しました: %w", err)
+			}
+		}
+
+		closed = &model.AccountingPeriodClose{
+			Month:       month,
+			EntryCount:  len(entries),
+			TotalAmount: journalTotal(entries),
+			ClosedBy:    userID,
+			ClosedAt:    time.Now(),
+		}
+		if err := tx.Create(closed).Error; err != nil {
+			return fmt.Errorf("月次締めの記録に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Journal month closed",
+		zap.String("month", month),
+		zap.Int("entry_count", closed.EntryCount),
+		zap.Int64("total_amount", closed.TotalAmount),
+		zap.String("user_id", userID))
+
+	return closed, nil
+}
+
+// monthEntries 対象月の仕訳と締め済みかどうかを取得
+func (s *journalExportService) monthEntries(db *gorm.DB, month string) ([]model.JournalEntry, bool, error) {
+	target, err := parseAnalyticsMonth(month, "対象月")
+	if err != nil {
+		return nil, false, err
+	}
+	closes, err := loadPeriodCloses(db)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if closes.isClosed(month) {
+		var entries []model.JournalEntry
+		if err := db.Where("month = ?", month).Order("slip_number").Find(&entries).Error; err != nil {
+			return nil, false, fmt.Errorf("締め済みの仕訳の取得に失敗しました: %w", err)
+		}
+		return entries, true, nil
+	}
+
+	entries, err := s.openMonthEntries(db, target, closes)
+	if err != nil {
+		return nil, false, err
+	}
+	return entries, false, nil
+}
+
+// openMonthEntries 未締めの月の仕訳を経費・請求書から作成する
+// 締め済みの翌月には、締め後に承認・入金などがあった締め済みの月の取引を月初日付で計上する
+func (s *journalExportService) openMonthEntries(db *gorm.DB, target time.Time, closes periodCloses) ([]model.JournalEntry, error) {
+	month := target.Format("2006-01")
+	from, to := target, target.AddDate(0, 1, 0)
+	catchUp := closes.nextMonth() == month
+	if catchUp {
+		from, _ = time.Parse("2006-01", closes.firstMonth())
+	}
+
+	mappings, err := loadJournalMappings(db)
+	if err != nil {
+		return nil, err
+	}
+	builder := newJournalBuilder(mappings)
+	if err := s.addExpenseEntries(db, builder, from, to); err != nil {
+		return nil, err
+	}
+	if err := s.addInvoiceEntries(db, builder, from, to); err != nil {
+		return nil, err
+	}
+	if len(builder.missing) > 0 {
+		keys := make([]string, 0, len(builder.missing))
+		for key := range builder.missing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, accountingerrors.ErrJournalMappingUndefinedError(keys)
+	}
+
+	entries := builder.entries
+	if catchUp {
+		if entries, err = excludeClosedEntries(db, entries); err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			if entries[i].EntryDate.Before(target) {
+				entries[i].EntryDate = target
+				entries[i].Description = truncateRunes(entries[i].Description+"（締め後計上）", 255)
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].EntryDate.Equal(entries[j].EntryDate) {
+			return entries[i].EntryDate.Before(entries[j].EntryDate)
+		}
+		return entries[i].SourceKey() < entries[j].SourceKey()
+	})
+	for i := range entries {
+		entries[i].Month = month
+		entries[i].SlipNumber = i + 1
+	}
+	return entries, nil
+}
+
+// addExpenseEntries 承認済みの経費の計上と精算の仕訳を追加
+func (s *journalExportService) addExpenseEntries(db *gorm.DB, builder *journalBuilder, from, to time.Time) error {
+	var rows []struct {
+		ID           string
+		Title        string
+		Category     string
+		CategoryCode *string
+		Amount       int64
+		ExpenseDate  time.Time
+		PaidAt       *time.Time
+		UserName     string
+	}
+	err := db.Table("expenses AS e").
+		Select("e.id, e.title, e.category, ec.code AS category_code, e.amount, e.expense_date, e.paid_at, "+
+			"COALESCE(NULLIF(u.name, ''), u.last_name || ' ' || u.first_name) AS user_name").
+		Joins("LEFT JOIN expense_categories ec ON ec.id = e.category_id").
+		Joins("LEFT JOIN users u ON u.id = e.user_id").
+		Where("e.deleted_at IS NULL AND e.status IN ?", journalExpenseStatuses).
+		Where("(e.expense_date >= ? AND e.expense_date < ?) OR (e.paid_at >= ? AND e.paid_at < ?)", from, to, from, to).
+		Scan(&rows).Error
+	if err != nil {
+		return fmt.Errorf("経費申請の取得に失敗しました: %w", err)
+	}
+
+	for _, row := range rows {
+		code := row.Category
+		if row.CategoryCode != nil && *row.CategoryCode != "" {
+			code = *row.CategoryCode
+		}
+		description := "経費 " + row.Title
+		if inRange(row.ExpenseDate, from, to) {
+			builder.add(model.ExpenseJournalMappingKey(code), model.JournalSourceExpense, row.ID,
+				row.ExpenseDate, row.Amount, 0, row.UserName, description)
+		}
+		if row.PaidAt != nil && inRange(*row.PaidAt, from, to) {
+			builder.add(model.JournalMappingExpensePayment, model.JournalSourceExpense, row.ID,
+				*row.PaidAt, row.Amount, 0, row.UserName, description+" 精算")
+		}
+	}
+	return nil
+}
+
+// addInvoiceEntries 請求書の売上計上と入金の仕訳を追加
+// 赤伝は元の請求書の売上を取り消す仕訳（借方・貸方を入れ替え）として計上する
+func (s *journalExportService) addInvoiceEntries(db *gorm.DB, builder *journalBuilder, from, to time.Time) error {
+	var sales []model.Invoice
+	err := db.Preload("Client").Preload("TaxSummaries").
+		Where("invoice_date >= ? AND invoice_date < ?", from, to).
+		Where("status IN ? OR (status = ? AND EXISTS (SELECT 1 FROM invoices cn WHERE cn.original_invoice_id = invoices.id AND cn.invoice_type = ? AND cn.deleted_at IS NULL))",
+			issuedInvoiceStatuses, model.InvoiceStatusCancelled, model.InvoiceTypeCreditNote).
+		Find(&sales).Error
+	if err != nil {
+		return fmt.Errorf("請求書の取得に失敗しました: %w", err)
+	}
+	for _, invoice := range sales {
+		description := fmt.Sprintf("%s分 請求 %s", invoice.BillingMonth, invoice.InvoiceNumber)
+		if invoice.IsCreditNote() {
+			description = fmt.Sprintf("%s分 請求取消 %s", invoice.BillingMonth, invoice.InvoiceNumber)
+		}
+		for _, summary := range invoice.TaxBreakdown() {
+			builder.add(model.InvoiceSalesJournalMappingKey(summary.TaxCategory), model.JournalSourceInvoice, invoice.ID,
+				invoice.InvoiceDate, yen(summary.TaxableAmount+summary.TaxAmount), yen(summary.TaxAmount),
+				invoice.Client.CompanyName, description)
+		}
+	}
+
+	// 支払済みにした後で赤伝により取り消された請求書も入金は残る
+	var collections []model.Invoice
+	err = db.Preload("Client").
+		Where("paid_date >= ? AND paid_date < ?", from, to).
+		Where("invoice_type = ? AND status IN ?", model.InvoiceTypeStandard,
+			[]model.InvoiceStatus{model.InvoiceStatusPaid, model.InvoiceStatusCancelled}).
+		Find(&collections).Error
+	if err != nil {
+		return fmt.Errorf("入金済みの請求書の取得に失敗しました: %w", err)
+	}
+	if len(collections) == 0 {
+		return nil
+	}
+	ids := make([]string, len(collections))
+	for i, invoice := range collections {
+		ids[i] = invoice.ID
+	}
+	fees, err := transferFees(db, ids)
+	if err != nil {
+		return err
+	}
+	for _, invoice := range collections {
+		total := yen(invoice.TotalAmount)
+		fee := fees[invoice.ID]
+		if fee > total {
+			fee = total
+		}
+		description := "入金 " + invoice.InvoiceNumber
+		if received := total - fee; received > 0 {
+			builder.add(model.JournalMappingInvoiceCollection, model.JournalSourceInvoice, invoice.ID,
+				*invoice.PaidDate, received, 0, invoice.Client.CompanyName, description)
+		}
+		if fee > 0 {
+			builder.add(model.JournalMappingInvoiceTransferFee, model.JournalSourceInvoice, invoice.ID,
+				*invoice.PaidDate, fee, 0, invoice.Client.CompanyName, description+" 振込手数料")
+		}
+	}
+	return nil
+}
+
+// mappingLabel 勘定科目設定キーを検証して表示名を取得
+func (s *journalExportService) mappingLabel(db *gorm.DB, mappingKey string) (string, error) {
+	if model.IsGeneralJournalMappingKey(mappingKey) {
+		return journalMappingLabel(mappingKey, ""), nil
+	}
+	code, ok := model.ExpenseCategoryCodeFromJournalMappingKey(mappingKey)
+	if !ok {
+		return "", accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "勘定科目設定のキーが正しくありません").
+			WithDetail("mapping_key", mappingKey)
+	}
+	var category model.ExpenseCategoryMaster
+	if err := db.Where("code = ?", code).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "経費カテゴリが見つかりません").
+				WithDetail("category_code", code)
+		}
+		return "", fmt.Errorf("経費カテゴリの取得に失敗しました: %w", err)
+	}
+	return journalMappingLabel(mappingKey, category.Name), nil
+}
+
+// journalBuilder 勘定科目設定から仕訳を組み立て、未登録の設定キーを集める
+type journalBuilder struct {
+	mappings map[string]*model.JournalAccountMapping
+	entries  []model.JournalEntry
+	missing  map[string]bool
+}
+
+// newJournalBuilder 仕訳の組み立てを開始
+func newJournalBuilder(mappings map[string]*model.JournalAccountMapping) *journalBuilder {
+	return &journalBuilder{mappings: mappings, missing: make(map[string]bool)}
+}
+
+// add 仕訳を1行追加（金額0は計上しない）
+func (b *journalBuilder) add(key string, sourceType model.JournalSourceType, sourceID string, date time.Time, amount, taxAmount int64, partnerName, description string) {
+	if amount == 0 {
+		return
+	}
+	mapping, ok := b.mappings[key]
+	if !ok {
+		b.missing[key] = true
+		return
+	}
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	b.entries = append(b.entries, model.NewJournalEntry(*mapping, sourceType, sourceID, date, amount, taxAmount,
+		truncateRunes(partnerName, 255), truncateRunes(description, 255)))
+}
+
+// periodCloses 締め済みの月（昇順）
+type periodCloses []string
+
+// loadPeriodCloses 締め済みの月を取得
+func loadPeriodCloses(db *gorm.DB) (periodCloses, error) {
+	var months []string
+	if err := db.Model(&model.AccountingPeriodClose{}).Order("month").Pluck("month", &months).Error; err != nil {
+		return nil, fmt.Errorf("月次締めの取得に失敗しました: %w", err)
+	}
+	return months, nil
+}
+
+// isClosed 対象月が締め済みか
+func (c periodCloses) isClosed(month string) bool {
+	for _, m := range c {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+// firstMonth 最初に締めた月（締め済みの月がなければ空）
+func (c periodCloses) firstMonth() string {
+	if len(c) == 0 {
+		return ""
+	}
+	return c[0]
+}
+
+// nextMonth 次に締める月（締め済みの月がなければ空）
+func (c periodCloses) nextMonth() string {
+	if len(c) == 0 {
+		return ""
+	}
+	last, err := time.Parse("2006-01", c[len(c)-1])
+	if err != nil {
+		return ""
+	}
+	return last.AddDate(0, 1, 0).Format("2006-01")
+}
+
+// loadJournalMappings 勘定科目設定を設定キーごとに取得
+func loadJournalMappings(db *gorm.DB) (map[string]*model.JournalAccountMapping, error) {
+	var mappings []*model.JournalAccountMapping
+	if err := db.Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("勘定科目設定の取得に失敗しました: %w", err)
+	}
+	result := make(map[string]*model.JournalAccountMapping, len(mappings))
+	for _, m := range mappings {
+		result[m.MappingKey] = m
+	}
+	return result, nil
+}
+
+// excludeClosedEntries 締め済みの月に計上済みの取引を除く
+func excludeClosedEntries(db *gorm.DB, entries []model.JournalEntry) ([]model.JournalEntry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	idSet := make(map[string]bool)
+	for _, e := range entries {
+		idSet[e.SourceID] = true
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+
+	var booked []model.JournalEntry
+	if err := db.Select("source_type, source_id, mapping_key").
+		Where("source_id IN ?", ids).
+		Find(&booked).Error; err != nil {
+		return nil, fmt.Errorf("締め済みの仕訳の取得に失敗しました: %w", err)
+	}
+	bookedKeys := make(map[string]bool, len(booked))
+	for i := range booked {
+		bookedKeys[booked[i].SourceKey()] = true
+	}
+
+	result := entries[:0]
+	for _, e := range entries {
+		if !bookedKeys[e.SourceKey()] {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// transferFees 確定した消込で差し引かれた振込手数料を請求書ごとに集計
+func transferFees(db *gorm.DB, invoiceIDs []string) (map[string]int64, error) {
+	var rows []struct {
+		InvoiceID string
+		Fee       int64
+	}
+	err := db.Model(&model.BankTransactionAllocation{}).
+		Select("bank_transaction_allocations.invoice_id, SUM(bank_transaction_allocations.fee_amount) AS fee").
+		Joins("JOIN bank_transactions ON bank_transactions.id = bank_transaction_allocations.bank_transaction_id").
+		Where("bank_transactions.status = ?", model.BankTransactionStatusConfirmed).
+		Where("bank_transaction_allocations.invoice_id IN ?", invoiceIDs).
+		Group("bank_transaction_allocations.invoice_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("振込手数料の集計に失敗しました: %w", err)
+	}
+	fees := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		fees[row.InvoiceID] = row.Fee
+	}
+	return fees, nil
+}
+
+// journalLine 仕訳を出力用の1行に変換
+func journalLine(e model.JournalEntry) journal.Entry {
+	return journal.Entry{
+		SlipNumber:        e.SlipNumber,
+		Date:              e.EntryDate,
+		DebitAccount:      e.DebitAccount,
+		DebitSubAccount:   e.DebitSubAccount,
+		DebitTaxCategory:  e.DebitTaxCategory,
+		DebitTaxAmount:    e.DebitTaxAmount,
+		CreditAccount:     e.CreditAccount,
+		CreditSubAccount:  e.CreditSubAccount,
+		CreditTaxCategory: e.CreditTaxCategory,
+		CreditTaxAmount:   e.CreditTaxAmount,
+		Amount:            e.Amount,
+		PartnerName:       e.PartnerName,
+		Description:       e.Description,
+	}
+}
+
+// journalTotal 仕訳の借方合計
+func journalTotal(entries []model.JournalEntry) int64 {
+	var total int64
+	for _, e := range entries {
+		total += e.Amount
+	}
+	return total
+}
+
+// journalMappingLabel 勘定科目設定の表示名
+func journalMappingLabel(key, categoryName string) string {
+	if label, ok := journalGeneralMappingLabels[key]; ok {
+		return label
+	}
+	for _, category := range []model.TaxCategory{model.TaxCategoryStandard, model.TaxCategoryReduced, model.TaxCategoryExempt, model.TaxCategoryNonTaxable} {
+		if key == model.InvoiceSalesJournalMappingKey(category) {
+			return "売上計上（" + category.Label() + "）"
+		}
+	}
+	return "経費計上（" + categoryName + "）"
+}
+
+// journalMappingToDTO 勘定科目設定をDTOに変換（未登録の場合は設定キーと表示名のみ）
+func journalMappingToDTO(key, label string, mapping *model.JournalAccountMapping) dto.JournalAccountMappingDTO {
+	result := dto.JournalAccountMappingDTO{MappingKey: key, Label: label}
+	if mapping == nil {
+		return result
+	}
+	updatedAt := mapping.UpdatedAt
+	result.Configured = true
+	result.DebitAccount = mapping.DebitAccount
+	result.DebitSubAccount = mapping.DebitSubAccount
+	result.DebitTaxCategory = mapping.DebitTaxCategory
+	result.CreditAccount = mapping.CreditAccount
+	result.CreditSubAccount = mapping.CreditSubAccount
+	result.CreditTaxCategory = mapping.CreditTaxCategory
+	result.UpdatedAt = &updatedAt
+	return result
+}
+
+// inRange 日時が [from, to) に含まれるか
+func inRange(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+// truncateRunes 文字数で切り詰める
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+	"github.com/duesk/monstera/internal/testutils"
+	"github.com/duesk/monstera/pkg/money"
+)
+
+// setupJournalExportTest 経費・請求書・仕訳のテーブルを持つSQLiteで仕訳出力サービスを作成
+// 2026年8月に、請求書の発行・入金（振込手数料あり）と交通費の計上・精算がある
+func setupJournalExportTest(t *testing.T) (*journalExportService, *gorm.DB) {
+	db, err := testutils.SetupInMemoryTestDB()
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // インメモリDBは接続ごとに別のDBになる
+	require.NoError(t, testutils.CreateTables(db,
+		&model.JournalAccountMapping{}, &model.JournalEntry{}, &model.JournalExport{}, &model.AccountingPeriodClose{},
+		&model.Invoice{}, &model.InvoiceTaxSummary{}, &model.Client{},
+		&model.BankTransaction{}, &model.BankTransactionAllocation{},
+		&model.Expense{}, &model.ExpenseCategoryMaster{}, &model.User{}))
+
+	require.NoError(t, db.Create(&model.Client{ID: "client-1", CompanyName: "テスト商事"}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create(&model.User{ID: "user-1", LastName: "山田", FirstName: "太郎", Email: "yamada@example.com"}).Error)
+	require.NoError(t, db.Create(&model.ExpenseCategoryMaster{ID: "category-1", Code: "transport", Name: "旅費交通費", IsActive: true, DisplayOrder: 1}).Error)
+
+	paidDate := journalDate(8, 31)
+	require.NoError(t, db.Omit(clause.Associations).Create(&model.Invoice{
+		ID:            "invoice-1",
+		ClientID:      "client-1",
+		InvoiceNumber: "INV-2026-0001",
+		InvoiceType:   model.InvoiceTypeStandard,
+		InvoiceDate:   journalDate(8, 5),
+		DueDate:       journalDate(8, 31),
+		BillingMonth:  "2026-07",
+		Subtotal:      money.Yen(100000),
+		TaxRate:       10,
+		TaxAmount:     money.Yen(10000),
+		TotalAmount:   money.Yen(110000),
+		Status:        model.InvoiceStatusPaid,
+		PaidDate:      &paidDate,
+	}).Error)
+	require.NoError(t, db.Create(&model.BankTransaction{ID: "txn-1", DedupeKey: "txn-1", Amount: 109340, Status: model.BankTransactionStatusConfirmed}).Error)
+	require.NoError(t, db.Omit("Invoice").Create(&model.BankTransactionAllocation{ID: "alloc-1", BankTransactionID: "txn-1", InvoiceID: "invoice-1", Amount: 109340, FeeAmount: 660}).Error)
+
+	expensePaidAt := journalDate(8, 25)
+	createJournalExpense(t, db, "expense-1", journalDate(8, 10), 5000, &expensePaidAt)
+
+	return &journalExportService{db: db, logger: zap.NewNop()}, db
+}
+
+func journalDate(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// createJournalExpense 承認済みの交通費を登録
+func createJournalExpense(t *testing.T, db *gorm.DB, id string, expenseDate time.Time, amount int, paidAt *time.Time) {
+	require.NoError(t, db.Omit(clause.Associations).Create(&model.Expense{
+		ID:          id,
+		UserID:      "user-1",
+		Title:       "客先訪問",
+		Category:    model.ExpenseCategoryTransport,
+		CategoryID:  "category-1",
+		Amount:      amount,
+		ExpenseDate: expenseDate,
+		Status:      model.ExpenseStatusApproved,
+		PaidAt:      paidAt,
+	}).Error)
+}
+
+// createJournalMappings 勘定科目設定を登録
+func createJournalMappings(t *testing.T, db *gorm.DB, keys ...string) {
+	for _, key := range keys {
+		mapping := &model.JournalAccountMapping{MappingKey: key, DebitAccount: "借方-" + key, CreditAccount: "貸方-" + key}
+		if key == model.InvoiceSalesJournalMappingKey(model.TaxCategoryStandard) {
+			mapping.DebitAccount, mapping.CreditAccount, mapping.CreditTaxCategory = "売掛金", "売上高", "課税売上10%"
+		}
+		require.NoError(t, db.Create(mapping).Error)
+	}
+}
+
+// allJournalMappings テストデータの仕訳に必要な勘定科目設定キー
+var allJournalMappings = []string{
+	model.InvoiceSalesJournalMappingKey(model.TaxCategoryStandard),
+	model.JournalMappingInvoiceCollection,
+	model.JournalMappingInvoiceTransferFee,
+	model.JournalMappingExpensePayment,
+	model.ExpenseJournalMappingKey("transport"),
+}
+
+func TestJournalExportService_GetEntries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("経費と請求書から日付順の仕訳を作成する", func(t *testing.T) {
+		s, db := setupJournalExportTest(t)
+		createJournalMappings(t, db, allJournalMappings...)
+
+		result, err := s.GetEntries(ctx, "2026-08")
+
+		require.NoError(t, err)
+		assert.False(t, result.Locked)
+		assert.Equal(t, 5, result.EntryCount)
+		assert.Equal(t, int64(110000+5000+5000+109340+660), result.TotalAmount)
+
+		keys := make([]string, len(result.Entries))
+		for i, e := range result.Entries {
+			keys[i] = e.MappingKey
+			assert.Equal(t, i+1, e.SlipNumber)
+		}
+		assert.Equal(t, []string{
+			model.InvoiceSalesJournalMappingKey(model.TaxCategoryStandard),
+			model.ExpenseJournalMappingKey("transport"),
+			model.JournalMappingExpensePayment,
+			model.JournalMappingInvoiceCollection,
+			model.JournalMappingInvoiceTransferFee,
+		}, keys)
+
+		sales := result.Entries[0]
+		assert.Equal(t, int64(110000), sales.Amount)
+		assert.Equal(t, int64(10000), sales.CreditTaxAmount)
+		assert.Equal(t, "テスト商事", sales.PartnerName)
+		assert.Equal(t, "山田 太郎", result.Entries[1].PartnerName)
+		assert.Equal(t, int64(109340), result.Entries[3].Amount)
+		assert.Equal(t, int64(660), result.Entries[4].Amount)
+	})
+
+	t.Run("勘定科目の対応がない取引があれば設定キーを返す", func(t *testing.T) {
+		s, db := setupJournalExportTest(t)
+		createJournalMappings(t, db, allJournalMappings[:4]...)
+
+		_, err := s.GetEntries(ctx, "2026-08")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrJournalMappingUndefined, accErr.Code)
+		assert.Equal(t, []string{model.ExpenseJournalMappingKey("transport")}, accErr.Details["mapping_keys"])
+	})
+}
+
+func TestJournalExportService_Export(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupJournalExportTest(t)
+	createJournalMappings(t, db, allJournalMappings...)
+
+	t.Run("未締めの月はファイル名で区別して出力履歴を記録する", func(t *testing.T) {
+		file, err := s.Export(ctx, "2026-08", "freee", "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "journal_freee_202608_draft.csv", file.FileName)
+		assert.NotEmpty(t, file.Content)
+		assert.False(t, file.Export.Locked)
+		assert.Equal(t, 5, file.Export.EntryCount)
+	})
+
+	t.Run("締め済みの月は確定した仕訳を出力する", func(t *testing.T) {
+		_, err := s.CloseMonth(ctx, "2026-08", "admin-1")
+		require.NoError(t, err)
+
+		file, err := s.Export(ctx, "2026-08", "yayoi", "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "journal_yayoi_202608.txt", file.FileName)
+		assert.True(t, file.Export.Locked)
+
+		exports, err := s.ListExports(ctx, "2026-08")
+		require.NoError(t, err)
+		assert.Len(t, exports, 2)
+	})
+
+	t.Run("対応していない出力形式は受け付けない", func(t *testing.T) {
+		_, err := s.Export(ctx, "2026-08", "csv", "admin-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrAccountingInvalidRequest, accErr.Code)
+	})
+}
+
+func TestJournalExportService_CloseMonth(t *testing.T) {
+	ctx := context.Background()
+	s, db := setupJournalExportTest(t)
+	createJournalMappings(t, db, allJournalMappings...)
+
+	closed, err := s.CloseMonth(ctx, "2026-08", "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, 5, closed.EntryCount)
+	assert.Equal(t, int64(230000), closed.TotalAmount)
+
+	t.Run("締め済みの月は確定した仕訳を返し、元データが変わっても変わらない", func(t *testing.T) {
+		require.NoError(t, db.Model(&model.Expense{}).Where("id = ?", "expense-1").Update("amount", 9000).Error)
+
+		result, err := s.GetEntries(ctx, "2026-08")
+
+		require.NoError(t, err)
+		assert.True(t, result.Locked)
+		assert.Equal(t, 5, result.EntryCount)
+		assert.Equal(t, int64(230000), result.TotalAmount)
+	})
+
+	t.Run("締め後に承認された締め済みの月の経費は翌月の月初日付で計上する", func(t *testing.T) {
+		createJournalExpense(t, db, "expense-2", journalDate(8, 20), 3000, nil)
+
+		result, err := s.GetEntries(ctx, "2026-09")
+
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		late := result.Entries[0]
+		assert.Equal(t, "expense-2", late.SourceID)
+		assert.True(t, late.EntryDate.Equal(journalDate(9, 1)))
+		assert.Contains(t, late.Description, "（締め後計上）")
+	})
+
+	t.Run("締め済みの月は再び締められない", func(t *testing.T) {
+		_, err := s.CloseMonth(ctx, "2026-08", "admin-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrJournalMonthClosed, accErr.Code)
+	})
+
+	t.Run("締め済みの月の翌月以外は締められない", func(t *testing.T) {
+		_, err := s.CloseMonth(ctx, "2026-07", "admin-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrJournalCloseOutOfOrder, accErr.Code)
+		assert.Equal(t, "2026-09", accErr.Details["next_month"])
+	})
+
+	t.Run("月末を過ぎていない月は締められない", func(t *testing.T) {
+		_, err := s.CloseMonth(ctx, time.Now().Format("2006-01"), "admin-1")
+
+		var accErr *accountingerrors.AccountingError
+		require.True(t, errors.As(err, &accErr))
+		assert.Equal(t, accountingerrors.ErrAccountingInvalidRequest, accErr.Code)
+	})
+}
@@ -90,6 +90,9 @@ func (s *leaveAdminService) ApproveLeaveRequest(ctx context.Context, requestID,
 		if err := txLeaveAdminRepo.ApproveRequest(ctx, requestID, approverID); err != nil {
 			return fmt.Errorf("承認処理に失敗しました")
 		}
+		if err := s.recordOnBehalfOf(ctx, tx, request, approverID); err != nil {
+			return err
+		}
 
 		// 残日数更新
 		balance.UsedDays += request.TotalDays
@@ -98,6 +101,14 @@ func (s *leaveAdminService) ApproveLeaveRequest(ctx context.Context, requestID,
 			return fmt.Errorf("休暇残日数の更新に失敗しました")
 		}
 
+		// 承認者の休暇の場合は休暇期間中の代理承認を登録
+		if err := registerLeaveDelegation(ctx, tx, s.logger, request, approverID); err != nil {
+			s.logger.Error("Failed to register approval delegation from leave request",
+				zap.Error(err),
+				zap.String("request_id", requestID))
+			return fmt.Errorf("代理承認の登録に失敗しました")
+		}
+
 		// 通知送信（トランザクション外で実行）
 		// TODO: NotifyLeaveApprovalメソッドをNotificationServiceに追加後有効化
 		// go func() {
@@ -135,6 +146,9 @@ func (s *leaveAdminService) RejectLeaveRequest(ctx context.Context, requestID, a
 		if err := txLeaveAdminRepo.RejectRequest(ctx, requestID, approverID, reason); err != nil {
 			return fmt.Errorf("却下処理に失敗しました")
 		}
+		if err := s.recordOnBehalfOf(ctx, tx, request, approverID); err != nil {
+			return err
+		}
 
 		// 通知送信（トランザクション外で実行）
 		// TODO: NotifyLeaveRejectionメソッドをNotificationServiceに追加後有効化
@@ -148,6 +162,25 @@ func (s *leaveAdminService) RejectLeaveRequest(ctx context.Context, requestID, a
 	})
 }
 
+// recordOnBehalfOf 申請者の上長の代理で承認・却下した場合に本来の承認者を記録
+func (s *leaveAdminService) recordOnBehalfOf(ctx context.Context, tx *gorm.DB, request *model.LeaveRequest, approverID string) error {
+	onBehalfOfID, err := resolveOnBehalfOf(ctx, tx, s.logger, request.UserID, approverID, model.ApprovalDelegationScopeLeave)
+	if err != nil {
+		s.logger.Error("Failed to resolve approval delegation", zap.Error(err), zap.String("request_id", request.ID))
+		return fmt.Errorf("代理承認の確認に失敗しました")
+	}
+	if onBehalfOfID == nil {
+		return nil
+	}
+	if err := tx.WithContext(ctx).Model(&model.LeaveRequest{}).
+		Where("id = ?", request.ID).
+		Update("on_behalf_of_id", *onBehalfOfID).Error; err != nil {
+		s.logger.Error("Failed to record approval on behalf", zap.Error(err), zap.String("request_id", request.ID))
+		return fmt.Errorf("代理承認の記録に失敗しました")
+	}
+	return nil
+}
+
 func (s *leaveAdminService) BulkApproveLeaveRequests(ctx context.Context, requestIDs []string, approverID string) ([]ApprovalResult, error) {
 	results := make([]ApprovalResult, 0, len(requestIDs))
 
@@ -73,6 +73,14 @@ func (s *mockS3Service) DeleteFile(ctx context.Context, s3Key string) error {
 	return nil
 }
 
+// DownloadFile モックではファイルを保持しないため取得できない
+func (s *mockS3Service) DownloadFile(ctx context.Context, s3Key string) ([]byte, error) {
+	if s3Key == "" {
+		return nil, fmt.Errorf("S3キーが指定されていません")
+	}
+	return nil, fmt.Errorf("モックS3ではファイルを取得できません")
+}
+
 // ValidateUploadedFile モックのファイル検証
 func (s *mockS3Service) ValidateUploadedFile(ctx context.Context, s3Key string) error {
 	if s3Key == "" {
@@ -52,6 +52,7 @@ type NotificationService interface {
 	NotifyExpenseLimitExceeded(ctx context.Context, userID string, expense *model.Expense, limitType string, exceededAmount int) error
 	NotifyExpenseLimitWarning(ctx context.Context, userID string, limitType string, usageRate float64) error
 	NotifyExpenseApprovalReminder(ctx context.Context, approverID string, pendingExpenses []model.Expense) error
+	NotifyCashAdvanceSettlementOverdue(ctx context.Context, advance *model.CashAdvance, daysOverdue int) error
 
 	// ハンドラー用メソッド
 	GetUserNotifications(ctx context.Context, userID string, limit, offset int) (interface{}, error)
@@ -416,6 +417,34 @@ func (s *notificationService) NotifyExpenseApprovalReminder(ctx context.Context,
 	return s.CreateNotification(ctx, notification)
 }
 
+// NotifyCashAdvanceSettlementOverdue 精算期限を過ぎた仮払金の精算を督促
+func (s *notificationService) NotifyCashAdvanceSettlementOverdue(ctx context.Context, advance *model.CashAdvance, daysOverdue int) error {
+	title := "仮払金の精算期限を過ぎています"
+	message := fmt.Sprintf("仮払金「%s」（%s円）の精算期限（%s）を%d日過ぎています。経費申請を紐付けて精算を申請してください。",
+		advance.Purpose, advance.Amount, advance.SettlementDueDate.Format("2006/01/02"), daysOverdue)
+
+	notification := &model.Notification{
+		RecipientID:      &advance.UserID,
+		Title:            title,
+		Message:          message,
+		NotificationType: model.NotificationTypeExpense,
+		Priority:         model.NotificationPriorityHigh,
+		Status:           model.NotificationStatusUnread,
+		ReferenceID:      &advance.ID,
+		ReferenceType:    stringPtr("cash_advance"),
+		Metadata: &model.NotificationMetadata{
+			AdditionalData: map[string]interface{}{
+				"cash_advance_id":     advance.ID,
+				"amount":              advance.Amount,
+				"settlement_due_date": advance.SettlementDueDate.Format("2006-01-02"),
+				"days_overdue":        daysOverdue,
+			},
+		},
+	}
+
+	return s.CreateNotification(ctx, notification)
+}
+
 // getLimitTypeDisplay 上限タイプの表示名を取得
 func getLimitTypeDisplay(limitType string) string {
 	switch limitType {
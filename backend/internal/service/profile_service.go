@@ -464,7 +464,7 @@ func (s *ProfileService) createProfileResponse(profile *model.Profile, user *mod
 		LanguageSkills:  languageSkills,
 		FrameworkSkills: frameworkSkills,
 		BusinessExps:    businessExps,
-		Role:            string(user.Role),
+		Role:            user.Role.String(),
 	}
 }
 
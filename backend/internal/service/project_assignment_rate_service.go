@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/duesk/monstera/internal/dto"
+	accountingerrors "github.com/duesk/monstera/internal/errors"
+	"github.com/duesk/monstera/internal/model"
+)
+
+// ProjectAssignmentRateServiceInterface 案件アサインの単価履歴サービスインターフェース
+type ProjectAssignmentRateServiceInterface interface {
+	ListRates(ctx context.Context, assignmentID string) (*dto.ProjectAssignmentRateListResponse, error)
+	CreateRate(ctx context.Context, assignmentID string, req *dto.ProjectAssignmentRateRequest, userID string) (*dto.ProjectAssignmentRateDTO, error)
+	UpdateRate(ctx context.Context, assignmentID, rateID string, req *dto.ProjectAssignmentRateRequest, userID string) (*dto.ProjectAssignmentRateDTO, error)
+	DeleteRate(ctx context.Context, assignmentID, rateID, reason, userID string) error
+}
+
+// projectAssignmentRateService 案件アサインの単価履歴サービス実装
+type projectAssignmentRateService struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewProjectAssignmentRateService 案件アサインの単価履歴サービスのコンストラクタ
+func NewProjectAssignmentRateService(db *gorm.DB, logger *zap.Logger) ProjectAssignmentRateServiceInterface {
+	return &projectAssignmentRateService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListRates 単価履歴と変更ログを取得
+func (s *projectAssignmentRateService) ListRates(ctx context.Context, assignmentID string) (*dto.ProjectAssignmentRateListResponse, error) {
+	if _, err := s.findAssignment(s.db.WithContext(ctx), assignmentID); err != nil {
+		return nil, err
+	}
+
+	var rates []*model.ProjectAssignmentRate
+	if err := s.db.WithContext(ctx).Where("assignment_id = ?", assignmentID).Order("effective_from ASC").Find(&rates).Error; err != nil {
+		return nil, fmt.Errorf("単価履歴の取得に失敗しました: %w", err)
+	}
+	var logs []*model.ProjectAssignmentRateAuditLog
+	if err := s.db.WithContext(ctx).Where("assignment_id = ?", assignmentID).Order("changed_at DESC").Find(&logs).Error; err != nil {
+		return nil, fmt.Errorf("単価履歴の変更ログの取得に失敗しました: %w", err)
+	}
+
+	response := &dto.ProjectAssignmentRateListResponse{
+		AssignmentID: assignmentID,
+		Rates:        make([]dto.ProjectAssignmentRateDTO, 0, len(rates)),
+		AuditLogs:    make([]dto.ProjectAssignmentRateAuditLogDTO, 0, len(logs)),
+	}
+	for _, rate := range rates {
+		response.Rates = append(response.Rates, dto.ProjectAssignmentRateToDTO(rate))
+	}
+	for _, log := range logs {
+		response.AuditLogs = append(response.AuditLogs, dto.ProjectAssignmentRateAuditLogToDTO(log))
+	}
+	return response, nil
+}
+
+// CreateRate 単価履歴を登録する
+// 終了日のない直前の単価履歴は、新しい単価の適用開始日の前日で終了させる（単価改定）
+func (s *projectAssignmentRateService) CreateRate(ctx context.Context, assignmentID string, req *dto.ProjectAssignmentRateRequest, userID string) (*dto.ProjectAssignmentRateDTO, error) {
+	rate := &model.ProjectAssignmentRate{AssignmentID: assignmentID, CreatedBy: userID}
+	if err := applyRateRequest(rate, req, userID); err != nil {
+		return nil, err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignment, err := s.findAssignment(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+		rates, err := s.lockRates(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+
+		for _, previous := range rates {
+			if previous.EffectiveTo != nil || !previous.EffectiveFrom.Before(rate.EffectiveFrom) {
+				continue
+			}
+			before := previous.Snapshot()
+			closedAt := rate.EffectiveFrom.AddDate(0, 0, -1)
+			previous.EffectiveTo = &closedAt
+			previous.UpdatedBy = userID
+			if err := tx.Save(previous).Error; err != nil {
+				return fmt.Errorf("単価履歴の更新に失敗しました: %w", err)
+			}
+			if err := s.writeAuditLog(tx, previous, model.ProjectAssignmentRateActionUpdate, before, previous.Snapshot(), req.Reason, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := checkRateOverlap(rate, rates); err != nil {
+			return err
+		}
+		if err := tx.Create(rate).Error; err != nil {
+			return fmt.Errorf("単価履歴の登録に失敗しました: %w", err)
+		}
+		if err := s.writeAuditLog(tx, rate, model.ProjectAssignmentRateActionCreate, nil, rate.Snapshot(), req.Reason, userID); err != nil {
+			return err
+		}
+		return s.syncAssignment(tx, assignment, append(rates, rate))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Project assignment rate created",
+		zap.String("assignment_id", assignmentID),
+		zap.String("rate_id", rate.ID),
+		zap.String("user_id", userID))
+
+	result := dto.ProjectAssignmentRateToDTO(rate)
+	return &result, nil
+}
+
+// UpdateRate 単価履歴を変更する
+func (s *projectAssignmentRateService) UpdateRate(ctx context.Context, assignmentID, rateID string, req *dto.ProjectAssignmentRateRequest, userID string) (*dto.ProjectAssignmentRateDTO, error) {
+	var rate *model.ProjectAssignmentRate
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignment, err := s.findAssignment(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+		rates, err := s.lockRates(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+
+		others := make([]*model.ProjectAssignmentRate, 0, len(rates))
+		for _, r := range rates {
+			if r.ID == rateID {
+				rate = r
+			} else {
+				others = append(others, r)
+			}
+		}
+		if rate == nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "単価履歴が見つかりません")
+		}
+
+		before := rate.Snapshot()
+		if err := applyRateRequest(rate, req, userID); err != nil {
+			return err
+		}
+		if err := checkRateOverlap(rate, others); err != nil {
+			return err
+		}
+		if err := tx.Save(rate).Error; err != nil {
+			return fmt.Errorf("単価履歴の更新に失敗しました: %w", err)
+		}
+		if err := s.writeAuditLog(tx, rate, model.ProjectAssignmentRateActionUpdate, before, rate.Snapshot(), req.Reason, userID); err != nil {
+			return err
+		}
+		return s.syncAssignment(tx, assignment, rates)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := dto.ProjectAssignmentRateToDTO(rate)
+	return &result, nil
+}
+
+// DeleteRate 単価履歴を削除する
+func (s *projectAssignmentRateService) DeleteRate(ctx context.Context, assignmentID, rateID, reason, userID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignment, err := s.findAssignment(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+		rates, err := s.lockRates(tx, assignmentID)
+		if err != nil {
+			return err
+		}
+
+		var rate *model.ProjectAssignmentRate
+		remaining := make([]*model.ProjectAssignmentRate, 0, len(rates))
+		for _, r := range rates {
+			if r.ID == rateID {
+				rate = r
+			} else {
+				remaining = append(remaining, r)
+			}
+		}
+		if rate == nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "単価履歴が見つかりません")
+		}
+
+		if err := tx.Delete(rate).Error; err != nil {
+			return fmt.Errorf("単価履歴の削除に失敗しました: %w", err)
+		}
+		if err := s.writeAuditLog(tx, rate, model.ProjectAssignmentRateActionDelete, rate.Snapshot(), nil, reason, userID); err != nil {
+			return err
+		}
+		return s.syncAssignment(tx, assignment, remaining)
+	})
+}
+
+// findAssignment 案件アサインを取得
+func (s *projectAssignmentRateService) findAssignment(tx *gorm.DB, assignmentID string) (*model.ProjectAssignment, error) {
+	var assignment model.ProjectAssignment
+	if err := tx.Where("id = ?", assignmentID).First(&assignment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, accountingerrors.NewAccountingError(accountingerrors.ErrAccountingNotFound, "案件アサインが見つかりません")
+		}
+		return nil, fmt.Errorf("案件アサインの取得に失敗しました: %w", err)
+	}
+	return &assignment, nil
+}
+
+// lockRates 案件アサインの単価履歴を更新ロック付きで取得
+func (s *projectAssignmentRateService) lockRates(tx *gorm.DB, assignmentID string) ([]*model.ProjectAssignmentRate, error) {
+	var rates []*model.ProjectAssignmentRate
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("assignment_id = ?", assignmentID).
+		Order("effective_from ASC").
+		Find(&rates).Error
+	if err != nil {
+		return nil, fmt.Errorf("単価履歴の取得に失敗しました: %w", err)
+	}
+	return rates, nil
+}
+
+// writeAuditLog 単価履歴の変更ログを記録
+func (s *projectAssignmentRateService) writeAuditLog(tx *gorm.DB, rate *model.ProjectAssignmentRate, action string, oldValues, newValues model.ProjectAssignmentRateSnapshot, reason, userID string) error {
+	log := &model.ProjectAssignmentRateAuditLog{
+		RateID:       rate.ID,
+		AssignmentID: rate.AssignmentID,
+		Action:       action,
+		OldValues:    oldValues,
+		NewValues:    newValues,
+		Reason:       reason,
+		ChangedBy:    userID,
+		ChangedAt:    time.Now(),
+	}
+	if err := tx.Create(log).Error; err != nil {
+		return fmt.Errorf("単価履歴の変更ログの記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// syncAssignment 本日適用される単価を案件アサインの現在の単価に反映する
+func (s *projectAssignmentRateService) syncAssignment(tx *gorm.DB, assignment *model.ProjectAssignment, rates []*model.ProjectAssignmentRate) error {
+	today := time.Now()
+	for _, rate := range rates {
+		if !rate.Covers(today) {
+			continue
+		}
+		billingType := rate.BillingType
+		err := tx.Model(assignment).Updates(map[string]interface{}{
+			"billing_type": &billingType,
+			"billing_rate": rate.BillingRate,
+			"min_hours":    rate.MinHours,
+			"max_hours":    rate.MaxHours,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("案件アサインの単価の更新に失敗しました: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// applyRateRequest リクエストの内容を単価履歴に反映して検証
+func applyRateRequest(rate *model.ProjectAssignmentRate, req *dto.ProjectAssignmentRateRequest, userID string) error {
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "適用開始日はYYYY-MM-DD形式で指定してください")
+	}
+	var effectiveTo *time.Time
+	if req.EffectiveTo != nil && *req.EffectiveTo != "" {
+		parsed, err := time.Parse("2006-01-02", *req.EffectiveTo)
+		if err != nil {
+			return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, "適用終了日はYYYY-MM-DD形式で指定してください")
+		}
+		effectiveTo = &parsed
+	}
+
+	rate.EffectiveFrom = effectiveFrom
+	rate.EffectiveTo = effectiveTo
+	rate.BillingType = model.ProjectBillingType(req.BillingType)
+	rate.BillingRate = req.BillingRate
+	rate.MinHours = req.MinHours
+	rate.MaxHours = req.MaxHours
+	rate.Notes = req.Notes
+	rate.UpdatedBy = userID
+
+	if err := rate.Validate(); err != nil {
+		return accountingerrors.NewAccountingError(accountingerrors.ErrAccountingInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// checkRateOverlap 適用期間が他の単価履歴と重ならないことを確認
+func checkRateOverlap(rate *model.ProjectAssignmentRate, others []*model.ProjectAssignmentRate) error {
+	for _, other := range others {
+		if other.ID != rate.ID && rate.Overlaps(other) {
+			return accountingerrors.ErrBillingRateOverlapError(rate.AssignmentID, other.ID)
+		}
+	}
+	return nil
+}
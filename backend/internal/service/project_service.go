@@ -153,6 +153,10 @@ func (s *projectService) Create(ctx context.Context, req *dto.ProjectCreate) (*d
         EndDate:     endPtr,
         Description: derefString(req.Description),
     }
+    if req.ProrationMethod != nil && *req.ProrationMethod != "" {
+        method := model.ProrationMethod(*req.ProrationMethod)
+        p.ProrationMethod = &method
+    }
     if err := s.db.WithContext(ctx).Create(p).Error; err != nil {
         return nil, err
     }
@@ -181,6 +185,9 @@ func (s *projectService) Update(ctx context.Context, id string, req *dto.Project
         updates["status"] = be
     }
     if req.Description != nil { updates["description"] = *req.Description }
+    if req.ProrationMethod != nil {
+        if *req.ProrationMethod == "" { updates["proration_method"] = gorm.Expr("NULL") } else { updates["proration_method"] = *req.ProrationMethod }
+    }
     if req.StartDate != nil {
         if *req.StartDate == "" { updates["start_date"] = gorm.Expr("NULL") } else {
             start, err := dateutil.ParseDateString(*req.StartDate)
@@ -226,6 +233,7 @@ func (s *projectService) toMinimalDTO(p *model.Project, clientName string) dto.P
         Description: p.Description,
         ClientID:    p.ClientID,
         ClientName:  clientName,
+        ProrationMethod: (*string)(p.ProrationMethod),
         CreatedAt:   p.CreatedAt,
         UpdatedAt:   p.UpdatedAt,
     }
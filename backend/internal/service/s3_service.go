@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ type S3Service interface {
 	GenerateUploadURL(ctx context.Context, userID string, req *dto.GenerateUploadURLRequest) (*dto.UploadURLResponse, error)
 	GetFileURL(ctx context.Context, s3Key string) (string, error)
 	DeleteFile(ctx context.Context, s3Key string) error
+	DownloadFile(ctx context.Context, s3Key string) ([]byte, error)
 
 	// ファイル情報管理
 	ValidateUploadedFile(ctx context.Context, s3Key string) error
@@ -50,29 +52,7 @@ func NewS3Service(bucketName, region, baseURL string, logger *zap.Logger) (S3Ser
 	pathStyle := os.Getenv("AWS_S3_PATH_STYLE") == "true"
 	disableSSL := os.Getenv("AWS_S3_DISABLE_SSL") == "true"
 
-	// AWS設定のオプションを構築
-	configOptions := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// カスタムエンドポイントが設定されている場合（MinIOなど）
-	if endpoint != "" {
-		configOptions = append(configOptions, config.WithEndpointResolverWithOptions(
-			aws.EndpointResolverWithOptionsFunc(
-				func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-					if service == s3.ServiceID {
-						return aws.Endpoint{
-							URL:               endpoint,
-							HostnameImmutable: true,
-							Source:            aws.EndpointSourceCustom,
-						}, nil
-					}
-					return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-				})),
-		)
-	}
-
-	cfg, err := config.LoadDefaultConfig(context.TODO(), configOptions...)
+	s3Client, err := newS3Client(region)
 	if err != nil {
 		logger.Error("Failed to load AWS config",
 			zap.Error(err),
@@ -80,22 +60,9 @@ func NewS3Service(bucketName, region, baseURL string, logger *zap.Logger) (S3Ser
 			zap.String("region", region),
 			zap.Bool("path_style", pathStyle),
 			zap.Bool("disable_ssl", disableSSL))
-		return nil, fmt.Errorf("AWS config load failed: %w", err)
+		return nil, err
 	}
 
-	// S3クライアントを作成（MinIO用の設定を適用）
-	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = pathStyle
-		if disableSSL {
-			o.EndpointOptions.DisableHTTPS = true
-		}
-		// MinIOの場合、署名バージョンv4を強制
-		if endpoint != "" {
-			o.UseAccelerate = false
-			o.UseARNRegion = false
-		}
-	})
-
 	// 接続テスト（バケットの存在確認）
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
@@ -127,6 +94,53 @@ func NewS3Service(bucketName, region, baseURL string, logger *zap.Logger) (S3Ser
 	}, nil
 }
 
+// newS3Client 環境変数（AWS_S3_ENDPOINT など）に従ってS3クライアントを生成（MinIO対応）
+func newS3Client(region string) (*s3.Client, error) {
+	endpoint := os.Getenv("AWS_S3_ENDPOINT")
+	pathStyle := os.Getenv("AWS_S3_PATH_STYLE") == "true"
+	disableSSL := os.Getenv("AWS_S3_DISABLE_SSL") == "true"
+
+	// AWS設定のオプションを構築
+	configOptions := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+
+	// カスタムエンドポイントが設定されている場合（MinIOなど）
+	if endpoint != "" {
+		configOptions = append(configOptions, config.WithEndpointResolverWithOptions(
+			aws.EndpointResolverWithOptionsFunc(
+				func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+					if service == s3.ServiceID {
+						return aws.Endpoint{
+							URL:               endpoint,
+							HostnameImmutable: true,
+							Source:            aws.EndpointSourceCustom,
+						}, nil
+					}
+					return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+				})),
+		)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), configOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("AWS config load failed: %w", err)
+	}
+
+	// S3クライアントを作成（MinIO用の設定を適用）
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = pathStyle
+		if disableSSL {
+			o.EndpointOptions.DisableHTTPS = true
+		}
+		// MinIOの場合、署名バージョンv4を強制
+		if endpoint != "" {
+			o.UseAccelerate = false
+			o.UseARNRegion = false
+		}
+	}), nil
+}
+
 // GenerateUploadURL Pre-signed URLを生成
 func (s *s3Service) GenerateUploadURL(ctx context.Context, userID string, req *dto.GenerateUploadURLRequest) (*dto.UploadURLResponse, error) {
 	// バリデーション
@@ -235,6 +249,31 @@ func (s *s3Service) DeleteFile(ctx context.Context, s3Key string) error {
 	return nil
 }
 
+// DownloadFile S3からファイルを取得
+func (s *s3Service) DownloadFile(ctx context.Context, s3Key string) ([]byte, error) {
+	if s3Key == "" {
+		return nil, fmt.Errorf("S3キーが指定されていません")
+	}
+
+	output, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(s3Key),
+	})
+	if err != nil {
+		s.logger.Error("Failed to download file from S3",
+			zap.Error(err),
+			zap.String("s3_key", s3Key))
+		return nil, fmt.Errorf("ファイルの取得に失敗しました")
+	}
+	defer output.Body.Close()
+
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ファイルの取得に失敗しました: %w", err)
+	}
+	return content, nil
+}
+
 // ValidateUploadedFile アップロードされたファイルを検証
 func (s *s3Service) ValidateUploadedFile(ctx context.Context, s3Key string) error {
 	if s3Key == "" {
@@ -152,7 +152,7 @@ func TestTechnologySuggestionServiceLogic(t *testing.T) {
 
 		// 現在の実装では常にfalseを返すことを確認
 		// TODO: 実装が更新されたらテストも更新
-		result := service.containsTechnology(responses, uuid.New())
+		result := service.containsTechnology(responses, uuid.New().String())
 		assert.False(t, result)
 	})
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/duesk/monstera/internal/dto"
 	"github.com/duesk/monstera/internal/model"
-	"github.com/duesk/monstera/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
@@ -167,12 +166,12 @@ type MockTechnologyCategoryRepository struct {
 	mock.Mock
 }
 
-func (m *MockTechnologyCategoryRepository) GetAll(ctx context.Context) ([]*model.TechnologyCategory, error) {
+func (m *MockTechnologyCategoryRepository) GetAll(ctx context.Context) ([]model.TechnologyCategory, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*model.TechnologyCategory), args.Error(1)
+	return args.Get(0).([]model.TechnologyCategory), args.Error(1)
 }
 
 func (m *MockTechnologyCategoryRepository) GetByID(ctx context.Context, id string) (*model.TechnologyCategory, error) {
@@ -148,11 +148,11 @@ func TestWorkHistoryEnhancedServiceSimple(t *testing.T) {
 
 	t.Run("IT経験年数計算テスト", func(t *testing.T) {
 		// calculator package がないため、サービス内の計算ロジックをテスト
-		userID := uuid.New()
+		userID := uuid.New().String()
 
 		// 簡単なデータを挿入
 		workHistory := &model.WorkHistory{
-			ID:          uuid.New(),
+			ID:          uuid.New().String(),
 			UserID:      userID,
 			ProjectName: "テストプロジェクト",
 			StartDate:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
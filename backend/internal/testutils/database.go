@@ -1,9 +1,13 @@
 package testutils
 
 import (
+	"fmt"
+	"strings"
+
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 // SetupInMemoryTestDB テスト用のインメモリデータベースをセットアップ
@@ -26,3 +30,80 @@ func CleanupTestDB(db *gorm.DB) error {
 	}
 	return sqlDB.Close()
 }
+
+// CreateTables モデルのテーブルをSQLiteに作成する
+// モデルのタグはMySQL向けの型や文字セットを含むためAutoMigrateを使わず、列名・主キー・既定値・一意制約だけで作成する
+func CreateTables(db *gorm.DB, models ...interface{}) error {
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+
+		var columns, primaryKeys []string
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			column := quote(field.DBName)
+			switch field.DataType {
+			case schema.Time, "date":
+				column += " datetime"
+			case schema.Int, schema.Uint:
+				column += " integer"
+			case schema.Bool:
+				column += " boolean"
+			}
+			if value := defaultValue(field); value != "" {
+				column += " DEFAULT " + value
+			}
+			if field.Unique {
+				column += " UNIQUE"
+			}
+			columns = append(columns, column)
+			if field.PrimaryKey {
+				primaryKeys = append(primaryKeys, quote(field.DBName))
+			}
+		}
+		if len(primaryKeys) > 0 {
+			columns = append(columns, "PRIMARY KEY ("+strings.Join(primaryKeys, ",")+")")
+		}
+		table := stmt.Schema.Table
+		if err := db.Exec("CREATE TABLE " + quote(table) + " (" + strings.Join(columns, ",") + ")").Error; err != nil {
+			return fmt.Errorf("%s: %w", table, err)
+		}
+
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if index.Class != "UNIQUE" {
+				continue
+			}
+			fields := make([]string, len(index.Fields))
+			for i, field := range index.Fields {
+				fields[i] = quote(field.DBName)
+			}
+			sql := "CREATE UNIQUE INDEX " + quote(index.Name) + " ON " + quote(table) + " (" + strings.Join(fields, ",") + ")"
+			if index.Where != "" {
+				sql += " WHERE " + index.Where
+			}
+			if err := db.Exec(sql).Error; err != nil {
+				return fmt.Errorf("%s: %w", index.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// defaultValue 列の既定値（関数呼び出しなどSQLiteで使えない既定値は空文字）
+func defaultValue(field *schema.Field) string {
+	if !field.HasDefaultValue || field.DefaultValue == "" || strings.Contains(field.DefaultValue, "(") {
+		return ""
+	}
+	if value, ok := field.DefaultValueInterface.(string); ok {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return field.DefaultValue
+}
+
+func quote(name string) string {
+	return "`" + name + "`"
+}
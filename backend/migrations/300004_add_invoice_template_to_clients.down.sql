@@ -0,0 +1,2 @@
+-- 取引先ごとの請求書PDFテンプレート設定を削除
+ALTER TABLE clients DROP COLUMN IF EXISTS invoice_template;
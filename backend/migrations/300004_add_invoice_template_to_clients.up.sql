@@ -0,0 +1,4 @@
+-- 取引先ごとの請求書PDFテンプレート設定を追加
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS invoice_template VARCHAR(50);
+
+COMMENT ON COLUMN clients.invoice_template IS '請求書PDFのレイアウトテンプレート名（未設定時はdefault）';
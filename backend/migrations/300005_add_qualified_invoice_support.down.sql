@@ -0,0 +1,4 @@
+-- 適格請求書（インボイス制度）対応の削除
+DROP TABLE IF EXISTS invoice_tax_summaries;
+ALTER TABLE invoices DROP COLUMN IF EXISTS registration_number;
+ALTER TABLE invoice_details DROP COLUMN IF EXISTS tax_category;
@@ -0,0 +1,40 @@
+-- 適格請求書（インボイス制度）対応
+
+-- 請求明細に税区分を追加
+ALTER TABLE invoice_details ADD COLUMN IF NOT EXISTS tax_category VARCHAR(20) NOT NULL DEFAULT 'standard_10';
+COMMENT ON COLUMN invoice_details.tax_category IS '税区分（standard_10: 10%, reduced_8: 軽減8%, exempt: 免税, non_taxable: 非課税）';
+
+-- 請求書に発行時点の登録番号を保持
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS registration_number VARCHAR(14);
+COMMENT ON COLUMN invoices.registration_number IS '適格請求書発行事業者登録番号（T+13桁）';
+
+-- 税率別小計テーブルの作成
+CREATE TABLE IF NOT EXISTS invoice_tax_summaries (
+    id VARCHAR(36) PRIMARY KEY,
+    invoice_id VARCHAR(36) NOT NULL,
+    tax_category VARCHAR(20) NOT NULL,
+    tax_rate DECIMAL(5, 2) NOT NULL,
+    taxable_amount DECIMAL(10, 2) NOT NULL,
+    tax_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_invoice_tax_summaries_invoice FOREIGN KEY (invoice_id) REFERENCES invoices(id) ON DELETE CASCADE
+);
+
+-- インデックス
+CREATE INDEX IF NOT EXISTS idx_invoice_tax_summaries_invoice_id ON invoice_tax_summaries(invoice_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_tax_summaries_invoice_category ON invoice_tax_summaries(invoice_id, tax_category);
+
+-- コメント
+COMMENT ON TABLE invoice_tax_summaries IS '請求書税率別小計テーブル';
+COMMENT ON COLUMN invoice_tax_summaries.invoice_id IS '請求書ID';
+COMMENT ON COLUMN invoice_tax_summaries.tax_category IS '税区分';
+COMMENT ON COLUMN invoice_tax_summaries.tax_rate IS '税率（%）';
+COMMENT ON COLUMN invoice_tax_summaries.taxable_amount IS '税率ごとの対価の額（税抜）';
+COMMENT ON COLUMN invoice_tax_summaries.tax_amount IS '税率ごとの消費税額（請求書単位で端数処理）';
+
+-- トリガー
+CREATE OR REPLACE TRIGGER update_invoice_tax_summaries_updated_at
+    BEFORE UPDATE ON invoice_tax_summaries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
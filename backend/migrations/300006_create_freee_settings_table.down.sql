@@ -0,0 +1,3 @@
+-- freee連携設定テーブルの削除
+DROP TRIGGER IF EXISTS update_freee_settings_updated_at ON freee_settings;
+DROP TABLE IF EXISTS freee_settings;
@@ -0,0 +1,46 @@
+-- freee連携設定テーブルの作成
+-- OAuthトークンはアプリケーション側でAES-GCM暗号化した値を保存する
+CREATE TABLE IF NOT EXISTS freee_settings (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    company_id INT NOT NULL,
+    company_name VARCHAR(255) NOT NULL,
+    access_token TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    token_expires_at TIMESTAMP(3) NOT NULL,
+    last_sync_at TIMESTAMP(3),
+    auto_sync_enabled BOOLEAN DEFAULT FALSE,
+    sync_interval INT DEFAULT 60,
+    default_account_item_id INT,
+    default_tax_code INT DEFAULT 8,
+    invoice_layout VARCHAR(50) DEFAULT 'default_layout',
+    tax_entry_method VARCHAR(50) DEFAULT 'inclusive',
+    last_sync_status VARCHAR(20),
+    last_sync_error TEXT,
+    sync_retry_count INT DEFAULT 0,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    deleted_at TIMESTAMP(3),
+    CONSTRAINT fk_freee_settings_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- インデックス
+CREATE UNIQUE INDEX IF NOT EXISTS idx_freee_settings_user ON freee_settings(user_id);
+CREATE INDEX IF NOT EXISTS idx_freee_settings_deleted_at ON freee_settings(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_freee_settings_auto_sync ON freee_settings(auto_sync_enabled) WHERE deleted_at IS NULL;
+
+-- コメント
+COMMENT ON TABLE freee_settings IS 'freee連携設定テーブル';
+COMMENT ON COLUMN freee_settings.user_id IS '連携したユーザーID';
+COMMENT ON COLUMN freee_settings.company_id IS 'freee事業所ID';
+COMMENT ON COLUMN freee_settings.company_name IS 'freee事業所名';
+COMMENT ON COLUMN freee_settings.access_token IS 'アクセストークン（暗号化済み）';
+COMMENT ON COLUMN freee_settings.refresh_token IS 'リフレッシュトークン（暗号化済み）';
+COMMENT ON COLUMN freee_settings.token_expires_at IS 'アクセストークンの有効期限';
+COMMENT ON COLUMN freee_settings.sync_interval IS '自動同期の間隔（分）';
+
+-- トリガー
+CREATE OR REPLACE TRIGGER update_freee_settings_updated_at
+    BEFORE UPDATE ON freee_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
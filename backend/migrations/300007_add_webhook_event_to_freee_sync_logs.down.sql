@@ -0,0 +1,4 @@
+-- freee Webhookのイベント記録用カラムを削除
+-- ENUMの値は削除できないため、freee_sync_typeの'webhook'は残す
+DROP INDEX IF EXISTS idx_sync_logs_event_id;
+ALTER TABLE freee_sync_logs DROP COLUMN IF EXISTS event_id;
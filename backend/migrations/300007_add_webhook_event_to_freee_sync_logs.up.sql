@@ -0,0 +1,9 @@
+-- freee Webhookのイベントを同期ログに記録するための拡張
+-- 再送されたイベントを二重に処理しないよう、イベントIDを一意にする
+ALTER TYPE freee_sync_type ADD VALUE IF NOT EXISTS 'webhook';
+
+ALTER TABLE freee_sync_logs ADD COLUMN IF NOT EXISTS event_id VARCHAR(100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sync_logs_event_id ON freee_sync_logs (event_id);
+
+COMMENT ON COLUMN freee_sync_logs.event_id IS 'freee WebhookのイベントID（Webhook以外はNULL）';
@@ -0,0 +1,6 @@
+-- 入出金明細の取込と入金消込のテーブル削除
+DROP TRIGGER IF EXISTS update_bank_transactions_updated_at ON bank_transactions;
+DROP TRIGGER IF EXISTS update_bank_statement_imports_updated_at ON bank_statement_imports;
+DROP TABLE IF EXISTS bank_transaction_allocations;
+DROP TABLE IF EXISTS bank_transactions;
+DROP TABLE IF EXISTS bank_statement_imports;
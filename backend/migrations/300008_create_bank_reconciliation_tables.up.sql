@@ -0,0 +1,99 @@
+-- 入出金明細の取込と入金消込のテーブル作成
+
+-- 入出金明細の取込履歴
+CREATE TABLE IF NOT EXISTS bank_statement_imports (
+    id VARCHAR(36) PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(20) NOT NULL,
+    bank_code VARCHAR(4),
+    bank_name VARCHAR(100),
+    branch_name VARCHAR(100),
+    account_number VARCHAR(20),
+    period_from TIMESTAMP(3),
+    period_to TIMESTAMP(3),
+    total_count INT DEFAULT 0,
+    deposit_count INT DEFAULT 0,
+    duplicate_count INT DEFAULT 0,
+    matched_count INT DEFAULT 0,
+    review_count INT DEFAULT 0,
+    unmatched_count INT DEFAULT 0,
+    imported_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_bank_statement_imports_format CHECK (format IN ('zengin', 'csv'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_imports_created_at ON bank_statement_imports(created_at);
+
+-- 取込んだ入出金明細
+CREATE TABLE IF NOT EXISTS bank_transactions (
+    id VARCHAR(36) PRIMARY KEY,
+    import_id VARCHAR(36) NOT NULL,
+    dedupe_key VARCHAR(64) NOT NULL,
+    transaction_date DATE NOT NULL,
+    direction VARCHAR(20) NOT NULL,
+    amount BIGINT NOT NULL,
+    payer_name VARCHAR(255),
+    payer_kana VARCHAR(255),
+    description VARCHAR(255),
+    reference VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'unmatched',
+    match_type VARCHAR(20),
+    confidence DECIMAL(3,2) DEFAULT 0,
+    fee_amount BIGINT DEFAULT 0,
+    overpaid_amount BIGINT DEFAULT 0,
+    review_reason VARCHAR(255),
+    candidate_ids TEXT,
+    confirmed_by VARCHAR(36),
+    confirmed_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_bank_transactions_import FOREIGN KEY (import_id) REFERENCES bank_statement_imports(id) ON DELETE CASCADE,
+    CONSTRAINT chk_bank_transactions_direction CHECK (direction IN ('deposit', 'withdrawal')),
+    CONSTRAINT chk_bank_transactions_status CHECK (status IN ('unmatched', 'needs_review', 'matched', 'confirmed', 'ignored'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_transactions_dedupe_key ON bank_transactions(dedupe_key);
+CREATE INDEX IF NOT EXISTS idx_bank_transactions_import_id ON bank_transactions(import_id);
+CREATE INDEX IF NOT EXISTS idx_bank_transactions_status_date ON bank_transactions(status, transaction_date);
+
+-- 入金の請求書への割当
+CREATE TABLE IF NOT EXISTS bank_transaction_allocations (
+    id VARCHAR(36) PRIMARY KEY,
+    bank_transaction_id VARCHAR(36) NOT NULL,
+    invoice_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    fee_amount BIGINT DEFAULT 0,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_bank_transaction_allocations_transaction FOREIGN KEY (bank_transaction_id) REFERENCES bank_transactions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bank_transaction_allocations_invoice FOREIGN KEY (invoice_id) REFERENCES invoices(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_transaction_allocations_transaction ON bank_transaction_allocations(bank_transaction_id);
+CREATE INDEX IF NOT EXISTS idx_bank_transaction_allocations_invoice ON bank_transaction_allocations(invoice_id);
+
+-- コメント
+COMMENT ON TABLE bank_statement_imports IS '入出金明細の取込履歴';
+COMMENT ON COLUMN bank_statement_imports.format IS '取込形式（zengin: 全銀協フォーマット, csv: 汎用CSV）';
+COMMENT ON COLUMN bank_statement_imports.duplicate_count IS '取込済みのため読み飛ばした明細数';
+COMMENT ON TABLE bank_transactions IS '取込んだ入出金明細';
+COMMENT ON COLUMN bank_transactions.dedupe_key IS '二重取込防止キー（口座・日付・金額・依頼人名などのハッシュ）';
+COMMENT ON COLUMN bank_transactions.payer_kana IS '照合用に正規化した振込依頼人名';
+COMMENT ON COLUMN bank_transactions.status IS '消込ステータス（unmatched, needs_review, matched, confirmed, ignored）';
+COMMENT ON COLUMN bank_transactions.match_type IS '照合種別（exact, fee_shortfall, combined, partial, overpayment, amount_only, none, manual）';
+COMMENT ON COLUMN bank_transactions.fee_amount IS '振込手数料として差し引かれた額';
+COMMENT ON COLUMN bank_transactions.overpaid_amount IS '請求残高を超えて入金された額';
+COMMENT ON TABLE bank_transaction_allocations IS '入金の請求書への割当（確定前は照合結果の提案）';
+COMMENT ON COLUMN bank_transaction_allocations.amount IS '入金から充当した額';
+COMMENT ON COLUMN bank_transaction_allocations.fee_amount IS '振込手数料として請求額から差し引いた額';
+
+-- トリガー
+CREATE OR REPLACE TRIGGER update_bank_statement_imports_updated_at
+    BEFORE UPDATE ON bank_statement_imports
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE OR REPLACE TRIGGER update_bank_transactions_updated_at
+    BEFORE UPDATE ON bank_transactions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- 督促ワークフローの削除
+-- ENUMの値は削除できないため、invoice_audit_action・scheduled_job_typeに追加した値は残す
+DELETE FROM scheduled_jobs WHERE job_type = 'invoice_reminder';
+DROP INDEX IF EXISTS idx_invoice_audit_logs_invoice_action;
@@ -0,0 +1,12 @@
+-- 支払期限超過の検知と督促ワークフロー
+-- 督促の送信履歴は請求書監査ログ（タイムライン）に記録する
+ALTER TYPE invoice_audit_action ADD VALUE IF NOT EXISTS 'dunning_reminder';
+ALTER TYPE invoice_audit_action ADD VALUE IF NOT EXISTS 'dunning_second_notice';
+ALTER TYPE invoice_audit_action ADD VALUE IF NOT EXISTS 'dunning_escalation';
+
+-- 督促スケジュールはinvoice_reminderジョブのパラメータとして保持する
+-- （ジョブが未登録の場合は既定のスケジュールを使い、督促メールは送らない）
+ALTER TYPE scheduled_job_type ADD VALUE IF NOT EXISTS 'invoice_reminder';
+
+-- 督促済みステップの確認用
+CREATE INDEX IF NOT EXISTS idx_invoice_audit_logs_invoice_action ON invoice_audit_logs (invoice_id, action);
@@ -0,0 +1,8 @@
+-- 赤伝（取消請求書）と訂正再発行の対応を削除
+DROP INDEX IF EXISTS idx_invoices_credit_note_original;
+DROP INDEX IF EXISTS idx_invoices_original_invoice_id;
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS fk_invoices_original_invoice;
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS chk_invoices_invoice_type;
+ALTER TABLE invoices DROP COLUMN IF EXISTS revision;
+ALTER TABLE invoices DROP COLUMN IF EXISTS original_invoice_id;
+ALTER TABLE invoices DROP COLUMN IF EXISTS invoice_type;
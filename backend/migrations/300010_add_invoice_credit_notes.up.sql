@@ -0,0 +1,21 @@
+-- 赤伝（取消請求書）と訂正再発行の対応
+-- 発行済みの請求書は直接編集せず、赤伝で取り消して新しい番号で再発行する
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS invoice_type VARCHAR(20) NOT NULL DEFAULT 'standard';
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS original_invoice_id VARCHAR(36);
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 0;
+
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS chk_invoices_invoice_type;
+ALTER TABLE invoices ADD CONSTRAINT chk_invoices_invoice_type CHECK (invoice_type IN ('standard', 'credit_note'));
+
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS fk_invoices_original_invoice;
+ALTER TABLE invoices ADD CONSTRAINT fk_invoices_original_invoice FOREIGN KEY (original_invoice_id) REFERENCES invoices(id);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_original_invoice_id ON invoices(original_invoice_id);
+
+-- 1件の請求書に対する赤伝は1件のみ
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_credit_note_original ON invoices(original_invoice_id)
+    WHERE invoice_type = 'credit_note' AND deleted_at IS NULL;
+
+COMMENT ON COLUMN invoices.invoice_type IS '請求書種別（standard: 通常, credit_note: 赤伝）';
+COMMENT ON COLUMN invoices.original_invoice_id IS '赤伝は取消対象、再発行は訂正元の請求書ID';
+COMMENT ON COLUMN invoices.revision IS '訂正による再発行の版数（初回発行は0）';
@@ -0,0 +1,9 @@
+-- 月次請求のテーブル削除
+-- ENUMの値は削除できないため、scheduled_job_typeに追加した値は残す
+DELETE FROM scheduled_jobs WHERE job_type = 'billing';
+DROP INDEX IF EXISTS idx_invoices_billing_run_id;
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS fk_invoices_billing_run;
+ALTER TABLE invoices DROP COLUMN IF EXISTS billing_run_id;
+
+DROP TRIGGER IF EXISTS update_billing_runs_updated_at ON billing_runs;
+DROP TABLE IF EXISTS billing_runs;
@@ -0,0 +1,43 @@
+-- 月次請求（全取引先の請求書下書きを一括作成し、承認でロックする）
+
+-- 請求月ごとの実行履歴
+CREATE TABLE IF NOT EXISTS billing_runs (
+    id VARCHAR(36) PRIMARY KEY,
+    billing_month VARCHAR(7) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    run_count INT NOT NULL DEFAULT 0,
+    invoice_count INT NOT NULL DEFAULT 0,
+    total_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    executed_by VARCHAR(36) NOT NULL,
+    executed_at TIMESTAMP(3) NOT NULL,
+    approved_by VARCHAR(36),
+    approved_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_billing_runs_status CHECK (status IN ('draft', 'approved'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_billing_runs_billing_month ON billing_runs(billing_month);
+
+COMMENT ON TABLE billing_runs IS '月次請求の実行履歴';
+COMMENT ON COLUMN billing_runs.billing_month IS '請求月（YYYY-MM）';
+COMMENT ON COLUMN billing_runs.status IS '状態（draft: 下書き・再実行可, approved: 承認済み・ロック）';
+COMMENT ON COLUMN billing_runs.run_count IS '実行回数（再実行で加算）';
+COMMENT ON COLUMN billing_runs.invoice_count IS '作成した請求書の件数';
+COMMENT ON COLUMN billing_runs.total_amount IS '作成した請求書の合計額（税込）';
+COMMENT ON COLUMN billing_runs.executed_by IS '最終実行者（スケジュール実行の場合はジョブの作成者）';
+
+CREATE OR REPLACE TRIGGER update_billing_runs_updated_at
+    BEFORE UPDATE ON billing_runs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 月次請求で作成した請求書
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS billing_run_id VARCHAR(36);
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS fk_invoices_billing_run;
+ALTER TABLE invoices ADD CONSTRAINT fk_invoices_billing_run FOREIGN KEY (billing_run_id) REFERENCES billing_runs(id);
+CREATE INDEX IF NOT EXISTS idx_invoices_billing_run_id ON invoices(billing_run_id);
+COMMENT ON COLUMN invoices.billing_run_id IS '月次請求の実行ID（手動作成の請求書はNULL）';
+
+-- 月次請求をスケジュールジョブとして登録できるようにする
+ALTER TYPE scheduled_job_type ADD VALUE IF NOT EXISTS 'billing';
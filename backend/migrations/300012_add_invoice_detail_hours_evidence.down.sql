@@ -0,0 +1,3 @@
+-- 請求明細の精算根拠を削除
+ALTER TABLE invoice_details DROP COLUMN IF EXISTS hours_evidence;
+ALTER TABLE invoice_details DROP COLUMN IF EXISTS actual_hours;
@@ -0,0 +1,6 @@
+-- 上下割・中間割の請求明細に精算の根拠（週報の稼働実績）を保持する
+ALTER TABLE invoice_details ADD COLUMN IF NOT EXISTS actual_hours DECIMAL(6, 2);
+ALTER TABLE invoice_details ADD COLUMN IF NOT EXISTS hours_evidence JSONB;
+
+COMMENT ON COLUMN invoice_details.actual_hours IS '精算に使った実稼働時間（固定額の明細はNULL）';
+COMMENT ON COLUMN invoice_details.hours_evidence IS '精算の根拠（精算幅、精算結果、集計した週報ごとの稼働時間）';
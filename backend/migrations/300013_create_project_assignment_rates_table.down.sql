@@ -0,0 +1,4 @@
+-- 案件アサインの単価履歴を削除
+DROP TABLE IF EXISTS project_assignment_rate_audit_logs;
+DROP TRIGGER IF EXISTS update_project_assignment_rates_updated_at ON project_assignment_rates;
+DROP TABLE IF EXISTS project_assignment_rates;
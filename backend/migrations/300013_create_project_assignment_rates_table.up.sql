@@ -0,0 +1,74 @@
+-- 案件アサインの単価履歴（単価改定後も過去月の請求を再計算できるようにする）
+
+-- 適用期間ごとの請求単価・精算条件
+CREATE TABLE IF NOT EXISTS project_assignment_rates (
+    id VARCHAR(36) PRIMARY KEY,
+    assignment_id VARCHAR(36) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    billing_type billing_type_enum NOT NULL DEFAULT 'fixed',
+    billing_rate DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    min_hours DECIMAL(5, 2),
+    max_hours DECIMAL(5, 2),
+    notes TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    deleted_at TIMESTAMP(3) NULL,
+    CONSTRAINT fk_project_assignment_rates_assignment FOREIGN KEY (assignment_id) REFERENCES project_assignments(id),
+    CONSTRAINT chk_project_assignment_rates_period CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_assignment_rates_assignment ON project_assignment_rates(assignment_id, effective_from) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_project_assignment_rates_deleted_at ON project_assignment_rates(deleted_at);
+
+COMMENT ON TABLE project_assignment_rates IS '案件アサインの単価履歴';
+COMMENT ON COLUMN project_assignment_rates.assignment_id IS '案件アサインID';
+COMMENT ON COLUMN project_assignment_rates.effective_from IS '適用開始日';
+COMMENT ON COLUMN project_assignment_rates.effective_to IS '適用終了日（NULLは終了日なし）';
+COMMENT ON COLUMN project_assignment_rates.billing_type IS '精算タイプ';
+COMMENT ON COLUMN project_assignment_rates.billing_rate IS '請求単価（月額）';
+COMMENT ON COLUMN project_assignment_rates.min_hours IS '精算下限時間';
+COMMENT ON COLUMN project_assignment_rates.max_hours IS '精算上限時間';
+
+CREATE OR REPLACE TRIGGER update_project_assignment_rates_updated_at
+    BEFORE UPDATE ON project_assignment_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 単価履歴の変更ログ
+CREATE TABLE IF NOT EXISTS project_assignment_rate_audit_logs (
+    id VARCHAR(36) PRIMARY KEY,
+    rate_id VARCHAR(36) NOT NULL,
+    assignment_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    old_values JSONB,
+    new_values JSONB,
+    reason TEXT,
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_project_assignment_rate_audit_logs_rate FOREIGN KEY (rate_id) REFERENCES project_assignment_rates(id),
+    CONSTRAINT chk_project_assignment_rate_audit_logs_action CHECK (action IN ('create', 'update', 'delete'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_assignment_rate_audit_logs_rate ON project_assignment_rate_audit_logs(rate_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_project_assignment_rate_audit_logs_assignment ON project_assignment_rate_audit_logs(assignment_id, changed_at);
+
+COMMENT ON TABLE project_assignment_rate_audit_logs IS '単価履歴の変更ログ';
+COMMENT ON COLUMN project_assignment_rate_audit_logs.action IS 'アクション（create, update, delete）';
+COMMENT ON COLUMN project_assignment_rate_audit_logs.old_values IS '変更前の内容';
+COMMENT ON COLUMN project_assignment_rate_audit_logs.new_values IS '変更後の内容';
+COMMENT ON COLUMN project_assignment_rate_audit_logs.reason IS '変更理由';
+
+-- 既存のアサインは現在の単価をアサイン期間全体の単価履歴として登録する
+INSERT INTO project_assignment_rates (
+    id, assignment_id, effective_from, effective_to, billing_type, billing_rate,
+    min_hours, max_hours, notes, created_by, updated_by
+)
+SELECT
+    gen_random_uuid()::VARCHAR, pa.id, pa.start_date, pa.end_date, COALESCE(pa.billing_type, 'fixed'), COALESCE(pa.billing_rate, 0),
+    pa.min_hours, pa.max_hours, '既存のアサインから移行', 'system', 'system'
+FROM project_assignments pa
+WHERE pa.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM project_assignment_rates r WHERE r.assignment_id = pa.id);
@@ -0,0 +1,6 @@
+-- 日割り方法の設定を削除
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS chk_projects_proration_method;
+ALTER TABLE projects DROP COLUMN IF EXISTS proration_method;
+
+ALTER TABLE clients DROP CONSTRAINT IF EXISTS chk_clients_proration_method;
+ALTER TABLE clients DROP COLUMN IF EXISTS proration_method;
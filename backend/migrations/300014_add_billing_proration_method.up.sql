@@ -0,0 +1,12 @@
+-- 月の途中の参画・離任時の日割り方法（取引先ごとに設定し、案件ごとに上書きできる）
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS proration_method VARCHAR(20) NOT NULL DEFAULT 'business_days';
+ALTER TABLE clients DROP CONSTRAINT IF EXISTS chk_clients_proration_method;
+ALTER TABLE clients ADD CONSTRAINT chk_clients_proration_method
+    CHECK (proration_method IN ('business_days', 'calendar_days', 'hours'));
+COMMENT ON COLUMN clients.proration_method IS '日割り方法（business_days: 営業日按分, calendar_days: 暦日按分, hours: 稼働時間×時間単価）';
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS proration_method VARCHAR(20);
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS chk_projects_proration_method;
+ALTER TABLE projects ADD CONSTRAINT chk_projects_proration_method
+    CHECK (proration_method IS NULL OR proration_method IN ('business_days', 'calendar_days', 'hours'));
+COMMENT ON COLUMN projects.proration_method IS '日割り方法（NULLは取引先の設定に従う）';
@@ -0,0 +1,9 @@
+-- ビジネスパートナーからの仕入と粗利管理のテーブルを削除
+DROP TRIGGER IF EXISTS update_partner_invoices_updated_at ON partner_invoices;
+DROP TABLE IF EXISTS partner_invoices;
+DROP TRIGGER IF EXISTS update_purchase_orders_updated_at ON purchase_orders;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TRIGGER IF EXISTS update_project_assignment_costs_updated_at ON project_assignment_costs;
+DROP TABLE IF EXISTS project_assignment_costs;
+DROP TRIGGER IF EXISTS update_business_partners_updated_at ON business_partners;
+DROP TABLE IF EXISTS business_partners;
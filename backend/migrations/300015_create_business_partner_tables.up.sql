@@ -0,0 +1,163 @@
+-- ビジネスパートナー（協力会社）からの仕入と粗利管理
+
+-- ビジネスパートナー
+CREATE TABLE IF NOT EXISTS business_partners (
+    id VARCHAR(36) PRIMARY KEY,
+    company_name VARCHAR(200) NOT NULL,
+    company_name_kana VARCHAR(200),
+    registration_number VARCHAR(14),
+    payment_terms INT DEFAULT 30,
+    proration_method VARCHAR(20) NOT NULL DEFAULT 'business_days',
+    contact_person VARCHAR(100),
+    contact_email VARCHAR(100),
+    contact_phone VARCHAR(20),
+    address VARCHAR(255),
+    notes TEXT,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    deleted_at TIMESTAMP(3) NULL,
+    CONSTRAINT chk_business_partners_proration_method CHECK (proration_method IN ('business_days', 'calendar_days', 'hours'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_business_partners_company_name ON business_partners(company_name);
+CREATE INDEX IF NOT EXISTS idx_business_partners_deleted_at ON business_partners(deleted_at);
+
+COMMENT ON TABLE business_partners IS 'ビジネスパートナー（協力会社）';
+COMMENT ON COLUMN business_partners.registration_number IS '適格請求書発行事業者登録番号';
+COMMENT ON COLUMN business_partners.payment_terms IS '支払サイト（日）';
+COMMENT ON COLUMN business_partners.proration_method IS '月途中の参画・離任時の日割り方法';
+
+CREATE OR REPLACE TRIGGER update_business_partners_updated_at
+    BEFORE UPDATE ON business_partners
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 案件アサインの仕入単価
+CREATE TABLE IF NOT EXISTS project_assignment_costs (
+    id VARCHAR(36) PRIMARY KEY,
+    assignment_id VARCHAR(36) NOT NULL,
+    partner_id VARCHAR(36) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    cost_type billing_type_enum NOT NULL DEFAULT 'fixed',
+    cost_rate DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    min_hours DECIMAL(5, 2),
+    max_hours DECIMAL(5, 2),
+    notes TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    deleted_at TIMESTAMP(3) NULL,
+    CONSTRAINT fk_project_assignment_costs_assignment FOREIGN KEY (assignment_id) REFERENCES project_assignments(id),
+    CONSTRAINT fk_project_assignment_costs_partner FOREIGN KEY (partner_id) REFERENCES business_partners(id),
+    CONSTRAINT chk_project_assignment_costs_period CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_assignment_costs_assignment ON project_assignment_costs(assignment_id, effective_from) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_project_assignment_costs_partner ON project_assignment_costs(partner_id);
+CREATE INDEX IF NOT EXISTS idx_project_assignment_costs_deleted_at ON project_assignment_costs(deleted_at);
+
+COMMENT ON TABLE project_assignment_costs IS '案件アサインの仕入単価';
+COMMENT ON COLUMN project_assignment_costs.partner_id IS 'ビジネスパートナーID';
+COMMENT ON COLUMN project_assignment_costs.effective_from IS '適用開始日';
+COMMENT ON COLUMN project_assignment_costs.effective_to IS '適用終了日（NULLは終了日なし）';
+COMMENT ON COLUMN project_assignment_costs.cost_type IS '精算タイプ';
+COMMENT ON COLUMN project_assignment_costs.cost_rate IS '仕入単価（月額）';
+COMMENT ON COLUMN project_assignment_costs.min_hours IS '精算下限時間';
+COMMENT ON COLUMN project_assignment_costs.max_hours IS '精算上限時間';
+
+CREATE OR REPLACE TRIGGER update_project_assignment_costs_updated_at
+    BEFORE UPDATE ON project_assignment_costs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 注文書
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id VARCHAR(36) PRIMARY KEY,
+    order_number VARCHAR(50) NOT NULL UNIQUE,
+    partner_id VARCHAR(36) NOT NULL,
+    assignment_id VARCHAR(36) NOT NULL,
+    cost_id VARCHAR(36) NOT NULL,
+    order_month VARCHAR(7) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    cost_type billing_type_enum NOT NULL,
+    cost_rate DECIMAL(10, 2) NOT NULL,
+    min_hours DECIMAL(5, 2),
+    max_hours DECIMAL(5, 2),
+    status VARCHAR(20) NOT NULL DEFAULT 'issued',
+    issued_by VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMP(3) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_purchase_orders_partner FOREIGN KEY (partner_id) REFERENCES business_partners(id),
+    CONSTRAINT fk_purchase_orders_assignment FOREIGN KEY (assignment_id) REFERENCES project_assignments(id),
+    CONSTRAINT fk_purchase_orders_cost FOREIGN KEY (cost_id) REFERENCES project_assignment_costs(id),
+    CONSTRAINT chk_purchase_orders_status CHECK (status IN ('issued', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_partner ON purchase_orders(partner_id, order_month);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_assignment ON purchase_orders(assignment_id, order_month);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchase_orders_issued ON purchase_orders(cost_id, order_month) WHERE status = 'issued';
+
+COMMENT ON TABLE purchase_orders IS 'ビジネスパートナーへの注文書';
+COMMENT ON COLUMN purchase_orders.order_month IS '対象月（YYYY-MM）';
+COMMENT ON COLUMN purchase_orders.period_start IS '作業期間（開始）';
+COMMENT ON COLUMN purchase_orders.period_end IS '作業期間（終了）';
+COMMENT ON COLUMN purchase_orders.cost_rate IS '発行時点の仕入単価';
+
+CREATE OR REPLACE TRIGGER update_purchase_orders_updated_at
+    BEFORE UPDATE ON purchase_orders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 受領した請求書
+CREATE TABLE IF NOT EXISTS partner_invoices (
+    id VARCHAR(36) PRIMARY KEY,
+    partner_id VARCHAR(36) NOT NULL,
+    purchase_order_id VARCHAR(36) NOT NULL,
+    assignment_id VARCHAR(36) NOT NULL,
+    billing_month VARCHAR(7) NOT NULL,
+    invoice_number VARCHAR(50),
+    invoice_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    hours DECIMAL(6, 2),
+    subtotal DECIMAL(12, 2) NOT NULL,
+    tax_amount DECIMAL(12, 2) NOT NULL,
+    total_amount DECIMAL(12, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expected_amount DECIMAL(12, 2),
+    expected_hours DECIMAL(6, 2),
+    billed_hours DECIMAL(6, 2),
+    match_notes TEXT,
+    approved_by VARCHAR(255),
+    approved_at TIMESTAMP(3),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_partner_invoices_partner FOREIGN KEY (partner_id) REFERENCES business_partners(id),
+    CONSTRAINT fk_partner_invoices_purchase_order FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id),
+    CONSTRAINT fk_partner_invoices_assignment FOREIGN KEY (assignment_id) REFERENCES project_assignments(id),
+    CONSTRAINT chk_partner_invoices_status CHECK (status IN ('matched', 'mismatched', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_partner_invoices_partner ON partner_invoices(partner_id, billing_month);
+CREATE INDEX IF NOT EXISTS idx_partner_invoices_purchase_order ON partner_invoices(purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_partner_invoices_billing_month ON partner_invoices(billing_month, status);
+
+COMMENT ON TABLE partner_invoices IS 'ビジネスパートナーから受領した請求書';
+COMMENT ON COLUMN partner_invoices.invoice_number IS 'パートナー側の請求書番号';
+COMMENT ON COLUMN partner_invoices.hours IS '請求書に記載された稼働時間';
+COMMENT ON COLUMN partner_invoices.subtotal IS '請求額（税抜）';
+COMMENT ON COLUMN partner_invoices.status IS '状態（matched, mismatched, approved, rejected）';
+COMMENT ON COLUMN partner_invoices.expected_amount IS '注文条件と週報から計算した仕入額（税抜）';
+COMMENT ON COLUMN partner_invoices.expected_hours IS '承認済みの週報の稼働時間';
+COMMENT ON COLUMN partner_invoices.billed_hours IS '取引先に請求した稼働時間';
+COMMENT ON COLUMN partner_invoices.match_notes IS '突合で見つかった差異';
+
+CREATE OR REPLACE TRIGGER update_partner_invoices_updated_at
+    BEFORE UPDATE ON partner_invoices
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
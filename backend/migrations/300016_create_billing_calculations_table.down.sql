@@ -0,0 +1,4 @@
+-- 請求計算のスナップショットを削除
+DROP TRIGGER IF EXISTS prevent_billing_calculations_modification ON billing_calculations;
+DROP FUNCTION IF EXISTS prevent_billing_calculation_modification();
+DROP TABLE IF EXISTS billing_calculations;
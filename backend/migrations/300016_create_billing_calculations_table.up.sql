@@ -0,0 +1,60 @@
+-- 確定した請求計算のスナップショット（変更・削除不可）
+
+CREATE TABLE IF NOT EXISTS billing_calculations (
+    id VARCHAR(36) PRIMARY KEY,
+    billing_month VARCHAR(7) NOT NULL,
+    assignment_id VARCHAR(36) NOT NULL,
+    revision INT NOT NULL,
+    client_id VARCHAR(36) NOT NULL,
+    project_id VARCHAR(36) NOT NULL,
+    project_group_id VARCHAR(36),
+    user_id VARCHAR(36) NOT NULL,
+    billing_run_id VARCHAR(36),
+    invoice_id VARCHAR(36) NOT NULL,
+    invoice_detail_id VARCHAR(36) NOT NULL,
+    invoice_number VARCHAR(50) NOT NULL,
+    billing_type billing_type_enum NOT NULL,
+    monthly_rate DECIMAL(10, 2) NOT NULL,
+    min_hours DECIMAL(5, 2),
+    max_hours DECIMAL(5, 2),
+    proration_method VARCHAR(20),
+    proration_basis VARCHAR(255),
+    actual_hours DECIMAL(6, 2),
+    rate_segments JSONB,
+    hours_evidence JSONB,
+    billing_amount DECIMAL(12, 2) NOT NULL,
+    notes TEXT,
+    calculated_by VARCHAR(255) NOT NULL,
+    calculated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT uq_billing_calculations_revision UNIQUE (assignment_id, billing_month, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_billing_calculations_month ON billing_calculations(billing_month);
+CREATE INDEX IF NOT EXISTS idx_billing_calculations_client ON billing_calculations(client_id, billing_month);
+CREATE INDEX IF NOT EXISTS idx_billing_calculations_project_group ON billing_calculations(project_group_id, billing_month);
+CREATE INDEX IF NOT EXISTS idx_billing_calculations_user ON billing_calculations(user_id, billing_month);
+CREATE INDEX IF NOT EXISTS idx_billing_calculations_invoice ON billing_calculations(invoice_id);
+
+COMMENT ON TABLE billing_calculations IS '確定した請求計算のスナップショット（変更・削除不可）';
+COMMENT ON COLUMN billing_calculations.revision IS '同じアサイン・請求月の計算の版（1から）';
+COMMENT ON COLUMN billing_calculations.invoice_id IS '計算結果から作成した請求書（下書きの作り直しで削除されても残す）';
+COMMENT ON COLUMN billing_calculations.invoice_number IS '請求書番号';
+COMMENT ON COLUMN billing_calculations.monthly_rate IS '月末時点の月額単価';
+COMMENT ON COLUMN billing_calculations.proration_basis IS '日割りの根拠';
+COMMENT ON COLUMN billing_calculations.actual_hours IS '承認済みの週報の稼働時間';
+COMMENT ON COLUMN billing_calculations.rate_segments IS '単価履歴の区間ごとの精算結果';
+COMMENT ON COLUMN billing_calculations.hours_evidence IS '精算に使った週報と稼働時間';
+COMMENT ON COLUMN billing_calculations.billing_amount IS '請求額（税抜）';
+
+-- スナップショットの変更・削除を禁止するトリガー関数
+CREATE OR REPLACE FUNCTION prevent_billing_calculation_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '請求計算のスナップショットは変更・削除できません (id: %)', OLD.id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER prevent_billing_calculations_modification
+    BEFORE UPDATE OR DELETE ON billing_calculations
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_billing_calculation_modification();
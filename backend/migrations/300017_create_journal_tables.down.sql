@@ -0,0 +1,8 @@
+-- 仕訳出力のテーブルを削除
+DROP TABLE IF EXISTS journal_exports;
+DROP TRIGGER IF EXISTS prevent_journal_entries_modification ON journal_entries;
+DROP FUNCTION IF EXISTS prevent_journal_entry_modification();
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS accounting_period_closes;
+DROP TRIGGER IF EXISTS update_journal_account_mappings_updated_at ON journal_account_mappings;
+DROP TABLE IF EXISTS journal_account_mappings;
@@ -0,0 +1,121 @@
+-- 仕訳出力（勘定科目設定・月次締め・締め済み仕訳・出力履歴）
+
+-- 勘定科目設定（経費カテゴリ・取引の種類ごと）
+CREATE TABLE IF NOT EXISTS journal_account_mappings (
+    id VARCHAR(36) PRIMARY KEY,
+    mapping_key VARCHAR(100) NOT NULL UNIQUE,
+    debit_account VARCHAR(100) NOT NULL,
+    debit_sub_account VARCHAR(100),
+    debit_tax_category VARCHAR(50),
+    credit_account VARCHAR(100) NOT NULL,
+    credit_sub_account VARCHAR(100),
+    credit_tax_category VARCHAR(50),
+    updated_by VARCHAR(36),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo')
+);
+
+COMMENT ON TABLE journal_account_mappings IS '仕訳の勘定科目設定';
+COMMENT ON COLUMN journal_account_mappings.mapping_key IS '設定キー（invoice_sales:<税区分>、invoice_collection、invoice_transfer_fee、expense_payment、expense_category:<カテゴリコード>）';
+COMMENT ON COLUMN journal_account_mappings.debit_tax_category IS '借方税区分（会計ソフトの表記）';
+COMMENT ON COLUMN journal_account_mappings.credit_tax_category IS '貸方税区分（会計ソフトの表記）';
+
+CREATE OR REPLACE TRIGGER update_journal_account_mappings_updated_at
+    BEFORE UPDATE ON journal_account_mappings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 初期設定
+INSERT INTO journal_account_mappings (id, mapping_key, debit_account, debit_tax_category, credit_account, credit_tax_category) VALUES
+    (gen_random_uuid()::text, 'invoice_sales:standard_10', '売掛金', '', '売上高', '課税売上10%'),
+    (gen_random_uuid()::text, 'invoice_sales:reduced_8', '売掛金', '', '売上高', '課税売上8%(軽)'),
+    (gen_random_uuid()::text, 'invoice_sales:exempt', '売掛金', '', '売上高', '輸出売上'),
+    (gen_random_uuid()::text, 'invoice_sales:non_taxable', '売掛金', '', '売上高', '非課売上'),
+    (gen_random_uuid()::text, 'invoice_collection', '普通預金', '', '売掛金', ''),
+    (gen_random_uuid()::text, 'invoice_transfer_fee', '支払手数料', '課対仕入10%', '売掛金', ''),
+    (gen_random_uuid()::text, 'expense_payment', '未払金', '', '普通預金', ''),
+    (gen_random_uuid()::text, 'expense_category:transport', '旅費交通費', '課対仕入10%', '未払金', ''),
+    (gen_random_uuid()::text, 'expense_category:entertainment', '交際費', '課対仕入10%', '未払金', ''),
+    (gen_random_uuid()::text, 'expense_category:supplies', '消耗品費', '課対仕入10%', '未払金', ''),
+    (gen_random_uuid()::text, 'expense_category:books', '新聞図書費', '課対仕入10%', '未払金', ''),
+    (gen_random_uuid()::text, 'expense_category:seminar', '研修費', '課対仕入10%', '未払金', ''),
+    (gen_random_uuid()::text, 'expense_category:other', '雑費', '課対仕入10%', '未払金', '')
+ON CONFLICT (mapping_key) DO NOTHING;
+
+-- 仕訳の月次締め
+CREATE TABLE IF NOT EXISTS accounting_period_closes (
+    id VARCHAR(36) PRIMARY KEY,
+    month VARCHAR(7) NOT NULL UNIQUE,
+    entry_count INT NOT NULL,
+    total_amount BIGINT NOT NULL,
+    closed_by VARCHAR(36) NOT NULL,
+    closed_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo')
+);
+
+COMMENT ON TABLE accounting_period_closes IS '仕訳の月次締め（締めは月の順に行う）';
+COMMENT ON COLUMN accounting_period_closes.total_amount IS '締めた仕訳の借方合計（円）';
+
+-- 締め済みの仕訳（変更・削除不可）
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id VARCHAR(36) PRIMARY KEY,
+    month VARCHAR(7) NOT NULL,
+    slip_number INT NOT NULL,
+    entry_date DATE NOT NULL,
+    source_type VARCHAR(20) NOT NULL,
+    source_id VARCHAR(36) NOT NULL,
+    mapping_key VARCHAR(100) NOT NULL,
+    debit_account VARCHAR(100) NOT NULL,
+    debit_sub_account VARCHAR(100),
+    debit_tax_category VARCHAR(50),
+    debit_tax_amount BIGINT NOT NULL DEFAULT 0,
+    credit_account VARCHAR(100) NOT NULL,
+    credit_sub_account VARCHAR(100),
+    credit_tax_category VARCHAR(50),
+    credit_tax_amount BIGINT NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    partner_name VARCHAR(255),
+    description VARCHAR(255),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT uq_journal_entries_slip UNIQUE (month, slip_number),
+    CONSTRAINT uq_journal_entries_source UNIQUE (source_type, source_id, mapping_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_month ON journal_entries(month);
+
+COMMENT ON TABLE journal_entries IS '締め済みの仕訳（変更・削除不可）';
+COMMENT ON COLUMN journal_entries.month IS '計上した締め月（締め済みの月に遅れて発生した取引は翌月に計上する）';
+COMMENT ON COLUMN journal_entries.source_type IS '元データの種類（expense, invoice）';
+COMMENT ON COLUMN journal_entries.mapping_key IS '使用した勘定科目設定キー';
+COMMENT ON COLUMN journal_entries.amount IS '金額（円、常に正の値）';
+
+-- 締め済みの仕訳の変更・削除を禁止するトリガー関数
+CREATE OR REPLACE FUNCTION prevent_journal_entry_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '締め済みの仕訳は変更・削除できません (id: %)', OLD.id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER prevent_journal_entries_modification
+    BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_journal_entry_modification();
+
+-- 仕訳の出力履歴
+CREATE TABLE IF NOT EXISTS journal_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    month VARCHAR(7) NOT NULL,
+    format VARCHAR(20) NOT NULL,
+    locked BOOLEAN NOT NULL DEFAULT FALSE,
+    entry_count INT NOT NULL,
+    total_amount BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    exported_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo')
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_exports_month ON journal_exports(month, created_at);
+
+COMMENT ON TABLE journal_exports IS '仕訳の出力履歴';
+COMMENT ON COLUMN journal_exports.format IS '出力形式（freee, moneyforward, yayoi）';
+COMMENT ON COLUMN journal_exports.locked IS '締め済みの仕訳から出力したか';
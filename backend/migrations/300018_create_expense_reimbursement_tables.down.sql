@@ -0,0 +1,8 @@
+-- 経費精算の振込のテーブルを削除
+DROP INDEX IF EXISTS idx_expenses_payment_batch_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS payment_batch_id;
+DROP TABLE IF EXISTS expense_reimbursement_items;
+DROP TRIGGER IF EXISTS update_expense_reimbursement_batches_updated_at ON expense_reimbursement_batches;
+DROP TABLE IF EXISTS expense_reimbursement_batches;
+DROP TRIGGER IF EXISTS update_profile_bank_accounts_updated_at ON profile_bank_accounts;
+DROP TABLE IF EXISTS profile_bank_accounts;
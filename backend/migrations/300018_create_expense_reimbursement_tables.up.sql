@@ -0,0 +1,88 @@
+-- 経費精算の振込（社員の振込先口座・総合振込バッチ）
+
+-- 社員の振込先口座（口座番号・口座名義は暗号化して保存）
+CREATE TABLE IF NOT EXISTS profile_bank_accounts (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL UNIQUE,
+    bank_code VARCHAR(4) NOT NULL,
+    bank_name VARCHAR(30) NOT NULL,
+    branch_code VARCHAR(3) NOT NULL,
+    branch_name VARCHAR(30) NOT NULL,
+    account_type VARCHAR(1) NOT NULL,
+    account_number_encrypted TEXT NOT NULL,
+    account_holder_encrypted TEXT NOT NULL,
+    account_number_last4 VARCHAR(4) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_profile_bank_accounts_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE profile_bank_accounts IS '社員の振込先口座（経費精算用）';
+COMMENT ON COLUMN profile_bank_accounts.bank_name IS '金融機関名（カナ）';
+COMMENT ON COLUMN profile_bank_accounts.branch_name IS '支店名（カナ）';
+COMMENT ON COLUMN profile_bank_accounts.account_type IS '預金種目（1:普通 2:当座 4:貯蓄）';
+COMMENT ON COLUMN profile_bank_accounts.account_number_encrypted IS '口座番号（暗号化）';
+COMMENT ON COLUMN profile_bank_accounts.account_holder_encrypted IS '口座名義カナ（暗号化）';
+COMMENT ON COLUMN profile_bank_accounts.account_number_last4 IS '口座番号の下4桁（画面表示用）';
+
+CREATE OR REPLACE TRIGGER update_profile_bank_accounts_updated_at
+    BEFORE UPDATE ON profile_bank_accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 経費精算の振込バッチ
+CREATE TABLE IF NOT EXISTS expense_reimbursement_batches (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    total_amount BIGINT NOT NULL,
+    employee_count INT NOT NULL,
+    expense_count INT NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    confirmed_by VARCHAR(36),
+    confirmed_at TIMESTAMP(3),
+    cancelled_by VARCHAR(36),
+    cancelled_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_expense_reimbursement_batches_status CHECK (status IN ('draft', 'confirmed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_reimbursement_batches_payment_date ON expense_reimbursement_batches(payment_date);
+
+COMMENT ON TABLE expense_reimbursement_batches IS '経費精算の振込バッチ（支払日ごとの総合振込）';
+COMMENT ON COLUMN expense_reimbursement_batches.status IS 'draft:経理の確認待ち confirmed:振込確定 cancelled:取消';
+
+CREATE OR REPLACE TRIGGER update_expense_reimbursement_batches_updated_at
+    BEFORE UPDATE ON expense_reimbursement_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 振込バッチの社員ごとの振込
+CREATE TABLE IF NOT EXISTS expense_reimbursement_items (
+    id VARCHAR(36) PRIMARY KEY,
+    batch_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    expense_count INT NOT NULL,
+    bank_code VARCHAR(4) NOT NULL,
+    bank_name VARCHAR(30) NOT NULL,
+    branch_code VARCHAR(3) NOT NULL,
+    branch_name VARCHAR(30) NOT NULL,
+    account_type VARCHAR(1) NOT NULL,
+    account_number_encrypted TEXT NOT NULL,
+    account_holder_encrypted TEXT NOT NULL,
+    account_number_last4 VARCHAR(4) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_expense_reimbursement_items_batch FOREIGN KEY (batch_id) REFERENCES expense_reimbursement_batches(id) ON DELETE CASCADE,
+    CONSTRAINT uq_expense_reimbursement_items_user UNIQUE (batch_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_reimbursement_items_user ON expense_reimbursement_items(user_id);
+
+COMMENT ON TABLE expense_reimbursement_items IS '振込バッチの社員ごとの振込（振込先口座はバッチ作成時点の登録内容）';
+
+-- 経費申請を精算した振込バッチ
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS payment_batch_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_expenses_payment_batch_id ON expenses(payment_batch_id);
+COMMENT ON COLUMN expenses.payment_batch_id IS '精算した振込バッチ（振込データ作成時に設定、取消で解除）';
@@ -0,0 +1,3 @@
+-- freee同期キューを削除
+DROP TRIGGER IF EXISTS update_freee_sync_jobs_updated_at ON freee_sync_jobs;
+DROP TABLE IF EXISTS freee_sync_jobs;
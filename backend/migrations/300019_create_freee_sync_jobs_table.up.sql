@@ -0,0 +1,48 @@
+-- freee同期キュー（請求書・取引先の送信を再試行付きで実行する）
+CREATE TABLE IF NOT EXISTS freee_sync_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    company_id INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(36) NOT NULL,
+    active_key VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    next_run_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    locked_by VARCHAR(100),
+    locked_until TIMESTAMP(3),
+    last_error TEXT,
+    freee_id INT,
+    completed_at TIMESTAMP(3),
+    created_by VARCHAR(36) NOT NULL,
+    requeued_by VARCHAR(36),
+    requeued_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_freee_sync_jobs_target_type CHECK (target_type IN ('invoice', 'partner')),
+    CONSTRAINT chk_freee_sync_jobs_status CHECK (status IN ('queued', 'running', 'succeeded', 'dead'))
+);
+
+-- 同じ対象の未完了ジョブは1件まで（二重投入の防止）
+-- 部分インデックスはMySQLにないため、完了時にNULLに戻す列の一意制約で表す
+CREATE UNIQUE INDEX IF NOT EXISTS idx_freee_sync_jobs_active_key ON freee_sync_jobs (active_key);
+CREATE INDEX IF NOT EXISTS idx_freee_sync_jobs_due ON freee_sync_jobs (status, next_run_at);
+CREATE INDEX IF NOT EXISTS idx_freee_sync_jobs_company ON freee_sync_jobs (company_id, status);
+
+COMMENT ON TABLE freee_sync_jobs IS 'freee同期キュー';
+COMMENT ON COLUMN freee_sync_jobs.company_id IS 'freeeの事業所ID（事業所ごとに1件ずつ実行する）';
+COMMENT ON COLUMN freee_sync_jobs.user_id IS 'freee連携のトークンを使うユーザー';
+COMMENT ON COLUMN freee_sync_jobs.target_type IS '同期対象（invoice:請求書 partner:取引先）';
+COMMENT ON COLUMN freee_sync_jobs.active_key IS '未完了（実行待ち・実行中）の間だけ「同期対象:ID」を持つ一意キー';
+COMMENT ON COLUMN freee_sync_jobs.status IS 'ステータス（queued:実行待ち running:実行中 succeeded:成功 dead:再試行上限）';
+COMMENT ON COLUMN freee_sync_jobs.attempts IS '実行回数';
+COMMENT ON COLUMN freee_sync_jobs.next_run_at IS '次回実行日時（指数バックオフ）';
+COMMENT ON COLUMN freee_sync_jobs.locked_by IS '実行中のワーカー';
+COMMENT ON COLUMN freee_sync_jobs.locked_until IS '実行中のワーカーの占有期限（過ぎたら中断とみなす）';
+COMMENT ON COLUMN freee_sync_jobs.freee_id IS '同期したfreeeの請求書ID・取引先ID';
+
+CREATE OR REPLACE TRIGGER update_freee_sync_jobs_updated_at
+    BEFORE UPDATE ON freee_sync_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
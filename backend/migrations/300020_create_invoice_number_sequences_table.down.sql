@@ -0,0 +1,8 @@
+-- 請求書番号の採番を削除
+ALTER TABLE clients DROP COLUMN IF EXISTS invoice_number_prefix;
+
+DROP TRIGGER IF EXISTS update_invoice_number_allocations_updated_at ON invoice_number_allocations;
+DROP TABLE IF EXISTS invoice_number_allocations;
+
+DROP TRIGGER IF EXISTS update_invoice_number_sequences_updated_at ON invoice_number_sequences;
+DROP TABLE IF EXISTS invoice_number_sequences;
@@ -0,0 +1,57 @@
+-- 請求書番号の採番（会計年度ごとの連番を欠番なく払い出す）
+CREATE TABLE IF NOT EXISTS invoice_number_sequences (
+    scope_key VARCHAR(100) PRIMARY KEY,
+    fiscal_year INT NOT NULL,
+    last_value INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo')
+);
+
+COMMENT ON TABLE invoice_number_sequences IS '請求書番号の連番';
+COMMENT ON COLUMN invoice_number_sequences.scope_key IS '連番を共有する範囲（連番の位置を{seq}のまま残した番号）';
+COMMENT ON COLUMN invoice_number_sequences.fiscal_year IS '会計年度';
+COMMENT ON COLUMN invoice_number_sequences.last_value IS '最後に払い出した連番';
+
+CREATE OR REPLACE TRIGGER update_invoice_number_sequences_updated_at
+    BEFORE UPDATE ON invoice_number_sequences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 払い出した請求書番号の台帳（使われなくなった番号も欠番として残す）
+CREATE TABLE IF NOT EXISTS invoice_number_allocations (
+    id VARCHAR(36) PRIMARY KEY,
+    scope_key VARCHAR(100) NOT NULL,
+    fiscal_year INT NOT NULL,
+    sequence INT NOT NULL,
+    invoice_number VARCHAR(50) NOT NULL,
+    invoice_id VARCHAR(36),
+    client_id VARCHAR(36),
+    status VARCHAR(20) NOT NULL DEFAULT 'allocated',
+    allocated_by VARCHAR(36) NOT NULL,
+    void_reason TEXT,
+    voided_by VARCHAR(36),
+    voided_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_invoice_number_allocations_status CHECK (status IN ('allocated', 'voided'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_number_allocations_number ON invoice_number_allocations (invoice_number);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_number_allocations_scope_seq ON invoice_number_allocations (scope_key, sequence);
+CREATE INDEX IF NOT EXISTS idx_invoice_number_allocations_fiscal_year ON invoice_number_allocations (fiscal_year, status);
+CREATE INDEX IF NOT EXISTS idx_invoice_number_allocations_invoice ON invoice_number_allocations (invoice_id);
+
+COMMENT ON TABLE invoice_number_allocations IS '請求書番号の払い出し台帳';
+COMMENT ON COLUMN invoice_number_allocations.sequence IS '連番';
+COMMENT ON COLUMN invoice_number_allocations.invoice_id IS '番号を使用している（使用していた）請求書';
+COMMENT ON COLUMN invoice_number_allocations.status IS '状態（allocated:使用中 voided:欠番）';
+COMMENT ON COLUMN invoice_number_allocations.void_reason IS '欠番にした理由';
+
+CREATE OR REPLACE TRIGGER update_invoice_number_allocations_updated_at
+    BEFORE UPDATE ON invoice_number_allocations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 採番パターンの {CLIENT} に使う取引先ごとのプレフィックス
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS invoice_number_prefix VARCHAR(20) NOT NULL DEFAULT '';
+COMMENT ON COLUMN clients.invoice_number_prefix IS '請求書番号の取引先プレフィックス';
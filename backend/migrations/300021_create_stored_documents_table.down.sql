@@ -0,0 +1,9 @@
+-- 電子帳簿保存法の保存文書を削除
+DROP TRIGGER IF EXISTS protect_stored_documents_delete ON stored_documents;
+DROP TRIGGER IF EXISTS protect_stored_documents_update ON stored_documents;
+DROP FUNCTION IF EXISTS protect_stored_documents();
+
+DROP TRIGGER IF EXISTS update_document_chain_heads_updated_at ON document_chain_heads;
+DROP TABLE IF EXISTS document_chain_heads;
+
+DROP TABLE IF EXISTS stored_documents;
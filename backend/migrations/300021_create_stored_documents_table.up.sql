@@ -0,0 +1,115 @@
+-- 電子帳簿保存法に対応した文書保存（取引年月日・金額・取引先で検索でき、改ざんを検知できる）
+CREATE TABLE IF NOT EXISTS stored_documents (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    version INT NOT NULL,
+    document_type VARCHAR(20) NOT NULL,
+    source_type VARCHAR(30),
+    source_id VARCHAR(36),
+    transaction_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    counterparty VARCHAR(200) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_size BIGINT NOT NULL,
+    storage_key VARCHAR(500) NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    chain_seq BIGINT NOT NULL,
+    prev_chain_hash CHAR(64) NOT NULL,
+    chain_hash CHAR(64) NOT NULL,
+    retention_until DATE NOT NULL,
+    superseded_by_id VARCHAR(36),
+    superseded_at TIMESTAMP(3),
+    replace_reason TEXT,
+    disposed_at TIMESTAMP(3),
+    disposed_by VARCHAR(36),
+    registered_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_stored_documents_type CHECK (document_type IN ('invoice', 'receipt', 'other'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stored_documents_group_version ON stored_documents (group_id, version);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stored_documents_chain_seq ON stored_documents (chain_seq);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stored_documents_storage_key ON stored_documents (storage_key);
+CREATE INDEX IF NOT EXISTS idx_stored_documents_transaction_date ON stored_documents (transaction_date);
+CREATE INDEX IF NOT EXISTS idx_stored_documents_amount ON stored_documents (amount);
+CREATE INDEX IF NOT EXISTS idx_stored_documents_counterparty ON stored_documents (counterparty);
+CREATE INDEX IF NOT EXISTS idx_stored_documents_source ON stored_documents (source_type, source_id);
+
+COMMENT ON TABLE stored_documents IS '電子帳簿保存法の保存文書';
+COMMENT ON COLUMN stored_documents.group_id IS '版をまたいで同じ文書を表すID';
+COMMENT ON COLUMN stored_documents.version IS '版（差し替えるたびに増える）';
+COMMENT ON COLUMN stored_documents.document_type IS '文書の種類（invoice:請求書 receipt:領収書 other:その他）';
+COMMENT ON COLUMN stored_documents.transaction_date IS '取引年月日';
+COMMENT ON COLUMN stored_documents.amount IS '取引金額（円）';
+COMMENT ON COLUMN stored_documents.counterparty IS '取引先';
+COMMENT ON COLUMN stored_documents.content_hash IS '文書本体のSHA-256';
+COMMENT ON COLUMN stored_documents.chain_seq IS 'ハッシュチェーンの連番';
+COMMENT ON COLUMN stored_documents.prev_chain_hash IS '直前の文書のチェーンハッシュ';
+COMMENT ON COLUMN stored_documents.chain_hash IS '直前のハッシュと文書の属性から計算したチェーンハッシュ';
+COMMENT ON COLUMN stored_documents.retention_until IS '法定保存期間の最終日（この日までは削除できない）';
+COMMENT ON COLUMN stored_documents.superseded_by_id IS '差し替え後の版';
+COMMENT ON COLUMN stored_documents.disposed_at IS '保存期間経過後に本体を廃棄した日時';
+
+-- ハッシュチェーンの末尾（登録時に行ロックして連番を進める）
+CREATE TABLE IF NOT EXISTS document_chain_heads (
+    id INT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_hash CHAR(64) NOT NULL,
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo')
+);
+
+INSERT INTO document_chain_heads (id, last_seq, last_hash)
+VALUES (1, 0, REPEAT('0', 64))
+ON CONFLICT (id) DO NOTHING;
+
+COMMENT ON TABLE document_chain_heads IS '保存文書のハッシュチェーンの末尾';
+
+CREATE OR REPLACE TRIGGER update_document_chain_heads_updated_at
+    BEFORE UPDATE ON document_chain_heads
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 保存文書は削除させず、登録内容（検索項目・ハッシュ）の変更も禁止する
+-- 変更できるのは差し替え・廃棄の記録のみで、廃棄は保存期間の経過後に限る
+CREATE OR REPLACE FUNCTION protect_stored_documents()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'stored_documents cannot be deleted (id=%)', OLD.id;
+    END IF;
+
+    IF NEW.id <> OLD.id
+        OR NEW.group_id <> OLD.group_id
+        OR NEW.version <> OLD.version
+        OR NEW.document_type <> OLD.document_type
+        OR NEW.transaction_date <> OLD.transaction_date
+        OR NEW.amount <> OLD.amount
+        OR NEW.counterparty <> OLD.counterparty
+        OR NEW.storage_key <> OLD.storage_key
+        OR NEW.content_hash <> OLD.content_hash
+        OR NEW.chain_seq <> OLD.chain_seq
+        OR NEW.prev_chain_hash <> OLD.prev_chain_hash
+        OR NEW.chain_hash <> OLD.chain_hash
+        OR NEW.retention_until <> OLD.retention_until THEN
+        RAISE EXCEPTION 'stored_documents are immutable (id=%)', OLD.id;
+    END IF;
+
+    IF NEW.disposed_at IS NOT NULL AND OLD.disposed_at IS NULL
+        AND NEW.disposed_at::date <= OLD.retention_until THEN
+        RAISE EXCEPTION 'stored_documents cannot be disposed within the retention period (id=%)', OLD.id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER protect_stored_documents_update
+    BEFORE UPDATE ON stored_documents
+    FOR EACH ROW
+    EXECUTE FUNCTION protect_stored_documents();
+
+CREATE OR REPLACE TRIGGER protect_stored_documents_delete
+    BEFORE DELETE ON stored_documents
+    FOR EACH ROW
+    EXECUTE FUNCTION protect_stored_documents();
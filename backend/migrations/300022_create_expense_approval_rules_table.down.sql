@@ -0,0 +1,9 @@
+-- 経費申請の承認ルート決定ルールを削除
+-- approval_type_enumに追加した値はPostgreSQLでは削除できないため残す
+DROP INDEX IF EXISTS idx_expenses_project_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS approval_rule_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS expense_approval_rule_steps;
+DROP TRIGGER IF EXISTS update_expense_approval_rules_updated_at ON expense_approval_rules;
+DROP TABLE IF EXISTS expense_approval_rules;
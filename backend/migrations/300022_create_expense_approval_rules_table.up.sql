@@ -0,0 +1,65 @@
+-- 経費申請の承認ルート決定ルール（金額・カテゴリ・部署・プロジェクトで承認者の段階を決める）
+
+-- ルールで指定できる承認種別を追加（部署長・指名した承認者）
+ALTER TYPE approval_type_enum ADD VALUE IF NOT EXISTS 'department_head';
+ALTER TYPE approval_type_enum ADD VALUE IF NOT EXISTS 'designated';
+
+CREATE TABLE IF NOT EXISTS expense_approval_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    priority INT NOT NULL DEFAULT 100,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    min_amount INT,
+    max_amount INT,
+    category_id VARCHAR(36),
+    department_id VARCHAR(36),
+    project_id VARCHAR(36),
+    auto_approve BOOLEAN NOT NULL DEFAULT false,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    deleted_at TIMESTAMP(3),
+    CONSTRAINT chk_expense_approval_rules_amount CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount < max_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_approval_rules_active ON expense_approval_rules (is_active, priority);
+CREATE INDEX IF NOT EXISTS idx_expense_approval_rules_deleted_at ON expense_approval_rules (deleted_at);
+
+CREATE OR REPLACE TRIGGER update_expense_approval_rules_updated_at
+    BEFORE UPDATE ON expense_approval_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE expense_approval_rules IS '経費申請の承認ルート決定ルール（条件に一致するルールのうち優先度の小さいものを適用）';
+COMMENT ON COLUMN expense_approval_rules.priority IS '優先度（小さいほど優先）';
+COMMENT ON COLUMN expense_approval_rules.min_amount IS '金額の下限（この金額以上、円）';
+COMMENT ON COLUMN expense_approval_rules.max_amount IS '金額の上限（この金額未満、円）';
+COMMENT ON COLUMN expense_approval_rules.category_id IS '経費カテゴリ（未指定は全て）';
+COMMENT ON COLUMN expense_approval_rules.department_id IS '申請者の部署（未指定は全て）';
+COMMENT ON COLUMN expense_approval_rules.project_id IS 'プロジェクト（未指定は全て）';
+COMMENT ON COLUMN expense_approval_rules.auto_approve IS '承認者を経ずに承認済みにする';
+
+-- 承認ルートの段階
+CREATE TABLE IF NOT EXISTS expense_approval_rule_steps (
+    id VARCHAR(36) PRIMARY KEY,
+    rule_id VARCHAR(36) NOT NULL,
+    step_order INT NOT NULL,
+    approval_type VARCHAR(20) NOT NULL,
+    approver_id VARCHAR(255),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT uk_expense_approval_rule_steps UNIQUE (rule_id, step_order),
+    CONSTRAINT fk_expense_approval_rule_steps_rule FOREIGN KEY (rule_id) REFERENCES expense_approval_rules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_expense_approval_rule_steps_type CHECK (approval_type IN ('manager', 'executive', 'department_head', 'designated'))
+);
+
+COMMENT ON TABLE expense_approval_rule_steps IS '承認ルートの段階（step_orderの順に承認）';
+COMMENT ON COLUMN expense_approval_rule_steps.approval_type IS '承認種別（manager・executive:承認者設定 department_head:申請者の部署長 designated:指名した承認者）';
+COMMENT ON COLUMN expense_approval_rule_steps.approver_id IS '指名した承認者（designatedの場合）';
+
+-- 経費申請のプロジェクトと適用した承認ルール
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS project_id VARCHAR(36);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS approval_rule_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_expenses_project_id ON expenses(project_id);
+COMMENT ON COLUMN expenses.project_id IS '経費を計上するプロジェクト（任意）';
+COMMENT ON COLUMN expenses.approval_rule_id IS '提出時に適用した承認ルール（ルール外の場合はNULL）';
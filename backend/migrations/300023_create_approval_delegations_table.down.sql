@@ -0,0 +1,7 @@
+-- 不在時の代理承認を削除
+ALTER TABLE weekly_reports DROP COLUMN IF EXISTS on_behalf_of_id;
+ALTER TABLE leave_requests DROP COLUMN IF EXISTS on_behalf_of_id;
+ALTER TABLE expense_approvals DROP COLUMN IF EXISTS on_behalf_of_id;
+
+DROP TRIGGER IF EXISTS update_approval_delegations_updated_at ON approval_delegations;
+DROP TABLE IF EXISTS approval_delegations;
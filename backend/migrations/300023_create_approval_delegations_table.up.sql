@@ -0,0 +1,47 @@
+-- 不在時の代理承認（経費申請・休暇申請・週報の承認を期間を決めて代理承認者に任せる）
+CREATE TABLE IF NOT EXISTS approval_delegations (
+    id VARCHAR(36) PRIMARY KEY,
+    delegator_id VARCHAR(255) NOT NULL,
+    delegate_id VARCHAR(255) NOT NULL,
+    scope VARCHAR(20) NOT NULL DEFAULT 'all',
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    leave_request_id VARCHAR(255),
+    reason TEXT,
+    revoked_at TIMESTAMP(3),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_approval_delegations_period CHECK (start_date <= end_date),
+    CONSTRAINT chk_approval_delegations_self CHECK (delegator_id <> delegate_id),
+    CONSTRAINT chk_approval_delegations_scope CHECK (scope IN ('all', 'expense', 'leave', 'weekly_report')),
+    CONSTRAINT chk_approval_delegations_source CHECK (source IN ('manual', 'leave_request'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_delegate ON approval_delegations (delegate_id, start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_delegator ON approval_delegations (delegator_id, start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_leave_request ON approval_delegations (leave_request_id);
+
+CREATE OR REPLACE TRIGGER update_approval_delegations_updated_at
+    BEFORE UPDATE ON approval_delegations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE approval_delegations IS '不在時の代理承認（期間中はdelegate_idの代理承認者がdelegator_idの承認者の承認を行える）';
+COMMENT ON COLUMN approval_delegations.delegator_id IS '不在になる承認者';
+COMMENT ON COLUMN approval_delegations.delegate_id IS '代理承認者';
+COMMENT ON COLUMN approval_delegations.scope IS '代理承認の対象（all:全て expense:経費申請 leave:休暇申請 weekly_report:週報）';
+COMMENT ON COLUMN approval_delegations.start_date IS '代理承認の開始日';
+COMMENT ON COLUMN approval_delegations.end_date IS '代理承認の終了日（この日を含む）';
+COMMENT ON COLUMN approval_delegations.source IS '登録元（manual:手動登録 leave_request:承認済みの休暇申請から自動登録）';
+COMMENT ON COLUMN approval_delegations.leave_request_id IS '自動登録の元になった休暇申請';
+COMMENT ON COLUMN approval_delegations.revoked_at IS '取り消し日時（取り消し済みの代理承認は無効）';
+
+-- 代理で承認した場合の本来の承認者
+ALTER TABLE expense_approvals ADD COLUMN IF NOT EXISTS on_behalf_of_id VARCHAR(255);
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS on_behalf_of_id VARCHAR(255);
+ALTER TABLE weekly_reports ADD COLUMN IF NOT EXISTS on_behalf_of_id VARCHAR(255);
+COMMENT ON COLUMN expense_approvals.on_behalf_of_id IS '代理承認の場合の本来の承認者（approver_idが代理承認者）';
+COMMENT ON COLUMN leave_requests.on_behalf_of_id IS '代理承認の場合の本来の承認者（approver_idが代理承認者）';
+COMMENT ON COLUMN weekly_reports.on_behalf_of_id IS '代理承認の場合の本来の承認者（commented_byが代理承認者）';
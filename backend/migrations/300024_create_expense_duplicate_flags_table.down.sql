@@ -0,0 +1,6 @@
+-- 領収書の重複・二重申請の検出を削除
+DROP TABLE IF EXISTS expense_duplicate_flags;
+
+DROP INDEX IF EXISTS idx_expense_receipts_content_hash;
+ALTER TABLE expense_receipts DROP COLUMN IF EXISTS perceptual_hash;
+ALTER TABLE expense_receipts DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,34 @@
+-- 領収書の重複・二重申請の検出
+
+-- 領収書ファイルのハッシュ
+ALTER TABLE expense_receipts ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE expense_receipts ADD COLUMN IF NOT EXISTS perceptual_hash VARCHAR(16);
+CREATE INDEX IF NOT EXISTS idx_expense_receipts_content_hash ON expense_receipts (content_hash);
+COMMENT ON COLUMN expense_receipts.content_hash IS 'ファイル内容のSHA-256（取得できなかった場合は空文字）';
+COMMENT ON COLUMN expense_receipts.perceptual_hash IS '画像の知覚ハッシュ（差分ハッシュ、画像以外はNULL）';
+
+-- 経費申請の重複の可能性
+CREATE TABLE IF NOT EXISTS expense_duplicate_flags (
+    id VARCHAR(36) PRIMARY KEY,
+    expense_id VARCHAR(255) NOT NULL,
+    duplicate_expense_id VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    receipt_id VARCHAR(255),
+    duplicate_receipt_id VARCHAR(255),
+    detected_at TIMESTAMP(3) NOT NULL,
+    override_reason TEXT,
+    overridden_by VARCHAR(255),
+    overridden_at TIMESTAMP(3),
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT fk_expense_duplicate_flags_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT chk_expense_duplicate_flags_reason CHECK (reason IN ('same_receipt', 'similar_receipt', 'similar_expense'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_duplicate_flags_expense_id ON expense_duplicate_flags (expense_id);
+CREATE INDEX IF NOT EXISTS idx_expense_duplicate_flags_duplicate_expense_id ON expense_duplicate_flags (duplicate_expense_id);
+
+COMMENT ON TABLE expense_duplicate_flags IS '経費申請の重複の可能性（提出時に検出し、承認には理由の入力が必要）';
+COMMENT ON COLUMN expense_duplicate_flags.duplicate_expense_id IS '重複の可能性がある既存の経費申請';
+COMMENT ON COLUMN expense_duplicate_flags.reason IS '理由（same_receipt:同じ領収書ファイル similar_receipt:類似した領収書画像 similar_expense:同じ申請者・金額・使用日・カテゴリ）';
+COMMENT ON COLUMN expense_duplicate_flags.override_reason IS '承認者が重複ではないと判断した理由';
+COMMENT ON COLUMN expense_duplicate_flags.overridden_by IS '理由を入力して承認した承認者';
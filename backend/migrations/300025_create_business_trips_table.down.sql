@@ -0,0 +1,9 @@
+-- 出張申請を削除
+DROP INDEX IF EXISTS idx_expenses_business_trip_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS business_trip_id;
+
+DROP TRIGGER IF EXISTS update_business_trip_per_diem_rates_updated_at ON business_trip_per_diem_rates;
+DROP TABLE IF EXISTS business_trip_per_diem_rates;
+
+DROP TRIGGER IF EXISTS update_business_trips_updated_at ON business_trips;
+DROP TABLE IF EXISTS business_trips;
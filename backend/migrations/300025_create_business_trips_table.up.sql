@@ -0,0 +1,78 @@
+-- 出張申請（事前承認と出張後の精算）
+CREATE TABLE IF NOT EXISTS business_trips (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    project_id VARCHAR(255),
+    purpose TEXT NOT NULL,
+    destination VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    estimated_cost INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    approver_id VARCHAR(255),
+    on_behalf_of_id VARCHAR(255),
+    approved_at TIMESTAMP(3),
+    approval_comment TEXT,
+    allowance_days INTEGER NOT NULL DEFAULT 0,
+    allowance_daily_rate INTEGER NOT NULL DEFAULT 0,
+    allowance_amount INTEGER NOT NULL DEFAULT 0,
+    expense_total INTEGER NOT NULL DEFAULT 0,
+    settlement_submitted_at TIMESTAMP(3),
+    settled_by VARCHAR(255),
+    settled_at TIMESTAMP(3),
+    settlement_comment TEXT,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_business_trips_period CHECK (start_date <= end_date),
+    CONSTRAINT chk_business_trips_estimated_cost CHECK (estimated_cost >= 0),
+    CONSTRAINT chk_business_trips_status CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled', 'settlement_pending', 'settled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_business_trips_user ON business_trips (user_id, start_date);
+CREATE INDEX IF NOT EXISTS idx_business_trips_status ON business_trips (status);
+CREATE INDEX IF NOT EXISTS idx_business_trips_project ON business_trips (project_id);
+
+CREATE OR REPLACE TRIGGER update_business_trips_updated_at
+    BEFORE UPDATE ON business_trips
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE business_trips IS '出張申請（出張前に事前承認を受け、出張後に関連する経費申請と日当で精算する）';
+COMMENT ON COLUMN business_trips.project_id IS '出張先の顧客案件';
+COMMENT ON COLUMN business_trips.estimated_cost IS '概算費用（交通費・宿泊費・日当の合計、円）';
+COMMENT ON COLUMN business_trips.status IS 'ステータス（pending:事前承認待ち approved:事前承認済み rejected:却下 cancelled:取消 settlement_pending:精算の承認待ち settled:精算済み）';
+COMMENT ON COLUMN business_trips.on_behalf_of_id IS '代理承認の場合の本来の承認者（approver_idが代理承認者）';
+COMMENT ON COLUMN business_trips.allowance_days IS '日当の日数（出張の開始日と終了日を含む）';
+COMMENT ON COLUMN business_trips.allowance_daily_rate IS '精算時に適用した日当の単価（円）';
+COMMENT ON COLUMN business_trips.allowance_amount IS '日当の合計（円）';
+COMMENT ON COLUMN business_trips.expense_total IS '精算した経費申請の合計（円）';
+
+-- 出張の日当の単価表
+CREATE TABLE IF NOT EXISTS business_trip_per_diem_rates (
+    id VARCHAR(36) PRIMARY KEY,
+    trip_type VARCHAR(20) NOT NULL,
+    daily_amount INTEGER NOT NULL,
+    effective_from DATE NOT NULL,
+    description VARCHAR(255),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_business_trip_per_diem_rates_trip_type CHECK (trip_type IN ('day_trip', 'overnight')),
+    CONSTRAINT chk_business_trip_per_diem_rates_amount CHECK (daily_amount >= 0),
+    CONSTRAINT uq_business_trip_per_diem_rates UNIQUE (trip_type, effective_from)
+);
+
+CREATE OR REPLACE TRIGGER update_business_trip_per_diem_rates_updated_at
+    BEFORE UPDATE ON business_trip_per_diem_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE business_trip_per_diem_rates IS '出張の日当の単価表（出張の開始日時点で有効な単価を使う）';
+COMMENT ON COLUMN business_trip_per_diem_rates.trip_type IS '出張の区分（day_trip:日帰り overnight:宿泊）';
+COMMENT ON COLUMN business_trip_per_diem_rates.daily_amount IS '1日あたりの日当（円）';
+COMMENT ON COLUMN business_trip_per_diem_rates.effective_from IS '適用開始日';
+
+-- 出張精算で精算した経費申請
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS business_trip_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_expenses_business_trip_id ON expenses (business_trip_id);
+COMMENT ON COLUMN expenses.business_trip_id IS '出張精算で精算した出張申請';
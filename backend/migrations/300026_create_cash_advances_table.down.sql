@@ -0,0 +1,14 @@
+-- 仮払金を削除
+DELETE FROM expense_approvals WHERE cash_advance_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_expense_approvals_cash_advance_id;
+ALTER TABLE expense_approvals DROP CONSTRAINT IF EXISTS chk_expense_approvals_target;
+ALTER TABLE expense_approvals DROP CONSTRAINT IF EXISTS uk_cash_advance_approval;
+ALTER TABLE expense_approvals DROP CONSTRAINT IF EXISTS fk_expense_approvals_cash_advance;
+ALTER TABLE expense_approvals DROP COLUMN IF EXISTS cash_advance_id;
+ALTER TABLE expense_approvals ALTER COLUMN expense_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_expenses_cash_advance_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS cash_advance_id;
+
+DROP TRIGGER IF EXISTS update_cash_advances_updated_at ON cash_advances;
+DROP TABLE IF EXISTS cash_advances;
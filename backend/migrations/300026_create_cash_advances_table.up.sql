@@ -0,0 +1,65 @@
+-- 仮払金（事前に支払い、実際の経費申請で精算する）
+CREATE TABLE IF NOT EXISTS cash_advances (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    purpose TEXT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    settlement_due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    approval_rule_id VARCHAR(36),
+    approver_id VARCHAR(255),
+    on_behalf_of_id VARCHAR(255),
+    approved_at TIMESTAMP(3),
+    approval_comment TEXT,
+    paid_at DATE,
+    paid_by VARCHAR(255),
+    expense_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    settlement_submitted_at TIMESTAMP(3),
+    settled_by VARCHAR(255),
+    settled_at TIMESTAMP(3),
+    settlement_comment TEXT,
+    closed_at DATE,
+    closed_by VARCHAR(255),
+    last_reminded_at TIMESTAMP(3),
+    reminder_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Tokyo'),
+    CONSTRAINT chk_cash_advances_amount CHECK (amount > 0),
+    CONSTRAINT chk_cash_advances_status CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled', 'paid', 'settlement_pending', 'settled', 'closed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_advances_user ON cash_advances (user_id, status);
+CREATE INDEX IF NOT EXISTS idx_cash_advances_status_due ON cash_advances (status, settlement_due_date);
+
+CREATE OR REPLACE TRIGGER update_cash_advances_updated_at
+    BEFORE UPDATE ON cash_advances
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE cash_advances IS '仮払金（承認後に支払い、実際の経費申請を紐付けて精算する。差額は返金または追加支払）';
+COMMENT ON COLUMN cash_advances.amount IS '仮払金額';
+COMMENT ON COLUMN cash_advances.settlement_due_date IS '精算期限（過ぎても精算を申請していない場合は督促する）';
+COMMENT ON COLUMN cash_advances.status IS 'ステータス（pending:承認待ち approved:支払待ち rejected:却下 cancelled:取消 paid:精算待ち settlement_pending:精算の承認待ち settled:差額の返金・追加支払待ち closed:精算完了）';
+COMMENT ON COLUMN cash_advances.approval_rule_id IS '申請時に適用した承認ルール（ルール外の場合はNULL）';
+COMMENT ON COLUMN cash_advances.approver_id IS '承認フローの最終承認者';
+COMMENT ON COLUMN cash_advances.on_behalf_of_id IS '代理承認の場合の本来の承認者（approver_idが代理承認者）';
+COMMENT ON COLUMN cash_advances.paid_at IS '仮払金を支払った日';
+COMMENT ON COLUMN cash_advances.expense_total IS '精算に紐付けた経費申請の合計';
+COMMENT ON COLUMN cash_advances.closed_at IS '差額の返金・追加支払が完了した日（差額がない場合は精算の承認日）';
+COMMENT ON COLUMN cash_advances.last_reminded_at IS '精算期限超過の督促を最後に送った日時';
+
+-- 仮払金の精算に紐付けた経費申請
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS cash_advance_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_expenses_cash_advance_id ON expenses (cash_advance_id);
+COMMENT ON COLUMN expenses.cash_advance_id IS '精算に紐付けた仮払金（振込バッチの対象外）';
+
+-- 仮払金の承認フロー（経費申請と同じ承認ルール・承認者設定で承認する）
+ALTER TABLE expense_approvals ALTER COLUMN expense_id DROP NOT NULL;
+ALTER TABLE expense_approvals ADD COLUMN IF NOT EXISTS cash_advance_id VARCHAR(36);
+ALTER TABLE expense_approvals ADD CONSTRAINT fk_expense_approvals_cash_advance
+    FOREIGN KEY (cash_advance_id) REFERENCES cash_advances(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE expense_approvals ADD CONSTRAINT uk_cash_advance_approval UNIQUE (cash_advance_id, approval_type, approval_order);
+ALTER TABLE expense_approvals ADD CONSTRAINT chk_expense_approvals_target
+    CHECK ((expense_id IS NULL) <> (cash_advance_id IS NULL));
+CREATE INDEX IF NOT EXISTS idx_expense_approvals_cash_advance_id ON expense_approvals (cash_advance_id);
+COMMENT ON COLUMN expense_approvals.cash_advance_id IS '仮払金の承認の場合の仮払金（expense_idとどちらか一方を設定）';
@@ -0,0 +1,3 @@
+-- 日次勤怠の案件を削除
+DROP INDEX IF EXISTS idx_daily_records_project_id;
+ALTER TABLE daily_records DROP COLUMN IF EXISTS project_id;
@@ -0,0 +1,6 @@
+-- 複数の案件に並行してアサインされている場合に、日次勤怠を案件ごとに請求の精算へ振り分ける
+ALTER TABLE daily_records ADD COLUMN IF NOT EXISTS project_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_daily_records_project_id ON daily_records (project_id);
+
+COMMENT ON COLUMN daily_records.project_id IS '稼働した案件（複数の案件に並行してアサインされている場合に指定、NULLはアサイン中の案件）';
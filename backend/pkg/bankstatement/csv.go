@@ -0,0 +1,255 @@
+package bankstatement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// defaultCSVDateFormats 日付形式が未指定の場合に試す形式
+var defaultCSVDateFormats = []string{
+	"2006/01/02",
+	"2006/1/2",
+	"2006-01-02",
+	"2006-1-2",
+	"20060102",
+	"2006.01.02",
+	"2006年1月2日",
+}
+
+// CSVMapping 汎用CSVの列対応
+// 列はヘッダー名または1始まりの列番号で指定する
+type CSVMapping struct {
+	Encoding          Encoding `json:"encoding"`
+	HasHeader         bool     `json:"has_header"`
+	SkipRows          int      `json:"skip_rows"` // ヘッダーより前に読み飛ばす行数
+	DateColumn        string   `json:"date_column"`
+	DateFormat        string   `json:"date_format"` // Goの日付レイアウト（未指定の場合は一般的な形式を順に試す）
+	DepositColumn     string   `json:"deposit_column"`
+	WithdrawalColumn  string   `json:"withdrawal_column"` // 入金額と出金額が別列の場合に指定
+	PayerColumn       string   `json:"payer_column"`
+	DescriptionColumn string   `json:"description_column"`
+	ReferenceColumn   string   `json:"reference_column"`
+}
+
+// Validate 列対応の必須項目をチェック
+func (m *CSVMapping) Validate() error {
+	if m.DateColumn == "" {
+		return errors.New("日付の列が指定されていません")
+	}
+	if m.DepositColumn == "" {
+		return errors.New("入金額の列が指定されていません")
+	}
+	if m.PayerColumn == "" {
+		return errors.New("振込依頼人名の列が指定されていません")
+	}
+	if m.Encoding != "" && m.Encoding != EncodingUTF8 && m.Encoding != EncodingShiftJIS {
+		return fmt.Errorf("文字コード %q には対応していません", m.Encoding)
+	}
+	return nil
+}
+
+// csvColumns 列対応を列番号に解決したもの（未指定は-1）
+type csvColumns struct {
+	date, deposit, withdrawal, payer, description, reference int
+}
+
+// ParseCSV 列対応に従って汎用CSVの入出金明細を解析
+// 入金額の列が負の値の場合は出金として扱う
+func ParseCSV(r io.Reader, mapping CSVMapping) (*Statement, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(decodingReader(r, mapping.Encoding))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSVの読み込みに失敗しました: %w", err)
+	}
+	if mapping.SkipRows > 0 {
+		if mapping.SkipRows >= len(rows) {
+			return nil, ErrEmptyStatement
+		}
+		rows = rows[mapping.SkipRows:]
+	}
+
+	var header []string
+	offset := mapping.SkipRows
+	if mapping.HasHeader {
+		if len(rows) == 0 {
+			return nil, ErrEmptyStatement
+		}
+		header = rows[0]
+		rows = rows[1:]
+		offset++
+	}
+
+	cols, err := resolveCSVColumns(mapping, header)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &Statement{Format: FormatCSV}
+	for i, row := range rows {
+		line := offset + i + 1
+		if isBlankRow(row) {
+			continue
+		}
+
+		tx, err := parseCSVRow(row, cols, mapping.DateFormat)
+		if err != nil {
+			return nil, fmt.Errorf("%d行目: %w", line, err)
+		}
+		if tx == nil {
+			continue
+		}
+		tx.Line = line
+		stmt.Transactions = append(stmt.Transactions, *tx)
+	}
+
+	if len(stmt.Transactions) == 0 {
+		return nil, ErrEmptyStatement
+	}
+	return stmt, nil
+}
+
+// resolveCSVColumns 列対応を列番号に解決
+func resolveCSVColumns(mapping CSVMapping, header []string) (csvColumns, error) {
+	resolve := func(spec string) (int, error) {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			return -1, nil
+		}
+		for i, name := range header {
+			if strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) == spec {
+				return i, nil
+			}
+		}
+		if n, err := strconv.Atoi(spec); err == nil && n > 0 {
+			return n - 1, nil
+		}
+		return -1, fmt.Errorf("列 %q が見つかりません", spec)
+	}
+
+	var cols csvColumns
+	var err error
+	if cols.date, err = resolve(mapping.DateColumn); err != nil {
+		return cols, err
+	}
+	if cols.deposit, err = resolve(mapping.DepositColumn); err != nil {
+		return cols, err
+	}
+	if cols.payer, err = resolve(mapping.PayerColumn); err != nil {
+		return cols, err
+	}
+	if cols.withdrawal, err = resolve(mapping.WithdrawalColumn); err != nil {
+		return cols, err
+	}
+	if cols.description, err = resolve(mapping.DescriptionColumn); err != nil {
+		return cols, err
+	}
+	if cols.reference, err = resolve(mapping.ReferenceColumn); err != nil {
+		return cols, err
+	}
+	return cols, nil
+}
+
+// parseCSVRow CSVの1行を取引に変換（金額がない行はnil）
+func parseCSVRow(row []string, cols csvColumns, dateFormat string) (*Transaction, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	deposit, err := parseCSVAmount(field(cols.deposit))
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := parseCSVAmount(field(cols.withdrawal))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		PayerName:   field(cols.payer),
+		Description: field(cols.description),
+		Reference:   field(cols.reference),
+	}
+	switch {
+	case deposit > 0:
+		tx.Direction = DirectionDeposit
+		tx.Amount = deposit
+	case deposit < 0:
+		tx.Direction = DirectionWithdrawal
+		tx.Amount = -deposit
+	case withdrawal != 0:
+		tx.Direction = DirectionWithdrawal
+		tx.Amount = max(withdrawal, -withdrawal)
+	default:
+		return nil, nil
+	}
+
+	date, err := parseCSVDate(field(cols.date), dateFormat)
+	if err != nil {
+		return nil, err
+	}
+	tx.Date = date
+	return tx, nil
+}
+
+// parseCSVAmount 金額文字列を円単位の整数に変換（空欄は0）
+func parseCSVAmount(s string) (int64, error) {
+	s = norm.NFKC.String(s)
+	s = strings.NewReplacer(",", "", "円", "", "¥", "", "\\", "", " ", "", "+", "").Replace(s)
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+	// 小数点以下（.00）が付く形式に対応
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		if strings.Trim(s[i+1:], "0") != "" {
+			return 0, fmt.Errorf("金額 %q に円未満の端数があります", s)
+		}
+		s = s[:i]
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("金額 %q を解析できません", s)
+	}
+	return n, nil
+}
+
+// parseCSVDate 日付文字列を解析
+func parseCSVDate(s, layout string) (time.Time, error) {
+	s = norm.NFKC.String(s)
+	layouts := defaultCSVDateFormats
+	if layout != "" {
+		layouts = []string{layout}
+	}
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("日付 %q を解析できません", s)
+}
+
+// isBlankRow 空行かチェック
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
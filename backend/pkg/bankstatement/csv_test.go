@@ -0,0 +1,104 @@
+package bankstatement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := strings.Join([]string{
+		"口座明細,普通 1234567",
+		"日付,お引出し,お預入れ,お取引内容,残高",
+		`2025/04/10,,"109,340",ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ,"1,109,340"`,
+		`2025/04/11,"50,000",,ｼﾞﾝｼﾞｬｸﾗﾌﾞ,"1,059,340"`,
+		"",
+		"2025/04/12,,０,利息,1059340",
+	}, "\n")
+
+	stmt, err := ParseCSV(strings.NewReader(data), CSVMapping{
+		HasHeader:        true,
+		SkipRows:         1,
+		DateColumn:       "日付",
+		DepositColumn:    "お預入れ",
+		WithdrawalColumn: "お引出し",
+		PayerColumn:      "お取引内容",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatCSV, stmt.Format)
+	require.Len(t, stmt.Transactions, 2)
+
+	dep := stmt.Transactions[0]
+	assert.Equal(t, DirectionDeposit, dep.Direction)
+	assert.Equal(t, int64(109340), dep.Amount)
+	assert.Equal(t, "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ", dep.PayerName)
+	assert.Equal(t, time.Date(2025, 4, 10, 0, 0, 0, 0, time.Local), dep.Date)
+	assert.Equal(t, 3, dep.Line)
+
+	wd := stmt.Transactions[1]
+	assert.Equal(t, DirectionWithdrawal, wd.Direction)
+	assert.Equal(t, int64(50000), wd.Amount)
+}
+
+func TestParseCSV_ColumnIndexAndShiftJIS(t *testing.T) {
+	raw := "20250410,ヤマダショウジ（カ,１１０，０００円\r\n20250415,ジンジャクラブ,-3000\r\n"
+	encoded, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(raw))
+	require.NoError(t, err)
+
+	stmt, err := ParseCSV(bytes.NewReader(encoded), CSVMapping{
+		Encoding:      EncodingShiftJIS,
+		DateColumn:    "1",
+		DateFormat:    "20060102",
+		PayerColumn:   "2",
+		DepositColumn: "3",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, stmt.Transactions, 2)
+	assert.Equal(t, "ヤマダショウジ（カ", stmt.Transactions[0].PayerName)
+	assert.Equal(t, int64(110000), stmt.Transactions[0].Amount)
+	assert.Equal(t, DirectionWithdrawal, stmt.Transactions[1].Direction)
+	assert.Equal(t, int64(3000), stmt.Transactions[1].Amount)
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping CSVMapping
+	}{
+		{
+			name:    "必須列の指定なし",
+			data:    "2025/04/10,100,ﾔﾏﾀﾞ",
+			mapping: CSVMapping{DateColumn: "1", PayerColumn: "3"},
+		},
+		{
+			name:    "存在しないヘッダー",
+			data:    "日付,金額,名義\n2025/04/10,100,ﾔﾏﾀﾞ",
+			mapping: CSVMapping{HasHeader: true, DateColumn: "日付", DepositColumn: "入金額", PayerColumn: "名義"},
+		},
+		{
+			name:    "日付形式の誤り",
+			data:    "04-10-2025,100,ﾔﾏﾀﾞ",
+			mapping: CSVMapping{DateColumn: "1", DepositColumn: "2", PayerColumn: "3"},
+		},
+		{
+			name:    "金額の誤り",
+			data:    "2025/04/10,abc,ﾔﾏﾀﾞ",
+			mapping: CSVMapping{DateColumn: "1", DepositColumn: "2", PayerColumn: "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.data), tt.mapping)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package bankstatement
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Direction 入出金区分
+type Direction string
+
+const (
+	// DirectionDeposit 入金
+	DirectionDeposit Direction = "deposit"
+	// DirectionWithdrawal 出金
+	DirectionWithdrawal Direction = "withdrawal"
+)
+
+// Format 明細ファイル形式
+type Format string
+
+const (
+	// FormatZengin 全銀協フォーマット（入出金取引明細）
+	FormatZengin Format = "zengin"
+	// FormatCSV 汎用CSV
+	FormatCSV Format = "csv"
+)
+
+// Encoding ファイルの文字コード
+type Encoding string
+
+const (
+	// EncodingUTF8 UTF-8
+	EncodingUTF8 Encoding = "utf-8"
+	// EncodingShiftJIS Shift_JIS
+	EncodingShiftJIS Encoding = "shift_jis"
+)
+
+var (
+	// ErrEmptyStatement 明細が空
+	ErrEmptyStatement = errors.New("明細データがありません")
+	// ErrInvalidRecord レコード形式が不正
+	ErrInvalidRecord = errors.New("明細レコードの形式が正しくありません")
+)
+
+// Statement 入出金明細
+type Statement struct {
+	Format        Format
+	BankCode      string
+	BankName      string
+	BranchCode    string
+	BranchName    string
+	AccountType   string
+	AccountNumber string
+	AccountName   string
+	PeriodFrom    *time.Time
+	PeriodTo      *time.Time
+	Transactions  []Transaction
+}
+
+// Transaction 入出金明細の1取引
+type Transaction struct {
+	Line        int // ファイル内のレコード位置（1始まり）
+	Date        time.Time
+	Direction   Direction
+	Amount      int64 // 円単位（常に正の値）
+	PayerName   string
+	PayerCode   string
+	Description string
+	Reference   string // 照会番号など銀行側の取引識別子
+	RemitBank   string
+	RemitBranch string
+}
+
+// IsDeposit 入金取引かチェック
+func (t *Transaction) IsDeposit() bool {
+	return t.Direction == DirectionDeposit
+}
+
+// Deposits 入金取引のみを取得
+func (s *Statement) Deposits() []Transaction {
+	deposits := make([]Transaction, 0, len(s.Transactions))
+	for _, tx := range s.Transactions {
+		if tx.IsDeposit() {
+			deposits = append(deposits, tx)
+		}
+	}
+	return deposits
+}
+
+// decodingReader 文字コードに応じたリーダーを返す
+func decodingReader(r io.Reader, encoding Encoding) io.Reader {
+	if encoding == EncodingShiftJIS {
+		return transform.NewReader(r, japanese.ShiftJIS.NewDecoder())
+	}
+	return r
+}
+
+// decodeShiftJIS Shift_JISのバイト列を文字列に変換
+func decodeShiftJIS(b []byte) string {
+	s, _, err := transform.Bytes(japanese.ShiftJIS.NewDecoder(), b)
+	if err != nil {
+		return strings.TrimSpace(string(b))
+	}
+	return strings.TrimSpace(string(s))
+}
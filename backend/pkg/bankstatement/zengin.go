@@ -0,0 +1,216 @@
+package bankstatement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// zenginRecordLength 全銀協フォーマットの1レコードのバイト長
+const zenginRecordLength = 200
+
+// 全銀協フォーマットのデータ区分
+const (
+	zenginRecordHeader  = '1'
+	zenginRecordData    = '2'
+	zenginRecordTrailer = '8'
+	zenginRecordEnd     = '9'
+)
+
+// 和暦の元年（西暦 = 元年の前年 + 和暦年）
+const (
+	reiwaBaseYear  = 2018
+	heiseiBaseYear = 1988
+)
+
+// zenginField 固定長レコード内の項目位置（バイト単位）
+type zenginField struct {
+	start, length int
+}
+
+// 入出金取引明細 ヘッダーレコードの項目
+var (
+	zenginHeaderPeriodFrom    = zenginField{10, 6}
+	zenginHeaderPeriodTo      = zenginField{16, 6}
+	zenginHeaderBankCode      = zenginField{22, 4}
+	zenginHeaderBankName      = zenginField{26, 15}
+	zenginHeaderBranchCode    = zenginField{41, 3}
+	zenginHeaderBranchName    = zenginField{44, 15}
+	zenginHeaderAccountType   = zenginField{62, 1}
+	zenginHeaderAccountNumber = zenginField{63, 10}
+	zenginHeaderAccountName   = zenginField{73, 40}
+)
+
+// 入出金取引明細 データレコードの項目
+var (
+	zenginDataReference   = zenginField{1, 8}
+	zenginDataDate        = zenginField{9, 6}
+	zenginDataDirection   = zenginField{21, 1}
+	zenginDataAmount      = zenginField{24, 12}
+	zenginDataPayerCode   = zenginField{71, 10}
+	zenginDataPayerName   = zenginField{81, 48}
+	zenginDataRemitBank   = zenginField{129, 15}
+	zenginDataRemitBranch = zenginField{144, 15}
+	zenginDataDescription = zenginField{159, 20}
+)
+
+// ParseZengin 全銀協フォーマットの入出金取引明細（Shift_JIS、200バイト固定長）を解析
+// 改行区切りのファイルと改行なしの連続したファイルの両方に対応する
+func ParseZengin(r io.Reader) (*Statement, error) {
+	return parseZengin(r, time.Now())
+}
+
+// parseZengin 基準日を指定して全銀協フォーマットを解析
+func parseZengin(r io.Reader, now time.Time) (*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("明細ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	records := splitZenginRecords(data)
+	if len(records) == 0 {
+		return nil, ErrEmptyStatement
+	}
+
+	stmt := &Statement{Format: FormatZengin}
+	for i, rec := range records {
+		line := i + 1
+		switch rec[0] {
+		case zenginRecordHeader:
+			parseZenginHeader(stmt, rec, now)
+		case zenginRecordData:
+			tx, err := parseZenginData(rec, now)
+			if err != nil {
+				return nil, fmt.Errorf("%d件目のレコード: %w", line, err)
+			}
+			tx.Line = line
+			stmt.Transactions = append(stmt.Transactions, *tx)
+		case zenginRecordTrailer, zenginRecordEnd:
+			// 件数・合計の検証は行わない
+		default:
+			return nil, fmt.Errorf("%d件目のレコード: データ区分 %q: %w", line, rec[0], ErrInvalidRecord)
+		}
+	}
+	return stmt, nil
+}
+
+// splitZenginRecords バイト列を200バイトのレコードに分割
+func splitZenginRecords(data []byte) [][]byte {
+	var chunks [][]byte
+	if bytes.ContainsAny(data, "\r\n") {
+		for _, line := range bytes.FieldsFunc(data, func(r rune) bool { return r == '\r' || r == '\n' }) {
+			chunks = append(chunks, line)
+		}
+	} else {
+		for len(data) > 0 {
+			n := min(zenginRecordLength, len(data))
+			chunks = append(chunks, data[:n])
+			data = data[n:]
+		}
+	}
+
+	records := make([][]byte, 0, len(chunks))
+	for _, chunk := range chunks {
+		// EOF文字（0x1A）や空白だけの行は無視する
+		if len(bytes.Trim(chunk, " \x1a")) == 0 {
+			continue
+		}
+		// 末尾の空白が削られたレコードは空白で補う
+		if len(chunk) < zenginRecordLength {
+			padded := bytes.Repeat([]byte{' '}, zenginRecordLength)
+			copy(padded, chunk)
+			chunk = padded
+		}
+		records = append(records, chunk)
+	}
+	return records
+}
+
+// parseZenginHeader ヘッダーレコードの口座情報を設定
+func parseZenginHeader(stmt *Statement, rec []byte, now time.Time) {
+	stmt.BankCode = zenginText(rec, zenginHeaderBankCode)
+	stmt.BankName = zenginText(rec, zenginHeaderBankName)
+	stmt.BranchCode = zenginText(rec, zenginHeaderBranchCode)
+	stmt.BranchName = zenginText(rec, zenginHeaderBranchName)
+	stmt.AccountType = zenginText(rec, zenginHeaderAccountType)
+	stmt.AccountNumber = zenginText(rec, zenginHeaderAccountNumber)
+	stmt.AccountName = zenginText(rec, zenginHeaderAccountName)
+	if from, err := parseWarekiDate(zenginText(rec, zenginHeaderPeriodFrom), now); err == nil {
+		stmt.PeriodFrom = &from
+	}
+	if to, err := parseWarekiDate(zenginText(rec, zenginHeaderPeriodTo), now); err == nil {
+		stmt.PeriodTo = &to
+	}
+}
+
+// parseZenginData データレコードを取引に変換
+func parseZenginData(rec []byte, now time.Time) (*Transaction, error) {
+	date, err := parseWarekiDate(zenginText(rec, zenginDataDate), now)
+	if err != nil {
+		return nil, err
+	}
+
+	amountText := zenginText(rec, zenginDataAmount)
+	amount, err := strconv.ParseInt(amountText, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("取引金額 %q: %w", amountText, ErrInvalidRecord)
+	}
+
+	// 入払区分 1:入金 2:出金
+	direction := DirectionDeposit
+	switch zenginText(rec, zenginDataDirection) {
+	case "1":
+	case "2":
+		direction = DirectionWithdrawal
+	default:
+		return nil, fmt.Errorf("入払区分 %q: %w", zenginText(rec, zenginDataDirection), ErrInvalidRecord)
+	}
+
+	return &Transaction{
+		Date:        date,
+		Direction:   direction,
+		Amount:      amount,
+		PayerName:   zenginText(rec, zenginDataPayerName),
+		PayerCode:   zenginText(rec, zenginDataPayerCode),
+		Description: zenginText(rec, zenginDataDescription),
+		Reference:   zenginText(rec, zenginDataReference),
+		RemitBank:   zenginText(rec, zenginDataRemitBank),
+		RemitBranch: zenginText(rec, zenginDataRemitBranch),
+	}, nil
+}
+
+// zenginText 固定長レコードから項目を取り出して文字列に変換
+func zenginText(rec []byte, f zenginField) string {
+	if f.start+f.length > len(rec) {
+		return ""
+	}
+	return decodeShiftJIS(rec[f.start : f.start+f.length])
+}
+
+// parseWarekiDate 和暦のYYMMDDを日付に変換
+// 令和の年として翌年より先になる場合は平成とみなす
+func parseWarekiDate(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 6 {
+		return time.Time{}, fmt.Errorf("日付 %q: %w", s, ErrInvalidRecord)
+	}
+	yy, errY := strconv.Atoi(s[0:2])
+	mm, errM := strconv.Atoi(s[2:4])
+	dd, errD := strconv.Atoi(s[4:6])
+	if errY != nil || errM != nil || errD != nil || mm < 1 || mm > 12 || dd < 1 || dd > 31 {
+		return time.Time{}, fmt.Errorf("日付 %q: %w", s, ErrInvalidRecord)
+	}
+
+	year := reiwaBaseYear + yy
+	if year > now.Year()+1 {
+		year = heiseiBaseYear + yy
+	}
+	date := time.Date(year, time.Month(mm), dd, 0, 0, 0, 0, now.Location())
+	if date.Day() != dd {
+		return time.Time{}, fmt.Errorf("日付 %q: %w", s, ErrInvalidRecord)
+	}
+	return date, nil
+}
@@ -0,0 +1,138 @@
+package bankstatement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// zenginRecord 項目を左詰め・空白埋めで並べてShift_JISの200バイトレコードを作る
+func zenginRecord(t *testing.T, fields ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for i := 0; i < len(fields); i += 2 {
+		width := len(fields[i])
+		value, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(fields[i+1]))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(value), width)
+		buf.Write(value)
+		buf.WriteString(strings.Repeat(" ", width-len(value)))
+	}
+	rec := buf.Bytes()
+	require.LessOrEqual(t, len(rec), zenginRecordLength)
+	return append(rec, bytes.Repeat([]byte{' '}, zenginRecordLength-len(rec))...)
+}
+
+// w 指定桁数の幅を表すプレースホルダ
+func w(n int) string {
+	return strings.Repeat("_", n)
+}
+
+func zenginHeader(t *testing.T) []byte {
+	return zenginRecord(t,
+		w(1), "1", w(2), "03", w(1), "0", w(6), "070501",
+		w(6), "070401", w(6), "070430",
+		w(4), "0001", w(15), "ﾐｽﾞﾎ", w(3), "100", w(15), "ﾎﾝﾃﾝ",
+		w(3), "", w(1), "1", w(10), "1234567", w(40), "ｶ)ﾃﾞｭｴｽｸ",
+	)
+}
+
+func zenginData(t *testing.T, ref, date, direction, amount, payer string) []byte {
+	return zenginRecord(t,
+		w(1), "2", w(8), ref, w(6), date, w(6), date, w(1), direction, w(2), "11",
+		w(12), amount, w(12), "000000000000", w(6), "", w(6), "", w(1), "", w(7), "", w(3), "",
+		w(10), "", w(48), payer, w(15), "ﾐﾂｲｽﾐﾄﾓ", w(15), "ｼﾌﾞﾔ", w(20), "ﾌﾘｺﾐ",
+	)
+}
+
+func TestParseZengin(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	records := [][]byte{
+		zenginHeader(t),
+		zenginData(t, "00000001", "070410", "1", "000000109340", "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"),
+		zenginData(t, "00000002", "070415", "2", "000000050000", "ｼﾞﾝｼﾞｬｸﾗﾌﾞ"),
+		zenginRecord(t, w(1), "8"),
+		zenginRecord(t, w(1), "9"),
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "改行区切り", data: bytes.Join(records, []byte("\r\n"))},
+		{name: "改行なし", data: bytes.Join(records, nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := parseZengin(bytes.NewReader(tt.data), now)
+			require.NoError(t, err)
+
+			assert.Equal(t, FormatZengin, stmt.Format)
+			assert.Equal(t, "0001", stmt.BankCode)
+			assert.Equal(t, "ﾐｽﾞﾎ", stmt.BankName)
+			assert.Equal(t, "1234567", stmt.AccountNumber)
+			require.NotNil(t, stmt.PeriodFrom)
+			assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), *stmt.PeriodFrom)
+
+			require.Len(t, stmt.Transactions, 2)
+			dep := stmt.Transactions[0]
+			assert.Equal(t, DirectionDeposit, dep.Direction)
+			assert.Equal(t, int64(109340), dep.Amount)
+			assert.Equal(t, "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ", dep.PayerName)
+			assert.Equal(t, "00000001", dep.Reference)
+			assert.Equal(t, time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), dep.Date)
+			assert.Equal(t, 2, dep.Line)
+
+			assert.Equal(t, DirectionWithdrawal, stmt.Transactions[1].Direction)
+			assert.Len(t, stmt.Deposits(), 1)
+		})
+	}
+}
+
+func TestParseZengin_InvalidRecord(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := parseZengin(bytes.NewReader(zenginRecord(t, w(1), "X")), now)
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+
+	_, err = parseZengin(bytes.NewReader(zenginData(t, "00000001", "070410", "1", "ABC", "ﾔﾏﾀﾞ")), now)
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+
+	_, err = parseZengin(bytes.NewReader(nil), now)
+	assert.ErrorIs(t, err, ErrEmptyStatement)
+}
+
+func TestParseWarekiDate(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "令和", input: "070410", expected: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)},
+		{name: "令和の翌年", input: "080105", expected: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "平成", input: "310430", expected: time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "存在しない日付", input: "070231", wantErr: true},
+		{name: "桁数不足", input: "0704", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWarekiDate(tt.input, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
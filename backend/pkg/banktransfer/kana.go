@@ -0,0 +1,51 @@
+package banktransfer
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// smallKana 全銀協フォーマットで使えない半角の小書きカナと置き換え先
+var smallKana = strings.NewReplacer(
+	"ｧ", "ｱ", "ｨ", "ｲ", "ｩ", "ｳ", "ｪ", "ｴ", "ｫ", "ｵ",
+	"ｬ", "ﾔ", "ｭ", "ﾕ", "ｮ", "ﾖ", "ｯ", "ﾂ",
+	"ｰ", "-", "･", ".",
+)
+
+// ToKana 口座名義などを全銀協フォーマットで使える半角文字に変換する
+// ひらがな・全角カナ・全角英数字を半角にし、英字は大文字、小書きカナは並字にする
+func ToKana(s string) (string, error) {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.TrimSpace(s)) {
+		// ひらがなはカタカナに寄せる
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 'ァ' - 'ぁ'
+		}
+		b.WriteRune(r)
+	}
+	kana := smallKana.Replace(strings.ToUpper(width.Narrow.String(b.String())))
+
+	for _, r := range kana {
+		if !isZenginChar(r) {
+			return "", fmt.Errorf("%w: %q に使用できない文字 %q が含まれています", ErrInvalidTransfer, s, r)
+		}
+	}
+	return kana, nil
+}
+
+// isZenginChar 全銀協フォーマットの名義に使える文字か
+func isZenginChar(r rune) bool {
+	switch {
+	case r >= '0' && r <= '9', r >= 'A' && r <= 'Z':
+		return true
+	case r >= 'ｦ' && r <= 'ﾟ':
+		return true
+	case strings.ContainsRune(" ().,-/\\｢｣", r):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,240 @@
+package banktransfer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// zenginRecordLength 総合振込の1レコードのバイト長
+const zenginRecordLength = 120
+
+// maxTransferAmount 1件の振込金額の上限（振込金額欄10桁）
+const maxTransferAmount = 9999999999
+
+// ErrInvalidTransfer 振込データが全銀協フォーマットの条件を満たさない
+var ErrInvalidTransfer = errors.New("振込データが正しくありません")
+
+// 預金種目
+const (
+	// AccountTypeOrdinary 普通預金
+	AccountTypeOrdinary = "1"
+	// AccountTypeChecking 当座預金
+	AccountTypeChecking = "2"
+	// AccountTypeSavings 貯蓄預金
+	AccountTypeSavings = "4"
+)
+
+var (
+	bankCodePattern      = regexp.MustCompile(`^\d{4}$`)
+	branchCodePattern    = regexp.MustCompile(`^\d{3}$`)
+	accountNumberPattern = regexp.MustCompile(`^\d{1,7}$`)
+	requesterCodePattern = regexp.MustCompile(`^\d{1,10}$`)
+)
+
+// Requester 振込依頼人（振込元の口座）
+type Requester struct {
+	Code          string // 振込依頼人コード（銀行との契約で付与される）
+	Name          string // 振込依頼人名（カナ）
+	BankCode      string
+	BankName      string // カナ
+	BranchCode    string
+	BranchName    string // カナ
+	AccountType   string
+	AccountNumber string
+}
+
+// Transfer 振込1件
+type Transfer struct {
+	BankCode      string
+	BankName      string // カナ
+	BranchCode    string
+	BranchName    string // カナ
+	AccountType   string
+	AccountNumber string
+	RecipientName string // 受取人名（カナ）
+	Amount        int64  // 円
+	CustomerCode  string // 顧客コード1（社員番号など、任意）
+}
+
+// IsValidAccountType 預金種目のコードか
+func IsValidAccountType(accountType string) bool {
+	switch accountType {
+	case AccountTypeOrdinary, AccountTypeChecking, AccountTypeSavings:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateAccount 振込先口座の金融機関コード・支店コード・預金種目・口座番号を検証
+func ValidateAccount(bankCode, branchCode, accountType, accountNumber string) error {
+	switch {
+	case !bankCodePattern.MatchString(bankCode):
+		return fmt.Errorf("%w: 金融機関コードは4桁の数字で指定してください", ErrInvalidTransfer)
+	case !branchCodePattern.MatchString(branchCode):
+		return fmt.Errorf("%w: 支店コードは3桁の数字で指定してください", ErrInvalidTransfer)
+	case !IsValidAccountType(accountType):
+		return fmt.Errorf("%w: 預金種目が正しくありません", ErrInvalidTransfer)
+	case !accountNumberPattern.MatchString(accountNumber):
+		return fmt.Errorf("%w: 口座番号は7桁以内の数字で指定してください", ErrInvalidTransfer)
+	}
+	return nil
+}
+
+// WriteZengin 全銀協フォーマットの総合振込ファイル（Shift_JIS、120バイト固定長、CRLF区切り）を書き出す
+func WriteZengin(w io.Writer, requester Requester, transferDate time.Time, transfers []Transfer) error {
+	if len(transfers) == 0 {
+		return fmt.Errorf("%w: 振込データがありません", ErrInvalidTransfer)
+	}
+	if !requesterCodePattern.MatchString(requester.Code) {
+		return fmt.Errorf("%w: 振込依頼人コードは10桁以内の数字で指定してください", ErrInvalidTransfer)
+	}
+	if err := ValidateAccount(requester.BankCode, requester.BranchCode, requester.AccountType, requester.AccountNumber); err != nil {
+		return fmt.Errorf("振込元口座: %w", err)
+	}
+
+	header, err := newRecord().
+		text("1", 1).
+		text("21", 2). // 種別コード（総合振込）
+		text("0", 1).  // コード区分（Shift_JIS）
+		number(requester.Code, 10).
+		kana(requester.Name, 40).
+		text(transferDate.Format("0102"), 4).
+		text(requester.BankCode, 4).
+		kana(requester.BankName, 15).
+		text(requester.BranchCode, 3).
+		kana(requester.BranchName, 15).
+		text(requester.AccountType, 1).
+		number(requester.AccountNumber, 7).
+		text("", 17).
+		bytes()
+	if err != nil {
+		return fmt.Errorf("ヘッダーレコード: %w", err)
+	}
+	records := [][]byte{header}
+
+	var total int64
+	for i, t := range transfers {
+		if err := ValidateAccount(t.BankCode, t.BranchCode, t.AccountType, t.AccountNumber); err != nil {
+			return fmt.Errorf("%d件目: %w", i+1, err)
+		}
+		if t.Amount <= 0 || t.Amount > maxTransferAmount {
+			return fmt.Errorf("%d件目: %w: 振込金額が範囲外です", i+1, ErrInvalidTransfer)
+		}
+		rec, err := newRecord().
+			text("2", 1).
+			text(t.BankCode, 4).
+			kana(t.BankName, 15).
+			text(t.BranchCode, 3).
+			kana(t.BranchName, 15).
+			text("", 4). // 手形交換所番号
+			text(t.AccountType, 1).
+			number(t.AccountNumber, 7).
+			kana(t.RecipientName, 30).
+			number(fmt.Sprint(t.Amount), 10).
+			text("0", 1). // 新規コード
+			kana(t.CustomerCode, 10).
+			text("", 10). // 顧客コード2
+			text("7", 1). // 振込指定区分（テレ振込）
+			text("", 1).  // 識別表示
+			text("", 7).
+			bytes()
+		if err != nil {
+			return fmt.Errorf("%d件目: %w", i+1, err)
+		}
+		records = append(records, rec)
+		total += t.Amount
+	}
+
+	trailer, err := newRecord().
+		text("8", 1).
+		number(fmt.Sprint(len(transfers)), 6).
+		number(fmt.Sprint(total), 12).
+		text("", 101).
+		bytes()
+	if err != nil {
+		return fmt.Errorf("トレーラーレコード: %w", err)
+	}
+	end, _ := newRecord().text("9", 1).text("", 119).bytes()
+	records = append(records, trailer, end)
+
+	for _, rec := range records {
+		if _, err := w.Write(append(rec, '\r', '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record 固定長レコードの組み立て（最初に発生したエラーを保持する）
+type record struct {
+	buf bytes.Buffer
+	err error
+}
+
+// newRecord 固定長レコードの組み立てを開始
+func newRecord() *record {
+	return &record{}
+}
+
+// text 左詰め・空白埋めの項目（長すぎる場合はエラー）
+func (r *record) text(s string, length int) *record {
+	if r.err != nil {
+		return r
+	}
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(s)
+	if err != nil || len(encoded) > length {
+		r.err = fmt.Errorf("%w: %q は%dバイトに収まりません", ErrInvalidTransfer, s, length)
+		return r
+	}
+	r.buf.WriteString(encoded)
+	r.buf.WriteString(strings.Repeat(" ", length-len(encoded)))
+	return r
+}
+
+// kana 半角カナに変換した左詰めの項目（長すぎる場合は切り詰める）
+func (r *record) kana(s string, length int) *record {
+	if r.err != nil {
+		return r
+	}
+	kana, err := ToKana(s)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	// 半角カナ・英数字はShift_JISで1バイトのため文字数で切り詰める
+	if runes := []rune(kana); len(runes) > length {
+		kana = string(runes[:length])
+	}
+	return r.text(kana, length)
+}
+
+// number 右詰め・ゼロ埋めの数字項目
+func (r *record) number(s string, length int) *record {
+	if r.err != nil {
+		return r
+	}
+	if len(s) > length {
+		r.err = fmt.Errorf("%w: %q は%d桁に収まりません", ErrInvalidTransfer, s, length)
+		return r
+	}
+	return r.text(strings.Repeat("0", length-len(s))+s, length)
+}
+
+// bytes 組み立てたレコード
+func (r *record) bytes() ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.buf.Len() != zenginRecordLength {
+		return nil, fmt.Errorf("%w: レコード長が%dバイトです", ErrInvalidTransfer, r.buf.Len())
+	}
+	return r.buf.Bytes(), nil
+}
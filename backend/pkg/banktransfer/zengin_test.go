@@ -0,0 +1,101 @@
+package banktransfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func testRequester() Requester {
+	return Requester{
+		Code:          "1234567890",
+		Name:          "カ）デュエスク",
+		BankCode:      "0001",
+		BankName:      "ミズホ",
+		BranchCode:    "100",
+		BranchName:    "ホンテン",
+		AccountType:   AccountTypeOrdinary,
+		AccountNumber: "1234567",
+	}
+}
+
+func TestWriteZengin(t *testing.T) {
+	transfers := []Transfer{
+		{BankCode: "0005", BankName: "ミツビシユーエフジェイ", BranchCode: "001", BranchName: "ホンテン",
+			AccountType: AccountTypeOrdinary, AccountNumber: "7654321", RecipientName: "やまだ たろう", Amount: 12800, CustomerCode: "E001"},
+		{BankCode: "0009", BankName: "ミツイスミトモ", BranchCode: "210", BranchName: "シブヤ",
+			AccountType: AccountTypeChecking, AccountNumber: "55", RecipientName: "スズキ　ハナコ", Amount: 3000},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteZengin(&buf, testRequester(), time.Date(2025, 5, 25, 0, 0, 0, 0, time.UTC), transfers))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 5)
+	for i, line := range lines {
+		assert.Len(t, line, zenginRecordLength, "%d行目", i+1)
+	}
+
+	header := decode(t, lines[0])
+	assert.True(t, strings.HasPrefix(header, "12101234567890ｶ)ﾃﾞﾕｴｽｸ"))
+	assert.Equal(t, "0525", lines[0][54:58])
+	assert.Equal(t, "0001", lines[0][58:62])
+
+	data := lines[1]
+	assert.Equal(t, "20005", data[0:5])
+	assert.Equal(t, "17654321", data[42:50])
+	assert.Equal(t, "ﾔﾏﾀﾞ ﾀﾛｳ", strings.TrimSpace(decode(t, data[50:80])))
+	assert.Equal(t, "0000012800", data[80:90])
+	assert.Equal(t, "E001", strings.TrimSpace(data[91:101]))
+	assert.Equal(t, "0000055", lines[2][43:50])
+
+	assert.Equal(t, "8000002000000015800", lines[3][:19])
+	assert.Equal(t, "9", strings.TrimSpace(lines[4]))
+}
+
+func TestWriteZenginValidation(t *testing.T) {
+	date := time.Date(2025, 5, 25, 0, 0, 0, 0, time.UTC)
+	valid := Transfer{BankCode: "0005", BranchCode: "001", AccountType: AccountTypeOrdinary, AccountNumber: "7654321", RecipientName: "ﾔﾏﾀﾞ ﾀﾛｳ", Amount: 100}
+
+	err := WriteZengin(&bytes.Buffer{}, testRequester(), date, nil)
+	assert.ErrorIs(t, err, ErrInvalidTransfer)
+
+	badBank := valid
+	badBank.BankCode = "5"
+	assert.ErrorIs(t, WriteZengin(&bytes.Buffer{}, testRequester(), date, []Transfer{badBank}), ErrInvalidTransfer)
+
+	zero := valid
+	zero.Amount = 0
+	assert.ErrorIs(t, WriteZengin(&bytes.Buffer{}, testRequester(), date, []Transfer{zero}), ErrInvalidTransfer)
+
+	kanji := valid
+	kanji.RecipientName = "山田太郎"
+	assert.ErrorIs(t, WriteZengin(&bytes.Buffer{}, testRequester(), date, []Transfer{kanji}), ErrInvalidTransfer)
+}
+
+func TestToKana(t *testing.T) {
+	tests := map[string]string{
+		"ヤマダ　タロウ":  "ﾔﾏﾀﾞ ﾀﾛｳ",
+		"きゃっしゅ":    "ｷﾔﾂｼﾕ",
+		"カ）ａｂｃ－１２": "ｶ)ABC-12",
+		"パーク":      "ﾊﾟ-ｸ",
+	}
+	for input, expected := range tests {
+		got, err := ToKana(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, got, input)
+	}
+}
+
+// decode Shift_JISの項目をUTF-8に戻す
+func decode(t *testing.T, s string) string {
+	t.Helper()
+	decoded, err := japanese.ShiftJIS.NewDecoder().String(s)
+	require.NoError(t, err)
+	return decoded
+}
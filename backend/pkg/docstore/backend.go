@@ -0,0 +1,42 @@
+package docstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrNotFound 保存されていないキー
+	ErrNotFound = errors.New("文書が見つかりません")
+	// ErrAlreadyExists 同じキーで保存済み（上書きはできない）
+	ErrAlreadyExists = errors.New("同じキーの文書が保存済みです")
+	// ErrInvalidKey 保存先として使えないキー
+	ErrInvalidKey = errors.New("文書のキーが正しくありません")
+)
+
+// Backend 文書ファイルの保存先
+// 一度保存したキーは上書きできず、差し替えは新しいキーで保存する
+type Backend interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidateKey 保存先のキーを検証（相対パスのみ、親ディレクトリへの参照は不可）
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("%w: %s", ErrInvalidKey, key)
+	}
+	if path.Clean(key) != key {
+		return fmt.Errorf("%w: %s", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." {
+			return fmt.Errorf("%w: %s", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
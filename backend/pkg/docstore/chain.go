@@ -0,0 +1,49 @@
+package docstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenesisHash ハッシュチェーンの最初の文書が参照する直前のハッシュ
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// ChainEntry ハッシュチェーンに含める文書の属性
+// 検索に使う取引年月日・金額・取引先と文書本体のハッシュを連結するため、いずれかを書き換えると以降のハッシュが一致しなくなる
+type ChainEntry struct {
+	Seq             int64
+	DocumentID      string
+	Version         int
+	DocumentType    string
+	TransactionDate time.Time
+	Amount          int64
+	Counterparty    string
+	ContentHash     string
+}
+
+// ContentHash 文書本体のSHA-256（16進数）
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ChainHash 直前の文書のハッシュと文書の属性から、この文書のチェーンハッシュを計算する
+// 文字列の属性は改行を含んでも区切りがずれないよう引用符で囲む
+func ChainHash(prevHash string, entry ChainEntry) string {
+	fields := []string{
+		prevHash,
+		strconv.FormatInt(entry.Seq, 10),
+		strconv.Quote(entry.DocumentID),
+		strconv.Itoa(entry.Version),
+		strconv.Quote(entry.DocumentType),
+		entry.TransactionDate.Format("2006-01-02"),
+		strconv.FormatInt(entry.Amount, 10),
+		strconv.Quote(entry.Counterparty),
+		entry.ContentHash,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	return hex.EncodeToString(sum[:])
+}
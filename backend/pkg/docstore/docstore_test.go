@@ -0,0 +1,84 @@
+package docstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Put(ctx, "2026/doc-1/v1.pdf", []byte("original"), "application/pdf"))
+	content, err := backend.Get(ctx, "2026/doc-1/v1.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), content)
+
+	// 保存済みのキーは上書きできない
+	assert.ErrorIs(t, backend.Put(ctx, "2026/doc-1/v1.pdf", []byte("tampered"), "application/pdf"), ErrAlreadyExists)
+	content, err = backend.Get(ctx, "2026/doc-1/v1.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), content)
+
+	_, err = backend.Get(ctx, "2026/doc-2/v1.pdf")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, backend.Delete(ctx, "2026/doc-1/v1.pdf"))
+	_, err = backend.Get(ctx, "2026/doc-1/v1.pdf")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, backend.Delete(ctx, "2026/doc-1/v1.pdf"))
+}
+
+func TestLocalBackendRejectsEscapingKeys(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(filepath.Join(root, "documents"))
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside.pdf", "a/../../outside.pdf", "a//b.pdf", "a\\b.pdf", "./a.pdf"} {
+		assert.ErrorIs(t, backend.Put(ctx, key, []byte("x"), "text/plain"), ErrInvalidKey, key)
+	}
+	_, err = os.Stat(filepath.Join(root, "outside.pdf"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestChainHash(t *testing.T) {
+	entry := ChainEntry{
+		Seq:             1,
+		DocumentID:      "doc-1",
+		Version:         1,
+		DocumentType:    "receipt",
+		TransactionDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Amount:          1100,
+		Counterparty:    "株式会社サンプル",
+		ContentHash:     ContentHash([]byte("receipt")),
+	}
+	first := ChainHash(GenesisHash, entry)
+	assert.Len(t, first, 64)
+	assert.Equal(t, first, ChainHash(GenesisHash, entry))
+
+	// 属性・本体・直前のハッシュのいずれを変えてもハッシュが変わる
+	tampered := entry
+	tampered.Amount = 11000
+	assert.NotEqual(t, first, ChainHash(GenesisHash, tampered))
+	tampered = entry
+	tampered.ContentHash = ContentHash([]byte("receipt!"))
+	assert.NotEqual(t, first, ChainHash(GenesisHash, tampered))
+	assert.NotEqual(t, first, ChainHash(first, entry))
+
+	// 区切り文字を含む取引先でも別の属性と混同しない
+	a := entry
+	a.Counterparty = "A\n1"
+	b := entry
+	b.Counterparty = "A"
+	assert.NotEqual(t, ChainHash(GenesisHash, a), ChainHash(GenesisHash, b))
+
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ContentHash(nil))
+}
@@ -0,0 +1,82 @@
+package docstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocalBackend ローカルファイルシステムの保存先（開発・テスト用）
+type LocalBackend struct {
+	root string
+}
+
+// NewLocalBackend rootディレクトリの下に文書を保存する
+func NewLocalBackend(root string) (*LocalBackend, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("文書の保存先ディレクトリを作成できません: %w", err)
+	}
+	return &LocalBackend{root: root}, nil
+}
+
+// Put 文書を保存（既に存在する場合はErrAlreadyExists）
+func (b *LocalBackend) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	name := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return fmt.Errorf("文書の保存先ディレクトリを作成できません: %w", err)
+	}
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o440)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("文書を保存できません: %w", err)
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(name)
+		return fmt.Errorf("文書を保存できません: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(name)
+		return fmt.Errorf("文書を保存できません: %w", err)
+	}
+	return nil
+}
+
+// Get 文書を読み込む
+func (b *LocalBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(b.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("文書を読み込めません: %w", err)
+	}
+	return content, nil
+}
+
+// Delete 文書を削除（存在しない場合は何もしない）
+func (b *LocalBackend) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(b.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("文書を削除できません: %w", err)
+	}
+	return nil
+}
+
+// path キーに対応するファイルパス
+func (b *LocalBackend) path(key string) string {
+	return filepath.Join(b.root, filepath.FromSlash(key))
+}
@@ -0,0 +1,108 @@
+package docstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Client S3Backendが使うS3 APIの一部（*s3.Clientが満たす）
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Backend S3（MinIO互換）の保存先
+// バケットにオブジェクトロック（コンプライアンスモード）を設定すると保存期間中の削除をS3側でも防げる
+type S3Backend struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+// NewS3Backend bucketのprefix配下に文書を保存する
+func NewS3Backend(client S3Client, bucket, prefix string) *S3Backend {
+	return &S3Backend{client: client, bucket: bucket, prefix: prefix}
+}
+
+// Put 文書を保存（既に存在する場合はErrAlreadyExists）
+func (b *S3Backend) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	_, err := b.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+	})
+	if err == nil {
+		return ErrAlreadyExists
+	}
+	if !isS3NotFound(err) {
+		return fmt.Errorf("文書の存在確認に失敗しました: %w", err)
+	}
+
+	_, err = b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(b.prefix + key),
+		Body:        bytes.NewReader(content),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("文書を保存できません: %w", err)
+	}
+	return nil
+}
+
+// Get 文書を読み込む
+func (b *S3Backend) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	output, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("文書を読み込めません: %w", err)
+	}
+	defer output.Body.Close()
+
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("文書を読み込めません: %w", err)
+	}
+	return content, nil
+}
+
+// Delete 文書を削除
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.prefix + key),
+	})
+	if err != nil {
+		return fmt.Errorf("文書を削除できません: %w", err)
+	}
+	return nil
+}
+
+// isS3NotFound オブジェクトが存在しないエラーか
+func isS3NotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
@@ -0,0 +1,131 @@
+package invoicepdf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Document 請求書PDFの描画データ
+type Document struct {
+	Title         string // 指定時はテンプレートのタイトルの代わりに表示する（赤伝など）
+	InvoiceNumber string
+	InvoiceDate   time.Time
+	DueDate       time.Time // ゼロ値の場合は支払期限を表示しない
+	BillingMonth  string // YYYY-MM
+
+	Recipient   Recipient
+	Issuer      Issuer
+	BankAccount BankAccount
+
+	Lines        []Line
+	Subtotal     float64
+	TaxAmount    float64
+	TotalAmount  float64
+	TaxSummaries []TaxSummary // 税率ごとの小計
+	Notes        string
+}
+
+// Recipient 請求先
+type Recipient struct {
+	Name          string
+	Address       string
+	ContactPerson string
+}
+
+// Issuer 請求元（自社）情報
+type Issuer struct {
+	Name               string
+	PostalCode         string
+	Address            string
+	Phone              string
+	Email              string
+	SealImagePath      string // 社印画像（PNG/JPEG）
+	RegistrationNumber string // 適格請求書発行事業者登録番号（T+13桁）
+}
+
+// BankAccount 振込先口座
+type BankAccount struct {
+	BankName      string
+	BranchName    string
+	AccountType   string // 普通, 当座
+	AccountNumber string
+	AccountHolder string
+}
+
+// IsEmpty 振込先が未設定かどうか
+func (b BankAccount) IsEmpty() bool {
+	return b.BankName == "" && b.AccountNumber == ""
+}
+
+// Line 請求明細行
+type Line struct {
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	Amount      float64
+	ReducedRate bool // 軽減税率対象（品目に※を付記する）
+}
+
+// TaxSummary 税率別の小計
+type TaxSummary struct {
+	Label         string  // 例: 10%対象
+	TaxRate       float64 // パーセント
+	TaxableAmount float64
+	TaxAmount     float64
+}
+
+// FormatYen 金額を「¥1,234」形式に整形
+func FormatYen(amount float64) string {
+	v := int64(math.Round(amount))
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return sign + "¥" + groupDigits(strconv.FormatInt(v, 10))
+}
+
+// FormatNumber 数値を桁区切りで整形（小数は2桁まで）
+func FormatNumber(value float64) string {
+	rounded := math.Round(value*100) / 100
+	s := strconv.FormatFloat(math.Abs(rounded), 'f', -1, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	result := groupDigits(intPart)
+	if fracPart != "" {
+		result += "." + fracPart
+	}
+	if rounded < 0 {
+		result = "-" + result
+	}
+	return result
+}
+
+// FormatDate 日付を「2006年1月2日」形式に整形
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d年%d月%d日", t.Year(), int(t.Month()), t.Day())
+}
+
+// groupDigits 3桁ごとにカンマを挿入
+func groupDigits(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,817 @@
+package invoicepdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/signintech/gopdf"
+)
+
+const (
+	fontRegular = "regular"
+	fontBold    = "bold"
+
+	footerHeight = 20.0
+)
+
+// pageSizes 対応する用紙サイズ
+var pageSizes = map[string]*gopdf.Rect{
+	"A4":     gopdf.PageSizeA4,
+	"B5":     gopdf.PageSizeB5,
+	"LETTER": gopdf.PageSizeLetter,
+}
+
+// Renderer 請求書PDFレンダラー
+// フォントはサブセット化してPDFに埋め込むため、閲覧環境のフォントに依存しない
+type Renderer struct {
+	regularFont []byte
+	boldFont    []byte
+	templates   map[string]*Template
+}
+
+// NewRenderer レンダラーを生成
+// boldFontが空の場合は通常フォントで太字を代用する
+func NewRenderer(regularFont, boldFont []byte, templates map[string]*Template) (*Renderer, error) {
+	if len(regularFont) == 0 {
+		return nil, fmt.Errorf("フォントが指定されていません")
+	}
+	if len(boldFont) == 0 {
+		boldFont = regularFont
+	}
+	registered := make(map[string]*Template, len(templates)+1)
+	for name, tmpl := range templates {
+		registered[name] = tmpl
+	}
+	if _, ok := registered[DefaultTemplateName]; !ok {
+		registered[DefaultTemplateName] = DefaultTemplate()
+	}
+	return &Renderer{
+		regularFont: regularFont,
+		boldFont:    boldFont,
+		templates:   registered,
+	}, nil
+}
+
+// Template テンプレートを取得（存在しない場合はデフォルト）
+func (r *Renderer) Template(name string) *Template {
+	if tmpl, ok := r.templates[name]; ok {
+		return tmpl
+	}
+	return r.templates[DefaultTemplateName]
+}
+
+// HasTemplate テンプレートが登録されているか
+func (r *Renderer) HasTemplate(name string) bool {
+	_, ok := r.templates[name]
+	return ok
+}
+
+// Render 請求書PDFを生成
+func (r *Renderer) Render(doc *Document, templateName string) ([]byte, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("請求書データが指定されていません")
+	}
+
+	w, err := r.newWriter(r.Template(templateName))
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []func(*Document) error{
+		w.drawHeader,
+		w.drawParties,
+		w.drawAmountSummary,
+		w.drawLines,
+		w.drawTotals,
+		w.drawBankInfo,
+		w.drawNotes,
+	}
+	for _, step := range steps {
+		if err := step(doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.drawFooter(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := w.pdf.Write(&buf); err != nil {
+		return nil, fmt.Errorf("PDFの書き出しに失敗しました: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writer 1件の請求書を描画する状態
+type writer struct {
+	pdf    *gopdf.GoPdf
+	tmpl   *Template
+	width  float64
+	height float64
+	y      float64
+	accent [3]uint8
+}
+
+func (r *Renderer) newWriter(tmpl *Template) (*writer, error) {
+	size, ok := pageSizes[strings.ToUpper(tmpl.PageSize)]
+	if !ok {
+		return nil, fmt.Errorf("未対応の用紙サイズです: %s", tmpl.PageSize)
+	}
+	red, green, blue, err := parseHexColor(tmpl.AccentColor)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := &gopdf.GoPdf{}
+	pdf.Start(gopdf.Config{PageSize: *size, Unit: gopdf.UnitPT})
+	if err := pdf.AddTTFFontData(fontRegular, r.regularFont); err != nil {
+		return nil, fmt.Errorf("フォントの読み込みに失敗しました: %w", err)
+	}
+	if err := pdf.AddTTFFontData(fontBold, r.boldFont); err != nil {
+		return nil, fmt.Errorf("フォントの読み込みに失敗しました: %w", err)
+	}
+
+	w := &writer{
+		pdf:    pdf,
+		tmpl:   tmpl,
+		width:  size.W,
+		height: size.H,
+		accent: [3]uint8{red, green, blue},
+	}
+	w.addPage()
+	return w, nil
+}
+
+// contentWidth 余白を除いた描画幅
+func (w *writer) contentWidth() float64 {
+	return w.width - w.tmpl.Margin*2
+}
+
+// left 描画領域の左端
+func (w *writer) left() float64 {
+	return w.tmpl.Margin
+}
+
+// right 描画領域の右端
+func (w *writer) right() float64 {
+	return w.width - w.tmpl.Margin
+}
+
+func (w *writer) addPage() {
+	w.pdf.AddPage()
+	w.y = w.tmpl.Margin
+}
+
+// ensureSpace 残りの高さが足りなければ改ページする
+func (w *writer) ensureSpace(h float64) bool {
+	if w.y+h <= w.height-w.tmpl.Margin-footerHeight {
+		return false
+	}
+	w.addPage()
+	return true
+}
+
+func (w *writer) setFont(bold bool, size float64) error {
+	family := fontRegular
+	if bold {
+		family = fontBold
+	}
+	return w.pdf.SetFont(family, "", size)
+}
+
+// text 指定領域にテキストを描画する
+func (w *writer) text(x, y, width, height float64, s string, align int) error {
+	if s == "" {
+		return nil
+	}
+	w.pdf.SetXY(x, y)
+	return w.pdf.CellWithOption(&gopdf.Rect{W: width, H: height}, s, gopdf.CellOption{Align: align | gopdf.Middle})
+}
+
+func (w *writer) setTextColor(accentBackground bool) {
+	if accentBackground {
+		w.pdf.SetTextColor(255, 255, 255)
+		return
+	}
+	w.pdf.SetTextColor(0, 0, 0)
+}
+
+func (w *writer) fillAccent(x, y, width, height float64) {
+	w.pdf.SetFillColor(w.accent[0], w.accent[1], w.accent[2])
+	w.pdf.RectFromUpperLeftWithStyle(x, y, width, height, "F")
+}
+
+func (w *writer) strokeRect(x, y, width, height float64) {
+	w.pdf.SetStrokeColor(w.accent[0], w.accent[1], w.accent[2])
+	w.pdf.SetLineWidth(0.6)
+	w.pdf.RectFromUpperLeftWithStyle(x, y, width, height, "D")
+}
+
+func (w *writer) hline(x1, x2, y float64, gray bool) {
+	if gray {
+		w.pdf.SetStrokeColor(200, 200, 200)
+		w.pdf.SetLineWidth(0.4)
+	} else {
+		w.pdf.SetStrokeColor(w.accent[0], w.accent[1], w.accent[2])
+		w.pdf.SetLineWidth(0.8)
+	}
+	w.pdf.Line(x1, y, x2, y)
+}
+
+// drawHeader タイトル・請求書番号・請求日
+func (w *writer) drawHeader(doc *Document) error {
+	sizes := w.tmpl.FontSizes
+	w.setTextColor(false)
+
+	if err := w.setFont(true, sizes.Title); err != nil {
+		return err
+	}
+	titleHeight := sizes.Title * 1.6
+	title := w.tmpl.Title
+	if doc.Title != "" {
+		title = doc.Title
+	}
+	if err := w.text(w.left(), w.y, w.contentWidth(), titleHeight, title, gopdf.Center); err != nil {
+		return err
+	}
+	w.y += titleHeight
+	w.hline(w.left(), w.right(), w.y, false)
+	w.y += 6
+
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	lineHeight := sizes.Body * 1.6
+	metaWidth := w.contentWidth() * 0.4
+	metaX := w.right() - metaWidth
+	meta := []string{
+		"請求書番号: " + doc.InvoiceNumber,
+		"請求日: " + FormatDate(doc.InvoiceDate),
+	}
+	for _, line := range meta {
+		if err := w.text(metaX, w.y, metaWidth, lineHeight, line, gopdf.Right); err != nil {
+			return err
+		}
+		w.y += lineHeight
+	}
+	w.y += 6
+	return nil
+}
+
+// drawParties 宛名と請求元（社印欄を含む）
+func (w *writer) drawParties(doc *Document) error {
+	sizes := w.tmpl.FontSizes
+	top := w.y
+	leftWidth := w.contentWidth() * 0.55
+	rightX := w.left() + w.contentWidth()*0.58
+	rightWidth := w.right() - rightX
+
+	// 宛名
+	if err := w.setFont(true, sizes.Heading+2); err != nil {
+		return err
+	}
+	nameHeight := (sizes.Heading + 2) * 1.8
+	recipient := strings.TrimSpace(doc.Recipient.Name + " " + w.tmpl.Honorific)
+	if err := w.text(w.left(), w.y, leftWidth, nameHeight, recipient, gopdf.Left); err != nil {
+		return err
+	}
+	w.y += nameHeight
+	w.hline(w.left(), w.left()+leftWidth, w.y, false)
+	w.y += 4
+
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	lineHeight := sizes.Body * 1.6
+	leftLines := []string{doc.Recipient.Address}
+	if doc.Recipient.ContactPerson != "" {
+		leftLines = append(leftLines, doc.Recipient.ContactPerson+" 様")
+	}
+	for _, line := range leftLines {
+		if line == "" {
+			continue
+		}
+		if err := w.text(w.left(), w.y, leftWidth, lineHeight, line, gopdf.Left); err != nil {
+			return err
+		}
+		w.y += lineHeight
+	}
+	w.y += lineHeight / 2
+	if err := w.text(w.left(), w.y, leftWidth, lineHeight, w.tmpl.Greeting, gopdf.Left); err != nil {
+		return err
+	}
+	w.y += lineHeight
+	leftBottom := w.y
+
+	// 請求元
+	y := top
+	if err := w.setFont(true, sizes.Heading); err != nil {
+		return err
+	}
+	issuerNameHeight := sizes.Heading * 1.8
+	if err := w.text(rightX, y, rightWidth, issuerNameHeight, doc.Issuer.Name, gopdf.Left); err != nil {
+		return err
+	}
+	y += issuerNameHeight
+
+	if err := w.setFont(false, sizes.Small); err != nil {
+		return err
+	}
+	smallHeight := sizes.Small * 1.6
+	issuerLines := []string{}
+	if doc.Issuer.RegistrationNumber != "" {
+		issuerLines = append(issuerLines, "登録番号: "+doc.Issuer.RegistrationNumber)
+	}
+	if doc.Issuer.PostalCode != "" {
+		issuerLines = append(issuerLines, "〒"+doc.Issuer.PostalCode)
+	}
+	issuerLines = append(issuerLines, doc.Issuer.Address)
+	if doc.Issuer.Phone != "" {
+		issuerLines = append(issuerLines, "TEL: "+doc.Issuer.Phone)
+	}
+	if doc.Issuer.Email != "" {
+		issuerLines = append(issuerLines, doc.Issuer.Email)
+	}
+	for _, line := range issuerLines {
+		if line == "" {
+			continue
+		}
+		if err := w.text(rightX, y, rightWidth, smallHeight, line, gopdf.Left); err != nil {
+			return err
+		}
+		y += smallHeight
+	}
+
+	// 社印欄（会社名に重ねて配置する）
+	if w.tmpl.ShowCompanySeal {
+		if err := w.drawCompanySeal(doc, w.right()-52, top); err != nil {
+			return err
+		}
+		if y < top+56 {
+			y = top + 56
+		}
+	}
+
+	// 押印欄
+	if len(w.tmpl.SealLabels) > 0 {
+		y += 6
+		if err := w.drawSealBoxes(y); err != nil {
+			return err
+		}
+		y += 60
+	}
+
+	if y > leftBottom {
+		w.y = y
+	} else {
+		w.y = leftBottom
+	}
+	w.y += 10
+	return nil
+}
+
+// drawCompanySeal 社印画像、未設定の場合は押印枠を描画
+func (w *writer) drawCompanySeal(doc *Document, x, y float64) error {
+	const size = 52.0
+	if doc.Issuer.SealImagePath != "" {
+		if err := w.pdf.Image(doc.Issuer.SealImagePath, x, y, &gopdf.Rect{W: size, H: size}); err != nil {
+			return fmt.Errorf("社印画像の読み込みに失敗しました: %w", err)
+		}
+		return nil
+	}
+
+	w.pdf.SetStrokeColor(190, 190, 190)
+	w.pdf.SetLineWidth(0.6)
+	w.pdf.RectFromUpperLeftWithStyle(x, y, size, size, "D")
+	if err := w.setFont(false, w.tmpl.FontSizes.Body); err != nil {
+		return err
+	}
+	w.pdf.SetTextColor(190, 190, 190)
+	defer w.setTextColor(false)
+	return w.text(x, y, size, size, "印", gopdf.Center)
+}
+
+// drawSealBoxes 承認・担当などの押印欄を右寄せで描画
+func (w *writer) drawSealBoxes(y float64) error {
+	const (
+		boxWidth    = 46.0
+		labelHeight = 12.0
+		stampHeight = 46.0
+	)
+	if err := w.setFont(false, w.tmpl.FontSizes.Small); err != nil {
+		return err
+	}
+	x := w.right() - boxWidth*float64(len(w.tmpl.SealLabels))
+	for _, label := range w.tmpl.SealLabels {
+		w.fillAccent(x, y, boxWidth, labelHeight)
+		w.setTextColor(true)
+		if err := w.text(x, y, boxWidth, labelHeight, label, gopdf.Center); err != nil {
+			return err
+		}
+		w.strokeRect(x, y, boxWidth, labelHeight+stampHeight)
+		x += boxWidth
+	}
+	w.setTextColor(false)
+	return nil
+}
+
+// drawAmountSummary ご請求金額と支払期限
+func (w *writer) drawAmountSummary(doc *Document) error {
+	sizes := w.tmpl.FontSizes
+	boxHeight := sizes.Heading * 2.4
+	labelWidth := w.contentWidth() * 0.25
+	amountWidth := w.contentWidth() * 0.30
+	w.ensureSpace(boxHeight * 2)
+
+	x := w.left()
+	w.fillAccent(x, w.y, labelWidth, boxHeight)
+	w.setTextColor(true)
+	if err := w.setFont(true, sizes.Heading); err != nil {
+		return err
+	}
+	if err := w.text(x, w.y, labelWidth, boxHeight, "ご請求金額（税込）", gopdf.Center); err != nil {
+		return err
+	}
+	w.setTextColor(false)
+	w.strokeRect(x, w.y, labelWidth+amountWidth, boxHeight)
+	if err := w.setFont(true, sizes.Heading+4); err != nil {
+		return err
+	}
+	if err := w.text(x+labelWidth, w.y, amountWidth-8, boxHeight, FormatYen(doc.TotalAmount), gopdf.Right); err != nil {
+		return err
+	}
+
+	// 支払期限・対象期間は金額の右側に並べる
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	infoX := x + labelWidth + amountWidth + 12
+	infoWidth := w.right() - infoX
+	lineHeight := boxHeight / 2
+	if !doc.DueDate.IsZero() {
+		if err := w.text(infoX, w.y, infoWidth, lineHeight, "お支払期限: "+FormatDate(doc.DueDate), gopdf.Left); err != nil {
+			return err
+		}
+	}
+	if period := formatBillingMonth(doc.BillingMonth); period != "" {
+		if err := w.text(infoX, w.y+lineHeight, infoWidth, lineHeight, "対象期間: "+period, gopdf.Left); err != nil {
+			return err
+		}
+	}
+	w.y += boxHeight + 14
+	return nil
+}
+
+// drawLines 明細表
+func (w *writer) drawLines(doc *Document) error {
+	sizes := w.tmpl.FontSizes
+	rowPadding := 4.0
+	lineHeight := sizes.Body * 1.5
+	headerHeight := sizes.Body * 2.2
+
+	widths := w.columnWidths()
+	if err := w.drawTableHeader(widths, headerHeight); err != nil {
+		return err
+	}
+
+	hasReduced := false
+	for _, line := range doc.Lines {
+		if err := w.setFont(false, sizes.Body); err != nil {
+			return err
+		}
+		description := line.Description
+		if line.ReducedRate {
+			description += " ※"
+			hasReduced = true
+		}
+		descLines, err := w.wrapDescription(description, widths)
+		if err != nil {
+			return err
+		}
+		rowHeight := float64(len(descLines))*lineHeight + rowPadding*2
+
+		if w.ensureSpace(rowHeight) {
+			if err := w.drawTableHeader(widths, headerHeight); err != nil {
+				return err
+			}
+			if err := w.setFont(false, sizes.Body); err != nil {
+				return err
+			}
+		}
+
+		x := w.left()
+		for i, col := range w.tmpl.Columns {
+			cellX := x + rowPadding
+			cellWidth := widths[i] - rowPadding*2
+			switch col.Field {
+			case ColumnDescription:
+				for j, text := range descLines {
+					if err := w.text(cellX, w.y+rowPadding+float64(j)*lineHeight, cellWidth, lineHeight, text, columnAlign(col)); err != nil {
+						return err
+					}
+				}
+			default:
+				value := formatColumnValue(col.Field, line)
+				if err := w.text(cellX, w.y+rowPadding, cellWidth, lineHeight, value, columnAlign(col)); err != nil {
+					return err
+				}
+			}
+			x += widths[i]
+		}
+		w.y += rowHeight
+		w.hline(w.left(), w.right(), w.y, true)
+	}
+
+	if hasReduced {
+		if err := w.setFont(false, sizes.Small); err != nil {
+			return err
+		}
+		noteHeight := sizes.Small * 1.8
+		w.ensureSpace(noteHeight)
+		if err := w.text(w.left(), w.y+2, w.contentWidth(), noteHeight, "※は軽減税率（8%）対象です。", gopdf.Left); err != nil {
+			return err
+		}
+		w.y += noteHeight
+	}
+	w.y += 8
+	return nil
+}
+
+func (w *writer) drawTableHeader(widths []float64, height float64) error {
+	if err := w.setFont(true, w.tmpl.FontSizes.Body); err != nil {
+		return err
+	}
+	w.fillAccent(w.left(), w.y, w.contentWidth(), height)
+	w.setTextColor(true)
+	x := w.left()
+	for i, col := range w.tmpl.Columns {
+		if err := w.text(x, w.y, widths[i], height, col.Label, gopdf.Center); err != nil {
+			return err
+		}
+		x += widths[i]
+	}
+	w.setTextColor(false)
+	w.y += height
+	return nil
+}
+
+// columnWidths 列幅の比率を実寸に変換
+func (w *writer) columnWidths() []float64 {
+	total := 0.0
+	for _, col := range w.tmpl.Columns {
+		total += col.Width
+	}
+	widths := make([]float64, len(w.tmpl.Columns))
+	for i, col := range w.tmpl.Columns {
+		widths[i] = w.contentWidth() * col.Width / total
+	}
+	return widths
+}
+
+// wrapDescription 品目を列幅に合わせて折り返す
+func (w *writer) wrapDescription(description string, widths []float64) ([]string, error) {
+	for i, col := range w.tmpl.Columns {
+		if col.Field != ColumnDescription {
+			continue
+		}
+		if description == "" {
+			return []string{""}, nil
+		}
+		var lines []string
+		for _, paragraph := range strings.Split(description, "\n") {
+			if paragraph == "" {
+				lines = append(lines, "")
+				continue
+			}
+			split, err := w.pdf.SplitText(paragraph, widths[i]-8)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, split...)
+		}
+		return lines, nil
+	}
+	return []string{""}, nil
+}
+
+// drawTotals 小計・消費税・合計と税率別内訳
+func (w *writer) drawTotals(doc *Document) error {
+	sizes := w.tmpl.FontSizes
+	rowHeight := sizes.Body * 2
+	labelWidth := w.contentWidth() * 0.2
+	valueWidth := w.contentWidth() * 0.2
+	x := w.right() - labelWidth - valueWidth
+
+	rows := []struct {
+		label string
+		value float64
+		bold  bool
+	}{
+		{"小計", doc.Subtotal, false},
+		{"消費税", doc.TaxAmount, false},
+		{"合計", doc.TotalAmount, true},
+	}
+	w.ensureSpace(rowHeight * float64(len(rows)+len(doc.TaxSummaries)+1))
+
+	for _, row := range rows {
+		if err := w.setFont(row.bold, sizes.Body); err != nil {
+			return err
+		}
+		w.fillAccent(x, w.y, labelWidth, rowHeight)
+		w.setTextColor(true)
+		if err := w.text(x, w.y, labelWidth, rowHeight, row.label, gopdf.Center); err != nil {
+			return err
+		}
+		w.setTextColor(false)
+		w.strokeRect(x, w.y, labelWidth+valueWidth, rowHeight)
+		if err := w.text(x+labelWidth, w.y, valueWidth-6, rowHeight, FormatYen(row.value), gopdf.Right); err != nil {
+			return err
+		}
+		w.y += rowHeight
+	}
+
+	// 税率別内訳
+	if len(doc.TaxSummaries) > 0 {
+		w.y += 6
+		if err := w.setFont(false, sizes.Small); err != nil {
+			return err
+		}
+		smallHeight := sizes.Small * 1.8
+		breakdownX := w.right() - w.contentWidth()*0.6
+		breakdownWidth := w.contentWidth() * 0.6
+		for _, summary := range doc.TaxSummaries {
+			text := fmt.Sprintf("%s  %s　消費税  %s", summary.Label, FormatYen(summary.TaxableAmount), FormatYen(summary.TaxAmount))
+			if err := w.text(breakdownX, w.y, breakdownWidth, smallHeight, text, gopdf.Right); err != nil {
+				return err
+			}
+			w.y += smallHeight
+		}
+	}
+	w.y += 12
+	return nil
+}
+
+// drawBankInfo 振込先
+func (w *writer) drawBankInfo(doc *Document) error {
+	if !w.tmpl.ShowBankInfo || doc.BankAccount.IsEmpty() {
+		return nil
+	}
+	sizes := w.tmpl.FontSizes
+	lineHeight := sizes.Body * 1.7
+	bank := doc.BankAccount
+
+	lines := []string{
+		strings.TrimSpace(bank.BankName + " " + bank.BranchName),
+		strings.TrimSpace(bank.AccountType + " " + bank.AccountNumber),
+	}
+	if bank.AccountHolder != "" {
+		lines = append(lines, "口座名義: "+bank.AccountHolder)
+	}
+	boxHeight := lineHeight*float64(len(lines)+1) + 8
+	if w.tmpl.BankNote != "" {
+		boxHeight += lineHeight
+	}
+	w.ensureSpace(boxHeight)
+
+	boxWidth := w.contentWidth() * 0.6
+	w.strokeRect(w.left(), w.y, boxWidth, boxHeight)
+	w.fillAccent(w.left(), w.y, boxWidth, lineHeight)
+	if err := w.setFont(true, sizes.Body); err != nil {
+		return err
+	}
+	w.setTextColor(true)
+	if err := w.text(w.left()+6, w.y, boxWidth-12, lineHeight, "お振込先", gopdf.Left); err != nil {
+		return err
+	}
+	w.setTextColor(false)
+
+	y := w.y + lineHeight + 4
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := w.text(w.left()+6, y, boxWidth-12, lineHeight, line, gopdf.Left); err != nil {
+			return err
+		}
+		y += lineHeight
+	}
+	if w.tmpl.BankNote != "" {
+		if err := w.setFont(false, sizes.Small); err != nil {
+			return err
+		}
+		if err := w.text(w.left()+6, y, boxWidth-12, lineHeight, w.tmpl.BankNote, gopdf.Left); err != nil {
+			return err
+		}
+	}
+	w.y += boxHeight + 12
+	return nil
+}
+
+// drawNotes 備考
+func (w *writer) drawNotes(doc *Document) error {
+	if !w.tmpl.ShowNotes || strings.TrimSpace(doc.Notes) == "" {
+		return nil
+	}
+	sizes := w.tmpl.FontSizes
+	lineHeight := sizes.Body * 1.6
+	boxWidth := w.contentWidth()
+
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(doc.Notes, "\n") {
+		if paragraph == "" {
+			lines = append(lines, "")
+			continue
+		}
+		split, err := w.pdf.SplitText(paragraph, boxWidth-12)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, split...)
+	}
+
+	w.ensureSpace(lineHeight * 2)
+	if err := w.setFont(true, sizes.Body); err != nil {
+		return err
+	}
+	if err := w.text(w.left(), w.y, boxWidth, lineHeight, "備考", gopdf.Left); err != nil {
+		return err
+	}
+	w.y += lineHeight
+	w.hline(w.left(), w.right(), w.y, false)
+	w.y += 4
+
+	if err := w.setFont(false, sizes.Body); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		w.ensureSpace(lineHeight)
+		if err := w.text(w.left()+6, w.y, boxWidth-12, lineHeight, line, gopdf.Left); err != nil {
+			return err
+		}
+		w.y += lineHeight
+	}
+	return nil
+}
+
+// drawFooter 全ページにフッターとページ番号を描画
+func (w *writer) drawFooter() error {
+	if err := w.setFont(false, w.tmpl.FontSizes.Small); err != nil {
+		return err
+	}
+	w.pdf.SetTextColor(120, 120, 120)
+	defer w.setTextColor(false)
+
+	pages := w.pdf.GetNumberOfPages()
+	y := w.height - w.tmpl.Margin - footerHeight + 6
+	for page := 1; page <= pages; page++ {
+		if err := w.pdf.SetPage(page); err != nil {
+			return err
+		}
+		if err := w.text(w.left(), y, w.contentWidth()*0.7, footerHeight, w.tmpl.FooterText, gopdf.Left); err != nil {
+			return err
+		}
+		pageText := fmt.Sprintf("%d / %d", page, pages)
+		if err := w.text(w.right()-60, y, 60, footerHeight, pageText, gopdf.Right); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnAlign 列の配置をgopdfの定数に変換
+func columnAlign(col Column) int {
+	switch col.Align {
+	case "right":
+		return gopdf.Right
+	case "center":
+		return gopdf.Center
+	default:
+		return gopdf.Left
+	}
+}
+
+// formatColumnValue 数値列の表示値
+func formatColumnValue(field string, line Line) string {
+	switch field {
+	case ColumnQuantity:
+		return FormatNumber(line.Quantity)
+	case ColumnUnitPrice:
+		return FormatYen(line.UnitPrice)
+	case ColumnAmount:
+		return FormatYen(line.Amount)
+	}
+	return ""
+}
+
+// formatBillingMonth YYYY-MMを「2006年1月分」形式に整形
+func formatBillingMonth(month string) string {
+	var year, m int
+	if _, err := fmt.Sscanf(month, "%d-%d", &year, &m); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d年%d月分", year, m)
+}
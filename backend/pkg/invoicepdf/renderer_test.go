@@ -0,0 +1,200 @@
+package invoicepdf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFontPath テストで使用するTTFフォント
+// INVOICE_PDF_TEST_FONTで上書き可能。見つからない場合は描画テストをスキップする
+func testFontPath(t *testing.T) string {
+	candidates := []string{
+		os.Getenv("INVOICE_PDF_TEST_FONT"),
+		"/usr/share/fonts/opentype/ipaexfont-gothic/ipaexg.ttf",
+		"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+	}
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	t.Skip("TTFフォントが見つからないためスキップします")
+	return ""
+}
+
+func sampleDocument() *Document {
+	return &Document{
+		InvoiceNumber: "INV-202501-0001",
+		InvoiceDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local),
+		DueDate:       time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local),
+		BillingMonth:  "2025-01",
+		Recipient: Recipient{
+			Name:          "株式会社サンプル",
+			Address:       "東京都千代田区1-1-1",
+			ContactPerson: "山田",
+		},
+		Issuer: Issuer{
+			Name:       "株式会社デュースク",
+			PostalCode: "100-0001",
+			Address:    "東京都千代田区2-2-2",
+			Phone:      "03-0000-0000",
+
+			RegistrationNumber: "T1234567890123",
+		},
+		BankAccount: BankAccount{
+			BankName:      "サンプル銀行",
+			BranchName:    "本店",
+			AccountType:   "普通",
+			AccountNumber: "1234567",
+			AccountHolder: "カ）デュースク",
+		},
+		Lines: []Line{
+			{Description: "システム開発支援（1月分）", Quantity: 1, UnitPrice: 800000, Amount: 800000},
+			{Description: "超過精算 10.5時間", Quantity: 10.5, UnitPrice: 5000, Amount: 52500},
+			{Description: "会議用飲料", Quantity: 2, UnitPrice: 1500, Amount: 3000, ReducedRate: true},
+		},
+		Subtotal:    855500,
+		TaxAmount:   85490,
+		TotalAmount: 940990,
+		TaxSummaries: []TaxSummary{
+			{Label: "10%対象", TaxRate: 10, TaxableAmount: 852500, TaxAmount: 85250},
+			{Label: "8%対象（軽減税率）", TaxRate: 8, TaxableAmount: 3000, TaxAmount: 240},
+		},
+		Notes: "いつもお世話になっております。",
+	}
+}
+
+func TestFormatYen(t *testing.T) {
+	assert.Equal(t, "¥0", FormatYen(0))
+	assert.Equal(t, "¥999", FormatYen(999))
+	assert.Equal(t, "¥1,000", FormatYen(1000))
+	assert.Equal(t, "¥1,234,567", FormatYen(1234567.4))
+	assert.Equal(t, "-¥50,000", FormatYen(-50000))
+}
+
+func TestFormatNumber(t *testing.T) {
+	assert.Equal(t, "1", FormatNumber(1))
+	assert.Equal(t, "10.5", FormatNumber(10.5))
+	assert.Equal(t, "1,600.25", FormatNumber(1600.25))
+	assert.Equal(t, "0.33", FormatNumber(1.0/3.0))
+}
+
+func TestFormatDate(t *testing.T) {
+	assert.Equal(t, "2025年2月28日", FormatDate(time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "", FormatDate(time.Time{}))
+}
+
+func TestParseTemplate(t *testing.T) {
+	t.Run("未指定項目はデフォルトを引き継ぐ", func(t *testing.T) {
+		tmpl, err := ParseTemplate([]byte(`{"title":"御請求書","show_bank_info":false}`))
+		require.NoError(t, err)
+		assert.Equal(t, "御請求書", tmpl.Title)
+		assert.False(t, tmpl.ShowBankInfo)
+		assert.Equal(t, "A4", tmpl.PageSize)
+		assert.Len(t, tmpl.Columns, 4)
+	})
+
+	t.Run("金額列がない場合はエラー", func(t *testing.T) {
+		_, err := ParseTemplate([]byte(`{"columns":[{"field":"description","label":"品目","width":1}]}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("未対応の用紙サイズはエラー", func(t *testing.T) {
+		_, err := ParseTemplate([]byte(`{"page_size":"A3"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("色指定が不正な場合はエラー", func(t *testing.T) {
+		_, err := ParseTemplate([]byte(`{"accent_color":"blue"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client_a.json"), []byte(`{"title":"ご請求書","page_size":"B5"}`), 0o644))
+
+	templates, err := LoadTemplates(dir)
+	require.NoError(t, err)
+	assert.Contains(t, templates, DefaultTemplateName)
+	require.Contains(t, templates, "client_a")
+	assert.Equal(t, "B5", templates["client_a"].PageSize)
+
+	templates, err = LoadTemplates(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Len(t, templates, 1)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	font, err := os.ReadFile(testFontPath(t))
+	require.NoError(t, err)
+
+	compact, err := ParseTemplate([]byte(`{"page_size":"B5","seal_labels":[],"footer_text":"株式会社デュースク"}`))
+	require.NoError(t, err)
+	renderer, err := NewRenderer(font, nil, map[string]*Template{"compact": compact})
+	require.NoError(t, err)
+
+	t.Run("デフォルトテンプレート", func(t *testing.T) {
+		data, err := renderer.Render(sampleDocument(), "")
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+		// フォントが埋め込まれていること
+		assert.Contains(t, string(data), "/FontFile2")
+	})
+
+	t.Run("クライアント別テンプレート", func(t *testing.T) {
+		data, err := renderer.Render(sampleDocument(), "compact")
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	})
+
+	t.Run("明細が多い場合は改ページする", func(t *testing.T) {
+		doc := sampleDocument()
+		for i := 0; i < 80; i++ {
+			doc.Lines = append(doc.Lines, Line{Description: "追加作業", Quantity: 1, UnitPrice: 1000, Amount: 1000})
+		}
+		data, err := renderer.Render(doc, DefaultTemplateName)
+		require.NoError(t, err)
+		match := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(string(data))
+		require.Len(t, match, 2)
+		pages, err := strconv.Atoi(match[1])
+		require.NoError(t, err)
+		assert.Greater(t, pages, 1)
+	})
+
+	t.Run("赤伝（タイトル指定・支払期限なし・マイナス金額）", func(t *testing.T) {
+		doc := sampleDocument()
+		doc.Title = "請求書（赤伝）"
+		doc.DueDate = time.Time{}
+		doc.BankAccount = BankAccount{}
+		for i := range doc.Lines {
+			doc.Lines[i].UnitPrice = -doc.Lines[i].UnitPrice
+			doc.Lines[i].Amount = -doc.Lines[i].Amount
+		}
+		doc.Subtotal, doc.TaxAmount, doc.TotalAmount = -doc.Subtotal, -doc.TaxAmount, -doc.TotalAmount
+		data, err := renderer.Render(doc, DefaultTemplateName)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	})
+
+	t.Run("未登録テンプレートはデフォルトを使用", func(t *testing.T) {
+		assert.Equal(t, DefaultTemplateName, renderer.Template("unknown").Name)
+		assert.False(t, renderer.HasTemplate("unknown"))
+	})
+}
+
+func TestNewRenderer_RequiresFont(t *testing.T) {
+	_, err := NewRenderer(nil, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package invoicepdf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultTemplateName デフォルトテンプレート名
+const DefaultTemplateName = "default"
+
+// 明細列のフィールド名
+const (
+	ColumnDescription = "description"
+	ColumnQuantity    = "quantity"
+	ColumnUnitPrice   = "unit_price"
+	ColumnAmount      = "amount"
+)
+
+// Template 請求書レイアウトテンプレート
+type Template struct {
+	Name        string    `json:"name"`
+	PageSize    string    `json:"page_size"`    // A4, B5, Letter
+	Margin      float64   `json:"margin"`       // 余白（pt）
+	Title       string    `json:"title"`        // 帳票タイトル
+	Honorific   string    `json:"honorific"`    // 宛名の敬称
+	Greeting    string    `json:"greeting"`     // 宛名下の挨拶文
+	AccentColor string    `json:"accent_color"` // 見出し色（#RRGGBB）
+	FontSizes   FontSizes `json:"font_sizes"`
+	Columns     []Column  `json:"columns"`
+
+	ShowCompanySeal bool     `json:"show_company_seal"` // 社印欄を表示するか
+	SealLabels      []string `json:"seal_labels"`       // 押印欄のラベル（例: 承認, 担当）
+	ShowBankInfo    bool     `json:"show_bank_info"`    // 振込先を表示するか
+	BankNote        string   `json:"bank_note"`         // 振込先の注記
+	ShowNotes       bool     `json:"show_notes"`        // 備考を表示するか
+	FooterText      string   `json:"footer_text"`
+}
+
+// FontSizes テンプレートのフォントサイズ
+type FontSizes struct {
+	Title   float64 `json:"title"`
+	Heading float64 `json:"heading"`
+	Body    float64 `json:"body"`
+	Small   float64 `json:"small"`
+}
+
+// Column 明細表の列定義
+type Column struct {
+	Field string  `json:"field"` // description, quantity, unit_price, amount
+	Label string  `json:"label"`
+	Width float64 `json:"width"` // 表全体に対する比率
+	Align string  `json:"align"` // left, center, right
+}
+
+// DefaultTemplate 標準の請求書テンプレートを返す
+func DefaultTemplate() *Template {
+	return &Template{
+		Name:        DefaultTemplateName,
+		PageSize:    "A4",
+		Margin:      40,
+		Title:       "請求書",
+		Honorific:   "御中",
+		Greeting:    "下記の通りご請求申し上げます。",
+		AccentColor: "#2F4F6F",
+		FontSizes: FontSizes{
+			Title:   22,
+			Heading: 12,
+			Body:    9,
+			Small:   8,
+		},
+		Columns: []Column{
+			{Field: ColumnDescription, Label: "品目", Width: 0.52, Align: "left"},
+			{Field: ColumnQuantity, Label: "数量", Width: 0.12, Align: "right"},
+			{Field: ColumnUnitPrice, Label: "単価", Width: 0.18, Align: "right"},
+			{Field: ColumnAmount, Label: "金額", Width: 0.18, Align: "right"},
+		},
+		ShowCompanySeal: true,
+		SealLabels:      []string{"承認", "担当"},
+		ShowBankInfo:    true,
+		BankNote:        "恐れ入りますが、振込手数料は貴社にてご負担ください。",
+		ShowNotes:       true,
+	}
+}
+
+// ParseTemplate JSONからテンプレートを生成する
+// 未指定の項目はデフォルトテンプレートの値を引き継ぐ
+func ParseTemplate(data []byte) (*Template, error) {
+	tmpl := DefaultTemplate()
+	if err := json.Unmarshal(data, tmpl); err != nil {
+		return nil, fmt.Errorf("テンプレートの解析に失敗しました: %w", err)
+	}
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// LoadTemplates ディレクトリ内の*.jsonをテンプレートとして読み込む
+// ディレクトリが存在しない場合はデフォルトテンプレートのみを返す
+func LoadTemplates(dir string) (map[string]*Template, error) {
+	templates := map[string]*Template{
+		DefaultTemplateName: DefaultTemplate(),
+	}
+	if dir == "" {
+		return templates, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("テンプレートの読み込みに失敗しました (%s): %w", file, err)
+		}
+		tmpl, err := ParseTemplate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		// ファイル名をテンプレート名とする
+		tmpl.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		templates[tmpl.Name] = tmpl
+	}
+	return templates, nil
+}
+
+// Validate テンプレートの妥当性を検証
+func (t *Template) Validate() error {
+	if _, ok := pageSizes[strings.ToUpper(t.PageSize)]; !ok {
+		return fmt.Errorf("未対応の用紙サイズです: %s", t.PageSize)
+	}
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("明細列が定義されていません")
+	}
+	hasAmount := false
+	for _, col := range t.Columns {
+		switch col.Field {
+		case ColumnDescription, ColumnQuantity, ColumnUnitPrice:
+		case ColumnAmount:
+			hasAmount = true
+		default:
+			return fmt.Errorf("未対応の明細列です: %s", col.Field)
+		}
+		if col.Width <= 0 {
+			return fmt.Errorf("明細列の幅が不正です: %s", col.Field)
+		}
+	}
+	if !hasAmount {
+		return fmt.Errorf("金額列は必須です")
+	}
+	if _, _, _, err := parseHexColor(t.AccentColor); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseHexColor #RRGGBB形式の色をRGBに変換
+func parseHexColor(s string) (uint8, uint8, uint8, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return 0, 0, 0, fmt.Errorf("色の指定が不正です: %s", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("色の指定が不正です: %s", s)
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
@@ -0,0 +1,201 @@
+package journal
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Format 仕訳の出力形式
+type Format string
+
+const (
+	// FormatFreee freee会計の仕訳インポート用CSV（UTF-8）
+	FormatFreee Format = "freee"
+	// FormatMoneyForward マネーフォワード クラウド会計の仕訳帳インポート用CSV（Shift_JIS）
+	FormatMoneyForward Format = "moneyforward"
+	// FormatYayoi 弥生会計のインポート形式（ヘッダーなし、Shift_JIS）
+	FormatYayoi Format = "yayoi"
+)
+
+var (
+	// ErrUnsupportedFormat 対応していない出力形式
+	ErrUnsupportedFormat = errors.New("対応していない出力形式です")
+	// ErrUnbalancedEntry 金額・勘定科目が揃っていない仕訳
+	ErrUnbalancedEntry = errors.New("仕訳の金額または勘定科目が正しくありません")
+)
+
+// Entry 仕訳1行（借方・貸方が同額の単一仕訳）
+type Entry struct {
+	SlipNumber        int
+	Date              time.Time
+	DebitAccount      string
+	DebitSubAccount   string
+	DebitTaxCategory  string
+	DebitTaxAmount    int64 // 0の場合は会計ソフト側で税区分から計算する
+	CreditAccount     string
+	CreditSubAccount  string
+	CreditTaxCategory string
+	CreditTaxAmount   int64
+	Amount            int64 // 円単位（常に正の値）
+	PartnerName       string
+	Description       string
+}
+
+// ParseFormat 出力形式の文字列をパース
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatFreee, FormatMoneyForward, FormatYayoi:
+		return f, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// FileExtension 出力ファイルの拡張子
+func (f Format) FileExtension() string {
+	if f == FormatYayoi {
+		return "txt"
+	}
+	return "csv"
+}
+
+// ContentType 出力ファイルのContent-Type
+func (f Format) ContentType() string {
+	if f == FormatFreee {
+		return "text/csv; charset=utf-8"
+	}
+	return "text/csv; charset=Shift_JIS"
+}
+
+// Write 仕訳を指定形式で書き出す
+func Write(w io.Writer, format Format, entries []Entry) error {
+	for i, e := range entries {
+		if e.Amount <= 0 || e.DebitAccount == "" || e.CreditAccount == "" {
+			return fmt.Errorf("%w（%d行目）", ErrUnbalancedEntry, i+1)
+		}
+	}
+
+	var header []string
+	var record func(Entry) []string
+	var encoder io.WriteCloser
+	switch format {
+	case FormatFreee:
+		header, record = freeeHeader, freeeRecord
+	case FormatMoneyForward:
+		header, record = moneyForwardHeader, moneyForwardRecord
+		encoder = shiftJISWriter(w)
+	case FormatYayoi:
+		record = yayoiRecord
+		encoder = shiftJISWriter(w)
+	default:
+		return ErrUnsupportedFormat
+	}
+	if encoder != nil {
+		w = encoder
+	}
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if header != nil {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		if err := cw.Write(record(e)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	if encoder != nil {
+		// 変換途中のバイト列を書き出す（元のライターは閉じない）
+		return encoder.Close()
+	}
+	return nil
+}
+
+// freeeHeader freee会計の仕訳インポート用CSVの見出し
+var freeeHeader = []string{
+	"日付", "伝票番号",
+	"借方勘定科目", "借方補助科目", "借方取引先", "借方税区分", "借方金額", "借方税額",
+	"貸方勘定科目", "貸方補助科目", "貸方取引先", "貸方税区分", "貸方金額", "貸方税額",
+	"摘要",
+}
+
+// freeeRecord freee会計の仕訳インポート用CSVの1行
+func freeeRecord(e Entry) []string {
+	return []string{
+		e.Date.Format("2006/01/02"), strconv.Itoa(e.SlipNumber),
+		e.DebitAccount, e.DebitSubAccount, e.PartnerName, e.DebitTaxCategory, amount(e.Amount), taxAmount(e.DebitTaxAmount),
+		e.CreditAccount, e.CreditSubAccount, e.PartnerName, e.CreditTaxCategory, amount(e.Amount), taxAmount(e.CreditTaxAmount),
+		e.Description,
+	}
+}
+
+// moneyForwardHeader マネーフォワード クラウド会計の仕訳帳インポート用CSVの見出し
+var moneyForwardHeader = []string{
+	"取引No", "取引日",
+	"借方勘定科目", "借方補助科目", "借方部門", "借方取引先", "借方税区分", "借方インボイス", "借方金額(円)", "借方税額",
+	"貸方勘定科目", "貸方補助科目", "貸方部門", "貸方取引先", "貸方税区分", "貸方インボイス", "貸方金額(円)", "貸方税額",
+	"摘要", "仕訳メモ", "タグ", "MF仕訳タイプ", "決算整理仕訳",
+}
+
+// moneyForwardRecord マネーフォワード クラウド会計の仕訳帳インポート用CSVの1行
+func moneyForwardRecord(e Entry) []string {
+	return []string{
+		strconv.Itoa(e.SlipNumber), e.Date.Format("2006/01/02"),
+		e.DebitAccount, e.DebitSubAccount, "", e.PartnerName, e.DebitTaxCategory, "", amount(e.Amount), taxAmount(e.DebitTaxAmount),
+		e.CreditAccount, e.CreditSubAccount, "", e.PartnerName, e.CreditTaxCategory, "", amount(e.Amount), taxAmount(e.CreditTaxAmount),
+		e.Description, "", "", "", "",
+	}
+}
+
+// yayoiRecord 弥生会計のインポート形式の1行（25項目）
+func yayoiRecord(e Entry) []string {
+	return []string{
+		"2000", // 識別フラグ（仕訳データ・1行の伝票）
+		strconv.Itoa(e.SlipNumber),
+		"", // 決算
+		e.Date.Format("2006/01/02"),
+		e.DebitAccount, e.DebitSubAccount, "", e.DebitTaxCategory, amount(e.Amount), taxAmount(e.DebitTaxAmount),
+		e.CreditAccount, e.CreditSubAccount, "", e.CreditTaxCategory, amount(e.Amount), taxAmount(e.CreditTaxAmount),
+		e.Description,
+		"",   // 番号
+		"",   // 期日
+		"0",  // タイプ（仕訳）
+		"",   // 生成元
+		"",   // 仕訳メモ
+		"0",  // 付箋1
+		"0",  // 付箋2
+		"no", // 調整
+	}
+}
+
+// amount 金額の文字列
+func amount(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+// taxAmount 税額の文字列（0は空欄にして会計ソフト側で計算させる）
+func taxAmount(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// shiftJISWriter Shift_JISで書き出すライター（変換できない文字は置き換える）
+func shiftJISWriter(w io.Writer) io.WriteCloser {
+	return transform.NewWriter(w, encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder()))
+}
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func sampleEntries() []Entry {
+	return []Entry{
+		{
+			SlipNumber:        1,
+			Date:              time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+			DebitAccount:      "売掛金",
+			CreditAccount:     "売上高",
+			CreditTaxCategory: "課税売上10%",
+			CreditTaxAmount:   80000,
+			Amount:            880000,
+			PartnerName:       "株式会社サンプル",
+			Description:       "2025年4月分 請求 INV-202504-001",
+		},
+		{
+			SlipNumber:       2,
+			Date:             time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC),
+			DebitAccount:     "旅費交通費",
+			DebitTaxCategory: "課対仕入10%",
+			CreditAccount:    "未払金",
+			CreditSubAccount: "山田 太郎",
+			Amount:           1280,
+			Description:      "客先訪問 交通費",
+		},
+	}
+}
+
+func TestWriteFreee(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatFreee, sampleEntries()))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "日付,伝票番号,借方勘定科目,借方補助科目,借方取引先,借方税区分,借方金額,借方税額,貸方勘定科目,貸方補助科目,貸方取引先,貸方税区分,貸方金額,貸方税額,摘要", lines[0])
+	assert.Equal(t, "2025/04/30,1,売掛金,,株式会社サンプル,,880000,,売上高,,株式会社サンプル,課税売上10%,880000,80000,2025年4月分 請求 INV-202504-001", lines[1])
+	assert.Equal(t, "2025/04/10,2,旅費交通費,,,課対仕入10%,1280,,未払金,山田 太郎,,,1280,,客先訪問 交通費", lines[2])
+}
+
+func TestWriteYayoi(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatYayoi, sampleEntries()[1:]))
+
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "2000,2,,2025/04/10,旅費交通費,,,課対仕入10%,1280,,未払金,山田 太郎,,,1280,,客先訪問 交通費,,,0,,,0,0,no\r\n", string(decoded))
+}
+
+func TestWriteMoneyForward(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatMoneyForward, sampleEntries()[:1]))
+
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(buf.Bytes())
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(decoded), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "取引No,取引日,借方勘定科目"))
+	assert.Equal(t, "1,2025/04/30,売掛金,,,株式会社サンプル,,,880000,,売上高,,,株式会社サンプル,課税売上10%,,880000,80000,2025年4月分 請求 INV-202504-001,,,,", lines[1])
+}
+
+func TestWriteRejectsInvalidEntries(t *testing.T) {
+	entries := sampleEntries()
+	entries[1].Amount = 0
+	err := Write(&bytes.Buffer{}, FormatFreee, entries)
+	assert.ErrorIs(t, err, ErrUnbalancedEntry)
+
+	_, err = ParseFormat("csv")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,280 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount 円建ての金額
+// DBの decimal(10,2) に合わせて 1/100 円（銭）単位の整数で保持し、浮動小数点の誤差による1円ずれを防ぐ
+type Amount int64
+
+// scale 1円あたりの内部単位数
+const scale = 100
+
+// 端数処理の単位
+const (
+	unitSen Amount = 1
+	unitYen Amount = scale
+)
+
+// Zero 0円
+const Zero Amount = 0
+
+// Rounding 端数処理の方法
+type Rounding int
+
+const (
+	// RoundingNone 端数処理なし（銭単位で四捨五入して保持）
+	RoundingNone Rounding = iota
+	// RoundingFloor 切り捨て（負の方向）
+	RoundingFloor
+	// RoundingCeil 切り上げ（正の方向）
+	RoundingCeil
+	// RoundingHalfUp 四捨五入（0.5円は絶対値の大きい方へ）
+	RoundingHalfUp
+	// RoundingTruncate 0方向への切り捨て（消費税の端数処理。赤伝でも元の請求書と絶対値が一致する）
+	RoundingTruncate
+)
+
+// Yen 円単位の整数から金額を作成
+func Yen(yen int64) Amount {
+	return Amount(yen * scale)
+}
+
+// FromFloat 浮動小数点数から金額を作成（銭未満は四捨五入）
+// 10進表記に直してから変換するため、0.1 のような値も誤差なく扱える
+func FromFloat(f float64) Amount {
+	return roundRat(decimalRat(f), unitSen, RoundingHalfUp)
+}
+
+// Parse 10進数の文字列から金額を作成（銭未満は四捨五入）
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Zero, fmt.Errorf("金額の形式が正しくありません: %q", s)
+	}
+	return roundRat(r, unitSen, RoundingHalfUp), nil
+}
+
+// Float64 浮動小数点数に変換（表示・外部ライブラリとの受け渡し用）
+func (a Amount) Float64() float64 {
+	return float64(a) / scale
+}
+
+// Yen 円未満を0方向に切り捨てた円単位の整数
+func (a Amount) Yen() int64 {
+	return int64(a) / scale
+}
+
+// IsZero 0円かチェック
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+// Sign 符号（-1, 0, 1）
+func (a Amount) Sign() int {
+	switch {
+	case a < 0:
+		return -1
+	case a > 0:
+		return 1
+	}
+	return 0
+}
+
+// Add 加算
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+// Sub 減算
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+// Neg 符号を反転
+func (a Amount) Neg() Amount {
+	return -a
+}
+
+// Abs 絶対値
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Round 円未満を端数処理する
+func (a Amount) Round(mode Rounding) Amount {
+	if mode == RoundingNone {
+		return a
+	}
+	return roundRat(a.rat(), unitYen, mode)
+}
+
+// Mul 数量・倍率を掛けて端数処理する（円未満）
+func (a Amount) Mul(factor float64, mode Rounding) Amount {
+	return a.MulDiv(factor, 1, mode)
+}
+
+// MulDiv a × mul ÷ div を計算して端数処理する（円未満）
+// 時間単価のような中間値を丸めずに計算するため、月額 × 実績時間 ÷ 基準時間 のように1回で計算する
+func (a Amount) MulDiv(mul, div float64, mode Rounding) Amount {
+	if div == 0 {
+		return Zero
+	}
+	r := a.rat()
+	r.Mul(r, decimalRat(mul))
+	r.Quo(r, decimalRat(div))
+	if mode == RoundingNone {
+		return roundRat(r, unitSen, RoundingHalfUp)
+	}
+	return roundRat(r, unitYen, mode)
+}
+
+// Percent 税率などの百分率を掛けて端数処理する（円未満）
+func (a Amount) Percent(rate float64, mode Rounding) Amount {
+	return a.MulDiv(rate, 100, mode)
+}
+
+// Sum 金額を合計
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+// String 10進表記（例: 1234.5, -30）
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	whole, frac := v/scale, v%scale
+	if frac == 0 {
+		return sign + strconv.FormatInt(whole, 10)
+	}
+	return strings.TrimRight(fmt.Sprintf("%s%d.%02d", sign, whole, frac), "0")
+}
+
+// MarshalJSON JSONでは従来の float64 と同じ数値として出力する
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON 数値と文字列のどちらの表記も受け付ける
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		*a = Zero
+		return nil
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Value decimal列へ10進表記で保存する
+func (a Amount) Value() (driver.Value, error) {
+	v := int64(a)
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/scale, v%scale), nil
+}
+
+// Scan decimal列の値を読み込む
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Zero
+	case int64:
+		*a = Yen(v)
+	case float64:
+		*a = FromFloat(v)
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	default:
+		return fmt.Errorf("金額に変換できない型です: %T", src)
+	}
+	return nil
+}
+
+// scanString 文字列表記の値を読み込む
+func (a *Amount) scanString(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// rat 円単位の有理数に変換
+func (a Amount) rat() *big.Rat {
+	return big.NewRat(int64(a), scale)
+}
+
+// decimalRat 浮動小数点数を10進表記どおりの有理数に変換（NaN・無限大は0）
+func decimalRat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// roundRat 円単位の有理数 r を unit（銭または円）の倍数に端数処理する
+func roundRat(r *big.Rat, unit Amount, mode Rounding) Amount {
+	// r × scale ÷ unit を整数に丸めてから unit を掛ける
+	x := new(big.Rat).Mul(r, big.NewRat(scale, int64(unit)))
+	num, den := x.Num(), x.Denom()
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	if m.Sign() == 0 {
+		return Amount(q.Int64()) * unit
+	}
+
+	negative := x.Sign() < 0
+	switch mode {
+	case RoundingFloor:
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		}
+	case RoundingCeil:
+		if !negative {
+			q.Add(q, big.NewInt(1))
+		}
+	case RoundingHalfUp, RoundingNone:
+		// 余りの絶対値 × 2 が分母以上なら絶対値の大きい方へ
+		twice := new(big.Int).Abs(m)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(den) >= 0 {
+			if negative {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	case RoundingTruncate:
+		// QuoRem は0方向に切り捨てる
+	}
+	return Amount(q.Int64()) * unit
+}
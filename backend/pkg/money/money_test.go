@@ -0,0 +1,114 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFloat(t *testing.T) {
+	assert.Equal(t, Yen(1000), FromFloat(1000))
+	assert.Equal(t, Amount(10), FromFloat(0.1))
+	assert.Equal(t, Amount(12346), FromFloat(123.455))
+	assert.Equal(t, Amount(-12346), FromFloat(-123.455))
+	// 0.1 + 0.2 の浮動小数点誤差が残らない
+	assert.Equal(t, Amount(30), FromFloat(0.1+0.2))
+}
+
+func TestParse(t *testing.T) {
+	v, err := Parse("527777.78")
+	require.NoError(t, err)
+	assert.Equal(t, Amount(52777778), v)
+
+	v, err = Parse("-1200.5")
+	require.NoError(t, err)
+	assert.Equal(t, Amount(-120050), v)
+
+	_, err = Parse("1,000")
+	assert.Error(t, err)
+}
+
+func TestAmountRound(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		mode   Rounding
+		want   Amount
+	}{
+		{"切り捨て", FromFloat(123.78), RoundingFloor, Yen(123)},
+		{"切り捨て（負）", FromFloat(-123.78), RoundingFloor, Yen(-124)},
+		{"切り上げ", FromFloat(123.12), RoundingCeil, Yen(124)},
+		{"四捨五入（切り捨て側）", FromFloat(123.49), RoundingHalfUp, Yen(123)},
+		{"四捨五入（0.5円）", FromFloat(123.5), RoundingHalfUp, Yen(124)},
+		{"四捨五入（負の0.5円）", FromFloat(-123.5), RoundingHalfUp, Yen(-124)},
+		{"0方向への切り捨て（負）", FromFloat(-123.78), RoundingTruncate, Yen(-123)},
+		{"端数処理なし", FromFloat(123.45), RoundingNone, FromFloat(123.45)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.Round(tt.mode))
+		})
+	}
+}
+
+func TestAmountMulDiv(t *testing.T) {
+	monthly := Yen(600000)
+
+	// 時間単価を丸めてから掛けると 600000/140=4285.71 × 130 = 557142.30 となり1円ずれる
+	assert.Equal(t, Yen(557143), monthly.MulDiv(130, 140, RoundingHalfUp))
+	assert.Equal(t, Yen(557142), monthly.MulDiv(130, 140, RoundingFloor))
+	assert.Equal(t, FromFloat(557142.86), monthly.MulDiv(130, 140, RoundingNone))
+
+	// 小数の時間も10進表記どおりに計算する
+	assert.Equal(t, Yen(300), Yen(1000).Mul(0.3, RoundingFloor))
+	assert.Equal(t, Zero, monthly.MulDiv(130, 0, RoundingHalfUp))
+}
+
+func TestAmountPercent(t *testing.T) {
+	assert.Equal(t, Yen(240), Yen(3003).Percent(8, RoundingTruncate))
+	assert.Equal(t, Yen(-240), Yen(-3003).Percent(8, RoundingTruncate))
+	assert.Equal(t, Yen(50000), Yen(500000).Percent(10, RoundingTruncate))
+}
+
+func TestAmountString(t *testing.T) {
+	assert.Equal(t, "1000", Yen(1000).String())
+	assert.Equal(t, "1234.5", FromFloat(1234.5).String())
+	assert.Equal(t, "-0.05", Amount(-5).String())
+	assert.Equal(t, "0", Zero.String())
+}
+
+func TestAmountJSON(t *testing.T) {
+	type payload struct {
+		Amount Amount  `json:"amount"`
+		Price  *Amount `json:"price,omitempty"`
+	}
+
+	data, err := json.Marshal(payload{Amount: FromFloat(527777.78)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":527777.78}`, string(data))
+
+	var decoded payload
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":1200.5,"price":"300"}`), &decoded))
+	assert.Equal(t, FromFloat(1200.5), decoded.Amount)
+	require.NotNil(t, decoded.Price)
+	assert.Equal(t, Yen(300), *decoded.Price)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":true}`), &decoded))
+}
+
+func TestAmountSQL(t *testing.T) {
+	value, err := FromFloat(-1234.5).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-1234.50", value)
+
+	var a Amount
+	require.NoError(t, a.Scan([]byte("800000.00")))
+	assert.Equal(t, Yen(800000), a)
+	require.NoError(t, a.Scan(int64(42)))
+	assert.Equal(t, Yen(42), a)
+	require.NoError(t, a.Scan(nil))
+	assert.Equal(t, Zero, a)
+	assert.Error(t, a.Scan(true))
+}
@@ -0,0 +1,117 @@
+// Package receipthash 領収書ファイルの重複検出用ハッシュ
+//
+// 同一ファイルの検出にはファイル内容のSHA-256、撮り直しや再圧縮した画像の検出には
+// 差分ハッシュ（dHash）による知覚ハッシュを使う。
+package receipthash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // GIF形式の領収書画像のデコード
+	_ "image/jpeg" // JPEG形式の領収書画像のデコード
+	_ "image/png"  // PNG形式の領収書画像のデコード
+	"math/bits"
+	"strconv"
+)
+
+// SimilarThreshold 同じ領収書の画像とみなす知覚ハッシュのハミング距離（64ビット中）
+const SimilarThreshold = 8
+
+// hashWidth・hashHeight 差分ハッシュの計算に使う縮小画像のサイズ（隣り合う画素の比較で64ビットになる）
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// luminanceTolerance 明るさが同じとみなす差（最大輝度の2%）
+// 余白のように明るさがほぼ同じ画素どうしの比較が、再圧縮や縮小の誤差で反転しないようにする
+const luminanceTolerance = 0xffff * 0.02
+
+// ErrUnsupportedImage 知覚ハッシュを計算できないファイル（PDFなど）
+var ErrUnsupportedImage = errors.New("知覚ハッシュに対応していないファイル形式です")
+
+// ContentHash ファイル内容のSHA-256（16進数）
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// PerceptualHash 画像の差分ハッシュ（16桁の16進数）
+// 画像をグレースケールの9x8に縮小し、横に隣り合う画素で右の方が明るい場合を1とする
+func PerceptualHash(content []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return "", ErrUnsupportedImage
+		}
+		return "", fmt.Errorf("画像のデコードに失敗しました: %w", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return "", ErrUnsupportedImage
+	}
+
+	var gray [hashHeight][hashWidth]float64
+	for y := 0; y < hashHeight; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/hashHeight
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/hashHeight
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < hashWidth; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/hashWidth
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/hashWidth
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			gray[y][x] = averageLuminance(img, x0, y0, x1, y1)
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if gray[y][x+1]-gray[y][x] > luminanceTolerance {
+				hash |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", hash), nil
+}
+
+// Distance 2つの知覚ハッシュのハミング距離
+func Distance(a, b string) (int, error) {
+	ha, err := strconv.ParseUint(a, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("知覚ハッシュの形式が不正です: %s", a)
+	}
+	hb, err := strconv.ParseUint(b, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("知覚ハッシュの形式が不正です: %s", b)
+	}
+	return bits.OnesCount64(ha ^ hb), nil
+}
+
+// IsSimilar 2つの知覚ハッシュが同じ領収書の画像とみなせるかチェック
+func IsSimilar(a, b string) bool {
+	distance, err := Distance(a, b)
+	return err == nil && distance <= SimilarThreshold
+}
+
+// averageLuminance 範囲内の画素の平均輝度（ITU-R BT.601）
+func averageLuminance(img image.Image, x0, y0, x1, y1 int) float64 {
+	var sum float64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+		}
+	}
+	return sum / float64((x1-x0)*(y1-y0))
+}
@@ -0,0 +1,87 @@
+package receipthash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiptImage テスト用の領収書風の画像（明るい背景に濃い帯）
+func receiptImage(width, height int, stripes []int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 240, G: 240, B: 235, A: 255})
+		}
+	}
+	for _, top := range stripes {
+		for y := top * height / 100; y < (top+4)*height/100; y++ {
+			for x := width / 10; x < width*(4+top%5)/10; x++ {
+				img.Set(x, y, color.RGBA{R: 30, G: 30, B: 30, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image, quality int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}))
+	return buf.Bytes()
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Equal(t, ContentHash([]byte("receipt")), ContentHash([]byte("receipt")))
+	assert.NotEqual(t, ContentHash([]byte("receipt")), ContentHash([]byte("receipt ")))
+	assert.Len(t, ContentHash(nil), 64)
+}
+
+func TestPerceptualHash(t *testing.T) {
+	original := receiptImage(600, 900, []int{10, 22, 35, 61, 80})
+	resized := receiptImage(300, 450, []int{10, 22, 35, 61, 80})
+	other := receiptImage(600, 900, []int{5, 40, 47, 52, 90})
+
+	originalHash, err := PerceptualHash(encodePNG(t, original))
+	require.NoError(t, err)
+	assert.Len(t, originalHash, 16)
+
+	// 再圧縮・縮小した同じ領収書は類似と判定する
+	recompressedHash, err := PerceptualHash(encodeJPEG(t, original, 60))
+	require.NoError(t, err)
+	assert.True(t, IsSimilar(originalHash, recompressedHash))
+
+	resizedHash, err := PerceptualHash(encodeJPEG(t, resized, 80))
+	require.NoError(t, err)
+	assert.True(t, IsSimilar(originalHash, resizedHash))
+
+	// 別の領収書は類似と判定しない
+	otherHash, err := PerceptualHash(encodePNG(t, other))
+	require.NoError(t, err)
+	assert.False(t, IsSimilar(originalHash, otherHash))
+
+	// 画像以外は知覚ハッシュを計算しない
+	_, err = PerceptualHash([]byte("%PDF-1.4 receipt"))
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestDistance(t *testing.T) {
+	distance, err := Distance("0000000000000000", "000000000000000f")
+	require.NoError(t, err)
+	assert.Equal(t, 4, distance)
+
+	_, err = Distance("not-a-hash", "0000000000000000")
+	assert.Error(t, err)
+	assert.False(t, IsSimilar("not-a-hash", "0000000000000000"))
+}
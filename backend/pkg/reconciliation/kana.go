@@ -0,0 +1,89 @@
+package reconciliation
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// legalFormKana 法人格の読み（振込依頼人名から除去する）
+var legalFormKana = []string{
+	"カブシキガイシヤ",
+	"ユウゲンガイシヤ",
+	"ゴウドウガイシヤ",
+	"ゴウシガイシヤ",
+	"ゴウメイガイシヤ",
+	"イツパンシヤダンホウジン",
+	"イツパンザイダンホウジン",
+	"シヤダンホウジン",
+	"ザイダンホウジン",
+	"トクテイヒエイリカツドウホウジン",
+}
+
+// legalFormAbbreviations 全銀の法人略語（カ）、（ユ）など
+var legalFormAbbreviations = []string{
+	"(カ)", "(ユ)", "(ド)", "(シ)", "(メ)", "(ザイ)", "(シヤ)", "(トクヒ)", "(イ)",
+	"カ)", "ユ)", "ド)", "シ)", "メ)", "(カ", "(ユ", "(ド",
+}
+
+// smallKana 小書き仮名と対応する大書き仮名（全銀の振込依頼人名は大書きで表記される）
+var smallKana = strings.NewReplacer(
+	"ァ", "ア", "ィ", "イ", "ゥ", "ウ", "ェ", "エ", "ォ", "オ",
+	"ッ", "ツ", "ャ", "ヤ", "ュ", "ユ", "ョ", "ヨ", "ヮ", "ワ",
+	"ヵ", "カ", "ヶ", "ケ",
+)
+
+// NormalizeKana 振込依頼人名と取引先名を照合できる形に正規化
+// 半角カナ・ひらがなを全角カタカナに揃え、小書き仮名・法人格・記号・空白を除去する
+func NormalizeKana(s string) string {
+	// 半角カナの濁点結合と英数字の半角化
+	s = norm.NFKC.String(s)
+	s = toKatakana(s)
+	s = strings.ToUpper(s)
+	s = smallKana.Replace(s)
+
+	for _, abbr := range legalFormAbbreviations {
+		s = strings.ReplaceAll(s, abbr, "")
+	}
+	compact := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r), r == 'ー', r == '-', r == '‐', r == '・', r == '.', r == ',', r == '(', r == ')', r == '「', r == '」', r == '/':
+			return -1
+		}
+		return r
+	}, s)
+	for _, form := range legalFormKana {
+		compact = strings.ReplaceAll(compact, form, "")
+	}
+	return compact
+}
+
+// toKatakana ひらがなを全角カタカナに変換
+func toKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
+
+// NameMatches 振込依頼人名と取引先名が一致するかチェック
+// 振込依頼人名は48文字で切り詰められたり、前後に依頼人コードが付くことがあるため部分一致で判定する
+func NameMatches(payer, clientKana string) bool {
+	p := NormalizeKana(payer)
+	c := NormalizeKana(clientKana)
+	if p == "" || c == "" {
+		return false
+	}
+	if p == c {
+		return true
+	}
+	// 短すぎる名前の部分一致は誤判定が多いため対象外
+	const minPartial = 3
+	if len([]rune(p)) < minPartial || len([]rune(c)) < minPartial {
+		return false
+	}
+	return strings.Contains(p, c) || strings.Contains(c, p)
+}
@@ -0,0 +1,314 @@
+package reconciliation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MatchType 消込の一致種別
+type MatchType string
+
+const (
+	// MatchTypeExact 請求額と入金額が一致
+	MatchTypeExact MatchType = "exact"
+	// MatchTypeFeeShortfall 振込手数料が差し引かれた入金
+	MatchTypeFeeShortfall MatchType = "fee_shortfall"
+	// MatchTypeCombined 複数の請求書をまとめた入金
+	MatchTypeCombined MatchType = "combined"
+	// MatchTypePartial 請求額に満たない一部入金
+	MatchTypePartial MatchType = "partial"
+	// MatchTypeOverpayment 請求額を超える過入金
+	MatchTypeOverpayment MatchType = "overpayment"
+	// MatchTypeAmountOnly 金額のみ一致（振込依頼人名が一致しない）
+	MatchTypeAmountOnly MatchType = "amount_only"
+	// MatchTypeNone 該当なし
+	MatchTypeNone MatchType = "none"
+)
+
+const (
+	// DefaultMaxBankFee 振込手数料として差し引きを許容する上限（円）
+	DefaultMaxBankFee int64 = 880
+	// DefaultMaxCombined まとめ入金として組み合わせを探索する請求書数の上限
+	DefaultMaxCombined = 12
+)
+
+// OpenInvoice 消込対象の未入金請求書
+type OpenInvoice struct {
+	ID            string
+	InvoiceNumber string
+	ClientID      string
+	PayerKana     string // 取引先名のカナ（振込依頼人名との照合に使う）
+	Remaining     int64  // 未入金残高（円）
+	DueDate       time.Time
+}
+
+// Deposit 入金取引
+type Deposit struct {
+	Date      time.Time
+	Amount    int64
+	PayerName string
+}
+
+// Allocation 入金の請求書への割当
+type Allocation struct {
+	InvoiceID string
+	Amount    int64 // 入金から充当する額
+	FeeAmount int64 // 振込手数料として請求額から差し引く額
+}
+
+// Result 照合結果
+type Result struct {
+	Type           MatchType
+	Allocations    []Allocation
+	FeeAmount      int64
+	OverpaidAmount int64
+	Confidence     float64
+	NeedsReview    bool
+	Reason         string
+	CandidateIDs   []string // 確認が必要な場合の候補請求書
+}
+
+// Matcher 入金と請求書の照合器
+type Matcher struct {
+	MaxBankFee  int64
+	MaxCombined int
+}
+
+// NewMatcher 照合器のコンストラクタ
+func NewMatcher() *Matcher {
+	return &Matcher{
+		MaxBankFee:  DefaultMaxBankFee,
+		MaxCombined: DefaultMaxCombined,
+	}
+}
+
+// Match 入金に対応する請求書を探す
+// 振込依頼人名が一致する請求書を優先し、一意に決まらない場合は確認待ちとする
+func (m *Matcher) Match(d Deposit, invoices []OpenInvoice) Result {
+	if d.Amount <= 0 {
+		return Result{Type: MatchTypeNone, NeedsReview: true, Reason: "入金額が0円です"}
+	}
+
+	var named, open []OpenInvoice
+	for _, inv := range invoices {
+		if inv.Remaining <= 0 {
+			continue
+		}
+		open = append(open, inv)
+		if NameMatches(d.PayerName, inv.PayerKana) {
+			named = append(named, inv)
+		}
+	}
+	sortByDueDate(named)
+	sortByDueDate(open)
+
+	if len(named) > 0 {
+		return m.matchNamed(d, named)
+	}
+	return m.matchAmountOnly(d, open)
+}
+
+// matchNamed 振込依頼人名が一致する請求書の中から照合
+func (m *Matcher) matchNamed(d Deposit, candidates []OpenInvoice) Result {
+	// 1件で一致（全額または手数料差引）
+	var exact, feeShort []OpenInvoice
+	for _, inv := range candidates {
+		switch diff := inv.Remaining - d.Amount; {
+		case diff == 0:
+			exact = append(exact, inv)
+		case diff > 0 && diff <= m.MaxBankFee:
+			feeShort = append(feeShort, inv)
+		}
+	}
+	if len(exact) > 0 {
+		return m.singleResult(d, MatchTypeExact, exact, 1.0)
+	}
+	if len(feeShort) > 0 {
+		return m.singleResult(d, MatchTypeFeeShortfall, feeShort, 0.9)
+	}
+
+	// 複数の請求書のまとめ入金
+	if combos := m.findCombinations(d.Amount, candidates); len(combos) > 0 {
+		res := combinedResult(d.Amount, combos[0])
+		if len(combos) > 1 {
+			res.NeedsReview = true
+			res.Confidence = 0.5
+			res.Reason = fmt.Sprintf("まとめ入金の組み合わせが%d通りあります", len(combos))
+			res.CandidateIDs = invoiceIDs(candidates)
+		}
+		return res
+	}
+
+	total := sumRemaining(candidates)
+	if d.Amount > total {
+		// 過入金：全件に充当して差額を残す
+		res := Result{
+			Type:           MatchTypeOverpayment,
+			OverpaidAmount: d.Amount - total,
+			Confidence:     0.5,
+			NeedsReview:    true,
+			Reason:         fmt.Sprintf("請求残高より%d円多く入金されています", d.Amount-total),
+			CandidateIDs:   invoiceIDs(candidates),
+		}
+		for _, inv := range candidates {
+			res.Allocations = append(res.Allocations, Allocation{InvoiceID: inv.ID, Amount: inv.Remaining})
+		}
+		return res
+	}
+
+	// 一部入金：支払期日の古い請求書から充当する
+	res := Result{Type: MatchTypePartial, Confidence: 0.7}
+	rest := d.Amount
+	for _, inv := range candidates {
+		if rest == 0 {
+			break
+		}
+		amount := min(rest, inv.Remaining)
+		res.Allocations = append(res.Allocations, Allocation{InvoiceID: inv.ID, Amount: amount})
+		rest -= amount
+	}
+	if len(candidates) > 1 {
+		res.Confidence = 0.4
+		res.NeedsReview = true
+		res.Reason = "一部入金の充当先が一意に決まりません"
+		res.CandidateIDs = invoiceIDs(candidates)
+	}
+	return res
+}
+
+// matchAmountOnly 振込依頼人名が一致しない場合は金額だけで候補を探し、必ず確認待ちとする
+func (m *Matcher) matchAmountOnly(d Deposit, candidates []OpenInvoice) Result {
+	var hits []OpenInvoice
+	for _, inv := range candidates {
+		if diff := inv.Remaining - d.Amount; diff >= 0 && diff <= m.MaxBankFee {
+			hits = append(hits, inv)
+		}
+	}
+	if len(hits) == 0 {
+		return Result{Type: MatchTypeNone, NeedsReview: true, Reason: "該当する請求書がありません"}
+	}
+
+	sortByDueDistance(hits, d.Date)
+	best := hits[0]
+	return Result{
+		Type:         MatchTypeAmountOnly,
+		Allocations:  []Allocation{{InvoiceID: best.ID, Amount: d.Amount, FeeAmount: best.Remaining - d.Amount}},
+		FeeAmount:    best.Remaining - d.Amount,
+		Confidence:   0.3,
+		NeedsReview:  true,
+		Reason:       "振込依頼人名が取引先名と一致しません",
+		CandidateIDs: invoiceIDs(hits),
+	}
+}
+
+// singleResult 1件の請求書に充当する結果を作成
+// 候補が複数ある場合は支払期日が入金日に最も近いものを仮に選び、確認待ちとする
+func (m *Matcher) singleResult(d Deposit, matchType MatchType, hits []OpenInvoice, confidence float64) Result {
+	sortByDueDistance(hits, d.Date)
+	best := hits[0]
+	fee := best.Remaining - d.Amount
+	res := Result{
+		Type:        matchType,
+		Allocations: []Allocation{{InvoiceID: best.ID, Amount: d.Amount, FeeAmount: fee}},
+		FeeAmount:   fee,
+		Confidence:  confidence,
+	}
+	if len(hits) > 1 {
+		res.NeedsReview = true
+		res.Confidence = confidence / 2
+		res.Reason = fmt.Sprintf("同額の請求書が%d件あります", len(hits))
+		res.CandidateIDs = invoiceIDs(hits)
+	}
+	return res
+}
+
+// findCombinations 合計が入金額（手数料差引を含む）と一致する2件以上の請求書の組み合わせを探す
+// 組み合わせは支払期日の古い請求書を多く含むものから順に返す
+func (m *Matcher) findCombinations(amount int64, candidates []OpenInvoice) [][]OpenInvoice {
+	n := min(len(candidates), m.MaxCombined)
+	if n < 2 {
+		return nil
+	}
+
+	var combos [][]OpenInvoice
+	for mask := 1; mask < 1<<n; mask++ {
+		var subset []OpenInvoice
+		var total int64
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, candidates[i])
+				total += candidates[i].Remaining
+			}
+		}
+		if len(subset) < 2 {
+			continue
+		}
+		if diff := total - amount; diff >= 0 && diff <= m.MaxBankFee {
+			combos = append(combos, subset)
+		}
+	}
+	sort.SliceStable(combos, func(i, j int) bool {
+		return combos[i][0].DueDate.Before(combos[j][0].DueDate) ||
+			(combos[i][0].DueDate.Equal(combos[j][0].DueDate) && len(combos[i]) > len(combos[j]))
+	})
+	return combos
+}
+
+// combinedResult まとめ入金の結果を作成
+// 手数料は支払期日の新しい請求書から差し引き、残高を超える分はその前の請求書に回す（充当額はマイナスにしない）
+func combinedResult(amount int64, subset []OpenInvoice) Result {
+	fee := sumRemaining(subset) - amount
+	res := Result{Type: MatchTypeCombined, FeeAmount: fee, Confidence: 0.8}
+	res.Allocations = make([]Allocation, len(subset))
+	for i := len(subset) - 1; i >= 0; i-- {
+		inv := subset[i]
+		deducted := min(fee, inv.Remaining)
+		fee -= deducted
+		res.Allocations[i] = Allocation{InvoiceID: inv.ID, Amount: inv.Remaining - deducted, FeeAmount: deducted}
+	}
+	return res
+}
+
+// sortByDueDate 支払期日の古い順に並べる
+func sortByDueDate(invoices []OpenInvoice) {
+	sort.SliceStable(invoices, func(i, j int) bool {
+		return invoices[i].DueDate.Before(invoices[j].DueDate)
+	})
+}
+
+// sortByDueDistance 支払期日が入金日に近い順に並べる（同じ距離なら古い方を優先）
+func sortByDueDistance(invoices []OpenInvoice, date time.Time) {
+	distance := func(inv OpenInvoice) time.Duration {
+		d := inv.DueDate.Sub(date)
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.SliceStable(invoices, func(i, j int) bool {
+		di, dj := distance(invoices[i]), distance(invoices[j])
+		if di != dj {
+			return di < dj
+		}
+		return invoices[i].DueDate.Before(invoices[j].DueDate)
+	})
+}
+
+// sumRemaining 未入金残高の合計
+func sumRemaining(invoices []OpenInvoice) int64 {
+	var total int64
+	for _, inv := range invoices {
+		total += inv.Remaining
+	}
+	return total
+}
+
+// invoiceIDs 請求書IDの一覧
+func invoiceIDs(invoices []OpenInvoice) []string {
+	ids := make([]string, 0, len(invoices))
+	for _, inv := range invoices {
+		ids = append(ids, inv.ID)
+	}
+	return ids
+}
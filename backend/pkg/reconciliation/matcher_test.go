@@ -0,0 +1,216 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeKana(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "半角カナと法人略語", input: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ", expected: "ヤマダシヨウジ"},
+		{name: "後株の略語", input: "ﾔﾏﾀﾞｼｮｳｼﾞ(ｶ", expected: "ヤマダシヨウジ"},
+		{name: "全角カナの法人格", input: "カブシキガイシャ ヤマダショウジ", expected: "ヤマダシヨウジ"},
+		{name: "ひらがなと長音", input: "でゅえすく・ぱーとなーず", expected: "デユエスクパトナズ"},
+		{name: "合同会社", input: "ゴウドウガイシャ　テスト", expected: "テスト"},
+		{name: "英数字", input: "ｴｲﾋﾞｰｼｰ ABC-123", expected: "エイビシABC123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeKana(tt.input))
+		})
+	}
+}
+
+func TestNameMatches(t *testing.T) {
+	assert.True(t, NameMatches("ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ", "ヤマダショウジ"))
+	assert.True(t, NameMatches("0001234 ﾔﾏﾀﾞｼｮｳｼﾞ(ｶ", "カブシキガイシャヤマダショウジ"))
+	assert.False(t, NameMatches("ｶ)ｽｽﾞｷｼｮｳｼﾞ", "ヤマダショウジ"))
+	assert.False(t, NameMatches("ﾔﾏ", "ヤマダショウジ"))
+	assert.False(t, NameMatches("", "ヤマダショウジ"))
+}
+
+func TestMatcher_Match(t *testing.T) {
+	depositDate := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+	due := func(month time.Month) time.Time {
+		return time.Date(2025, month, 30, 0, 0, 0, 0, time.UTC)
+	}
+	yamada := "ヤマダショウジ"
+	suzuki := "スズキコウギョウ"
+
+	tests := []struct {
+		name           string
+		deposit        Deposit
+		invoices       []OpenInvoice
+		wantType       MatchType
+		wantReview     bool
+		wantAlloc      []Allocation
+		wantFee        int64
+		wantOverpaid   int64
+		wantCandidates int
+	}{
+		{
+			name:    "全額一致",
+			deposit: Deposit{Amount: 110000, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-1", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+				{ID: "inv-2", PayerKana: suzuki, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:  MatchTypeExact,
+			wantAlloc: []Allocation{{InvoiceID: "inv-1", Amount: 110000}},
+		},
+		{
+			name:    "振込手数料差引",
+			deposit: Deposit{Amount: 109340, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-1", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:  MatchTypeFeeShortfall,
+			wantAlloc: []Allocation{{InvoiceID: "inv-1", Amount: 109340, FeeAmount: 660}},
+			wantFee:   660,
+		},
+		{
+			name:    "同額の請求書が複数",
+			deposit: Deposit{Amount: 110000, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-3", PayerKana: yamada, Remaining: 110000, DueDate: due(3)},
+				{ID: "inv-4", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:       MatchTypeExact,
+			wantReview:     true,
+			wantAlloc:      []Allocation{{InvoiceID: "inv-4", Amount: 110000}},
+			wantCandidates: 2,
+		},
+		{
+			name:    "まとめ入金（手数料差引）",
+			deposit: Deposit{Amount: 329560, PayerName: "ﾔﾏﾀﾞｼｮｳｼﾞ(ｶ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-5", PayerKana: yamada, Remaining: 220000, DueDate: due(4)},
+				{ID: "inv-6", PayerKana: yamada, Remaining: 110000, DueDate: due(3)},
+				{ID: "inv-7", PayerKana: yamada, Remaining: 55000, DueDate: due(5)},
+			},
+			wantType: MatchTypeCombined,
+			wantAlloc: []Allocation{
+				{InvoiceID: "inv-6", Amount: 110000},
+				{InvoiceID: "inv-5", Amount: 219560, FeeAmount: 440},
+			},
+			wantFee: 440,
+		},
+		{
+			name:    "一部入金",
+			deposit: Deposit{Amount: 50000, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-1", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:  MatchTypePartial,
+			wantAlloc: []Allocation{{InvoiceID: "inv-1", Amount: 50000}},
+		},
+		{
+			name:    "複数請求書への一部入金",
+			deposit: Deposit{Amount: 150000, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-8", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+				{ID: "inv-9", PayerKana: yamada, Remaining: 100000, DueDate: due(3)},
+			},
+			wantType:   MatchTypePartial,
+			wantReview: true,
+			wantAlloc: []Allocation{
+				{InvoiceID: "inv-9", Amount: 100000},
+				{InvoiceID: "inv-8", Amount: 50000},
+			},
+			wantCandidates: 2,
+		},
+		{
+			name:    "過入金",
+			deposit: Deposit{Amount: 120000, PayerName: "ｶ)ﾔﾏﾀﾞｼｮｳｼﾞ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-1", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:       MatchTypeOverpayment,
+			wantReview:     true,
+			wantAlloc:      []Allocation{{InvoiceID: "inv-1", Amount: 110000}},
+			wantOverpaid:   10000,
+			wantCandidates: 1,
+		},
+		{
+			name:    "名義不一致で金額のみ一致",
+			deposit: Deposit{Amount: 110000, PayerName: "ｶ)ﾔﾏﾀﾞﾎｰﾙﾃﾞｨﾝｸﾞｽ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-2", PayerKana: suzuki, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:       MatchTypeAmountOnly,
+			wantReview:     true,
+			wantAlloc:      []Allocation{{InvoiceID: "inv-2", Amount: 110000}},
+			wantCandidates: 1,
+		},
+		{
+			name:    "該当なし",
+			deposit: Deposit{Amount: 3000, PayerName: "ﾘｿｸ"},
+			invoices: []OpenInvoice{
+				{ID: "inv-1", PayerKana: yamada, Remaining: 110000, DueDate: due(4)},
+			},
+			wantType:   MatchTypeNone,
+			wantReview: true,
+		},
+	}
+
+	matcher := NewMatcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.deposit.Date = depositDate
+			res := matcher.Match(tt.deposit, tt.invoices)
+
+			assert.Equal(t, tt.wantType, res.Type)
+			assert.Equal(t, tt.wantReview, res.NeedsReview, res.Reason)
+			assert.Equal(t, tt.wantAlloc, res.Allocations)
+			assert.Equal(t, tt.wantFee, res.FeeAmount)
+			assert.Equal(t, tt.wantOverpaid, res.OverpaidAmount)
+			assert.Len(t, res.CandidateIDs, tt.wantCandidates)
+		})
+	}
+}
+
+func TestMatcher_CombinedAmbiguous(t *testing.T) {
+	due := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+	invoices := []OpenInvoice{
+		{ID: "a", PayerKana: "ヤマダショウジ", Remaining: 100000, DueDate: due},
+		{ID: "b", PayerKana: "ヤマダショウジ", Remaining: 50000, DueDate: due},
+		{ID: "c", PayerKana: "ヤマダショウジ", Remaining: 50000, DueDate: due},
+	}
+
+	res := NewMatcher().Match(Deposit{Date: due, Amount: 150000, PayerName: "ﾔﾏﾀﾞｼｮｳｼﾞ"}, invoices)
+
+	assert.Equal(t, MatchTypeCombined, res.Type)
+	assert.True(t, res.NeedsReview)
+	require.Len(t, res.Allocations, 2)
+	assert.Equal(t, "a", res.Allocations[0].InvoiceID)
+}
+
+func TestMatcher_CombinedFeeExceedsNewestInvoice(t *testing.T) {
+	due := func(month time.Month) time.Time {
+		return time.Date(2025, month, 30, 0, 0, 0, 0, time.UTC)
+	}
+	invoices := []OpenInvoice{
+		{ID: "a", PayerKana: "ヤマダショウジ", Remaining: 100000, DueDate: due(3)},
+		{ID: "b", PayerKana: "ヤマダショウジ", Remaining: 50000, DueDate: due(4)},
+		{ID: "c", PayerKana: "ヤマダショウジ", Remaining: 500, DueDate: due(5)},
+	}
+
+	// 手数料800円が最も新しい請求書の残高500円を超える
+	res := NewMatcher().Match(Deposit{Date: due(5), Amount: 149700, PayerName: "ﾔﾏﾀﾞｼｮｳｼﾞ"}, invoices)
+
+	assert.Equal(t, MatchTypeCombined, res.Type)
+	assert.Equal(t, int64(800), res.FeeAmount)
+	assert.Equal(t, []Allocation{
+		{InvoiceID: "a", Amount: 100000},
+		{InvoiceID: "b", Amount: 49700, FeeAmount: 300},
+		{InvoiceID: "c", Amount: 0, FeeAmount: 500},
+	}, res.Allocations)
+}
@@ -39,6 +39,7 @@ func setupPS(t *testing.T) (*gorm.DB, service.ProjectService) {
         work_location TEXT,
         description TEXT,
         requirements TEXT,
+        proration_method TEXT,
         created_at DATETIME,
         updated_at DATETIME,
         deleted_at DATETIME